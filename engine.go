@@ -107,6 +107,13 @@ func NewDefault(pro sql.DatabaseProvider) *Engine {
 	return New(a, nil)
 }
 
+// RegisterFunction registers a custom function implemented in Go under the name given, making it available to all
+// queries run by this engine. The name may not be that of a built-in function. If a function with the same name was
+// already registered, it is replaced when replace is true, and an error is returned otherwise.
+func (e *Engine) RegisterFunction(name string, fn sql.Function, replace bool) error {
+	return e.Analyzer.Catalog.RegisterUserFunction(name, fn, replace)
+}
+
 // AnalyzeQuery analyzes a query and returns its Schema.
 func (e *Engine) AnalyzeQuery(
 	ctx *sql.Context,
@@ -476,6 +476,15 @@ func TestTableFunctions(t *testing.T) {
 			Query:       "SELECT * from does_not_exist('q', 123);",
 			ExpectedErr: sql.ErrTableFunctionNotFound,
 		},
+		{
+			Name: "undefined table function keeps the name's spelling",
+			Assertions: []enginetest.ScriptTestAssertion{
+				{
+					Query:          "SELECT * from Does_Not_Exist('q', 123);",
+					ExpectedErrStr: "table function: 'Does_Not_Exist' not found",
+				},
+			},
+		},
 		{
 			Name:        "projection of non-existent column from table function",
 			Query:       "SELECT none from simple_TABLE_function(123);",
@@ -531,7 +540,96 @@ func TestTableFunctions(t *testing.T) {
 	harness := enginetest.NewMemoryHarness("", 1, testNumPartitions, true, nil)
 	db := harness.NewDatabase("mydb")
 	databaseProvider := harness.NewDatabaseProvider(db)
-	testDatabaseProvider := NewTestProvider(&databaseProvider, SimpleTableFunction{})
+	testDatabaseProvider := NewTestProvider(&databaseProvider, SimpleTableFunction{}, &SequenceTableFunction{})
+	engine := enginetest.NewEngineWithProvider(t, harness, testDatabaseProvider)
+	for _, test := range tableFunctionScriptTests {
+		enginetest.TestScriptWithEngine(t, engine, harness, test)
+	}
+}
+
+func TestTableFunctionNamedArguments(t *testing.T) {
+	var tableFunctionScriptTests = []enginetest.ScriptTest{
+		{
+			Name:     "named arguments",
+			Query:    "SELECT * from number_sequence(start => 1, stop => 5);",
+			Expected: []sql.Row{{1}, {2}, {3}, {4}, {5}},
+		},
+		{
+			Name:     "named arguments in any order",
+			Query:    "SELECT * from number_sequence(step => 2, stop => 5, start => 1);",
+			Expected: []sql.Row{{1}, {3}, {5}},
+		},
+		{
+			Name:     "positional and named arguments",
+			Query:    "SELECT * from number_sequence(2, step => 3, STOP => 10);",
+			Expected: []sql.Row{{2}, {5}, {8}},
+		},
+		{
+			Name:     "positional arguments",
+			Query:    "SELECT * from number_sequence(1, 3);",
+			Expected: []sql.Row{{1}, {2}, {3}},
+		},
+		{
+			Name:     "filtered table function results",
+			Query:    "SELECT * from number_sequence(1, 3) where x = 2;",
+			Expected: []sql.Row{{2}},
+		},
+		{
+			Name:     "quote in a comment before a named argument",
+			Query:    "SELECT * from number_sequence(/* it's */ start => 1, stop => 2);",
+			Expected: []sql.Row{{1}, {2}},
+		},
+		{
+			Name:     "null-safe equality is not a named argument",
+			Query:    "SELECT x <=> 2 from number_sequence(start => 1, stop => 2);",
+			Expected: []sql.Row{{0}, {1}},
+		},
+		{
+			Name:     "parenthesized comparison as a named argument",
+			Query:    "SELECT * from number_sequence(start => (2 > 1), stop => 1 + 2);",
+			Expected: []sql.Row{{1}, {2}, {3}},
+		},
+		{
+			Name:        "unparenthesized comparison as a named argument",
+			Query:       "SELECT * from number_sequence(start => 2 > 1, stop => 3);",
+			ExpectedErr: sql.ErrSyntaxError,
+		},
+		{
+			Name:        "unknown argument name",
+			Query:       "SELECT * from number_sequence(start => 1, finish => 5);",
+			ExpectedErr: sql.ErrUnknownTableFunctionArgument,
+		},
+		{
+			Name:        "missing required argument",
+			Query:       "SELECT * from number_sequence(start => 1);",
+			ExpectedErr: sql.ErrMissingTableFunctionArgument,
+		},
+		{
+			Name:        "argument given twice",
+			Query:       "SELECT * from number_sequence(1, 5, start => 1);",
+			ExpectedErr: sql.ErrDuplicateTableFunctionArgument,
+		},
+		{
+			Name:        "positional argument after named argument",
+			Query:       "SELECT * from number_sequence(start => 1, 5);",
+			ExpectedErr: sql.ErrPositionalTableFunctionArgument,
+		},
+		{
+			Name:        "too many positional arguments",
+			Query:       "SELECT * from number_sequence(1, 5, 1, 1);",
+			ExpectedErr: sql.ErrInvalidArgumentNumber,
+		},
+		{
+			Name:        "named arguments to a table function without parameters",
+			Query:       "SELECT * from simple_table_function(one => 123);",
+			ExpectedErr: sql.ErrTableFunctionNamedArguments,
+		},
+	}
+
+	harness := enginetest.NewMemoryHarness("", 1, testNumPartitions, true, nil)
+	db := harness.NewDatabase("mydb")
+	databaseProvider := harness.NewDatabaseProvider(db)
+	testDatabaseProvider := NewTestProvider(&databaseProvider, SimpleTableFunction{}, &SequenceTableFunction{})
 	engine := enginetest.NewEngineWithProvider(t, harness, testDatabaseProvider)
 	for _, test := range tableFunctionScriptTests {
 		enginetest.TestScriptWithEngine(t, engine, harness, test)
@@ -638,6 +736,95 @@ func (itr *SimpleTableFunctionRowIter) Close(_ *sql.Context) error {
 	return nil
 }
 
+var _ sql.NamedArgumentsTableFunction = (*SequenceTableFunction)(nil)
+
+// SequenceTableFunction is a table function for testing named arguments. When evaluated, returns the integers from
+// start to stop, counting by step.
+type SequenceTableFunction struct {
+	args              []sql.Expression
+	start, stop, step int64
+}
+
+func (s *SequenceTableFunction) NewInstance(ctx *sql.Context, _ sql.Database, args []sql.Expression) (sql.Node, error) {
+	var bounds [3]int64
+	for i, arg := range args {
+		v, err := arg.Eval(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		v, err = sql.Int64.Convert(v)
+		if err != nil {
+			return nil, err
+		}
+		bounds[i] = v.(int64)
+	}
+
+	return &SequenceTableFunction{args: args, start: bounds[0], stop: bounds[1], step: bounds[2]}, nil
+}
+
+func (s *SequenceTableFunction) Parameters() []sql.TableFunctionParameter {
+	return []sql.TableFunctionParameter{
+		{Name: "start"},
+		{Name: "stop"},
+		{Name: "step", Default: expression.NewLiteral(int64(1), sql.Int64)},
+	}
+}
+
+func (s *SequenceTableFunction) Resolved() bool {
+	return true
+}
+
+func (s *SequenceTableFunction) String() string {
+	return "SequenceTableFunction"
+}
+
+func (s *SequenceTableFunction) Schema() sql.Schema {
+	return sql.Schema{{Name: "x", Type: sql.Int64}}
+}
+
+func (s *SequenceTableFunction) Children() []sql.Node {
+	return nil
+}
+
+func (s *SequenceTableFunction) RowIter(_ *sql.Context, _ sql.Row) (sql.RowIter, error) {
+	var rows []sql.Row
+	for x := s.start; x <= s.stop; x += s.step {
+		rows = append(rows, sql.Row{x})
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+func (s *SequenceTableFunction) WithChildren(_ ...sql.Node) (sql.Node, error) {
+	return s, nil
+}
+
+func (s *SequenceTableFunction) CheckPrivileges(_ *sql.Context, _ sql.PrivilegedOperationChecker) bool {
+	return true
+}
+
+func (s *SequenceTableFunction) Expressions() []sql.Expression {
+	return s.args
+}
+
+func (s *SequenceTableFunction) WithExpressions(e ...sql.Expression) (sql.Node, error) {
+	ns := *s
+	ns.args = e
+	return &ns, nil
+}
+
+func (s *SequenceTableFunction) Database() sql.Database {
+	return nil
+}
+
+func (s *SequenceTableFunction) WithDatabase(_ sql.Database) (sql.Node, error) {
+	return s, nil
+}
+
+func (s *SequenceTableFunction) FunctionName() string {
+	return "number_sequence"
+}
+
 var _ sql.FunctionProvider = (*TestProvider)(nil)
 
 type TestProvider struct {
@@ -645,10 +832,15 @@ type TestProvider struct {
 	tableFunctions map[string]sql.TableFunction
 }
 
-func NewTestProvider(dbProvider *sql.MutableDatabaseProvider, tf sql.TableFunction) *TestProvider {
+func NewTestProvider(dbProvider *sql.MutableDatabaseProvider, tfs ...sql.TableFunction) *TestProvider {
+	tableFunctions := make(map[string]sql.TableFunction)
+	for _, tf := range tfs {
+		tableFunctions[strings.ToLower(tf.FunctionName())] = tf
+	}
+
 	return &TestProvider{
 		*dbProvider,
-		map[string]sql.TableFunction{strings.ToLower(tf.FunctionName()): tf},
+		tableFunctions,
 	}
 }
 
@@ -663,3 +855,83 @@ func (t TestProvider) TableFunction(_ *sql.Context, name string) (sql.TableFunct
 
 	return nil, sql.ErrTableFunctionNotFound.New(name)
 }
+
+func TestRegisterFunction(t *testing.T) {
+	require := require.New(t)
+
+	harness := enginetest.NewDefaultMemoryHarness()
+	engine := enginetest.NewEngine(t, harness)
+	defer engine.Close()
+
+	require.NoError(engine.RegisterFunction("reverse_words", sql.Function1{Name: "reverse_words", Fn: newReverseWords}, false))
+
+	err := engine.RegisterFunction("REVERSE_WORDS", sql.Function1{Name: "reverse_words", Fn: newReverseWords}, false)
+	require.True(function.ErrFunctionAlreadyRegistered.Is(err), "unexpected error %v", err)
+	require.NoError(engine.RegisterFunction("REVERSE_WORDS", sql.Function1{Name: "reverse_words", Fn: newReverseWords}, true))
+
+	err = engine.RegisterFunction("concat", sql.Function1{Name: "concat", Fn: newReverseWords}, false)
+	require.True(sql.ErrBuiltInFunctionRedefined.Is(err), "unexpected error %v", err)
+
+	enginetest.TestScriptWithEngine(t, engine, harness, enginetest.ScriptTest{
+		Name: "user defined function",
+		Assertions: []enginetest.ScriptTestAssertion{
+			{
+				Query:    "SELECT reverse_words('one two three')",
+				Expected: []sql.Row{{"three two one"}},
+			},
+			{
+				Query: "SELECT i, Reverse_Words(s) FROM mytable ORDER BY i",
+				Expected: []sql.Row{
+					{1, "row first"},
+					{2, "row second"},
+					{3, "row third"},
+				},
+			},
+			{
+				Query:       "SELECT reverse_words('a b', 'c')",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+		},
+	})
+}
+
+// reverseWords is a user defined function that reverses the order of the space separated words in a string.
+type reverseWords struct {
+	expression.UnaryExpression
+}
+
+func newReverseWords(e sql.Expression) sql.Expression {
+	return &reverseWords{expression.UnaryExpression{Child: e}}
+}
+
+func (r *reverseWords) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := r.Child.Eval(ctx, row)
+	if err != nil || val == nil {
+		return nil, err
+	}
+	val, err = sql.LongText.Convert(val)
+	if err != nil {
+		return nil, err
+	}
+
+	words := strings.Fields(val.(string))
+	for i, j := 0, len(words)-1; i < j; i, j = i+1, j-1 {
+		words[i], words[j] = words[j], words[i]
+	}
+	return strings.Join(words, " "), nil
+}
+
+func (r *reverseWords) Type() sql.Type {
+	return sql.LongText
+}
+
+func (r *reverseWords) String() string {
+	return fmt.Sprintf("REVERSE_WORDS(%s)", r.Child)
+}
+
+func (r *reverseWords) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(r, len(children), 1)
+	}
+	return newReverseWords(children[0]), nil
+}
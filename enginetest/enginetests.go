@@ -1425,8 +1425,8 @@ func TestShowTriggers(t *testing.T, harness Harness) {
 					"OLD",                   // action_reference_old_row
 					"NEW",                   // action_reference_new_row
 					date,                    // created
-					"",                      // sql_mode
-					"",                      // definer
+					"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES", // sql_mode
+					"root@localhost", // definer
 					sql.Collation_Default.CharacterSet().String(), // character_set_client
 					sql.Collation_Default.String(),                // collation_connection
 					sql.Collation_Default.String(),                // database_collation
@@ -1449,8 +1449,8 @@ func TestShowTriggers(t *testing.T, harness Harness) {
 					"OLD",                   // action_reference_old_row
 					"NEW",                   // action_reference_new_row
 					date,                    // created
-					"",                      // sql_mode
-					"",                      // definer
+					"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES", // sql_mode
+					"root@localhost", // definer
 					sql.Collation_Default.CharacterSet().String(), // character_set_client
 					sql.Collation_Default.String(),                // collation_connection
 					sql.Collation_Default.String(),                // database_collation
@@ -1473,8 +1473,8 @@ func TestShowTriggers(t *testing.T, harness Harness) {
 					"OLD",                   // action_reference_old_row
 					"NEW",                   // action_reference_new_row
 					date,                    // created
-					"",                      // sql_mode
-					"",                      // definer
+					"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES", // sql_mode
+					"root@localhost", // definer
 					sql.Collation_Default.CharacterSet().String(), // character_set_client
 					sql.Collation_Default.String(),                // collation_connection
 					sql.Collation_Default.String(),                // database_collation
@@ -1497,8 +1497,8 @@ func TestShowTriggers(t *testing.T, harness Harness) {
 					"OLD",                   // action_reference_old_row
 					"NEW",                   // action_reference_new_row
 					date,                    // created
-					"",                      // sql_mode
-					"",                      // definer
+					"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES", // sql_mode
+					"root@localhost", // definer
 					sql.Collation_Default.CharacterSet().String(), // character_set_client
 					sql.Collation_Default.String(),                // collation_connection
 					sql.Collation_Default.String(),                // database_collation
@@ -1521,8 +1521,8 @@ func TestShowTriggers(t *testing.T, harness Harness) {
 					"OLD",                                   // action_reference_old_row
 					"NEW",                                   // action_reference_new_row
 					date,                                    // created
-					"",                                      // sql_mode
-					"",                                      // definer
+					"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES", // sql_mode
+					"root@localhost", // definer
 					sql.Collation_Default.CharacterSet().String(), // character_set_client
 					sql.Collation_Default.String(),                // collation_connection
 					sql.Collation_Default.String(),                // database_collation
@@ -1545,8 +1545,8 @@ func TestShowTriggers(t *testing.T, harness Harness) {
 					"OLD",                                   // action_reference_old_row
 					"NEW",                                   // action_reference_new_row
 					date,                                    // created
-					"",                                      // sql_mode
-					"",                                      // definer
+					"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES", // sql_mode
+					"root@localhost", // definer
 					sql.Collation_Default.CharacterSet().String(), // character_set_client
 					sql.Collation_Default.String(),                // collation_connection
 					sql.Collation_Default.String(),                // database_collation
@@ -1569,8 +1569,8 @@ func TestShowTriggers(t *testing.T, harness Harness) {
 					"OLD",                                   // action_reference_old_row
 					"NEW",                                   // action_reference_new_row
 					date,                                    // created
-					"",                                      // sql_mode
-					"",                                      // definer
+					"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES", // sql_mode
+					"root@localhost", // definer
 					sql.Collation_Default.CharacterSet().String(), // character_set_client
 					sql.Collation_Default.String(),                // collation_connection
 					sql.Collation_Default.String(),                // database_collation
@@ -1593,8 +1593,8 @@ func TestShowTriggers(t *testing.T, harness Harness) {
 					"OLD",                                   // action_reference_old_row
 					"NEW",                                   // action_reference_new_row
 					date,                                    // created
-					"",                                      // sql_mode
-					"",                                      // definer
+					"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES", // sql_mode
+					"root@localhost", // definer
 					sql.Collation_Default.CharacterSet().String(), // character_set_client
 					sql.Collation_Default.String(),                // collation_connection
 					sql.Collation_Default.String(),                // database_collation
@@ -3884,6 +3884,52 @@ func TestWindowFunctions(t *testing.T, harness Harness) {
 		{5, 0.0},
 	}, nil, nil)
 
+	TestQuery(t, harness, e, `SELECT a, row_number() over (order by b, a), rank() over (order by b), dense_rank() over (order by b) FROM t1 order by a`, []sql.Row{
+		{0, 1, uint64(1), uint64(1)},
+		{1, 3, uint64(3), uint64(2)},
+		{2, 5, uint64(5), uint64(3)},
+		{3, 2, uint64(1), uint64(1)},
+		{4, 4, uint64(3), uint64(2)},
+		{5, 6, uint64(6), uint64(4)},
+	}, nil, nil)
+
+	TestQuery(t, harness, e, `SELECT a, rank() over (partition by c order by b), dense_rank() over (partition by c order by b) FROM t1 order by a`, []sql.Row{
+		{0, uint64(1), uint64(1)},
+		{1, uint64(1), uint64(1)},
+		{2, uint64(4), uint64(3)},
+		{3, uint64(1), uint64(1)},
+		{4, uint64(3), uint64(2)},
+		{5, uint64(5), uint64(4)},
+	}, nil, nil)
+
+	// no order by clause -> all rows are peers
+	TestQuery(t, harness, e, `SELECT a, rank() over (partition by b), dense_rank() over (partition by b) FROM t1 order by a`, []sql.Row{
+		{0, uint64(1), uint64(1)},
+		{1, uint64(1), uint64(1)},
+		{2, uint64(1), uint64(1)},
+		{3, uint64(1), uint64(1)},
+		{4, uint64(1), uint64(1)},
+		{5, uint64(1), uint64(1)},
+	}, nil, nil)
+
+	TestQuery(t, harness, e, `SELECT a, first_value(a) over (partition by abs(b - 1) order by a) FROM t1 order by a`, []sql.Row{
+		{0, 0},
+		{1, 1},
+		{2, 0},
+		{3, 0},
+		{4, 1},
+		{5, 5},
+	}, nil, nil)
+
+	TestQuery(t, harness, e, `SELECT a, row_number() over (partition by c, b % 2 order by a) FROM t1 order by a`, []sql.Row{
+		{0, 1},
+		{1, 1},
+		{2, 2},
+		{3, 3},
+		{4, 1},
+		{5, 2},
+	}, nil, nil)
+
 	TestQuery(t, harness, e, `SELECT a, first_value(b) over (partition by c order by b) FROM t1 order by a`, []sql.Row{
 		{0, 0},
 		{1, 1},
@@ -3920,6 +3966,39 @@ func TestWindowFunctions(t *testing.T, harness Harness) {
 		{5, 0},
 	}, nil, nil)
 
+	TestQuery(t, harness, e, `SELECT c, sum(b), count(b) FROM t1 group by c order by c`, []sql.Row{
+		{0, float64(6), 5},
+		{1, float64(1), 1},
+	}, nil, nil)
+
+	TestQuery(t, harness, e, `SELECT a, sum(b) over (partition by c), count(b) over (partition by c) FROM t1 order by a`, []sql.Row{
+		{0, float64(6), 5},
+		{1, float64(1), 1},
+		{2, float64(6), 5},
+		{3, float64(6), 5},
+		{4, float64(6), 5},
+		{5, float64(6), 5},
+	}, nil, nil)
+
+	// with an order by, the default frame ends at the current row's peers, giving a running sum
+	TestQuery(t, harness, e, `SELECT a, sum(b) over (partition by c order by a) FROM t1 order by a`, []sql.Row{
+		{0, float64(0)},
+		{1, float64(1)},
+		{2, float64(2)},
+		{3, float64(2)},
+		{4, float64(3)},
+		{5, float64(6)},
+	}, nil, nil)
+
+	TestQuery(t, harness, e, `SELECT a, sum(b) over (order by b), count(b) over (order by b) FROM t1 order by a`, []sql.Row{
+		{0, float64(0), 2},
+		{1, float64(2), 4},
+		{2, float64(4), 5},
+		{3, float64(0), 2},
+		{4, float64(2), 4},
+		{5, float64(7), 6},
+	}, nil, nil)
+
 	TestQuery(t, harness, e, `SELECT a, lag(a) over (partition by c order by a) FROM t1 order by a`, []sql.Row{
 		{0, nil},
 		{1, nil},
@@ -3992,8 +4071,30 @@ func TestWindowFunctions(t *testing.T, harness Harness) {
 		{5, "s"},
 	}, nil, nil)
 
+	TestQuery(t, harness, e, `SELECT a, lag(a) over (partition by a), lag(a, 1, a*10) over (partition by a) FROM t1 order by a`, []sql.Row{
+		{0, nil, 0},
+		{1, nil, 10},
+		{2, nil, 20},
+		{3, nil, 30},
+		{4, nil, 40},
+		{5, nil, 50},
+	}, nil, nil)
+
+	TestQuery(t, harness, e, `SELECT a, lag(a, 10) over (order by a), lag(a, 10, -1) over (order by a) FROM t1 order by a`, []sql.Row{
+		{0, nil, -1},
+		{1, nil, -1},
+		{2, nil, -1},
+		{3, nil, -1},
+		{4, nil, -1},
+		{5, nil, -1},
+	}, nil, nil)
+
+	TestQuery(t, harness, e, `SELECT a, lag(a) over (order by a), lag(a, 1, -1) over (partition by c order by a) FROM t1 where a > 10`, []sql.Row{}, nil, nil)
+
 	AssertErr(t, e, harness, "SELECT a, lag(a, -1) over (partition by c) FROM t1", expression.ErrInvalidOffset)
 	AssertErr(t, e, harness, "SELECT a, lag(a, 's') over (partition by c) FROM t1", expression.ErrInvalidOffset)
+	AssertErr(t, e, harness, "SELECT a, lag(json_extract(concat('{', a), '$')) over (partition by c order by a) FROM t1", nil)
+	AssertErr(t, e, harness, "SELECT a, lag(a, 1, json_extract(concat('{', a), '$')) over (partition by c order by a) FROM t1", nil)
 
 }
 
@@ -4051,6 +4152,25 @@ func TestWindowRowFrames(t *testing.T, harness Harness) {
 		`SELECT sum(y) over (partition by z order by x rows between 2 preceding and 1 preceding) FROM a order by x`,
 		[]sql.Row{{nil}, {float64(0)}, {float64(1)}, {float64(3)}, {float64(2)}, {float64(1)}},
 		nil, nil)
+
+	RunQuery(t, e, harness, "CREATE TABLE b (x INTEGER PRIMARY KEY, y INTEGER)")
+	RunQuery(t, e, harness, "INSERT INTO b VALUES (1,1), (2,NULL), (3,3), (4,4), (5,5)")
+	TestQuery(t, harness, e,
+		`SELECT sum(distinct y) over (order by x rows between 1 preceding and 1 following) FROM b order by x`,
+		[]sql.Row{{float64(1)}, {float64(4)}, {float64(7)}, {float64(12)}, {float64(9)}},
+		nil, nil)
+	TestQuery(t, harness, e,
+		`SELECT count(distinct y) over (order by x rows between 1 preceding and 1 following) FROM b order by x`,
+		[]sql.Row{{1}, {2}, {2}, {3}, {2}},
+		nil, nil)
+	TestQuery(t, harness, e,
+		`SELECT sum(distinct y) over (partition by z order by x rows between 1 preceding and current row) FROM a order by x`,
+		[]sql.Row{{float64(0)}, {float64(1)}, {float64(3)}, {float64(2)}, {float64(1)}, {float64(4)}},
+		nil, nil)
+	TestQuery(t, harness, e,
+		`SELECT avg(distinct y) over (partition by z order by x rows between 2 preceding and current row) FROM a order by x`,
+		[]sql.Row{{float64(0)}, {0.5}, {float64(1)}, {float64(1)}, {float64(1)}, {float64(4) / float64(3)}},
+		nil, nil)
 }
 
 func TestWindowRangeFrames(t *testing.T, harness Harness) {
@@ -4167,6 +4287,14 @@ func TestWindowRangeFrames(t *testing.T, harness Harness) {
 		`SELECT sum(y) over (partition by z order by date range between unbounded preceding and interval '1' DAY following) FROM c order by x`,
 		[]sql.Row{{float64(1)}, {float64(1)}, {float64(1)}, {float64(1)}, {float64(5)}, {float64(5)}, {float64(10)}, {float64(10)}, {float64(10)}, {float64(10)}},
 		nil, nil)
+
+	// datetime range, 7 days trailing
+	RunQuery(t, e, harness, "CREATE TABLE d (x INTEGER PRIMARY KEY, y INTEGER, z INTEGER, ts DATETIME)")
+	RunQuery(t, e, harness, "INSERT INTO d VALUES (0,0,0,'2022-01-01 10:00:00'), (1,1,0,'2022-01-02 10:00:00'), (2,2,0,'2022-01-03 10:00:00'), (3,3,0,'2022-01-04 10:00:00'), (4,4,0,'2022-01-05 10:00:00'), (5,5,0,'2022-01-06 10:00:00'), (6,6,0,'2022-01-07 10:00:00'), (7,7,0,'2022-01-08 10:00:00'), (8,8,0,'2022-01-09 10:00:00'), (9,9,0,'2022-01-10 10:00:00'), (10,10,0,'2022-01-17 09:00:00')")
+	TestQuery(t, harness, e,
+		`SELECT sum(y) over (partition by z order by ts range between interval 7 DAY preceding and current row) FROM d order by x`,
+		[]sql.Row{{float64(0)}, {float64(1)}, {float64(3)}, {float64(6)}, {float64(10)}, {float64(15)}, {float64(21)}, {float64(28)}, {float64(36)}, {float64(44)}, {float64(19)}},
+		nil, nil)
 	TestQuery(t, harness, e,
 		`SELECT count(y) over (partition by z order by date range between interval '1' DAY following and interval '2' DAY following) FROM c order by x`,
 		[]sql.Row{{1}, {1}, {1}, {1}, {1}, {0}, {2}, {2}, {0}, {0}},
@@ -4189,11 +4317,11 @@ func TestNamedWindows(t *testing.T, harness Harness) {
 
 	TestQuery(t, harness, e,
 		`SELECT sum(y) over (w1) FROM a WINDOW w1 as (order by z) order by x`,
-		[]sql.Row{{float64(0)}, {float64(1)}, {float64(3)}, {float64(3)}, {float64(4)}, {float64(7)}},
+		[]sql.Row{{float64(7)}, {float64(7)}, {float64(7)}, {float64(7)}, {float64(7)}, {float64(7)}},
 		nil, nil)
 	TestQuery(t, harness, e,
 		`SELECT sum(y) over (w1) FROM a WINDOW w1 as (partition by z) order by x`,
-		[]sql.Row{{float64(0)}, {float64(1)}, {float64(3)}, {float64(3)}, {float64(4)}, {float64(7)}},
+		[]sql.Row{{float64(7)}, {float64(7)}, {float64(7)}, {float64(7)}, {float64(7)}, {float64(7)}},
 		nil, nil)
 	TestQuery(t, harness, e,
 		`SELECT sum(y) over w FROM a WINDOW w as (partition by z order by x rows unbounded preceding) order by x`,
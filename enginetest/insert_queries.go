@@ -644,6 +644,24 @@ var SpatialInsertQueries = []WriteQueryTest{
 		SelectQuery:         "SELECT * FROM polygon_table;",
 		ExpectedSelect:      []sql.Row{{0, sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 0, Y: 0}}}}}}, {1, sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{X: 1, Y: 1}, {X: 1, Y: -1}, {X: -1, Y: -1}, {X: -1, Y: 1}, {X: 1, Y: 1}}}}}}},
 	},
+	{
+		WriteQuery:          "INSERT INTO point_table VALUES (1, 'POINT(1 1)');",
+		ExpectedWriteResult: []sql.Row{{sql.NewOkResult(1)}},
+		SelectQuery:         "SELECT * FROM point_table;",
+		ExpectedSelect:      []sql.Row{{5, sql.Point{X: 1, Y: 2}}, {1, sql.Point{X: 1, Y: 1}}},
+	},
+	{
+		WriteQuery:          "INSERT INTO line_table VALUES (2, 'LINESTRING(1 2,3 4)');",
+		ExpectedWriteResult: []sql.Row{{sql.NewOkResult(1)}},
+		SelectQuery:         "SELECT * FROM line_table;",
+		ExpectedSelect:      []sql.Row{{0, sql.Linestring{Points: []sql.Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}}, {1, sql.Linestring{Points: []sql.Point{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 5, Y: 6}}}}, {2, sql.Linestring{Points: []sql.Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}}},
+	},
+	{
+		WriteQuery:          "INSERT INTO polygon_table VALUES (1, 'POLYGON((1 1,1 -1,-1 -1,-1 1,1 1))');",
+		ExpectedWriteResult: []sql.Row{{sql.NewOkResult(1)}},
+		SelectQuery:         "SELECT * FROM polygon_table;",
+		ExpectedSelect:      []sql.Row{{0, sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 0, Y: 0}}}}}}, {1, sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{X: 1, Y: 1}, {X: 1, Y: -1}, {X: -1, Y: -1}, {X: -1, Y: 1}, {X: 1, Y: 1}}}}}}},
+	},
 }
 var InsertScripts = []ScriptTest{
 	{
@@ -1062,6 +1080,76 @@ var InsertScripts = []ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "insert WKT strings into spatial columns",
+		SetUpScript: []string{
+			"create table spatial (pk int primary key, g geometry, p point)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "insert into spatial values (1, 'POINT(1 2)', 'POINT(3 4)'), (2, 'LINESTRING(1 2,3 4)', NULL), (3, 'POLYGON((0 0,0 1,1 1,0 0))', NULL)",
+				Expected: []sql.Row{{sql.NewOkResult(3)}},
+			},
+			{
+				Query: "select * from spatial order by pk",
+				Expected: []sql.Row{
+					{1, sql.Geometry{Inner: sql.Point{X: 1, Y: 2}}, sql.Point{X: 3, Y: 4}},
+					{2, sql.Geometry{Inner: sql.Linestring{Points: []sql.Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}}, nil},
+					{3, sql.Geometry{Inner: sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 0, Y: 0}}}}}}, nil},
+				},
+			},
+			{
+				Query:    "select ST_ASWKT(g), ST_X(p) from spatial where pk = 1",
+				Expected: []sql.Row{{"POINT(1 2)", 3.0}},
+			},
+			{
+				Query:    "insert into spatial values (4, point(1, 2), point(3, 4)), (5, 'POINT(5 6)', 'POINT(7 8)'), (6, NULL, ST_GEOMFROMTEXT('POINT(9 10)'))",
+				Expected: []sql.Row{{sql.NewOkResult(3)}},
+			},
+			{
+				Query:    "insert into spatial values (7, X'00000000010200000002000000000000000000F03F000000000000004000000000000008400000000000001040', X'000000000101000000000000000000F03F0000000000000040'), (8, NULL, X'E61000000101000000000000000000F03F0000000000000040')",
+				Expected: []sql.Row{{sql.NewOkResult(2)}},
+			},
+			{
+				Query: "select * from spatial where pk > 3 order by pk",
+				Expected: []sql.Row{
+					{4, sql.Geometry{Inner: sql.Point{X: 1, Y: 2}}, sql.Point{X: 3, Y: 4}},
+					{5, sql.Geometry{Inner: sql.Point{X: 5, Y: 6}}, sql.Point{X: 7, Y: 8}},
+					{6, nil, sql.Point{X: 9, Y: 10}},
+					{7, sql.Geometry{Inner: sql.Linestring{Points: []sql.Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}}, sql.Point{X: 1, Y: 2}},
+					{8, nil, sql.Point{SRID: 4326, X: 1, Y: 2}},
+				},
+			},
+		},
+	},
+	{
+		Name: "update spatial columns with WKT strings",
+		SetUpScript: []string{
+			"create table spatial (pk int primary key, g geometry, p point)",
+			"insert into spatial values (1, point(1, 2), point(3, 4)), (2, NULL, NULL)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "update spatial set p = 'POINT(5 5)' where pk = 1",
+				Expected: []sql.Row{{newUpdateResult(1, 1)}},
+			},
+			{
+				Query:    "update spatial set g = 'LINESTRING(1 2,3 4)', p = 'POINT(6 6)' where pk = 2",
+				Expected: []sql.Row{{newUpdateResult(1, 1)}},
+			},
+			{
+				Query: "select * from spatial order by pk",
+				Expected: []sql.Row{
+					{1, sql.Geometry{Inner: sql.Point{X: 1, Y: 2}}, sql.Point{X: 5, Y: 5}},
+					{2, sql.Geometry{Inner: sql.Linestring{Points: []sql.Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}}, sql.Point{X: 6, Y: 6}},
+				},
+			},
+			{
+				Query:       "update spatial set p = 'POINT(1 2' where pk = 1",
+				ExpectedErr: sql.ErrInvalidGISData,
+			},
+		},
+	},
 }
 
 var InsertErrorTests = []GenericErrorQueryTest{
@@ -1148,6 +1236,26 @@ var InsertErrorTests = []GenericErrorQueryTest{
 }
 
 var InsertErrorScripts = []ScriptTest{
+	{
+		Name: "insert invalid WKT into spatial columns",
+		SetUpScript: []string{
+			"create table spatial (pk int primary key, g geometry, p point)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:       "insert into spatial values (1, 'POINT(1 2', NULL)",
+				ExpectedErr: sql.ErrInvalidGISData,
+			},
+			{
+				Query:       "insert into spatial (pk, p) values (1, 'LINESTRING(1 2,3 4)')",
+				ExpectedErr: sql.ErrNotPoint,
+			},
+			{
+				Query:       "insert into spatial (pk, p) values (1, 'POINT(a b)')",
+				ExpectedErr: sql.ErrInvalidGISData,
+			},
+		},
+	},
 	{
 		Name:        "create table with non-pk auto_increment column",
 		Query:       "create table bad (pk int primary key, c0 int auto_increment);",
@@ -132,6 +132,8 @@ func (d Database) GetTriggers(ctx *sql.Context) ([]sql.TriggerDefinition, error)
 			CreateStatement: fmt.Sprintf("CREATE TRIGGER `%s` %s %s ON `%s` FOR EACH ROW %s;",
 				row[0].(string), row[4].(string), row[1].(string), row[2].(string), row[3].(string)),
 			CreatedAt: time.Time{}, // TODO: time works in with doltharness
+			ForEach:   "row",
+			SqlMode:   row[6].(string),
 		})
 	}
 	if err != io.EOF {
@@ -15,6 +15,7 @@
 package enginetest
 
 import (
+	"strings"
 	"time"
 
 	"github.com/dolthub/vitess/go/sqltypes"
@@ -23,6 +24,7 @@ import (
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/analyzer"
 	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
 )
 
 type QueryTest struct {
@@ -123,6 +125,46 @@ var SpatialQueryTests = []QueryTest{
 		Query:    `SELECT ST_ASTEXT(p) from polygon_table`,
 		Expected: []sql.Row{{"POLYGON((0 0,0 1,1 1,0 0))"}},
 	},
+	{
+		Query:    `SELECT ST_ASWKT(LINESTRING(POINT(1.1,2.22),POINT(3.333,4.4444)), 'axis-order=srid-defined', 1)`,
+		Expected: []sql.Row{{"LINESTRING(1.1 2.2,3.3 4.4)"}},
+	},
+	{
+		Query:    `SELECT ST_ASWKT(ST_SRID(POINT(1,2), 4326), 'axis-order=long-lat'), ST_ASTEXT(POINT(1,2), 'axis-order=long-lat')`,
+		Expected: []sql.Row{{"POINT(2 1)", "POINT(1 2)"}},
+	},
+	{
+		Query:    `SELECT ST_ASWKT(ST_GEOMFROMTEXT('POINT Z (1 2 3)')), ST_ASWKT(ST_GEOMFROMTEXT('LINESTRINGZM(1 2 3 4, 5 6 7 8)'))`,
+		Expected: []sql.Row{{"POINT Z (1 2 3)", "LINESTRING ZM (1 2 3 4,5 6 7 8)"}},
+	},
+	{
+		Query:    `SELECT ST_ASWKT(ST_GEOMCOLLFROMTEXT('GEOMETRYCOLLECTION(POINT(1 2), GEOMETRYCOLLECTION(LINESTRING(3 4,5 6)))'))`,
+		Expected: []sql.Row{{"GEOMETRYCOLLECTION(POINT(1 2),GEOMETRYCOLLECTION(LINESTRING(3 4,5 6)))"}},
+	},
+	{
+		Query: `SELECT ST_TOUCHES(a, b), ST_OVERLAPS(a, c), ST_DISJOINT(a, d), ST_CROSSES(ST_GEOMFROMTEXT('LINESTRING(1 1,6 1)'), a) FROM
+			(SELECT ST_GEOMFROMTEXT('POLYGON((0 0,4 0,4 4,0 4,0 0))') AS a,
+				ST_GEOMFROMTEXT('POLYGON((4 0,8 0,8 4,4 4,4 0))') AS b,
+				ST_GEOMFROMTEXT('POLYGON((2 2,6 2,6 6,2 6,2 2))') AS c,
+				ST_GEOMFROMTEXT('POLYGON((10 10,12 10,12 12,10 12,10 10))') AS d) AS sq`,
+		Expected: []sql.Row{{true, true, true, true}},
+	},
+	{
+		Query: `SELECT ST_ASWKT(ST_INTERSECTION(a, b)), ST_ASWKT(ST_UNION(a, c)), ST_ASWKT(ST_DIFFERENCE(a, b)), ST_UNION(a, NULL) FROM
+			(SELECT ST_GEOMFROMTEXT('POLYGON((0 0,4 0,4 4,0 4,0 0))') AS a,
+				ST_GEOMFROMTEXT('POLYGON((2 2,6 2,6 6,2 6,2 2))') AS b,
+				ST_GEOMFROMTEXT('POLYGON((10 10,12 10,12 12,10 12,10 10))') AS c) AS sq`,
+		Expected: []sql.Row{{
+			"POLYGON((2 2,4 2,4 4,2 4,2 2))",
+			"MULTIPOLYGON(((0 0,4 0,4 4,0 4,0 0)),((10 10,12 10,12 12,10 12,10 10)))",
+			"POLYGON((0 0,4 0,4 2,2 2,2 4,0 4,0 0))",
+			nil,
+		}},
+	},
+	{
+		Query:    `SELECT ST_ASWKT(ST_MAKEPOINT(1, -2.5)), ST_ASWKT(POINT('3', 4)), ST_MAKEPOINT(NULL, 1)`,
+		Expected: []sql.Row{{"POINT(1 -2.5)", "POINT(3 4)", nil}},
+	},
 	{
 		Query:    `SELECT ST_GEOMFROMTEXT(ST_ASWKT(POINT(1,2)))`,
 		Expected: []sql.Row{{sql.Point{X: 1, Y: 2}}},
@@ -187,6 +229,26 @@ var SpatialQueryTests = []QueryTest{
 		Query:    `SELECT ST_SRID(p, 4326) from point_table`,
 		Expected: []sql.Row{{sql.Point{SRID: 4326, X: 1, Y: 2}}},
 	},
+	{
+		Query:    `SELECT ST_SRID(ST_SRID(p, 4326)), ST_X(ST_SRID(p, 4326)) from point_table`,
+		Expected: []sql.Row{{uint32(4326), 1.0}},
+	},
+	{
+		Query:    `SELECT ST_LONGITUDE(ST_SRID(p, 4326)), ST_LATITUDE(ST_SRID(p, 4326)) from point_table`,
+		Expected: []sql.Row{{1.0, 2.0}},
+	},
+	{
+		Query:    `SELECT ST_ASWKT(ST_LATITUDE(ST_SRID(p, 4326), 45)), ST_ASWKT(ST_LONGITUDE(ST_SRID(p, 4326), -120)) from point_table`,
+		Expected: []sql.Row{{"POINT(1 45)", "POINT(-120 2)"}},
+	},
+	{
+		Query:    `SELECT ST_GEOHASH(ST_SRID(p, 4326), 10) from point_table`,
+		Expected: []sql.Row{{"s02equ04ve"}},
+	},
+	{
+		Query:    `SELECT ST_ASWKT(ST_POINTFROMGEOHASH('s0', 4326)), ST_SRID(ST_POINTFROMGEOHASH('s0', 0))`,
+		Expected: []sql.Row{{"POINT(5.625 2.8125)", uint32(0)}},
+	},
 	{
 		Query: `SELECT ST_SRID(l, 4326) from line_table ORDER BY l`,
 		Expected: []sql.Row{
@@ -279,6 +341,12 @@ var SpatialQueryTests = []QueryTest{
 			{2},
 		},
 	},
+	{
+		Query: `SELECT ST_DIMENSION(ST_COLLECT(l)), ST_DIMENSION(ST_COLLECT(ST_CENTROID(l))) from line_table`,
+		Expected: []sql.Row{
+			{1, 0},
+		},
+	},
 	{
 		Query: `SELECT ST_SWAPXY(p) from point_table`,
 		Expected: []sql.Row{
@@ -298,6 +366,202 @@ var SpatialQueryTests = []QueryTest{
 			{sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 0}}}}}},
 		},
 	},
+	{
+		Query: `SELECT ST_ASWKT(ST_FLIPCOORDINATES(p)) from point_table`,
+		Expected: []sql.Row{
+			{"POINT(2 1)"},
+		},
+	},
+	{
+		Query: `SELECT ST_ASWKT(ST_FORCEPOLYGONCCW(p)), ST_ASWKT(ST_FORCEPOLYGONCW(p)) from polygon_table`,
+		Expected: []sql.Row{
+			{"POLYGON((0 0,1 1,0 1,0 0))", "POLYGON((0 0,0 1,1 1,0 0))"},
+		},
+	},
+	{
+		Query: `SELECT ST_ASWKT(ST_FORCEPOLYGONCW(p)) from point_table`,
+		Expected: []sql.Row{
+			{"POINT(1 2)"},
+		},
+	},
+	{
+		Query: `SELECT ST_ASWKT(ST_REVERSE(l)), ST_ASWKT(ST_REVERSE(ST_REVERSE(l))) from line_table`,
+		Expected: []sql.Row{
+			{"LINESTRING(3 4,1 2)", "LINESTRING(1 2,3 4)"},
+			{"LINESTRING(5 6,3 4,1 2)", "LINESTRING(1 2,3 4,5 6)"},
+		},
+	},
+	{
+		Query: `SELECT ST_ASWKT(ST_REVERSE(p)) from polygon_table`,
+		Expected: []sql.Row{
+			{"POLYGON((0 0,1 1,0 1,0 0))"},
+		},
+	},
+	{
+		Query: `SELECT ST_ASWKT(ST_REVERSE(p)) from point_table`,
+		Expected: []sql.Row{
+			{"POINT(1 2)"},
+		},
+	},
+	{
+		Query: `SELECT ST_ASWKT(ST_BOUNDARY(l)) from line_table`,
+		Expected: []sql.Row{
+			{"MULTIPOINT((1 2),(3 4))"},
+			{"MULTIPOINT((1 2),(5 6))"},
+		},
+	},
+	{
+		Query: `SELECT ST_ASWKT(ST_BOUNDARY(ST_GEOMFROMTEXT('LINESTRING(0 0,1 1,2 0,0 0)')))`,
+		Expected: []sql.Row{
+			{"MULTIPOINT()"},
+		},
+	},
+	{
+		Query: `SELECT ST_ASWKT(ST_BOUNDARY(p)) from polygon_table`,
+		Expected: []sql.Row{
+			{"MULTILINESTRING((0 0,0 1,1 1,0 0))"},
+		},
+	},
+	{
+		Query: `SELECT ST_ASWKT(ST_GEOMFROMTEXT(ST_ASWKT(ST_BOUNDARY(l)))), ST_GEOMFROMTEXT(ST_ASWKT(ST_BOUNDARY(l))) from line_table ORDER BY l`,
+		Expected: []sql.Row{
+			{"MULTIPOINT((1 2),(3 4))", sql.MultiPoint{Points: []sql.Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}},
+			{"MULTIPOINT((1 2),(5 6))", sql.MultiPoint{Points: []sql.Point{{X: 1, Y: 2}, {X: 5, Y: 6}}}},
+		},
+	},
+	{
+		Query: `SELECT ST_ASWKT(ST_GEOMFROMTEXT(ST_ASWKT(ST_BOUNDARY(p)))) from polygon_table`,
+		Expected: []sql.Row{
+			{"MULTILINESTRING((0 0,0 1,1 1,0 0))"},
+		},
+	},
+	{
+		Query: `SELECT ST_ASWKT(ST_BOUNDARY(p)) from point_table`,
+		Expected: []sql.Row{
+			{"GEOMETRYCOLLECTION()"},
+		},
+	},
+	{
+		Query: `SELECT ST_TRANSFORM(p, 0) from point_table`,
+		Expected: []sql.Row{
+			{sql.Point{X: 1, Y: 2}},
+		},
+	},
+	{
+		Query: `SELECT ROUND(ST_X(ST_TRANSFORM(ST_SRID(p, 4326), 3857)), 2), ROUND(ST_Y(ST_TRANSFORM(ST_SRID(p, 4326), 3857)), 2) from point_table`,
+		Expected: []sql.Row{
+			{111319.49, 222684.21},
+		},
+	},
+	{
+		Query: `SELECT ST_ASWKT(ST_SIMPLIFY(l, 1)) from line_table ORDER BY l`,
+		Expected: []sql.Row{
+			{"LINESTRING(1 2,3 4)"},
+			{"LINESTRING(1 2,5 6)"},
+		},
+	},
+	{
+		Query: `SELECT ST_AREA(ST_UNION(ST_GEOMFROMTEXT('POLYGON((0 0,1 0,1 1,0 1,0 0))'), ST_GEOMFROMTEXT('POLYGON((2 2,3 2,3 3,2 3,2 2))'))),
+			ST_AREA(ST_GEOMFROMTEXT('POLYGON((0 0,4 0,4 4,0 4,0 0),(1 1,3 1,3 3,1 3,1 1))')), ST_AREA(NULL)`,
+		Expected: []sql.Row{{2.0, 12.0, nil}},
+	},
+	{
+		Query: `SELECT ST_LENGTH(l), ST_LENGTH(ST_UNION(ST_GEOMFROMTEXT('LINESTRING(0 0,3 4)'), ST_GEOMFROMTEXT('LINESTRING(10 10,10 12)'))) from line_table ORDER BY l`,
+		Expected: []sql.Row{
+			{2.8284271247461903, 7.0},
+			{5.656854249492381, 7.0},
+		},
+	},
+	{
+		Query: `SELECT ST_AREA(ST_GEOMFROMTEXT('MULTIPOLYGON(((0 0,1 0,1 1,0 0)))')),
+			ST_AREA(ST_GEOMFROMTEXT('MULTIPOLYGON(((0 0,4 0,4 4,0 4,0 0),(1 1,3 1,3 3,1 3,1 1)),((5 5,6 5,6 6,5 5)))'))`,
+		Expected: []sql.Row{{0.5, 12.5}},
+	},
+	{
+		Query:    `SELECT ST_LENGTH(ST_GEOMFROMTEXT('MULTILINESTRING((0 0,3 4),(0 0,0 1))')), ST_LENGTH(ST_GEOMFROMTEXT('MULTILINESTRING((0 0,3 4))'))`,
+		Expected: []sql.Row{{6.0, 5.0}},
+	},
+	{
+		Query: `SELECT ST_ASWKT(ST_CENTROID(l)) from line_table ORDER BY l`,
+		Expected: []sql.Row{
+			{"POINT(2 3)"},
+			{"POINT(3 4)"},
+		},
+	},
+	{
+		Query: `SELECT ST_EQUALS(l, ST_GEOMFROMTEXT('LINESTRING(3 4,1 2)')), ST_EQUALS(l, POINT(1, 2)) from line_table ORDER BY l`,
+		Expected: []sql.Row{
+			{true, false},
+			{false, false},
+		},
+	},
+	{
+		Query: `SELECT ST_ISVALID(p), ST_ISSIMPLE(p) from polygon_table`,
+		Expected: []sql.Row{
+			{true, true},
+		},
+	},
+	{
+		Query: `SELECT ST_ISSIMPLE(ST_GEOMFROMTEXT('LINESTRING(0 0,2 2,2 0,0 2)'))`,
+		Expected: []sql.Row{
+			{false},
+		},
+	},
+	{
+		Query: `SELECT ST_ISVALID(ST_BUFFER(p, 1)), ST_ISVALID(ST_BUFFER(p, 1, 2)) from point_table`,
+		Expected: []sql.Row{
+			{true, true},
+		},
+	},
+	{
+		Query: `SELECT ST_ISVALID(ST_BUFFER(l, 0.5)) from line_table ORDER BY l`,
+		Expected: []sql.Row{
+			{true},
+			{true},
+		},
+	},
+	{
+		Query: `SELECT ST_ISVALID(ST_BUFFER(ST_GEOMFROMTEXT('LINESTRING(0 0,1 0,1 1)'), 5)), ST_ISVALID(ST_BUFFER(ST_GEOMFROMTEXT('LINESTRING(0 0,10 0,0 0.1)'), 1))`,
+		Expected: []sql.Row{
+			{true, true},
+		},
+	},
+	{
+		Query: `SELECT ST_ASWKT(ST_BUFFER(p, -1)) from point_table`,
+		Expected: []sql.Row{
+			{"POLYGON()"},
+		},
+	},
+	{
+		Query: `SELECT ST_ASWKT(ST_COLLECT(l)) from line_table where i = 0`,
+		Expected: []sql.Row{
+			{"MULTILINESTRING((1 2,3 4))"},
+		},
+	},
+	{
+		Query: `SELECT ST_ASWKT(ST_COLLECT(p)) from polygon_table`,
+		Expected: []sql.Row{
+			{"MULTIPOLYGON(((0 0,0 1,1 1,0 0)))"},
+		},
+	},
+	{
+		Query: `SELECT ST_ASWKT(ST_GEOMFROMTEXT(ST_ASWKT(ST_COLLECT(l)))) from line_table where i = 1`,
+		Expected: []sql.Row{
+			{"MULTILINESTRING((1 2,3 4,5 6))"},
+		},
+	},
+	{
+		Query: `SELECT ST_ASWKT(ST_GEOMFROMTEXT(ST_ASWKT(ST_COLLECT(p)))), ST_GEOMFROMTEXT(ST_ASWKT(ST_COLLECT(p))) from point_table`,
+		Expected: []sql.Row{
+			{"MULTIPOINT((1 2))", sql.MultiPoint{Points: []sql.Point{{X: 1, Y: 2}}}},
+		},
+	},
+	{
+		Query: `SELECT ST_ASWKT(ST_GEOMFROMTEXT(ST_ASWKT(ST_COLLECT(p)))) from polygon_table`,
+		Expected: []sql.Row{
+			{"MULTIPOLYGON(((0 0,0 1,1 1,0 0)))"},
+		},
+	},
 	{
 		Query: `SELECT ST_ASWKT(g) from geometry_table ORDER BY i`,
 		Expected: []sql.Row{
@@ -654,6 +918,29 @@ var QueryTests = []QueryTest{
 			{1},
 		},
 	},
+	{
+		Query: "SELECT mt.a, mt.b FROM (select i,s FROM mytable) mt (a,b) where mt.a > 1 order by mt.b;",
+		Expected: []sql.Row{
+			{2, "second row"},
+			{3, "third row"},
+		},
+	},
+	{
+		Query: "SELECT x, y FROM (select i, count(*) FROM mytable group by i) d (x,y) where y = 1 order by x desc;",
+		Expected: []sql.Row{
+			{3, 1},
+			{2, 1},
+			{1, 1},
+		},
+	},
+	{
+		Query: "SELECT d1.x, d2.z FROM (select i,s FROM mytable) d1 (x,y) join (select i2,s2 FROM othertable) d2 (w,z) on d1.x = d2.w order by 1;",
+		Expected: []sql.Row{
+			{1, "third"},
+			{2, "second"},
+			{3, "first"},
+		},
+	},
 	{
 		Query: `SELECT * FROM (values row(1+1,2+2), row(floor(1.5),concat("a","b"))) a order by 1`,
 		Expected: []sql.Row{
@@ -767,6 +1054,22 @@ var QueryTests = []QueryTest{
 			{-5040},
 		},
 	},
+	{
+		Query:    "SELECT TIMESTAMPDIFF(MONTH, '2021-01-31', '2021-02-28'), TIMESTAMPDIFF(MONTH, '2021-01-31', '2021-03-01'), TIMESTAMPDIFF(MONTH, '2021-03-31', '2021-02-28'), TIMESTAMPDIFF(YEAR, '2020-02-29', '2021-02-28')",
+		Expected: []sql.Row{{int64(0), int64(1), int64(-1), int64(0)}},
+	},
+	{
+		Query:    "SELECT TIMESTAMPDIFF(DAY, '2020-02-28', '2020-03-01'), TIMESTAMPDIFF(DAY, '2021-01-02 00:00:00', '2021-01-01 00:00:01'), TIMESTAMPDIFF(SECOND, '2021-01-01 00:00:01.9', '2021-01-01'), TIMESTAMPDIFF(SQL_TSI_SECOND, '2020-12-31 23:59:59', '2021-01-01')",
+		Expected: []sql.Row{{int64(2), int64(0), int64(-1), int64(1)}},
+	},
+	{
+		Query:    "SELECT TIMESTAMPADD(MONTH, 1, '2021-01-31'), TIMESTAMPADD(YEAR, 1, '2020-02-29'), TIMESTAMPADD(SECOND, -1, '2021-01-01'), TIMESTAMPADD(DAY, 1, '2020-02-28')",
+		Expected: []sql.Row{{time.Date(2021, time.February, 28, 0, 0, 0, 0, time.UTC), time.Date(2021, time.February, 28, 0, 0, 0, 0, time.UTC), time.Date(2020, time.December, 31, 23, 59, 59, 0, time.UTC), time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC)}},
+	},
+	{
+		Query:    "SELECT i, TIMESTAMPDIFF(DAY, date_col, TIMESTAMPADD(WEEK, i, date_col)) FROM datetime_table ORDER BY i",
+		Expected: []sql.Row{{int64(1), int64(7)}, {int64(2), int64(14)}, {int64(3), int64(21)}},
+	},
 	{
 		Query: `SELECT JSON_MERGE_PRESERVE('{ "a": 1, "b": 2 }','{ "a": 3, "c": 4 }','{ "a": 5, "d": 6 }')`,
 		Expected: []sql.Row{
@@ -832,6 +1135,37 @@ var QueryTests = []QueryTest{
 			{sql.MustJSON(`[{"id": 4,"name": "row four"}, [7, 8], {"d": 2}]`)},
 		},
 	},
+	{
+		Query: `SELECT * FROM JSON_TABLE('[{"id": 1, "name": "one"}, {"id": 2, "name": "two"}, {"name": "three"}]', '$[*]'
+			COLUMNS (id INT PATH '$.id', name VARCHAR(20) PATH '$.name')) AS jt`,
+		Expected: []sql.Row{
+			{1, "one"},
+			{2, "two"},
+			{nil, "three"},
+		},
+	},
+	{
+		Query: `SELECT jt.rn, jt.x FROM JSON_TABLE('{"a": [{"x": "b"}, {"x": "a"}]}', '$.a[*]'
+			COLUMNS (rn FOR ORDINALITY, x VARCHAR(10) PATH '$.x')) AS jt ORDER BY x`,
+		Expected: []sql.Row{
+			{uint32(2), "a"},
+			{uint32(1), "b"},
+		},
+	},
+	{
+		Query: `SELECT val FROM JSON_TABLE('{"a": [1, 2]}', '$.a' COLUMNS (val JSON PATH '$')) jt`,
+		Expected: []sql.Row{
+			{sql.MustJSON(`[1, 2]`)},
+		},
+	},
+	{
+		Query: `SELECT * FROM JSON_TABLE('[[1, "x"], [3]]', '$[*][*]' COLUMNS (val INT PATH '$', strict INT PATH '$' ERROR ON EMPTY)) AS jt`,
+		Expected: []sql.Row{
+			{1, 1},
+			{nil, nil},
+			{3, 3},
+		},
+	},
 	{
 		Query: `SELECT column_0, sum(column_1) FROM 
 			(values row(1,1), row(1,3), row(2,2), row(2,5), row(3,9)) a 
@@ -889,6 +1223,26 @@ var QueryTests = []QueryTest{
 			{2, "second row", "second", 2, 2, "second row"},
 		},
 	},
+	{
+		Query: `SELECT i, (SELECT GROUP_CONCAT(CONCAT(mt2.i, ':', COALESCE(ot2.s2, 'none')) ORDER BY mt2.i)
+			FROM othertable ot1 LEFT JOIN othertable ot2 ON ot1.i2 = ot2.i2 AND ot2.i2 < mt.i
+			RIGHT JOIN mytable mt2 ON mt2.i = ot1.i2 WHERE mt2.i <= mt.i) FROM mytable mt ORDER BY i`,
+		Expected: []sql.Row{
+			{1, "1:none"},
+			{2, "1:third,2:none"},
+			{3, "1:third,2:second,3:none"},
+		},
+	},
+	{
+		Query: `SELECT s, (SELECT GROUP_CONCAT(CONCAT(ot.s2, ':', COALESCE(mt2.i, 'none')) ORDER BY ot.s2)
+			FROM mytable mt2 RIGHT JOIN othertable ot ON mt2.i = ot.i2 AND mt2.i <> mt.i
+			LEFT JOIN mytable mt3 ON mt3.i = ot.i2 + 1 WHERE mt3.i IS NOT NULL OR mt.i = 1) FROM mytable mt ORDER BY s`,
+		Expected: []sql.Row{
+			{"first row", "first:3,second:2,third:none"},
+			{"second row", "second:none,third:1"},
+			{"third row", "second:2,third:1"},
+		},
+	},
 	{
 		Query: `SELECT a.column_0, b.column_1 FROM (values row(1+1,2+2), row(floor(1.5),concat("a","b"))) a
 			join (values row(2,4), row(1.0,"ab")) b on a.column_0 = b.column_0 and a.column_0 = b.column_0
@@ -1078,6 +1432,41 @@ var QueryTests = []QueryTest{
 			{1, 3, "first"},
 		},
 	},
+	{
+		Query:    "SELECT t.s FROM (SELECT i, s, i * 2 AS d, ROW_NUMBER() OVER (ORDER BY i DESC) rn FROM mytable) t ORDER BY t.s",
+		Expected: []sql.Row{{"first row"}, {"second row"}, {"third row"}},
+	},
+	{
+		Query:    "SELECT t.m FROM (SELECT s, COUNT(*) AS c, MAX(i) AS m FROM mytable GROUP BY s) t (s, c, m) ORDER BY t.m",
+		Expected: []sql.Row{{int64(1)}, {int64(2)}, {int64(3)}},
+	},
+	{
+		Query:    "SELECT b.x FROM (SELECT a.y AS x, a.z FROM (SELECT i AS y, s AS z, i * 10 AS w FROM mytable) a) b ORDER BY b.x",
+		Expected: []sql.Row{{int64(1)}, {int64(2)}, {int64(3)}},
+	},
+	{
+		Query:    "SELECT COUNT(*) FROM (SELECT s, MAX(i) FROM mytable GROUP BY s) t",
+		Expected: []sql.Row{{int64(3)}},
+	},
+	{
+		Query: "SELECT a.i, a.d, o.s2 FROM (SELECT i, i * 2 AS d FROM mytable) a JOIN othertable o ON a.i = o.i2 WHERE a.d > 2 ORDER BY a.i",
+		Expected: []sql.Row{
+			{int64(2), int64(4), "second"},
+			{int64(3), int64(6), "first"},
+		},
+	},
+	{
+		// The filter must not move below the limit, or it would change which rows are limited.
+		Query:    "SELECT * FROM (SELECT i, s FROM mytable ORDER BY i LIMIT 2) a WHERE a.i > 1",
+		Expected: []sql.Row{{int64(2), "second row"}},
+	},
+	{
+		Query: "SELECT * FROM (SELECT s, COUNT(*) AS c FROM mytable GROUP BY s) t WHERE t.c > 0 AND t.s <> 'first row' ORDER BY t.s",
+		Expected: []sql.Row{
+			{"second row", int64(1)},
+			{"third row", int64(1)},
+		},
+	},
 	{
 		// In this case, the analyzer should not push the filter below the window function.
 		Query: "SELECT * FROM (SELECT ROW_NUMBER() OVER (ORDER BY s2 ASC) idx, i2, s2 FROM othertable ORDER BY i2 ASC) a WHERE s2 <> 'second'",
@@ -1156,6 +1545,19 @@ var QueryTests = []QueryTest{
 			{"testing", 4},
 		},
 	},
+	{
+		Query: `SELECT t.i,
+			(SELECT count(*)
+			 FROM mytable u
+			 JOIN (SELECT i2 FROM othertable) s ON u.i <= s.i2 OR u.i > s.i2
+			 WHERE u.i = t.i) AS c
+			FROM mytable t ORDER BY 1`,
+		Expected: []sql.Row{
+			{1, 3},
+			{2, 3},
+			{3, 3},
+		},
+	},
 	{
 		Query: `WITH mt1 as (select i,s FROM mytable)
 			SELECT mtouter.i, (select s from mt1 where i = mtouter.i+1) FROM mt1 as mtouter where mtouter.i > 1 order by 1`,
@@ -2749,6 +3151,26 @@ var QueryTests = []QueryTest{
 		Query:    "SELECT YEAR('2007-12-11') FROM mytable",
 		Expected: []sql.Row{{int32(2007)}, {int32(2007)}, {int32(2007)}},
 	},
+	{
+		Query:    "SELECT EXTRACT(YEAR_MONTH FROM '2021-03-01'), EXTRACT(QUARTER FROM '2021-03-01'), EXTRACT(DAY_MINUTE FROM '2021-03-01 13:07:09'), extract(second_microsecond from '2021-03-01 13:07:09.5')",
+		Expected: []sql.Row{{int64(202103), int64(1), int64(11307), int64(9500000)}},
+	},
+	{
+		Query:    "SELECT i, EXTRACT(DAY FROM date_col), EXTRACT(DAY_HOUR FROM datetime_col), EXTRACT(HOUR_SECOND /* of */ FROM timestamp_col) FROM datetime_table ORDER BY i",
+		Expected: []sql.Row{{int64(1), int64(31), int64(112), int64(120000)}, {int64(2), int64(3), int64(412), int64(120000)}, {int64(3), int64(7), int64(712), int64(120001)}},
+	},
+	{
+		Query:    "SELECT EXTRACT(HOUR FROM '10:10:10'), EXTRACT(HOUR FROM '100:10:10'), EXTRACT(MINUTE_SECOND FROM '100:10:10'), EXTRACT(HOUR_MINUTE FROM CAST('-10:20:30' AS TIME))",
+		Expected: []sql.Row{{int64(10), int64(100), int64(1010), int64(-1020)}},
+	},
+	{
+		Query:    "SELECT EXTRACT(HOUR FROM TIMEDIFF('2021-03-02 10:00:00', '2021-03-01 00:30:00')), EXTRACT(MINUTE FROM TIMEDIFF('2021-03-02 10:00:00', '2021-03-01 00:30:00'))",
+		Expected: []sql.Row{{int64(33), int64(30)}},
+	},
+	{
+		Query:    "WITH t AS (SELECT EXTRACT(YEAR FROM '2021-03-04') AS unit) SELECT unit, EXTRACT(DAY FROM '2021-03-04') FROM t",
+		Expected: []sql.Row{{int64(2021), int64(4)}},
+	},
 	{
 		Query:    "SELECT MONTH('2007-12-11') FROM mytable",
 		Expected: []sql.Row{{int32(12)}, {int32(12)}, {int32(12)}},
@@ -2785,6 +3207,22 @@ var QueryTests = []QueryTest{
 		Query:    "SELECT DAYOFYEAR('20071211') FROM mytable",
 		Expected: []sql.Row{{int32(345)}, {int32(345)}, {int32(345)}},
 	},
+	{
+		Query:    "SELECT LAST_DAY('2020-02-10'), LAST_DAY('2021-02-10 11:12:13'), LAST_DAY(NULL), DAYOFYEAR(STR_TO_DATE('2020 366', '%Y %j')), DAYOFWEEK(STR_TO_DATE('2021 100', '%Y %j'))",
+		Expected: []sql.Row{{time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC), time.Date(2021, time.February, 28, 0, 0, 0, 0, time.UTC), nil, int32(366), int32(7)}},
+	},
+	{
+		Query:    "SELECT MAKEDATE(2021, 60), MAKEDATE(2021, 0), MAKETIME(12, 30, 45), MAKETIME(12, 60, 0)",
+		Expected: []sql.Row{{time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC), nil, "12:30:45", nil}},
+	},
+	{
+		Query:    "SELECT PERIOD_ADD(200801, 2), PERIOD_ADD(200812, 2), PERIOD_DIFF(200902, 200812), PERIOD_DIFF(200801, 200803)",
+		Expected: []sql.Row{{int64(200803), int64(200902), int64(2), int64(-2)}},
+	},
+	{
+		Query:    "SELECT SEC_TO_TIME(90061), SEC_TO_TIME(-3661), TIME_TO_SEC('25:01:01'), TIME_TO_SEC(SEC_TO_TIME(-3661))",
+		Expected: []sql.Row{{"25:01:01", "-01:01:01", int64(90061), int64(-3661)}},
+	},
 	{
 		Query:    "SELECT YEARWEEK('0000-01-01')",
 		Expected: []sql.Row{{int32(1)}},
@@ -2805,6 +3243,14 @@ var QueryTests = []QueryTest{
 		Query:    "SELECT YEARWEEK('1987-01-01', 20), YEARWEEK('1987-01-01', 1), YEARWEEK('1987-01-01', 2), YEARWEEK('1987-01-01', 3), YEARWEEK('1987-01-01', 4), YEARWEEK('1987-01-01', 5), YEARWEEK('1987-01-01', 6), YEARWEEK('1987-01-01', 7)",
 		Expected: []sql.Row{{int32(198653), int32(198701), int32(198652), int32(198701), int32(198653), int32(198652), int32(198653), int32(198652)}},
 	},
+	{
+		Query:    "SELECT WEEK('2021-01-01', 0), WEEK('2021-01-01', 1), WEEK('2021-01-01', 2), WEEK('2021-01-01', 3), WEEK('2021-01-01', 4), WEEK('2021-01-01', 5), WEEK('2021-01-01', 6), WEEK('2021-01-01', 7)",
+		Expected: []sql.Row{{int32(0), int32(0), int32(52), int32(53), int32(0), int32(0), int32(53), int32(52)}},
+	},
+	{
+		Query:    "SELECT i, WEEK('2021-01-01', i), YEARWEEK('2021-01-01', i) FROM mytable ORDER BY i",
+		Expected: []sql.Row{{int64(1), int32(0), int32(202053)}, {int64(2), int32(52), int32(202052)}, {int64(3), int32(53), int32(202053)}},
+	},
 	{
 		Query:    "SELECT i FROM mytable WHERE i BETWEEN 1 AND 2",
 		Expected: []sql.Row{{int64(1)}, {int64(2)}},
@@ -3606,6 +4052,14 @@ var QueryTests = []QueryTest{
 			{string("third row3")},
 		},
 	},
+	{
+		Query: "SELECT CONCAT(s, i), Concat(s, i) FROM mytable",
+		Expected: []sql.Row{
+			{string("first row1"), string("first row1")},
+			{string("second row2"), string("second row2")},
+			{string("third row3"), string("third row3")},
+		},
+	},
 	{
 		Query: "SELECT version()",
 		Expected: []sql.Row{
@@ -3746,14 +4200,30 @@ var QueryTests = []QueryTest{
 			{"04AM"},
 		},
 	},
+	{
+		Query:    "select time_format('13:14:15.123', '%H:%i:%s.%f'), time_format('13:14:15', '%Y-%m-%d %H')",
+		Expected: []sql.Row{{"13:14:15.123000", nil}},
+	},
 	{
 		Query: "select from_unixtime(i) from mytable order by 1",
 		Expected: []sql.Row{
-			{time.Unix(1, 0)},
-			{time.Unix(2, 0)},
-			{time.Unix(3, 0)},
+			{time.Date(1970, 1, 1, 0, 0, 1, 0, time.UTC)},
+			{time.Date(1970, 1, 1, 0, 0, 2, 0, time.UTC)},
+			{time.Date(1970, 1, 1, 0, 0, 3, 0, time.UTC)},
+		},
+	},
+	{
+		Query: "select from_unixtime(i * 86400, '%Y-%m-%d %H:%i:%s') from mytable order by 1",
+		Expected: []sql.Row{
+			{"1970-01-02 00:00:00"},
+			{"1970-01-03 00:00:00"},
+			{"1970-01-04 00:00:00"},
 		},
 	},
+	{
+		Query:    "select from_unixtime(-1), from_unixtime(null), from_unixtime(1, null), unix_timestamp(null), unix_timestamp('1969-12-31')",
+		Expected: []sql.Row{{nil, nil, nil, nil, float64(0)}},
+	},
 	// TODO: add additional tests for other functions. Every function needs an engine test to ensure it works correctly
 	//  with the analyzer.
 	{
@@ -4446,6 +4916,22 @@ var QueryTests = []QueryTest{
 		Query:    "SELECT '2018-05-02' - INTERVAL 1 DAY",
 		Expected: []sql.Row{{time.Date(2018, time.May, 1, 0, 0, 0, 0, time.UTC)}},
 	},
+	{
+		Query:    "SELECT DATE_ADD('2021-01-31', INTERVAL 1 MONTH), DATE_ADD('2020-01-31', INTERVAL 1 MONTH), DATE_SUB('2021-03-31', INTERVAL 1 MONTH), '2020-02-29' + INTERVAL 1 YEAR",
+		Expected: []sql.Row{{time.Date(2021, time.February, 28, 0, 0, 0, 0, time.UTC), time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC), time.Date(2021, time.February, 28, 0, 0, 0, 0, time.UTC), time.Date(2021, time.February, 28, 0, 0, 0, 0, time.UTC)}},
+	},
+	{
+		Query:    "SELECT DATE_ADD('2021-11-30', INTERVAL 1 QUARTER), DATE_ADD('2021-01-01', INTERVAL 2 WEEK), DATE_SUB('2021-01-01', INTERVAL '1-2' YEAR_MONTH)",
+		Expected: []sql.Row{{time.Date(2022, time.February, 28, 0, 0, 0, 0, time.UTC), time.Date(2021, time.January, 15, 0, 0, 0, 0, time.UTC), time.Date(2019, time.November, 1, 0, 0, 0, 0, time.UTC)}},
+	},
+	{
+		Query:    "SELECT DATE_ADD('2021-01-01', INTERVAL '1 30' DAY_HOUR), '2021-01-01' - INTERVAL '-1 2' DAY_HOUR, DATE_ADD('2021-01-01', INTERVAL '1 2:03:04' DAY_SECOND)",
+		Expected: []sql.Row{{time.Date(2021, time.January, 3, 6, 0, 0, 0, time.UTC), time.Date(2021, time.January, 2, 2, 0, 0, 0, time.UTC), time.Date(2021, time.January, 2, 2, 3, 4, 0, time.UTC)}},
+	},
+	{
+		Query:    "SELECT DATE_ADD('2021-01-01 23:59:59.000002', INTERVAL '1.999999' SECOND_MICROSECOND), DATE_ADD('2021-01-01', INTERVAL 1.5 SECOND), DATE_SUB('2021-01-01', INTERVAL 1 MICROSECOND)",
+		Expected: []sql.Row{{time.Date(2021, time.January, 2, 0, 0, 1, 1000, time.UTC), time.Date(2021, time.January, 1, 0, 0, 1, 500000000, time.UTC), time.Date(2020, time.December, 31, 23, 59, 59, 999999000, time.UTC)}},
+	},
 	{
 		Query:    `SELECT i AS i FROM mytable ORDER BY i`,
 		Expected: []sql.Row{{int64(1)}, {int64(2)}, {int64(3)}},
@@ -4867,6 +5353,14 @@ var QueryTests = []QueryTest{
 		Query:    `SELECT NOW() - (NOW() - INTERVAL 1 SECOND)`,
 		Expected: []sql.Row{{int64(1)}},
 	},
+	{
+		Query:    `SELECT NOW() = NOW(), CURRENT_TIMESTAMP(6) = CURRENT_TIMESTAMP(6), CURDATE() = CURRENT_DATE(), CURTIME() = CURRENT_TIME(), NOW() = (SELECT NOW())`,
+		Expected: []sql.Row{{true, true, true, true, true}},
+	},
+	{
+		Query:    `SELECT COUNT(DISTINCT NOW(6)), COUNT(DISTINCT (SELECT NOW(6) FROM dual)), COUNT(DISTINCT UNIX_TIMESTAMP()) FROM mytable`,
+		Expected: []sql.Row{{int64(1), int64(1), int64(1)}},
+	},
 	{
 		Query:    `SELECT SUBSTR(SUBSTRING('0123456789ABCDEF', 1, 10), -4)`,
 		Expected: []sql.Row{{"6789"}},
@@ -5349,6 +5843,37 @@ var QueryTests = []QueryTest{
 			{3, 3},
 		},
 	},
+	{
+		Query: `SELECT pk, (SELECT max(b.c1) + opk.pk FROM one_pk a, one_pk b) FROM one_pk opk ORDER BY 1`,
+		Expected: []sql.Row{
+			{0, 30},
+			{1, 31},
+			{2, 32},
+			{3, 33},
+		},
+	},
+	{
+		Query: `SELECT pk, (SELECT max(t.c1 + opk.pk) FROM one_pk, (SELECT c1 FROM one_pk) t) FROM one_pk opk ORDER BY 1`,
+		Expected: []sql.Row{
+			{0, 30},
+			{1, 31},
+			{2, 32},
+			{3, 33},
+		},
+	},
+	{
+		Query: `SELECT pk, (SELECT max(t.z) FROM one_pk JOIN (SELECT 5 AS z) t) FROM one_pk ORDER BY 1`,
+		Expected: []sql.Row{
+			{0, 5},
+			{1, 5},
+			{2, 5},
+			{3, 5},
+		},
+	},
+	{
+		Query:    `SELECT (SELECT max(t.z) FROM one_pk JOIN (SELECT 5 AS z) t)`,
+		Expected: []sql.Row{{5}},
+	},
 	{
 		Query: `SELECT pk, (SELECT max(pk) FROM one_pk WHERE pk <= opk.pk) FROM one_pk opk ORDER BY 1`,
 		Expected: []sql.Row{
@@ -6326,6 +6851,46 @@ var QueryTests = []QueryTest{
 			{7, 1},
 		},
 	},
+	{
+		Query: "SELECT pk, v2, GROUP_CONCAT(pk SEPARATOR '|') OVER (PARTITION BY v2 ORDER BY pk) FROM one_pk_three_idx ORDER BY pk",
+		Expected: []sql.Row{
+			{0, 0, "0"},
+			{1, 0, "0|1"},
+			{2, 1, "2"},
+			{3, 2, "3"},
+			{4, 0, "0|1|4"},
+			{5, 0, "0|1|4|5"},
+			{6, 3, "6"},
+			{7, 4, "7"},
+		},
+	},
+	{
+		Query: "SELECT GROUP_CONCAT(pk SEPARATOR '|') OVER (ORDER BY pk) FROM one_pk_three_idx WHERE pk < 3 ORDER BY pk",
+		ExpectedColumns: sql.Schema{
+			{
+				Name: "GROUP_CONCAT(pk SEPARATOR '|') OVER (ORDER BY pk)",
+				Type: sql.Text,
+			},
+		},
+		Expected: []sql.Row{
+			{"0"},
+			{"0|1"},
+			{"0|1|2"},
+		},
+	},
+	{
+		Query: "SELECT pk, GROUP_CONCAT(pk ORDER BY pk DESC SEPARATOR '-') OVER w FROM one_pk_three_idx WINDOW w AS (PARTITION BY v2 ORDER BY pk) ORDER BY pk",
+		Expected: []sql.Row{
+			{0, "0"},
+			{1, "1-0"},
+			{2, "2"},
+			{3, "3"},
+			{4, "4-1-0"},
+			{5, "5-4-1-0"},
+			{6, "6"},
+			{7, "7"},
+		},
+	},
 	{
 		Query: "SELECT pk, row_number() over (order by v2, pk), max(pk) over () from one_pk_three_idx ORDER BY pk",
 		Expected: []sql.Row{
@@ -6538,6 +7103,18 @@ var QueryTests = []QueryTest{
 			{time.Date(2020, 1, 1, 16, 0, 0, 0, time.UTC)},
 		},
 	},
+	{
+		Query: `SELECT CONVERT_TZ("2021-03-14 06:30:00", "+00:00", "America/New_York"), CONVERT_TZ("2021-03-14 07:30:00", "+00:00", "America/New_York")`,
+		Expected: []sql.Row{
+			{time.Date(2021, 3, 14, 1, 30, 0, 0, time.UTC), time.Date(2021, 3, 14, 3, 30, 0, 0, time.UTC)},
+		},
+	},
+	{
+		Query: `SELECT CONVERT_TZ("2021-03-14 06:30:00", "+00:00", "America/Nowhere")`,
+		Expected: []sql.Row{
+			{nil},
+		},
+	},
 	{
 		Query: `SELECT 1 from dual WHERE EXISTS (SELECT 1 from dual);`,
 		Expected: []sql.Row{
@@ -7048,9 +7625,6 @@ var BrokenQueries = []QueryTest{
 	{
 		Query: "SELECT json_type() FROM dual;",
 	},
-	{
-		Query: "SELECT json_table() FROM dual;",
-	},
 	{
 		Query: "SELECT json_valid() FROM dual;",
 	},
@@ -7320,7 +7894,7 @@ var DateParseQueries = []QueryTest{
 	},
 	{
 		Query:    "SELECT STR_TO_DATE('01/02/99 314', '%m/%e/%y %f')",
-		Expected: []sql.Row{{time.Date(1999, time.January, 2, 0, 0, 0, 314000, time.Local)}},
+		Expected: []sql.Row{{time.Date(1999, time.January, 2, 0, 0, 0, 314000000, time.Local)}},
 	},
 	{
 		Query:    "SELECT STR_TO_DATE('01/02/99 05:14:12 PM', '%m/%e/%y %r')",
@@ -8188,6 +8762,14 @@ type QueryErrorTest struct {
 }
 
 var errorQueries = []QueryErrorTest{
+	{
+		Query:       strings.Repeat("SELECT (", 70) + "SELECT 1" + strings.Repeat(")", 70),
+		ExpectedErr: sql.ErrMaxNestingDepthExceeded,
+	},
+	{
+		Query:       strings.Repeat("SELECT * FROM (", 70) + "SELECT 1" + strings.Repeat(") t", 70),
+		ExpectedErr: sql.ErrMaxNestingDepthExceeded,
+	},
 	{
 		Query:       "select foo.i from mytable as a",
 		ExpectedErr: sql.ErrTableNotFound,
@@ -8371,6 +8953,10 @@ var errorQueries = []QueryErrorTest{
 		Query:       "SELECT C FROM (select i,s FROM mytable) mt (a,b) order by a desc;",
 		ExpectedErr: sql.ErrColumnNotFound,
 	},
+	{
+		Query:          "SELECT i, (WITH t AS (SELECT nonexistent FROM othertable) SELECT * FROM t) FROM mytable",
+		ExpectedErrStr: `column "nonexistent" could not be found in any table in scope`,
+	},
 	{
 		Query:       "SELECT i FROM (select i,s FROM mytable) mt (a,b) order by a desc;",
 		ExpectedErr: sql.ErrColumnNotFound,
@@ -8387,6 +8973,106 @@ var errorQueries = []QueryErrorTest{
 		Query:       "SELECT a FROM (select i,s FROM mytable) mt (a,b,c) order by a desc;",
 		ExpectedErr: sql.ErrColumnCountMismatch,
 	},
+	{
+		Query:          "SELECT concatt('a', 'b')",
+		ExpectedErrStr: "function: 'concatt' not found: did you mean concat?",
+	},
+	{
+		Query:       `SELECT * FROM JSON_TABLE('[1, 2]', '$[*]') AS jt`,
+		ExpectedErr: sql.ErrSyntaxError,
+	},
+	{
+		Query:       `SELECT * FROM JSON_TABLE('[1, 2]', '$[*]' COLUMNS (x INT)) AS jt`,
+		ExpectedErr: function.ErrInvalidJSONTableColumns,
+	},
+	{
+		Query:       `SELECT * FROM JSON_TABLE('[1, 2]', concat('$', '[*]') COLUMNS (x INT PATH '$')) AS jt`,
+		ExpectedErr: function.ErrJSONTableConstantArgument,
+	},
+	{
+		Query:       `SELECT * FROM JSON_TABLE('[1, 2]', '$[*]' COLUMNS (x INT PATH '$', X INT PATH '$')) AS jt`,
+		ExpectedErr: sql.ErrDuplicateColumnName,
+	},
+	{
+		Query:       `SELECT ST_TRANSFORM(POINT(1, 2), 3857)`,
+		ExpectedErr: function.ErrUnsupportedTransform,
+	},
+	{
+		Query:       `SELECT ST_GEOMFROMTEXT('POINT(1 2) garbage')`,
+		ExpectedErr: sql.ErrInvalidGISData,
+	},
+	{
+		Query:       `SELECT ST_AREA(POINT(1, 2))`,
+		ExpectedErr: sql.ErrInvalidGISData,
+	},
+	{
+		Query:       `SELECT ST_LATITUDE(POINT(1, 2))`,
+		ExpectedErr: function.ErrNonGeographic,
+	},
+	{
+		Query:       `SELECT ST_LATITUDE(ST_SRID(POINT(1, 2), 4326), 100)`,
+		ExpectedErr: function.ErrLatitudeOutOfRange,
+	},
+	{
+		Query:       `SELECT ST_GEOHASH(POINT(1, 2), 10)`,
+		ExpectedErr: function.ErrNonGeographic,
+	},
+	{
+		Query:       `SELECT ST_POINTFROMGEOHASH('abc', 4326)`,
+		ExpectedErr: function.ErrInvalidGeoHash,
+	},
+	{
+		Query:       `SELECT ST_DIMENSION('POINT(1 2)')`,
+		ExpectedErr: sql.ErrInvalidGISData,
+	},
+	{
+		Query:       `SELECT ST_SRID(POINT(1, 2), -1)`,
+		ExpectedErr: sql.ErrInvalidArgumentDetails,
+	},
+	{
+		Query:       `SELECT ST_SRID(POINT(1, 2), 1234)`,
+		ExpectedErr: function.ErrInvalidSRID,
+	},
+	{
+		Query:          "SELECT substring('abc')",
+		ExpectedErrStr: "function 'substring' expected 2 or 3 arguments, 1 received",
+	},
+	{
+		Query:          "SELECT substring('abc', 1, 2, 3)",
+		ExpectedErrStr: "function 'substring' expected 2 or 3 arguments, 4 received",
+	},
+	{
+		Query:          "SELECT regexp_replace('abc', 'b')",
+		ExpectedErrStr: "function 'regexp_replace' expected 3 to 6 arguments, 2 received",
+	},
+	{
+		Query:          "SELECT concat()",
+		ExpectedErrStr: "function 'concat' expected 1 or more arguments, 0 received",
+	},
+	{
+		Query:          "SELECT abs(1, 2)",
+		ExpectedErrStr: "function 'abs' expected 1 arguments, 2 received",
+	},
+	{
+		Query:          "SELECT now(1, 2)",
+		ExpectedErrStr: "function 'now' expected 0 or 1 arguments, 2 received",
+	},
+	{
+		Query:          "SELECT i, lag(i, 1, 0, 2) over (order by i) FROM mytable",
+		ExpectedErrStr: "function 'lag' expected 1 to 3 arguments, 4 received",
+	},
+	{
+		Query:       "SELECT a FROM (select i,s FROM mytable) mt (a,a) order by a desc;",
+		ExpectedErr: sql.ErrDuplicateColumnName,
+	},
+	{
+		Query:       "SELECT a FROM (select i,s FROM mytable) mt (a,A);",
+		ExpectedErr: sql.ErrDuplicateColumnName,
+	},
+	{
+		Query:       "WITH mt (a,a) as (select i,s FROM mytable) SELECT a FROM mt;",
+		ExpectedErr: sql.ErrDuplicateColumnName,
+	},
 	{
 		Query:       "SELECT i FROM mytable limit ?",
 		ExpectedErr: sql.ErrInvalidSyntax,
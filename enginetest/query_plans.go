@@ -40,7 +40,7 @@ var PlanTests = []QueryPlanTest{
 				from mytable join othertable on i = i2 order by 1`,
 		ExpectedPlan: "Sort(row_number() over (order by i desc) ASC)\n" +
 			" └─ Project(row_number() over ( order by [mytable.i, idx=0, type=BIGINT, nullable=false] DESC) as row_number() over (order by i desc), i2)\n" +
-			"     └─ Window(row_number() over ( order by [mytable.i, idx=0, type=BIGINT, nullable=false] DESC), mytable.i as i2)\n" +
+			"     └─ Window(row_number() over ( order by [mytable.i, idx=0, type=BIGINT, nullable=false] DESC) [PartitionFramer(ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING)], mytable.i as i2)\n" +
 			"         └─ IndexedJoin(mytable.i = othertable.i2)\n" +
 			"             ├─ Table(mytable)\n" +
 			"             └─ IndexedTableAccess(othertable on [othertable.i2])\n" +
@@ -79,12 +79,105 @@ var PlanTests = []QueryPlanTest{
 				order by 1`,
 		ExpectedPlan: "Sort(row_number() over (order by i desc) ASC)\n" +
 			" └─ Project(row_number() over ( order by [mytable.i, idx=0, type=BIGINT, nullable=false] DESC) as row_number() over (order by i desc), i2)\n" +
-			"     └─ Window(row_number() over ( order by [mytable.i, idx=0, type=BIGINT, nullable=false] DESC), mytable.i as i2)\n" +
+			"     └─ Window(row_number() over ( order by [mytable.i, idx=0, type=BIGINT, nullable=false] DESC) [PartitionFramer(ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING)], mytable.i as i2)\n" +
 			"         └─ IndexedJoin(mytable.i = othertable.i2)\n" +
 			"             ├─ IndexedTableAccess(mytable on [mytable.i] with ranges: [{[2, 2]}])\n" +
 			"             └─ IndexedTableAccess(othertable on [othertable.i2])\n" +
 			"",
 	},
+	{
+		Query: `select i, s from (select i, s, row_number() over (partition by s order by i desc) rn from mytable) t where rn = 1 order by i`,
+		ExpectedPlan: "Sort(t.i ASC)\n" +
+			" └─ SubqueryAlias(t)\n" +
+			"     └─ Project(mytable.i, mytable.s)\n" +
+			"         └─ Filter(row_number() over ( partition by mytable.s order by [mytable.i, idx=0, type=BIGINT, nullable=false] DESC) = 1)\n" +
+			"             └─ Window(Limit per partition: [1]; mytable.i, mytable.s, row_number() over ( partition by mytable.s order by [mytable.i, idx=0, type=BIGINT, nullable=false] DESC) [PartitionFramer(ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING)])\n" +
+			"                 └─ Projected table access on [i s]\n" +
+			"                     └─ Table(mytable)\n" +
+			"",
+	},
+	{
+		Query: `select i, s, c from (select i, s, row_number() over (partition by s order by i desc) rn, count(*) over (partition by s) c from mytable) t where rn <= 2 order by i`,
+		ExpectedPlan: "Sort(t.i ASC)\n" +
+			" └─ SubqueryAlias(t)\n" +
+			"     └─ Project(mytable.i, mytable.s, COUNT(*) as c)\n" +
+			"         └─ Filter(row_number() over ( partition by mytable.s order by [mytable.i, idx=0, type=BIGINT, nullable=false] DESC) <= 2)\n" +
+			"             └─ Window(mytable.i, mytable.s, row_number() over ( partition by mytable.s order by [mytable.i, idx=0, type=BIGINT, nullable=false] DESC) [PartitionFramer(ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING)], COUNT(*) [PartitionFramer(ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING)])\n" +
+			"                 └─ Projected table access on [i s]\n" +
+			"                     └─ Table(mytable)\n" +
+			"",
+	},
+	{
+		Query: `select t.s from (select i, s, i * 2 as d, row_number() over (order by i desc) rn from mytable) t order by t.s`,
+		ExpectedPlan: "Sort(t.s ASC)\n" +
+			" └─ SubqueryAlias(t)\n" +
+			"     └─ Project(mytable.s)\n" +
+			"         └─ Window(mytable.s)\n" +
+			"             └─ Projected table access on [i s]\n" +
+			"                 └─ Table(mytable)\n" +
+			"",
+	},
+	{
+		Query: `select t.m from (select s, count(*) as c, max(i) as m from mytable group by s) t (s, c, m) order by t.m`,
+		ExpectedPlan: "Sort(t.m ASC)\n" +
+			" └─ SubqueryAlias(t)\n" +
+			"     └─ Project(MAX(mytable.i) as m)\n" +
+			"         └─ GroupBy\n" +
+			"             ├─ SelectedExprs(MAX(mytable.i))\n" +
+			"             ├─ Grouping(mytable.s)\n" +
+			"             └─ Projected table access on [s i]\n" +
+			"                 └─ Table(mytable)\n" +
+			"",
+	},
+	{
+		Query: `select a.i, a.d, o.s2 from (select i, i * 2 as d from mytable) a join othertable o on a.i = o.i2 where a.d > 2 order by a.i`,
+		ExpectedPlan: "Sort(a.i ASC)\n" +
+			" └─ Project(a.i, a.d, o.s2)\n" +
+			"     └─ IndexedJoin(a.i = o.i2)\n" +
+			"         ├─ SubqueryAlias(a)\n" +
+			"         │   └─ Project(mytable.i, (mytable.i * 2) as d)\n" +
+			"         │       └─ Filter((mytable.i * 2) > 2)\n" +
+			"         │           └─ Projected table access on [i]\n" +
+			"         │               └─ Table(mytable)\n" +
+			"         └─ TableAlias(o)\n" +
+			"             └─ IndexedTableAccess(othertable on [othertable.i2])\n" +
+			"",
+	},
+	{
+		Query: `select * from (select i, s from mytable order by i limit 2) a where a.i > 1`,
+		ExpectedPlan: "SubqueryAlias(a)\n" +
+			" └─ Filter(mytable.i > 1)\n" +
+			"     └─ Limit(2)\n" +
+			"         └─ TopN(Limit: [2]; mytable.i ASC)\n" +
+			"             └─ Projected table access on [i s]\n" +
+			"                 └─ Table(mytable)\n" +
+			"",
+	},
+	{
+		Query: `select * from (select s, count(*) as c from mytable group by s) t where t.c > 0 and t.s <> 'first row' order by t.s`,
+		ExpectedPlan: "Sort(t.s ASC)\n" +
+			" └─ SubqueryAlias(t)\n" +
+			"     └─ Project(mytable.s, COUNT(*) as c)\n" +
+			"         └─ Filter(COUNT(*) > 0)\n" +
+			"             └─ GroupBy\n" +
+			"                 ├─ SelectedExprs(mytable.s, COUNT(*))\n" +
+			"                 ├─ Grouping(mytable.s)\n" +
+			"                 └─ Filter(NOT((mytable.s = \"first row\")))\n" +
+			"                     └─ Projected table access on [s]\n" +
+			"                         └─ IndexedTableAccess(mytable on [mytable.s] with ranges: [{(first row, ∞)}, {(-∞, first row)}])\n" +
+			"",
+	},
+	{
+		Query: `select i, s from (select i, s, row_number() over (order by i desc) rn from mytable) t where 2 >= rn and i > 1 order by i`,
+		ExpectedPlan: "Sort(t.i ASC)\n" +
+			" └─ SubqueryAlias(t)\n" +
+			"     └─ Project(mytable.i, mytable.s)\n" +
+			"         └─ Filter((2 >= row_number() over ( order by [mytable.i, idx=0, type=BIGINT, nullable=false] DESC)) AND (mytable.i > 1))\n" +
+			"             └─ Window(Limit per partition: [2]; mytable.i, mytable.s, row_number() over ( order by [mytable.i, idx=0, type=BIGINT, nullable=false] DESC) [PartitionFramer(ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING)])\n" +
+			"                 └─ Projected table access on [i s]\n" +
+			"                     └─ Table(mytable)\n" +
+			"",
+	},
 	{
 		Query: `INSERT INTO mytable(i,s) SELECT t1.i, 'hello' FROM mytable t1 JOIN mytable t2 on t1.i = t2.i + 1 where t1.i = 2 and t2.i = 1`,
 		ExpectedPlan: "Insert(i, s)\n" +
@@ -293,13 +386,12 @@ var PlanTests = []QueryPlanTest{
 	{
 		Query: `SELECT mytable.i, selfjoin.i FROM mytable INNER JOIN mytable selfjoin ON mytable.i = selfjoin.i WHERE selfjoin.i IN (SELECT 1 FROM DUAL)`,
 		ExpectedPlan: "Project(mytable.i, selfjoin.i)\n" +
-			" └─ Filter(selfjoin.i IN (Project(1)\n" +
+			" └─ SemiJoin(selfjoin.i = 1)\n" +
+			"     ├─ IndexedJoin(mytable.i = selfjoin.i)\n" +
+			"     │   ├─ Table(mytable)\n" +
+			"     │   └─ TableAlias(selfjoin)\n" +
+			"     │       └─ IndexedTableAccess(mytable on [mytable.i])\n" +
 			"     └─ Table(dual)\n" +
-			"    ))\n" +
-			"     └─ IndexedJoin(mytable.i = selfjoin.i)\n" +
-			"         ├─ Table(mytable)\n" +
-			"         └─ TableAlias(selfjoin)\n" +
-			"             └─ IndexedTableAccess(mytable on [mytable.i])\n" +
 			"",
 	},
 	{
@@ -1875,7 +1967,7 @@ var PlanTests = []QueryPlanTest{
 		Query: `SELECT ROW_NUMBER() OVER (ORDER BY s2 ASC) idx, i2, s2 FROM othertable WHERE s2 <> 'second' ORDER BY i2 ASC`,
 		ExpectedPlan: "Sort(othertable.i2 ASC)\n" +
 			" └─ Project(row_number() over ( order by [othertable.s2, idx=0, type=TEXT, nullable=false] ASC) as idx, othertable.i2, othertable.s2)\n" +
-			"     └─ Window(row_number() over ( order by [othertable.s2, idx=0, type=TEXT, nullable=false] ASC), othertable.i2, othertable.s2)\n" +
+			"     └─ Window(row_number() over ( order by [othertable.s2, idx=0, type=TEXT, nullable=false] ASC) [PartitionFramer(ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING)], othertable.i2, othertable.s2)\n" +
 			"         └─ Filter(NOT((othertable.s2 = \"second\")))\n" +
 			"             └─ Projected table access on [i2 s2]\n" +
 			"                 └─ IndexedTableAccess(othertable on [othertable.s2] with ranges: [{(second, ∞)}, {(-∞, second)}])\n" +
@@ -1884,11 +1976,11 @@ var PlanTests = []QueryPlanTest{
 	{
 		Query: `SELECT * FROM (SELECT ROW_NUMBER() OVER (ORDER BY s2 ASC) idx, i2, s2 FROM othertable ORDER BY i2 ASC) a WHERE s2 <> 'second'`,
 		ExpectedPlan: "SubqueryAlias(a)\n" +
-			" └─ Filter(NOT((othertable.s2 = \"second\")))\n" +
-			"     └─ Sort(othertable.i2 ASC)\n" +
-			"         └─ Project(row_number() over ( order by [othertable.s2, idx=0, type=TEXT, nullable=false] ASC) as idx, othertable.i2, othertable.s2)\n" +
-			"             └─ Window(row_number() over ( order by [othertable.s2, idx=0, type=TEXT, nullable=false] ASC), othertable.i2, othertable.s2)\n" +
-			"                 └─ Projected table access on [s2 i2]\n" +
+			" └─ Sort(othertable.i2 ASC)\n" +
+			"     └─ Project(row_number() over ( order by [othertable.s2, idx=0, type=TEXT, nullable=false] ASC) as idx, othertable.i2, othertable.s2)\n" +
+			"         └─ Filter(NOT((othertable.s2 = \"second\")))\n" +
+			"             └─ Window(row_number() over ( order by [othertable.s2, idx=0, type=TEXT, nullable=false] ASC) [PartitionFramer(ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING)], othertable.i2, othertable.s2)\n" +
+			"                 └─ Projected table access on [i2 s2]\n" +
 			"                     └─ Table(othertable)\n" +
 			"",
 	},
@@ -1896,7 +1988,7 @@ var PlanTests = []QueryPlanTest{
 		Query: `SELECT ROW_NUMBER() OVER (ORDER BY s2 ASC) idx, i2, s2 FROM othertable WHERE i2 < 2 OR i2 > 2 ORDER BY i2 ASC`,
 		ExpectedPlan: "Sort(othertable.i2 ASC)\n" +
 			" └─ Project(row_number() over ( order by [othertable.s2, idx=0, type=TEXT, nullable=false] ASC) as idx, othertable.i2, othertable.s2)\n" +
-			"     └─ Window(row_number() over ( order by [othertable.s2, idx=0, type=TEXT, nullable=false] ASC), othertable.i2, othertable.s2)\n" +
+			"     └─ Window(row_number() over ( order by [othertable.s2, idx=0, type=TEXT, nullable=false] ASC) [PartitionFramer(ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING)], othertable.i2, othertable.s2)\n" +
 			"         └─ Projected table access on [i2 s2]\n" +
 			"             └─ IndexedTableAccess(othertable on [othertable.i2] with ranges: [{(-∞, 2)}, {(2, ∞)}])\n" +
 			"",
@@ -1904,10 +1996,10 @@ var PlanTests = []QueryPlanTest{
 	{
 		Query: `SELECT * FROM (SELECT ROW_NUMBER() OVER (ORDER BY s2 ASC) idx, i2, s2 FROM othertable ORDER BY i2 ASC) a WHERE i2 < 2 OR i2 > 2`,
 		ExpectedPlan: "SubqueryAlias(a)\n" +
-			" └─ Filter((othertable.i2 < 2) OR (othertable.i2 > 2))\n" +
-			"     └─ Sort(othertable.i2 ASC)\n" +
-			"         └─ Project(row_number() over ( order by [othertable.s2, idx=0, type=TEXT, nullable=false] ASC) as idx, othertable.i2, othertable.s2)\n" +
-			"             └─ Window(row_number() over ( order by [othertable.s2, idx=0, type=TEXT, nullable=false] ASC), othertable.i2, othertable.s2)\n" +
+			" └─ Sort(othertable.i2 ASC)\n" +
+			"     └─ Project(row_number() over ( order by [othertable.s2, idx=0, type=TEXT, nullable=false] ASC) as idx, othertable.i2, othertable.s2)\n" +
+			"         └─ Filter((othertable.i2 < 2) OR (othertable.i2 > 2))\n" +
+			"             └─ Window(row_number() over ( order by [othertable.s2, idx=0, type=TEXT, nullable=false] ASC) [PartitionFramer(ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING)], othertable.i2, othertable.s2)\n" +
 			"                 └─ Projected table access on [i2 s2]\n" +
 			"                     └─ Table(othertable)\n" +
 			"",
@@ -1915,7 +2007,7 @@ var PlanTests = []QueryPlanTest{
 	{
 		Query: `SELECT t, n, lag(t, 1, t+1) over (partition by n) FROM bigtable`,
 		ExpectedPlan: "Project(bigtable.t, bigtable.n, lag(bigtable.t, 1, (bigtable.t + 1)) over ( partition by bigtable.n) as lag(t, 1, t+1) over (partition by n))\n" +
-			" └─ Window(bigtable.t, bigtable.n, lag(bigtable.t, 1, (bigtable.t + 1)) over ( partition by bigtable.n))\n" +
+			" └─ Window(bigtable.t, bigtable.n, lag(bigtable.t, 1, (bigtable.t + 1)) over ( partition by bigtable.n) [PartitionFramer(ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING)])\n" +
 			"     └─ Projected table access on [t n]\n" +
 			"         └─ Table(bigtable)\n" +
 			"",
@@ -1923,7 +2015,7 @@ var PlanTests = []QueryPlanTest{
 	{
 		Query: `select i, row_number() over (w3) from mytable window w1 as (w2), w2 as (), w3 as (w1)`,
 		ExpectedPlan: "Project(mytable.i, row_number() over () as row_number() over (w3))\n" +
-			" └─ Window(mytable.i, row_number() over ())\n" +
+			" └─ Window(mytable.i, row_number() over () [PartitionFramer(ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING)])\n" +
 			"     └─ Projected table access on [i]\n" +
 			"         └─ Table(mytable)\n" +
 			"",
@@ -1931,7 +2023,7 @@ var PlanTests = []QueryPlanTest{
 	{
 		Query: `select i, row_number() over (w1 partition by s) from mytable window w1 as (order by i asc)`,
 		ExpectedPlan: "Project(mytable.i, row_number() over ( partition by mytable.s order by [mytable.i, idx=0, type=BIGINT, nullable=false] ASC) as row_number() over (w1 partition by s))\n" +
-			" └─ Window(mytable.i, row_number() over ( partition by mytable.s order by [mytable.i, idx=0, type=BIGINT, nullable=false] ASC))\n" +
+			" └─ Window(mytable.i, row_number() over ( partition by mytable.s order by [mytable.i, idx=0, type=BIGINT, nullable=false] ASC) [PartitionFramer(ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING)])\n" +
 			"     └─ Projected table access on [i s]\n" +
 			"         └─ Table(mytable)\n" +
 			"",
@@ -2033,6 +2125,28 @@ var PlanTests = []QueryPlanTest{
 			" └─ IndexedTableAccess(one_pk on [one_pk.pk] with ranges: [{[1, 1]}])\n" +
 			"",
 	},
+	{
+		Query: `SELECT * FROM mytable WHERE i NOT IN (SELECT i2 FROM othertable)`,
+		ExpectedPlan: "AntiJoin(mytable.i = othertable.i2)\n" +
+			" ├─ Table(mytable)\n" +
+			" └─ Projected table access on [i2]\n" +
+			"     └─ Table(othertable)\n" +
+			"",
+	},
+	{
+		Query: `SELECT * FROM mytable WHERE EXISTS (SELECT 1 FROM othertable WHERE othertable.i2 = mytable.i AND othertable.s2 <> 'first')`,
+		ExpectedPlan: "SemiJoin(othertable.i2 = mytable.i)\n" +
+			" ├─ Table(mytable)\n" +
+			" └─ Projected table access on [i2]\n" +
+			"     └─ IndexedTableAccess(othertable on [othertable.s2] with ranges: [{(-∞, first)}, {(first, ∞)}])\n" +
+			"",
+	},
+	{
+		Query: `SELECT i + (SELECT COUNT(*) FROM othertable) FROM mytable`,
+		ExpectedPlan: "Project((mytable.i + 3) as i + (SELECT COUNT(*) FROM othertable))\n" +
+			" └─ Table(mytable)\n" +
+			"",
+	},
 }
 
 var ScriptQueryPlanTest = []ScriptTest{}
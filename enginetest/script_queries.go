@@ -15,6 +15,8 @@
 package enginetest
 
 import (
+	"time"
+
 	"gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/go-mysql-server/sql/analyzer"
@@ -67,6 +69,392 @@ type ScriptTestAssertion struct {
 // Unlike other engine tests, ScriptTests must be self-contained. No other tables are created outside the definition of
 // the tests.
 var ScriptTests = []ScriptTest{
+	{
+		Name: "LATERAL derived tables read the columns of the tables before them",
+		SetUpScript: []string{
+			"create table a (x int primary key, y int)",
+			"create table b (x int, z int)",
+			"insert into a values (1, 10), (2, 20), (3, 30)",
+			"insert into b values (1, 100), (1, 101), (2, 200), (4, 400)",
+			"create view v as select a.x, t.z from a join lateral (select b.z from b where b.x = a.x) t",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select a.x, t.z from a join lateral (select b.z from b where b.x = a.x) t order by 1, 2",
+				Expected: []sql.Row{{1, 100}, {1, 101}, {2, 200}},
+			},
+			{
+				Query:    "select a.x, t.z from a, lateral (select b.z from b where b.x = a.x) as t order by 1, 2",
+				Expected: []sql.Row{{1, 100}, {1, 101}, {2, 200}},
+			},
+			{
+				Query:    "select a.x, t.z from a left join lateral (select b.z from b where b.x = a.x) t on true order by 1, 2",
+				Expected: []sql.Row{{1, 100}, {1, 101}, {2, 200}, {3, nil}},
+			},
+			{
+				Query:    "select a.x, t.c from a cross join lateral (select count(*) as c from b where b.x <= a.x) t order by 1",
+				Expected: []sql.Row{{1, 2}, {2, 3}, {3, 3}},
+			},
+			{
+				Query:    "select a.x, t.z, u.w from a join lateral (select b.z from b where b.x = a.x) t join lateral (select t.z + a.y as w) u order by 1, 2",
+				Expected: []sql.Row{{1, 100, 110}, {1, 101, 111}, {2, 200, 220}},
+			},
+			{
+				Query:    "select a.x, t.z from a join (select x, z from b) s on s.x = a.x join lateral (select s.z + 1 as z) t order by 1, 2",
+				Expected: []sql.Row{{1, 101}, {1, 102}, {2, 201}},
+			},
+			{
+				Query:    "select o.x, (select max(t.z) from a join lateral (select a.y + o.x as z) t) from a o order by 1",
+				Expected: []sql.Row{{1, 31}, {2, 32}, {3, 33}},
+			},
+			{
+				Query:    "select * from v order by 1, 2",
+				Expected: []sql.Row{{1, 100}, {1, 101}, {2, 200}},
+			},
+			{
+				Query:    "select * from (select 1 as lateral) lateral",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:       "select a.x, t.z from a join (select b.z from b where b.x = a.x) t",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+		},
+	},
+	{
+		Name: "cached subquery results spilled to disk",
+		SetUpScript: []string{
+			"create table a (pk int primary key, x int)",
+			"create table b (pk int primary key, x int, s varchar(100))",
+			"insert into a with recursive c(n) as (select 1 union all select n + 1 from c where n < 100) select n, n % 10 from c",
+			"insert into b with recursive c(n) as (select 1 union all select n + 1 from c where n < 100) select n, n % 10, repeat('x', 50) from c",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select count(*), sum(a.pk), sum(length(sub.s)) from a left join (select b.x, b.s from b where b.pk > 0) sub on sub.x = a.x",
+				Expected: []sql.Row{{int64(1000), float64(50500), float64(50000)}},
+			},
+			{
+				Query:    "set tmp_table_size = 1024",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "select count(*), sum(a.pk), sum(length(sub.s)) from a left join (select b.x, b.s from b where b.pk > 0) sub on sub.x = a.x",
+				Expected: []sql.Row{{int64(1000), float64(50500), float64(50000)}},
+			},
+		},
+	},
+	{
+		Name: "MIN, MAX and GROUP_CONCAT order by use the collation of strings",
+		SetUpScript: []string{
+			"create table t (pk int primary key, ci varchar(20) collate utf8mb4_0900_ai_ci, cs varchar(20) collate utf8mb4_0900_bin)",
+			"insert into t values (1, 'a', 'a'), (2, 'B', 'B'), (3, 'c', 'c'), (4, 'D', 'D')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select min(ci), max(ci), min(cs), max(cs) from t",
+				Expected: []sql.Row{{"a", "D", "B", "c"}},
+			},
+			{
+				Query:    "select group_concat(ci order by ci), group_concat(cs order by cs) from t",
+				Expected: []sql.Row{{"a,B,c,D", "B,D,a,c"}},
+			},
+			{
+				Query:    "select min(cs collate utf8mb4_0900_ai_ci), max(ci collate utf8mb4_0900_bin), group_concat(cs order by cs collate utf8mb4_0900_ai_ci desc) from t",
+				Expected: []sql.Row{{"a", "c", "D,c,B,a"}},
+			},
+			{
+				Query:       "select 'a' collate utf8mb4_unknown_ci",
+				ExpectedErr: sql.ErrCollationNotSupported,
+			},
+		},
+	},
+	{
+		Name: "top-N per group filters window results in a subquery",
+		SetUpScript: []string{
+			"create table sales (id int primary key, region varchar(10), amount int)",
+			"insert into sales values (1, 'east', 10), (2, 'east', 30), (3, 'east', 20), (4, 'west', 5), (5, 'west', 50), (6, 'north', 7)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select id, region, amount from (select id, region, amount, row_number() over (partition by region order by amount desc) rn from sales) t where rn = 1 order by id",
+				Expected: []sql.Row{{2, "east", 30}, {5, "west", 50}, {6, "north", 7}},
+			},
+			{
+				Query:    "select id, region, amount, rn from (select id, region, amount, row_number() over (partition by region order by amount desc) rn from sales) t where rn <= 2 order by region, rn",
+				Expected: []sql.Row{{2, "east", 30, 1}, {3, "east", 20, 2}, {6, "north", 7, 1}, {5, "west", 50, 1}, {4, "west", 5, 2}},
+			},
+			{
+				Query:    "select region, amount from (select region, amount, row_number() over (partition by region order by amount desc) rn from sales) t where rn = 1 and amount > 10 order by region",
+				Expected: []sql.Row{{"east", 30}, {"west", 50}},
+			},
+		},
+	},
+	{
+		Name: "row number filters keep the same rows with and without a partition limit",
+		SetUpScript: []string{
+			"create table scores (id int primary key, team varchar(10), score int)",
+			"insert into scores values (1, 'red', 10), (2, 'red', 30), (3, 'red', 30), (4, 'red', 20), (5, 'blue', NULL), (6, 'blue', 50), (7, NULL, 7), (8, NULL, 8), (9, 'blue', 5)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select id, team, rn from (select id, team, row_number() over (partition by team order by score desc, id) rn from scores) t where rn <= 2 order by id",
+				Expected: []sql.Row{{2, "red", 1}, {3, "red", 2}, {6, "blue", 1}, {7, nil, 2}, {8, nil, 1}, {9, "blue", 2}},
+			},
+			{
+				// another window function over the same rows prevents the partition limit
+				Query:    "select id, team, rn from (select id, team, row_number() over (partition by team order by score desc, id) rn, count(*) over () c from scores) t where rn <= 2 order by id",
+				Expected: []sql.Row{{2, "red", 1}, {3, "red", 2}, {6, "blue", 1}, {7, nil, 2}, {8, nil, 1}, {9, "blue", 2}},
+			},
+			{
+				Query:    "select id, team, rn from (select id, team, row_number() over (partition by team order by score) rn from scores) t where rn < 2 order by id",
+				Expected: []sql.Row{{1, "red", 1}, {5, "blue", 1}, {7, nil, 1}},
+			},
+			{
+				Query:    "select id, team, rn from (select id, team, row_number() over (partition by team order by score) rn, count(*) over () c from scores) t where rn < 2 order by id",
+				Expected: []sql.Row{{1, "red", 1}, {5, "blue", 1}, {7, nil, 1}},
+			},
+			{
+				Query:    "select id from (select id, row_number() over (order by score desc, id) rn from scores) t where rn = 3",
+				Expected: []sql.Row{{3}},
+			},
+		},
+	},
+	{
+		Name: "FROM_UNIXTIME and UNIX_TIMESTAMP use the session time zone",
+		SetUpScript: []string{
+			"SET time_zone = '-06:00'",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT FROM_UNIXTIME(1447430881), FROM_UNIXTIME(1447430881, '%Y %D %M %h:%i:%s %x')",
+				Expected: []sql.Row{{time.Date(2015, 11, 13, 10, 8, 1, 0, time.UTC), "2015 13th November 10:08:01 2015"}},
+			},
+			{
+				Query:    "SELECT UNIX_TIMESTAMP('2015-11-13 10:08:01'), UNIX_TIMESTAMP(FROM_UNIXTIME(1447430881))",
+				Expected: []sql.Row{{float64(1447430881), float64(1447430881)}},
+			},
+			{
+				Query:    "SET time_zone = 'SYSTEM'",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "SELECT FROM_UNIXTIME(1447430881), UNIX_TIMESTAMP('2015-11-13 16:08:01')",
+				Expected: []sql.Row{{time.Date(2015, 11, 13, 16, 8, 1, 0, time.UTC), float64(1447430881)}},
+			},
+		},
+	},
+	{
+		Name: "WEEK uses default_week_format without a mode",
+		SetUpScript: []string{
+			"SET default_week_format = 3",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT WEEK('2021-01-01'), WEEK('2021-01-01', 0), YEARWEEK('2021-01-01')",
+				Expected: []sql.Row{{int32(53), int32(0), int32(202052)}},
+			},
+		},
+	},
+	{
+		Name: "recursive cte respects cte_max_recursion_depth",
+		SetUpScript: []string{
+			"set @@cte_max_recursion_depth = 5",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:       "with recursive t (n) as (select (1) from dual union all select n + 1 from t where n < 10) select n from t order by n",
+				ExpectedErr: sql.ErrCteRecursionLimitExceeded,
+			},
+			{
+				Query:    "with recursive t (n) as (select (1) from dual union all select n + 1 from t where n < 5) select n from t order by n",
+				Expected: []sql.Row{{1}, {2}, {3}, {4}, {5}},
+			},
+			{
+				Query:    "set @@cte_max_recursion_depth = 10",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "with recursive t (n) as (select (1) from dual union all select n + 1 from t where n < 10) select n from t order by n",
+				Expected: []sql.Row{{1}, {2}, {3}, {4}, {5}, {6}, {7}, {8}, {9}, {10}},
+			},
+		},
+	},
+	{
+		Name: "non-deterministic cte returns the same rows at every reference",
+		SetUpScript: []string{
+			"create table a (i int primary key)",
+			"insert into a values (1), (2), (3)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "with t as (select rand() r) select t1.r = t2.r from t t1 join t t2",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "with t as (select i, rand() r from a) select count(*) from t t1 join t t2 on t1.i = t2.i where t1.r = t2.r",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "with t as (select i from a) select t1.i, t2.i from t t1 join t t2 on t1.i = t2.i order by 1",
+				Expected: []sql.Row{{1, 1}, {2, 2}, {3, 3}},
+			},
+		},
+	},
+	{
+		Name: "references to the same cte read different columns and filters",
+		SetUpScript: []string{
+			"create table b (i int primary key, j int, k int, l int, m int)",
+			"insert into b values (1, 10, 100, 1000, 10000), (2, 20, 200, 2000, 20000)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "with t as (select i, j, k, l, m from b) select t1.j, t2.k from t t1 join t t2 on t1.i = t2.i order by 1",
+				Expected: []sql.Row{{10, 100}, {20, 200}},
+			},
+			{
+				Query:    "with t as (select i, j, k, l, m from b) select t1.j, t2.m from t t1, t t2 where t1.i = 1 and t2.i = 2",
+				Expected: []sql.Row{{10, 20000}},
+			},
+			{
+				Query:    "with t as (select i, j, k, l, m, rand() r from b) select t1.j, t2.k, t1.r = t2.r from t t1 join t t2 on t1.i = t2.i order by 1",
+				Expected: []sql.Row{{10, 100, true}, {20, 200, true}},
+			},
+			{
+				Query:    "with t as (select i, j, k, l, m, rand() r from b) select t1.m, t2.k from t t1, t t2 where t1.i = 1 and t2.i = 2",
+				Expected: []sql.Row{{10000, 200}},
+			},
+			{
+				Query:    "with t as (select i, j, k, l, m, rand() r from b) select t1.m from t t1 where t1.i in (select i from t where k = 200)",
+				Expected: []sql.Row{{20000}},
+			},
+			{
+				Query:    "with t as (select i, j, k, l, m, rand() r from b), u as (select i, r, m from t where j = 10) select u.m, t.k, t.r = u.r from t join u on t.i = u.i",
+				Expected: []sql.Row{{10000, 100, true}},
+			},
+		},
+	},
+	{
+		Name: "non-deterministic subquery alias returns the same rows at every join iteration",
+		SetUpScript: []string{
+			"create table a (i int primary key)",
+			"insert into a values (1), (2), (3)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select count(*), count(distinct s.r) from a t join (select i, rand() r from a) s on t.i <= s.i or t.i > s.i",
+				Expected: []sql.Row{{9, 3}},
+			},
+			{
+				Query:    "select s.i, count(*), count(distinct s.r) from a t join (select i, rand() r from a) s on t.i <= s.i or t.i > s.i group by s.i order by s.i",
+				Expected: []sql.Row{{1, 3, 1}, {2, 3, 1}, {3, 3, 1}},
+			},
+			{
+				Query:    "select count(distinct s.r) from a t left join (select i, rand() r from a) s on t.i <> s.i",
+				Expected: []sql.Row{{3}},
+			},
+		},
+	},
+	{
+		Name: "correlated exists subqueries rewritten as semi joins",
+		SetUpScript: []string{
+			"create table a (x int primary key, y int)",
+			"create table b (z int primary key, y int, w varchar(10))",
+			"insert into a values (1, 1), (2, 2), (3, null), (4, 4)",
+			"insert into b values (1, 1, 'one'), (2, 1, 'uno'), (3, 2, 'two'), (4, null, 'null')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select x from a where exists (select 1 from b where b.y = a.y) order by x",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "select x from a where (select count(*) from b where b.y = a.y) > 0 order by x",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "select x from a where exists (select 1 from b where b.y = a.y and b.w <> 'one') and x > 1 order by x",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "select x from a where (select count(*) from b where b.y = a.y and b.w <> 'one') > 0 and x > 1 order by x",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "select x from a where exists (select 1 from b where b.y = a.y and b.z = a.x) order by x",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select x from a where exists (select 1 from b where b.y = a.y + 1) order by x",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "update a set y = y + 10 where exists (select 1 from b where b.y = a.y)",
+				Expected: []sql.Row{{newUpdateResult(2, 2)}},
+			},
+			{
+				Query:    "select * from a order by x",
+				Expected: []sql.Row{{1, 11}, {2, 12}, {3, nil}, {4, 4}},
+			},
+			{
+				Query:    "delete from a where exists (select 1 from b where b.y = a.y - 10)",
+				Expected: []sql.Row{{sql.NewOkResult(2)}},
+			},
+			{
+				Query:    "select * from a order by x",
+				Expected: []sql.Row{{3, nil}, {4, 4}},
+			},
+		},
+	},
+	{
+		Name: "in and not in subqueries rewritten as semi and anti joins",
+		SetUpScript: []string{
+			"create table a (x int primary key, y int)",
+			"create table b (z int primary key, y int)",
+			"create table c (z int primary key, y int)",
+			"insert into a values (1, 1), (2, 2), (3, null), (4, 4)",
+			"insert into b values (1, 1), (2, 2), (3, 5)",
+			"insert into c values (1, 1), (2, null)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select x from a where y in (select y from b) order by x",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "select x from a where y not in (select y from b) order by x",
+				Expected: []sql.Row{{4}},
+			},
+			{
+				Query:    "select x from a where y not in (select y from c) order by x",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select x from a where y not in (select y from c where z > 5) order by x",
+				Expected: []sql.Row{{1}, {2}, {3}, {4}},
+			},
+			{
+				Query:    "select x from a where y in (select y from b where b.z = a.x) order by x",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "select x from a where y not in (select y from c where c.z = a.x) order by x",
+				Expected: []sql.Row{{3}, {4}},
+			},
+			{
+				Query:    "update a set y = y + 10 where y in (select y from b)",
+				Expected: []sql.Row{{newUpdateResult(2, 2)}},
+			},
+			{
+				Query:    "delete from a where y not in (select y + 10 from b)",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "select * from a order by x",
+				Expected: []sql.Row{{1, 11}, {2, 12}, {3, nil}},
+			},
+		},
+	},
 	{
 		Name: "failed statements data validation for INSERT, UPDATE",
 		SetUpScript: []string{
@@ -152,6 +540,39 @@ var ScriptTests = []ScriptTest{
 			{2},
 		},
 	},
+	{
+		Name: "DISTINCT in aggregate function arguments",
+		SetUpScript: []string{
+			"CREATE TABLE t (id int primary key, g int, x int)",
+			"INSERT INTO t VALUES (1,1,1), (2,1,1), (3,1,2), (4,2,3), (5,2,3), (6,2,NULL), (7,3,4)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT COUNT(x), COUNT(DISTINCT x) FROM t",
+				Expected: []sql.Row{{6, 4}},
+			},
+			{
+				Query:    "SELECT g, COUNT(x), COUNT(DISTINCT x) FROM t GROUP BY g ORDER BY g",
+				Expected: []sql.Row{{1, 3, 2}, {2, 2, 1}, {3, 1, 1}},
+			},
+			{
+				Query:    "SELECT SUM(x), SUM(DISTINCT x) FROM t",
+				Expected: []sql.Row{{float64(14), float64(10)}},
+			},
+			{
+				Query:    "SELECT g, SUM(DISTINCT x) FROM t GROUP BY g ORDER BY g",
+				Expected: []sql.Row{{1, float64(3)}, {2, float64(3)}, {3, float64(4)}},
+			},
+			{
+				Query:    "SELECT GROUP_CONCAT(x ORDER BY x), GROUP_CONCAT(DISTINCT x ORDER BY x) FROM t",
+				Expected: []sql.Row{{"1,1,2,3,3,4", "1,2,3,4"}},
+			},
+			{
+				Query:    "SELECT g, COUNT(x), COUNT(DISTINCT x) FROM t GROUP BY g HAVING COUNT(DISTINCT x) > 1",
+				Expected: []sql.Row{{1, 3, 2}},
+			},
+		},
+	},
 	{
 		Name: "sqllogictest index/commute/10/slt_good_1.test",
 		SetUpScript: []string{
@@ -1912,6 +2333,126 @@ var ScriptTests = []ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "ST_COLLECT aggregates grouped geometries",
+		SetUpScript: []string{
+			"create table places (pk int primary key, grp int, p point)",
+			// the order of the geometries collected from several rows isn't defined, so the rows of a group share a point
+			"insert into places values (1, 1, 'POINT(1 2)'), (2, 1, 'POINT(1 2)'), (3, 2, 'POINT(5 6)'), (4, 2, NULL), (5, 3, NULL)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "select grp, st_collect(p) from places group by grp order by grp",
+				Expected: []sql.Row{
+					{1, sql.MultiPoint{Points: []sql.Point{{X: 1, Y: 2}, {X: 1, Y: 2}}}},
+					{2, sql.MultiPoint{Points: []sql.Point{{X: 5, Y: 6}}}},
+					{3, nil},
+				},
+			},
+			{
+				Query: "select grp, st_aswkt(st_collect(p)) from places group by grp having grp < 3 order by grp",
+				Expected: []sql.Row{
+					{1, "MULTIPOINT((1 2),(1 2))"},
+					{2, "MULTIPOINT((5 6))"},
+				},
+			},
+		},
+	},
+	{
+		Name: "spatial indexes are used by ST_CONTAINS and ST_WITHIN",
+		SetUpScript: []string{
+			"create table places (pk int primary key, p point not null, spatial index p_idx (p))",
+			"insert into places values (1, 'POINT(1 1)'), (2, 'POINT(3 3)'), (3, 'POINT(5 5)'), (4, 'POINT(2 8)'), (5, 'POINT(9 9)')",
+			"create table areas (pk int primary key, g geometry not null)",
+			"create spatial index g_idx on areas (g)",
+			"insert into areas values (1, ST_GEOMFROMTEXT('POLYGON((0 0,4 0,4 4,0 4,0 0))')), (2, ST_GEOMFROMTEXT('POLYGON((4 4,10 4,10 10,4 10,4 4))')), (3, ST_GEOMFROMTEXT('LINESTRING(0 9,3 9)'))",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "explain select pk from places where st_contains(st_geomfromtext('POLYGON((0 0,6 0,6 6,0 6,0 0))'), p)",
+				Expected: []sql.Row{
+					{"Project(places.pk)"},
+					{" └─ FilterST_CONTAINS({0 [{0 [{0 0 0} {0 6 0} {0 6 6} {0 0 6} {0 0 0}]}]},places.p)"},
+					{"     └─ Projected table access on [pk p]"},
+					{"         └─ IndexedTableAccess(places on [places.p] with envelope: [(0, 0), (6, 6)])"},
+				},
+			},
+			{
+				Query:    "select pk from places where st_contains(st_geomfromtext('POLYGON((0 0,6 0,6 6,0 6,0 0))'), p) order by pk",
+				Expected: []sql.Row{{1}, {2}, {3}},
+			},
+			{
+				Query:    "select pk from places where st_within(p, st_geomfromtext('POLYGON((0 0,10 0,10 10,0 10,0 0))')) order by pk",
+				Expected: []sql.Row{{1}, {2}, {3}, {4}, {5}},
+			},
+			{
+				Query:    "select pk from areas where st_contains(g, point(2, 2)) order by pk",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select pk from areas where st_within(st_geomfromtext('LINESTRING(5 5,9 9)'), g) or st_contains(g, point(1, 9)) order by pk",
+				Expected: []sql.Row{{2}, {3}},
+			},
+			{
+				Query: "show create table places",
+				Expected: []sql.Row{{"places", "CREATE TABLE `places` (\n" +
+					"  `pk` int NOT NULL,\n" +
+					"  `p` point NOT NULL,\n" +
+					"  PRIMARY KEY (`pk`),\n" +
+					"  SPATIAL KEY `p_idx` (`p`)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+			{
+				Query:       "create spatial index pk_idx on places (pk)",
+				ExpectedErr: plan.ErrSpatialIndexNotGeometry,
+			},
+			{
+				Query:       "create table nullable_places (pk int primary key, p point, spatial index (p))",
+				ExpectedErr: plan.ErrSpatialIndexNullable,
+			},
+			{
+				Query:       "create spatial index pk_p_idx on places (pk, p)",
+				ExpectedErr: plan.ErrSpatialIndexColumns,
+			},
+		},
+	},
+	{
+		Name: "explain shows the frames of window functions",
+		SetUpScript: []string{
+			"create table w (pk int primary key, x int)",
+			"insert into w values (1, 1), (2, 2), (3, 4)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "explain select pk, sum(x) over (order by pk rows between 1 preceding and current row) from w",
+				Expected: []sql.Row{
+					{"Window(w.pk, SUM(w.x) [RowFramer(ROWS BETWEEN 1 PRECEDING AND CURRENT ROW)])"},
+					{" └─ Projected table access on [pk x]"},
+					{"     └─ Table(w)"},
+				},
+			},
+			{
+				Query: "explain select pk, sum(x) over (order by pk) from w",
+				Expected: []sql.Row{
+					{"Window(w.pk, SUM(w.x) [RangeFramer(RANGE BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW)])"},
+					{" └─ Projected table access on [pk x]"},
+					{"     └─ Table(w)"},
+				},
+			},
+			{
+				Query: "explain select pk, sum(x) over () from w",
+				Expected: []sql.Row{
+					{"Window(w.pk, SUM(w.x) [PartitionFramer(ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING)])"},
+					{" └─ Projected table access on [pk x]"},
+					{"     └─ Table(w)"},
+				},
+			},
+			{
+				Query:    "select pk, sum(x) over (order by pk rows between 1 preceding and current row) from w order by pk",
+				Expected: []sql.Row{{1, float64(1)}, {2, float64(3)}, {3, float64(6)}},
+			},
+		},
+	},
 }
 
 var CreateCheckConstraintsScripts = []ScriptTest{
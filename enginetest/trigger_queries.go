@@ -960,6 +960,141 @@ var TriggerTests = []ScriptTest{
 			},
 		},
 	},
+	// Statement triggers
+	{
+		Name: "row and statement triggers on insert",
+		SetUpScript: []string{
+			"create table a (x int primary key)",
+			"create table counts (name varchar(20) primary key, n int)",
+			"insert into counts values ('before row', 0), ('after row', 0), ('before statement', 0), ('after statement', 0)",
+			"create trigger br before insert on a for each row update counts set n = n + 1 where name = 'before row'",
+			"create trigger ar after insert on a for each row update counts set n = n + 1 where name = 'after row'",
+			"create trigger bs before insert on a for each statement update counts set n = n + 1 where name = 'before statement'",
+			"create trigger as1 after insert on a for each statement update counts set n = n + 1 where name = 'after statement'",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "insert into a values (1), (2), (3)",
+				Expected: []sql.Row{
+					{sql.NewOkResult(3)},
+				},
+			},
+			{
+				Query: "select * from counts order by 1",
+				Expected: []sql.Row{
+					{"after row", 3}, {"after statement", 1}, {"before row", 3}, {"before statement", 1},
+				},
+			},
+			{
+				Query: "insert into a values (4), (5)",
+				Expected: []sql.Row{
+					{sql.NewOkResult(2)},
+				},
+			},
+			{
+				Query: "select * from counts order by 1",
+				Expected: []sql.Row{
+					{"after row", 5}, {"after statement", 2}, {"before row", 5}, {"before statement", 2},
+				},
+			},
+			{
+				Query: "select trigger_name, action_orientation from information_schema.triggers order by 1",
+				Expected: []sql.Row{
+					{"ar", "ROW"}, {"as1", "STATEMENT"}, {"br", "ROW"}, {"bs", "STATEMENT"},
+				},
+			},
+		},
+	},
+	{
+		Name: "row and statement triggers on update and delete",
+		SetUpScript: []string{
+			"create table a (x int primary key, y int)",
+			"create table log (msg varchar(20))",
+			"insert into a values (1, 0), (2, 0), (3, 0), (4, 0)",
+			"create trigger ur after update on a for each row insert into log values ('update row')",
+			"create trigger us after update on a for each statement insert into log values ('update statement')",
+			"create trigger dr before delete on a for each row insert into log values ('delete row')",
+			"create trigger ds before delete on a for each statement insert into log values ('delete statement')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "update a set y = 1 where x > 1",
+				Expected: []sql.Row{
+					{newUpdateResult(3, 3)},
+				},
+			},
+			{
+				Query: "select msg, count(*) from log group by msg order by 1",
+				Expected: []sql.Row{
+					{"update row", 3}, {"update statement", 1},
+				},
+			},
+			{
+				Query: "delete from a where x < 3",
+				Expected: []sql.Row{
+					{sql.NewOkResult(2)},
+				},
+			},
+			{
+				Query: "select msg, count(*) from log group by msg order by 1",
+				Expected: []sql.Row{
+					{"delete row", 2}, {"delete statement", 1}, {"update row", 3}, {"update statement", 1},
+				},
+			},
+			{
+				Query: "delete from a where x > 10",
+				Expected: []sql.Row{
+					{sql.NewOkResult(0)},
+				},
+			},
+			{
+				Query: "select msg, count(*) from log group by msg order by 1",
+				Expected: []sql.Row{
+					{"delete row", 2}, {"delete statement", 2}, {"update row", 3}, {"update statement", 1},
+				},
+			},
+		},
+	},
+	// OLD and NEW references
+	{
+		Name: "old and new rows available to each trigger event",
+		SetUpScript: []string{
+			"create table a (x int primary key, y int)",
+			"create table log (event varchar(10), old_x int, old_y int, new_x int, new_y int)",
+			"create trigger ins after insert on a for each row insert into log values ('insert', null, null, new.x, new.y)",
+			"create trigger upd after update on a for each row insert into log values ('update', old.x, old.y, new.x, new.y)",
+			"create trigger del after delete on a for each row insert into log values ('delete', old.x, old.y, null, null)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "insert into a values (1, 10), (2, 20)",
+				Expected: []sql.Row{
+					{sql.NewOkResult(2)},
+				},
+			},
+			{
+				Query: "update a set y = y + 1 where x = 2",
+				Expected: []sql.Row{
+					{newUpdateResult(1, 1)},
+				},
+			},
+			{
+				Query: "delete from a where x = 1",
+				Expected: []sql.Row{
+					{sql.NewOkResult(1)},
+				},
+			},
+			{
+				Query: "select * from log order by 1, 2, 4",
+				Expected: []sql.Row{
+					{"delete", 1, 10, nil, nil},
+					{"insert", nil, nil, 1, 10},
+					{"insert", nil, nil, 2, 20},
+					{"update", 2, 20, 2, 21},
+				},
+			},
+		},
+	},
 	// Multiple triggers defined
 	{
 		Name: "triggers before and after insert",
@@ -1359,6 +1494,156 @@ end;`,
 			},
 		},
 	},
+	{
+		Name: "trigger before insert signals on invalid data",
+		SetUpScript: []string{
+			"create table a (x int primary key, y int)",
+			`create trigger check_y before insert on a for each row
+begin
+	if new.y < 0 then
+		signal sqlstate '45000' set message_text = 'y must not be negative';
+	end if;
+end;`,
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "insert into a values (1, 1)",
+				Expected: []sql.Row{
+					{sql.NewOkResult(1)},
+				},
+			},
+			{
+				Query:          "insert into a values (2, 2), (3, -3)",
+				ExpectedErrStr: "y must not be negative (errno 1644) (sqlstate 45000)",
+			},
+			{
+				Query: "select * from a order by 1",
+				Expected: []sql.Row{
+					{1, 1},
+				},
+			},
+		},
+	},
+	{
+		Name: "trigger with resignal outside of a handler",
+		SetUpScript: []string{
+			"create table a (x int primary key)",
+			`create trigger trig_with_resignal before insert on a for each row
+begin
+	declare cond_name condition for sqlstate '45000';
+	if new.x = 5 then resignal cond_name set message_text = 'trig err';
+	end if;
+end;`,
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "insert into a values (1)",
+				Expected: []sql.Row{
+					{sql.NewOkResult(1)},
+				},
+			},
+			{
+				Query:          "insert into a values (5)",
+				ExpectedErrStr: "RESIGNAL when handler not active (errno 1645) (sqlstate 0K000)",
+			},
+		},
+	},
+	// CREATE TRIGGER IF NOT EXISTS
+	{
+		Name: "create trigger with the name of an existing trigger",
+		SetUpScript: []string{
+			"create table a (x int primary key)",
+			"create table b (y int primary key)",
+			"create trigger trig before insert on a for each row insert into b values (new.x)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:       "create trigger trig before insert on a for each row insert into b values (new.x * 2)",
+				ExpectedErr: sql.ErrTriggerAlreadyExists,
+			},
+			{
+				Query: "create trigger if not exists trig before insert on a for each row insert into b values (new.x * 2)",
+				Expected: []sql.Row{
+					{sql.NewOkResult(0)},
+				},
+				ExpectedWarning:                 1359,
+				ExpectedWarningsCount:           1,
+				ExpectedWarningMessageSubstring: "Trigger already exists",
+			},
+			{
+				Query:       "create trigger TRIG after insert on a for each row insert into b values (new.x * 2)",
+				ExpectedErr: sql.ErrTriggerAlreadyExists,
+			},
+			{
+				Query: "create trigger if not exists Trig before insert on a for each row insert into b values (new.x * 2)",
+				Expected: []sql.Row{
+					{sql.NewOkResult(0)},
+				},
+				ExpectedWarning:                 1359,
+				ExpectedWarningsCount:           1,
+				ExpectedWarningMessageSubstring: "Trigger already exists",
+			},
+			{
+				Query: "create trigger if not exists trig2 after insert on a for each row insert into b values (new.x * 3)",
+				Expected: []sql.Row{
+					{sql.NewOkResult(0)},
+				},
+			},
+			{
+				Query: "insert into a values (1)",
+				Expected: []sql.Row{
+					{sql.NewOkResult(1)},
+				},
+			},
+			{
+				Query: "select y from b order by 1",
+				Expected: []sql.Row{
+					{1}, {3},
+				},
+			},
+		},
+	},
+	// Trigger sql_mode
+	{
+		Name: "trigger body runs under the sql_mode it was created with",
+		SetUpScript: []string{
+			"create table a (x int primary key)",
+			"create table b (m varchar(200) primary key)",
+			"set sql_mode = 'NO_ZERO_DATE'",
+			"create trigger trig before insert on a for each row insert into b values (@@sql_mode)",
+			"set sql_mode = 'STRICT_TRANS_TABLES'",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "insert into a values (1)",
+				Expected: []sql.Row{
+					{sql.NewOkResult(1)},
+				},
+			},
+			{
+				Query: "select m from b",
+				Expected: []sql.Row{
+					{"NO_ZERO_DATE"},
+				},
+			},
+			{
+				Query: "select @@sql_mode",
+				Expected: []sql.Row{
+					{"STRICT_TRANS_TABLES"},
+				},
+			},
+			{
+				Query: "select trigger_name, sql_mode from information_schema.triggers",
+				Expected: []sql.Row{
+					{"trig", "NO_ZERO_DATE"},
+				},
+			},
+			{
+				Query:    "set sql_mode = default",
+				Expected: []sql.Row{{}},
+			},
+		},
+	},
 	// SHOW CREATE TRIGGER scripts
 	{
 		Name: "show create triggers",
@@ -1374,7 +1659,7 @@ end;`,
 				Expected: []sql.Row{
 					{
 						"a1", // Trigger
-						"",   // sql_mode
+						"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES",                              // sql_mode
 						"create trigger a1 before insert on a for each row set new.x = new.x + 1", // SQL Original Statement
 						sql.Collation_Default.CharacterSet().String(),                             // character_set_client
 						sql.Collation_Default.String(),                                            // collation_connection
@@ -1388,7 +1673,7 @@ end;`,
 				Expected: []sql.Row{
 					{
 						"b1", // Trigger
-						"",   // sql_mode
+						"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES",                              // sql_mode
 						"create trigger b1 before insert on b for each row set new.y = new.y + 2", // SQL Original Statement
 						sql.Collation_Default.CharacterSet().String(),                             // character_set_client
 						sql.Collation_Default.String(),                                            // collation_connection
@@ -1425,8 +1710,8 @@ end;`,
 						"set new.x = new.x + 1", // Statement
 						"BEFORE",                // Timing
 						time.Unix(0, 0).UTC(),   // Created
-						"",                      // sql_mode
-						"",                      // Definer
+						"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES", // sql_mode
+						"root@localhost", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1438,8 +1723,8 @@ end;`,
 						"set new.x = new.x + 2", // Statement
 						"BEFORE",                // Timing
 						time.Unix(0, 0).UTC(),   // Created
-						"",                      // sql_mode
-						"",                      // Definer
+						"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES", // sql_mode
+						"root@localhost", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1451,8 +1736,8 @@ end;`,
 						"insert into abb values (new.y)", // Statement
 						"AFTER",                          // Timing
 						time.Unix(0, 0).UTC(),            // Created
-						"",                               // sql_mode
-						"",                               // Definer
+						"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES", // sql_mode
+						"root@localhost", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1464,8 +1749,8 @@ end;`,
 						"set new.y = old.y + 2", // Statement
 						"BEFORE",                // Timing
 						time.Unix(0, 0).UTC(),   // Created
-						"",                      // sql_mode
-						"",                      // Definer
+						"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES", // sql_mode
+						"root@localhost", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1482,8 +1767,8 @@ end;`,
 						"set new.x = new.x + 1", // Statement
 						"BEFORE",                // Timing
 						time.Unix(0, 0).UTC(),   // Created
-						"",                      // sql_mode
-						"",                      // Definer
+						"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES", // sql_mode
+						"root@localhost", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1495,8 +1780,8 @@ end;`,
 						"set new.x = new.x + 2", // Statement
 						"BEFORE",                // Timing
 						time.Unix(0, 0).UTC(),   // Created
-						"",                      // sql_mode
-						"",                      // Definer
+						"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES", // sql_mode
+						"root@localhost", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1508,8 +1793,8 @@ end;`,
 						"insert into abb values (new.y)", // Statement
 						"AFTER",                          // Timing
 						time.Unix(0, 0).UTC(),            // Created
-						"",                               // sql_mode
-						"",                               // Definer
+						"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES", // sql_mode
+						"root@localhost", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1521,8 +1806,8 @@ end;`,
 						"set new.y = old.y + 2", // Statement
 						"BEFORE",                // Timing
 						time.Unix(0, 0).UTC(),   // Created
-						"",                      // sql_mode
-						"",                      // Definer
+						"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES", // sql_mode
+						"root@localhost", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1539,8 +1824,8 @@ end;`,
 						"insert into abb values (new.y)", // Statement
 						"AFTER",                          // Timing
 						time.Unix(0, 0).UTC(),            // Created
-						"",                               // sql_mode
-						"",                               // Definer
+						"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES", // sql_mode
+						"root@localhost", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1552,8 +1837,8 @@ end;`,
 						"set new.y = old.y + 2", // Statement
 						"BEFORE",                // Timing
 						time.Unix(0, 0).UTC(),   // Created
-						"",                      // sql_mode
-						"",                      // Definer
+						"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES", // sql_mode
+						"root@localhost", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1570,8 +1855,8 @@ end;`,
 						"set new.x = new.x + 1", // Statement
 						"BEFORE",                // Timing
 						time.Unix(0, 0).UTC(),   // Created
-						"",                      // sql_mode
-						"",                      // Definer
+						"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES", // sql_mode
+						"root@localhost", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1583,8 +1868,8 @@ end;`,
 						"set new.x = new.x + 2", // Statement
 						"BEFORE",                // Timing
 						time.Unix(0, 0).UTC(),   // Created
-						"",                      // sql_mode
-						"",                      // Definer
+						"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES", // sql_mode
+						"root@localhost", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1596,8 +1881,8 @@ end;`,
 						"insert into abb values (new.y)", // Statement
 						"AFTER",                          // Timing
 						time.Unix(0, 0).UTC(),            // Created
-						"",                               // sql_mode
-						"",                               // Definer
+						"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES", // sql_mode
+						"root@localhost", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1614,8 +1899,8 @@ end;`,
 						"insert into abb values (new.y)", // Statement
 						"AFTER",                          // Timing
 						time.Unix(0, 0).UTC(),            // Created
-						"",                               // sql_mode
-						"",                               // Definer
+						"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES", // sql_mode
+						"root@localhost", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1632,8 +1917,8 @@ end;`,
 						"set new.x = new.x + 1", // Statement
 						"BEFORE",                // Timing
 						time.Unix(0, 0).UTC(),   // Created
-						"",                      // sql_mode
-						"",                      // Definer
+						"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES", // sql_mode
+						"root@localhost", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1645,8 +1930,54 @@ end;`,
 						"set new.x = new.x + 2", // Statement
 						"BEFORE",                // Timing
 						time.Unix(0, 0).UTC(),   // Created
-						"",                      // sql_mode
-						"",                      // Definer
+						"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES", // sql_mode
+						"root@localhost", // Definer
+						sql.Collation_Default.CharacterSet().String(), // character_set_client
+						sql.Collation_Default.String(),                // collation_connection
+						sql.Collation_Default.String(),                // Database Collation
+					},
+				},
+			},
+		},
+	},
+	{
+		Name: "information_schema.triggers",
+		SetUpScript: []string{
+			"create table abb (x int primary key)",
+			"create table acc (y int primary key)",
+			"create trigger t1 before insert on abb for each row set new.x = new.x + 1",
+			"create trigger t2 after update on acc for each row insert into abb values (new.y)",
+			"create definer = `bob@localhost` trigger t3 after delete on acc for each row delete from abb where x = old.y",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "select trigger_name, event_manipulation, event_object_table, action_timing, action_statement, action_orientation, created <= now() from information_schema.triggers order by 1",
+				Expected: []sql.Row{
+					{"t1", "INSERT", "abb", "BEFORE", "set new.x = new.x + 1", "ROW", true},
+					{"t2", "UPDATE", "acc", "AFTER", "insert into abb values (new.y)", "ROW", true},
+					{"t3", "DELETE", "acc", "AFTER", "delete from abb where x = old.y", "ROW", true},
+				},
+			},
+			{
+				Query: "select trigger_name, definer from information_schema.triggers order by 1",
+				Expected: []sql.Row{
+					{"t1", "root@localhost"},
+					{"t2", "root@localhost"},
+					{"t3", "bob@localhost@%"},
+				},
+			},
+			{
+				Query: "show triggers where `Trigger` = 't3'",
+				Expected: []sql.Row{
+					{
+						"t3",                              // Trigger
+						"DELETE",                          // Event
+						"acc",                             // Table
+						"delete from abb where x = old.y", // Statement
+						"AFTER",                           // Timing
+						time.Unix(0, 0).UTC(),             // Created
+						"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES", // sql_mode
+						"bob@localhost@%", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -2015,6 +2346,15 @@ var TriggerErrorTests = []ScriptTest{
 		Query:       "create trigger not_found before insert on y for each row set new.a = new.a + 1",
 		ExpectedErr: sql.ErrTableNotFound,
 	},
+	{
+		Name: "trigger on view",
+		SetUpScript: []string{
+			"create table x (a int primary key, b int)",
+			"create view v as select * from x",
+		},
+		Query:       "create trigger view_trigger before insert on v for each row set new.b = new.a",
+		ExpectedErr: sql.ErrExpectedTableFoundView,
+	},
 	{
 		Name: "trigger errors on execution",
 		SetUpScript: []string{
@@ -2063,6 +2403,19 @@ var TriggerErrorTests = []ScriptTest{
 		Query:       "insert into a values (1), (2), (3)",
 		ExpectedErr: sql.ErrTriggerTableInUse,
 	},
+	{
+		Name: "circular dependency, after update triggers",
+		SetUpScript: []string{
+			"create table a (x int primary key)",
+			"create table b (y int primary key)",
+			"insert into a values (1)",
+			"insert into b values (1)",
+			"create trigger a1 after update on a for each row update b set y = new.x",
+			"create trigger b1 after update on b for each row update a set x = new.y",
+		},
+		Query:       "update a set x = 2",
+		ExpectedErr: sql.ErrTriggerTableInUse,
+	},
 	{
 		Name: "circular dependency, nested two deep",
 		SetUpScript: []string{
@@ -2084,6 +2437,15 @@ var TriggerErrorTests = []ScriptTest{
 		Query:       "create trigger old_on_insert before insert on x for each row set new.c = old.a + 1",
 		ExpectedErr: sql.ErrInvalidUseOfOldNew,
 	},
+	{
+		Name: "reference to old in statement trigger",
+		SetUpScript: []string{
+			"create table x (a int primary key, b int, c int)",
+			"create table y (a int)",
+		},
+		Query:       "create trigger old_in_statement after update on x for each statement update y set a = old.a",
+		ExpectedErr: sql.ErrInvalidUseOfOldNew,
+	},
 	{
 		Name: "reference to new on delete",
 		SetUpScript: []string{
@@ -2092,6 +2454,33 @@ var TriggerErrorTests = []ScriptTest{
 		Query:       "create trigger new_on_delete before delete on x for each row set new.c = old.a + 1",
 		ExpectedErr: sql.ErrInvalidUseOfOldNew,
 	},
+	{
+		Name: "reference to old in inserted values on insert",
+		SetUpScript: []string{
+			"create table x (a int primary key, b int, c int)",
+			"create table y (a int)",
+		},
+		Query:       "create trigger old_on_insert after insert on x for each row insert into y values (old.a)",
+		ExpectedErr: sql.ErrInvalidUseOfOldNew,
+	},
+	{
+		Name: "reference to new in inserted values on delete",
+		SetUpScript: []string{
+			"create table x (a int primary key, b int, c int)",
+			"create table y (a int)",
+		},
+		Query:       "create trigger new_on_delete after delete on x for each row insert into y values (new.a)",
+		ExpectedErr: sql.ErrInvalidUseOfOldNew,
+	},
+	{
+		Name: "reference to new in a filter on delete",
+		SetUpScript: []string{
+			"create table x (a int primary key, b int, c int)",
+			"create table y (a int)",
+		},
+		Query:       "create trigger new_on_delete after delete on x for each row delete from y where a = new.a",
+		ExpectedErr: sql.ErrInvalidUseOfOldNew,
+	},
 	{
 		Name: "set old row on update",
 		SetUpScript: []string{
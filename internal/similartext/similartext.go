@@ -97,6 +97,26 @@ func Find(names []string, src string) string {
 		strings.Join(matchMap[minDistance], " or "))
 }
 
+// Closest returns the name in `names` with the smallest edit distance to
+// `src`, or an empty string if none is closer than `DistanceSkipped`. Ties
+// are broken by choosing the name that sorts first.
+func Closest(names []string, src string) string {
+	if len(src) == 0 {
+		return ""
+	}
+
+	closest := ""
+	minDistance := DistanceSkipped
+	for _, name := range names {
+		dist := distanceForStrings(name, src)
+		if dist < minDistance || (dist == minDistance && closest != "" && name < closest) {
+			closest, minDistance = name, dist
+		}
+	}
+
+	return closest
+}
+
 // FindFromMap does the same as Find but taking a map instead
 // of a string array as first argument.
 func FindFromMap(names interface{}, src string) string {
@@ -44,6 +44,19 @@ func TestFind(t *testing.T) {
 	require.Equal(", maybe you mean aka or ake?", res)
 }
 
+func TestClosest(t *testing.T) {
+	require := require.New(t)
+
+	require.Empty(Closest(nil, "foo"))
+
+	names := []string{"foo", "bar", "aka", "ake"}
+	require.Empty(Closest(names, ""))
+	require.Equal("bar", Closest(names, "baz"))
+	require.Equal("foo", Closest(names, "foo"))
+	require.Equal("aka", Closest(names, "aki"))
+	require.Empty(Closest(names, "willBeTooDifferent"))
+}
+
 func TestFindFromMap(t *testing.T) {
 	require := require.New(t)
 
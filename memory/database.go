@@ -206,6 +206,11 @@ func (d *BaseDatabase) GetTriggers(ctx *sql.Context) ([]sql.TriggerDefinition, e
 }
 
 func (d *BaseDatabase) CreateTrigger(ctx *sql.Context, definition sql.TriggerDefinition) error {
+	for _, trigger := range d.triggers {
+		if strings.EqualFold(trigger.Name, definition.Name) {
+			return sql.ErrTriggerAlreadyExists.New(definition.Name)
+		}
+	}
 	d.triggers = append(d.triggers, definition)
 	return nil
 }
@@ -0,0 +1,135 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// SpatialIndex is a SPATIAL index over a single geometry column. It doesn't handle any filters, so the spatial
+// predicates that use it are still evaluated on every row it returns.
+type SpatialIndex struct {
+	Tbl        *Table
+	TableName  string
+	Expr       sql.Expression
+	Name       string
+	CommentStr string
+}
+
+var _ sql.SpatialIndex = (*SpatialIndex)(nil)
+var _ ExpressionsIndex = (*SpatialIndex)(nil)
+
+func (idx *SpatialIndex) ID() string                          { return idx.Name }
+func (idx *SpatialIndex) Database() string                    { return "" }
+func (idx *SpatialIndex) Table() string                       { return idx.TableName }
+func (idx *SpatialIndex) MemTable() *Table                    { return idx.Tbl }
+func (idx *SpatialIndex) ColumnExpressions() []sql.Expression { return []sql.Expression{idx.Expr} }
+func (idx *SpatialIndex) Expressions() []string               { return []string{idx.Expr.String()} }
+func (idx *SpatialIndex) IsUnique() bool                      { return false }
+func (idx *SpatialIndex) Comment() string                     { return idx.CommentStr }
+func (idx *SpatialIndex) IndexType() string                   { return "SPATIAL" }
+func (idx *SpatialIndex) IsGenerated() bool                   { return false }
+
+// NewLookup implements the interface sql.Index. Spatial indexes can't be searched by ranges.
+func (idx *SpatialIndex) NewLookup(*sql.Context, ...sql.Range) (sql.IndexLookup, error) {
+	return nil, nil
+}
+
+// NewSpatialLookup implements the interface sql.SpatialIndex.
+func (idx *SpatialIndex) NewSpatialLookup(_ *sql.Context, envelope sql.Envelope) (sql.SpatialIndexLookup, error) {
+	return &SpatialIndexLookup{idx: idx, envelope: envelope}, nil
+}
+
+// ColumnExpressionTypes implements the interface sql.Index.
+func (idx *SpatialIndex) ColumnExpressionTypes(*sql.Context) []sql.ColumnExpressionType {
+	return []sql.ColumnExpressionType{{Expression: idx.Expr.String(), Type: idx.Expr.Type()}}
+}
+
+// SpatialIndexLookup is the lookup of the rows of a partition whose geometries have envelopes intersecting an envelope.
+type SpatialIndexLookup struct {
+	idx      *SpatialIndex
+	envelope sql.Envelope
+}
+
+var _ sql.SpatialIndexLookup = (*SpatialIndexLookup)(nil)
+var _ sql.DriverIndexLookup = (*SpatialIndexLookup)(nil)
+
+func (l *SpatialIndexLookup) String() string {
+	return fmt.Sprintf("%s within %s", l.idx.ID(), l.envelope)
+}
+
+// Index implements the interface sql.IndexLookup.
+func (l *SpatialIndexLookup) Index() sql.Index {
+	return l.idx
+}
+
+// Ranges implements the interface sql.IndexLookup. Spatial lookups have no ranges.
+func (l *SpatialIndexLookup) Ranges() sql.RangeCollection {
+	return nil
+}
+
+// Envelope implements the interface sql.SpatialIndexLookup.
+func (l *SpatialIndexLookup) Envelope() sql.Envelope {
+	return l.envelope
+}
+
+// Indexes implements the interface sql.DriverIndexLookup.
+func (l *SpatialIndexLookup) Indexes() []string {
+	return []string{l.idx.ID()}
+}
+
+// Values implements the interface sql.DriverIndexLookup. An RTree is built over the geometries of the partition every
+// time it is searched, which is only suitable for testing the use of spatial indexes in the engine.
+func (l *SpatialIndexLookup) Values(p sql.Partition) (sql.IndexValueIter, error) {
+	rows, ok := l.idx.Tbl.partitions[string(p.Key())]
+	if !ok {
+		return nil, sql.ErrPartitionNotFound.New(p.Key())
+	}
+
+	tree := sql.NewRTree()
+	for i, row := range rows {
+		v, err := l.idx.Expr.Eval(sql.NewEmptyContext(), row)
+		if err != nil {
+			return nil, err
+		}
+		if env, ok := sql.GeometryEnvelope(v); ok {
+			tree.Insert(env, i)
+		}
+	}
+
+	var positions []int
+	for _, v := range tree.Search(l.envelope) {
+		positions = append(positions, v.(int))
+	}
+	sort.Ints(positions)
+
+	values := make([][]byte, 0, len(positions))
+	for _, pos := range positions {
+		encoded, err := EncodeIndexValue(&IndexValue{Pos: pos})
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, encoded)
+	}
+
+	return &indexValIter{
+		tbl:       l.idx.Tbl,
+		partition: p,
+		values:    values,
+	}, nil
+}
@@ -1035,6 +1035,16 @@ func (t *Table) createIndex(name string, columns []sql.IndexColumn, constraint s
 		exprs[i] = expression.NewGetFieldWithTable(idx, field.Type, t.name, field.Name, field.Nullable)
 	}
 
+	if constraint == sql.IndexConstraint_Spatial {
+		return &SpatialIndex{
+			Tbl:        t,
+			TableName:  t.name,
+			Expr:       exprs[0],
+			Name:       name,
+			CommentStr: comment,
+		}, nil
+	}
+
 	return &Index{
 		DB:         "",
 		DriverName: "",
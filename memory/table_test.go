@@ -420,3 +420,39 @@ func TestTableIndexKeyValueIter(t *testing.T) {
 		})
 	}
 }
+
+func TestSpatialIndexLookup(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	table := memory.NewPartitionedTable("places", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "pk", Type: sql.Int64, Source: "places", PrimaryKey: true},
+		{Name: "g", Type: sql.GeometryType{}, Source: "places"},
+	}), 2)
+	rows := []sql.Row{
+		sql.NewRow(int64(1), sql.Point{X: 1, Y: 1}),
+		sql.NewRow(int64(2), sql.Point{X: 5, Y: 5}),
+		sql.NewRow(int64(3), sql.Linestring{Points: []sql.Point{{X: -1, Y: 3}, {X: 1, Y: 3}}}),
+		sql.NewRow(int64(4), sql.Point{X: 9, Y: 0}),
+		sql.NewRow(int64(5), sql.Linestring{Points: []sql.Point{{X: 2, Y: 6}, {X: 3, Y: 8}}}),
+	}
+	for _, row := range rows {
+		require.NoError(table.Insert(ctx, row))
+	}
+
+	require.NoError(table.CreateIndex(ctx, "g_idx", sql.IndexUsing_Default, sql.IndexConstraint_Spatial, []sql.IndexColumn{{Name: "g"}}, ""))
+	indexes, err := table.GetIndexes(ctx)
+	require.NoError(err)
+	require.Len(indexes, 1)
+	idx, ok := indexes[0].(sql.SpatialIndex)
+	require.True(ok)
+	require.Equal("SPATIAL", idx.IndexType())
+
+	lookup, err := idx.NewSpatialLookup(ctx, sql.Envelope{MinX: 0, MinY: 0, MaxX: 4, MaxY: 4})
+	require.NoError(err)
+	require.Equal(sql.Envelope{MinX: 0, MinY: 0, MaxX: 4, MaxY: 4}, lookup.Envelope())
+
+	indexed := table.WithIndexLookup(lookup)
+	actual := getAllRows(t, indexed)
+	require.ElementsMatch([]sql.Row{rows[0], rows[2]}, actual)
+}
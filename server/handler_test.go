@@ -689,3 +689,50 @@ func TestHandlerFoundRowsCapabilities(t *testing.T) {
 		})
 	}
 }
+
+func TestHandlerGeometryRoundTrip(t *testing.T) {
+	require := require.New(t)
+	e := setupMemDB(require)
+	dummyConn := &mysql.Conn{ConnectionID: 1}
+	handler := NewHandler(
+		e,
+		NewSessionManager(
+			testSessionBuilder,
+			opentracing.NoopTracer{},
+			func(ctx *sql.Context, db string) bool { return db == "test" },
+			sql.NewMemoryManager(nil),
+			sqle.NewProcessList(),
+			"foo",
+		),
+		0,
+		false,
+		nil,
+	)
+	handler.NewConnection(dummyConn)
+	handler.ComInitDB(dummyConn, "test")
+
+	query := func(q string) []sqltypes.Value {
+		var row []sqltypes.Value
+		err := handler.ComQuery(dummyConn, q, func(res *sqltypes.Result, more bool) error {
+			if len(res.Rows) > 0 {
+				row = res.Rows[0]
+			}
+			return nil
+		})
+		require.NoError(err, q)
+		return row
+	}
+
+	query("CREATE TABLE spatial (pk int primary key, p point)")
+	query("INSERT INTO spatial VALUES (1, ST_SRID(POINT(1, 2), 4326))")
+
+	// A geometry value is sent to the client in MySQL's internal format, which must be accepted when it's sent back
+	p := query("SELECT p FROM spatial WHERE pk = 1")[0].Raw()
+	require.Equal([]byte{0xe6, 0x10, 0, 0, 1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f, 0, 0, 0, 0, 0, 0, 0, 0x40}, p)
+	query(fmt.Sprintf("INSERT INTO spatial VALUES (2, X'%x')", p))
+
+	row := query("SELECT p, ST_SRID(p), ST_ASWKT(p) FROM spatial WHERE pk = 2")
+	require.Equal(p, row[0].Raw())
+	require.Equal("4326", row[1].ToString())
+	require.Equal("POINT(1 2)", row[2].ToString())
+}
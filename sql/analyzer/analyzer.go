@@ -32,6 +32,10 @@ const debugAnalyzerKey = "DEBUG_ANALYZER"
 
 const maxAnalysisIterations = 8
 
+// DefaultMaxNestingDepth is the maximum number of subqueries and common table expressions a query may be nested in,
+// unless the analyzer is configured with another maximum.
+const DefaultMaxNestingDepth = 64
+
 // ErrMaxAnalysisIters is thrown when the analysis iterations are exceeded
 var ErrMaxAnalysisIters = errors.NewKind("exceeded max analysis iterations (%d)")
 
@@ -55,6 +59,9 @@ type Builder struct {
 	provider            sql.DatabaseProvider
 	debug               bool
 	parallelism         int
+	parallelSubqueries  bool
+	correlatedCache     bool
+	maxNestingDepth     int
 }
 
 // NewBuilder creates a new Builder from a specific catalog.
@@ -83,6 +90,25 @@ func (ab *Builder) WithParallelism(parallelism int) *Builder {
 	return ab
 }
 
+// WithParallelSubqueryAnalysis makes the Analyzer analyze the uncorrelated subquery expressions of a node concurrently.
+func (ab *Builder) WithParallelSubqueryAnalysis() *Builder {
+	ab.parallelSubqueries = true
+	return ab
+}
+
+// WithCorrelatedSubqueryCache makes the Analyzer memoize the results of deterministic, correlated scalar subqueries
+// for each distinct tuple of the outer scope columns they reference.
+func (ab *Builder) WithCorrelatedSubqueryCache() *Builder {
+	ab.correlatedCache = true
+	return ab
+}
+
+// WithMaxNestingDepth sets the maximum number of subqueries and common table expressions a query may be nested in.
+func (ab *Builder) WithMaxNestingDepth(depth int) *Builder {
+	ab.maxNestingDepth = depth
+	return ab
+}
+
 // AddPreAnalyzeRule adds a new rule to the analyze before the standard analyzer rules.
 func (ab *Builder) AddPreAnalyzeRule(name string, fn RuleFunc) *Builder {
 	ab.preAnalyzeRules = append(ab.preAnalyzeRules, Rule{name, fn})
@@ -242,12 +268,15 @@ func (ab *Builder) Build() *Analyzer {
 	}
 
 	return &Analyzer{
-		Debug:          debug || ab.debug,
-		contextStack:   make([]string, 0),
-		Batches:        batches,
-		Catalog:        NewCatalog(ab.provider),
-		Parallelism:    ab.parallelism,
-		ProcedureCache: NewProcedureCache(),
+		Debug:                    debug || ab.debug,
+		contextStack:             make([]string, 0),
+		Batches:                  batches,
+		Catalog:                  NewCatalog(ab.provider),
+		Parallelism:              ab.parallelism,
+		ProcedureCache:           NewProcedureCache(),
+		ParallelSubqueryAnalysis: ab.parallelSubqueries,
+		CorrelatedSubqueryCache:  ab.correlatedCache,
+		MaxNestingDepth:          ab.maxNestingDepth,
 	}
 }
 
@@ -267,6 +296,14 @@ type Analyzer struct {
 	Catalog *Catalog
 	// ProcedureCache is a cache of stored procedures.
 	ProcedureCache *ProcedureCache
+	// Whether to analyze the uncorrelated subquery expressions of a node concurrently
+	ParallelSubqueryAnalysis bool
+	// Whether to memoize the results of deterministic, correlated scalar subqueries per distinct tuple of the outer
+	// scope columns they reference
+	CorrelatedSubqueryCache bool
+	// The maximum number of subqueries and common table expressions a query may be nested in, or DefaultMaxNestingDepth
+	// if zero
+	MaxNestingDepth int
 }
 
 // NewDefault creates a default Analyzer instance with all default Rules and configuration.
@@ -369,6 +406,7 @@ func (a *Analyzer) analyzeWithSelector(ctx *sql.Context, n sql.Node, scope *Scop
 	span, ctx := ctx.Span("analyze", opentracing.Tags{
 		//"plan": , n.String(),
 	})
+	ctx = withSubqueryCache(ctx)
 
 	var err error
 	a.Log("starting analysis of node of type: %T", n)
@@ -30,6 +30,8 @@ type Catalog struct {
 
 	provider         sql.DatabaseProvider
 	builtInFunctions function.Registry
+	userFunctions    map[string]sql.Function
+	tableFunctions   map[string]sql.TableFunction
 	mu               sync.RWMutex
 	locks            sessionLocks
 }
@@ -50,10 +52,20 @@ func NewCatalog(provider sql.DatabaseProvider) *Catalog {
 		GrantTables:      grant_tables.CreateEmptyGrantTables(),
 		provider:         provider,
 		builtInFunctions: function.NewRegistry(),
+		userFunctions:    make(map[string]sql.Function),
+		tableFunctions:   builtInTableFunctions(),
 		locks:            make(sessionLocks),
 	}
 }
 
+func builtInTableFunctions() map[string]sql.TableFunction {
+	tableFunctions := make(map[string]sql.TableFunction, len(function.BuiltInTableFunctions))
+	for _, tf := range function.BuiltInTableFunctions {
+		tableFunctions[tf.FunctionName()] = tf
+	}
+	return tableFunctions
+}
+
 func NewDatabaseProvider(dbs ...sql.Database) sql.DatabaseProvider {
 	return sql.NewDatabaseProvider(dbs...)
 }
@@ -222,6 +234,24 @@ func (c *Catalog) RegisterFunction(ctx *sql.Context, fns ...sql.Function) {
 	}
 }
 
+// RegisterUserFunction registers the function given under the name given, so that it can be called from queries.
+// Names are case-insensitive and may not collide with a built-in function. Registering a name that is already
+// registered returns an error, unless replace is true, in which case the previous function is replaced.
+func (c *Catalog) RegisterUserFunction(name string, fn sql.Function, replace bool) error {
+	name = strings.ToLower(name)
+	if _, ok := c.builtInFunctions[name]; ok {
+		return sql.ErrBuiltInFunctionRedefined.New(name)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.userFunctions[name]; ok && !replace {
+		return function.ErrFunctionAlreadyRegistered.New(name)
+	}
+	c.userFunctions[name] = fn
+	return nil
+}
+
 // Function returns the function with the name given, or sql.ErrFunctionNotFound if it doesn't exist
 func (c *Catalog) Function(ctx *sql.Context, name string) (sql.Function, error) {
 	if fp, ok := c.provider.(sql.FunctionProvider); ok {
@@ -233,20 +263,46 @@ func (c *Catalog) Function(ctx *sql.Context, name string) (sql.Function, error)
 		}
 	}
 
-	return c.builtInFunctions.Function(ctx, name)
+	// Function names are case-insensitive, but errors keep the name as it was given
+	lower := strings.ToLower(name)
+	if f, ok := c.builtInFunctions[lower]; ok {
+		return f, nil
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if f, ok := c.userFunctions[lower]; ok {
+		return f, nil
+	}
+
+	names := make([]string, 0, len(c.builtInFunctions)+len(c.userFunctions))
+	for n := range c.builtInFunctions {
+		names = append(names, n)
+	}
+	for n := range c.userFunctions {
+		names = append(names, n)
+	}
+	if similar := similartext.Closest(names, lower); similar != "" {
+		return nil, sql.ErrFunctionNotFound.Wrap(fmt.Errorf("did you mean %s?", similar), name)
+	}
+	return nil, sql.ErrFunctionNotFound.New(name)
 }
 
 // TableFunction implements the TableFunctionProvider interface
 func (c *Catalog) TableFunction(ctx *sql.Context, name string) (sql.TableFunction, error) {
 	if fp, ok := c.provider.(sql.TableFunctionProvider); ok {
 		tf, err := fp.TableFunction(ctx, name)
-		if err != nil {
+		if err != nil && !sql.ErrTableFunctionNotFound.Is(err) {
 			return nil, err
 		} else if tf != nil {
 			return tf, nil
 		}
 	}
 
+	if tf, ok := c.tableFunctions[strings.ToLower(name)]; ok {
+		return tf, nil
+	}
+
 	return nil, sql.ErrTableFunctionNotFound.New(name)
 }
 
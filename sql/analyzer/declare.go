@@ -124,6 +124,18 @@ func resolveDeclarationsInner(ctx *sql.Context, a *Analyzer, node sql.Node, scop
 				return nil, sql.ErrSignalOnlySqlState.New()
 			}
 			newChild = plan.NewSignal(condition.SqlStateValue, child.Signal.Info)
+		case *plan.Resignal:
+			newChild = child
+			if child.Name != "" {
+				condition := scope.GetCondition(child.Name)
+				if condition == nil {
+					return nil, sql.ErrDeclareConditionNotFound.New(child.Name)
+				}
+				if condition.SqlStateValue == "" {
+					return nil, sql.ErrSignalOnlySqlState.New()
+				}
+				newChild = plan.NewResignal(condition.SqlStateValue, "", child.Info)
+			}
 		default:
 			newChild = child
 		}
@@ -159,6 +159,10 @@ func (r *indexAnalyzer) MatchingIndexes(ctx *sql.Context, db string, table strin
 
 	var indexes []idxWithLen
 	for _, idx := range r.indexesByTable[table] {
+		// Spatial indexes can't be searched by ranges, see MatchingSpatialIndex
+		if _, ok := idx.(sql.SpatialIndex); ok {
+			continue
+		}
 		indexExprs := idx.Expressions()
 		if ok, prefixCount := exprsAreIndexSubset(exprStrs, indexExprs); ok && prefixCount >= 1 {
 			indexes = append(indexes, idxWithLen{idx, len(indexExprs), prefixCount})
@@ -200,6 +204,25 @@ func (r *indexAnalyzer) MatchingIndexes(ctx *sql.Context, db string, table strin
 	return sortedIndexes
 }
 
+// MatchingSpatialIndex returns the spatial index over the given expression. If there is more than one, the index with
+// the lowest ID is returned.
+func (r *indexAnalyzer) MatchingSpatialIndex(table string, expr sql.Expression) sql.SpatialIndex {
+	var match sql.SpatialIndex
+	for _, idx := range r.indexesByTable[table] {
+		spatial, ok := idx.(sql.SpatialIndex)
+		if !ok {
+			continue
+		}
+		if exprs := idx.Expressions(); len(exprs) != 1 || exprs[0] != expr.String() {
+			continue
+		}
+		if match == nil || spatial.ID() < match.ID() {
+			match = spatial
+		}
+	}
+	return match
+}
+
 // ExpressionsWithIndexes finds all the combinations of expressions with matching indexes. This only matches
 // multi-column indexes. Sorts the list of expressions by their length in descending order.
 func (r *indexAnalyzer) ExpressionsWithIndexes(db string, exprs ...sql.Expression) [][]sql.Expression {
@@ -209,6 +232,9 @@ func (r *indexAnalyzer) ExpressionsWithIndexes(db string, exprs ...sql.Expressio
 	for _, idxes := range r.indexesByTable {
 	Indexes:
 		for _, idx := range idxes {
+			if _, ok := idx.(sql.SpatialIndex); ok {
+				continue
+			}
 			var used = make(map[int]struct{})
 			var matched []sql.Expression
 			for _, ie := range idx.Expressions() {
@@ -60,6 +60,10 @@ func replaceJoinPlans(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (
 		case *plan.IndexedJoin:
 			return n, nil
 		case plan.JoinNode:
+			// Reordering the tables in a join would change the scope of a lateral subquery alias
+			if hasLateralSubqueryAlias(n) {
+				return n, nil
+			}
 			oldJoin = n
 
 			var err error
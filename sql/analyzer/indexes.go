@@ -19,6 +19,7 @@ import (
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
 	"github.com/dolthub/go-mysql-server/sql/plan"
 )
 
@@ -237,6 +238,15 @@ func getIndexes(
 		result[getField.Table()] = lookup
 	case *expression.IsNull:
 		return getIndexes(ctx, a, ia, expression.NewEquals(e.Child, expression.NewLiteral(nil, sql.Null)), tableAliases)
+	case *function.Contains, *function.Within:
+		children := e.Children()
+		lookup, err := getSpatialIndexLookup(ctx, ia, e, children[0], children[1], tableAliases)
+		if err != nil || lookup == nil {
+			return result, err
+		}
+
+		getField := expression.ExtractGetField(lookup.fields[0])
+		result[getField.Table()] = lookup
 	case *expression.Not:
 		r, err := getNegatedIndexes(ctx, a, ia, e, tableAliases)
 		if err != nil {
@@ -388,6 +398,57 @@ func getComparisonIndexLookup(
 	return nil, nil
 }
 
+// getSpatialIndexLookup returns the spatial index lookup for a spatial predicate between a geometry column and a constant
+// geometry, if the column has a spatial index. The rows satisfying either ST_CONTAINS or ST_WITHIN have geometries whose
+// envelopes intersect the envelope of the constant geometry, so the lookup searches the index for that envelope.
+func getSpatialIndexLookup(
+	ctx *sql.Context,
+	ia *indexAnalyzer,
+	e, left, right sql.Expression,
+	tableAliases TableAliases,
+) (*indexLookup, error) {
+	if !isEvaluable(right) {
+		left, right = right, left
+	}
+	if isEvaluable(left) || !isEvaluable(right) {
+		return nil, nil
+	}
+
+	gf, ok := left.(*expression.GetField)
+	if !ok {
+		return nil, nil
+	}
+
+	normalizedExpressions := normalizeExpressions(ctx, tableAliases, gf)
+	idx := ia.MatchingSpatialIndex(gf.Table(), normalizedExpressions[0])
+	if idx == nil {
+		return nil, nil
+	}
+
+	value, err := right.Eval(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// The predicate is never true for null and empty geometries, which leaves nothing to search for
+	envelope, ok := sql.GeometryEnvelope(value)
+	if !ok {
+		return nil, nil
+	}
+
+	lookup, err := idx.NewSpatialLookup(ctx, envelope)
+	if err != nil || lookup == nil {
+		return nil, err
+	}
+
+	return &indexLookup{
+		fields:  []sql.Expression{left},
+		lookup:  lookup,
+		indexes: []sql.Index{idx},
+		expr:    e,
+	}, nil
+}
+
 // Returns an equivalent expression to the one given with the left and right terms reversed. The new left and right side
 // of the expression are returned as well.
 func swapTermsOfExpression(e expression.Comparer) (left sql.Expression, right sql.Expression, newExpr expression.Comparer) {
@@ -1062,6 +1123,13 @@ func canMergeIndexes(a, b sql.IndexLookup) bool {
 	if a == nil || b == nil {
 		return false
 	}
+	// Spatial lookups have no ranges to combine
+	if _, ok := a.(sql.SpatialIndexLookup); ok {
+		return false
+	}
+	if _, ok := b.(sql.SpatialIndexLookup); ok {
+		return false
+	}
 	ai := a.Index()
 	bi := b.Index()
 	if ai.Database() != bi.Database() || ai.Table() != bi.Table() {
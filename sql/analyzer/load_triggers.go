@@ -111,6 +111,10 @@ func loadTriggersFromDb(ctx *sql.Context, db sql.Database) ([]*plan.CreateTrigge
 				return nil, sql.ErrTriggerCreateStatementInvalid.New(trigger.CreateStatement)
 			}
 			triggerPlan.CreatedAt = trigger.CreatedAt // use the stored created time
+			if trigger.Definer != "" {
+				triggerPlan.Definer = trigger.Definer // use the stored definer
+			}
+			triggerPlan.SqlMode = trigger.SqlMode // use the stored sql_mode
 			loadedTriggers = append(loadedTriggers, triggerPlan)
 		}
 	}
@@ -100,7 +100,12 @@ func pruneColumnsIsSafe(n sql.Node) bool {
 		}
 		return isSafe
 	})
-	return isSafe
+	if !isSafe {
+		return false
+	}
+	// Columns of a join's left side can be used by a lateral
+	// subquery alias on its right side, which isn't visible here.
+	return !hasLateralSubqueryAlias(n)
 }
 
 func columnsUsedByNode(n sql.Node) usedColumns {
@@ -130,21 +135,21 @@ func pruneSubqueryColumns(
 
 	// The columns coming from the parent have the subquery alias name as the source. We need to find the real table in
 	// order to prune the subquery correctly. The columns might also have been renamed.
-	tableByCol := make(map[string]string)
+	colByName := make(map[string]*sql.Column)
 	for i, col := range n.Child.Schema() {
 		name := col.Name
 		if len(n.Columns) > 0 {
 			name = n.Columns[i]
 		}
-		tableByCol[name] = col.Source
+		colByName[name] = col
 	}
 
-	for col := range parentColumns[n.Name()] {
-		table, ok := tableByCol[col]
+	for name := range parentColumns[n.Name()] {
+		col, ok := colByName[name]
 		if !ok {
-			return nil, fmt.Errorf("this is likely a bug: missing projected column %q on subquery %q", col, n.Name())
+			return nil, fmt.Errorf("this is likely a bug: missing projected column %q on subquery %q", name, n.Name())
 		}
-		columns.add(table, col)
+		columns.add(col.Source, col.Name)
 	}
 
 	findUsedColumns(columns, n.Child)
@@ -237,7 +242,7 @@ func pruneSubqueries(
 ) (sql.Node, error) {
 	return plan.TransformUpCtx(n, canPruneChild, func(c plan.TransformContext) (sql.Node, error) {
 		subq, ok := c.Node.(*plan.SubqueryAlias)
-		if !ok {
+		if !ok || isMaterializedCte(subq) {
 			return c.Node, nil
 		}
 
@@ -0,0 +1,211 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// pruneSubqueryAliasColumns removes the columns of subquery aliases that the rest of the query doesn't use from the
+// projections of their children, so that a derived table doesn't compute expressions, aggregates or window functions
+// only for them to be discarded. pruneColumns only removes the fields passed up through projections, and it can then
+// remove the fields that only the removed expressions used.
+func pruneSubqueryAliasColumns(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	// All of the columns of the select of an insert are inserted, even if none of them is used elsewhere.
+	switch n.(type) {
+	case *plan.InsertInto, *plan.CreateTrigger:
+		return n, nil
+	}
+
+	if !pruneColumnsIsSafe(n) || hasUnion(n) {
+		return n, nil
+	}
+
+	columns := columnsUsedByNode(n)
+	findUsedColumns(columns, n)
+
+	pruned, changed, err := pruneSubqueryAliasesColumns(a, n, columns)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		return n, nil
+	}
+
+	return fixRemainingFieldsIndexes(ctx, a, pruned, scope)
+}
+
+// hasUnion returns whether the node given has a union outside of its subquery aliases. The columns of the right side
+// of a union aren't in the schema of the node, so their uses can't be found.
+func hasUnion(n sql.Node) bool {
+	found := false
+	plan.Inspect(n, func(n sql.Node) bool {
+		switch n.(type) {
+		case *plan.Union:
+			found = true
+		case *plan.SubqueryAlias:
+			return false
+		}
+		return !found
+	})
+	return found
+}
+
+// pruneSubqueryAliasesColumns prunes the columns of the subquery aliases in the node given, and of the ones nested in
+// them, given the columns used by the node. It returns whether any column was pruned.
+func pruneSubqueryAliasesColumns(a *Analyzer, n sql.Node, columns usedColumns) (sql.Node, bool, error) {
+	changed := false
+	node, err := plan.TransformUpCtx(n, canPruneChild, func(c plan.TransformContext) (sql.Node, error) {
+		sq, ok := c.Node.(*plan.SubqueryAlias)
+		if !ok || isMaterializedCte(sq) {
+			return c.Node, nil
+		}
+
+		sq, pruned := pruneSubqueryAliasProjection(a, sq, columns[sq.Name()])
+		changed = changed || pruned
+
+		// Every column left is used, so the subquery aliases in the child are pruned with the columns its own nodes
+		// use.
+		childColumns := columnsUsedByNode(sq.Child)
+		findUsedColumns(childColumns, sq.Child)
+		child, childChanged, err := pruneSubqueryAliasesColumns(a, sq.Child, childColumns)
+		if err != nil {
+			return nil, err
+		}
+		if !childChanged {
+			return sq, nil
+		}
+
+		changed = true
+		return sq.WithChildren(child)
+	})
+	return node, changed, err
+}
+
+// pruneSubqueryAliasProjection removes the columns not in the set given from the projection of the child of the
+// subquery alias, and from its column list. A subquery alias keeps at least one column, because the number of its rows
+// may still matter. It returns whether any column was removed.
+func pruneSubqueryAliasProjection(a *Analyzer, sq *plan.SubqueryAlias, used map[string]struct{}) (*plan.SubqueryAlias, bool) {
+	schema := sq.Schema()
+	keep := make([]bool, len(schema))
+	kept := 0
+	for i, col := range schema {
+		if _, ok := used[col.Name]; ok {
+			keep[i] = true
+			kept++
+		}
+	}
+
+	if kept == len(schema) {
+		return sq, false
+	}
+	if kept == 0 {
+		keep[0] = true
+	}
+
+	child, ok := pruneProjection(sq.Child, keep)
+	if !ok {
+		return sq, false
+	}
+
+	a.Log("pruned the unused columns of subquery alias %q", sq.Name())
+
+	nsq, _ := sq.WithChildren(child)
+	sq = nsq.(*plan.SubqueryAlias)
+	if len(sq.Columns) > 0 {
+		var columns []string
+		for i, name := range sq.Columns {
+			if keep[i] {
+				columns = append(columns, name)
+			}
+		}
+		sq = sq.WithColumns(columns)
+	}
+
+	return sq, true
+}
+
+// pruneProjection removes the expressions of the projection at the top of the node given that aren't marked to be
+// kept. When a project is over a group by or a window, the expressions of those that the project no longer uses are
+// removed as well, but their grouping, partitioning and ordering are left as they are. It returns false when the node
+// has no projection that can be pruned.
+func pruneProjection(n sql.Node, keep []bool) (sql.Node, bool) {
+	switch n := n.(type) {
+	case *plan.Project:
+		projections := keptExpressions(n.Projections, keep)
+		child := n.Child
+		if isGroupByOrWindow(child) {
+			child, _ = pruneProjection(child, usedChildColumns(projections, len(child.Schema())))
+		}
+		return plan.NewProject(projections, child), true
+	case *plan.GroupBy:
+		return plan.NewGroupBy(keptExpressions(n.SelectedExprs, keep), n.GroupByExprs, n.Child), true
+	case *plan.Window:
+		return plan.NewWindow(keptExpressions(n.SelectExprs, keep), n.Child), true
+	case *plan.Limit:
+		child, ok := pruneProjection(n.Child, keep)
+		if !ok {
+			return n, false
+		}
+		node, err := n.WithChildren(child)
+		return node, err == nil
+	default:
+		return n, false
+	}
+}
+
+func isGroupByOrWindow(n sql.Node) bool {
+	switch n.(type) {
+	case *plan.GroupBy, *plan.Window:
+		return true
+	default:
+		return false
+	}
+}
+
+func keptExpressions(exprs []sql.Expression, keep []bool) []sql.Expression {
+	var kept []sql.Expression
+	for i, e := range exprs {
+		if keep[i] {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+// usedChildColumns returns which of the columns of a child the expressions given read, keeping at least one.
+func usedChildColumns(exprs []sql.Expression, childLen int) []bool {
+	used := make([]bool, childLen)
+	anyUsed := false
+	for _, e := range exprs {
+		sql.Inspect(e, func(e sql.Expression) bool {
+			if gf, ok := e.(*expression.GetField); ok && gf.Index() < childLen {
+				used[gf.Index()] = true
+				anyUsed = true
+			}
+			return true
+		})
+	}
+	if !anyUsed && childLen > 0 {
+		used[0] = true
+	}
+	return used
+}
@@ -0,0 +1,236 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestPruneSubqueryAliasColumns(t *testing.T) {
+	rule := getRuleFrom(OnceAfterDefault, "prune_subquery_alias_columns")
+
+	t1 := plan.NewResolvedTable(memory.NewTable("t1", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "foo", Type: sql.Int64, Source: "t1"},
+		{Name: "bar", Type: sql.Int64, Source: "t1"},
+		{Name: "bax", Type: sql.Int64, Source: "t1"},
+	})), nil, nil)
+
+	testCases := []analyzerFnTestCase{
+		{
+			name: "unused expressions",
+			node: plan.NewProject(
+				[]sql.Expression{
+					gf(0, "t", "foo"),
+				},
+				plan.NewSubqueryAlias("t", "",
+					plan.NewProject(
+						[]sql.Expression{
+							gf(0, "t1", "foo"),
+							expression.NewAlias("doubled", expression.NewMult(gf(1, "t1", "bar"), lit(2))),
+							gf(2, "t1", "bax"),
+						},
+						t1,
+					),
+				),
+			),
+			expected: plan.NewProject(
+				[]sql.Expression{
+					gf(0, "t", "foo"),
+				},
+				plan.NewSubqueryAlias("t", "",
+					plan.NewProject(
+						[]sql.Expression{
+							gf(0, "t1", "foo"),
+						},
+						t1,
+					),
+				),
+			),
+		},
+		{
+			name: "column list",
+			node: plan.NewProject(
+				[]sql.Expression{
+					gf(2, "t", "c"),
+				},
+				plan.NewSubqueryAlias("t", "",
+					plan.NewProject(
+						[]sql.Expression{
+							gf(0, "t1", "foo"),
+							expression.NewAlias("doubled", expression.NewMult(gf(1, "t1", "bar"), lit(2))),
+							gf(2, "t1", "bax"),
+						},
+						t1,
+					),
+				).WithColumns([]string{"a", "b", "c"}),
+			),
+			expected: plan.NewProject(
+				[]sql.Expression{
+					gf(0, "t", "c"),
+				},
+				plan.NewSubqueryAlias("t", "",
+					plan.NewProject(
+						[]sql.Expression{
+							gf(2, "t1", "bax"),
+						},
+						t1,
+					),
+				).WithColumns([]string{"c"}),
+			),
+		},
+		{
+			name: "unused aggregations",
+			node: plan.NewProject(
+				[]sql.Expression{
+					gf(0, "t", "bar"),
+				},
+				plan.NewSubqueryAlias("t", "",
+					plan.NewProject(
+						[]sql.Expression{
+							gf(0, "t1", "bar"),
+							expression.NewAlias("c", gf(1, "", "COUNT(t1.foo)")),
+							expression.NewAlias("m", gf(2, "", "MAX(t1.bax)")),
+						},
+						plan.NewGroupBy(
+							[]sql.Expression{
+								gf(1, "t1", "bar"),
+								aggregation.NewCount(gf(0, "t1", "foo")),
+								aggregation.NewMax(gf(2, "t1", "bax")),
+							},
+							[]sql.Expression{
+								gf(1, "t1", "bar"),
+							},
+							t1,
+						),
+					),
+				),
+			),
+			expected: plan.NewProject(
+				[]sql.Expression{
+					gf(0, "t", "bar"),
+				},
+				plan.NewSubqueryAlias("t", "",
+					plan.NewProject(
+						[]sql.Expression{
+							gf(0, "t1", "bar"),
+						},
+						plan.NewGroupBy(
+							[]sql.Expression{
+								gf(1, "t1", "bar"),
+							},
+							[]sql.Expression{
+								gf(1, "t1", "bar"),
+							},
+							t1,
+						),
+					),
+				),
+			),
+		},
+		{
+			name: "grouping kept when the only used aggregation is on another column",
+			node: plan.NewProject(
+				[]sql.Expression{
+					gf(1, "t", "m"),
+				},
+				plan.NewSubqueryAlias("t", "",
+					plan.NewGroupBy(
+						[]sql.Expression{
+							gf(1, "t1", "bar"),
+							expression.NewAlias("m", aggregation.NewMax(gf(2, "t1", "bax"))),
+						},
+						[]sql.Expression{
+							gf(1, "t1", "bar"),
+						},
+						t1,
+					),
+				),
+			),
+			expected: plan.NewProject(
+				[]sql.Expression{
+					gf(0, "t", "m"),
+				},
+				plan.NewSubqueryAlias("t", "",
+					plan.NewGroupBy(
+						[]sql.Expression{
+							expression.NewAlias("m", aggregation.NewMax(gf(2, "t1", "bax"))),
+						},
+						[]sql.Expression{
+							gf(1, "t1", "bar"),
+						},
+						t1,
+					),
+				),
+			),
+		},
+		{
+			name: "no used columns keeps the first one",
+			node: plan.NewProject(
+				[]sql.Expression{
+					expression.NewAlias("one", lit(1)),
+				},
+				plan.NewSubqueryAlias("t", "",
+					plan.NewProject(
+						[]sql.Expression{
+							gf(0, "t1", "foo"),
+							gf(1, "t1", "bar"),
+						},
+						t1,
+					),
+				),
+			),
+			expected: plan.NewProject(
+				[]sql.Expression{
+					expression.NewAlias("one", lit(1)),
+				},
+				plan.NewSubqueryAlias("t", "",
+					plan.NewProject(
+						[]sql.Expression{
+							gf(0, "t1", "foo"),
+						},
+						t1,
+					),
+				),
+			),
+		},
+		{
+			name: "distinct is not pruned",
+			node: plan.NewProject(
+				[]sql.Expression{
+					gf(0, "t", "foo"),
+				},
+				plan.NewSubqueryAlias("t", "",
+					plan.NewDistinct(
+						plan.NewProject(
+							[]sql.Expression{
+								gf(0, "t1", "foo"),
+								gf(1, "t1", "bar"),
+							},
+							t1,
+						),
+					),
+				),
+			),
+		},
+	}
+
+	runTestCases(t, nil, testCases, nil, *rule)
+}
@@ -113,6 +113,11 @@ func canProject(n sql.Node, a *Analyzer) bool {
 		return false
 	}
 
+	// Projecting the left side of a join changes the row seen by a lateral subquery alias on its right side.
+	if hasLateralSubqueryAlias(n) {
+		return false
+	}
+
 	containsIndexedJoin := false
 	plan.Inspect(n, func(node sql.Node) bool {
 		if _, ok := node.(*plan.IndexedJoin); ok {
@@ -177,6 +182,12 @@ func filterPushdownChildSelector(c plan.TransformContext) bool {
 		// filters pushed below them. Instead, the step will be run
 		// again by the Transform function, starting at this node.
 		return false
+	case *plan.Limit, *plan.TopN, *plan.GroupBy:
+		// A filter above a limit or a group by was pushed into a
+		// subquery alias, and pushing it further would change which
+		// rows are limited or grouped. Like for windows, the step is
+		// run again below them.
+		return false
 	case *plan.IndexedJoin:
 		if n.JoinType() == plan.JoinTypeLeft || n.JoinType() == plan.JoinTypeRight {
 			return c.ChildNum == 0
@@ -290,10 +301,10 @@ func transformPushdownFilters(ctx *sql.Context, a *Analyzer, n sql.Node, scope *
 
 			// Then move filter predicates directly above their respective tables in joins
 			return pushdownAboveTables(node, filters)
-		case *plan.Window:
-			// Analyze below the Window in isolation to push down
+		case *plan.Window, *plan.Limit, *plan.TopN, *plan.GroupBy:
+			// Analyze below the node in isolation to push down
 			// any relevant indexes, for example.
-			child, err := pushdownFiltersAtNode(ctx, a, n.Child, scope)
+			child, err := pushdownFiltersAtNode(ctx, a, n.Children()[0], scope)
 			if err != nil {
 				return nil, err
 			}
@@ -373,11 +384,12 @@ func convertFiltersToIndexedAccess(
 			// TableAlias, but not to the resolved table directly
 			// beneath it.
 			return false
-		case *plan.Window:
+		case *plan.Window, *plan.Limit, *plan.TopN, *plan.GroupBy:
 			// Windows operate across the rows they see and cannot
-			// have filters pushed below them. If there is an index
-			// pushdown, it will get picked up in the isolated pass
-			// run by the filters pushdown transform.
+			// have filters pushed below them, and neither can
+			// limits and group bys. If there is an index pushdown,
+			// it will get picked up in the isolated pass run by the
+			// filters pushdown transform.
 			return false
 		}
 		return true
@@ -559,8 +571,30 @@ func pushdownFiltersToAboveTable(
 // Opaque, it behaves a little bit like a FilteredTable, and pushing the
 // filters down below it can help find index usage opportunities later in the
 // analysis phase.
+//
+// This is also how QUALIFY-style filters on window results are planned, as in
+// `SELECT ... FROM (SELECT ..., ROW_NUMBER() OVER (...) rn FROM t) sq WHERE rn = 1`.
+// The filter lands right above the subquery's Window, so rows are filtered as
+// soon as their window values are computed rather than after the subquery's
+// results are materialized. Filters never move below a Window, since that
+// would change the rows the window functions see, but limitWindowPartitions
+// later lets a ROW_NUMBER() Window skip the rows such a filter drops.
 func pushdownFiltersUnderSubqueryAlias(ctx *sql.Context, a *Analyzer, sa *plan.SubqueryAlias, filters *filterSet) (sql.Node, error) {
-	handled := filters.availableFiltersForTable(ctx, sa.Name())
+	// The child of a lateral subquery alias is analyzed with the left side of its join in scope, which the field
+	// indexes below don't account for.
+	if sa.Lateral {
+		return sa, nil
+	}
+	// Every reference to a materialized common table expression reads the rows computed for the first one.
+	if isMaterializedCte(sa) {
+		return sa, nil
+	}
+	var handled []sql.Expression
+	for _, f := range filters.availableFiltersForTable(ctx, sa.Name()) {
+		if canPushdownIntoSubqueryAlias(f) {
+			handled = append(handled, f)
+		}
+	}
 	if len(handled) == 0 {
 		return sa, nil
 	}
@@ -586,7 +620,131 @@ func pushdownFiltersUnderSubqueryAlias(ctx *sql.Context, a *Analyzer, sa *plan.S
 		})
 	}
 
-	return sa.WithChildren(plan.NewFilter(expression.JoinAnd(expressionsForChild...), sa.Child))
+	return sa.WithChildren(pushdownFilterInSubquery(sa.Child, expression.JoinAnd(expressionsForChild...)))
+}
+
+// canPushdownIntoSubqueryAlias returns whether the filter given can move into a subquery alias. A non-deterministic
+// filter could drop other rows inside the subquery alias than above it, and a subquery would lose its scope.
+func canPushdownIntoSubqueryAlias(filter sql.Expression) bool {
+	if !exprIsCacheable(filter, 0) {
+		return false
+	}
+	hasSubquery := false
+	sql.Inspect(filter, func(e sql.Expression) bool {
+		if _, ok := e.(*plan.Subquery); ok {
+			hasSubquery = true
+		}
+		return !hasSubquery
+	})
+	return !hasSubquery
+}
+
+// pushdownFilterInSubquery places the filter given, in terms of the schema of the node given, as low in the node as
+// it can go without changing the rows the node returns. The filter moves through projects, sorts, distincts and
+// other filters, and through group bys when it only reads grouping columns; each of its conjuncts moves on its own. It
+// never moves below a limit or a window, or below a column computed by an aggregation, a window function, a subquery
+// or a non-deterministic expression.
+func pushdownFilterInSubquery(n sql.Node, filter sql.Expression) sql.Node {
+	switch n := n.(type) {
+	case *plan.Project:
+		pushed, kept := splitProjectedFilters(filter, n.Projections, nil)
+		if pushed != nil {
+			return wrapInFilter(kept, plan.NewProject(n.Projections, pushdownFilterInSubquery(n.Child, pushed)))
+		}
+	case *plan.GroupBy:
+		pushed, kept := splitProjectedFilters(filter, n.SelectedExprs, n.GroupByExprs)
+		if pushed != nil {
+			return wrapInFilter(kept, plan.NewGroupBy(n.SelectedExprs, n.GroupByExprs, pushdownFilterInSubquery(n.Child, pushed)))
+		}
+	case *plan.Sort, *plan.Distinct, *plan.Filter:
+		node, err := n.WithChildren(pushdownFilterInSubquery(n.Children()[0], filter))
+		if err == nil {
+			return node
+		}
+	}
+	return plan.NewFilter(filter, n)
+}
+
+// splitProjectedFilters splits the conjunction given into the filters that can move below the projection, with their
+// fields replaced, and the ones that must stay above it. Either result is nil when it has no filters.
+func splitProjectedFilters(filter sql.Expression, projections, grouping []sql.Expression) (pushed, kept sql.Expression) {
+	var below, above []sql.Expression
+	for _, f := range splitConjunction(filter) {
+		if replaced, ok := replaceProjectedFields(f, projections, grouping); ok {
+			below = append(below, replaced)
+		} else {
+			above = append(above, f)
+		}
+	}
+	return expression.JoinAnd(below...), expression.JoinAnd(above...)
+}
+
+func wrapInFilter(filter sql.Expression, n sql.Node) sql.Node {
+	if filter == nil {
+		return n
+	}
+	return plan.NewFilter(filter, n)
+}
+
+// replaceProjectedFields replaces the fields of the filter given with the expressions of the projection they read,
+// so that the filter can be evaluated below the projection. When grouping expressions are given, only the fields
+// of grouping columns can be replaced. It returns false if a field can't be replaced.
+func replaceProjectedFields(filter sql.Expression, projections, grouping []sql.Expression) (sql.Expression, bool) {
+	ok := true
+	replaced, err := expression.TransformUp(filter, func(e sql.Expression) (sql.Expression, error) {
+		gf, isField := e.(*expression.GetField)
+		if !isField {
+			return e, nil
+		}
+		if gf.Index() >= len(projections) {
+			ok = false
+			return e, nil
+		}
+
+		p := projections[gf.Index()]
+		if alias, isAlias := p.(*expression.Alias); isAlias {
+			p = alias.Child
+		}
+		if !canPushFilterThrough(p) || (grouping != nil && !isGroupingField(p, grouping)) {
+			ok = false
+		}
+		return p, nil
+	})
+	if err != nil || !ok {
+		return nil, false
+	}
+	return replaced, true
+}
+
+// canPushFilterThrough returns whether a filter on the column computed by the expression given can be evaluated
+// below the node computing it.
+func canPushFilterThrough(e sql.Expression) bool {
+	can := true
+	sql.Inspect(e, func(e sql.Expression) bool {
+		switch e.(type) {
+		case sql.WindowAdaptableExpression, *plan.Subquery:
+			can = false
+		default:
+			if isNonDeterministic(e) {
+				can = false
+			}
+		}
+		return can
+	})
+	return can
+}
+
+func isGroupingField(e sql.Expression, grouping []sql.Expression) bool {
+	gf, ok := e.(*expression.GetField)
+	if !ok {
+		return false
+	}
+	for _, g := range grouping {
+		if ggf, ok := g.(*expression.GetField); ok && ggf.Index() == gf.Index() {
+			return true
+		}
+	}
+	return false
 }
 
 // pushdownIndexesToTable attempts to convert filter predicates to indexes on tables that implement
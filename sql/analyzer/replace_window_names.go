@@ -22,8 +22,8 @@ import (
 
 // replaceNamedWindows will 1) extract window definitions from a *plan.NamedWindows node,
 // 2) resolve window name references, 3) embed resolved window definitions in sql.Window clauses
-// (currently in expression.UnresolvedFunction instances), and 4) replace the plan.NamedWindows
-// node with its child *plan.Window.
+// (in expression.UnresolvedFunction instances, and in sql.Aggregation instances such as
+// GROUP_CONCAT), and 4) replace the plan.NamedWindows node with its child *plan.Window.
 func replaceNamedWindows(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
 	return plan.TransformUp(n, func(n sql.Node) (sql.Node, error) {
 		switch n.(type) {
@@ -48,18 +48,29 @@ func replaceNamedWindows(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope
 			newExprs := make([]sql.Expression, len(window.SelectExprs))
 			for i, expr := range window.SelectExprs {
 				newExprs[i], err = expression.TransformUp(expr, func(e sql.Expression) (sql.Expression, error) {
-					uf, ok := e.(*expression.UnresolvedFunction)
-					if !ok {
+					switch e := e.(type) {
+					case *expression.UnresolvedFunction:
+						if e.Window == nil {
+							return e, nil
+						}
+						newWindow, err := resolveWindowDef(e.Window, wn.WindowDefs)
+						if err != nil {
+							return nil, err
+						}
+						return e.WithWindow(newWindow), nil
+					case sql.Aggregation:
+						// aggregations the parser creates directly, like GROUP_CONCAT
+						if e.Window() == nil {
+							return e, nil
+						}
+						newWindow, err := resolveWindowDef(e.Window(), wn.WindowDefs)
+						if err != nil {
+							return nil, err
+						}
+						return e.WithWindow(newWindow)
+					default:
 						return e, nil
 					}
-					if uf.Window == nil {
-						return e, nil
-					}
-					newWindow, err := resolveWindowDef(uf.Window, wn.WindowDefs)
-					if err != nil {
-						return nil, err
-					}
-					return uf.WithWindow(newWindow), nil
 				})
 				if err != nil {
 					return nil, err
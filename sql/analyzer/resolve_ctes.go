@@ -34,7 +34,103 @@ func resolveCommonTableExpressions(ctx *sql.Context, a *Analyzer, n sql.Node, sc
 		return n, nil
 	}
 
-	return resolveCtesInNode(ctx, a, n, scope, make(map[string]sql.Node))
+	ctes := make(map[string]sql.Node)
+	n, err := resolveCtesInNode(ctx, a, n, scope, ctes)
+	if err != nil {
+		return nil, err
+	}
+
+	return unshareSingleCteReferences(n, ctes)
+}
+
+// unshareSingleCteReferences removes the Materialization of the common table expressions given that the node given
+// references only once. A single reference has no other reference to agree with, so it's left to the rules that prune
+// and push filters into subquery aliases.
+func unshareSingleCteReferences(n sql.Node, ctes map[string]sql.Node) (sql.Node, error) {
+	refs := make(map[*plan.Materialization]int)
+	for _, cte := range ctes {
+		if sa, ok := cte.(*plan.SubqueryAlias); ok && sa.Materialization != nil {
+			refs[sa.Materialization] = 0
+		}
+	}
+	if len(refs) == 0 {
+		return n, nil
+	}
+
+	countCteReferences(n, refs)
+
+	single := make(map[*plan.Materialization]bool)
+	for m, count := range refs {
+		if count < 2 {
+			single[m] = true
+		}
+	}
+	if len(single) == 0 {
+		return n, nil
+	}
+
+	return removeMaterializations(n, single)
+}
+
+// countCteReferences counts the references in the node given, including the ones in subquery aliases and subquery
+// expressions, to each of the materializations in |refs|.
+func countCteReferences(n sql.Node, refs map[*plan.Materialization]int) {
+	plan.Inspect(n, func(n sql.Node) bool {
+		if sa, ok := n.(*plan.SubqueryAlias); ok {
+			if _, ok := refs[sa.Materialization]; ok {
+				refs[sa.Materialization]++
+			}
+		}
+		if ne, ok := n.(sql.Expressioner); ok {
+			for _, e := range ne.Expressions() {
+				sql.Inspect(e, func(e sql.Expression) bool {
+					if sq, ok := e.(*plan.Subquery); ok {
+						countCteReferences(sq.Query, refs)
+						return false
+					}
+					return true
+				})
+			}
+		}
+		return true
+	})
+}
+
+// removeMaterializations removes the materializations given from the subquery aliases of the node given, including
+// the ones in subquery aliases and subquery expressions.
+func removeMaterializations(n sql.Node, materializations map[*plan.Materialization]bool) (sql.Node, error) {
+	return plan.TransformUpWithOpaque(n, func(n sql.Node) (sql.Node, error) {
+		n, err := plan.TransformExpressions(n, func(e sql.Expression) (sql.Expression, error) {
+			return expression.TransformUp(e, func(e sql.Expression) (sql.Expression, error) {
+				sq, ok := e.(*plan.Subquery)
+				if !ok {
+					return e, nil
+				}
+				query, err := removeMaterializations(sq.Query, materializations)
+				if err != nil {
+					return nil, err
+				}
+				return sq.WithQuery(query), nil
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if sa, ok := n.(*plan.SubqueryAlias); ok && materializations[sa.Materialization] {
+			return sa.WithMaterialization(nil), nil
+		}
+		return n, nil
+	})
+}
+
+// isMaterializedCte returns whether the subquery alias given is a reference to a common table expression that is
+// materialized by materializeCtes: one that is referenced more than once and has non-deterministic expressions. Every
+// reference to such a CTE must keep the child it was resolved with, so that all of them read the same rows. A
+// deterministic CTE returns the same rows at every reference anyway, so its references are optimized like any other
+// subquery alias.
+func isMaterializedCte(sa *plan.SubqueryAlias) bool {
+	return sa.Materialization != nil && !isDeterminstic(sa.Child)
 }
 
 func resolveCtesInNode(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope, ctes map[string]sql.Node) (sql.Node, error) {
@@ -114,9 +210,8 @@ func stripWith(ctx *sql.Context, a *Analyzer, scope *Scope, n sql.Node, ctes map
 		subquery := cte.Subquery
 
 		if len(cte.Columns) > 0 {
-			schemaLen := schemaLength(subquery)
-			if schemaLen != len(cte.Columns) {
-				return nil, sql.ErrColumnCountMismatch.New()
+			if err := validateSubqueryAliasColumns(cte.Columns, subquery); err != nil {
+				return nil, err
 			}
 
 			subquery = subquery.WithColumns(cte.Columns)
@@ -137,13 +232,30 @@ func stripWith(ctx *sql.Context, a *Analyzer, scope *Scope, n sql.Node, ctes map
 				rCte,
 			)
 		} else {
-			ctes[strings.ToLower(cteName)] = subquery
+			ctes[strings.ToLower(cteName)] = subquery.WithMaterialization(plan.NewMaterialization())
 		}
 	}
 
 	return with.Child, nil
 }
 
+// materializeCtes wraps the references to a common table expression that is referenced more than once and contains
+// non-deterministic expressions in a plan.MaterializedResults, so that every reference reads the same result set. The
+// rules that prune the columns of subquery aliases and push filters into them leave these references alone, so that
+// the rows computed for one reference have the columns and rows every other reference expects.
+func materializeCtes(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	return plan.TransformUpCtx(n, nil, func(c plan.TransformContext) (sql.Node, error) {
+		sa, ok := c.Node.(*plan.SubqueryAlias)
+		if !ok || !isMaterializedCte(sa) {
+			return c.Node, nil
+		}
+		if _, ok := c.Parent.(*plan.MaterializedResults); ok {
+			return c.Node, nil
+		}
+		return plan.NewMaterializedResults(sa, sa.Materialization), nil
+	})
+}
+
 // schemaLength returns the length of a node's schema without actually accessing it. Useful when a node isn't yet
 // resolved, so Schema() could fail.
 func schemaLength(node sql.Node) int {
@@ -264,7 +376,12 @@ func resolveRecursiveCte(ctx *sql.Context, a *Analyzer, node sql.Node, sq sql.No
 		return node, nil
 	}
 
-	newInit, err := a.analyzeThroughBatch(ctx, rCte.Init, scope, "default-rules")
+	initCtx, err := a.withNestedScope(ctx)
+	if err != nil {
+		return node, err
+	}
+
+	newInit, err := a.analyzeThroughBatch(initCtx, rCte.Init, scope, "default-rules")
 	if err != nil {
 		return node, err
 	}
@@ -15,6 +15,9 @@
 package analyzer
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
 	"github.com/dolthub/go-mysql-server/sql/grant_tables"
@@ -49,7 +52,12 @@ func resolveTableFunctions(ctx *sql.Context, a *Analyzer, n sql.Node, _ *Scope)
 			database = privilegedDatabase.Unwrap()
 		}
 
-		newInstance, err := tableFunction.NewInstance(ctx, database, utf.Arguments)
+		arguments, err := bindTableFunctionArguments(tableFunction, utf)
+		if err != nil {
+			return nil, err
+		}
+
+		newInstance, err := tableFunction.NewInstance(ctx, database, arguments)
 		if err != nil {
 			return nil, err
 		}
@@ -58,6 +66,70 @@ func resolveTableFunctions(ctx *sql.Context, a *Analyzer, n sql.Node, _ *Scope)
 	})
 }
 
+// bindTableFunctionArguments returns the arguments of the table function call given in positional order. Arguments
+// given by name are placed at the position of the parameter with that name, and parameters that aren't given take
+// their default value.
+func bindTableFunctionArguments(tableFunction sql.TableFunction, utf *expression.UnresolvedTableFunction) ([]sql.Expression, error) {
+	name := utf.FunctionName()
+	ntf, ok := tableFunction.(sql.NamedArgumentsTableFunction)
+	if !ok {
+		if utf.HasNamedArguments() {
+			return nil, sql.ErrTableFunctionNamedArguments.New(name)
+		}
+		return utf.Arguments, nil
+	}
+
+	params := ntf.Parameters()
+	arguments := make([]sql.Expression, len(params))
+	var named bool
+	for i, arg := range utf.Arguments {
+		var argName string
+		if i < len(utf.ArgumentNames) {
+			argName = utf.ArgumentNames[i]
+		}
+
+		if argName == "" {
+			if named {
+				return nil, sql.ErrPositionalTableFunctionArgument.New(name)
+			}
+			if i >= len(params) {
+				return nil, sql.ErrInvalidArgumentNumber.New(name, len(params), len(utf.Arguments))
+			}
+			arguments[i] = arg
+			continue
+		}
+
+		named = true
+		idx := -1
+		for j, param := range params {
+			if strings.EqualFold(param.Name, argName) {
+				idx = j
+				break
+			}
+		}
+
+		if idx < 0 {
+			return nil, sql.ErrUnknownTableFunctionArgument.New(name, argName)
+		}
+		if arguments[idx] != nil {
+			return nil, sql.ErrDuplicateTableFunctionArgument.New(name, params[idx].Name)
+		}
+		arguments[idx] = arg
+	}
+
+	for i, param := range params {
+		if arguments[i] != nil {
+			continue
+		}
+		if param.Default == nil {
+			return nil, sql.ErrMissingTableFunctionArgument.New(name, param.Name)
+		}
+		arguments[i] = param.Default
+	}
+
+	return arguments, nil
+}
+
 // resolveFunctions replaces UnresolvedFunction nodes with equivalent functions from the Catalog.
 func resolveFunctions(ctx *sql.Context, a *Analyzer, n sql.Node, _ *Scope) (sql.Node, error) {
 	span, _ := ctx.Span("resolve_functions")
@@ -89,11 +161,24 @@ func resolveFunctionsInExpr(ctx *sql.Context, a *Analyzer) sql.TransformExprFunc
 			return nil, err
 		}
 
+		// Check the arity before constructing, so that every function reports
+		// a wrong number of arguments the same way.
+		if err := sql.ValidateArity(f, len(uf.Arguments)); err != nil {
+			return nil, err
+		}
+
 		rf, err := f.NewInstance(uf.Arguments)
 		if err != nil {
 			return nil, err
 		}
 
+		if uf.Distinct {
+			rf, err = withDistinctArgument(n, rf)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		// Because of the way that we instantiate functions, we need to pass in the window from the UnresolvedFunction
 		// separately. Otherwise we would need to change function constructors to all consider windows, when most
 		// functions don't have a window expression.
@@ -114,3 +199,23 @@ func resolveFunctionsInExpr(ctx *sql.Context, a *Analyzer) sql.TransformExprFunc
 		return rf, nil
 	}
 }
+
+// withDistinctArgument wraps the argument of the aggregate function given in a DistinctExpression, so that
+// every aggregate qualified with DISTINCT shares the same deduplication instead of implementing its own.
+func withDistinctArgument(name string, e sql.Expression) (sql.Expression, error) {
+	if _, ok := e.(sql.Aggregation); !ok {
+		return nil, sql.ErrUnsupportedSyntax.New(fmt.Sprintf("DISTINCT in non-aggregate function %s", name))
+	}
+
+	children := append([]sql.Expression(nil), e.Children()...)
+	if len(children) == 0 {
+		return nil, sql.ErrUnsupportedSyntax.New(fmt.Sprintf("DISTINCT in function %s without arguments", name))
+	}
+
+	if _, ok := children[0].(*expression.Star); ok {
+		return nil, sql.ErrUnsupportedSyntax.New(fmt.Sprintf("DISTINCT * in function %s", name))
+	}
+
+	children[0] = expression.NewDistinctExpression(children[0])
+	return e.WithChildren(children...)
+}
@@ -0,0 +1,58 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestResolveFunctionsCaseInsensitive(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+	a := NewDefault(sql.NewDatabaseProvider())
+
+	project := func(name string) sql.Node {
+		return plan.NewProject(
+			[]sql.Expression{
+				expression.NewUnresolvedFunction(name, false, nil, expression.NewLiteral("a", sql.LongText), expression.NewLiteral("b", sql.LongText)),
+			},
+			plan.NewUnresolvedTable("dual", ""),
+		)
+	}
+
+	lower, err := resolveFunctions(ctx, a, project("concat"), nil)
+	require.NoError(err)
+	upper, err := resolveFunctions(ctx, a, project("CONCAT"), nil)
+	require.NoError(err)
+	require.Equal(lower, upper)
+
+	_, err = resolveFunctions(ctx, a, project("concatt"), nil)
+	require.True(sql.ErrFunctionNotFound.Is(err))
+	require.Equal("function: 'concatt' not found: did you mean concat?", err.Error())
+
+	_, err = resolveFunctions(ctx, a, project("notAFunctionAtAll"), nil)
+	require.True(sql.ErrFunctionNotFound.Is(err))
+	require.Equal("function: 'notAFunctionAtAll' not found", err.Error())
+
+	_, err = resolveFunctions(ctx, a, project("CONCATT"), nil)
+	require.True(sql.ErrFunctionNotFound.Is(err))
+	require.Equal("function: 'CONCATT' not found: did you mean concat?", err.Error())
+}
@@ -398,10 +398,20 @@ func aggregationEquals(ctx *sql.Context, a, b sql.Expression) bool {
 
 	switch a := a.(type) {
 	case *aggregation.Count:
+		b, ok := b.(*aggregation.Count)
+		if !ok {
+			return false
+		}
+
 		// it doesn't matter what's inside a Count, the result will be
-		// the same.
-		_, ok := b.(*aggregation.Count)
-		return ok
+		// the same, unless it only counts distinct values.
+		aDistinct, aOk := a.Child.(*expression.DistinctExpression)
+		bDistinct, bOk := b.Child.(*expression.DistinctExpression)
+		if aOk || bOk {
+			return aOk && bOk && aggregationChildEquals(ctx, aDistinct.Child, bDistinct.Child)
+		}
+
+		return true
 	case *aggregation.CountDistinct:
 		// it doesn't matter what's inside a Count, the result will be
 		// the same.
@@ -455,6 +465,13 @@ func aggregationEquals(ctx *sql.Context, a, b sql.Expression) bool {
 			return false
 		}
 
+		return aggregationChildEquals(ctx, a.Child, b.Child)
+	case *aggregation.Collect:
+		b, ok := b.(*aggregation.Collect)
+		if !ok {
+			return false
+		}
+
 		return aggregationChildEquals(ctx, a.Child, b.Child)
 	default:
 		return false
@@ -15,8 +15,15 @@
 package analyzer
 
 import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
 	"github.com/dolthub/go-mysql-server/sql/plan"
 )
 
@@ -27,26 +34,209 @@ func resolveSubqueries(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope)
 	return plan.TransformUp(n, func(n sql.Node) (sql.Node, error) {
 		switch n := n.(type) {
 		case *plan.SubqueryAlias:
+			// Lateral subqueries are analyzed along with their join, below
+			if n.Lateral {
+				return n, nil
+			}
+			cache := subqueryCacheFromContext(ctx)
+			if cache.analyzed(resolveSubqueriesStage, n.Child) {
+				return n, nil
+			}
+
+			subqueryCtx, err := a.withNestedScope(ctx)
+			if err != nil {
+				return nil, err
+			}
+
 			// subqueries do not have access to outer scope
-			child, err := a.analyzeThroughBatch(ctx, n.Child, nil, "default-rules")
+			child, err := a.analyzeThroughBatch(subqueryCtx, n.Child, nil, "default-rules")
 			if err != nil {
 				return nil, err
 			}
 
-			if len(n.Columns) > 0 {
-				schemaLen := schemaLength(n.Child)
-				if schemaLen != len(n.Columns) {
-					return nil, sql.ErrColumnCountMismatch.New()
-				}
+			if err := validateSubqueryAliasColumns(n.Columns, n.Child); err != nil {
+				return nil, err
 			}
 
-			return n.WithChildren(StripPassthroughNodes(child))
+			child = StripPassthroughNodes(child)
+			cache.add(resolveSubqueriesStage, child)
+			return n.WithChildren(child)
+		case plan.JoinNode, *plan.CrossJoin:
+			return analyzeLateralSubqueryAlias(ctx, a, n, scope, a.analyzeThroughBatch)
 		default:
 			return n, nil
 		}
 	})
 }
 
+// subqueryStage is a stage of the analysis of the children of subquery aliases. Later stages include the earlier ones.
+type subqueryStage byte
+
+const (
+	// resolveSubqueriesStage is the analysis through the default rules done by resolveSubqueries
+	resolveSubqueriesStage subqueryStage = iota + 1
+	// finalizeSubqueriesStage is the analysis from the default rules on done by finalizeSubqueries
+	finalizeSubqueriesStage
+)
+
+// subqueryCacheKey is the key of the subqueryCache in the context of an analysis.
+type subqueryCacheKey struct{}
+
+// subqueryCache records the children of subquery aliases that have been analyzed during an analysis, along with the
+// last stage of their analysis, so that they aren't analyzed again by later passes over the nodes containing them, such
+// as the repeated analysis of subquery expressions selecting from views. Children are recorded by identity, so a child
+// that has been changed since its analysis is a new node and is analyzed again. Subquery aliases are analyzed without
+// an outer scope, which makes the result of their analysis depend only on their child.
+type subqueryCache struct {
+	mu       sync.Mutex
+	children map[sql.Node]subqueryStage
+}
+
+// withSubqueryCache returns a context holding a subqueryCache for an analysis, unless the context given already has one.
+func withSubqueryCache(ctx *sql.Context) *sql.Context {
+	if subqueryCacheFromContext(ctx) != nil {
+		return ctx
+	}
+	cache := &subqueryCache{children: make(map[sql.Node]subqueryStage)}
+	return ctx.WithContext(context.WithValue(ctx.Context, subqueryCacheKey{}, cache))
+}
+
+// subqueryCacheFromContext returns the subqueryCache of the context given, or nil if it has none.
+func subqueryCacheFromContext(ctx *sql.Context) *subqueryCache {
+	cache, _ := ctx.Value(subqueryCacheKey{}).(*subqueryCache)
+	return cache
+}
+
+// analyzed returns whether the node given is the result of an analysis through the stage given.
+func (c *subqueryCache) analyzed(stage subqueryStage, child sql.Node) bool {
+	if c == nil || !isPointerNode(child) {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.children[child] >= stage
+}
+
+// add records the node given as the result of an analysis through the stage given.
+func (c *subqueryCache) add(stage subqueryStage, child sql.Node) {
+	if c == nil || !isPointerNode(child) {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.children[child] = stage
+}
+
+// addFinalized records the children of the subquery aliases of the node given, which is the result of a complete
+// analysis, as analyzed through every stage. The rules following finalizeSubqueries may have changed these children
+// since they were recorded by finalizeSubqueries.
+func (c *subqueryCache) addFinalized(n sql.Node) {
+	if c == nil {
+		return
+	}
+	plan.Inspect(n, func(n sql.Node) bool {
+		if sa, ok := n.(*plan.SubqueryAlias); ok && !sa.Lateral {
+			c.add(finalizeSubqueriesStage, sa.Child)
+		}
+		return true
+	})
+}
+
+// isPointerNode returns whether the node given is a pointer, the only nodes whose identity can be recorded.
+func isPointerNode(n sql.Node) bool {
+	return n != nil && reflect.TypeOf(n).Kind() == reflect.Ptr
+}
+
+// analyzeLateralSubqueryAlias analyzes the child of a lateral subquery alias on the right side of the join given. The
+// columns of the left side of the join are in scope for the lateral subquery, except for right joins, where the left
+// side is the secondary. This scope matches the row each join implementation passes to the RowIter of its right side.
+func analyzeLateralSubqueryAlias(
+	ctx *sql.Context,
+	a *Analyzer,
+	join sql.Node,
+	scope *Scope,
+	analyze func(*sql.Context, sql.Node, *Scope, string) (sql.Node, error),
+) (sql.Node, error) {
+	children := join.Children()
+	sa, ok := children[1].(*plan.SubqueryAlias)
+	if !ok || !sa.Lateral {
+		return join, nil
+	}
+
+	lateralScope := scope
+	if _, ok := join.(*plan.RightJoin); !ok {
+		lateralScope = scope.newScope(plan.NewProject([]sql.Expression{expression.NewStar()}, joinedTables(children[0])))
+	}
+
+	subqueryCtx, err := a.withNestedScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	child, err := analyze(subqueryCtx, sa.Child, lateralScope, "default-rules")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateSubqueryAliasColumns(sa.Columns, sa.Child); err != nil {
+		return nil, err
+	}
+
+	newSa, err := sa.WithChildren(StripPassthroughNodes(child))
+	if err != nil {
+		return nil, err
+	}
+	return join.WithChildren(children[0], newSa)
+}
+
+// joinedTables returns the tables joined by the node given as a cross join, which has the same schema. The conditions
+// of the joins may not be resolved yet, which keeps the joins themselves from being used as a scope.
+func joinedTables(n sql.Node) sql.Node {
+	switch n := n.(type) {
+	case plan.JoinNode, *plan.CrossJoin:
+		children := n.Children()
+		return plan.NewCrossJoin(joinedTables(children[0]), joinedTables(children[1]))
+	default:
+		return n
+	}
+}
+
+// validateSubqueryAliasColumns returns an error if the explicit column list given for a derived table or common table
+// expression doesn't name every column of its query exactly once. An empty list is always valid.
+func validateSubqueryAliasColumns(columns []string, child sql.Node) error {
+	if len(columns) == 0 {
+		return nil
+	}
+
+	if schemaLength(child) != len(columns) {
+		return sql.ErrColumnCountMismatch.New()
+	}
+
+	seen := make(map[string]struct{}, len(columns))
+	for _, col := range columns {
+		lower := strings.ToLower(col)
+		if _, ok := seen[lower]; ok {
+			return sql.ErrDuplicateColumnName.New(col)
+		}
+		seen[lower] = struct{}{}
+	}
+
+	return nil
+}
+
+// hasLateralSubqueryAlias returns whether the node given contains a lateral subquery alias. Rules that change the
+// schema of a join's left side, or move tables between sides, must not be applied to such a node.
+func hasLateralSubqueryAlias(n sql.Node) bool {
+	found := false
+	plan.Inspect(n, func(n sql.Node) bool {
+		if sa, ok := n.(*plan.SubqueryAlias); ok && sa.Lateral {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
 func finalizeSubqueries(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
 	span, ctx := ctx.Span("finalize_subqueries")
 	defer span.Finish()
@@ -54,20 +244,35 @@ func finalizeSubqueries(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope)
 	return plan.TransformUp(n, func(n sql.Node) (sql.Node, error) {
 		switch n := n.(type) {
 		case *plan.SubqueryAlias:
+			// Lateral subqueries are analyzed along with their join, below
+			if n.Lateral {
+				return n, nil
+			}
+			cache := subqueryCacheFromContext(ctx)
+			if cache.analyzed(finalizeSubqueriesStage, n.Child) {
+				return n, nil
+			}
+
+			subqueryCtx, err := a.withNestedScope(ctx)
+			if err != nil {
+				return nil, err
+			}
+
 			// subqueries do not have access to outer scope
-			child, err := a.analyzeStartingAtBatch(ctx, n.Child, nil, "default-rules")
+			child, err := a.analyzeStartingAtBatch(subqueryCtx, n.Child, nil, "default-rules")
 			if err != nil {
 				return nil, err
 			}
 
-			if len(n.Columns) > 0 {
-				schemaLen := schemaLength(n.Child)
-				if schemaLen != len(n.Columns) {
-					return nil, sql.ErrColumnCountMismatch.New()
-				}
+			if err := validateSubqueryAliasColumns(n.Columns, n.Child); err != nil {
+				return nil, err
 			}
 
-			return n.WithChildren(StripPassthroughNodes(child))
+			child = StripPassthroughNodes(child)
+			cache.add(finalizeSubqueriesStage, child)
+			return n.WithChildren(child)
+		case plan.JoinNode, *plan.CrossJoin:
+			return analyzeLateralSubqueryAlias(ctx, a, n, scope, a.analyzeStartingAtBatch)
 		default:
 			return n, nil
 		}
@@ -80,11 +285,22 @@ func flattenTableAliases(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope
 	return plan.TransformUp(n, func(n sql.Node) (sql.Node, error) {
 		switch n := n.(type) {
 		case *plan.TableAlias:
-			if sa, isSA := n.Children()[0].(*plan.SubqueryAlias); isSA {
+			// Walk down the whole chain of aliases under this one, so it collapses to a single node with the
+			// outermost name however deep it is.
+			child := n.Child
+			var inner *plan.TableAlias
+			for {
+				ta, isTA := child.(*plan.TableAlias)
+				if !isTA {
+					break
+				}
+				inner, child = ta, ta.Child
+			}
+			if sa, isSA := child.(*plan.SubqueryAlias); isSA {
 				return sa.WithName(n.Name()), nil
 			}
-			if ta, isTA := n.Children()[0].(*plan.TableAlias); isTA {
-				return ta.WithName(n.Name()), nil
+			if inner != nil {
+				return inner.WithName(n.Name()), nil
 			}
 			return n, nil
 		default:
@@ -94,35 +310,185 @@ func flattenTableAliases(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope
 }
 
 func resolveSubqueryExpressions(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
-	return plan.TransformExpressionsUpWithNode(n, func(n sql.Node, e sql.Expression) (sql.Expression, error) {
-		s, ok := e.(*plan.Subquery)
-		// We always analyze subquery expressions even if they are resolved, since other transformations to the surrounding
-		// query might cause them to need to shift their field indexes.
-		if !ok {
-			return e, nil
+	return plan.TransformUp(n, func(n sql.Node) (sql.Node, error) {
+		var analyzed map[*plan.Subquery]subqueryAnalysis
+		if a != nil && a.ParallelSubqueryAnalysis {
+			analyzed = analyzeUncorrelatedSubqueries(ctx, a, n, scope)
 		}
 
-		subqueryCtx, cancelFunc := ctx.NewSubContext()
-		defer cancelFunc()
-		subScope := scope.newScope(n)
+		return plan.TransformExpressionsWithNode(n, func(n sql.Node, e sql.Expression) (sql.Expression, error) {
+			s, ok := e.(*plan.Subquery)
+			// We always analyze subquery expressions even if they are resolved, since other transformations to the surrounding
+			// query might cause them to need to shift their field indexes.
+			if !ok {
+				return e, nil
+			}
 
-		analyzed, err := a.Analyze(subqueryCtx, s.Query, subScope)
-		if err != nil {
-			// We ignore certain errors, deferring them to later analysis passes. Specifically, if the subquery isn't
-			// resolved or a column can't be found in the scope node, wait until a later pass.
-			// TODO: we won't be able to give the right error message in all cases when we do this, although we attempt to
-			//  recover the actual error in the validation step.
-			if ErrValidationResolved.Is(err) || sql.ErrTableColumnNotFound.Is(err) || sql.ErrColumnNotFound.Is(err) {
-				// keep the work we have and defer remainder of analysis of this subquery until a later pass
-				return s.WithQuery(analyzed), nil
-			}
-			return nil, err
-		}
+			res, ok := analyzed[s]
+			if !ok {
+				res = analyzeSubqueryExpression(ctx, a, n, s, scope)
+			}
+			if res.err != nil {
+				// We ignore certain errors, deferring them to later analysis passes. Specifically, if the subquery isn't
+				// resolved or a column can't be found in the scope node, wait until a later pass.
+				if ErrValidationResolved.Is(res.err) || sql.ErrTableColumnNotFound.Is(res.err) || sql.ErrColumnNotFound.Is(res.err) {
+					// keep the work we have and defer remainder of analysis of this subquery until a later pass, along with
+					// the error, which is reported if the subquery is still unresolved when it's validated
+					return s.WithQuery(res.node).WithDeferredError(res.err), nil
+				}
+				return nil, res.err
+			}
 
-		return s.WithQuery(StripPassthroughNodes(analyzed)), nil
+			analyzed := StripPassthroughNodes(res.node)
+			subqueryCacheFromContext(ctx).addFinalized(analyzed)
+			return s.WithQuery(analyzed).WithDeferredError(nil), nil
+		})
 	})
 }
 
+// withNestedScope returns a context for the analysis of a subquery or common table expression nested in the query of
+// the context given. Returns ErrMaxNestingDepthExceeded if it's nested more deeply than the analyzer allows.
+func (a *Analyzer) withNestedScope(ctx *sql.Context) (*sql.Context, error) {
+	max := a.MaxNestingDepth
+	if max <= 0 {
+		max = DefaultMaxNestingDepth
+	}
+	return ctx.WithNestingDepth(max)
+}
+
+// subqueryAnalysis is the result of the analysis of the query of a subquery expression.
+type subqueryAnalysis struct {
+	node sql.Node
+	err  error
+}
+
+// analyzeSubqueryExpression analyzes the query of the subquery expression given, which is an expression of the node
+// given.
+func analyzeSubqueryExpression(ctx *sql.Context, a *Analyzer, n sql.Node, s *plan.Subquery, scope *Scope) subqueryAnalysis {
+	subqueryCtx, err := a.withNestedScope(ctx)
+	if err != nil {
+		return subqueryAnalysis{err: err}
+	}
+	subqueryCtx, cancelFunc := subqueryCtx.NewSubContext()
+	defer cancelFunc()
+	subScope := scope.newScope(n)
+
+	analyzed, err := a.Analyze(subqueryCtx, s.Query, subScope)
+	return subqueryAnalysis{node: analyzed, err: err}
+}
+
+// analyzeUncorrelatedSubqueries analyzes the uncorrelated subquery expressions of the node given concurrently, and
+// returns the results of their analysis. Nothing is analyzed unless there are at least two such subqueries. Every
+// analysis has its own copy of the analyzer, whose debug context stack is the only state that analysis modifies.
+func analyzeUncorrelatedSubqueries(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) map[*plan.Subquery]subqueryAnalysis {
+	ne, ok := n.(sql.Expressioner)
+	if !ok {
+		return nil
+	}
+
+	subScope := scope.newScope(n)
+	var subqueries []*plan.Subquery
+	for _, e := range ne.Expressions() {
+		sql.Inspect(e, func(e sql.Expression) bool {
+			if s, ok := e.(*plan.Subquery); ok && isUncorrelatedSubquery(s.Query, subScope) {
+				subqueries = append(subqueries, s)
+			}
+			return true
+		})
+	}
+	if len(subqueries) < 2 {
+		return nil
+	}
+
+	results := make([]subqueryAnalysis, len(subqueries))
+	var wg sync.WaitGroup
+	for i, s := range subqueries {
+		wg.Add(1)
+		go func(i int, s *plan.Subquery) {
+			defer wg.Done()
+			subAnalyzer := *a
+			subAnalyzer.contextStack = append([]string(nil), a.contextStack...)
+			results[i] = analyzeSubqueryExpression(ctx, &subAnalyzer, n, s, scope)
+		}(i, s)
+	}
+	wg.Wait()
+
+	analyzed := make(map[*plan.Subquery]subqueryAnalysis, len(subqueries))
+	for i, s := range subqueries {
+		analyzed[s] = results[i]
+	}
+	return analyzed
+}
+
+// isUncorrelatedSubquery returns whether the query of a subquery expression doesn't reference any columns of the outer
+// scope given. Before the query is resolved, its columns must be qualified by one of its tables, or have a name that
+// isn't in the outer scope. Columns that can't be told apart from those of the outer scope make the query correlated,
+// as do unqualified columns when the outer scope isn't resolved yet.
+func isUncorrelatedSubquery(query sql.Node, scope *Scope) bool {
+	scopeSchema := scope.Schema()
+	if query.Resolved() {
+		return len(outerScopeFieldIndexes(query, len(scopeSchema))) == 0
+	}
+
+	tables := make(map[string]bool)
+	var columns []*expression.UnresolvedColumn
+	uncorrelated := true
+	var inspect func(n sql.Node)
+	inspect = func(n sql.Node) {
+		plan.Inspect(n, func(n sql.Node) bool {
+			if nameable, ok := n.(sql.Nameable); ok {
+				tables[strings.ToLower(nameable.Name())] = true
+			}
+			return true
+		})
+		plan.InspectExpressions(n, func(e sql.Expression) bool {
+			switch e := e.(type) {
+			case *expression.UnresolvedColumn:
+				columns = append(columns, e)
+			case *deferredColumn:
+				columns = append(columns, e.UnresolvedColumn)
+			case *expression.GetField:
+				if e.Index() < len(scopeSchema) {
+					uncorrelated = false
+				}
+			case *plan.Subquery:
+				inspect(e.Query)
+				return false
+			}
+			return true
+		})
+	}
+	inspect(query)
+	if !uncorrelated {
+		return false
+	}
+
+	scopeResolved := true
+	for _, n := range scope.OuterToInner() {
+		for _, child := range n.Children() {
+			scopeResolved = scopeResolved && child.Resolved()
+		}
+	}
+
+	for _, c := range columns {
+		if c.Table() != "" {
+			if !tables[strings.ToLower(c.Table())] {
+				return false
+			}
+			continue
+		}
+		if !scopeResolved {
+			return false
+		}
+		for _, col := range scopeSchema {
+			if col.Name == "" || strings.EqualFold(col.Name, c.Name()) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // StripPassthroughNodes strips all top-level passthrough nodes meant to apply only to top-level queries (query
 // tracking, transaction logic, etc) from the node tree given and return the first non-passthrough child element. This
 // is useful for when we invoke the analyzer recursively when e.g. analyzing subqueries or triggers
@@ -152,7 +518,7 @@ func exprIsCacheable(expr sql.Expression, lowestAllowedIdx int) bool {
 				return false
 			}
 		}
-		if nd, ok := e.(sql.NonDeterministicExpression); ok && nd.IsNonDeterministic() {
+		if isNonDeterministic(e) {
 			cacheable = false
 			return false
 		}
@@ -171,12 +537,12 @@ func nodeIsCacheable(n sql.Node, lowestAllowedIdx int) bool {
 					return false
 				}
 			}
-		} else if _, ok := node.(*plan.SubqueryAlias); ok {
-			// SubqueryAliases are always cacheable.  In fact, we
-			// do not go far enough here yet. CTEs must be cached /
-			// materialized and the same result set used throughout
-			// the query when they are non-determinstic in order to
-			// give correct results.
+		} else if sa, ok := node.(*plan.SubqueryAlias); ok && !sa.Lateral {
+			// SubqueryAliases are always cacheable. CTEs that
+			// must return the same result set throughout the
+			// query are materialized by materializeCtes. Lateral
+			// ones are analyzed in the scope of their join, and
+			// can read the outer scope of the node.
 			return false
 		}
 		return true
@@ -184,6 +550,18 @@ func nodeIsCacheable(n sql.Node, lowestAllowedIdx int) bool {
 	return cacheable
 }
 
+// isNonDeterministic returns whether the expression given can return different results on evaluations within a query.
+// Functions of the current time, like NOW(), are non-deterministic across queries, but they read the time of the query
+// from sql.Context.QueryTime, which is fixed for the query.
+func isNonDeterministic(e sql.Expression) bool {
+	switch e.(type) {
+	case *function.Now, function.CurrDate, function.CurrTime, *function.CurrTimestamp:
+		return false
+	}
+	nd, ok := e.(sql.NonDeterministicExpression)
+	return ok && nd.IsNonDeterministic()
+}
+
 func isDeterminstic(n sql.Node) bool {
 	res := true
 	plan.InspectExpressions(n, func(e sql.Expression) bool {
@@ -192,7 +570,7 @@ func isDeterminstic(n sql.Node) bool {
 				res = false
 			}
 			return false
-		} else if nd, ok := e.(sql.NonDeterministicExpression); ok && nd.IsNonDeterministic() {
+		} else if isNonDeterministic(e) {
 			res = false
 			return false
 		}
@@ -203,7 +581,9 @@ func isDeterminstic(n sql.Node) bool {
 
 // cacheSubqueryResults determines whether it's safe to cache the results for any subquery expressions, and marks the
 // subquery as cacheable if so. Caching subquery results is safe in the case that no outer scope columns are referenced,
-// if all expressions in the subquery are deterministic, and if the subquery isn't inside a trigger block.
+// if all expressions in the subquery are deterministic, and if the subquery isn't inside a trigger block. When the
+// analyzer's CorrelatedSubqueryCache option is set, deterministic subqueries that do reference outer scope columns
+// instead memoize their results per distinct tuple of those columns.
 func cacheSubqueryResults(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
 	// No need to inspect for trigger blocks as the Analyzer is recursively invoked on trigger blocks.
 	if n, ok := n.(*plan.TriggerBeginEndBlock); ok {
@@ -222,25 +602,168 @@ func cacheSubqueryResults(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scop
 			return s.WithCachedResults(), nil
 		}
 
+		if a != nil && a.CorrelatedSubqueryCache && isDeterminstic(s.Query) {
+			if idxs := outerScopeFieldIndexes(s.Query, scopeLen); len(idxs) > 0 {
+				return s.WithCorrelationCache(idxs), nil
+			}
+		}
+
 		return s, nil
 	})
 }
 
+// flattenScalarSubqueries evaluates uncorrelated, deterministic scalar subqueries that are guaranteed to return a
+// single row, such as an aggregation without a GROUP BY, and replaces them with the resulting literal. Subqueries
+// that could have side effects, or that belong to a trigger or stored procedure body, are left alone, since those
+// run at a different time than they are analyzed.
+func flattenScalarSubqueries(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	if a.ProcedureCache.IsPopulating || len(scope.MemoNodes()) > 0 {
+		return n, nil
+	}
+	if _, ok := n.(*plan.TriggerBeginEndBlock); ok {
+		return n, nil
+	}
+
+	// IN and EXISTS operate on the subquery itself, rather than on its value
+	operands := make(map[*plan.Subquery]bool)
+	plan.InspectExpressions(n, func(e sql.Expression) bool {
+		switch e := e.(type) {
+		case *plan.InSubquery:
+			if s, ok := e.Right.(*plan.Subquery); ok {
+				operands[s] = true
+			}
+		case *plan.ExistsSubquery:
+			if s, ok := e.Children()[0].(*plan.Subquery); ok {
+				operands[s] = true
+			}
+		}
+		return true
+	})
+
+	return plan.TransformExpressionsUpWithNode(n, func(n sql.Node, e sql.Expression) (sql.Expression, error) {
+		s, ok := e.(*plan.Subquery)
+		if !ok || operands[s] || !s.Resolved() {
+			return e, nil
+		}
+
+		scopeLen := len(scope.newScope(n).Schema())
+		if !nodeIsCacheable(s.Query, scopeLen) || !isDeterminstic(s.Query) ||
+			!returnsSingleRow(s.Query) || !isSideEffectFree(s.Query) {
+			return e, nil
+		}
+
+		val, err := s.Eval(ctx, make(sql.Row, scopeLen))
+		s.Dispose()
+		if err != nil {
+			// Leave any errors to be reported if and when the subquery is evaluated during execution
+			return e, nil
+		}
+
+		return expression.NewLiteral(val, s.Type()), nil
+	})
+}
+
+// returnsSingleRow returns whether the node given always returns exactly one row, which is the case for aggregations
+// without any grouping expressions.
+func returnsSingleRow(n sql.Node) bool {
+	switch n := n.(type) {
+	case *plan.Project:
+		return returnsSingleRow(n.Child)
+	case *plan.Sort:
+		return returnsSingleRow(n.Child)
+	case *plan.GroupBy:
+		return len(n.GroupByExprs) == 0
+	default:
+		return false
+	}
+}
+
+// isSideEffectFree returns whether the node given only reads data, so that evaluating it ahead of time can't be
+// observed.
+func isSideEffectFree(n sql.Node) bool {
+	res := true
+	plan.Inspect(n, func(n sql.Node) bool {
+		switch n.(type) {
+		case nil, *plan.Project, *plan.GroupBy, *plan.Having, *plan.Filter, *plan.Sort, *plan.Distinct,
+			*plan.OrderedDistinct, *plan.Limit, *plan.ResolvedTable, *plan.IndexedTableAccess, *plan.TableAlias,
+			*plan.DecoratedNode, *plan.SubqueryAlias, *plan.CrossJoin, plan.JoinNode:
+		default:
+			res = false
+		}
+		return res
+	})
+	if !res {
+		return false
+	}
+
+	plan.InspectExpressions(n, func(e sql.Expression) bool {
+		switch e := e.(type) {
+		case *expression.SetField, *function.Sleep, *function.GetLock, *function.ReleaseLock,
+			*function.ReleaseAllLocks:
+			res = false
+		case *plan.Subquery:
+			res = isSideEffectFree(e.Query)
+			return false
+		}
+		return res
+	})
+	return res
+}
+
+// outerScopeFieldIndexes returns the sorted, distinct indexes of the outer scope columns referenced anywhere in the
+// node given, including in any nested subqueries.
+func outerScopeFieldIndexes(n sql.Node, scopeLen int) []int {
+	seen := make(map[int]struct{})
+	var inspect func(n sql.Node)
+	inspect = func(n sql.Node) {
+		plan.InspectExpressions(n, func(e sql.Expression) bool {
+			switch e := e.(type) {
+			case *expression.GetField:
+				if e.Index() < scopeLen {
+					seen[e.Index()] = struct{}{}
+				}
+			case *plan.Subquery:
+				inspect(e.Query)
+				return false
+			}
+			return true
+		})
+	}
+	inspect(n)
+
+	idxs := make([]int, 0, len(seen))
+	for idx := range seen {
+		idxs = append(idxs, idx)
+	}
+	sort.Ints(idxs)
+	return idxs
+}
+
 // cacheSubqueryAlisesInJoins will look for joins against subquery aliases that
 // will repeatedly execute the subquery, and will insert a *plan.CachedResults
 // node on top of those nodes.
 func cacheSubqueryAlisesInJoins(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
 	n, err := plan.TransformUpCtx(n, nil, func(c plan.TransformContext) (sql.Node, error) {
+		// A node cached by an earlier pass is only cached once
+		if cr, isCR := c.Node.(*plan.CachedResults); isCR {
+			if _, isCachedTwice := cr.UnaryNode.Child.(*plan.CachedResults); isCachedTwice {
+				return cr.UnaryNode.Child, nil
+			}
+			return c.Node, nil
+		}
+
 		_, isJoin := c.Parent.(plan.JoinNode)
 		_, isIndexedJoin := c.Parent.(*plan.IndexedJoin)
 		if isJoin || isIndexedJoin {
-			_, isSubqueryAlias := c.Node.(*plan.SubqueryAlias)
-			if isSubqueryAlias {
-				// SubqueryAliases are always cacheable. They
-				// cannot reference their outside scope and
-				// even when they have non-determinstic
-				// expressions they should return the same
-				// results across multiple iterations.
+			// Inside a subquery, the children of a join are wrapped in a StripRowNode removing the scope row, which
+			// the cached results don't depend on.
+			if srn, isStripRow := c.Node.(*plan.StripRowNode); isStripRow {
+				if isCacheableSubqueryAlias(srn.Child) {
+					return srn.WithChildren(plan.NewCachedResults(srn.Child))
+				}
+				return c.Node, nil
+			}
+			if isCacheableSubqueryAlias(c.Node) {
 				return plan.NewCachedResults(c.Node), nil
 			}
 		}
@@ -267,16 +790,28 @@ func cacheSubqueryAlisesInJoins(ctx *sql.Context, a *Analyzer, n sql.Node, scope
 			return true
 		}
 		n, err = plan.TransformUpCtx(n, selector, func(c plan.TransformContext) (sql.Node, error) {
-			cr, isCR := c.Node.(*plan.CachedResults)
-			if isCR {
-				return cr.UnaryNode.Child, nil
+			// remove every layer of caching, in case the node was cached more than once
+			node := c.Node
+			for {
+				cr, isCR := node.(*plan.CachedResults)
+				if !isCR {
+					return node, nil
+				}
+				node = cr.UnaryNode.Child
 			}
-			return c.Node, nil
 		})
 	}
 	return n, err
 }
 
+// isCacheableSubqueryAlias returns whether the node given is a non-lateral SubqueryAlias. Those are always
+// cacheable. They cannot reference their outside scope and even when they have non-deterministic expressions they
+// should return the same results across multiple iterations.
+func isCacheableSubqueryAlias(n sql.Node) bool {
+	sa, isSubqueryAlias := n.(*plan.SubqueryAlias)
+	return isSubqueryAlias && !sa.Lateral
+}
+
 func setJoinScopeLen(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
 	scopeLen := len(scope.Schema())
 	if scopeLen == 0 {
@@ -285,15 +820,27 @@ func setJoinScopeLen(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (s
 	return plan.TransformUp(n, func(n sql.Node) (sql.Node, error) {
 		if j, ok := n.(plan.JoinNode); ok {
 			nj := j.WithScopeLen(scopeLen)
-			if _, ok := nj.Left().(*plan.StripRowNode); !ok {
-				return nj.WithChildren(
-					plan.NewStripRowNode(nj.Left(), scopeLen),
-					plan.NewStripRowNode(nj.Right(), scopeLen),
-				)
-			} else {
-				return nj, nil
-			}
+			// Both sides are stripped of the scope row, whatever the type of the join. Subquery expressions are analyzed
+			// again after changes to their outer scope, so a side stripped by an earlier pass may have a stale length.
+			return nj.WithChildren(
+				withStripRowNode(nj.Left(), scopeLen),
+				withStripRowNode(nj.Right(), scopeLen),
+			)
+		}
+		if j, ok := n.(*plan.CrossJoin); ok {
+			// A cross join passes the rows of its left side, which start with the scope row, through as they are, so only
+			// its right side is stripped.
+			return j.WithChildren(j.Left(), withStripRowNode(j.Right(), scopeLen))
 		}
 		return n, nil
 	})
 }
+
+// withStripRowNode returns the node given wrapped in a StripRowNode removing the scope row of the length given,
+// replacing any StripRowNode it's already wrapped in.
+func withStripRowNode(n sql.Node, scopeLen int) sql.Node {
+	if srn, ok := n.(*plan.StripRowNode); ok {
+		n = srn.Child
+	}
+	return plan.NewStripRowNode(n, scopeLen)
+}
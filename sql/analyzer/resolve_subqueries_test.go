@@ -16,13 +16,19 @@ package analyzer
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-errors.v1"
+
 	"github.com/dolthub/go-mysql-server/sql/expression/function"
+	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
 
 	"github.com/dolthub/go-mysql-server/memory"
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/parse"
 	"github.com/dolthub/go-mysql-server/sql/plan"
 )
 
@@ -438,7 +444,61 @@ func TestResolveSubqueryExpressions(t *testing.T) {
 	}
 
 	ctx := sql.NewContext(context.Background()).WithCurrentDB("mydb")
-	runTestCases(t, ctx, testCases, a, getRule("resolve_subquery_exprs"))
+	resolveSubqueryExprs := getRule("resolve_subquery_exprs")
+	runTestCases(t, ctx, testCases, a, Rule{
+		Name: "resolve_subquery_exprs",
+		Apply: func(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+			n, err := resolveSubqueryExprs.Apply(ctx, a, n, scope)
+			if err != nil {
+				return nil, err
+			}
+			// Subqueries whose analysis is deferred keep the error that deferred it, which has a stack trace and can't be
+			// compared with the expected nodes
+			return plan.TransformExpressionsUp(n, func(e sql.Expression) (sql.Expression, error) {
+				s, ok := e.(*plan.Subquery)
+				if !ok {
+					return e, nil
+				}
+				require.Equal(t, s.Resolved(), s.DeferredError() == nil)
+				return s.WithDeferredError(nil), nil
+			})
+		},
+	})
+}
+
+func TestDeferredSubqueryError(t *testing.T) {
+	mytable := memory.NewTable("mytable", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "i", Type: sql.Int64, Source: "mytable"},
+	}))
+	db := memory.NewDatabase("mydb")
+	db.AddTable("mytable", mytable)
+	a := withoutProcessTracking(NewDefault(sql.NewDatabaseProvider(db)))
+
+	testCases := []struct {
+		query string
+		err   *errors.Kind
+	}{
+		{"select i, (select nonexistent from mytable) from mytable", sql.ErrColumnNotFound},
+		{"select i, (select mytable.nonexistent from mytable) from mytable", sql.ErrTableColumnNotFound},
+		{"select i from mytable where i in (select i from mytable t where t.i > nonexistent)", sql.ErrColumnNotFound},
+		// the column isn't deferred in the subquery alias of the common table expression, so only the error that
+		// deferred the analysis of the subquery names it
+		{"select i, (with t as (select nonexistent from mytable) select * from t) from mytable", sql.ErrColumnNotFound},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.query, func(t *testing.T) {
+			require := require.New(t)
+			node, err := parse.Parse(sql.NewEmptyContext(), tt.query)
+			require.NoError(err)
+
+			ctx := sql.NewContext(context.Background()).WithCurrentDB("mydb")
+			_, err = a.Analyze(ctx, node, nil)
+			require.Error(err)
+			require.True(tt.err.Is(err), "unexpected error: %s", err)
+			require.Contains(err.Error(), "nonexistent")
+		})
+	}
 }
 
 func TestCacheSubqueryResults(t *testing.T) {
@@ -564,14 +624,696 @@ func TestCacheSubqueryResults(t *testing.T) {
 				plan.NewResolvedTable(table, nil, nil),
 			),
 		},
+		{
+			name: "cacheable, current time expression",
+			node: plan.NewProject(
+				[]sql.Expression{
+					gf(0, "mytable", "i"),
+					plan.NewSubquery(
+						plan.NewProject(
+							[]sql.Expression{
+								gf(3, "mytables", "x"),
+							},
+							plan.NewFilter(
+								gt(
+									mustExpr(function.NewNow()),
+									gf(3, "mytable2", "x"),
+								),
+								plan.NewResolvedTable(table2, nil, nil),
+							),
+						),
+						""),
+				},
+				plan.NewResolvedTable(table, nil, nil),
+			),
+			expected: plan.NewProject(
+				[]sql.Expression{
+					gf(0, "mytable", "i"),
+					plan.NewSubquery(
+						plan.NewProject(
+							[]sql.Expression{
+								gf(3, "mytables", "x"),
+							},
+							plan.NewFilter(
+								gt(
+									mustExpr(function.NewNow()),
+									gf(3, "mytable2", "x"),
+								),
+								plan.NewResolvedTable(table2, nil, nil),
+							),
+						),
+						"").WithCachedResults(),
+				},
+				plan.NewResolvedTable(table, nil, nil),
+			),
+		},
 	}
 
 	runTestCases(t, sql.NewEmptyContext(), testCases, nil, getRule("cache_subquery_results"))
 }
 
+func TestCacheCorrelatedSubqueryResults(t *testing.T) {
+	table := memory.NewTable("mytable", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "i", Type: sql.Int64, Source: "mytable"},
+		{Name: "x", Type: sql.Int64, Source: "mytable"},
+	}))
+	table2 := memory.NewTable("mytable2", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "i", Type: sql.Int64, Source: "mytable2"},
+		{Name: "y", Type: sql.Int64, Source: "mytable2"},
+	}))
+
+	testCases := []analyzerFnTestCase{
+		{
+			name: "not cacheable, outer scope referenced, correlation cache",
+			node: plan.NewProject(
+				[]sql.Expression{
+					gf(0, "mytable", "i"),
+					plan.NewSubquery(
+						plan.NewProject(
+							[]sql.Expression{
+								gf(3, "mytables", "x"),
+							},
+							plan.NewFilter(
+								gt(
+									gf(0, "mytable", "i"),
+									gf(3, "mytable2", "x"),
+								),
+								plan.NewResolvedTable(table2, nil, nil),
+							),
+						),
+						""),
+				},
+				plan.NewResolvedTable(table, nil, nil),
+			),
+			expected: plan.NewProject(
+				[]sql.Expression{
+					gf(0, "mytable", "i"),
+					plan.NewSubquery(
+						plan.NewProject(
+							[]sql.Expression{
+								gf(3, "mytables", "x"),
+							},
+							plan.NewFilter(
+								gt(
+									gf(0, "mytable", "i"),
+									gf(3, "mytable2", "x"),
+								),
+								plan.NewResolvedTable(table2, nil, nil),
+							),
+						),
+						"").WithCorrelationCache([]int{0}),
+				},
+				plan.NewResolvedTable(table, nil, nil),
+			),
+		},
+		{
+			name: "not cacheable, outer scope referenced, non-deterministic expression",
+			node: plan.NewProject(
+				[]sql.Expression{
+					gf(0, "mytable", "i"),
+					plan.NewSubquery(
+						plan.NewProject(
+							[]sql.Expression{
+								mustExpr(function.NewRand()),
+							},
+							plan.NewFilter(
+								gt(
+									gf(0, "mytable", "i"),
+									gf(3, "mytable2", "x"),
+								),
+								plan.NewResolvedTable(table2, nil, nil),
+							),
+						),
+						""),
+				},
+				plan.NewResolvedTable(table, nil, nil),
+			),
+		},
+	}
+
+	a := NewBuilder(sql.NewDatabaseProvider()).WithCorrelatedSubqueryCache().Build()
+	runTestCases(t, sql.NewEmptyContext(), testCases, a, getRule("cache_subquery_results"))
+}
+
+func TestCacheSubqueryAliasesInJoins(t *testing.T) {
+	table := memory.NewTable("mytable", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "i", Type: sql.Int64, Source: "mytable"},
+	}))
+	sa := func(name string) sql.Node {
+		return plan.NewSubqueryAlias(name, "", plan.NewResolvedTable(table, nil, nil))
+	}
+	cached := plan.NewCachedResults
+	cond := expression.NewLiteral(true, sql.Boolean)
+
+	testCases := []analyzerFnTestCase{
+		{
+			name: "left join then right join",
+			node: plan.NewRightJoin(plan.NewLeftJoin(sa("a"), sa("b"), cond), sa("c"), cond),
+			expected: plan.NewRightJoin(
+				plan.NewLeftJoin(cached(sa("a")), cached(sa("b")), cond),
+				sa("c"),
+				cond,
+			),
+		},
+		{
+			name: "right join then left join",
+			node: plan.NewLeftJoin(plan.NewRightJoin(sa("a"), sa("b"), cond), sa("c"), cond),
+			expected: plan.NewLeftJoin(
+				plan.NewRightJoin(cached(sa("a")), sa("b"), cond),
+				cached(sa("c")),
+				cond,
+			),
+		},
+		{
+			name: "right join then right join",
+			node: plan.NewRightJoin(plan.NewRightJoin(sa("a"), sa("b"), cond), sa("c"), cond),
+			expected: plan.NewRightJoin(
+				plan.NewRightJoin(cached(sa("a")), cached(sa("b")), cond),
+				sa("c"),
+				cond,
+			),
+		},
+		{
+			name: "already cached",
+			node: plan.NewLeftJoin(
+				plan.NewRightJoin(cached(sa("a")), cached(cached(sa("b"))), cond),
+				cached(cached(sa("c"))),
+				cond,
+			),
+			expected: plan.NewLeftJoin(
+				plan.NewRightJoin(cached(sa("a")), sa("b"), cond),
+				cached(sa("c")),
+				cond,
+			),
+		},
+		{
+			name:  "not at the top of the tree",
+			node:  plan.NewRightJoin(plan.NewLeftJoin(sa("a"), sa("b"), cond), sa("c"), cond),
+			scope: (*Scope)(nil).newScope(plan.NewResolvedTable(table, nil, nil)),
+			expected: plan.NewRightJoin(
+				plan.NewLeftJoin(cached(sa("a")), cached(sa("b")), cond),
+				cached(sa("c")),
+				cond,
+			),
+		},
+		{
+			name:  "scope row stripped",
+			node:  plan.NewInnerJoin(plan.NewStripRowNode(sa("a"), 1), plan.NewStripRowNode(sa("b"), 1), cond),
+			scope: (*Scope)(nil).newScope(plan.NewResolvedTable(table, nil, nil)),
+			expected: plan.NewInnerJoin(
+				plan.NewStripRowNode(cached(sa("a")), 1),
+				plan.NewStripRowNode(cached(sa("b")), 1),
+				cond,
+			),
+		},
+	}
+
+	rule := getRule("cache_subquery_aliases_in_joins")
+	runTestCases(t, nil, testCases, nil, rule)
+
+	t.Run("applied twice", func(t *testing.T) {
+		runTestCases(t, nil, testCases, nil, Rule{
+			Name: rule.Name,
+			Apply: func(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+				n, err := rule.Apply(ctx, a, n, scope)
+				if err != nil {
+					return nil, err
+				}
+				return rule.Apply(ctx, a, n, scope)
+			},
+		})
+	})
+}
+
+func TestSetJoinScopeLen(t *testing.T) {
+	table := memory.NewTable("mytable", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "i", Type: sql.Int64, Source: "mytable"},
+		{Name: "x", Type: sql.Int64, Source: "mytable"},
+	}))
+	rt := func(name string) sql.Node {
+		return plan.NewTableAlias(name, plan.NewResolvedTable(table, nil, nil))
+	}
+	strip := func(n sql.Node, numCols int) sql.Node {
+		return plan.NewStripRowNode(n, numCols)
+	}
+	cond := expression.NewLiteral(true, sql.Boolean)
+	scope := (*Scope)(nil).newScope(plan.NewProject(nil, plan.NewResolvedTable(table, nil, nil)))
+
+	testCases := []analyzerFnTestCase{
+		{
+			name:  "nested left and right joins",
+			node:  plan.NewRightJoin(plan.NewLeftJoin(rt("a"), rt("b"), cond), rt("c"), cond),
+			scope: scope,
+			expected: plan.NewRightJoin(
+				strip(plan.NewLeftJoin(strip(rt("a"), 2), strip(rt("b"), 2), cond).WithScopeLen(2), 2),
+				strip(rt("c"), 2),
+				cond,
+			).WithScopeLen(2),
+		},
+		{
+			name:     "stripped by an earlier pass with another scope",
+			node:     plan.NewLeftJoin(strip(rt("a"), 1), strip(rt("b"), 1), cond).WithScopeLen(1),
+			scope:    scope,
+			expected: plan.NewLeftJoin(strip(rt("a"), 2), strip(rt("b"), 2), cond).WithScopeLen(2),
+		},
+		{
+			name:     "right side not stripped",
+			node:     plan.NewInnerJoin(strip(rt("a"), 2), rt("b"), cond),
+			scope:    scope,
+			expected: plan.NewInnerJoin(strip(rt("a"), 2), strip(rt("b"), 2), cond).WithScopeLen(2),
+		},
+		{
+			name: "no scope",
+			node: plan.NewRightJoin(rt("a"), rt("b"), cond),
+		},
+	}
+
+	runTestCases(t, nil, testCases, nil, getRule("set_join_scope_len"))
+}
+
+func TestFlattenScalarSubqueries(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	table := memory.NewTable("mytable", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "i", Type: sql.Int64, Source: "mytable"},
+		{Name: "x", Type: sql.Int64, Source: "mytable"},
+	}))
+	table2 := memory.NewTable("mytable2", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "i", Type: sql.Int64, Source: "mytable2"},
+		{Name: "y", Type: sql.Int64, Source: "mytable2"},
+	}))
+	require.NoError(t, table2.Insert(ctx, sql.NewRow(int64(1), int64(10))))
+	require.NoError(t, table2.Insert(ctx, sql.NewRow(int64(2), int64(20))))
+
+	countQuery := func(groupBy ...sql.Expression) *plan.Subquery {
+		return plan.NewSubquery(
+			plan.NewGroupBy(
+				[]sql.Expression{aggregation.NewCount(gf(2, "mytable2", "i"))},
+				groupBy,
+				plan.NewResolvedTable(table2, nil, nil),
+			),
+			"select count(i) from mytable2").WithCachedResults()
+	}
+
+	testCases := []analyzerFnTestCase{
+		{
+			name: "aggregate without grouping",
+			node: plan.NewProject(
+				[]sql.Expression{
+					expression.NewPlus(gf(0, "mytable", "i"), countQuery()),
+				},
+				plan.NewResolvedTable(table, nil, nil),
+			),
+			expected: plan.NewProject(
+				[]sql.Expression{
+					expression.NewPlus(gf(0, "mytable", "i"), expression.NewLiteral(int64(2), sql.Int64)),
+				},
+				plan.NewResolvedTable(table, nil, nil),
+			),
+		},
+		{
+			name: "aggregate with grouping",
+			node: plan.NewProject(
+				[]sql.Expression{
+					expression.NewPlus(gf(0, "mytable", "i"), countQuery(gf(3, "mytable2", "y"))),
+				},
+				plan.NewResolvedTable(table, nil, nil),
+			),
+		},
+		{
+			name: "in subquery",
+			node: plan.NewFilter(
+				plan.NewInSubquery(gf(0, "mytable", "i"), countQuery()),
+				plan.NewResolvedTable(table, nil, nil),
+			),
+		},
+	}
+
+	a := withoutProcessTracking(NewDefault(sql.NewDatabaseProvider()))
+	runTestCases(t, ctx, testCases, a, getRule("flatten_scalar_subqueries"))
+}
+
 func mustExpr(e sql.Expression, err error) sql.Expression {
 	if err != nil {
 		panic(err)
 	}
 	return e
 }
+
+func TestFlattenTableAliases(t *testing.T) {
+	table := memory.NewTable("mytable", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "i", Type: sql.Int64, Source: "mytable"},
+	}))
+	subquery := plan.NewSubqueryAlias("sq", "select i from mytable",
+		plan.NewProject(
+			[]sql.Expression{gf(0, "mytable", "i")},
+			plan.NewResolvedTable(table, nil, nil),
+		),
+	)
+
+	testCases := []analyzerFnTestCase{
+		{
+			name: "single alias",
+			node: plan.NewTableAlias("a", plan.NewResolvedTable(table, nil, nil)),
+		},
+		{
+			name: "triple nested aliases",
+			node: plan.NewProject(
+				[]sql.Expression{gf(0, "a", "i")},
+				plan.NewTableAlias("a",
+					plan.NewTableAlias("b",
+						plan.NewTableAlias("c", plan.NewResolvedTable(table, nil, nil)),
+					),
+				),
+			),
+			expected: plan.NewProject(
+				[]sql.Expression{gf(0, "a", "i")},
+				plan.NewTableAlias("a", plan.NewResolvedTable(table, nil, nil)),
+			),
+		},
+		{
+			name: "triple nested aliases of a subquery alias",
+			node: plan.NewTableAlias("a",
+				plan.NewTableAlias("b",
+					plan.NewTableAlias("c", subquery),
+				),
+			),
+			expected: subquery.WithName("a"),
+		},
+	}
+
+	a := withoutProcessTracking(NewDefault(sql.NewDatabaseProvider()))
+	runTestCases(t, sql.NewEmptyContext(), testCases, a, getRule("flatten_table_aliases"))
+}
+
+func TestLateralSubqueryAlias(t *testing.T) {
+	require := require.New(t)
+
+	a := memory.NewTable("a", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "i", Type: sql.Int64, Source: "a", PrimaryKey: true},
+	}))
+	b := memory.NewTable("b", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "j", Type: sql.Int64, Source: "b", PrimaryKey: true},
+	}))
+	for _, i := range []int64{1, 2, 3} {
+		require.NoError(a.Insert(sql.NewEmptyContext(), sql.NewRow(i)))
+		require.NoError(b.Insert(sql.NewEmptyContext(), sql.NewRow(i)))
+	}
+	db := memory.NewDatabase("mydb")
+	db.AddTable("a", a)
+	db.AddTable("b", b)
+
+	analyzer := withoutProcessTracking(NewDefault(sql.NewDatabaseProvider(db)))
+
+	// SELECT a.i, l.j FROM a, LATERAL (SELECT j FROM b WHERE b.j <= a.i) l
+	lateral := plan.NewSubqueryAlias(
+		"l", "",
+		plan.NewProject(
+			[]sql.Expression{uc("j")},
+			plan.NewFilter(
+				expression.NewLessThanOrEqual(uqc("b", "j"), uqc("a", "i")),
+				plan.NewUnresolvedTable("b", ""),
+			),
+		),
+	).WithLateral(true)
+
+	testCases := []struct {
+		name     string
+		node     sql.Node
+		expected []sql.Row
+	}{
+		{
+			name: "cross join",
+			node: plan.NewProject(
+				[]sql.Expression{uqc("a", "i"), uqc("l", "j")},
+				plan.NewCrossJoin(plan.NewUnresolvedTable("a", ""), lateral),
+			),
+			expected: []sql.Row{
+				{int64(1), int64(1)},
+				{int64(2), int64(1)}, {int64(2), int64(2)},
+				{int64(3), int64(1)}, {int64(3), int64(2)}, {int64(3), int64(3)},
+			},
+		},
+		{
+			name: "left join",
+			node: plan.NewProject(
+				[]sql.Expression{uqc("a", "i"), uqc("l", "j")},
+				plan.NewLeftJoin(
+					plan.NewUnresolvedTable("a", ""),
+					lateral,
+					expression.NewGreaterThan(uqc("l", "j"), expression.NewLiteral(int8(1), sql.Int8)),
+				),
+			),
+			expected: []sql.Row{
+				{int64(1), nil},
+				{int64(2), int64(2)},
+				{int64(3), int64(2)}, {int64(3), int64(3)},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := sql.NewContext(context.Background()).WithCurrentDB("mydb")
+			analyzed, err := analyzer.Analyze(ctx, tt.node, nil)
+			require.NoError(err)
+
+			rows, err := sql.NodeToRows(ctx, analyzed)
+			require.NoError(err)
+			require.ElementsMatch(tt.expected, rows)
+		})
+	}
+}
+
+// nestedViews returns a database with a table and views nested to the depth given, each view selecting from the one
+// below it, along with a query of the outermost view.
+func nestedViews(t testing.TB, depth int) (*memory.Database, sql.Node) {
+	ctx := sql.NewEmptyContext()
+	foo := memory.NewTable("foo", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "foo", PrimaryKey: true},
+	}))
+	for i := int64(1); i <= 10; i++ {
+		require.NoError(t, foo.Insert(ctx, sql.NewRow(i)))
+	}
+	db := memory.NewDatabase("mydb")
+	db.AddTable("foo", foo)
+
+	below := "foo"
+	for i := 1; i <= depth; i++ {
+		name := fmt.Sprintf("v%d", i)
+		require.NoError(t, db.CreateView(ctx, name, fmt.Sprintf("select a from %s where a > %d", below, i)))
+		below = name
+	}
+
+	node, err := parse.Parse(ctx, fmt.Sprintf("select a from foo where a in (select a from %s)", below))
+	require.NoError(t, err)
+	return db, node
+}
+
+func TestNestedViewAnalysis(t *testing.T) {
+	// analyses counts the subqueries analyzed through the default rules for every depth of nested views
+	analyses := make(map[int]int)
+	for depth := 1; depth <= 5; depth++ {
+		t.Run(fmt.Sprintf("depth %d", depth), func(t *testing.T) {
+			require := require.New(t)
+			db, node := nestedViews(t, depth)
+			a := withoutProcessTracking(NewBuilder(sql.NewDatabaseProvider(db)).
+				AddPreAnalyzeRule("count_analyses", func(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+					analyses[depth]++
+					return n, nil
+				}).Build())
+
+			ctx := sql.NewContext(context.Background()).WithCurrentDB("mydb")
+			analyzed, err := a.Analyze(ctx, node, nil)
+			require.NoError(err)
+
+			rows, err := sql.NodeToRows(ctx, analyzed)
+			require.NoError(err)
+			var expected []sql.Row
+			for i := int64(depth + 1); i <= 10; i++ {
+				expected = append(expected, sql.NewRow(i))
+			}
+			require.ElementsMatch(expected, rows)
+		})
+	}
+
+	// The views are analyzed once, however many times the subquery expression selecting from them is analyzed, so
+	// every level of nesting adds a single analysis.
+	for depth := 2; depth <= 5; depth++ {
+		require.Equal(t, analyses[depth-1]+1, analyses[depth], "analyses at depth %d", depth)
+	}
+}
+
+func BenchmarkNestedViewAnalysis(b *testing.B) {
+	db, node := nestedViews(b, 5)
+	a := withoutProcessTracking(NewDefault(sql.NewDatabaseProvider(db)))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ctx := sql.NewContext(context.Background()).WithCurrentDB("mydb")
+		if _, err := a.Analyze(ctx, node, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCorrelatedSubqueryAnalysis(b *testing.B) {
+	// a large schema: many tables of many columns
+	db := memory.NewDatabase("mydb")
+	for i := 0; i < 200; i++ {
+		name := fmt.Sprintf("t%d", i)
+		schema := make(sql.Schema, 50)
+		for j := range schema {
+			schema[j] = &sql.Column{Name: fmt.Sprintf("c%d", j), Type: sql.Int64, Source: name, Nullable: true}
+		}
+		db.AddTable(name, memory.NewTable(name, sql.NewPrimaryKeySchema(schema)))
+	}
+	a := withoutProcessTracking(NewDefault(sql.NewDatabaseProvider(db)))
+
+	// the correlated subqueries select from the tables of the outer query
+	node, err := parse.Parse(sql.NewEmptyContext(), `select t0.c0, t1.c1 from t0 join t1 on t0.c0 = t1.c0
+		where t0.c1 = (select max(t0b.c1) from t0 t0b join t1 t1b on t0b.c0 = t1b.c0 where t0b.c2 = t0.c2)
+		and exists (select 1 from t1 t1c where t1c.c3 = t1.c3 and t1c.c4 > t0.c4)`)
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ctx := sql.NewContext(context.Background()).WithCurrentDB("mydb")
+		if _, err := a.Analyze(ctx, node, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestParallelSubqueryAnalysis(t *testing.T) {
+	a := memory.NewTable("a", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "i", Type: sql.Int64, Source: "a", PrimaryKey: true},
+	}))
+	b := memory.NewTable("b", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "j", Type: sql.Int64, Source: "b", PrimaryKey: true},
+	}))
+	for _, i := range []int64{1, 2, 3} {
+		require.NoError(t, a.Insert(sql.NewEmptyContext(), sql.NewRow(i)))
+		require.NoError(t, b.Insert(sql.NewEmptyContext(), sql.NewRow(i*10)))
+	}
+	db := memory.NewDatabase("mydb")
+	db.AddTable("a", a)
+	db.AddTable("b", b)
+
+	sequential := withoutProcessTracking(NewDefault(sql.NewDatabaseProvider(db)))
+	parallel := withoutProcessTracking(NewBuilder(sql.NewDatabaseProvider(db)).WithParallelSubqueryAnalysis().Build())
+
+	// Before the outer query is resolved, the subqueries with unqualified columns are taken to be correlated
+	testCases := []struct {
+		query        string
+		uncorrelated int
+	}{
+		{
+			query:        "select i, (select max(j) from b), (select count(*) from b), (select min(b.j) from b) from a",
+			uncorrelated: 2,
+		},
+		{
+			query:        "select i, (select max(j) from b where b.j <= a.i * 10), (select count(*) from b), (select sum(b.j) from b) from a",
+			uncorrelated: 2,
+		},
+		{
+			query:        "select i from a where i < (select count(*) from b) and i > (select count(*) - 3 from b where b.j > 10)",
+			uncorrelated: 2,
+		},
+		{
+			query:        "select i, (select max(j) from b where j > i) from a",
+			uncorrelated: 0,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.query, func(t *testing.T) {
+			require := require.New(t)
+			node, err := parse.Parse(sql.NewEmptyContext(), tt.query)
+			require.NoError(err)
+
+			var uncorrelated int
+			plan.InspectExpressionsWithNode(node, func(n sql.Node, e sql.Expression) bool {
+				if s, ok := e.(*plan.Subquery); ok && isUncorrelatedSubquery(s.Query, (*Scope)(nil).newScope(n)) {
+					uncorrelated++
+				}
+				return true
+			})
+			require.Equal(tt.uncorrelated, uncorrelated)
+
+			ctx := sql.NewContext(context.Background()).WithCurrentDB("mydb")
+			expected, err := sequential.Analyze(ctx, node, nil)
+			require.NoError(err)
+			expectedRows, err := sql.NodeToRows(ctx, expected)
+			require.NoError(err)
+
+			for i := 0; i < 10; i++ {
+				ctx := sql.NewContext(context.Background()).WithCurrentDB("mydb")
+				analyzed, err := parallel.Analyze(ctx, node, nil)
+				require.NoError(err)
+				require.Equal(sql.DebugString(expected), sql.DebugString(analyzed))
+
+				rows, err := sql.NodeToRows(ctx, analyzed)
+				require.NoError(err)
+				require.Equal(expectedRows, rows)
+			}
+		})
+	}
+}
+
+func TestMaxNestingDepth(t *testing.T) {
+	leaf, err := parse.Parse(sql.NewEmptyContext(), "select 1")
+	require.NoError(t, err)
+	dual := leaf.(*plan.Project).Child
+	provider := sql.NewDatabaseProvider(memory.NewDatabase("mydb"))
+
+	// The parser limits the nesting of queries, so the deeply nested queries are built here instead
+	nestedSubqueries := func(depth int) sql.Node {
+		n := leaf
+		for i := 0; i < depth; i++ {
+			n = plan.NewProject([]sql.Expression{plan.NewSubquery(n, "")}, dual)
+		}
+		return n
+	}
+	nestedSubqueryAliases := func(depth int) sql.Node {
+		n := leaf
+		for i := 0; i < depth; i++ {
+			n = plan.NewProject([]sql.Expression{expression.NewStar()}, plan.NewSubqueryAlias(fmt.Sprintf("t%d", i), "", n))
+		}
+		return n
+	}
+
+	t.Run("200 levels of subqueries", func(t *testing.T) {
+		a := withoutProcessTracking(NewDefault(provider))
+		for _, n := range []sql.Node{nestedSubqueries(200), nestedSubqueryAliases(200)} {
+			_, err := a.Analyze(sql.NewEmptyContext().WithCurrentDB("mydb"), n, nil)
+			require.Error(t, err)
+			require.True(t, sql.ErrMaxNestingDepthExceeded.Is(err), "%v", err)
+		}
+	})
+
+	t.Run("configured maximum", func(t *testing.T) {
+		require := require.New(t)
+		a := withoutProcessTracking(NewBuilder(provider).WithMaxNestingDepth(3).Build())
+		for _, tt := range []struct {
+			query    string
+			exceeded bool
+		}{
+			{"select (select (select (select 1)))", false},
+			{"select (select (select (select (select 1))))", true},
+			{"select * from (select * from (select * from (select 1) a) b) c", false},
+			{"select * from (select * from (select * from (select * from (select 1) a) b) c) d", true},
+			{"with a as (select 1), b as (select * from a), c as (select * from b) select * from c", false},
+			{"select (select * from (select (select 1)) a) from (select 1) b", false},
+			{"select (select * from (select (select (select 1))) a)", true},
+		} {
+			node, err := parse.Parse(sql.NewEmptyContext(), tt.query)
+			require.NoError(err)
+			_, err = a.Analyze(sql.NewEmptyContext().WithCurrentDB("mydb"), node, nil)
+			if tt.exceeded {
+				require.True(sql.ErrMaxNestingDepthExceeded.Is(err), "%s: %v", tt.query, err)
+			} else {
+				require.NoError(err, tt.query)
+			}
+		}
+	})
+}
@@ -70,6 +70,16 @@ func resolveTables(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql
 			c.Node, _ = p.WithChildren(resolvedTables...)
 			return n, nil
 		case *plan.UnresolvedTable:
+			if p.AsOf == nil {
+				db := p.Database
+				if db == "" {
+					db = ctx.GetCurrentDatabase()
+				}
+				if rt, ok := scope.resolvedTable(db, p.Name()); ok {
+					a.Log("table resolved from outer scope: %s", p.Name())
+					return plan.NewResolvedTable(rt.Table, rt.Database, nil), nil
+				}
+			}
 			r, err := resolveTable(ctx, p, a)
 			if sql.ErrTableNotFound.Is(err) && ignore {
 				return p, nil
@@ -142,3 +142,85 @@ func TestResolveTablesNested(t *testing.T) {
 	)
 	require.Equal(expected, analyzed)
 }
+
+func TestResolveTablesFromScope(t *testing.T) {
+	f := getRule("resolve_tables")
+
+	schema := sql.NewPrimaryKeySchema(sql.Schema{{Name: "i", Type: sql.Int32}})
+	table := memory.NewTable("mytable", schema)
+	otherTable := memory.NewTable("othertable", schema)
+	db := memory.NewHistoryDatabase("mydb")
+	db.AddTableAsOf("mytable", table, "2019-01-01")
+	db.AddTable("othertable", otherTable)
+
+	a := NewBuilder(sql.NewDatabaseProvider(db)).AddPostAnalyzeRule(f.Name, f.Apply).Build()
+	ctx := sql.NewEmptyContext().WithCurrentDB("mydb")
+
+	// The table of the outer scope is a different instance than the one in the catalog, to tell which one was used
+	scopeTable := memory.NewTable("mytable", schema)
+	scope := (*Scope)(nil).newScope(plan.NewProject(
+		[]sql.Expression{expression.NewGetField(0, sql.Int32, "i", true)},
+		plan.NewResolvedTable(scopeTable, db, nil),
+	))
+
+	testCases := []struct {
+		name     string
+		node     sql.Node
+		scope    *Scope
+		expected sql.Node
+	}{
+		{
+			name:     "table of the outer scope",
+			node:     plan.NewUnresolvedTable("MyTable", ""),
+			scope:    scope,
+			expected: plan.NewResolvedTable(scopeTable, db, nil),
+		},
+		{
+			name:     "table of the outer scope in the database given",
+			node:     plan.NewUnresolvedTable("mytable", "MYDB"),
+			scope:    scope,
+			expected: plan.NewResolvedTable(scopeTable, db, nil),
+		},
+		{
+			name:     "table of the outer scope of an outer scope",
+			node:     plan.NewUnresolvedTable("mytable", ""),
+			scope:    scope.newScope(plan.NewProject(nil, plan.NewResolvedTable(otherTable, db, nil))),
+			expected: plan.NewResolvedTable(scopeTable, db, nil),
+		},
+		{
+			name:     "table not in the outer scope",
+			node:     plan.NewUnresolvedTable("othertable", ""),
+			scope:    scope,
+			expected: plan.NewResolvedTable(otherTable, db, nil),
+		},
+		{
+			name:     "table as of a revision",
+			node:     plan.NewUnresolvedTableAsOf("mytable", "", expression.NewLiteral("2019-01-01", sql.LongText)),
+			scope:    scope,
+			expected: plan.NewResolvedTable(table, db, "2019-01-01"),
+		},
+		{
+			name: "table with pushed down projections",
+			node: plan.NewUnresolvedTable("mytable", ""),
+			scope: (*Scope)(nil).newScope(plan.NewProject(nil, plan.NewDecoratedNode(
+				"Projected table access on [i]",
+				plan.NewResolvedTable(scopeTable, db, nil),
+			))),
+			expected: plan.NewResolvedTable(table, db, nil),
+		},
+		{
+			name:     "no outer scope",
+			node:     plan.NewUnresolvedTable("mytable", ""),
+			expected: plan.NewResolvedTable(table, db, nil),
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			analyzed, err := f.Apply(ctx, a, tt.node, tt.scope)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, analyzed)
+			require.Same(t, tt.expected.(*plan.ResolvedTable).Table, analyzed.(*plan.ResolvedTable).Table)
+		})
+	}
+}
@@ -93,6 +93,7 @@ var OnceAfterDefault = []Rule{
 	{"resolve_generators", resolveGenerators},
 	{"remove_unnecessary_converts", removeUnnecessaryConverts},
 	{"assign_catalog", assignCatalog},
+	{"prune_subquery_alias_columns", pruneSubqueryAliasColumns},
 	{"prune_columns", pruneColumns},
 	{"optimize_joins", constructJoinPlan},
 	{"pushdown_filters", pushdownFilters},
@@ -102,10 +103,15 @@ var OnceAfterDefault = []Rule{
 	{"set_join_scope_len", setJoinScopeLen},
 	{"erase_projection", eraseProjection},
 	{"insert_topn", insertTopNNodes},
+	{"limit_window_partitions", limitWindowPartitions},
 	// One final pass at analyzing subqueries to handle rewriting field indexes after changes to outer scope by
 	// previous rules.
 	{"resolve_subquery_exprs", resolveSubqueryExpressions},
 	{"cache_subquery_results", cacheSubqueryResults},
+	{"flatten_scalar_subqueries", flattenScalarSubqueries},
+	{"decorrelate_exists_subqueries", decorrelateExistsSubqueries},
+	{"rewrite_in_subqueries", rewriteInSubqueries},
+	{"materialize_ctes", materializeCtes},
 	{"cache_subquery_aliases_in_joins", cacheSubqueryAlisesInJoins},
 	{"apply_hash_lookups", applyHashLookups},
 	{"apply_hash_in", applyHashIn},
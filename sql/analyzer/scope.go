@@ -15,6 +15,9 @@
 package analyzer
 
 import (
+	"strings"
+	"sync"
+
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
 	"github.com/dolthub/go-mysql-server/sql/plan"
@@ -28,18 +31,27 @@ type Scope struct {
 	// Memo nodes are nodes in the execution context that shouldn't be considered for name resolution, but are still
 	// important for analysis.
 	memos []sql.Node
+	// The tables resolved in the scope nodes, which the analysis of a subquery reuses instead of resolving them against
+	// the catalog again. Filled on first use.
+	tables *scopeTables
+}
+
+// scopeTables indexes the resolved tables of the nodes of a scope by database and table name.
+type scopeTables struct {
+	once   sync.Once
+	tables map[string]*plan.ResolvedTable
 }
 
 // newScope creates a new Scope object with the additional innermost Node context. When constructing with a subquery,
 // the Node given should be the sibling Node of the subquery.
 func (s *Scope) newScope(node sql.Node) *Scope {
 	if s == nil {
-		return &Scope{nodes: []sql.Node{node}}
+		return &Scope{nodes: []sql.Node{node}, tables: &scopeTables{}}
 	}
 	var newNodes []sql.Node
 	newNodes = append(newNodes, node)
 	newNodes = append(newNodes, s.nodes...)
-	return &Scope{nodes: newNodes, memos: s.memos}
+	return &Scope{nodes: newNodes, memos: s.memos, tables: &scopeTables{}}
 }
 
 // memo creates a new Scope object with the memo node given. Memo nodes don't affect name resolution, but are used in
@@ -51,7 +63,7 @@ func (s *Scope) memo(node sql.Node) *Scope {
 	var newNodes []sql.Node
 	newNodes = append(newNodes, node)
 	newNodes = append(newNodes, s.memos...)
-	return &Scope{memos: newNodes, nodes: s.nodes}
+	return &Scope{memos: newNodes, nodes: s.nodes, tables: s.tables}
 }
 
 // withMemos returns a new scope object identical to the receiver, but with its memos replaced with the ones given.
@@ -59,7 +71,40 @@ func (s *Scope) withMemos(memoNodes []sql.Node) *Scope {
 	if s == nil {
 		return &Scope{memos: memoNodes}
 	}
-	return &Scope{memos: memoNodes, nodes: s.nodes}
+	return &Scope{memos: memoNodes, nodes: s.nodes, tables: s.tables}
+}
+
+// resolvedTable returns the resolved table named [name] in the database [db] found in the scope nodes, if any. The
+// resolved tables of the outer scopes are the same tables the catalog would return for the name, so a subquery can
+// use them instead of looking them up again. Tables resolved as of a revision, and tables with pushed down filters or
+// projections, which are decorated, aren't reused.
+func (s *Scope) resolvedTable(db, name string) (*plan.ResolvedTable, bool) {
+	if s == nil || s.tables == nil {
+		return nil, false
+	}
+	s.tables.once.Do(func() {
+		s.tables.tables = make(map[string]*plan.ResolvedTable)
+		for _, n := range s.nodes {
+			plan.Inspect(n, func(n sql.Node) bool {
+				if _, ok := n.(*plan.DecoratedNode); ok {
+					return false
+				}
+				if rt, ok := n.(*plan.ResolvedTable); ok && rt.AsOf == nil && rt.Database != nil {
+					key := scopeTableKey(rt.Database.Name(), rt.Name())
+					if _, ok := s.tables.tables[key]; !ok {
+						s.tables.tables[key] = rt
+					}
+				}
+				return true
+			})
+		}
+	})
+	rt, ok := s.tables.tables[scopeTableKey(db, name)]
+	return rt, ok
+}
+
+func scopeTableKey(db, name string) string {
+	return strings.ToLower(db) + "." + strings.ToLower(name)
 }
 
 func (s *Scope) MemoNodes() []sql.Node {
@@ -0,0 +1,332 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzer
+
+import (
+	"reflect"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// decorrelateExistsSubqueries rewrites filters on correlated EXISTS subqueries into semi joins. A subquery qualifies
+// when it reads a single table and references the outer query only through equalities between an outer column and an
+// inner one in its WHERE clause. The semi join evaluates the subquery once for the whole query, rather than once for
+// every outer row.
+func decorrelateExistsSubqueries(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, ctx := ctx.Span("decorrelate_exists_subqueries")
+	defer span.Finish()
+
+	return rewriteFilterSubqueries(n, scope, func(e sql.Expression, left sql.Node) (sql.Node, bool) {
+		exists, ok := e.(*plan.ExistsSubquery)
+		if !ok {
+			return nil, false
+		}
+		subquery, ok := exists.Children()[0].(*plan.Subquery)
+		if !ok {
+			return nil, false
+		}
+		ds, ok := decorrelateSubquery(subquery.Query, len(left.Schema()))
+		// Uncorrelated subqueries are already cached by cacheSubqueryResults
+		if !ok || len(ds.joinConds) == 0 {
+			return nil, false
+		}
+		cond := expression.JoinAnd(ds.joinConds...)
+		a.Log("rewriting correlated EXISTS subquery as a semi join on %s", cond)
+		return plan.NewSemiJoin(left, ds.source, cond), true
+	})
+}
+
+// rewriteInSubqueries rewrites filters on IN and NOT IN subqueries into semi joins and anti joins respectively. Both
+// uncorrelated subqueries and subqueries of the shape accepted by decorrelateExistsSubqueries qualify, as long as they
+// select a single column. The anti join for NOT IN rejects an outer row when the comparison is NULL for any row of the
+// subquery, so that a NULL on either side makes the predicate unknown unless the subquery is empty.
+func rewriteInSubqueries(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, ctx := ctx.Span("rewrite_in_subqueries")
+	defer span.Finish()
+
+	return rewriteFilterSubqueries(n, scope, func(e sql.Expression, left sql.Node) (sql.Node, bool) {
+		anti := false
+		if not, ok := e.(*expression.Not); ok {
+			anti = true
+			e = not.Child
+		}
+		in, ok := e.(*plan.InSubquery)
+		if !ok {
+			return nil, false
+		}
+		subquery, ok := in.Right.(*plan.Subquery)
+		if !ok || sql.NumColumns(in.Left.Type()) != 1 {
+			return nil, false
+		}
+		ds, ok := decorrelateSubquery(subquery.Query, len(left.Schema()))
+		if !ok || len(ds.projections) != 1 {
+			return nil, false
+		}
+		projection := ds.projections[0]
+		if alias, ok := projection.(*expression.Alias); ok {
+			projection = alias.Child
+		}
+		if sql.NumColumns(projection.Type()) != 1 {
+			return nil, false
+		}
+
+		eq := expression.NewEquals(in.Left, projection)
+		if anti {
+			// Rows of the subquery that don't match the correlation must not make the predicate unknown
+			cond := sql.Expression(eq)
+			if len(ds.joinConds) > 0 {
+				cond = expression.NewAnd(expression.NewIsTrue(expression.JoinAnd(ds.joinConds...)), eq)
+			}
+			a.Log("rewriting NOT IN subquery as an anti join on %s", cond)
+			return plan.NewAntiJoin(left, ds.source, cond), true
+		}
+
+		conds := ds.joinConds
+		if !containsExpression(conds, eq) {
+			conds = append(conds, eq)
+		}
+		cond := expression.JoinAnd(conds...)
+		a.Log("rewriting IN subquery as a semi join on %s", cond)
+		return plan.NewSemiJoin(left, ds.source, cond), true
+	})
+}
+
+// rewriteFilterSubqueries replaces each conjunct of a filter for which |rewrite| returns true with the join it
+// returns, which must have the same schema as its left side. Filters that select the rows of an UPDATE or DELETE are
+// left alone, since those nodes need to find the table they modify under them.
+func rewriteFilterSubqueries(n sql.Node, scope *Scope, rewrite func(e sql.Expression, left sql.Node) (sql.Node, bool)) (sql.Node, error) {
+	// The correlation conditions are only valid as join conditions when the subquery's scope is exactly the row of
+	// the filter's child, which isn't true inside another subquery.
+	if !n.Resolved() || len(scope.Schema()) > 0 {
+		return n, nil
+	}
+	switch n.(type) {
+	case *plan.Update, *plan.DeleteFrom:
+		return n, nil
+	}
+
+	return plan.TransformUpCtx(n, isNotDmlTarget, func(c plan.TransformContext) (sql.Node, error) {
+		n := c.Node
+		filter, ok := n.(*plan.Filter)
+		if !ok {
+			return n, nil
+		}
+
+		child := filter.Child
+		changed := false
+		var remaining []sql.Expression
+		for _, e := range splitConjunction(filter.Expression) {
+			join, ok := rewrite(e, child)
+			if !ok {
+				remaining = append(remaining, e)
+				continue
+			}
+			child = join
+			changed = true
+		}
+
+		if !changed {
+			return n, nil
+		}
+		if len(remaining) == 0 {
+			return child, nil
+		}
+		return plan.NewFilter(expression.JoinAnd(remaining...), child), nil
+	})
+}
+
+// isNotDmlTarget returns false for the children of nodes that modify the rows of their child.
+func isNotDmlTarget(c plan.TransformContext) bool {
+	switch c.Parent.(type) {
+	case *plan.Update, *plan.DeleteFrom:
+		return false
+	default:
+		return true
+	}
+}
+
+// decorrelatedSubquery is a subquery split into a part that can be evaluated independently of the outer row, and
+// the expressions that relate it to the outer row. All expressions are in terms of the outer row followed by a row of
+// |source|.
+type decorrelatedSubquery struct {
+	source      sql.Node
+	joinConds   []sql.Expression
+	projections []sql.Expression
+}
+
+// decorrelateSubquery splits the analyzed subquery given, whose scope has |scopeLen| columns, into a
+// decorrelatedSubquery. Returns false if the subquery doesn't have the required shape.
+func decorrelateSubquery(query sql.Node, scopeLen int) (decorrelatedSubquery, bool) {
+	var ds decorrelatedSubquery
+	n := query
+	var filters []sql.Expression
+	projectionsValid := true
+	for done := false; !done; {
+		switch nn := n.(type) {
+		case *plan.Project, *plan.Distinct, *plan.Sort:
+			if len(filters) > 0 {
+				// A filter above a projection refers to the projection's schema, not the table's
+				return ds, false
+			}
+			if p, ok := nn.(*plan.Project); ok {
+				if ds.projections != nil {
+					// The outer projection refers to the schema of the inner one
+					projectionsValid = false
+				}
+				ds.projections = p.Projections
+			}
+			n = nn.Children()[0]
+		case *plan.Filter:
+			filters = append(filters, splitConjunction(nn.Expression)...)
+			n = nn.Child
+		default:
+			done = true
+		}
+	}
+	if !projectionsValid {
+		ds.projections = nil
+	}
+	for _, p := range ds.projections {
+		if !exprIsCacheable(p, scopeLen) {
+			ds.projections = nil
+			break
+		}
+	}
+
+	source, ok := uncorrelatedTableSource(n)
+	if !ok {
+		return ds, false
+	}
+
+	var residual []sql.Expression
+	for _, f := range filters {
+		if exprIsCacheable(f, scopeLen) {
+			shifted, err := shiftFieldIndexes(f, -scopeLen)
+			if err != nil {
+				return ds, false
+			}
+			residual = append(residual, shifted)
+			continue
+		}
+
+		eq, ok := f.(*expression.Equals)
+		if !ok {
+			return ds, false
+		}
+		if !(onlyReferencesScope(eq.Left(), scopeLen) && referencesOnlyInner(eq.Right(), scopeLen)) &&
+			!(onlyReferencesScope(eq.Right(), scopeLen) && referencesOnlyInner(eq.Left(), scopeLen)) {
+			return ds, false
+		}
+		ds.joinConds = append(ds.joinConds, eq)
+	}
+
+	if len(residual) > 0 {
+		source = plan.NewFilter(expression.JoinAnd(residual...), source)
+	}
+	ds.source = source
+	return ds, true
+}
+
+// uncorrelatedTableSource returns whether the node given is a single table access that doesn't depend on the outer
+// row. A subquery that looks up an index with the values of the outer row is already cheap to evaluate for every
+// outer row, and a join would have to replace the lookup with a scan of the whole table, so it doesn't qualify.
+func uncorrelatedTableSource(n sql.Node) (sql.Node, bool) {
+	simple := true
+	plan.Inspect(n, func(n sql.Node) bool {
+		switch n := n.(type) {
+		case nil, *plan.ResolvedTable, *plan.TableAlias, *plan.DecoratedNode:
+		case *plan.IndexedTableAccess:
+			for _, e := range n.Expressions() {
+				if hasGetField(e) {
+					simple = false
+				}
+			}
+		default:
+			simple = false
+		}
+		return simple
+	})
+	return n, simple
+}
+
+// hasGetField returns whether the expression given reads any column.
+func hasGetField(e sql.Expression) bool {
+	found := false
+	sql.Inspect(e, func(e sql.Expression) bool {
+		if _, ok := e.(*expression.GetField); ok {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// onlyReferencesScope returns whether the expression given reads at least one column, and reads only columns of the
+// outer scope, which are those with an index less than |scopeLen|.
+func onlyReferencesScope(e sql.Expression, scopeLen int) bool {
+	found := false
+	valid := true
+	sql.Inspect(e, func(e sql.Expression) bool {
+		switch e := e.(type) {
+		case *expression.GetField:
+			found = true
+			if e.Index() >= scopeLen {
+				valid = false
+			}
+		case *plan.Subquery:
+			valid = false
+		}
+		if isNonDeterministic(e) {
+			valid = false
+		}
+		return valid
+	})
+	return found && valid
+}
+
+// referencesOnlyInner returns whether the expression given reads at least one column, and reads no columns of the
+// outer scope.
+func referencesOnlyInner(e sql.Expression, scopeLen int) bool {
+	found := false
+	sql.Inspect(e, func(e sql.Expression) bool {
+		if _, ok := e.(*expression.GetField); ok {
+			found = true
+		}
+		return true
+	})
+	return found && exprIsCacheable(e, scopeLen)
+}
+
+// containsExpression returns whether the expression given is in the list given.
+func containsExpression(exprs []sql.Expression, e sql.Expression) bool {
+	for _, expr := range exprs {
+		if reflect.DeepEqual(expr, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// shiftFieldIndexes returns the expression given with the index of every GetField moved by |offset|.
+func shiftFieldIndexes(e sql.Expression, offset int) (sql.Expression, error) {
+	return expression.TransformUp(e, func(e sql.Expression) (sql.Expression, error) {
+		if gf, ok := e.(*expression.GetField); ok {
+			return gf.WithIndex(gf.Index() + offset), nil
+		}
+		return e, nil
+	})
+}
@@ -35,36 +35,37 @@ func validateCreateTrigger(ctx *sql.Context, a *Analyzer, node sql.Node, scope *
 		return node, nil
 	}
 
+	// Triggers can only be defined on base tables, not views
+	if err := validateTriggerTable(ct.Table); err != nil {
+		return nil, err
+	}
+
+	// The parser only accepts valid timings and events, but trigger nodes can be built by other means as well
+	switch plan.TriggerTime(ct.TriggerTime) {
+	case plan.BeforeTrigger, plan.AfterTrigger:
+	default:
+		return nil, sql.ErrInvalidTriggerTime.New(ct.TriggerTime)
+	}
+	switch plan.TriggerEvent(ct.TriggerEvent) {
+	case plan.InsertTrigger, plan.UpdateTrigger, plan.DeleteTrigger:
+	default:
+		return nil, sql.ErrInvalidTriggerEvent.New(ct.TriggerEvent)
+	}
+
+	forEach := plan.TriggerForEach(ct.ForEach)
+	if forEach != plan.RowTrigger && forEach != plan.StatementTrigger {
+		return nil, sql.ErrInvalidTriggerForEach.New(ct.ForEach)
+	}
+
 	// We just want to verify that the trigger is correctly defined before creating it. If it is, we replace the
 	// UnresolvedColumn expressions with placeholder expressions that say they are Resolved().
 	// TODO: this might work badly for databases with tables named new and old. Needs tests.
 	var err error
-	plan.InspectExpressions(ct, func(e sql.Expression) bool {
-		switch e := e.(type) {
-		case *expression.UnresolvedColumn:
-			if strings.ToLower(e.Table()) == "new" {
-				if ct.TriggerEvent == sqlparser.DeleteStr {
-					err = sql.ErrInvalidUseOfOldNew.New("new", ct.TriggerEvent)
-				}
-			}
-			if strings.ToLower(e.Table()) == "old" {
-				if ct.TriggerEvent == sqlparser.InsertStr {
-					err = sql.ErrInvalidUseOfOldNew.New("old", ct.TriggerEvent)
-				}
-			}
-		case *deferredColumn:
-			if strings.ToLower(e.Table()) == "new" {
-				if ct.TriggerEvent == sqlparser.DeleteStr {
-					err = sql.ErrInvalidUseOfOldNew.New("new", ct.TriggerEvent)
-				}
-			}
-			if strings.ToLower(e.Table()) == "old" {
-				if ct.TriggerEvent == sqlparser.InsertStr {
-					err = sql.ErrInvalidUseOfOldNew.New("old", ct.TriggerEvent)
-				}
-			}
+	inspectTriggerBodyExpressions(ct.Body, func(e sql.Expression) bool {
+		if col, ok := e.(column); ok && err == nil {
+			err = validateOldNewReference(ct, col.Table())
 		}
-		return true
+		return err == nil
 	})
 
 	if err != nil {
@@ -99,15 +100,16 @@ func validateCreateTrigger(ctx *sql.Context, a *Analyzer, node sql.Node, scope *
 
 	// Finally analyze the entire trigger body with an appropriate scope for any "old" and "new" table references. This
 	// will catch (most) other errors in a trigger body. We set the trigger body at the end to pass to final validation
-	// steps at the end of analysis.
-	scopeNode := plan.NewProject(
-		[]sql.Expression{expression.NewStar()},
-		plan.NewCrossJoin(
-			plan.NewTableAlias("old", getResolvedTable(ct.Table)),
-			plan.NewTableAlias("new", getResolvedTable(ct.Table)),
-		),
-	)
+	// steps at the end of analysis. Statement triggers have no rows to reference.
+	if forEach == plan.StatementTrigger {
+		triggerLogic, err := a.Analyze(ctx, ct.Body, nil)
+		if err != nil {
+			return nil, err
+		}
+		return ct.WithChildren(ct.Table, StripPassthroughNodes(triggerLogic))
+	}
 
+	scopeNode := triggerScopeNode(ct.TriggerEvent, getResolvedTable(ct.Table))
 	triggerLogic, err := a.Analyze(ctx, ct.Body, (*Scope)(nil).newScope(scopeNode))
 	if err != nil {
 		return nil, err
@@ -116,6 +118,26 @@ func validateCreateTrigger(ctx *sql.Context, a *Analyzer, node sql.Node, scope *
 	return ct.WithChildren(ct.Table, StripPassthroughNodes(triggerLogic))
 }
 
+// validateTriggerTable returns an error if the resolved table node given is not a base table that can have triggers
+// defined on it. Views, temporary tables and the tables of read-only databases can't.
+func validateTriggerTable(n sql.Node) error {
+	if sq, ok := n.(*plan.SubqueryAlias); ok {
+		return sql.ErrExpectedTableFoundView.New(sq.Name())
+	}
+
+	rt := getResolvedTable(n)
+	if rt == nil {
+		return sql.ErrExpectedTableFoundView.New(getTableName(n))
+	}
+	if tt, ok := rt.Table.(sql.TemporaryTable); ok && tt.IsTemporary() {
+		return sql.ErrTriggerOnTemporaryTable.New(rt.Name())
+	}
+	if ro, ok := rt.Database.(sql.ReadOnlyDatabase); ok && ro.IsReadOnly() {
+		return ErrReadOnlyDatabase.New(ro.Name())
+	}
+	return nil
+}
+
 func applyTriggers(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
 	// Skip this step for CreateTrigger statements
 	if _, ok := n.(*plan.CreateTrigger); ok {
@@ -177,6 +199,7 @@ func applyTriggers(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql
 			if !ok {
 				return nil, sql.ErrTriggerCreateStatementInvalid.New(trigger.CreateStatement)
 			}
+			ct.SqlMode = trigger.SqlMode // run the body under the sql_mode it was created with
 
 			triggerTable := getTableName(ct.Table)
 			if stringContains(affectedTables, triggerTable) && triggerEventsMatch(triggerEvent, ct.TriggerEvent) {
@@ -217,6 +240,15 @@ func applyTrigger(ctx *sql.Context, a *Analyzer, originalNode, n sql.Node, scope
 		return nil, err
 	}
 
+	triggerTable := getTableName(trigger.Table)
+	triggerTime := plan.TriggerTime(trigger.TriggerTime)
+	triggerDefinition := sql.TriggerDefinition{
+		Name:            trigger.TriggerName,
+		CreateStatement: trigger.CreateTriggerString,
+		ForEach:         trigger.ForEach,
+		SqlMode:         trigger.SqlMode,
+	}
+
 	return plan.TransformUpCtx(n, nil, func(c plan.TransformContext) (sql.Node, error) {
 		// Don't double-apply trigger executors to the bodies of triggers. To avoid this, don't apply the trigger if the
 		// parent is a trigger body.
@@ -232,42 +264,24 @@ func applyTrigger(ctx *sql.Context, a *Analyzer, originalNode, n sql.Node, scope
 		switch n := c.Node.(type) {
 		case *plan.InsertInto:
 			if trigger.TriggerTime == sqlparser.BeforeStr {
-				triggerExecutor := plan.NewTriggerExecutor(n.Source, triggerLogic, plan.InsertTrigger, plan.TriggerTime(trigger.TriggerTime), sql.TriggerDefinition{
-					Name:            trigger.TriggerName,
-					CreateStatement: trigger.CreateTriggerString,
-				})
+				triggerExecutor := plan.NewTriggerExecutor(n.Source, triggerLogic, triggerTable, plan.InsertTrigger, triggerTime, triggerDefinition)
 				return n.WithSource(triggerExecutor), nil
 			} else {
-				return plan.NewTriggerExecutor(n, triggerLogic, plan.InsertTrigger, plan.TriggerTime(trigger.TriggerTime), sql.TriggerDefinition{
-					Name:            trigger.TriggerName,
-					CreateStatement: trigger.CreateTriggerString,
-				}), nil
+				return plan.NewTriggerExecutor(n, triggerLogic, triggerTable, plan.InsertTrigger, triggerTime, triggerDefinition), nil
 			}
 		case *plan.Update:
 			if trigger.TriggerTime == sqlparser.BeforeStr {
-				triggerExecutor := plan.NewTriggerExecutor(n.Child, triggerLogic, plan.UpdateTrigger, plan.TriggerTime(trigger.TriggerTime), sql.TriggerDefinition{
-					Name:            trigger.TriggerName,
-					CreateStatement: trigger.CreateTriggerString,
-				})
+				triggerExecutor := plan.NewTriggerExecutor(n.Child, triggerLogic, triggerTable, plan.UpdateTrigger, triggerTime, triggerDefinition)
 				return n.WithChildren(triggerExecutor)
 			} else {
-				return plan.NewTriggerExecutor(n, triggerLogic, plan.UpdateTrigger, plan.TriggerTime(trigger.TriggerTime), sql.TriggerDefinition{
-					Name:            trigger.TriggerName,
-					CreateStatement: trigger.CreateTriggerString,
-				}), nil
+				return plan.NewTriggerExecutor(n, triggerLogic, triggerTable, plan.UpdateTrigger, triggerTime, triggerDefinition), nil
 			}
 		case *plan.DeleteFrom:
 			if trigger.TriggerTime == sqlparser.BeforeStr {
-				triggerExecutor := plan.NewTriggerExecutor(n.Child, triggerLogic, plan.DeleteTrigger, plan.TriggerTime(trigger.TriggerTime), sql.TriggerDefinition{
-					Name:            trigger.TriggerName,
-					CreateStatement: trigger.CreateTriggerString,
-				})
+				triggerExecutor := plan.NewTriggerExecutor(n.Child, triggerLogic, triggerTable, plan.DeleteTrigger, triggerTime, triggerDefinition)
 				return n.WithChildren(triggerExecutor)
 			} else {
-				return plan.NewTriggerExecutor(n, triggerLogic, plan.DeleteTrigger, plan.TriggerTime(trigger.TriggerTime), sql.TriggerDefinition{
-					Name:            trigger.TriggerName,
-					CreateStatement: trigger.CreateTriggerString,
-				}), nil
+				return plan.NewTriggerExecutor(n, triggerLogic, triggerTable, plan.DeleteTrigger, triggerTime, triggerDefinition), nil
 			}
 		}
 
@@ -283,35 +297,73 @@ func getTriggerLogic(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope, tr
 	// fabricate one with the right properties (its child schema matches the table schema, with the right aliased name)
 	var triggerLogic sql.Node
 	var err error
-	switch trigger.TriggerEvent {
+	if plan.TriggerForEach(trigger.ForEach) == plan.StatementTrigger {
+		// Statement triggers don't reference the rows of the trigger table, so they only need the memos of the scope
+		triggerLogic, err = a.Analyze(ctx, trigger.Body, (*Scope)(nil).withMemos(scope.memo(n).MemoNodes()))
+		return StripPassthroughNodes(triggerLogic), err
+	}
+
+	scopeNode := triggerScopeNode(trigger.TriggerEvent, getResolvedTable(n))
+	triggerLogic, err = a.Analyze(ctx, trigger.Body, (*Scope)(nil).newScope(scopeNode).withMemos(scope.memo(n).MemoNodes()))
+	return StripPassthroughNodes(triggerLogic), err
+}
+
+// triggerScopeNode returns the node used as the scope of the body of a row trigger on the table given, which exposes
+// the rows of the trigger table that are available to the trigger event given: "new" for inserts, "old" for deletes
+// and both for updates, in that order.
+func triggerScopeNode(triggerEvent string, table sql.Node) sql.Node {
+	var rows sql.Node
+	switch strings.ToLower(triggerEvent) {
 	case sqlparser.InsertStr:
-		scopeNode := plan.NewProject(
-			[]sql.Expression{expression.NewStar()},
-			plan.NewTableAlias("new", getResolvedTable(n)),
-		)
-		triggerLogic, err = a.Analyze(ctx, trigger.Body, (*Scope)(nil).newScope(scopeNode).withMemos(scope.memo(n).MemoNodes()))
-	case sqlparser.UpdateStr:
-		scopeNode := plan.NewProject(
-			[]sql.Expression{expression.NewStar()},
-			plan.NewCrossJoin(
-				plan.NewTableAlias("old", getResolvedTable(n)),
-				plan.NewTableAlias("new", getResolvedTable(n)),
-			),
-		)
-		triggerLogic, err = a.Analyze(ctx, trigger.Body, (*Scope)(nil).newScope(scopeNode).withMemos(scope.memo(n).MemoNodes()))
+		rows = plan.NewTableAlias("new", table)
 	case sqlparser.DeleteStr:
-		scopeNode := plan.NewProject(
-			[]sql.Expression{expression.NewStar()},
-			plan.NewTableAlias("old", getResolvedTable(n)),
+		rows = plan.NewTableAlias("old", table)
+	default:
+		rows = plan.NewCrossJoin(
+			plan.NewTableAlias("old", table),
+			plan.NewTableAlias("new", table),
 		)
-		triggerLogic, err = a.Analyze(ctx, trigger.Body, (*Scope)(nil).newScope(scopeNode).withMemos(scope.memo(n).MemoNodes()))
 	}
+	return plan.NewProject([]sql.Expression{expression.NewStar()}, rows)
+}
+
+// validateOldNewReference returns an error if the table given is a reference to the "old" or "new" row that isn't
+// available to the trigger given: statement triggers have neither, insert triggers have no old row, and delete
+// triggers have no new row.
+func validateOldNewReference(ct *plan.CreateTrigger, table string) error {
+	if !isOldOrNew(table) {
+		return nil
+	}
+	table = strings.ToLower(table)
+	if plan.TriggerForEach(ct.ForEach) == plan.StatementTrigger {
+		return sql.ErrInvalidUseOfOldNew.New(table, plan.StatementTrigger)
+	}
+	if (table == "new" && ct.TriggerEvent == sqlparser.DeleteStr) || (table == "old" && ct.TriggerEvent == sqlparser.InsertStr) {
+		return sql.ErrInvalidUseOfOldNew.New(table, ct.TriggerEvent)
+	}
+	return nil
+}
 
-	return StripPassthroughNodes(triggerLogic), err
+// inspectTriggerBodyExpressions calls f on the expressions of the trigger body given, including those in the sources
+// of INSERT statements, which aren't children of their InsertInto nodes.
+func inspectTriggerBodyExpressions(body sql.Node, f func(sql.Expression) bool) {
+	plan.Inspect(body, func(n sql.Node) bool {
+		if ii, ok := n.(*plan.InsertInto); ok {
+			inspectTriggerBodyExpressions(ii.Source, f)
+		}
+		if ex, ok := n.(sql.Expressioner); ok {
+			for _, e := range ex.Expressions() {
+				sql.Inspect(e, f)
+			}
+		}
+		return true
+	})
 }
 
 // validateNoCircularUpdates returns an error if the trigger logic attempts to update the table that invoked it (or any
-// table being updated in an outer scope of this analysis)
+// table being updated in an outer scope of this analysis). This rejects recursive trigger chains, such as two triggers
+// that insert into each other's tables, before they execute. TriggerExecutor also refuses to re-enter the triggers of a
+// table and event that are already executing, for plans that get past this check.
 func validateNoCircularUpdates(trigger *plan.CreateTrigger, n sql.Node, scope *Scope) error {
 	var circularRef error
 	plan.Inspect(trigger.Body, func(node sql.Node) bool {
@@ -389,6 +441,12 @@ Top:
 	return beforeTriggers, afterTriggers
 }
 
+// isOldOrNew returns whether the table name given is one of the "old" and "new" references to the rows of a trigger.
+func isOldOrNew(table string) bool {
+	table = strings.ToLower(table)
+	return table == "old" || table == "new"
+}
+
 func triggerEventsMatch(event plan.TriggerEvent, event2 string) bool {
 	return strings.ToLower((string)(event)) == strings.ToLower(event2)
 }
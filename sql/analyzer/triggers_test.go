@@ -0,0 +1,74 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// temporaryTable is a memory table that reports itself as temporary.
+type temporaryTable struct {
+	*memory.Table
+}
+
+func (t temporaryTable) IsTemporary() bool {
+	return true
+}
+
+func TestValidateCreateTriggerErrors(t *testing.T) {
+	f := getRule("validate_create_trigger")
+
+	schema := sql.NewPrimaryKeySchema(sql.Schema{{Name: "i", Type: sql.Int64, Source: "mytable"}})
+	db := memory.NewDatabase("mydb")
+	table := memory.NewTable("mytable", schema)
+	readOnlyDb := memory.NewReadOnlyDatabase("readonly")
+
+	body := plan.NewInsertInto(sql.UnresolvedDatabase(""), plan.NewUnresolvedTable("other", ""), plan.NewValues([][]sql.Expression{{
+		expression.NewUnresolvedQualifiedColumn("new", "i"),
+	}}), false, nil, nil, false)
+	trigger := func(triggerTime, triggerEvent string, table sql.Node) sql.Node {
+		return plan.NewCreateTrigger(db, "trig", triggerTime, triggerEvent, "row", nil, table, body, "", "", time.Unix(0, 0), "", "", false)
+	}
+
+	testCases := []struct {
+		name string
+		node sql.Node
+		err  *errors.Kind
+	}{
+		{"invalid timing", trigger("during", "insert", plan.NewResolvedTable(table, db, nil)), sql.ErrInvalidTriggerTime},
+		{"upper case timing", trigger("BEFORE", "insert", plan.NewResolvedTable(table, db, nil)), sql.ErrInvalidTriggerTime},
+		{"invalid event", trigger("before", "select", plan.NewResolvedTable(table, db, nil)), sql.ErrInvalidTriggerEvent},
+		{"temporary table", trigger("before", "insert", plan.NewResolvedTable(temporaryTable{table}, db, nil)), sql.ErrTriggerOnTemporaryTable},
+		{"read-only database", trigger("before", "insert", plan.NewResolvedTable(table, readOnlyDb, nil)), ErrReadOnlyDatabase},
+		{"view", trigger("before", "insert", plan.NewSubqueryAlias("myview", "", plan.NewResolvedTable(table, db, nil))), sql.ErrExpectedTableFoundView},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.Apply(sql.NewEmptyContext(), nil, tt.node, nil)
+			require.Error(t, err)
+			require.True(t, tt.err.Is(err), "unexpected error %v", err)
+		})
+	}
+}
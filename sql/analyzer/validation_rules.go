@@ -191,6 +191,10 @@ func unresolvedError(n sql.Node) error {
 		switch e := e.(type) {
 		case *plan.Subquery:
 			plan.InspectExpressions(e.Query, walkFn)
+			if err == nil && !e.Resolved() {
+				// the error that deferred the analysis of the subquery is more specific than an unresolved node
+				err = e.DeferredError()
+			}
 			if err != nil {
 				return false
 			}
@@ -39,6 +39,30 @@ func TestValidateResolved(t *testing.T) {
 	require.Error(err)
 }
 
+func TestValidateResolvedDeferredSubquery(t *testing.T) {
+	require := require.New(t)
+
+	vr := getValidationRule(validateResolvedRule)
+
+	subquery := plan.NewSubquery(
+		plan.NewProject(
+			[]sql.Expression{expression.NewUnresolvedColumn("nonexistent")},
+			plan.NewUnresolvedTable("b", ""),
+		),
+		"select nonexistent from b",
+	)
+	node := func(s *plan.Subquery) sql.Node {
+		return plan.NewProject([]sql.Expression{s}, dummyNode{true})
+	}
+
+	_, err := vr.Apply(sql.NewEmptyContext(), nil, node(subquery), nil)
+	require.True(ErrValidationResolved.Is(err))
+
+	_, err = vr.Apply(sql.NewEmptyContext(), nil, node(subquery.WithDeferredError(sql.ErrColumnNotFound.New("nonexistent"))), nil)
+	require.True(sql.ErrColumnNotFound.Is(err))
+	require.Contains(err.Error(), "nonexistent")
+}
+
 func TestValidateOrderBy(t *testing.T) {
 	require := require.New(t)
 
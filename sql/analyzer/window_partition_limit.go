@@ -0,0 +1,170 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation/window"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// limitWindowPartitions sets a PartitionLimit on a Window whose one window function is ROW_NUMBER() when the filter
+// right above it only keeps the first rows of each partition, as in
+// `SELECT ... FROM (SELECT ..., ROW_NUMBER() OVER (...) rn FROM t) sq WHERE rn <= 3`. The Window then keeps only the
+// top rows of each partition, rather than sorting and numbering every row. The filter is left in place.
+func limitWindowPartitions(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	// Field indexes are offset by the outer scope, which the indexes below don't account for
+	if len(scope.Schema()) > 0 {
+		return n, nil
+	}
+
+	return plan.TransformUp(n, func(n sql.Node) (sql.Node, error) {
+		filter, ok := n.(*plan.Filter)
+		if !ok {
+			return n, nil
+		}
+
+		project, _ := filter.Child.(*plan.Project)
+		var w *plan.Window
+		if project != nil {
+			w, ok = project.Child.(*plan.Window)
+		} else {
+			w, ok = filter.Child.(*plan.Window)
+		}
+		if !ok || w.PartitionLimit > 0 {
+			return n, nil
+		}
+
+		rowNumberIdx := windowRowNumberIndex(w)
+		if rowNumberIdx < 0 {
+			return n, nil
+		}
+
+		limit := 0
+		for _, cond := range splitConjunction(filter.Expression) {
+			gf, bound, ok := rowNumberBound(cond)
+			if !ok {
+				continue
+			}
+
+			// Follow the field through the projection above the Window
+			idx := gf.Index()
+			if project != nil {
+				if idx >= len(project.Projections) {
+					continue
+				}
+				e := project.Projections[idx]
+				if alias, ok := e.(*expression.Alias); ok {
+					e = alias.Child
+				}
+				projected, ok := e.(*expression.GetField)
+				if !ok {
+					continue
+				}
+				idx = projected.Index()
+			}
+
+			if idx == rowNumberIdx && (limit == 0 || bound < limit) {
+				limit = bound
+			}
+		}
+		if limit <= 0 {
+			return n, nil
+		}
+
+		a.Log("limiting window partitions to %d rows", limit)
+		var child sql.Node = w.WithPartitionLimit(limit)
+		if project != nil {
+			var err error
+			child, err = project.WithChildren(child)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return filter.WithChildren(child)
+	})
+}
+
+// windowRowNumberIndex returns the index of the ROW_NUMBER() in the expressions of the Window given, or -1 if it's
+// not the only window function or aggregation of the Window.
+func windowRowNumberIndex(w *plan.Window) int {
+	idx := -1
+	for i, e := range w.SelectExprs {
+		if _, ok := e.(*window.RowNumber); ok && idx < 0 {
+			idx = i
+			continue
+		}
+		if containsWindow(e) || containsAggregation(e) {
+			return -1
+		}
+	}
+	return idx
+}
+
+// rowNumberBound returns the field and the largest row number that the condition given keeps, for conditions like
+// `rn <= 3`, `rn < 4`, `rn = 3` or `3 >= rn` with an integer literal.
+func rowNumberBound(cond sql.Expression) (*expression.GetField, int, bool) {
+	var left, right sql.Expression
+	var orEqual, equal bool
+	switch c := cond.(type) {
+	case *expression.Equals:
+		left, right, equal = c.Left(), c.Right(), true
+	case *expression.LessThanOrEqual:
+		left, right, orEqual = c.Left(), c.Right(), true
+	case *expression.LessThan:
+		left, right = c.Left(), c.Right()
+	case *expression.GreaterThanOrEqual:
+		left, right, orEqual = c.Right(), c.Left(), true
+	case *expression.GreaterThan:
+		left, right = c.Right(), c.Left()
+	default:
+		return nil, 0, false
+	}
+
+	// equality may be written either way around
+	if _, ok := right.(*expression.GetField); ok && equal {
+		left, right = right, left
+	}
+	gf, ok := left.(*expression.GetField)
+	if !ok {
+		return nil, 0, false
+	}
+	lit, ok := right.(*expression.Literal)
+	if !ok {
+		return nil, 0, false
+	}
+
+	if lit.Value() == nil || !sql.IsInteger(lit.Type()) {
+		return nil, 0, false
+	}
+	v, err := sql.Int64.Convert(lit.Value())
+	if err != nil {
+		return nil, 0, false
+	}
+
+	bound := v.(int64)
+	if !equal && !orEqual {
+		bound--
+	}
+	if bound < 1 || bound > maxWindowPartitionLimit {
+		return nil, 0, false
+	}
+	return gf, int(bound), true
+}
+
+// maxWindowPartitionLimit is the largest PartitionLimit set on a Window. Larger bounds keep most rows of any partition
+// anyway, and their heaps would cost more than sorting.
+const maxWindowPartitionLimit = 1000
@@ -17,7 +17,11 @@ package sql
 import (
 	"fmt"
 	"strings"
+	"unicode"
 
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
 	"gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/go-mysql-server/internal/regex"
@@ -56,6 +60,7 @@ type collationLike byte
 const (
 	collationCompareInsensitive collationCompare = iota
 	collationCompareSensitive
+	collationCompareCaseInsensitive
 )
 
 const (
@@ -74,7 +79,15 @@ type Collation struct {
 var Collations = map[string]Collation{}
 
 func newCollation(name string, cs CharacterSet) Collation {
-	c := Collation{Name: name, CharSet: cs, compare: collationCompareInsensitive, like: collationLikeInsensitive}
+	// Only the _ci collations compare case insensitively, and the _as_ci ones are still accent sensitive
+	compare := collationCompareSensitive
+	if strings.HasSuffix(name, "_ci") {
+		compare = collationCompareInsensitive
+		if strings.HasSuffix(name, "_as_ci") {
+			compare = collationCompareCaseInsensitive
+		}
+	}
+	c := Collation{Name: name, CharSet: cs, compare: compare, like: collationLikeInsensitive}
 	Collations[name] = c
 	return c
 }
@@ -1145,3 +1158,27 @@ func (c Collation) PadSpace() string {
 func (c Collation) Equals(other Collation) bool {
 	return c.Name == other.Name
 }
+
+// Compare compares two strings with this Collation, returning -1, 0 or 1 like strings.Compare. Case insensitive
+// collations compare the lower case strings, and accent insensitive collations also ignore the accents of letters.
+func (c Collation) Compare(a, b string) int {
+	switch c.compare {
+	case collationCompareInsensitive:
+		return strings.Compare(removeAccents(strings.ToLower(a)), removeAccents(strings.ToLower(b)))
+	case collationCompareCaseInsensitive:
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// removeAccents returns the string given with the combining marks of its decomposed letters removed, so that accented
+// letters become their base letters.
+func removeAccents(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	res, _, err := transform.String(t, s)
+	if err != nil {
+		return s
+	}
+	return res
+}
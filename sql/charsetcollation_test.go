@@ -69,3 +69,31 @@ func testParseCollation(t *testing.T, charset *string, collation *string, binary
 		}
 	})
 }
+
+func TestCollationCompare(t *testing.T) {
+	tests := []struct {
+		collation Collation
+		a         string
+		b         string
+		expected  int
+	}{
+		{Collation_utf8mb4_0900_bin, "a", "A", 1},
+		{Collation_utf8mb4_0900_bin, "a", "á", -1},
+		{Collation_utf8mb4_bin, "a", "A", 1},
+		{Collation_binary, "a", "A", 1},
+		{Collation_utf8mb4_0900_ai_ci, "a", "A", 0},
+		{Collation_utf8mb4_0900_ai_ci, "a", "á", 0},
+		{Collation_utf8mb4_0900_ai_ci, "Résumé", "resume", 0},
+		{Collation_utf8mb4_0900_ai_ci, "a", "B", -1},
+		{Collation_utf8mb4_general_ci, "B", "a", 1},
+		{Collation_utf8mb4_0900_as_ci, "a", "A", 0},
+		{Collation_utf8mb4_0900_as_ci, "a", "á", -1},
+		{Collation_utf8mb4_0900_as_cs, "a", "A", 1},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s %s %s", test.collation, test.a, test.b), func(t *testing.T) {
+			assert.Equal(t, test.expected, test.collation.Compare(test.a, test.b))
+		})
+	}
+}
@@ -131,14 +131,25 @@ type WindowFunction interface {
 	StartPartition(*Context, WindowInterval, WindowBuffer) error
 	// DefaultFramer returns a new instance of the default WindowFramer for a particular aggregation
 	DefaultFramer() WindowFramer
-	// NewSlidingFrameInterval is updates the function's internal aggregation state for the next
-	// Compute call using three WindowInterval: added, dropped, and current.
-	//TODO: implement sliding window interface in aggregation functions and windowBlockIter
-	//NewSlidingFrameInterval(added, dropped WindowInterval)
 	// Compute returns an aggregation result for a given interval and buffer
 	Compute(*Context, WindowInterval, WindowBuffer) interface{}
 }
 
+// SlidingWindowFunction is a WindowFunction with an invertible aggregation, which is updated
+// incrementally as the frame slides instead of being recomputed over the whole frame for every row.
+type SlidingWindowFunction interface {
+	WindowFunction
+
+	// StartSlidingPartition discards any previous state and initializes an empty incremental
+	// aggregation for a new partition
+	StartSlidingPartition(*Context, WindowInterval, WindowBuffer) error
+	// NewSlidingFrameInterval updates the function's internal aggregation state for the next
+	// ComputeSliding call, adding the rows in [added] and removing the rows in [dropped]
+	NewSlidingFrameInterval(ctx *Context, added, dropped WindowInterval, buf WindowBuffer) error
+	// ComputeSliding returns the aggregation result for the current frame
+	ComputeSliding(*Context) interface{}
+}
+
 // WindowAdaptableExpression is an Expression that can be executed as a window aggregation
 type WindowAdaptableExpression interface {
 	Expression
@@ -164,8 +175,7 @@ type WindowFramer interface {
 	Interval() (WindowInterval, error)
 	// SlidingInterval returns three WindowIntervals: the current frame, dropped range since the
 	// last frame, and added range since the last frame.
-	// TODO: implement sliding window interface in framers, windowBlockIter, and aggregation functions
-	//SlidingInterval(ctx Context) (WindowInterval, WindowInterval, WindowInterval)
+	SlidingInterval(ctx *Context) (WindowInterval, WindowInterval, WindowInterval)
 }
 
 // WindowFrame describe input bounds for an aggregation function
@@ -328,6 +338,22 @@ type TableFunction interface {
 	FunctionName() string
 }
 
+// TableFunctionParameter describes a parameter of a table function that accepts named arguments.
+type TableFunctionParameter struct {
+	// Name is the name used to give this argument by name, e.g. `start` in `f(start => 1)`.
+	Name string
+	// Default is the value used when the argument is not given. Parameters without a default are required.
+	Default Expression
+}
+
+// NamedArgumentsTableFunction is a TableFunction whose arguments can be given by name as well as by position.
+type NamedArgumentsTableFunction interface {
+	TableFunction
+
+	// Parameters returns the parameters of this table function, in positional order.
+	Parameters() []TableFunctionParameter
+}
+
 // Table represents the backend of a SQL table.
 type Table interface {
 	Nameable
@@ -742,6 +768,9 @@ type TriggerDefinition struct {
 	Name            string    // The name of this trigger. Trigger names in a database are unique.
 	CreateStatement string    // The text of the statement to create this trigger.
 	CreatedAt       time.Time // The time that the trigger was created.
+	Definer         string    // The user whose privileges the trigger runs with, in user@host form.
+	ForEach         string    // Whether the trigger runs for each "row" or each "statement". Empty means each row.
+	SqlMode         string    // The sql_mode the trigger was created under, which its body runs with.
 }
 
 // TriggerDatabase is a Database that supports the creation and execution of triggers. The engine handles all parsing
@@ -754,7 +783,7 @@ type TriggerDatabase interface {
 	GetTriggers(ctx *Context) ([]TriggerDefinition, error)
 
 	// CreateTrigger is called when an integrator is asked to create a trigger. The create trigger statement string is
-	// provided to store, along with the name of the trigger.
+	// provided to store, along with the name of the trigger. The name has already been validated to be unused.
 	CreateTrigger(ctx *Context, definition TriggerDefinition) error
 
 	// DropTrigger is called when a trigger should no longer be stored. The name has already been validated.
@@ -150,9 +150,19 @@ var (
 	// ErrTriggerDoesNotExist is returned when a trigger does not exist.
 	ErrTriggerDoesNotExist = errors.NewKind(`trigger "%s" does not exist`)
 
+	// ErrTriggerOnTemporaryTable is returned when creating a trigger on a temporary table
+	ErrTriggerOnTemporaryTable = errors.NewKind("Trigger's '%s' is a temporary table")
+
+	// ErrTriggerAlreadyExists is returned when creating a trigger with the name of an existing one.
+	ErrTriggerAlreadyExists = errors.NewKind(`trigger "%s" already exists`)
+
 	// ErrTriggerTableInUse is returned when trigger execution calls for a table that invoked a trigger being updated by it
 	ErrTriggerTableInUse = errors.NewKind("Can't update table %s in stored function/trigger because it is already used by statement which invoked this stored function/trigger")
 
+	// ErrRecursiveTrigger is returned when executing a trigger would re-enter a (table, event) pair whose triggers are
+	// already executing.
+	ErrRecursiveTrigger = errors.NewKind("recursive trigger invocation: %s triggers on table %s are already executing")
+
 	// ErrTriggerCannotBeDropped is returned when dropping a trigger would cause another trigger to reference a non-existent trigger.
 	ErrTriggerCannotBeDropped = errors.NewKind(`trigger "%s" cannot be dropped as it is referenced by trigger "%s"`)
 
@@ -198,6 +208,15 @@ var (
 	// ErrInvalidUseOfOldNew is returned when a trigger attempts to make use of OLD or NEW references when they don't exist
 	ErrInvalidUseOfOldNew = errors.NewKind("There is no %s row in on %s trigger")
 
+	// ErrInvalidTriggerTime is returned when a trigger runs at some time other than before or after its event
+	ErrInvalidTriggerTime = errors.NewKind("Invalid trigger timing: %s, expected BEFORE or AFTER")
+
+	// ErrInvalidTriggerEvent is returned when a trigger runs on some event other than an insert, update or delete
+	ErrInvalidTriggerEvent = errors.NewKind("Invalid trigger event: %s, expected INSERT, UPDATE or DELETE")
+
+	// ErrInvalidTriggerForEach is returned when a trigger runs for each of something other than rows or statements
+	ErrInvalidTriggerForEach = errors.NewKind("Invalid FOR EACH clause in trigger: %s, expected ROW or STATEMENT")
+
 	// ErrInvalidUpdateOfOldRow is returned when a trigger attempts to assign to an old row's value with SET
 	ErrInvalidUpdateOfOldRow = errors.NewKind("Updating of old row is not allowed in trigger")
 
@@ -232,6 +251,10 @@ var (
 	// list with a different number of columns than the schema of the table.
 	ErrColumnCountMismatch = errors.NewKind("In definition of view, derived table or common table expression, SELECT list and column names list have different column counts")
 
+	// ErrDuplicateColumnName is returned when a derived table or common table expression has a declared column list
+	// that names the same column more than once.
+	ErrDuplicateColumnName = errors.NewKind("Duplicate column name '%s'")
+
 	// ErrUuidUnableToParse is returned when a UUID is unable to be parsed.
 	ErrUuidUnableToParse = errors.NewKind("unable to parse '%s' to UUID: %s")
 
@@ -341,9 +364,27 @@ var (
 	// ErrFunctionNotFound is thrown when a function is not found
 	ErrFunctionNotFound = errors.NewKind("function: '%s' not found")
 
+	// ErrBuiltInFunctionRedefined is thrown when a user-defined function is registered with the name of a built-in
+	ErrBuiltInFunctionRedefined = errors.NewKind("function '%s' is a built-in function and can't be redefined")
+
 	// ErrTableFunctionNotFound is thrown when a table function is not found
 	ErrTableFunctionNotFound = errors.NewKind("table function: '%s' not found")
 
+	// ErrTableFunctionNamedArguments is thrown when named arguments are given to a table function that doesn't accept them
+	ErrTableFunctionNamedArguments = errors.NewKind("table function '%s' does not accept named arguments")
+
+	// ErrUnknownTableFunctionArgument is thrown when a table function is given an argument name it doesn't declare
+	ErrUnknownTableFunctionArgument = errors.NewKind("table function '%s' has no argument named '%s'")
+
+	// ErrDuplicateTableFunctionArgument is thrown when a table function argument is given more than once
+	ErrDuplicateTableFunctionArgument = errors.NewKind("table function '%s' argument '%s' was given more than once")
+
+	// ErrMissingTableFunctionArgument is thrown when a required table function argument is not given
+	ErrMissingTableFunctionArgument = errors.NewKind("table function '%s' is missing required argument '%s'")
+
+	// ErrPositionalTableFunctionArgument is thrown when a positional table function argument follows a named one
+	ErrPositionalTableFunctionArgument = errors.NewKind("table function '%s' has a positional argument after a named argument")
+
 	// ErrInvalidArgumentNumber is returned when the number of arguments to call a
 	// function is different from the function arity.
 	ErrInvalidArgumentNumber = errors.NewKind("function '%s' expected %v arguments, %v received")
@@ -379,15 +420,25 @@ var (
 	// ErrViewDoesNotExist is returned when a DROP VIEW statement drops a view that does not exist
 	ErrViewDoesNotExist = errors.NewKind("the view %s.%s does not exist")
 
+	// ErrExpectedTableFoundView is returned when a statement that requires a base table is given a view
+	ErrExpectedTableFoundView = errors.NewKind("'%s' is not BASE TABLE")
+
 	// ErrSessionDoesNotSupportPersistence is thrown when a feature is not already supported
 	ErrSessionDoesNotSupportPersistence = errors.NewKind("session does not support persistence")
 
 	// ErrInvalidGISData is thrown when a "ST_<spatial_type>FromText" function receives a malformed string
 	ErrInvalidGISData = errors.NewKind("invalid GIS data provided to function %s")
 
+	// ErrUnexpectedGeometryType is thrown when a "ST_<spatial_type>FromText" function receives a well-formed string of
+	// another geometry type
+	ErrUnexpectedGeometryType = errors.NewKind("unexpected geometry type %s provided to function %s")
+
 	// ErrIllegalGISValue is thrown when a spatial type constructor receives a non-geometric when one should be provided
 	ErrIllegalGISValue = errors.NewKind("illegal non geometric '%v' value found during parsing")
 
+	// ErrDiffSRIDs is thrown when a spatial function receives geometries of different spatial reference systems
+	ErrDiffSRIDs = errors.NewKind("binary geometry function %s given two geometries of different srids: %v and %v, which should have been identical")
+
 	// ErrUnsupportedSyntax is returned when syntax that parses correctly is not supported
 	ErrUnsupportedSyntax = errors.NewKind("unsupported syntax: %s")
 
@@ -453,6 +504,10 @@ var (
 	// ErrInvalidRecursiveCteRecursiveQuery is returned when the recursive CTE recursion clause is not supported.
 	ErrInvalidRecursiveCteRecursiveQuery = errors.NewKind("recursive cte recursive query must be a recursive projection; found: %v")
 
+	// ErrMaxNestingDepthExceeded is returned when subqueries or common table expressions are nested more deeply than the
+	// analyzer allows
+	ErrMaxNestingDepthExceeded = errors.NewKind("subqueries and common table expressions are nested more than the maximum depth of %d")
+
 	// ErrCteRecursionLimitExceeded is returned when a recursive CTE's execution stack depth exceeds the static limit.
 	ErrCteRecursionLimitExceeded = errors.NewKind("WITH RECURSIVE iteration limit exceeded")
 
@@ -0,0 +1,70 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// Collate is the COLLATE clause, which gives the string value of its child the collation given. Functions that are
+// collation aware, such as MIN, MAX and the ordering of GROUP_CONCAT, then use that collation to compare the strings.
+//
+// cc: https://dev.mysql.com/doc/refman/8.0/en/charset-collate.html
+type Collate struct {
+	UnaryExpression
+	Collation sql.Collation
+}
+
+var _ sql.Expression = (*Collate)(nil)
+
+// NewCollate creates a new Collate expression.
+func NewCollate(e sql.Expression, collation sql.Collation) sql.Expression {
+	return &Collate{UnaryExpression{Child: e}, collation}
+}
+
+func (c *Collate) String() string {
+	return fmt.Sprintf("%s COLLATE %s", c.Child, c.Collation)
+}
+
+// Type implements the sql.Expression interface. String types keep their base type and length, every other type becomes
+// a LONGTEXT.
+func (c *Collate) Type() sql.Type {
+	if st, ok := c.Child.Type().(sql.StringType); ok {
+		if t, err := sql.CreateString(st.Type(), st.MaxCharacterLength(), c.Collation); err == nil {
+			return t
+		}
+	}
+	return sql.CreateLongText(c.Collation)
+}
+
+// Eval implements the sql.Expression interface.
+func (c *Collate) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := c.Child.Eval(ctx, row)
+	if err != nil || val == nil {
+		return nil, err
+	}
+
+	return c.Type().Convert(val)
+}
+
+// WithChildren implements the sql.Expression interface.
+func (c *Collate) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(c, len(children), 1)
+	}
+	return NewCollate(children[0], c.Collation), nil
+}
@@ -0,0 +1,40 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"testing"
+
+	"github.com/dolthub/vitess/go/vt/proto/query"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestCollate(t *testing.T) {
+	require := require.New(t)
+
+	varchar := sql.MustCreateString(query.Type_VARCHAR, 20, sql.Collation_Default)
+	e := NewCollate(NewGetField(0, varchar, "foo", true), sql.Collation_utf8mb4_0900_ai_ci)
+	require.Equal("foo COLLATE utf8mb4_0900_ai_ci", e.String())
+	require.Equal(sql.MustCreateString(query.Type_VARCHAR, 20, sql.Collation_utf8mb4_0900_ai_ci), e.Type())
+	require.Equal("hi", eval(t, e, sql.NewRow("hi")))
+	require.Nil(eval(t, e, sql.NewRow(nil)))
+
+	// Other types become text with the collation
+	e = NewCollate(NewLiteral(int64(1), sql.Int64), sql.Collation_utf8mb4_0900_ai_ci)
+	require.Equal(sql.CreateLongText(sql.Collation_utf8mb4_0900_ai_ci), e.Type())
+	require.Equal("1", eval(t, e, sql.Row{nil}))
+}
@@ -0,0 +1,147 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestCollect_Name(t *testing.T) {
+	assert := require.New(t)
+
+	c := NewCollect(expression.NewGetField(0, sql.GeometryType{}, "field", true))
+	assert.Equal("ST_COLLECT(field)", c.String())
+}
+
+func TestCollect(t *testing.T) {
+	line := sql.Linestring{Points: []sql.Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}
+	poly := sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{}, {X: 1}, {X: 1, Y: 1}, {}}}}}
+	testCases := []struct {
+		name     string
+		rows     []sql.Row
+		expected interface{}
+		err      bool
+	}{
+		{
+			"points",
+			[]sql.Row{{sql.Point{X: 1, Y: 2}}, {nil}, {sql.Geometry{Inner: sql.Point{X: 3, Y: 4}}}},
+			sql.MultiPoint{Points: []sql.Point{{X: 1, Y: 2}, {X: 3, Y: 4}}},
+			false,
+		},
+		{
+			"linestrings",
+			[]sql.Row{{line}, {line}},
+			sql.MultiLinestring{Lines: []sql.Linestring{line, line}},
+			false,
+		},
+		{
+			"polygons",
+			[]sql.Row{{poly}},
+			sql.MultiPolygon{Polygons: []sql.Polygon{poly}},
+			false,
+		},
+		{
+			"mixed geometries",
+			[]sql.Row{{sql.Point{X: 1, Y: 2}}, {line}, {poly}},
+			sql.GeometryCollection{Geoms: []interface{}{sql.Point{X: 1, Y: 2}, line, poly}},
+			false,
+		},
+		{
+			"multi geometries",
+			[]sql.Row{{sql.MultiPoint{Points: []sql.Point{{X: 1, Y: 2}}}}},
+			sql.GeometryCollection{Geoms: []interface{}{sql.MultiPoint{Points: []sql.Point{{X: 1, Y: 2}}}}},
+			false,
+		},
+		{
+			"srid is kept",
+			[]sql.Row{{sql.Point{SRID: 4326, X: 1, Y: 2}}},
+			sql.MultiPoint{SRID: 4326, Points: []sql.Point{{SRID: 4326, X: 1, Y: 2}}},
+			false,
+		},
+		{
+			"only nulls",
+			[]sql.Row{{nil}, {nil}},
+			nil,
+			false,
+		},
+		{
+			"different srids",
+			[]sql.Row{{sql.Point{X: 1, Y: 2}}, {sql.Point{SRID: 4326, X: 1, Y: 2}}},
+			nil,
+			true,
+		},
+		{
+			"not a geometry",
+			[]sql.Row{{"POINT(1 2)"}},
+			nil,
+			true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			ctx := sql.NewEmptyContext()
+
+			c := NewCollect(expression.NewGetField(0, sql.GeometryType{}, "field", true))
+			b, err := c.NewBuffer()
+			assert.NoError(err)
+
+			for _, row := range tt.rows {
+				assert.NoError(b.Update(ctx, row))
+			}
+
+			v, err := b.Eval(ctx)
+			if tt.err {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tt.expected, v)
+		})
+	}
+}
+
+func TestCollectAgg(t *testing.T) {
+	buf := []sql.Row{
+		{sql.Point{X: 1, Y: 2}},
+		{nil},
+		{sql.Point{X: 3, Y: 4}},
+		{sql.Point{SRID: 4326, X: 5, Y: 6}},
+	}
+
+	t.Run("frames of a partition", func(t *testing.T) {
+		require := require.New(t)
+		ctx := sql.NewEmptyContext()
+		agg := NewCollectAgg(expression.NewGetField(0, sql.GeometryType{}, "field", true))
+		require.NoError(agg.StartPartition(ctx, sql.WindowInterval{Start: 0, End: 3}, buf))
+		require.Equal(sql.MultiPoint{Points: []sql.Point{{X: 1, Y: 2}}}, agg.Compute(ctx, sql.WindowInterval{Start: 0, End: 2}, buf))
+		require.Equal(sql.MultiPoint{Points: []sql.Point{{X: 3, Y: 4}}}, agg.Compute(ctx, sql.WindowInterval{Start: 1, End: 3}, buf))
+		require.Nil(agg.Compute(ctx, sql.WindowInterval{Start: 1, End: 2}, buf))
+	})
+
+	t.Run("different srids in a partition", func(t *testing.T) {
+		require := require.New(t)
+		ctx := sql.NewEmptyContext()
+		agg := NewCollectAgg(expression.NewGetField(0, sql.GeometryType{}, "field", true))
+		err := agg.StartPartition(ctx, sql.WindowInterval{Start: 2, End: 4}, buf)
+		require.True(sql.ErrDiffSRIDs.Is(err))
+	})
+}
@@ -72,13 +72,19 @@ func (g *GroupConcat) Window() *sql.WindowDefinition {
 // NewBuffer creates a new buffer for the aggregation.
 func (g *GroupConcat) NewBuffer() (sql.AggregationBuffer, error) {
 	var rows []sql.Row
-	distinctSet := make(map[string]bool)
-	return &groupConcatBuffer{g, rows, distinctSet}, nil
+	var distinct *expression.DistinctExpression
+	if g.distinct != "" {
+		distinct = expression.NewDistinctExpression(expression.NewTuple(g.selectExprs...))
+	}
+	return &groupConcatBuffer{g, rows, distinct}, nil
 }
 
 // NewWindowFunctionAggregation implements sql.WindowAdaptableExpression
 func (g *GroupConcat) NewWindowFunction() (sql.WindowFunction, error) {
-	return NewGroupConcatAgg(g), nil
+	if g.window == nil {
+		return NewGroupConcatAgg(g), nil
+	}
+	return NewGroupConcatAgg(g).WithWindow(g.window)
 }
 
 // Eval implements the Expression interface.
@@ -102,7 +108,10 @@ func (g *GroupConcat) Resolved() bool {
 		}
 	}
 
-	return true
+	if g.window == nil {
+		return true
+	}
+	return windowResolved(g.window)
 }
 
 func (g *GroupConcat) String() string {
@@ -167,7 +176,8 @@ func (g *GroupConcat) IsNullable() bool {
 
 // Children implements the Expression interface.
 func (g *GroupConcat) Children() []sql.Expression {
-	return append(g.sf.ToExpressions(), g.selectExprs...)
+	children := append(g.sf.ToExpressions(), g.selectExprs...)
+	return append(children, g.window.ToExpressions()...)
 }
 
 // WithChildren implements the Expression interface.
@@ -176,17 +186,38 @@ func (g *GroupConcat) WithChildren(children ...sql.Expression) (sql.Expression,
 		return nil, sql.ErrInvalidChildrenNumber.New(GroupConcat{}, len(children), 2)
 	}
 
-	// Get the order by expression using the length of the sort fields.
+	// Get the order by expression using the length of the sort fields, and the window expressions after the select
+	// expressions.
 	sortFieldMarker := len(g.sf)
 	orderByExpr := children[:len(g.sf)]
+	selectExprs := children[sortFieldMarker:]
+	if g.window != nil {
+		windowMarker := len(children) - len(g.window.ToExpressions())
+		if windowMarker < sortFieldMarker {
+			return nil, sql.ErrInvalidChildrenNumber.New(g, len(children), len(g.Children()))
+		}
+		selectExprs = children[sortFieldMarker:windowMarker]
+	}
 
-	return NewGroupConcat(g.distinct, g.sf.FromExpressions(orderByExpr...), g.separator, children[sortFieldMarker:], g.maxLen)
+	gc, err := NewGroupConcat(g.distinct, g.sf.FromExpressions(orderByExpr...), g.separator, selectExprs, g.maxLen)
+	if err != nil {
+		return nil, err
+	}
+	if g.window == nil {
+		return gc, nil
+	}
+
+	w, err := g.window.FromExpressions(children[sortFieldMarker+len(selectExprs):])
+	if err != nil {
+		return nil, err
+	}
+	return gc.WithWindow(w)
 }
 
 type groupConcatBuffer struct {
-	gc          *GroupConcat
-	rows        []sql.Row
-	distinctSet map[string]bool
+	gc       *GroupConcat
+	rows     []sql.Row
+	distinct *expression.DistinctExpression
 }
 
 // Update implements the AggregationBuffer interface.
@@ -220,14 +251,15 @@ func (g *groupConcatBuffer) Update(ctx *sql.Context, originalRow sql.Row) error
 
 	vs := v.(string)
 
-	// Get the current array of rows and the map
-	// Check if distinct is active if so look at and update our map
-	if g.gc.distinct != "" {
-		// If this value exists go ahead and return nil
-		if _, ok := g.distinctSet[vs]; ok {
+	// If distinct is active, skip values that have already been seen
+	if g.distinct != nil {
+		dv, err := g.distinct.Eval(ctx, originalRow)
+		if err != nil {
+			return err
+		}
+
+		if dv == nil {
 			return nil
-		} else {
-			g.distinctSet[vs] = true
 		}
 	}
 
@@ -253,6 +285,7 @@ func (g *groupConcatBuffer) Eval(ctx *sql.Context) (interface{}, error) {
 			SortFields: g.gc.sf,
 			Rows:       rows,
 			Ctx:        ctx,
+			Collated:   true,
 		}
 
 		sort.Stable(sorter)
@@ -290,6 +323,9 @@ func (g *groupConcatBuffer) Eval(ctx *sql.Context) (interface{}, error) {
 
 // Dispose implements the Disposable interface.
 func (g *groupConcatBuffer) Dispose() {
+	if g.distinct != nil {
+		g.distinct.Dispose()
+	}
 }
 
 func evalExprs(ctx *sql.Context, exprs []sql.Expression, row sql.Row) (sql.Row, sql.Type, error) {
@@ -107,3 +107,34 @@ func TestGroupConcat_ReturnType(t *testing.T) {
 		require.Equal(t, tt.returnType, gc.Type())
 	}
 }
+
+// Validates that the order by of group_concat compares strings with their collation
+func TestGroupConcat_OrderByCollation(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+
+	testCases := []struct {
+		collation sql.Collation
+		expected  string
+	}{
+		{sql.Collation_utf8mb4_0900_bin, "B,C,a"},
+		{sql.Collation_utf8mb4_0900_ai_ci, "a,B,C"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.collation.String(), func(t *testing.T) {
+			field := expression.NewGetField(0, sql.MustCreateString(query.Type_VARCHAR, 10, tt.collation), "field", true)
+			sf := sql.SortFields{{Column: field, Order: sql.Ascending}}
+			gc, err := NewGroupConcat("", sf, ",", []sql.Expression{field}, 1024)
+			require.NoError(t, err)
+
+			buf, _ := gc.NewBuffer()
+			for _, v := range []string{"C", "a", "B"} {
+				require.NoError(t, buf.Update(ctx, sql.Row{v}))
+			}
+
+			result, err := buf.Eval(ctx)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dolthub/vitess/go/vt/proto/query"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -62,6 +63,23 @@ func TestMax_Eval_Text(t *testing.T) {
 	assert.Equal("b", v)
 }
 
+func TestMax_Eval_Text_CaseInsensitive(t *testing.T) {
+	assert := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	typ := sql.MustCreateString(query.Type_VARCHAR, 10, sql.Collation_utf8mb4_0900_ai_ci)
+	m := NewMax(expression.NewGetField(0, typ, "field", true))
+	b, _ := m.NewBuffer()
+
+	b.Update(ctx, sql.NewRow("a"))
+	b.Update(ctx, sql.NewRow("A"))
+	b.Update(ctx, sql.NewRow("b"))
+
+	v, err := b.Eval(ctx)
+	assert.NoError(err)
+	assert.Equal("b", v)
+}
+
 func TestMax_Eval_Timestamp(t *testing.T) {
 	assert := require.New(t)
 	ctx := sql.NewEmptyContext()
@@ -307,7 +307,7 @@ func (m *maxBuffer) Update(ctx *sql.Context, row sql.Row) error {
 		return nil
 	}
 
-	cmp, err := m.expr.Type().Compare(v, m.val)
+	cmp, err := sql.CollatedCompare(m.expr.Type(), v, m.val)
 	if err != nil {
 		return err
 	}
@@ -353,7 +353,7 @@ func (m *minBuffer) Update(ctx *sql.Context, row sql.Row) error {
 		return nil
 	}
 
-	cmp, err := m.expr.Type().Compare(v, m.val)
+	cmp, err := sql.CollatedCompare(m.expr.Type(), v, m.val)
 	if err != nil {
 		return err
 	}
@@ -412,3 +412,117 @@ func (j *jsonArrayBuffer) Eval(ctx *sql.Context) (interface{}, error) {
 // Dispose implements the Disposable interface.
 func (j *jsonArrayBuffer) Dispose() {
 }
+
+type collectBuffer struct {
+	vals []interface{}
+	expr sql.Expression
+}
+
+func NewCollectBuffer(child sql.Expression) *collectBuffer {
+	return &collectBuffer{nil, child}
+}
+
+// Update implements the AggregationBuffer interface.
+func (c *collectBuffer) Update(ctx *sql.Context, row sql.Row) error {
+	v, err := c.expr.Eval(ctx, row)
+	if err != nil {
+		return err
+	}
+
+	// NULL values are skipped
+	if v == nil {
+		return nil
+	}
+
+	c.vals = append(c.vals, v)
+	return nil
+}
+
+// Eval implements the AggregationBuffer interface.
+func (c *collectBuffer) Eval(ctx *sql.Context) (interface{}, error) {
+	return collectGeometries(c.vals)
+}
+
+// Dispose implements the Disposable interface.
+func (c *collectBuffer) Dispose() {
+	expression.Dispose(c.expr)
+}
+
+// collectGeometries combines geometries into a single multi geometry. Points, linestrings and polygons are collected
+// into a multipoint, multilinestring and multipolygon respectively, and any other mix of geometries into a geometry
+// collection. All geometries must share the same SRID. No geometries result in NULL.
+func collectGeometries(vals []interface{}) (interface{}, error) {
+	if len(vals) == 0 {
+		return nil, nil
+	}
+
+	geoms := make([]interface{}, len(vals))
+	var srid uint32
+	for i, v := range vals {
+		if g, ok := v.(sql.Geometry); ok {
+			v = g.Inner
+		}
+
+		var s uint32
+		switch v := v.(type) {
+		case sql.Point:
+			s = v.SRID
+		case sql.Linestring:
+			s = v.SRID
+		case sql.Polygon:
+			s = v.SRID
+		case sql.MultiPoint:
+			s = v.SRID
+		case sql.MultiLinestring:
+			s = v.SRID
+		case sql.MultiPolygon:
+			s = v.SRID
+		case sql.GeometryCollection:
+			s = v.SRID
+		default:
+			return nil, sql.ErrInvalidGISData.New("ST_COLLECT")
+		}
+
+		if i == 0 {
+			srid = s
+		} else if s != srid {
+			return nil, sql.ErrDiffSRIDs.New("ST_COLLECT", srid, s)
+		}
+		geoms[i] = v
+	}
+
+	switch geoms[0].(type) {
+	case sql.Point:
+		points := make([]sql.Point, len(geoms))
+		for i, g := range geoms {
+			p, ok := g.(sql.Point)
+			if !ok {
+				return sql.GeometryCollection{SRID: srid, Geoms: geoms}, nil
+			}
+			points[i] = p
+		}
+		return sql.MultiPoint{SRID: srid, Points: points}, nil
+	case sql.Linestring:
+		lines := make([]sql.Linestring, len(geoms))
+		for i, g := range geoms {
+			l, ok := g.(sql.Linestring)
+			if !ok {
+				return sql.GeometryCollection{SRID: srid, Geoms: geoms}, nil
+			}
+			lines[i] = l
+		}
+		return sql.MultiLinestring{SRID: srid, Lines: lines}, nil
+	case sql.Polygon:
+		polys := make([]sql.Polygon, len(geoms))
+		for i, g := range geoms {
+			p, ok := g.(sql.Polygon)
+			if !ok {
+				return sql.GeometryCollection{SRID: srid, Geoms: geoms}, nil
+			}
+			polys[i] = p
+		}
+		return sql.MultiPolygon{SRID: srid, Polygons: polys}, nil
+	default:
+		return sql.GeometryCollection{SRID: srid, Geoms: geoms}, nil
+	}
+}
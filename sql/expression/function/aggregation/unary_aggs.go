@@ -11,6 +11,13 @@ var UnaryAggDefs support.GenDefs = []support.AggDef{ // alphabetically sorted
 		RetType:  "sql.Float64",
 		Nullable: true,
 	},
+	{
+		Name:     "Collect",
+		SqlName:  "st_collect",
+		Desc:     "returns the geometries of all rows aggregated into a multi geometry or a geometry collection.",
+		RetType:  "sql.GeometryType{}",
+		Nullable: true,
+	},
 	{
 		Name:    "Count",
 		Desc:    "returns a count of the number of non-NULL values of expr in the rows retrieved by a SELECT statement.",
@@ -65,6 +65,62 @@ func (a *Avg) NewWindowFunction() (sql.WindowFunction, error) {
 	return NewAvgAgg(child).WithWindow(a.Window())
 }
 
+type Collect struct {
+	unaryAggBase
+}
+
+var _ sql.FunctionExpression = (*Collect)(nil)
+var _ sql.Aggregation = (*Collect)(nil)
+var _ sql.WindowAdaptableExpression = (*Collect)(nil)
+
+func NewCollect(e sql.Expression) *Collect {
+	return &Collect{
+		unaryAggBase{
+			UnaryExpression: expression.UnaryExpression{Child: e},
+			functionName:    "Collect",
+			description:     "returns the geometries of all rows aggregated into a multi geometry or a geometry collection.",
+		},
+	}
+}
+
+func (a *Collect) Type() sql.Type {
+	return sql.GeometryType{}
+}
+
+func (a *Collect) IsNullable() bool {
+	return true
+}
+
+func (a *Collect) String() string {
+	return fmt.Sprintf("ST_COLLECT(%s)", a.Child)
+}
+
+func (a *Collect) WithWindow(window *sql.WindowDefinition) (sql.Aggregation, error) {
+	res, err := a.unaryAggBase.WithWindow(window)
+	return &Collect{unaryAggBase: *res.(*unaryAggBase)}, err
+}
+
+func (a *Collect) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	res, err := a.unaryAggBase.WithChildren(children...)
+	return &Collect{unaryAggBase: *res.(*unaryAggBase)}, err
+}
+
+func (a *Collect) NewBuffer() (sql.AggregationBuffer, error) {
+	child, err := expression.Clone(a.UnaryExpression.Child)
+	if err != nil {
+		return nil, err
+	}
+	return NewCollectBuffer(child), nil
+}
+
+func (a *Collect) NewWindowFunction() (sql.WindowFunction, error) {
+	child, err := expression.Clone(a.UnaryExpression.Child)
+	if err != nil {
+		return nil, err
+	}
+	return NewCollectAgg(child).WithWindow(a.Window())
+}
+
 type Count struct {
 	unaryAggBase
 }
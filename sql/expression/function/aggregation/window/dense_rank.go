@@ -0,0 +1,114 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package window
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
+)
+
+type DenseRank struct {
+	window *sql.WindowDefinition
+}
+
+var _ sql.FunctionExpression = (*DenseRank)(nil)
+var _ sql.WindowAggregation = (*DenseRank)(nil)
+var _ sql.WindowAdaptableExpression = (*DenseRank)(nil)
+
+func NewDenseRank() sql.Expression {
+	return &DenseRank{}
+}
+
+// Description implements sql.FunctionExpression
+func (d *DenseRank) Description() string {
+	return "returns the rank of the current row within its partition, without gaps."
+}
+
+// Window implements sql.WindowExpression
+func (d *DenseRank) Window() *sql.WindowDefinition {
+	return d.window
+}
+
+func (d *DenseRank) Resolved() bool {
+	return windowResolved(d.window)
+}
+
+func (d *DenseRank) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("dense_rank()")
+	if d.window != nil {
+		sb.WriteString(" ")
+		sb.WriteString(d.window.String())
+	}
+	return sb.String()
+}
+
+func (d *DenseRank) DebugString() string {
+	sb := strings.Builder{}
+	sb.WriteString("dense_rank()")
+	if d.window != nil {
+		sb.WriteString(" ")
+		sb.WriteString(sql.DebugString(d.window))
+	}
+	return sb.String()
+}
+
+// FunctionName implements sql.FunctionExpression
+func (d *DenseRank) FunctionName() string {
+	return "DENSE_RANK"
+}
+
+// Type implements sql.Expression
+func (d *DenseRank) Type() sql.Type {
+	return sql.Uint64
+}
+
+// IsNullable implements sql.Expression
+func (d *DenseRank) IsNullable() bool {
+	return false
+}
+
+// Eval implements sql.Expression
+func (d *DenseRank) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	panic("eval called on window function")
+}
+
+// Children implements sql.Expression
+func (d *DenseRank) Children() []sql.Expression {
+	return d.window.ToExpressions()
+}
+
+// WithChildren implements sql.Expression
+func (d *DenseRank) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	window, err := d.window.FromExpressions(children)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.WithWindow(window)
+}
+
+// WithWindow implements sql.WindowAggregation
+func (d *DenseRank) WithWindow(window *sql.WindowDefinition) (sql.WindowAggregation, error) {
+	nr := *d
+	nr.window = window
+	return &nr, nil
+}
+
+func (d *DenseRank) NewWindowFunction() (sql.WindowFunction, error) {
+	return aggregation.NewDenseRank(d.window.OrderBy.ToExpressions()), nil
+}
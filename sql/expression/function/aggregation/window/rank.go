@@ -0,0 +1,114 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package window
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
+)
+
+type Rank struct {
+	window *sql.WindowDefinition
+}
+
+var _ sql.FunctionExpression = (*Rank)(nil)
+var _ sql.WindowAggregation = (*Rank)(nil)
+var _ sql.WindowAdaptableExpression = (*Rank)(nil)
+
+func NewRank() sql.Expression {
+	return &Rank{}
+}
+
+// Description implements sql.FunctionExpression
+func (r *Rank) Description() string {
+	return "returns the rank of the current row within its partition, with gaps."
+}
+
+// Window implements sql.WindowExpression
+func (r *Rank) Window() *sql.WindowDefinition {
+	return r.window
+}
+
+func (r *Rank) Resolved() bool {
+	return windowResolved(r.window)
+}
+
+func (r *Rank) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("rank()")
+	if r.window != nil {
+		sb.WriteString(" ")
+		sb.WriteString(r.window.String())
+	}
+	return sb.String()
+}
+
+func (r *Rank) DebugString() string {
+	sb := strings.Builder{}
+	sb.WriteString("rank()")
+	if r.window != nil {
+		sb.WriteString(" ")
+		sb.WriteString(sql.DebugString(r.window))
+	}
+	return sb.String()
+}
+
+// FunctionName implements sql.FunctionExpression
+func (r *Rank) FunctionName() string {
+	return "RANK"
+}
+
+// Type implements sql.Expression
+func (r *Rank) Type() sql.Type {
+	return sql.Uint64
+}
+
+// IsNullable implements sql.Expression
+func (r *Rank) IsNullable() bool {
+	return false
+}
+
+// Eval implements sql.Expression
+func (r *Rank) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	panic("eval called on window function")
+}
+
+// Children implements sql.Expression
+func (r *Rank) Children() []sql.Expression {
+	return r.window.ToExpressions()
+}
+
+// WithChildren implements sql.Expression
+func (r *Rank) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	window, err := r.window.FromExpressions(children)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.WithWindow(window)
+}
+
+// WithWindow implements sql.WindowAggregation
+func (r *Rank) WithWindow(window *sql.WindowDefinition) (sql.WindowAggregation, error) {
+	nr := *r
+	nr.window = window
+	return &nr, nil
+}
+
+func (r *Rank) NewWindowFunction() (sql.WindowFunction, error) {
+	return aggregation.NewRank(r.window.OrderBy.ToExpressions()), nil
+}
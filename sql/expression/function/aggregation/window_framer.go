@@ -16,6 +16,7 @@ package aggregation
 
 import (
 	"errors"
+	"fmt"
 	"io"
 
 	ast "github.com/dolthub/vitess/go/vt/sqlparser"
@@ -53,6 +54,14 @@ func NewUnboundedPrecedingToCurrentRowFramer() *RowsUnboundedPrecedingToCurrentR
 	}
 }
 
+// PartitionFramer generates one sql.WindowInterval spanning the whole partition for every row
+// in the partition. It's the default framer of a window aggregate without an explicit frame
+// or ORDER BY, which returns the aggregate of the partition on every row.
+//
+// Ex: partition = [0, 1, 2]
+// =>
+// frames: {0,3},   {0,3},   {0,3}
+// rows:   [0,1,2], [0,1,2], [0,1,2]
 type PartitionFramer struct {
 	idx                          int
 	partitionStart, partitionEnd int
@@ -60,6 +69,9 @@ type PartitionFramer struct {
 	followOffset, precOffset int
 	frameStart, frameEnd     int
 	partitionSet             bool
+
+	// the frame returned by the previous call to Next
+	lastFrame sql.WindowInterval
 }
 
 func NewPartitionFramer() *PartitionFramer {
@@ -80,6 +92,7 @@ func (f *PartitionFramer) NewFramer(interval sql.WindowInterval) (sql.WindowFram
 		partitionStart: interval.Start,
 		partitionEnd:   interval.End,
 		partitionSet:   true,
+		lastFrame:      sql.WindowInterval{Start: interval.Start, End: interval.Start},
 	}, nil
 }
 
@@ -88,6 +101,9 @@ func (f *PartitionFramer) Next(ctx *sql.Context, buffer sql.WindowBuffer) (sql.W
 		return sql.WindowInterval{}, io.EOF
 	}
 	if f.idx == 0 || (0 < f.idx && f.idx < f.partitionEnd) {
+		if f.idx > f.partitionStart {
+			f.lastFrame = sql.WindowInterval{Start: f.frameStart, End: f.frameEnd}
+		}
 		f.idx++
 		return f.Interval()
 	}
@@ -109,14 +125,18 @@ func (f *PartitionFramer) Interval() (sql.WindowInterval, error) {
 	return sql.WindowInterval{Start: f.frameStart, End: f.frameEnd}, nil
 }
 
-func (f *PartitionFramer) SlidingInterval(ctx sql.Context) (sql.WindowInterval, sql.WindowInterval, sql.WindowInterval) {
-	panic("implement me")
+func (f *PartitionFramer) SlidingInterval(ctx *sql.Context) (sql.WindowInterval, sql.WindowInterval, sql.WindowInterval) {
+	return slidingInterval(f.lastFrame, sql.WindowInterval{Start: f.frameStart, End: f.frameEnd})
 }
 
 func (f *PartitionFramer) Close() {
 	panic("implement me")
 }
 
+func (f *PartitionFramer) String() string {
+	return "PartitionFramer(ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING)"
+}
+
 func NewGroupByFramer() *GroupByFramer {
 	return &GroupByFramer{
 		frameEnd:       -1,
@@ -126,6 +146,15 @@ func NewGroupByFramer() *GroupByFramer {
 	}
 }
 
+// GroupByFramer generates a single sql.WindowInterval spanning the whole partition, so that a
+// partition produces one output row. It frames a plain GROUP BY aggregate, where each group is
+// a partition. A window aggregate without an ORDER BY, like SUM(x) OVER (PARTITION BY k), also
+// aggregates the whole partition but returns it on every row, and uses a PartitionFramer instead.
+//
+// Ex: partition = [0, 1, 2]
+// =>
+// frames: {0,3}
+// rows:   [0,1,2]
 type GroupByFramer struct {
 	evaluated                    bool
 	partitionStart, partitionEnd int
@@ -171,8 +200,44 @@ func (f *GroupByFramer) Interval() (sql.WindowInterval, error) {
 	return sql.WindowInterval{Start: f.frameStart, End: f.frameEnd}, nil
 }
 
-func (f *GroupByFramer) SlidingInterval(ctx sql.Context) (sql.WindowInterval, sql.WindowInterval, sql.WindowInterval) {
-	panic("implement me")
+// SlidingInterval implements sql.WindowFramer. A GroupByFramer returns a single frame, which is added whole.
+func (f *GroupByFramer) SlidingInterval(ctx *sql.Context) (sql.WindowInterval, sql.WindowInterval, sql.WindowInterval) {
+	return slidingInterval(sql.WindowInterval{}, sql.WindowInterval{Start: f.frameStart, End: f.frameEnd})
+}
+
+func (f *GroupByFramer) String() string {
+	return "GroupByFramer"
+}
+
+// frameString describes a frame with the given bound type and start and end extents, as it would
+// be written in an OVER clause.
+func frameString(boundType, startExtent, endExtent string) string {
+	return fmt.Sprintf("%s BETWEEN %s AND %s", boundType, startExtent, endExtent)
+}
+
+// slidingInterval returns the [current] frame, and the ranges dropped from and added to the [last]
+// frame. Frame bounds only move forward within a partition, so each range is contiguous.
+func slidingInterval(last, current sql.WindowInterval) (sql.WindowInterval, sql.WindowInterval, sql.WindowInterval) {
+	if last.End <= last.Start {
+		return current, sql.WindowInterval{Start: current.Start, End: current.Start}, current
+	}
+
+	dropped := sql.WindowInterval{Start: last.Start, End: last.End}
+	if current.Start < dropped.End {
+		dropped.End = current.Start
+	}
+	if dropped.End < dropped.Start {
+		dropped.End = dropped.Start
+	}
+
+	added := sql.WindowInterval{Start: last.End, End: current.End}
+	if current.Start > added.Start {
+		added.Start = current.Start
+	}
+	if added.End < added.Start {
+		added.End = added.Start
+	}
+	return current, dropped, added
 }
 
 // rowFramerBase is a sql.WindowFramer iterator that tracks
@@ -194,6 +259,9 @@ type rowFramerBase struct {
 	frameEnd       int
 	partitionSet   bool
 
+	// the frame returned by the previous call to Next
+	lastFrame sql.WindowInterval
+
 	// add [startOffset] to current [idx] to find start index
 	// is set unless [unboundedPreceding] is true
 	startOffset int
@@ -276,6 +344,7 @@ func (f *rowFramerBase) Next(ctx *sql.Context, buffer sql.WindowBuffer) (sql.Win
 		newStart = newEnd
 	}
 
+	f.lastFrame = sql.WindowInterval{Start: f.frameStart, End: f.frameEnd}
 	f.frameStart = newStart
 	f.frameEnd = newEnd
 
@@ -298,6 +367,39 @@ func (f *rowFramerBase) Interval() (sql.WindowInterval, error) {
 	return sql.WindowInterval{Start: f.frameStart, End: f.frameEnd}, nil
 }
 
+func (f *rowFramerBase) SlidingInterval(ctx *sql.Context) (sql.WindowInterval, sql.WindowInterval, sql.WindowInterval) {
+	return slidingInterval(f.lastFrame, sql.WindowInterval{Start: f.frameStart, End: f.frameEnd})
+}
+
+// String returns the frame of this framer, like RowFramer(ROWS BETWEEN 1 PRECEDING AND CURRENT ROW).
+func (f *rowFramerBase) String() string {
+	var startExtent string
+	switch {
+	case f.unboundedPreceding:
+		startExtent = "UNBOUNDED PRECEDING"
+	case f.startNPreceding != 0:
+		startExtent = fmt.Sprintf("%d PRECEDING", f.startNPreceding)
+	case f.startNFollowing != 0:
+		startExtent = fmt.Sprintf("%d FOLLOWING", f.startNFollowing)
+	default:
+		startExtent = "CURRENT ROW"
+	}
+
+	var endExtent string
+	switch {
+	case f.unboundedFollowing:
+		endExtent = "UNBOUNDED FOLLOWING"
+	case f.endNPreceding != 0:
+		endExtent = fmt.Sprintf("%d PRECEDING", f.endNPreceding)
+	case f.endNFollowing != 0:
+		endExtent = fmt.Sprintf("%d FOLLOWING", f.endNFollowing)
+	default:
+		endExtent = "CURRENT ROW"
+	}
+
+	return fmt.Sprintf("RowFramer(%s)", frameString("ROWS", startExtent, endExtent))
+}
+
 // rangeFramerBase is a sql.WindowFramer iterator that tracks
 // value ranges in a sql.WindowBuffer using bound
 // conditions on the order by [orderBy] column. Only a subset of
@@ -317,6 +419,9 @@ type rangeFramerBase struct {
 	frameStart, frameEnd         int
 	partitionSet                 bool
 
+	// the frame returned by the previous call to Next
+	lastFrame sql.WindowInterval
+
 	// reference expression for boundary calculation
 	orderBy sql.Expression
 
@@ -426,11 +531,41 @@ func (f *rangeFramerBase) Next(ctx *sql.Context, buf sql.WindowBuffer) (sql.Wind
 	}
 
 	f.idx++
+	f.lastFrame = sql.WindowInterval{Start: f.frameStart, End: f.frameEnd}
 	f.frameStart = newStart
 	f.frameEnd = newEnd
 	return f.Interval()
 }
 
+// String returns the frame of this framer, like RangeFramer(RANGE BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW).
+func (f *rangeFramerBase) String() string {
+	var startExtent string
+	switch {
+	case f.unboundedPreceding:
+		startExtent = "UNBOUNDED PRECEDING"
+	case f.startNPreceding != nil:
+		startExtent = fmt.Sprintf("%s PRECEDING", f.startNPreceding)
+	case f.startNFollowing != nil:
+		startExtent = fmt.Sprintf("%s FOLLOWING", f.startNFollowing)
+	default:
+		startExtent = "CURRENT ROW"
+	}
+
+	var endExtent string
+	switch {
+	case f.unboundedFollowing:
+		endExtent = "UNBOUNDED FOLLOWING"
+	case f.endNPreceding != nil:
+		endExtent = fmt.Sprintf("%s PRECEDING", f.endNPreceding)
+	case f.endNFollowing != nil:
+		endExtent = fmt.Sprintf("%s FOLLOWING", f.endNFollowing)
+	default:
+		endExtent = "CURRENT ROW"
+	}
+
+	return fmt.Sprintf("RangeFramer(%s)", frameString("RANGE", startExtent, endExtent))
+}
+
 type stopCond int
 
 const (
@@ -485,12 +620,19 @@ func (f *rangeFramerBase) Interval() (sql.WindowInterval, error) {
 	return sql.WindowInterval{Start: f.frameStart, End: f.frameEnd}, nil
 }
 
+func (f *rangeFramerBase) SlidingInterval(ctx *sql.Context) (sql.WindowInterval, sql.WindowInterval, sql.WindowInterval) {
+	return slidingInterval(f.lastFrame, sql.WindowInterval{Start: f.frameStart, End: f.frameEnd})
+}
+
 type PeerGroupFramer struct {
 	idx                          int
 	partitionStart, partitionEnd int
 	frameStart, frameEnd         int
 	partitionSet                 bool
 
+	// the frame returned by the previous call to Next
+	lastFrame sql.WindowInterval
+
 	// reference for peer calculation
 	orderBy []sql.Expression
 }
@@ -521,6 +663,7 @@ func (f *PeerGroupFramer) Next(ctx *sql.Context, buf sql.WindowBuffer) (sql.Wind
 	if f.idx != 0 && f.idx >= f.partitionEnd || !f.partitionSet {
 		return sql.WindowInterval{}, io.EOF
 	}
+	f.lastFrame = sql.WindowInterval{Start: f.frameStart, End: f.frameEnd}
 	if f.idx >= f.frameEnd {
 		peerGroup, err := nextPeerGroup(ctx, f.idx, f.partitionEnd, f.orderBy, buf)
 		if err != nil {
@@ -548,6 +691,14 @@ func (f *PeerGroupFramer) Interval() (sql.WindowInterval, error) {
 	return sql.WindowInterval{Start: f.frameStart, End: f.frameEnd}, nil
 }
 
+func (f *PeerGroupFramer) SlidingInterval(ctx *sql.Context) (sql.WindowInterval, sql.WindowInterval, sql.WindowInterval) {
+	return slidingInterval(f.lastFrame, sql.WindowInterval{Start: f.frameStart, End: f.frameEnd})
+}
+
+func (f *PeerGroupFramer) String() string {
+	return fmt.Sprintf("PeerGroupFramer(%s)", frameString("RANGE", "CURRENT ROW", "CURRENT ROW"))
+}
+
 // nextPeerGroup scans for a sql.WindowInterval of rows with the same value as
 // the current row [a.pos]. This is equivalent to a partitioning algorithm, but
 // we are using the OrderBy fields, and we stream the results.
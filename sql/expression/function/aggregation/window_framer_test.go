@@ -16,6 +16,7 @@ package aggregation
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"testing"
 
@@ -169,11 +170,14 @@ func TestWindowRowFramers(t *testing.T) {
 				framer, err = framer.NewFramer(p)
 				require.NoError(t, err)
 
+				var last sql.WindowInterval
 				for {
 					frame, err = framer.Next(ctx, nil)
 					if errors.Is(err, io.EOF) {
 						break
 					}
+					requireSlidingInterval(t, ctx, framer, last, frame)
+					last = frame
 					res = append(res, frame)
 				}
 			}
@@ -291,11 +295,14 @@ func TestWindowRangeFramers(t *testing.T) {
 			for _, p := range partitions {
 				framer, err = framer.NewFramer(p)
 				require.NoError(t, err)
+				var last sql.WindowInterval
 				for {
 					frame, err = framer.Next(ctx, buffer)
 					if errors.Is(err, io.EOF) {
 						break
 					}
+					requireSlidingInterval(t, ctx, framer, last, frame)
+					last = frame
 					res = append(res, frame)
 				}
 			}
@@ -304,6 +311,78 @@ func TestWindowRangeFramers(t *testing.T) {
 	}
 }
 
+func TestWindowFramerString(t *testing.T) {
+	w := &sql.WindowDefinition{OrderBy: sql.SortFields{{Column: expression.NewGetField(1, sql.Int64, "", false), Order: 1}}}
+	tests := []struct {
+		Name     string
+		Framer   func(sql.WindowFrame, *sql.WindowDefinition) (sql.WindowFramer, error)
+		Expected string
+	}{
+		{
+			Name:     "rows n preceding to current row framer",
+			Framer:   NewRowsNPrecedingToCurrentRowFramer,
+			Expected: "RowFramer(ROWS BETWEEN 2 PRECEDING AND CURRENT ROW)",
+		},
+		{
+			Name:     "rows unbounded preceding to n following framer",
+			Framer:   NewRowsUnboundedPrecedingToNFollowingFramer,
+			Expected: "RowFramer(ROWS BETWEEN UNBOUNDED PRECEDING AND 1 FOLLOWING)",
+		},
+		{
+			Name:     "range n preceding to n following framer",
+			Framer:   NewRangeNPrecedingToNFollowingFramer,
+			Expected: "RangeFramer(RANGE BETWEEN 2 PRECEDING AND 1 FOLLOWING)",
+		},
+		{
+			Name:     "range unbounded preceding to current row framer",
+			Framer:   NewRangeUnboundedPrecedingToCurrentRowFramer,
+			Expected: "RangeFramer(RANGE BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			framer, err := tt.Framer(dummyFrame{}, w)
+			require.NoError(t, err)
+			require.Equal(t, tt.Expected, fmt.Sprint(framer))
+
+			// partitioned framers describe the same frame
+			framer, err = framer.NewFramer(sql.WindowInterval{Start: 0, End: 2})
+			require.NoError(t, err)
+			require.Equal(t, tt.Expected, fmt.Sprint(framer))
+		})
+	}
+
+	require.Equal(t, "PartitionFramer(ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING)", fmt.Sprint(NewPartitionFramer()))
+	require.Equal(t, "GroupByFramer", fmt.Sprint(NewGroupByFramer()))
+	require.Equal(t, "PeerGroupFramer(RANGE BETWEEN CURRENT ROW AND CURRENT ROW)", fmt.Sprint(NewPeerGroupFramer(nil)))
+}
+
+// requireSlidingInterval checks that removing the dropped range from the [last] frame and
+// appending the added range gives the current [frame].
+func requireSlidingInterval(t *testing.T, ctx *sql.Context, framer sql.WindowFramer, last, frame sql.WindowInterval) {
+	current, dropped, added := framer.SlidingInterval(ctx)
+	require.Equal(t, frame, current)
+
+	rows := make(map[int]bool)
+	for i := last.Start; i < last.End; i++ {
+		rows[i] = true
+	}
+	for i := dropped.Start; i < dropped.End; i++ {
+		require.True(t, rows[i], "dropped row %d is not in the last frame %v", i, last)
+		delete(rows, i)
+	}
+	for i := added.Start; i < added.End; i++ {
+		require.False(t, rows[i], "added row %d is already in the frame", i)
+		rows[i] = true
+	}
+
+	require.Len(t, rows, current.End-current.Start)
+	for i := current.Start; i < current.End; i++ {
+		require.True(t, rows[i], "row %d is missing from the frame %v", i, current)
+	}
+}
+
 type dummyFrame struct{}
 
 var _ sql.WindowFrame = (*dummyFrame)(nil)
@@ -38,6 +38,10 @@ var _ sql.WindowFunction = (*RowNumber)(nil)
 var _ sql.WindowFunction = (*Lag)(nil)
 var _ sql.WindowFunction = (*Lead)(nil)
 
+var _ sql.SlidingWindowFunction = (*SumAgg)(nil)
+var _ sql.SlidingWindowFunction = (*AvgAgg)(nil)
+var _ sql.SlidingWindowFunction = (*CountAgg)(nil)
+
 type SumAgg struct {
 	partitionStart, partitionEnd int
 	expr                         sql.Expression
@@ -45,6 +49,12 @@ type SumAgg struct {
 
 	// use prefix sums to quickly calculate arbitrary frame sum within partition
 	prefixSum []float64
+	// orderBy is the ORDER BY of the window, which determines the default frame
+	orderBy []sql.Expression
+
+	// running sum and row count of the current frame for sliding aggregation
+	slidingSum  float64
+	slidingRows int
 }
 
 func NewSumAgg(e sql.Expression) *SumAgg {
@@ -63,6 +73,10 @@ func (a *SumAgg) WithWindow(w *sql.WindowDefinition) (sql.WindowFunction, error)
 			return nil, err
 		}
 		na.framer = framer
+		return &na, nil
+	}
+	if w.OrderBy != nil {
+		na.orderBy = w.OrderBy.ToExpressions()
 	}
 	return &na, nil
 }
@@ -71,12 +85,24 @@ func (a *SumAgg) Dispose() {
 	expression.Dispose(a.expr)
 }
 
-// DefaultFramer returns a NewUnboundedPrecedingToCurrentRowFramer
+// DefaultFramer returns a NewPartitionFramer, which sums the whole partition, or a framer from the
+// start of the partition to the current row's peers when the window has an ORDER BY.
 func (a *SumAgg) DefaultFramer() sql.WindowFramer {
 	if a.framer != nil {
 		return a.framer
 	}
-	return NewUnboundedPrecedingToCurrentRowFramer()
+
+	if a.orderBy == nil || len(a.orderBy) < 1 {
+		return NewPartitionFramer()
+	}
+
+	return &RangeUnboundedPrecedingToCurrentRowFramer{
+		rangeFramerBase{
+			orderBy:            a.orderBy[0],
+			unboundedPreceding: true,
+			endCurrentRow:      true,
+		},
+	}
 }
 
 func (a *SumAgg) StartPartition(ctx *sql.Context, interval sql.WindowInterval, buf sql.WindowBuffer) error {
@@ -87,17 +113,80 @@ func (a *SumAgg) StartPartition(ctx *sql.Context, interval sql.WindowInterval, b
 	return err
 }
 
-func (a *SumAgg) NewSlidingFrameInterval(added, dropped sql.WindowInterval) {
-	panic("sliding window interface not implemented yet")
-}
-
 func (a *SumAgg) Compute(ctx *sql.Context, interval sql.WindowInterval, buf sql.WindowBuffer) interface{} {
 	if interval.End-interval.Start < 1 {
 		return nil
 	}
+	if resetDistinct(a.expr) {
+		sum, nonNullCnt, err := floatSum(ctx, interval, buf, a.expr)
+		if err != nil || nonNullCnt < 1 {
+			return nil
+		}
+		return sum
+	}
 	return computePrefixSum(interval, a.partitionStart, a.prefixSum)
 }
 
+func (a *SumAgg) StartSlidingPartition(ctx *sql.Context, interval sql.WindowInterval, buf sql.WindowBuffer) error {
+	a.partitionStart, a.partitionEnd = interval.Start, interval.End
+	a.Dispose()
+	a.prefixSum = nil
+	a.slidingSum, a.slidingRows = 0, 0
+	return nil
+}
+
+func (a *SumAgg) NewSlidingFrameInterval(ctx *sql.Context, added, dropped sql.WindowInterval, buf sql.WindowBuffer) error {
+	addedSum, _, err := floatSum(ctx, added, buf, a.expr)
+	if err != nil {
+		return err
+	}
+	droppedSum, _, err := floatSum(ctx, dropped, buf, a.expr)
+	if err != nil {
+		return err
+	}
+	a.slidingSum += addedSum - droppedSum
+	a.slidingRows += (added.End - added.Start) - (dropped.End - dropped.Start)
+	return nil
+}
+
+func (a *SumAgg) ComputeSliding(ctx *sql.Context) interface{} {
+	if a.slidingRows < 1 {
+		return nil
+	}
+	return a.slidingSum
+}
+
+// resetDistinct clears the values seen by [e] if it is an argument over DISTINCT values, so that it can be
+// evaluated over a new frame, and returns whether it is one. Such an argument can't be aggregated with prefix sums
+// or incrementally, since a value only counts once however many rows of the frame have it.
+func resetDistinct(e sql.Expression) bool {
+	de, ok := e.(*expression.DistinctExpression)
+	if ok {
+		de.Dispose()
+	}
+	return ok
+}
+
+// floatSum returns the sum of [e] over the rows in [interval], and the number of those rows
+// with non-null values.
+func floatSum(ctx *sql.Context, interval sql.WindowInterval, buf sql.WindowBuffer, e sql.Expression) (float64, int, error) {
+	var sum float64
+	var nonNullCnt int
+	for i := interval.Start; i < interval.End; i++ {
+		v, err := e.Eval(ctx, buf[i])
+		if err != nil {
+			return 0, 0, err
+		}
+		val, err := sql.Float64.Convert(v)
+		if err != nil || val == nil {
+			continue
+		}
+		sum += val.(float64)
+		nonNullCnt++
+	}
+	return sum, nonNullCnt, nil
+}
+
 func floatPrefixSum(ctx *sql.Context, interval sql.WindowInterval, buf sql.WindowBuffer, e sql.Expression) ([]float64, []int, error) {
 	intervalLen := interval.End - interval.Start
 	sums := make([]float64, intervalLen)
@@ -145,6 +234,10 @@ type AvgAgg struct {
 	prefixSum []float64
 	// exclude nulls in average denominator
 	nullCnt []int
+
+	// running sum and non-null row count of the current frame for sliding aggregation
+	slidingSum float64
+	slidingCnt int
 }
 
 func NewAvgAgg(e sql.Expression) *AvgAgg {
@@ -186,11 +279,15 @@ func (a *AvgAgg) StartPartition(ctx *sql.Context, interval sql.WindowInterval, b
 	return err
 }
 
-func (a *AvgAgg) NewSlidingFrameInterval(added, dropped sql.WindowInterval) {
-	panic("sliding window interface not implemented yet")
-}
-
 func (a *AvgAgg) Compute(ctx *sql.Context, interval sql.WindowInterval, buf sql.WindowBuffer) interface{} {
+	if resetDistinct(a.expr) {
+		sum, nonNullCnt, err := floatSum(ctx, interval, buf, a.expr)
+		if err != nil || nonNullCnt < 1 {
+			return nil
+		}
+		return sum / float64(nonNullCnt)
+	}
+
 	startIdx := interval.Start - a.partitionStart - 1
 	endIdx := interval.End - a.partitionStart - 1
 
@@ -203,9 +300,42 @@ func (a *AvgAgg) Compute(ctx *sql.Context, interval sql.WindowInterval, buf sql.
 		nonNullCnt -= startIdx + 1
 		nonNullCnt += a.nullCnt[startIdx]
 	}
+	if nonNullCnt < 1 {
+		return nil
+	}
 	return computePrefixSum(interval, a.partitionStart, a.prefixSum) / float64(nonNullCnt)
 }
 
+func (a *AvgAgg) StartSlidingPartition(ctx *sql.Context, interval sql.WindowInterval, buf sql.WindowBuffer) error {
+	a.Dispose()
+	a.partitionStart = interval.Start
+	a.partitionEnd = interval.End
+	a.prefixSum, a.nullCnt = nil, nil
+	a.slidingSum, a.slidingCnt = 0, 0
+	return nil
+}
+
+func (a *AvgAgg) NewSlidingFrameInterval(ctx *sql.Context, added, dropped sql.WindowInterval, buf sql.WindowBuffer) error {
+	addedSum, addedCnt, err := floatSum(ctx, added, buf, a.expr)
+	if err != nil {
+		return err
+	}
+	droppedSum, droppedCnt, err := floatSum(ctx, dropped, buf, a.expr)
+	if err != nil {
+		return err
+	}
+	a.slidingSum += addedSum - droppedSum
+	a.slidingCnt += addedCnt - droppedCnt
+	return nil
+}
+
+func (a *AvgAgg) ComputeSliding(ctx *sql.Context) interface{} {
+	if a.slidingCnt < 1 {
+		return nil
+	}
+	return a.slidingSum / float64(a.slidingCnt)
+}
+
 type MaxAgg struct {
 	expr   sql.Expression
 	framer sql.WindowFramer
@@ -246,10 +376,6 @@ func (a *MaxAgg) StartPartition(ctx *sql.Context, interval sql.WindowInterval, b
 	return nil
 }
 
-func (a *MaxAgg) NewSlidingFrameInterval(added, dropped sql.WindowInterval) {
-	panic("sliding window interface not implemented yet")
-}
-
 func (a *MaxAgg) Compute(ctx *sql.Context, interval sql.WindowInterval, buffer sql.WindowBuffer) interface{} {
 	var max interface{}
 	for i := interval.Start; i < interval.End; i++ {
@@ -267,7 +393,7 @@ func (a *MaxAgg) Compute(ctx *sql.Context, interval sql.WindowInterval, buffer s
 			max = v
 		}
 
-		cmp, err := a.expr.Type().Compare(v, max)
+		cmp, err := sql.CollatedCompare(a.expr.Type(), v, max)
 		if err != nil {
 			return err
 		}
@@ -318,10 +444,6 @@ func (a *MinAgg) StartPartition(ctx *sql.Context, interval sql.WindowInterval, b
 	return nil
 }
 
-func (a *MinAgg) NewSlidingFrameInterval(added, dropped sql.WindowInterval) {
-	panic("sliding window interface not implemented yet")
-}
-
 func (a *MinAgg) Compute(ctx *sql.Context, interval sql.WindowInterval, buf sql.WindowBuffer) interface{} {
 	var min interface{}
 	for _, row := range buf[interval.Start:interval.End] {
@@ -339,7 +461,7 @@ func (a *MinAgg) Compute(ctx *sql.Context, interval sql.WindowInterval, buf sql.
 			continue
 		}
 
-		cmp, err := a.expr.Type().Compare(v, min)
+		cmp, err := sql.CollatedCompare(a.expr.Type(), v, min)
 		if err != nil {
 			return err
 		}
@@ -390,10 +512,6 @@ func (a *LastAgg) StartPartition(ctx *sql.Context, interval sql.WindowInterval,
 	return nil
 }
 
-func (a *LastAgg) NewSlidingFrameInterval(added, dropped sql.WindowInterval) {
-	panic("sliding window interface not implemented yet")
-}
-
 func (a *LastAgg) Compute(ctx *sql.Context, interval sql.WindowInterval, buffer sql.WindowBuffer) interface{} {
 	if interval.End-interval.Start < 1 {
 		return nil
@@ -448,10 +566,6 @@ func (a *FirstAgg) StartPartition(ctx *sql.Context, interval sql.WindowInterval,
 	return nil
 }
 
-func (a *FirstAgg) NewSlidingFrameInterval(added, dropped sql.WindowInterval) {
-	panic("sliding window interface not implemented yet")
-}
-
 func (a *FirstAgg) Compute(ctx *sql.Context, interval sql.WindowInterval, buffer sql.WindowBuffer) interface{} {
 	if interval.End-interval.Start < 1 {
 		return nil
@@ -478,6 +592,9 @@ type CountAgg struct {
 	pos int
 	// peerGroup tracks value increments
 	peerGroup sql.WindowInterval
+
+	// running row count of the current frame for sliding aggregation
+	slidingCnt int64
 }
 
 func NewCountAgg(e sql.Expression) *CountAgg {
@@ -549,15 +666,66 @@ func (a *CountAgg) StartPartition(ctx *sql.Context, interval sql.WindowInterval,
 	return nil
 }
 
-func (a *CountAgg) NewSlidingFrameInterval(added, dropped sql.WindowInterval) {
-	panic("sliding window interface not implemented yet")
-}
-
 func (a *CountAgg) Compute(ctx *sql.Context, interval sql.WindowInterval, buf sql.WindowBuffer) interface{} {
 	a.pos++
+	if resetDistinct(a.expr) {
+		cnt, err := countRows(ctx, interval, buf, a.expr)
+		if err != nil {
+			return nil
+		}
+		return cnt
+	}
 	return int64(computePrefixSum(sql.WindowInterval{Start: interval.Start, End: interval.End}, a.partitionStart, a.prefixSum))
 }
 
+func (a *CountAgg) StartSlidingPartition(ctx *sql.Context, interval sql.WindowInterval, buf sql.WindowBuffer) error {
+	a.Dispose()
+	a.partitionStart, a.partitionEnd = interval.Start, interval.End
+	a.pos = a.partitionStart
+	a.peerGroup = sql.WindowInterval{}
+	a.prefixSum = nil
+	a.slidingCnt = 0
+	return nil
+}
+
+func (a *CountAgg) NewSlidingFrameInterval(ctx *sql.Context, added, dropped sql.WindowInterval, buf sql.WindowBuffer) error {
+	addedCnt, err := countRows(ctx, added, buf, a.expr)
+	if err != nil {
+		return err
+	}
+	droppedCnt, err := countRows(ctx, dropped, buf, a.expr)
+	if err != nil {
+		return err
+	}
+	a.slidingCnt += addedCnt - droppedCnt
+	return nil
+}
+
+func (a *CountAgg) ComputeSliding(ctx *sql.Context) interface{} {
+	a.pos++
+	return a.slidingCnt
+}
+
+// countRows returns the number of rows in [interval] with a non-null value for [expr], or
+// the number of rows for a star expression.
+func countRows(ctx *sql.Context, interval sql.WindowInterval, buf sql.WindowBuffer, expr sql.Expression) (int64, error) {
+	if _, ok := expr.(*expression.Star); ok {
+		return int64(interval.End - interval.Start), nil
+	}
+
+	var cnt int64
+	for i := interval.Start; i < interval.End; i++ {
+		v, err := expr.Eval(ctx, buf[i])
+		if err != nil {
+			return 0, err
+		}
+		if v != nil {
+			cnt++
+		}
+	}
+	return cnt, nil
+}
+
 func countPrefixSum(ctx *sql.Context, interval sql.WindowInterval, buf sql.WindowBuffer, expr sql.Expression) ([]float64, error) {
 	intervalLen := interval.End - interval.Start
 	sums := make([]float64, intervalLen)
@@ -586,14 +754,15 @@ func countPrefixSum(ctx *sql.Context, interval sql.WindowInterval, buf sql.Windo
 	return sums, nil
 }
 
+// GroupConcatAgg is the window function form of GROUP_CONCAT. Each frame is concatenated on its own, so the default
+// framer gives a running concatenation within every partition when the window has an ORDER BY, in that order unless
+// the GROUP_CONCAT has its own.
+// TODO make this more efficient, ideally with sliding window and hashes
 type GroupConcatAgg struct {
 	gc     *GroupConcat
 	framer sql.WindowFramer
-	// hash map to deduplicate values
-	// TODO make this more efficient, ideally with sliding window and hashes
-	distinct map[string]struct{}
-	// original row order used for optional result sorting
-	rows []sql.Row
+	// orderBy is the ORDER BY of the window, which determines the default frame
+	orderBy []sql.Expression
 }
 
 func NewGroupConcatAgg(gc *GroupConcat) *GroupConcatAgg {
@@ -610,6 +779,10 @@ func (a *GroupConcatAgg) WithWindow(w *sql.WindowDefinition) (sql.WindowFunction
 			return nil, err
 		}
 		na.framer = framer
+		return &na, nil
+	}
+	if w.OrderBy != nil {
+		na.orderBy = w.OrderBy.ToExpressions()
 	}
 	return &na, nil
 }
@@ -618,29 +791,34 @@ func (a *GroupConcatAgg) Dispose() {
 	expression.Dispose(a.gc)
 }
 
-// DefaultFramer returns a NewUnboundedPrecedingToCurrentRowFramer
+// DefaultFramer returns a NewPartitionFramer, which concatenates the whole partition, or a framer from the start of the
+// partition to the current row's peers when the window has an ORDER BY.
 func (a *GroupConcatAgg) DefaultFramer() sql.WindowFramer {
 	if a.framer != nil {
 		return a.framer
 	}
-	return NewUnboundedPrecedingToCurrentRowFramer()
+
+	if len(a.orderBy) < 1 {
+		return NewPartitionFramer()
+	}
+
+	return &RangeUnboundedPrecedingToCurrentRowFramer{
+		rangeFramerBase{
+			orderBy:            a.orderBy[0],
+			unboundedPreceding: true,
+			endCurrentRow:      true,
+		},
+	}
 }
 
 func (a *GroupConcatAgg) StartPartition(ctx *sql.Context, interval sql.WindowInterval, buf sql.WindowBuffer) error {
 	a.Dispose()
-	var err error
-	a.rows, a.distinct, err = a.filterToDistinct(ctx, buf[interval.Start:interval.End])
-	return err
-}
-
-func (a *GroupConcatAgg) NewSlidingFrameInterval(added, dropped sql.WindowInterval) {
-	panic("sliding window interface not implemented yet")
+	return nil
 }
 
 func (a *GroupConcatAgg) Compute(ctx *sql.Context, interval sql.WindowInterval, buf sql.WindowBuffer) interface{} {
-	rows := a.rows
-
-	if len(rows) == 0 {
+	rows, err := a.filterToDistinct(ctx, buf[interval.Start:interval.End])
+	if err != nil || len(rows) == 0 {
 		return nil
 	}
 
@@ -685,13 +863,15 @@ func (a *GroupConcatAgg) Compute(ctx *sql.Context, interval sql.WindowInterval,
 	return ret
 }
 
-func (a *GroupConcatAgg) filterToDistinct(ctx *sql.Context, buf sql.WindowBuffer) ([]sql.Row, map[string]struct{}, error) {
+// filterToDistinct returns the rows of [buf] with a non-null value, each extended with its value converted to a
+// string. Only the first row with each value is kept for a DISTINCT GROUP_CONCAT.
+func (a *GroupConcatAgg) filterToDistinct(ctx *sql.Context, buf sql.WindowBuffer) ([]sql.Row, error) {
 	rows := make([]sql.Row, 0)
 	distinct := make(map[string]struct{}, 0)
 	for _, row := range buf {
 		evalRow, retType, err := evalExprs(ctx, a.gc.selectExprs, row)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 
 		a.gc.returnType = retType
@@ -709,7 +889,7 @@ func (a *GroupConcatAgg) filterToDistinct(ctx *sql.Context, buf sql.WindowBuffer
 		}
 
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 
 		if v == nil {
@@ -731,9 +911,9 @@ func (a *GroupConcatAgg) filterToDistinct(ctx *sql.Context, buf sql.WindowBuffer
 
 		// Append the current value to the end of the row. We want to preserve the row's original structure for
 		// for sort ordering in the final step.
-		rows = append(rows, append(row, nil, vs))
+		rows = append(rows, append(row.Copy(), nil, vs))
 	}
-	return rows, distinct, nil
+	return rows, nil
 }
 
 type WindowedJSONArrayAgg struct {
@@ -773,10 +953,6 @@ func (a *WindowedJSONArrayAgg) StartPartition(ctx *sql.Context, interval sql.Win
 	return nil
 }
 
-func (a *WindowedJSONArrayAgg) NewSlidingFrameInterval(added, dropped sql.WindowInterval) {
-	panic("sliding window interface not implemented yet")
-}
-
 func (a *WindowedJSONArrayAgg) Compute(ctx *sql.Context, interval sql.WindowInterval, buf sql.WindowBuffer) interface{} {
 	res, err := a.aggregateVals(ctx, interval, buf)
 	if err != nil {
@@ -808,6 +984,74 @@ func (a *WindowedJSONArrayAgg) aggregateVals(ctx *sql.Context, interval sql.Wind
 	return vals, nil
 }
 
+type WindowedCollectAgg struct {
+	expr   sql.Expression
+	framer sql.WindowFramer
+	// we need to eval the partition before Compute to return evaluation errors
+	vals  []interface{}
+	start int
+}
+
+func NewCollectAgg(expr sql.Expression) *WindowedCollectAgg {
+	return &WindowedCollectAgg{
+		expr: expr,
+	}
+}
+
+func (a *WindowedCollectAgg) WithWindow(w *sql.WindowDefinition) (sql.WindowFunction, error) {
+	na := *a
+	if w.Frame != nil {
+		framer, err := w.Frame.NewFramer(w)
+		if err != nil {
+			return nil, err
+		}
+		na.framer = framer
+	}
+	return &na, nil
+}
+
+func (a *WindowedCollectAgg) Dispose() {
+	expression.Dispose(a.expr)
+}
+
+// DefaultFramer returns a NewUnboundedPrecedingToCurrentRowFramer
+func (a *WindowedCollectAgg) DefaultFramer() sql.WindowFramer {
+	return NewUnboundedPrecedingToCurrentRowFramer()
+}
+
+func (a *WindowedCollectAgg) StartPartition(ctx *sql.Context, interval sql.WindowInterval, buf sql.WindowBuffer) error {
+	a.Dispose()
+	a.vals = make([]interface{}, 0, interval.End-interval.Start)
+	a.start = interval.Start
+	var geoms []interface{}
+	for _, row := range buf[interval.Start:interval.End] {
+		v, err := a.expr.Eval(ctx, row)
+		if err != nil {
+			return err
+		}
+		a.vals = append(a.vals, v)
+		if v != nil {
+			geoms = append(geoms, v)
+		}
+	}
+
+	// the geometries of every frame are valid together when those of the whole partition are
+	_, err := collectGeometries(geoms)
+	return err
+}
+
+func (a *WindowedCollectAgg) Compute(ctx *sql.Context, interval sql.WindowInterval, buf sql.WindowBuffer) interface{} {
+	var geoms []interface{}
+	for _, v := range a.vals[interval.Start-a.start : interval.End-a.start] {
+		if v != nil {
+			geoms = append(geoms, v)
+		}
+	}
+
+	res, _ := collectGeometries(geoms)
+	return res
+}
+
 type WindowedJSONObjectAgg struct {
 	j      *JSONObjectAgg
 	framer sql.WindowFramer
@@ -852,10 +1096,6 @@ func (a *WindowedJSONObjectAgg) StartPartition(ctx *sql.Context, interval sql.Wi
 	return err
 }
 
-func (a *WindowedJSONObjectAgg) NewSlidingFrameInterval(added, dropped sql.WindowInterval) {
-	panic("sliding window interface not implemented yet")
-}
-
 func (a *WindowedJSONObjectAgg) Compute(ctx *sql.Context, interval sql.WindowInterval, buf sql.WindowBuffer) interface{} {
 	if len(a.vals) == 0 {
 		return nil
@@ -931,10 +1171,6 @@ func (a *RowNumber) StartPartition(ctx *sql.Context, interval sql.WindowInterval
 	return nil
 }
 
-func (a *RowNumber) NewSlidingFrameInterval(added, dropped sql.WindowInterval) {
-	panic("implement me")
-}
-
 func (a *RowNumber) Compute(ctx *sql.Context, interval sql.WindowInterval, buffer sql.WindowBuffer) interface{} {
 	if interval.End-interval.Start < 1 {
 		return nil
@@ -986,10 +1222,6 @@ func (a *PercentRank) StartPartition(ctx *sql.Context, interval sql.WindowInterv
 	return nil
 }
 
-func (a *PercentRank) NewSlidingFrameInterval(added, dropped sql.WindowInterval) {
-	panic("implement me")
-}
-
 // Compute returns the number of elements before the current peer group (rank),
 // and returns (rank - 1)/(rows - 1).
 // ex: [1, 2, 2, 2, 3, 3, 3, 4, 5, 5, 6] => every 3 returns float64(4) / float64(9), because
@@ -1009,6 +1241,99 @@ func (a *PercentRank) Compute(ctx *sql.Context, interval sql.WindowInterval, buf
 	}
 }
 
+type Rank struct {
+	partitionStart int
+	// orderBy tracks peer group increments
+	orderBy []sql.Expression
+}
+
+func NewRank(orderBy []sql.Expression) *Rank {
+	return &Rank{
+		partitionStart: -1,
+		orderBy:        orderBy,
+	}
+}
+
+func (a *Rank) WithWindow(w *sql.WindowDefinition) (sql.WindowFunction, error) {
+	na := *a
+	na.orderBy = w.OrderBy.ToExpressions()
+	return &na, nil
+}
+
+func (a *Rank) Dispose() {
+	return
+}
+
+// DefaultFramer returns a NewPeerGroupFramer
+func (a *Rank) DefaultFramer() sql.WindowFramer {
+	return NewPeerGroupFramer(a.orderBy)
+}
+
+func (a *Rank) StartPartition(ctx *sql.Context, interval sql.WindowInterval, buffer sql.WindowBuffer) error {
+	a.Dispose()
+	a.partitionStart = interval.Start
+	return nil
+}
+
+// Compute returns one more than the number of rows before the current peer group, leaving gaps after ties.
+// ex: [1, 2, 2, 3] => [1, 2, 2, 4]
+func (a *Rank) Compute(ctx *sql.Context, interval sql.WindowInterval, buf sql.WindowBuffer) interface{} {
+	if interval.End-interval.Start < 1 {
+		return nil
+	}
+	return uint64(interval.Start-a.partitionStart) + 1
+}
+
+type DenseRank struct {
+	// orderBy tracks peer group increments
+	orderBy []sql.Expression
+	// peerGroup is the peer group of the previous row
+	peerGroup sql.WindowInterval
+	// rank counts the peer groups seen in the partition
+	rank uint64
+}
+
+func NewDenseRank(orderBy []sql.Expression) *DenseRank {
+	return &DenseRank{
+		orderBy: orderBy,
+	}
+}
+
+func (a *DenseRank) WithWindow(w *sql.WindowDefinition) (sql.WindowFunction, error) {
+	na := *a
+	na.orderBy = w.OrderBy.ToExpressions()
+	return &na, nil
+}
+
+func (a *DenseRank) Dispose() {
+	return
+}
+
+// DefaultFramer returns a NewPeerGroupFramer
+func (a *DenseRank) DefaultFramer() sql.WindowFramer {
+	return NewPeerGroupFramer(a.orderBy)
+}
+
+func (a *DenseRank) StartPartition(ctx *sql.Context, interval sql.WindowInterval, buffer sql.WindowBuffer) error {
+	a.Dispose()
+	a.peerGroup = sql.WindowInterval{}
+	a.rank = 0
+	return nil
+}
+
+// Compute returns the number of peer groups up to and including the current one, without gaps after ties.
+// ex: [1, 2, 2, 3] => [1, 2, 2, 3]
+func (a *DenseRank) Compute(ctx *sql.Context, interval sql.WindowInterval, buf sql.WindowBuffer) interface{} {
+	if interval.End-interval.Start < 1 {
+		return nil
+	}
+	if a.rank == 0 || interval != a.peerGroup {
+		a.peerGroup = interval
+		a.rank++
+	}
+	return a.rank
+}
+
 type Lag struct {
 	leadLagBase
 }
@@ -1041,7 +1366,10 @@ type leadLagBase struct {
 	expr   sql.Expression
 	def    sql.Expression
 	offset int
-	pos    int
+	// values are the results for the rows of the partition
+	values []interface{}
+	// pos is the index of the current row within the partition
+	pos int
 }
 
 func (a *leadLagBase) WithWindow(w *sql.WindowDefinition) (sql.WindowFunction, error) {
@@ -1049,7 +1377,7 @@ func (a *leadLagBase) WithWindow(w *sql.WindowDefinition) (sql.WindowFunction, e
 }
 
 func (a *leadLagBase) Dispose() {
-	return
+	a.values = nil
 }
 
 // DefaultFramer returns a NewPartitionFramer
@@ -1057,29 +1385,38 @@ func (a *leadLagBase) DefaultFramer() sql.WindowFramer {
 	return NewPartitionFramer()
 }
 
+// StartPartition evaluates the results for every row of the partition up front, so that an error evaluating [expr] or
+// [def] is returned rather than dropped. The result of a row is the value of [expr] for the row [offset] rows before
+// it (after it for Lead), or the value of [def] for the row itself if that row is outside the partition. It's nil if
+// neither exists.
 func (a *leadLagBase) StartPartition(ctx *sql.Context, interval sql.WindowInterval, buffer sql.WindowBuffer) error {
 	a.Dispose()
+	a.pos = 0
+	for i := interval.Start; i < interval.End && i < len(buffer); i++ {
+		var res interface{}
+		var err error
+		// the referenced row is looked up within the partition, so it never belongs to another partition
+		ref := i - a.offset
+		switch {
+		case ref >= interval.Start && ref < interval.End:
+			res, err = a.expr.Eval(ctx, buffer[ref])
+		case a.def != nil:
+			res, err = a.def.Eval(ctx, buffer[i])
+		}
+		if err != nil {
+			return err
+		}
+		a.values = append(a.values, res)
+	}
 	return nil
 }
 
-func (a *leadLagBase) NewSlidingFrameInterval(added, dropped sql.WindowInterval) {
-	panic("implement me")
-}
-
 func (a *leadLagBase) Compute(ctx *sql.Context, interval sql.WindowInterval, buffer sql.WindowBuffer) interface{} {
-	var res interface{}
-	var err error
-	idx := a.pos - a.offset
-	switch {
-	case interval.Start > interval.End:
-	case idx >= interval.Start && idx < interval.End:
-		res, err = a.expr.Eval(ctx, buffer[idx])
-	case a.def != nil:
-		res, err = a.def.Eval(ctx, buffer[a.pos])
-	}
-	if err != nil {
+	if a.pos >= len(a.values) {
+		// the partition is empty, or every row of it has been computed
 		return nil
 	}
+	res := a.values[a.pos]
 	a.pos++
 	return res
 }
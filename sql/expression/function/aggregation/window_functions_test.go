@@ -275,6 +275,8 @@ func TestGroupedAggFuncs(t *testing.T) {
 }
 
 func TestWindowedAggFuncs(t *testing.T) {
+	// orderByX orders the rows of each partition by their second field, which is unique within the partition
+	orderByX := sql.NewWindowDefinition(nil, sql.SortFields{{Column: expression.NewGetField(1, sql.LongText, "x", true)}}, nil, "", "")
 	tests := []struct {
 		Name     string
 		Agg      sql.WindowFunction
@@ -322,6 +324,42 @@ func TestWindowedAggFuncs(t *testing.T) {
 			Agg:      NewRowNumber(),
 			Expected: sql.Row{1, 2, 3, 4, 1, 2, 3, 4, 1, 2, 3, 4, 5, 6},
 		},
+		{
+			Name: "group concat",
+			Agg:  mustNewGroupConcatAgg(mustNewGroupByConcat("", nil, "-", []sql.Expression{expression.NewGetField(1, sql.LongText, "x", true)}, 1042), orderByX),
+			Expected: sql.Row{
+				"1", "1-2", "1-2-3", "1-2-3-4",
+				"1", "1-2", "1-2-3", "1-2-3-4",
+				"1", "1-2", "1-2-3", "1-2-3-4", "1-2-3-4-5", "1-2-3-4-5-6",
+			},
+		},
+		{
+			Name: "group concat without order by",
+			Agg:  mustNewGroupConcatAgg(mustNewGroupByConcat("", nil, "-", []sql.Expression{expression.NewGetField(1, sql.LongText, "x", true)}, 1042), sql.NewWindowDefinition(nil, nil, nil, "", "")),
+			Expected: sql.Row{
+				"1-2-3-4", "1-2-3-4", "1-2-3-4", "1-2-3-4",
+				"1-2-3-4", "1-2-3-4", "1-2-3-4", "1-2-3-4",
+				"1-2-3-4-5-6", "1-2-3-4-5-6", "1-2-3-4-5-6", "1-2-3-4-5-6", "1-2-3-4-5-6", "1-2-3-4-5-6",
+			},
+		},
+		{
+			Name: "group concat null",
+			Agg:  mustNewGroupConcatAgg(mustNewGroupByConcat("", nil, ",", []sql.Expression{expression.NewGetField(0, sql.LongText, "x", true)}, 1042), orderByX),
+			Expected: sql.Row{
+				"1", "1", "1,3", "1,3,4",
+				"1", "1", "1,3", "1,3,4",
+				"1", "1,2", "1,2", "1,2", "1,2,5", "1,2,5,6",
+			},
+		},
+		{
+			Name: "group concat distinct",
+			Agg:  mustNewGroupConcatAgg(mustNewGroupByConcat("DISTINCT", nil, ",", []sql.Expression{expression.NewGetField(2, sql.LongText, "x", true)}, 1042), orderByX),
+			Expected: sql.Row{
+				"1", "1,2", "1,2,3", "1,2,3",
+				"1", "1,2", "1,2,3", "1,2,3",
+				"1", "1,2", "1,2,3", "1,2,3,4", "1,2,3,4,5", "1,2,3,4,5",
+			},
+		},
 		{
 			Name: "percent rank no peers",
 			Agg:  NewPercentRank([]sql.Expression{}),
@@ -340,6 +378,42 @@ func TestWindowedAggFuncs(t *testing.T) {
 				float64(0), float64(1) / float64(5), float64(1) / float64(5), float64(3) / float64(5), float64(3) / float64(5), float64(1),
 			},
 		},
+		{
+			Name: "rank no peers",
+			Agg:  NewRank([]sql.Expression{}),
+			Expected: sql.Row{
+				uint64(1), uint64(1), uint64(1), uint64(1),
+				uint64(1), uint64(1), uint64(1), uint64(1),
+				uint64(1), uint64(1), uint64(1), uint64(1), uint64(1), uint64(1),
+			},
+		},
+		{
+			Name: "rank peer groups",
+			Agg:  NewRank([]sql.Expression{expression.NewGetField(5, sql.LongText, "x", true)}),
+			Expected: sql.Row{
+				uint64(1), uint64(1), uint64(3), uint64(4),
+				uint64(1), uint64(2), uint64(2), uint64(4),
+				uint64(1), uint64(2), uint64(2), uint64(4), uint64(4), uint64(6),
+			},
+		},
+		{
+			Name: "dense rank no peers",
+			Agg:  NewDenseRank([]sql.Expression{}),
+			Expected: sql.Row{
+				uint64(1), uint64(1), uint64(1), uint64(1),
+				uint64(1), uint64(1), uint64(1), uint64(1),
+				uint64(1), uint64(1), uint64(1), uint64(1), uint64(1), uint64(1),
+			},
+		},
+		{
+			Name: "dense rank peer groups",
+			Agg:  NewDenseRank([]sql.Expression{expression.NewGetField(5, sql.LongText, "x", true)}),
+			Expected: sql.Row{
+				uint64(1), uint64(1), uint64(2), uint64(3),
+				uint64(1), uint64(2), uint64(2), uint64(3),
+				uint64(1), uint64(2), uint64(2), uint64(3), uint64(3), uint64(4),
+			},
+		},
 	}
 
 	buf := []sql.Row{
@@ -391,6 +465,295 @@ func TestWindowedAggFuncs(t *testing.T) {
 
 }
 
+func TestLeadLagPartitionBounds(t *testing.T) {
+	x := expression.NewGetField(0, sql.LongText, "x", true)
+	def := expression.NewGetField(1, sql.LongText, "x", true)
+	tests := []struct {
+		Name       string
+		Agg        sql.WindowFunction
+		Partitions []sql.WindowInterval
+		Expected   sql.Row
+	}{
+		{
+			Name:       "lag single row partitions",
+			Agg:        NewLag(x, nil, 1),
+			Partitions: []sql.WindowInterval{{Start: 0, End: 1}, {Start: 1, End: 2}, {Start: 2, End: 3}},
+			Expected:   sql.Row{nil, nil, nil},
+		},
+		{
+			Name:       "lag single row partitions w/ default",
+			Agg:        NewLag(x, def, 1),
+			Partitions: []sql.WindowInterval{{Start: 0, End: 1}, {Start: 1, End: 2}, {Start: 2, End: 3}},
+			Expected:   sql.Row{10, 20, 30},
+		},
+		{
+			Name:       "lead single row partitions",
+			Agg:        NewLead(x, nil, 1),
+			Partitions: []sql.WindowInterval{{Start: 0, End: 1}, {Start: 1, End: 2}, {Start: 2, End: 3}},
+			Expected:   sql.Row{nil, nil, nil},
+		},
+		{
+			Name:       "lead single row partitions w/ default",
+			Agg:        NewLead(x, def, 1),
+			Partitions: []sql.WindowInterval{{Start: 0, End: 1}, {Start: 1, End: 2}, {Start: 2, End: 3}},
+			Expected:   sql.Row{10, 20, 30},
+		},
+		{
+			Name:       "lag offset larger than partition",
+			Agg:        NewLag(x, nil, 5),
+			Partitions: []sql.WindowInterval{{Start: 0, End: 3}},
+			Expected:   sql.Row{nil, nil, nil},
+		},
+		{
+			Name:       "lag offset larger than partition w/ default",
+			Agg:        NewLag(x, def, 5),
+			Partitions: []sql.WindowInterval{{Start: 0, End: 3}},
+			Expected:   sql.Row{10, 20, 30},
+		},
+		{
+			Name:       "lead offset larger than partition w/ default",
+			Agg:        NewLead(x, def, 5),
+			Partitions: []sql.WindowInterval{{Start: 0, End: 3}},
+			Expected:   sql.Row{10, 20, 30},
+		},
+		{
+			Name:       "lag after empty partition",
+			Agg:        NewLag(x, def, 1),
+			Partitions: []sql.WindowInterval{{Start: 0, End: 0}, {Start: 0, End: 3}},
+			Expected:   sql.Row{nil, 10, 1, 2},
+		},
+		{
+			Name:       "lead after empty partition",
+			Agg:        NewLead(x, def, 1),
+			Partitions: []sql.WindowInterval{{Start: 0, End: 0}, {Start: 0, End: 3}},
+			Expected:   sql.Row{nil, 2, 3, 30},
+		},
+		{
+			Name:       "lag partitions of differing sizes",
+			Agg:        NewLag(x, nil, 1),
+			Partitions: []sql.WindowInterval{{Start: 0, End: 1}, {Start: 1, End: 4}, {Start: 4, End: 6}, {Start: 6, End: 10}},
+			Expected:   sql.Row{nil, nil, 2, 3, nil, 5, nil, 7, 8, 9},
+		},
+		{
+			Name:       "lag partitions of differing sizes w/ default",
+			Agg:        NewLag(x, def, 2),
+			Partitions: []sql.WindowInterval{{Start: 0, End: 1}, {Start: 1, End: 4}, {Start: 4, End: 6}, {Start: 6, End: 10}},
+			Expected:   sql.Row{10, 20, 30, 2, 50, 60, 70, 80, 7, 8},
+		},
+		{
+			Name:       "lead partitions of differing sizes",
+			Agg:        NewLead(x, nil, 1),
+			Partitions: []sql.WindowInterval{{Start: 0, End: 1}, {Start: 1, End: 4}, {Start: 4, End: 6}, {Start: 6, End: 10}},
+			Expected:   sql.Row{nil, 3, 4, nil, 6, nil, 8, 9, 10, nil},
+		},
+		{
+			Name:       "lead partitions of differing sizes w/ default",
+			Agg:        NewLead(x, def, 2),
+			Partitions: []sql.WindowInterval{{Start: 0, End: 1}, {Start: 1, End: 4}, {Start: 4, End: 6}, {Start: 6, End: 10}},
+			Expected:   sql.Row{10, 4, 30, 40, 50, 60, 9, 10, 90, 100},
+		},
+	}
+
+	buf := []sql.Row{
+		{1, 10},
+		{2, 20},
+		{3, 30},
+		{4, 40},
+		{5, 50},
+		{6, 60},
+		{7, 70},
+		{8, 80},
+		{9, 90},
+		{10, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx := sql.NewEmptyContext()
+			var res sql.Row
+			for _, p := range tt.Partitions {
+				require.NoError(t, tt.Agg.StartPartition(ctx, p, buf))
+				framer, err := tt.Agg.DefaultFramer().NewFramer(p)
+				require.NoError(t, err)
+				for {
+					interval, err := framer.Next(ctx, buf)
+					if errors.Is(err, io.EOF) {
+						break
+					}
+					require.NoError(t, err)
+					res = append(res, tt.Agg.Compute(ctx, interval, buf))
+				}
+			}
+			require.Equal(t, tt.Expected, res)
+		})
+	}
+
+	t.Run("empty buffer", func(t *testing.T) {
+		ctx := sql.NewEmptyContext()
+		for _, agg := range []sql.WindowFunction{NewLag(x, def, 1), NewLead(x, def, 1)} {
+			p := sql.WindowInterval{}
+			require.NoError(t, agg.StartPartition(ctx, p, nil))
+			require.Nil(t, agg.Compute(ctx, p, nil))
+		}
+	})
+}
+
+func TestSlidingWindowAggFuncs(t *testing.T) {
+	aggs := []struct {
+		Name string
+		Agg  func() sql.SlidingWindowFunction
+	}{
+		{
+			Name: "sum",
+			Agg:  func() sql.SlidingWindowFunction { return NewSumAgg(expression.NewGetField(0, sql.Int64, "x", true)) },
+		},
+		{
+			Name: "avg",
+			Agg:  func() sql.SlidingWindowFunction { return NewAvgAgg(expression.NewGetField(0, sql.Int64, "x", true)) },
+		},
+		{
+			Name: "count",
+			Agg:  func() sql.SlidingWindowFunction { return NewCountAgg(expression.NewGetField(0, sql.Int64, "x", true)) },
+		},
+		{
+			Name: "count star",
+			Agg:  func() sql.SlidingWindowFunction { return NewCountAgg(expression.NewStar()) },
+		},
+	}
+
+	framers := []struct {
+		Name   string
+		Framer sql.WindowFramer
+	}{
+		{
+			Name:   "unbounded preceding to current row",
+			Framer: NewUnboundedPrecedingToCurrentRowFramer(),
+		},
+		{
+			Name:   "3 preceding to 1 preceding",
+			Framer: &RowsNPrecedingToNPrecedingFramer{rowFramerBase{startNPreceding: 3, endNPreceding: 1}},
+		},
+		{
+			Name:   "2 preceding to 2 following",
+			Framer: &RowsNPrecedingToNFollowingFramer{rowFramerBase{startNPreceding: 2, endNFollowing: 2}},
+		},
+		{
+			Name:   "1 following to 3 following",
+			Framer: &RowsNFollowingToNFollowingFramer{rowFramerBase{startNFollowing: 1, endNFollowing: 3}},
+		},
+		{
+			Name:   "current row to unbounded following",
+			Framer: &RowsCurrentRowToUnboundedFollowingFramer{rowFramerBase{startCurrentRow: true, unboundedFollowing: true}},
+		},
+		{
+			Name:   "partition",
+			Framer: NewPartitionFramer(),
+		},
+	}
+
+	buf := []sql.Row{
+		{int64(1)}, {nil}, {int64(3)}, {int64(-4)}, {int64(5)},
+		{nil}, {nil}, {int64(2)},
+		{int64(7)}, {int64(8)}, {nil}, {int64(-10)}, {int64(11)}, {nil}, {int64(13)}, {int64(14)},
+	}
+
+	partitions := []sql.WindowInterval{
+		{Start: 0, End: 5},
+		{Start: 5, End: 8},
+		{Start: 8, End: 16},
+	}
+
+	for _, agg := range aggs {
+		for _, framer := range framers {
+			t.Run(agg.Name+" "+framer.Name, func(t *testing.T) {
+				ctx := sql.NewEmptyContext()
+				expected, err := computeWindowAgg(ctx, agg.Agg(), framer.Framer, partitions, buf)
+				require.NoError(t, err)
+				res, err := computeSlidingWindowAgg(ctx, agg.Agg(), framer.Framer, partitions, buf)
+				require.NoError(t, err)
+				require.Equal(t, expected, res)
+			})
+		}
+	}
+}
+
+func BenchmarkSlidingWindowSum(b *testing.B) {
+	buf := make([]sql.Row, 10000)
+	for i := range buf {
+		buf[i] = sql.Row{int64(i % 100)}
+	}
+	partitions := []sql.WindowInterval{{Start: 0, End: len(buf)}}
+	framer := &RowsNPrecedingToNFollowingFramer{rowFramerBase{startNPreceding: 500, endNFollowing: 500}}
+	agg := NewSumAgg(expression.NewGetField(0, sql.Int64, "x", true))
+	ctx := sql.NewEmptyContext()
+
+	b.Run("recompute", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, err := computeWindowAgg(ctx, agg, framer, partitions, buf)
+			require.NoError(b, err)
+		}
+	})
+	b.Run("sliding", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, err := computeSlidingWindowAgg(ctx, agg, framer, partitions, buf)
+			require.NoError(b, err)
+		}
+	})
+}
+
+// computeWindowAgg computes [agg] over every frame of [framer] in [partitions].
+func computeWindowAgg(ctx *sql.Context, agg sql.WindowFunction, framer sql.WindowFramer, partitions []sql.WindowInterval, buf sql.WindowBuffer) (sql.Row, error) {
+	var res sql.Row
+	for _, p := range partitions {
+		if err := agg.StartPartition(ctx, p, buf); err != nil {
+			return nil, err
+		}
+		f, err := framer.NewFramer(p)
+		if err != nil {
+			return nil, err
+		}
+		for {
+			interval, err := f.Next(ctx, buf)
+			if errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				return nil, err
+			}
+			res = append(res, agg.Compute(ctx, interval, buf))
+		}
+	}
+	return res, nil
+}
+
+// computeSlidingWindowAgg computes [agg] over every frame of [framer] in [partitions],
+// updating the aggregation incrementally between frames.
+func computeSlidingWindowAgg(ctx *sql.Context, agg sql.SlidingWindowFunction, framer sql.WindowFramer, partitions []sql.WindowInterval, buf sql.WindowBuffer) (sql.Row, error) {
+	var res sql.Row
+	for _, p := range partitions {
+		if err := agg.StartSlidingPartition(ctx, p, buf); err != nil {
+			return nil, err
+		}
+		f, err := framer.NewFramer(p)
+		if err != nil {
+			return nil, err
+		}
+		for {
+			_, err := f.Next(ctx, buf)
+			if errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				return nil, err
+			}
+			_, dropped, added := f.SlidingInterval(ctx)
+			if err := agg.NewSlidingFrameInterval(ctx, added, dropped, buf); err != nil {
+				return nil, err
+			}
+			res = append(res, agg.ComputeSliding(ctx))
+		}
+	}
+	return res, nil
+}
+
 func mustNewGroupByConcat(distinct string, orderBy sql.SortFields, separator string, selectExprs []sql.Expression, maxLen int) *GroupConcat {
 	gc, err := NewGroupConcat(distinct, orderBy, separator, selectExprs, maxLen)
 	if err != nil {
@@ -398,3 +761,11 @@ func mustNewGroupByConcat(distinct string, orderBy sql.SortFields, separator str
 	}
 	return gc
 }
+
+func mustNewGroupConcatAgg(gc *GroupConcat, w *sql.WindowDefinition) sql.WindowFunction {
+	agg, err := NewGroupConcatAgg(gc).WithWindow(w)
+	if err != nil {
+		panic(err)
+	}
+	return agg
+}
@@ -29,10 +29,14 @@ import (
 // 3. Rearrange partition results into the projected ordering given by [outputOrdinals].
 //
 // We assume [outputOrdinals] is appropriately sized for [partitionIters].
+//
+// An optional [partitionLimit] drops rows from the materialized input before
+// it's given to [partitionIters].
 type WindowIter struct {
 	partitionIters []*WindowPartitionIter
 	outputOrdinals [][]int
 	iter           sql.RowIter
+	partitionLimit *PartitionLimit
 	initialized    bool
 }
 
@@ -47,6 +51,12 @@ func NewWindowIter(partitionIters []*WindowPartitionIter, outputOrdinals [][]int
 var _ sql.RowIter = (*WindowIter)(nil)
 var _ sql.Disposable = (*WindowIter)(nil)
 
+// WithPartitionLimit sets a PartitionLimit for the input rows of the WindowIter.
+func (i *WindowIter) WithPartitionLimit(l *PartitionLimit) *WindowIter {
+	i.partitionLimit = l
+	return i
+}
+
 // Close implements sql.RowIter
 func (i *WindowIter) Close(ctx *sql.Context) error {
 	i.Dispose()
@@ -121,6 +131,13 @@ func (i *WindowIter) initializeIters(ctx *sql.Context) error {
 		buf = append(buf, row)
 	}
 
+	if i.partitionLimit != nil {
+		buf, err = i.partitionLimit.apply(ctx, buf)
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, i := range i.partitionIters {
 		// each iter has its own copy of input buffer
 		i.child = &windowBufferIter{buf: buf}
@@ -41,7 +41,12 @@ func NewAggregation(a sql.WindowFunction, f sql.WindowFramer) *Aggregation {
 
 // startPartition disposes and recreates [framer] and resets the internal state of the aggregation [fn].
 func (a *Aggregation) startPartition(ctx *sql.Context, interval sql.WindowInterval, buf sql.WindowBuffer) error {
-	err := a.fn.StartPartition(ctx, interval, buf)
+	var err error
+	if fn, ok := a.slidingFunction(); ok {
+		err = fn.StartSlidingPartition(ctx, interval, buf)
+	} else {
+		err = a.fn.StartPartition(ctx, interval, buf)
+	}
 	if err != nil {
 		return err
 	}
@@ -52,6 +57,45 @@ func (a *Aggregation) startPartition(ctx *sql.Context, interval sql.WindowInterv
 	return nil
 }
 
+// slidingFunction returns [fn] as a sql.SlidingWindowFunction when its result can be updated
+// incrementally. Aggregations over DISTINCT values can't be, because a row dropped from the frame
+// may have the same value as a row that is still in it.
+func (a *Aggregation) slidingFunction() (sql.SlidingWindowFunction, bool) {
+	fn, ok := a.fn.(sql.SlidingWindowFunction)
+	if !ok {
+		return nil, false
+	}
+	var e sql.Expression
+	switch fn := a.fn.(type) {
+	case *SumAgg:
+		e = fn.expr
+	case *AvgAgg:
+		e = fn.expr
+	case *CountAgg:
+		e = fn.expr
+	}
+	if _, ok := e.(*expression.DistinctExpression); ok {
+		return nil, false
+	}
+	return fn, true
+}
+
+// compute returns the result of [fn] for the current frame of [framer]. Invertible aggregations
+// are updated incrementally with the rows added to and dropped from the last frame, others are
+// recomputed over the whole frame.
+func (a *Aggregation) compute(ctx *sql.Context, interval sql.WindowInterval, buf sql.WindowBuffer) (interface{}, error) {
+	fn, ok := a.slidingFunction()
+	if !ok {
+		return a.fn.Compute(ctx, interval, buf), nil
+	}
+
+	_, dropped, added := a.framer.SlidingInterval(ctx)
+	if err := fn.NewSlidingFrameInterval(ctx, added, dropped, buf); err != nil {
+		return nil, err
+	}
+	return fn.ComputeSliding(ctx), nil
+}
+
 // WindowPartition is an Aggregation set with unique partition and sorting keys.
 // There may be several WindowPartitions in one query, but each has unique key set.
 // A WindowPartitionIter is used to evaluate a WindowPartition with a specific sql.RowIter.
@@ -158,7 +202,9 @@ func (i *WindowPartitionIter) Next(ctx *sql.Context) (sql.Row, error) {
 }
 
 // materializeInput empties the child iterator int a buffer and sorts by (WPK, WSK). Returns
-// a sorted sql.WindowBuffer and a list of original row indices for resorting.
+// a sorted sql.WindowBuffer and a list of original row indices for resorting. The sort is
+// stable, so rows tied on (WPK, WSK) keep the order of [child], as if the original row index
+// were the last sort key.
 func (i *WindowPartitionIter) materializeInput(ctx *sql.Context) (sql.WindowBuffer, []int, error) {
 	input := make(sql.WindowBuffer, 0)
 	j := 0
@@ -275,7 +321,10 @@ func (i *WindowPartitionIter) compute(ctx *sql.Context) (sql.Row, error) {
 				return nil, err
 			}
 		}
-		row[j] = agg.fn.Compute(ctx, interval, i.input)
+		row[j], err = agg.compute(ctx, interval, i.input)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// TODO: move sort by above aggregation
@@ -373,7 +422,9 @@ func isNewPartition(ctx *sql.Context, partitionBy []sql.Expression, last sql.Row
 		if err != nil {
 			return false, err
 		}
-		return cmp != 0, nil
+		if cmp != 0 {
+			return true, nil
+		}
 	}
 
 	return false, nil
@@ -0,0 +1,135 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregation
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// PartitionLimit keeps only the first [Limit] rows of every partition of a window, in the order ROW_NUMBER() numbers
+// them: sorted by [SortBy], with ties in the order of the input. It lets a window whose results are filtered on
+// ROW_NUMBER() <= [Limit] skip sorting the rows it would drop.
+//
+// Rows that are kept get the same ROW_NUMBER() as without the limit. Every other window function over the same rows
+// would see fewer rows, so a PartitionLimit is only correct for windows whose one window function is ROW_NUMBER().
+type PartitionLimit struct {
+	PartitionBy []sql.Expression
+	SortBy      sql.SortFields
+	Limit       int
+}
+
+// limitedPartition is a bounded heap of the first rows of a partition with the given key.
+type limitedPartition struct {
+	key  sql.Row
+	rows *expression.TopRowsHeap
+}
+
+// apply returns the rows of [buf] that are within the first [l.Limit] rows of their partition, in the order of [buf].
+// Each partition keeps a heap of at most [l.Limit] rows, so only the rows that are kept are ever sorted.
+func (l *PartitionLimit) apply(ctx *sql.Context, buf sql.WindowBuffer) (sql.WindowBuffer, error) {
+	if len(buf) == 0 || l.Limit <= 0 {
+		return buf, nil
+	}
+
+	// The index of each row in [buf] is appended to it and sorted on last, which breaks ties like the stable sort of
+	// WindowPartitionIter does
+	idxPos := len(buf[0])
+	sortFields := append(append(sql.SortFields{}, l.SortBy...), sql.SortField{
+		Column: expression.NewGetField(idxPos, sql.Int64, "", false),
+		Order:  sql.Ascending,
+	})
+
+	partitions := make(map[uint64][]*limitedPartition)
+	var all []*limitedPartition
+	for j, row := range buf {
+		key, _, err := evalExprs(ctx, l.PartitionBy, row)
+		if err != nil {
+			return nil, err
+		}
+		hash, err := sql.HashOf(key)
+		if err != nil {
+			return nil, err
+		}
+
+		// Different keys may share a hash, so the key is compared as well. Keys that compare equal but hash
+		// differently only keep more rows than needed, which doesn't change the first rows of their partition.
+		var p *limitedPartition
+		for _, candidate := range partitions[hash] {
+			equal, err := partitionKeysEqual(l.PartitionBy, candidate.key, key)
+			if err != nil {
+				return nil, err
+			}
+			if equal {
+				p = candidate
+				break
+			}
+		}
+		if p == nil {
+			p = &limitedPartition{
+				key: key,
+				rows: &expression.TopRowsHeap{
+					Sorter: expression.Sorter{
+						SortFields: sortFields,
+						Rows:       []sql.Row{},
+						Ctx:        ctx,
+					},
+				},
+			}
+			partitions[hash] = append(partitions[hash], p)
+			all = append(all, p)
+		}
+
+		heap.Push(p.rows, append(row[:idxPos:idxPos], j))
+		if p.rows.Len() > l.Limit {
+			heap.Pop(p.rows)
+		}
+		if p.rows.LastError != nil {
+			return nil, p.rows.LastError
+		}
+	}
+
+	var kept sql.WindowBuffer
+	for _, p := range all {
+		kept = append(kept, p.rows.Sorter.Rows...)
+	}
+
+	// restore the order of [buf], and drop the row indexes
+	sort.Slice(kept, func(j, k int) bool {
+		return kept[j][idxPos].(int) < kept[k][idxPos].(int)
+	})
+	for j, row := range kept {
+		kept[j] = row[:idxPos]
+	}
+
+	return kept, nil
+}
+
+// partitionKeysEqual returns whether two evaluated keys of [partitionBy] are the same partition.
+func partitionKeysEqual(partitionBy []sql.Expression, a, b sql.Row) (bool, error) {
+	for i, expr := range partitionBy {
+		cmp, err := expr.Type().Compare(a[i], b[i])
+		if err != nil {
+			return false, err
+		}
+		if cmp != 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregation
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+var (
+	limitPartitionBy = []sql.Expression{expression.NewGetField(1, sql.Int64, "p", true)}
+	limitSortBy      = sql.SortFields{{Column: expression.NewGetField(2, sql.Int64, "v", true), Order: sql.Descending}}
+)
+
+// rowNumberIter returns a WindowIter of the id and the ROW_NUMBER() of the rows of [buf], which are rows of (id, p, v),
+// partitioned by p and sorted by v descending, with the PartitionLimit given.
+func rowNumberIter(buf sql.WindowBuffer, limit *PartitionLimit) *WindowIter {
+	id := NewLastAgg(expression.NewGetField(0, sql.Int64, "id", false))
+	rowNumber := NewRowNumber()
+	return NewWindowIter(
+		[]*WindowPartitionIter{
+			NewWindowPartitionIter(NewWindowPartition(nil, nil, []*Aggregation{NewAggregation(id, id.DefaultFramer())})),
+			NewWindowPartitionIter(NewWindowPartition(limitPartitionBy, limitSortBy, []*Aggregation{NewAggregation(rowNumber, rowNumber.DefaultFramer())})),
+		},
+		[][]int{{0}, {1}},
+		&windowBufferIter{buf: buf},
+	).WithPartitionLimit(limit)
+}
+
+func TestPartitionLimit(t *testing.T) {
+	t.Run("keeps the first rows of each partition in input order", func(t *testing.T) {
+		buf := sql.WindowBuffer{
+			{int64(1), int64(1), int64(10)},
+			{int64(2), int64(2), int64(5)},
+			{int64(3), int64(1), int64(30)},
+			{int64(4), int64(1), int64(30)},
+			{int64(5), nil, int64(1)},
+			{int64(6), int64(2), nil},
+			{int64(7), int64(1), int64(20)},
+			{int64(8), nil, int64(2)},
+		}
+		limit := &PartitionLimit{PartitionBy: limitPartitionBy, SortBy: limitSortBy, Limit: 2}
+
+		ctx := sql.NewEmptyContext()
+		res, err := sql.RowIterToRows(ctx, nil, rowNumberIter(buf, limit))
+		require.NoError(t, err)
+		require.Equal(t, []sql.Row{
+			{int64(2), 1},
+			{int64(3), 1},
+			{int64(4), 2},
+			{int64(5), 2},
+			{int64(6), 2},
+			{int64(8), 1},
+		}, res)
+	})
+
+	t.Run("matches a filter on row numbers without a limit", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		buf := make(sql.WindowBuffer, 2000)
+		for i := range buf {
+			buf[i] = sql.Row{int64(i), int64(r.Intn(25)), int64(r.Intn(10))}
+		}
+
+		ctx := sql.NewEmptyContext()
+		all, err := sql.RowIterToRows(ctx, nil, rowNumberIter(buf, nil))
+		require.NoError(t, err)
+
+		for _, k := range []int{1, 3, 10, 200} {
+			t.Run(fmt.Sprintf("limit %d", k), func(t *testing.T) {
+				var expected []sql.Row
+				for _, row := range all {
+					if row[1].(int) <= k {
+						expected = append(expected, row)
+					}
+				}
+
+				limit := &PartitionLimit{PartitionBy: limitPartitionBy, SortBy: limitSortBy, Limit: k}
+				res, err := sql.RowIterToRows(ctx, nil, rowNumberIter(buf, limit))
+				require.NoError(t, err)
+				require.Equal(t, expected, res)
+			})
+		}
+	})
+}
+
+func BenchmarkTopRowsPerPartition(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	buf := make(sql.WindowBuffer, 100000)
+	for i := range buf {
+		buf[i] = sql.Row{int64(i), int64(r.Intn(1000)), int64(r.Intn(1000000))}
+	}
+	ctx := sql.NewEmptyContext()
+
+	b.Run("sort", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, err := sql.RowIterToRows(ctx, nil, rowNumberIter(buf, nil))
+			require.NoError(b, err)
+		}
+	})
+	b.Run("partition limit", func(b *testing.B) {
+		limit := &PartitionLimit{PartitionBy: limitPartitionBy, SortBy: limitSortBy, Limit: 3}
+		for i := 0; i < b.N; i++ {
+			_, err := sql.RowIterToRows(ctx, nil, rowNumberIter(buf, limit))
+			require.NoError(b, err)
+		}
+	})
+}
@@ -86,6 +86,148 @@ func TestWindowPartitionIter(t *testing.T) {
 	}
 }
 
+func TestWindowPartitionIterFramers(t *testing.T) {
+	z := expression.NewGetField(3, sql.Int64, "z", true)
+
+	t.Run("default framer without a frame", func(t *testing.T) {
+		require.IsType(t, &PartitionFramer{}, NewSumAgg(z).DefaultFramer())
+		require.IsType(t, &PartitionFramer{}, NewCountAgg(z).DefaultFramer())
+	})
+
+	tests := []struct {
+		Name     string
+		Framer   func() sql.WindowFramer
+		Expected []sql.Row
+	}{
+		{
+			// one row per group, as for SELECT SUM(z), COUNT(z) ... GROUP BY x
+			Name:   "group by framer",
+			Framer: func() sql.WindowFramer { return NewGroupByFramer() },
+			Expected: []sql.Row{
+				{float64(27), int64(5)},
+				{float64(23), int64(4)},
+			},
+		},
+		{
+			// one row per input row, as for SELECT SUM(z) OVER (PARTITION BY x), COUNT(z) OVER (PARTITION BY x) ...
+			Name:   "partition framer",
+			Framer: func() sql.WindowFramer { return NewPartitionFramer() },
+			Expected: []sql.Row{
+				{float64(27), int64(5)},
+				{float64(27), int64(5)},
+				{float64(27), int64(5)},
+				{float64(27), int64(5)},
+				{float64(27), int64(5)},
+				{float64(23), int64(4)},
+				{float64(23), int64(4)},
+				{float64(23), int64(4)},
+				{float64(23), int64(4)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx := sql.NewEmptyContext()
+			iter := NewWindowPartitionIter(
+				&WindowPartition{
+					PartitionBy: partitionByX,
+					SortBy:      sortByW,
+					Aggs: []*Aggregation{
+						NewAggregation(NewSumAgg(z), tt.Framer()),
+						NewAggregation(NewCountAgg(z), tt.Framer()),
+					},
+				})
+			iter.child = mustNewRowIter(t, ctx)
+			res, err := sql.RowIterToRows(ctx, nil, iter)
+			require.NoError(t, err)
+			require.Equal(t, tt.Expected, res)
+		})
+	}
+}
+
+func TestWindowPartitionIterDefaultFramers(t *testing.T) {
+	z := expression.NewGetField(3, sql.Int64, "z", true)
+
+	tests := []struct {
+		Name     string
+		Window   *sql.WindowDefinition
+		Framer   sql.WindowFramer
+		Expected []sql.Row
+	}{
+		{
+			// SUM(z) OVER (PARTITION BY x) sums the whole partition on every row
+			Name:   "partition by without order by",
+			Window: sql.NewWindowDefinition(partitionByX, nil, nil, "", ""),
+			Framer: &PartitionFramer{},
+			Expected: []sql.Row{
+				{float64(27)}, {float64(27)}, {float64(27)}, {float64(27)}, {float64(27)},
+				{float64(23)}, {float64(23)}, {float64(23)}, {float64(23)},
+			},
+		},
+		{
+			// SUM(z) OVER (PARTITION BY x ORDER BY w) is a running sum within the partition
+			Name:   "partition by with order by",
+			Window: sql.NewWindowDefinition(partitionByX, sortByW, nil, "", ""),
+			Framer: &RangeUnboundedPrecedingToCurrentRowFramer{},
+			Expected: []sql.Row{
+				{float64(4)}, {float64(8)}, {float64(14)}, {float64(17)}, {float64(27)},
+				{float64(4)}, {float64(10)}, {float64(18)}, {float64(23)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx := sql.NewEmptyContext()
+			fn, err := NewSumAgg(z).WithWindow(tt.Window)
+			require.NoError(t, err)
+			framer := fn.DefaultFramer()
+			require.IsType(t, tt.Framer, framer)
+
+			iter := NewWindowPartitionIter(
+				&WindowPartition{
+					PartitionBy: tt.Window.PartitionBy,
+					SortBy:      sortByW,
+					Aggs:        []*Aggregation{NewAggregation(fn, framer)},
+				})
+			iter.child = mustNewRowIter(t, ctx)
+			res, err := sql.RowIterToRows(ctx, nil, iter)
+			require.NoError(t, err)
+			require.Equal(t, tt.Expected, res)
+		})
+	}
+}
+
+func TestWindowPartitionIterTiedSortBy(t *testing.T) {
+	// every row has the same sort key, so row numbers follow the input order within each partition
+	var rows, expected []sql.Row
+	for j := 0; j < 500; j++ {
+		x := "forest"
+		if j%2 == 1 {
+			x = "desert"
+		}
+		rows = append(rows, sql.Row{int64(1), x})
+		expected = append(expected, sql.Row{j/2 + 1})
+	}
+
+	for k := 0; k < 10; k++ {
+		ctx := sql.NewEmptyContext()
+		iter := NewWindowPartitionIter(
+			&WindowPartition{
+				PartitionBy: partitionByX,
+				SortBy:      sortByW,
+				Aggs: []*Aggregation{
+					NewAggregation(NewRowNumber(), NewPartitionFramer()),
+				},
+			})
+		iter.child = sql.RowsToRowIter(rows...)
+		res, err := sql.RowIterToRows(ctx, nil, iter)
+		require.NoError(t, err)
+		require.Equal(t, expected, res)
+	}
+}
+
 func mustNewRowIter(t *testing.T, ctx *sql.Context) sql.RowIter {
 	childSchema := sql.NewPrimaryKeySchema(sql.Schema{
 		{Name: "w", Type: sql.Int64, Nullable: true},
@@ -176,6 +318,71 @@ func TestWindowPartition_InitializePartitions(t *testing.T) {
 	require.ElementsMatch(t, expPartitions, partitions)
 }
 
+func TestWindowPartition_InitializePartitionsExpressions(t *testing.T) {
+	input := []sql.Row{
+		{int64(1), "forest", "leaf", 4},
+		{int64(3), "forest", "canopy", 6},
+		{int64(5), "forest", "wildflower", 10},
+		{int64(2), "forest", "bark", 4},
+		{int64(4), "forest", "bug", 3},
+		{int64(6), "desert", "sand", 4},
+		{int64(8), "desert", "scorpion", 8},
+		{int64(7), "desert", "cactus", 6},
+		{int64(9), "desert", "mummy", 5},
+	}
+	parity := expression.NewArithmetic(expression.NewGetFieldWithTable(0, sql.Int64, "a", "w", false), expression.NewLiteral(int64(2), sql.Int64), "%")
+
+	tests := []struct {
+		name        string
+		partitionBy []sql.Expression
+		expected    []sql.WindowInterval
+	}{
+		{
+			name:        "expression",
+			partitionBy: []sql.Expression{parity},
+			expected: []sql.WindowInterval{
+				{Start: 0, End: 3},
+				{Start: 3, End: 7},
+				{Start: 7, End: 9},
+			},
+		},
+		{
+			name:        "column and expression",
+			partitionBy: append(partitionByX, parity),
+			expected: []sql.WindowInterval{
+				{Start: 0, End: 3},
+				{Start: 3, End: 5},
+				{Start: 5, End: 7},
+				{Start: 7, End: 9},
+			},
+		},
+		{
+			name:        "expression and column",
+			partitionBy: []sql.Expression{parity, partitionByX[0]},
+			expected: []sql.WindowInterval{
+				{Start: 0, End: 3},
+				{Start: 3, End: 5},
+				{Start: 5, End: 7},
+				{Start: 7, End: 9},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := sql.NewEmptyContext()
+			i := NewWindowPartitionIter(
+				&WindowPartition{
+					PartitionBy: tt.partitionBy,
+				})
+			i.input = input
+			partitions, err := i.initializePartitions(ctx)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, partitions)
+		})
+	}
+}
+
 func TestWindowPartition_MaterializeOutput(t *testing.T) {
 	t.Run("non nil input", func(t *testing.T) {
 		ctx := sql.NewEmptyContext()
@@ -0,0 +1,203 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// Area is a function that returns the area of a polygon or multipolygon.
+type Area struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*Area)(nil)
+
+// NewArea creates a new ST_AREA expression.
+func NewArea(e sql.Expression) sql.Expression {
+	return &Area{expression.UnaryExpression{Child: e}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (a *Area) FunctionName() string {
+	return "st_area"
+}
+
+// Description implements sql.FunctionExpression
+func (a *Area) Description() string {
+	return "returns the area of the polygon or multipolygon."
+}
+
+// Type implements the sql.Expression interface.
+func (a *Area) Type() sql.Type {
+	return sql.Float64
+}
+
+func (a *Area) String() string {
+	return fmt.Sprintf("ST_AREA(%s)", a.Child.String())
+}
+
+// WithChildren implements the Expression interface.
+func (a *Area) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(a, len(children), 1)
+	}
+	return NewArea(children[0]), nil
+}
+
+// GeometryArea returns the area of a polygon or multipolygon. The area of the interior rings of a polygon is subtracted
+// from the area of its exterior ring, and the area of a multipolygon is the sum of the areas of its polygons. It
+// returns false for any other geometry.
+// TODO: geographic SRIDs are computed on the plane rather than on the ellipsoid
+func GeometryArea(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case sql.Polygon:
+		return polygonArea(v), true
+	case sql.MultiPolygon:
+		var area float64
+		for _, p := range v.Polygons {
+			area += polygonArea(p)
+		}
+		return area, true
+	case sql.Geometry:
+		return GeometryArea(v.Inner)
+	default:
+		return 0, false
+	}
+}
+
+// polygonArea returns the area of the exterior ring of a polygon minus the area of its interior rings.
+func polygonArea(p sql.Polygon) float64 {
+	var area float64
+	for i, l := range p.Lines {
+		ringArea, _, _ := ringCentroid(l.Points)
+		if i > 0 {
+			ringArea = -ringArea
+		}
+		area += ringArea
+	}
+	return area
+}
+
+// Eval implements the sql.Expression interface.
+func (a *Area) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := a.Child.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return nil if geometry is nil
+	if val == nil {
+		return nil, nil
+	}
+
+	area, ok := GeometryArea(val)
+	if !ok {
+		return nil, sql.ErrInvalidGISData.New(a.FunctionName())
+	}
+	return area, nil
+}
+
+// STLength is a function that returns the length of a linestring or multilinestring.
+type STLength struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*STLength)(nil)
+
+// NewSTLength creates a new ST_LENGTH expression.
+func NewSTLength(e sql.Expression) sql.Expression {
+	return &STLength{expression.UnaryExpression{Child: e}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (l *STLength) FunctionName() string {
+	return "st_length"
+}
+
+// Description implements sql.FunctionExpression
+func (l *STLength) Description() string {
+	return "returns the length of the linestring or multilinestring."
+}
+
+// Type implements the sql.Expression interface.
+func (l *STLength) Type() sql.Type {
+	return sql.Float64
+}
+
+func (l *STLength) String() string {
+	return fmt.Sprintf("ST_LENGTH(%s)", l.Child.String())
+}
+
+// WithChildren implements the Expression interface.
+func (l *STLength) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(l, len(children), 1)
+	}
+	return NewSTLength(children[0]), nil
+}
+
+// GeometryLength returns the length of a linestring or multilinestring, which is the sum of the lengths of its
+// linestrings. It returns false for any other geometry.
+// TODO: geographic SRIDs are computed on the plane rather than on the ellipsoid
+func GeometryLength(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case sql.Linestring:
+		return linestringLength(v), true
+	case sql.MultiLinestring:
+		var length float64
+		for _, l := range v.Lines {
+			length += linestringLength(l)
+		}
+		return length, true
+	case sql.Geometry:
+		return GeometryLength(v.Inner)
+	default:
+		return 0, false
+	}
+}
+
+// linestringLength returns the sum of the lengths of the segments of a linestring.
+func linestringLength(l sql.Linestring) float64 {
+	var length float64
+	for i := 1; i < len(l.Points); i++ {
+		a, b := l.Points[i-1], l.Points[i]
+		length += math.Hypot(b.X-a.X, b.Y-a.Y)
+	}
+	return length
+}
+
+// Eval implements the sql.Expression interface.
+func (l *STLength) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := l.Child.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return nil if geometry is nil
+	if val == nil {
+		return nil, nil
+	}
+
+	length, ok := GeometryLength(val)
+	if !ok {
+		return nil, sql.ErrInvalidGISData.New(l.FunctionName())
+	}
+	return length, nil
+}
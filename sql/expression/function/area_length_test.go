@@ -0,0 +1,114 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestArea(t *testing.T) {
+	square := func(x, y, size float64) sql.Linestring {
+		return sql.Linestring{Points: []sql.Point{{X: x, Y: y}, {X: x + size, Y: y}, {X: x + size, Y: y + size}, {X: x, Y: y + size}, {X: x, Y: y}}}
+	}
+
+	t.Run("unit square", func(t *testing.T) {
+		require := require.New(t)
+		f := NewArea(expression.NewLiteral(sql.Polygon{Lines: []sql.Linestring{square(0, 0, 1)}}, sql.PolygonType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(1.0, v)
+	})
+
+	t.Run("polygon with hole", func(t *testing.T) {
+		require := require.New(t)
+		poly := sql.Polygon{Lines: []sql.Linestring{square(0, 0, 4), square(1, 1, 2)}}
+		f := NewArea(expression.NewLiteral(poly, sql.PolygonType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(12.0, v)
+	})
+
+	t.Run("multipolygon of two unit squares", func(t *testing.T) {
+		require := require.New(t)
+		mpoly := sql.MultiPolygon{Polygons: []sql.Polygon{
+			{Lines: []sql.Linestring{square(0, 0, 1)}},
+			{Lines: []sql.Linestring{square(2, 2, 1)}},
+		}}
+		f := NewArea(expression.NewLiteral(mpoly, sql.MultiPolygonType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(2.0, v)
+	})
+
+	t.Run("multipolygon with holes", func(t *testing.T) {
+		require := require.New(t)
+		mpoly := sql.MultiPolygon{Polygons: []sql.Polygon{
+			{Lines: []sql.Linestring{square(0, 0, 4), square(1, 1, 2)}},
+			{Lines: []sql.Linestring{square(10, 10, 3), square(11, 11, 1)}},
+		}}
+		f := NewArea(expression.NewLiteral(sql.Geometry{Inner: mpoly}, sql.GeometryType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(20.0, v)
+	})
+
+	t.Run("linestring", func(t *testing.T) {
+		f := NewArea(expression.NewLiteral(square(0, 0, 1), sql.LinestringType{}))
+		_, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.True(t, sql.ErrInvalidGISData.Is(err))
+	})
+
+	t.Run("null", func(t *testing.T) {
+		require := require.New(t)
+		f := NewArea(expression.NewLiteral(nil, sql.Null))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Nil(v)
+	})
+}
+
+func TestSTLength(t *testing.T) {
+	t.Run("linestring", func(t *testing.T) {
+		require := require.New(t)
+		line := sql.Linestring{Points: []sql.Point{{X: 0, Y: 0}, {X: 3, Y: 4}, {X: 3, Y: 0}}}
+		f := NewSTLength(expression.NewLiteral(line, sql.LinestringType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(9.0, v)
+	})
+
+	t.Run("multilinestring", func(t *testing.T) {
+		require := require.New(t)
+		mline := sql.MultiLinestring{Lines: []sql.Linestring{
+			{Points: []sql.Point{{X: 0, Y: 0}, {X: 3, Y: 4}}},
+			{Points: []sql.Point{{X: 10, Y: 10}, {X: 10, Y: 12}, {X: 11, Y: 12}}},
+		}}
+		f := NewSTLength(expression.NewLiteral(sql.Geometry{Inner: mline}, sql.GeometryType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(8.0, v)
+	})
+
+	t.Run("point", func(t *testing.T) {
+		f := NewSTLength(expression.NewLiteral(sql.Point{X: 1, Y: 2}, sql.PointType{}))
+		_, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.True(t, sql.ErrInvalidGISData.Is(err))
+	})
+}
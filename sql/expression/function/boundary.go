@@ -0,0 +1,152 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// Boundary is a function that returns the boundary of a geometry
+type Boundary struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*Boundary)(nil)
+
+// NewBoundary creates a new ST_BOUNDARY expression.
+func NewBoundary(e sql.Expression) sql.Expression {
+	return &Boundary{expression.UnaryExpression{Child: e}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (b *Boundary) FunctionName() string {
+	return "st_boundary"
+}
+
+// Description implements sql.FunctionExpression
+func (b *Boundary) Description() string {
+	return "returns the boundary of the geometry given."
+}
+
+// IsNullable implements the sql.Expression interface.
+func (b *Boundary) IsNullable() bool {
+	return b.Child.IsNullable()
+}
+
+// Type implements the sql.Expression interface.
+func (b *Boundary) Type() sql.Type {
+	return sql.GeometryType{}
+}
+
+func (b *Boundary) String() string {
+	return fmt.Sprintf("ST_BOUNDARY(%s)", b.Child.String())
+}
+
+// WithChildren implements the Expression interface.
+func (b *Boundary) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(b, len(children), 1)
+	}
+	return NewBoundary(children[0]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (b *Boundary) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	// Evaluate child
+	val, err := b.Child.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return nil if geometry is nil
+	if val == nil {
+		return nil, nil
+	}
+
+	boundary, ok := geometryBoundary(val)
+	if !ok {
+		return nil, sql.ErrInvalidGISData.New("ST_BOUNDARY")
+	}
+	return boundary, nil
+}
+
+// geometryBoundary returns the boundary of a geometry. The boundary of a linestring is a multipoint of its endpoints,
+// which is empty when the linestring is closed, and the boundary of a polygon is a multilinestring of its rings.
+// Points have an empty geometry collection as their boundary. For a multilinestring, the endpoints shared by an even
+// number of its linestrings are not in its boundary. Returns false if the value is not a geometry, or is a geometry
+// collection, whose boundary is not defined.
+func geometryBoundary(v interface{}) (interface{}, bool) {
+	switch v := v.(type) {
+	case sql.Point:
+		return sql.GeometryCollection{SRID: v.SRID}, true
+	case sql.MultiPoint:
+		return sql.GeometryCollection{SRID: v.SRID}, true
+	case sql.Linestring:
+		return sql.MultiPoint{SRID: v.SRID, Points: lineEndpoints(v)}, true
+	case sql.MultiLinestring:
+		return sql.MultiPoint{SRID: v.SRID, Points: multiLineEndpoints(v)}, true
+	case sql.Polygon:
+		return sql.MultiLinestring{SRID: v.SRID, Lines: append([]sql.Linestring(nil), v.Lines...)}, true
+	case sql.MultiPolygon:
+		var lines []sql.Linestring
+		for _, p := range v.Polygons {
+			lines = append(lines, p.Lines...)
+		}
+		return sql.MultiLinestring{SRID: v.SRID, Lines: lines}, true
+	case sql.Geometry:
+		return geometryBoundary(v.Inner)
+	default:
+		return nil, false
+	}
+}
+
+// lineEndpoints returns the first and last points of a linestring, or none if the linestring is closed or empty.
+func lineEndpoints(l sql.Linestring) []sql.Point {
+	if len(l.Points) == 0 || l.Points[0].Equals(l.Points[len(l.Points)-1]) {
+		return nil
+	}
+	return []sql.Point{l.Points[0], l.Points[len(l.Points)-1]}
+}
+
+// multiLineEndpoints returns the endpoints of the linestrings of a multilinestring that are the endpoint of an odd
+// number of them, in the order they first appear.
+func multiLineEndpoints(m sql.MultiLinestring) []sql.Point {
+	var points []sql.Point
+	var counts []int
+	for _, l := range m.Lines {
+	endpoints:
+		for _, p := range lineEndpoints(l) {
+			for i := range points {
+				if points[i].Equals(p) {
+					counts[i]++
+					continue endpoints
+				}
+			}
+			points = append(points, p)
+			counts = append(counts, 1)
+		}
+	}
+
+	var boundary []sql.Point
+	for i, p := range points {
+		if counts[i]%2 == 1 {
+			boundary = append(boundary, p)
+		}
+	}
+	return boundary
+}
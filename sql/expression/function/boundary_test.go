@@ -0,0 +1,90 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestBoundary(t *testing.T) {
+	t.Run("open linestring has its endpoints", func(t *testing.T) {
+		require := require.New(t)
+		line := sql.Linestring{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 0}}}
+		f := NewBoundary(expression.NewLiteral(line, sql.LinestringType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.MultiPoint{Points: []sql.Point{{X: 0, Y: 0}, {X: 2, Y: 0}}}, v)
+	})
+
+	t.Run("closed linestring has an empty boundary", func(t *testing.T) {
+		require := require.New(t)
+		ring := sql.Linestring{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 0}, {X: 0, Y: 0}}}
+		f := NewBoundary(expression.NewLiteral(ring, sql.LinestringType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.MultiPoint{}, v)
+	})
+
+	t.Run("polygon has its rings", func(t *testing.T) {
+		require := require.New(t)
+		outer := sql.Linestring{Points: []sql.Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 0}}}
+		inner := sql.Linestring{Points: []sql.Point{{X: 1, Y: 1}, {X: 2, Y: 2}, {X: 3, Y: 1}, {X: 1, Y: 1}}}
+		poly := sql.Polygon{SRID: 4326, Lines: []sql.Linestring{outer, inner}}
+		f := NewBoundary(expression.NewLiteral(poly, sql.PolygonType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.MultiLinestring{SRID: 4326, Lines: []sql.Linestring{outer, inner}}, v)
+	})
+
+	t.Run("point has an empty boundary", func(t *testing.T) {
+		require := require.New(t)
+		f := NewBoundary(expression.NewLiteral(sql.Point{X: 1, Y: 2}, sql.PointType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.GeometryCollection{}, v)
+	})
+
+	t.Run("multilinestring endpoints shared by two lines are dropped", func(t *testing.T) {
+		require := require.New(t)
+		lines := sql.MultiLinestring{Lines: []sql.Linestring{
+			{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 1}}},
+			{Points: []sql.Point{{X: 1, Y: 1}, {X: 2, Y: 0}}},
+		}}
+		f := NewBoundary(expression.NewLiteral(lines, sql.MultiLinestringType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.MultiPoint{Points: []sql.Point{{X: 0, Y: 0}, {X: 2, Y: 0}}}, v)
+	})
+
+	t.Run("geometry collection is invalid", func(t *testing.T) {
+		require := require.New(t)
+		f := NewBoundary(expression.NewLiteral(sql.GeometryCollection{}, sql.GeometryCollectionType{}))
+		_, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.Error(err)
+	})
+
+	t.Run("null is null", func(t *testing.T) {
+		require := require.New(t)
+		f := NewBoundary(expression.NewLiteral(nil, sql.Null))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Nil(v)
+	})
+}
@@ -0,0 +1,603 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// defaultBufferSegments is the default number of segments used to approximate a quarter circle in ST_BUFFER
+const defaultBufferSegments = 8
+
+// Buffer is a function that returns a polygon approximating the points within a distance of a geometry.
+type Buffer struct {
+	expression.NaryExpression
+}
+
+var _ sql.FunctionExpression = (*Buffer)(nil)
+
+// NewBuffer creates a new ST_BUFFER expression.
+func NewBuffer(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) != 2 && len(args) != 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New("ST_BUFFER", "2 or 3", len(args))
+	}
+	return &Buffer{expression.NaryExpression{ChildExpressions: args}}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (b *Buffer) FunctionName() string {
+	return "st_buffer"
+}
+
+// Description implements sql.FunctionExpression
+func (b *Buffer) Description() string {
+	return "returns a polygon of the points whose distance from the geometry is less than or equal to the given distance."
+}
+
+// Type implements the sql.Expression interface.
+func (b *Buffer) Type() sql.Type {
+	return sql.GeometryType{}
+}
+
+func (b *Buffer) String() string {
+	var args = make([]string, len(b.ChildExpressions))
+	for i, arg := range b.ChildExpressions {
+		args[i] = arg.String()
+	}
+	return fmt.Sprintf("ST_BUFFER(%s)", strings.Join(args, ","))
+}
+
+// WithChildren implements the Expression interface.
+func (b *Buffer) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewBuffer(children...)
+}
+
+// Eval implements the sql.Expression interface.
+func (b *Buffer) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	g, err := b.ChildExpressions[0].Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return null if geometry is null
+	if g == nil {
+		return nil, nil
+	}
+
+	d, err := b.ChildExpressions[1].Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return null if distance is null
+	if d == nil {
+		return nil, nil
+	}
+
+	d, err = sql.Float64.Convert(d)
+	if err != nil {
+		return nil, err
+	}
+	distance := d.(float64)
+
+	segs := int64(defaultBufferSegments)
+	if len(b.ChildExpressions) == 3 {
+		s, err := b.ChildExpressions[2].Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if s == nil {
+			return nil, nil
+		}
+		s, err = sql.Int64.Convert(s)
+		if err != nil {
+			return nil, err
+		}
+		segs = s.(int64)
+		if segs < 1 {
+			return nil, sql.ErrInvalidArgumentDetails.New(b.FunctionName(), "the number of segments per quarter circle must be positive")
+		}
+	}
+
+	if geom, ok := g.(sql.Geometry); ok {
+		g = geom.Inner
+	}
+
+	switch g := g.(type) {
+	case sql.Point:
+		return BufferPoints([]sql.Point{g}, g.SRID, distance, int(segs)), nil
+	case sql.Linestring:
+		return BufferPoints(g.Points, g.SRID, distance, int(segs)), nil
+	case sql.Polygon:
+		return nil, sql.ErrUnsupportedFeature.New("ST_BUFFER of a polygon")
+	default:
+		return nil, sql.ErrInvalidGISData.New(b.FunctionName())
+	}
+}
+
+// BufferPoints returns a polygon approximating the points within [distance] of the point or linestring through
+// [points], using [segs] segments for every quarter circle. A point is buffered by a regular polygon. A linestring is
+// buffered by the union of a rectangle around each of its segments and a regular polygon around each of its points,
+// which gives it round caps and joins, and holes where it loops around. A zero distance returns the geometry itself, and
+// a negative distance returns an empty polygon.
+func BufferPoints(points []sql.Point, srid uint32, distance float64, segs int) interface{} {
+	if distance < 0 {
+		return sql.Polygon{SRID: srid}
+	}
+
+	path := dedupPoints(points, srid)
+	if len(path) == 0 {
+		return sql.Polygon{SRID: srid}
+	}
+	if distance == 0 {
+		if len(points) == 1 {
+			return path[0]
+		}
+		return sql.Linestring{SRID: srid, Points: append([]sql.Point(nil), points...)}
+	}
+
+	step := math.Pi / 2 / float64(segs)
+	if len(path) == 1 {
+		ring := bufferArc(path[0], distance, 0, -2*math.Pi, step)
+		ring = append(ring, ring[0])
+		return sql.Polygon{SRID: srid, Lines: []sql.Linestring{{SRID: srid, Points: ring}}}
+	}
+	return bufferPath(path, srid, distance, step)
+}
+
+// dedupPoints returns [points] without consecutive duplicates, with every point in the spatial reference system [srid]
+func dedupPoints(points []sql.Point, srid uint32) []sql.Point {
+	var res []sql.Point
+	for _, p := range points {
+		p.SRID = srid
+		if len(res) == 0 || !res[len(res)-1].Equals(p) {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+// bufferEdge is a directed edge of one of the convex parts of a buffer, which has the part on its left
+type bufferEdge struct {
+	from, to sql.Point
+	part     int
+}
+
+// bufferPath returns the union of the rectangles at [distance] around the segments of [path] and the regular polygons
+// around its points. The boundary of the union is made of the pieces of the edges of these convex parts, split where
+// they cross, that have no part on their outer side. The pieces are chained into rings: the outermost one is the
+// shell, clockwise like the buffer of a point, and the others are holes, counterclockwise.
+func bufferPath(path []sql.Point, srid uint32, distance, step float64) sql.Polygon {
+	scale := distance
+	for _, p := range path {
+		scale = math.Max(scale, math.Max(math.Abs(p.X), math.Abs(p.Y)))
+	}
+	tolerance := scale * 1e-14
+
+	// Every part is a convex polygon, counterclockwise and not closed
+	var parts [][]sql.Point
+	angles := make([]float64, len(path)-1)
+	for i := range angles {
+		a, b := path[i], path[i+1]
+		angles[i] = math.Atan2(b.Y-a.Y, b.X-a.X)
+		parts = append(parts, []sql.Point{
+			offsetPoint(a, distance, angles[i]-math.Pi/2),
+			offsetPoint(b, distance, angles[i]-math.Pi/2),
+			offsetPoint(b, distance, angles[i]+math.Pi/2),
+			offsetPoint(a, distance, angles[i]+math.Pi/2),
+		})
+	}
+	for i, p := range path {
+		// The corners of the adjacent rectangles are vertices of the polygon around a point, so that they join exactly
+		var corners []float64
+		if i > 0 {
+			corners = append(corners, angles[i-1]-math.Pi/2, angles[i-1]+math.Pi/2)
+		}
+		if i < len(angles) {
+			corners = append(corners, angles[i]-math.Pi/2, angles[i]+math.Pi/2)
+		}
+		parts = append(parts, bufferCircle(p, distance, step, corners))
+	}
+
+	boxes := make([]bufferBox, len(parts))
+	for i, part := range parts {
+		boxes[i] = newBufferBox(part...)
+	}
+
+	var boundary []bufferEdge
+	for _, e := range splitBufferEdges(parts, boxes, tolerance) {
+		covered := false
+		box := newBufferBox(e.from, e.to)
+		for i, part := range parts {
+			if i != e.part && boxes[i].overlaps(box, tolerance) && coversBufferEdge(part, e, tolerance) {
+				covered = true
+				break
+			}
+		}
+		if !covered && !containsBufferEdge(boundary, e) {
+			boundary = append(boundary, e)
+		}
+	}
+
+	var shell []sql.Point
+	var holes [][]sql.Point
+	shellArea := 0.0
+	for _, ring := range chainBufferEdges(boundary, tolerance) {
+		area := signedArea(ring)
+		switch {
+		case area > shellArea:
+			// the union of the parts is connected, so other counterclockwise rings are left over from rounding
+			shell, shellArea = ring, area
+		case area < -tolerance*scale:
+			holes = append(holes, ring)
+		}
+	}
+
+	poly := sql.Polygon{SRID: srid}
+	if shell == nil {
+		return poly
+	}
+	for _, ring := range append([][]sql.Point{shell}, holes...) {
+		line := sql.Linestring{SRID: srid}
+		for i := len(ring) - 1; i >= 0; i-- {
+			ring[i].SRID = srid
+			line.Points = append(line.Points, ring[i])
+		}
+		line.Points = append(line.Points, line.Points[0])
+		poly.Lines = append(poly.Lines, line)
+	}
+	return poly
+}
+
+// bufferCircle returns the regular polygon at [distance] around [center], counterclockwise from the angle 0 with at
+// most [step] radians between its vertices, with extra vertices at the angles [corners]. The regular vertices close to
+// a corner are left out, as the tiny edges they would make are hard to tell apart from the edges of the rectangles.
+func bufferCircle(center sql.Point, distance, step float64, corners []float64) []sql.Point {
+	var angles []float64
+	for _, a := range corners {
+		a = math.Mod(a, 2*math.Pi)
+		if a < 0 {
+			a += 2 * math.Pi
+		}
+		angles = append(angles, a)
+	}
+	n := int(math.Round(2 * math.Pi / step))
+regular:
+	for i := 0; i < n; i++ {
+		a := 2 * math.Pi * float64(i) / float64(n)
+		for _, c := range angles[:len(corners)] {
+			if d := math.Abs(a - c); d < step/4 || 2*math.Pi-d < step/4 {
+				continue regular
+			}
+		}
+		angles = append(angles, a)
+	}
+	sort.Float64s(angles)
+
+	var res []sql.Point
+	last := math.Inf(-1)
+	for _, a := range angles {
+		if a-last < step*1e-6 || 2*math.Pi-a < step*1e-6 {
+			continue
+		}
+		res = append(res, offsetPoint(center, distance, a))
+		last = a
+	}
+	return res
+}
+
+// bufferBox is the bounding box of some points
+type bufferBox struct {
+	minX, minY, maxX, maxY float64
+}
+
+// newBufferBox returns the bounding box of [points]
+func newBufferBox(points ...sql.Point) bufferBox {
+	b := bufferBox{minX: math.Inf(1), minY: math.Inf(1), maxX: math.Inf(-1), maxY: math.Inf(-1)}
+	for _, p := range points {
+		b.minX, b.maxX = math.Min(b.minX, p.X), math.Max(b.maxX, p.X)
+		b.minY, b.maxY = math.Min(b.minY, p.Y), math.Max(b.maxY, p.Y)
+	}
+	return b
+}
+
+// overlaps returns whether the boxes are less than [tolerance] apart
+func (b bufferBox) overlaps(o bufferBox, tolerance float64) bool {
+	return b.maxX >= o.minX-tolerance && o.maxX >= b.minX-tolerance &&
+		b.maxY >= o.minY-tolerance && o.maxY >= b.minY-tolerance
+}
+
+// splitBufferEdges returns the edges of the convex polygons [parts], split at every point where they meet the edges of
+// another part. Only the parts whose bounding [boxes] overlap are compared. Pieces shorter than [tolerance] are
+// dropped.
+func splitBufferEdges(parts [][]sql.Point, boxes []bufferBox, tolerance float64) []bufferEdge {
+	type edge struct {
+		bufferEdge
+		box    bufferBox
+		splits []sql.Point
+	}
+	edges := make([][]*edge, len(parts))
+	for i, part := range parts {
+		for j := range part {
+			e := bufferEdge{from: part[j], to: part[(j+1)%len(part)], part: i}
+			edges[i] = append(edges[i], &edge{bufferEdge: e, box: newBufferBox(e.from, e.to)})
+		}
+	}
+
+	for i := range parts {
+		for j := i + 1; j < len(parts); j++ {
+			if !boxes[i].overlaps(boxes[j], tolerance) {
+				continue
+			}
+			for _, e := range edges[i] {
+				for _, f := range edges[j] {
+					if !e.box.overlaps(f.box, tolerance) {
+						continue
+					}
+					for _, p := range edgeCrossings(e.bufferEdge, f.bufferEdge, tolerance) {
+						e.splits = append(e.splits, p)
+						f.splits = append(f.splits, p)
+					}
+				}
+			}
+		}
+	}
+
+	var res []bufferEdge
+	for _, partEdges := range edges {
+		for _, e := range partEdges {
+			dx, dy := e.to.X-e.from.X, e.to.Y-e.from.Y
+			position := func(p sql.Point) float64 {
+				return (p.X-e.from.X)*dx + (p.Y-e.from.Y)*dy
+			}
+			sort.Slice(e.splits, func(i, j int) bool {
+				return position(e.splits[i]) < position(e.splits[j])
+			})
+
+			from, pieces := e.from, len(res)
+			for _, p := range append(e.splits, e.to) {
+				if math.Hypot(p.X-from.X, p.Y-from.Y) <= tolerance {
+					continue
+				}
+				res = append(res, bufferEdge{from: from, to: p, part: e.part})
+				from = p
+			}
+			if len(res) > pieces && from != e.to {
+				// the last split point was within tolerance of the end of the edge
+				res[len(res)-1].to = e.to
+			}
+		}
+	}
+	return res
+}
+
+// edgeCrossings returns the points where the edges [e] and [f] meet, excluding their endpoints. Collinear edges meet
+// at the endpoints of each that are within the other.
+func edgeCrossings(e, f bufferEdge, tolerance float64) []sql.Point {
+	rx, ry := e.to.X-e.from.X, e.to.Y-e.from.Y
+	sx, sy := f.to.X-f.from.X, f.to.Y-f.from.Y
+	qx, qy := f.from.X-e.from.X, f.from.Y-e.from.Y
+	rLen, sLen := math.Hypot(rx, ry), math.Hypot(sx, sy)
+	denom := rx*sy - ry*sx
+
+	var res []sql.Point
+	if math.Abs(denom) <= 1e-12*rLen*sLen {
+		if math.Abs(qx*ry-qy*rx) > tolerance*rLen {
+			return nil
+		}
+		for _, c := range []struct {
+			p    sql.Point
+			edge bufferEdge
+		}{{f.from, e}, {f.to, e}, {e.from, f}, {e.to, f}} {
+			if withinEdge(c.edge, c.p, tolerance) {
+				res = append(res, c.p)
+			}
+		}
+		return res
+	}
+
+	t := (qx*sy - qy*sx) / denom
+	u := (qx*ry - qy*rx) / denom
+	tTol, uTol := tolerance/rLen, tolerance/sLen
+	if t < -tTol || t > 1+tTol || u < -uTol || u > 1+uTol {
+		return nil
+	}
+	p := sql.Point{X: e.from.X + t*rx, Y: e.from.Y + t*ry}
+	// A crossing at an endpoint of either edge is that endpoint, so that the pieces of both edges end at the same point
+	for _, end := range []sql.Point{e.from, e.to, f.from, f.to} {
+		if math.Hypot(p.X-end.X, p.Y-end.Y) <= tolerance {
+			p = end
+			break
+		}
+	}
+	return []sql.Point{p}
+}
+
+// withinEdge returns whether [p], which is on the line through the edge [e], is strictly between its endpoints
+func withinEdge(e bufferEdge, p sql.Point, tolerance float64) bool {
+	dx, dy := e.to.X-e.from.X, e.to.Y-e.from.Y
+	t := ((p.X-e.from.X)*dx + (p.Y-e.from.Y)*dy) / (dx*dx + dy*dy)
+	tTol := tolerance / math.Hypot(dx, dy)
+	return t > tTol && t < 1-tTol
+}
+
+// coversBufferEdge returns whether the counterclockwise convex polygon [part] covers the right side of the piece of
+// edge [e], which then isn't on the boundary of the buffer. The piece doesn't cross the edges of the part, so its
+// midpoint is either inside the part, outside it, or on an edge of the part that overlaps the piece. Overlapping edges
+// cover the piece when they go the other way, with the part on the right of the piece.
+func coversBufferEdge(part []sql.Point, e bufferEdge, tolerance float64) bool {
+	mid := sql.Point{X: (e.from.X + e.to.X) / 2, Y: (e.from.Y + e.to.Y) / 2}
+	onEdge := -1
+	for i, a := range part {
+		b := part[(i+1)%len(part)]
+		dx, dy := b.X-a.X, b.Y-a.Y
+		// the distance from the midpoint to the line through the edge, positive on the side of the part
+		dist := (dx*(mid.Y-a.Y) - dy*(mid.X-a.X)) / math.Hypot(dx, dy)
+		switch {
+		case dist < -tolerance:
+			return false
+		case dist <= tolerance:
+			onEdge = i
+		}
+	}
+	if onEdge < 0 {
+		return true
+	}
+	a, b := part[onEdge], part[(onEdge+1)%len(part)]
+	return (b.X-a.X)*(e.to.X-e.from.X)+(b.Y-a.Y)*(e.to.Y-e.from.Y) < 0
+}
+
+// containsBufferEdge returns whether [edges] has an edge with the same endpoints as [e], which happens when the
+// edges of two parts overlap.
+func containsBufferEdge(edges []bufferEdge, e bufferEdge) bool {
+	for _, f := range edges {
+		if f.from.X == e.from.X && f.from.Y == e.from.Y && f.to.X == e.to.X && f.to.Y == e.to.Y {
+			return true
+		}
+	}
+	return false
+}
+
+// chainBufferEdges joins [edges] into rings, which are not closed. Where several edges start at the end of another,
+// the ring takes the leftmost turn, which keeps it around the area it started on. Collinear points are dropped.
+func chainBufferEdges(edges []bufferEdge, tolerance float64) [][]sql.Point {
+	used := make([]bool, len(edges))
+	var rings [][]sql.Point
+	for start := range edges {
+		if used[start] {
+			continue
+		}
+		used[start] = true
+		ring := []sql.Point{edges[start].from}
+		cur := edges[start]
+		for math.Hypot(cur.to.X-ring[0].X, cur.to.Y-ring[0].Y) > tolerance {
+			next, turn := -1, math.Inf(-1)
+			for i, e := range edges {
+				if used[i] || math.Hypot(e.from.X-cur.to.X, e.from.Y-cur.to.Y) > tolerance {
+					continue
+				}
+				ax, ay := cur.to.X-cur.from.X, cur.to.Y-cur.from.Y
+				bx, by := e.to.X-e.from.X, e.to.Y-e.from.Y
+				if t := math.Atan2(ax*by-ay*bx, ax*bx+ay*by); t > turn {
+					next, turn = i, t
+				}
+			}
+			if next < 0 {
+				break
+			}
+			used[next] = true
+			ring = append(ring, edges[next].from)
+			cur = edges[next]
+		}
+		if math.Hypot(cur.to.X-ring[0].X, cur.to.Y-ring[0].Y) > tolerance {
+			// an open chain is left over from edges lost to rounding
+			continue
+		}
+
+		for _, loop := range splitPinchedRing(ring, tolerance) {
+			loop = dropCollinearPoints(loop, tolerance)
+			if len(loop) >= 3 {
+				rings = append(rings, loop)
+			}
+		}
+	}
+	return rings
+}
+
+// splitPinchedRing splits the unclosed [ring] into loops that don't pass through the same point twice, which happens
+// where a hole of the buffer touches its shell.
+func splitPinchedRing(ring []sql.Point, tolerance float64) [][]sql.Point {
+	var loops [][]sql.Point
+	var stack []sql.Point
+	for _, p := range ring {
+		for i, q := range stack {
+			if math.Hypot(p.X-q.X, p.Y-q.Y) <= tolerance {
+				loops = append(loops, append([]sql.Point(nil), stack[i:]...))
+				stack = stack[:i]
+				break
+			}
+		}
+		stack = append(stack, p)
+	}
+	return append(loops, stack)
+}
+
+// dropCollinearPoints returns the unclosed [ring] without the points on the straight line between their neighbours
+func dropCollinearPoints(ring []sql.Point, tolerance float64) []sql.Point {
+	for changed := true; changed && len(ring) >= 3; {
+		changed = false
+		var res []sql.Point
+		for i, b := range ring {
+			a, c := ring[(i+len(ring)-1)%len(ring)], ring[(i+1)%len(ring)]
+			if len(res) > 0 {
+				a = res[len(res)-1]
+			}
+			abx, aby := b.X-a.X, b.Y-a.Y
+			bcx, bcy := c.X-b.X, c.Y-b.Y
+			cross := abx*bcy - aby*bcx
+			if math.Abs(cross) <= tolerance*math.Hypot(c.X-a.X, c.Y-a.Y) && abx*bcx+aby*bcy >= 0 {
+				changed = true
+				continue
+			}
+			res = append(res, b)
+		}
+		ring = res
+	}
+	return ring
+}
+
+// signedArea returns the area of the unclosed [ring], which is positive when it is counterclockwise
+func signedArea(ring []sql.Point) float64 {
+	area := 0.0
+	for i, a := range ring {
+		b := ring[(i+1)%len(ring)]
+		area += a.X*b.Y - b.X*a.Y
+	}
+	return area / 2
+}
+
+// bufferArc returns the points at [distance] from [center], clockwise from the angle [from] to the angle [to], with
+// at most [step] radians between consecutive points. The point at [to] is excluded when the arc is a full circle.
+func bufferArc(center sql.Point, distance, from, to, step float64) []sql.Point {
+	for to > from {
+		to -= 2 * math.Pi
+	}
+
+	full := from-to >= 2*math.Pi
+	n := int(math.Ceil((from-to)/step - 1e-9))
+	if n < 1 {
+		n = 1
+	}
+
+	var res []sql.Point
+	for i := 0; i <= n; i++ {
+		if i == n && full {
+			break
+		}
+		res = append(res, offsetPoint(center, distance, from-(from-to)*float64(i)/float64(n)))
+	}
+	return res
+}
+
+// offsetPoint returns the point at [distance] from [p] in the direction of [angle]
+func offsetPoint(p sql.Point, distance, angle float64) sql.Point {
+	return sql.Point{SRID: p.SRID, X: p.X + distance*math.Cos(angle), Y: p.Y + distance*math.Sin(angle)}
+}
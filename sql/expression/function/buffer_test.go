@@ -0,0 +1,199 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// ringArea returns the unsigned area of a closed ring
+func ringArea(points []sql.Point) float64 {
+	var area float64
+	for i := 1; i < len(points); i++ {
+		area += points[i-1].X*points[i].Y - points[i].X*points[i-1].Y
+	}
+	return math.Abs(area / 2)
+}
+
+func TestBuffer(t *testing.T) {
+	t.Run("point with default segments", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewBuffer(expression.NewLiteral(sql.Point{X: 1, Y: 2}, sql.PointType{}), expression.NewLiteral(2, sql.Int32))
+		require.NoError(err)
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+
+		poly := v.(sql.Polygon)
+		require.Len(poly.Lines, 1)
+		ring := poly.Lines[0].Points
+		require.Len(ring, 4*defaultBufferSegments+1)
+		require.Equal(ring[0], ring[len(ring)-1])
+		require.True(isLinearRing(poly.Lines[0]))
+		for _, p := range ring {
+			require.InDelta(2, math.Hypot(p.X-1, p.Y-2), 1e-9)
+		}
+		require.InDelta(math.Pi*4, ringArea(ring), 0.2)
+	})
+
+	t.Run("point with segments", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewBuffer(
+			expression.NewLiteral(sql.Geometry{Inner: sql.Point{}}, sql.GeometryType{}),
+			expression.NewLiteral(1, sql.Int32),
+			expression.NewLiteral(32, sql.Int32),
+		)
+		require.NoError(err)
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+
+		ring := v.(sql.Polygon).Lines[0].Points
+		require.Len(ring, 4*32+1)
+		require.InDelta(math.Pi, ringArea(ring), 0.01)
+	})
+
+	t.Run("straight linestring", func(t *testing.T) {
+		require := require.New(t)
+		line := sql.Linestring{Points: []sql.Point{{X: 0, Y: 0}, {X: 5, Y: 0}, {X: 10, Y: 0}}}
+		f, err := NewBuffer(expression.NewLiteral(line, sql.LinestringType{}), expression.NewLiteral(1, sql.Int32), expression.NewLiteral(64, sql.Int32))
+		require.NoError(err)
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+
+		ring := v.(sql.Polygon).Lines[0]
+		require.True(isLinearRing(ring))
+		require.True(isSimpleLinestring(ring.Points))
+		// a 10x2 rectangle with two half circles of radius 1
+		require.InDelta(20+math.Pi, ringArea(ring.Points), 0.01)
+	})
+
+	t.Run("bent linestring", func(t *testing.T) {
+		require := require.New(t)
+		line := sql.Linestring{Points: []sql.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}}}
+		f, err := NewBuffer(expression.NewLiteral(line, sql.LinestringType{}), expression.NewLiteral(1, sql.Int32), expression.NewLiteral(64, sql.Int32))
+		require.NoError(err)
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+
+		ring := v.(sql.Polygon).Lines[0]
+		require.True(isLinearRing(ring))
+		require.True(isSimpleLinestring(ring.Points))
+		// two 10x2 rectangles overlapping in a 1x1 square, a quarter circle on the outer corner and two half circles at
+		// the ends
+		require.InDelta(40-1+math.Pi/4+math.Pi, ringArea(ring.Points), 0.01)
+	})
+
+	t.Run("reflex turn wider than its segments", func(t *testing.T) {
+		require := require.New(t)
+		line := sql.Linestring{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}}}
+		f, err := NewBuffer(expression.NewLiteral(line, sql.LinestringType{}), expression.NewLiteral(5, sql.Int32))
+		require.NoError(err)
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+
+		poly := v.(sql.Polygon)
+		require.True(isValidGeometry(poly))
+		require.Len(poly.Lines, 1)
+		for _, p := range poly.Lines[0].Points {
+			require.True(p.X >= -5 && p.X <= 6 && p.Y >= -5 && p.Y <= 6, "point %v is too far from the line", p)
+		}
+	})
+
+	t.Run("sharp turn", func(t *testing.T) {
+		require := require.New(t)
+		line := sql.Linestring{Points: []sql.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 0, Y: 0.1}}}
+		f, err := NewBuffer(expression.NewLiteral(line, sql.LinestringType{}), expression.NewLiteral(1, sql.Int32), expression.NewLiteral(64, sql.Int32))
+		require.NoError(err)
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+
+		poly := v.(sql.Polygon)
+		require.True(isValidGeometry(poly))
+		require.Len(poly.Lines, 1)
+		for _, p := range poly.Lines[0].Points {
+			require.True(p.X >= -1 && p.X <= 11 && p.Y >= -1 && p.Y <= 1.1, "point %v is too far from the line", p)
+		}
+		// the two 10x2 rectangles, nearly on top of each other, and a circle around the turn and the ends
+		require.InDelta(20+math.Pi, ringArea(poly.Lines[0].Points), 1)
+	})
+
+	t.Run("loop leaves a hole", func(t *testing.T) {
+		require := require.New(t)
+		line := sql.Linestring{Points: []sql.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}, {X: 0, Y: -1}}}
+		f, err := NewBuffer(expression.NewLiteral(line, sql.LinestringType{}), expression.NewLiteral(1, sql.Int32))
+		require.NoError(err)
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+
+		poly := v.(sql.Polygon)
+		require.True(isValidGeometry(poly))
+		require.Len(poly.Lines, 2)
+		require.InDelta(64, ringArea(poly.Lines[1].Points), 0.01)
+	})
+
+	t.Run("zero distance", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewBuffer(expression.NewLiteral(sql.Point{X: 1, Y: 2}, sql.PointType{}), expression.NewLiteral(0, sql.Int32))
+		require.NoError(err)
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Point{X: 1, Y: 2}, v)
+	})
+
+	t.Run("negative distance on a point", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewBuffer(expression.NewLiteral(sql.Point{X: 1, Y: 2}, sql.PointType{}), expression.NewLiteral(-1, sql.Int32))
+		require.NoError(err)
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Polygon{}, v)
+	})
+
+	t.Run("invalid segments", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewBuffer(expression.NewLiteral(sql.Point{}, sql.PointType{}), expression.NewLiteral(1, sql.Int32), expression.NewLiteral(0, sql.Int32))
+		require.NoError(err)
+		_, err = f.Eval(sql.NewEmptyContext(), nil)
+		require.Error(err)
+	})
+
+	t.Run("null is null", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewBuffer(expression.NewLiteral(nil, sql.Null), expression.NewLiteral(1, sql.Int32))
+		require.NoError(err)
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(nil, v)
+
+		f, err = NewBuffer(expression.NewLiteral(sql.Point{}, sql.PointType{}), expression.NewLiteral(nil, sql.Null))
+		require.NoError(err)
+		v, err = f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(nil, v)
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewBuffer(expression.NewLiteral(123, sql.Int64), expression.NewLiteral(1, sql.Int32))
+		require.NoError(err)
+		_, err = f.Eval(sql.NewEmptyContext(), nil)
+		require.Error(err)
+	})
+}
@@ -0,0 +1,181 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// Centroid is a function that returns the mathematical centroid of a geometry as a point.
+type Centroid struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*Centroid)(nil)
+
+// NewCentroid creates a new ST_CENTROID expression.
+func NewCentroid(e sql.Expression) sql.Expression {
+	return &Centroid{expression.UnaryExpression{Child: e}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (c *Centroid) FunctionName() string {
+	return "st_centroid"
+}
+
+// Description implements sql.FunctionExpression
+func (c *Centroid) Description() string {
+	return "returns the mathematical centroid of the geometry as a point."
+}
+
+// IsNullable implements the sql.Expression interface.
+func (c *Centroid) IsNullable() bool {
+	return c.Child.IsNullable()
+}
+
+// Type implements the sql.Expression interface.
+func (c *Centroid) Type() sql.Type {
+	return sql.PointType{}
+}
+
+func (c *Centroid) String() string {
+	return fmt.Sprintf("ST_CENTROID(%s)", c.Child.String())
+}
+
+// WithChildren implements the Expression interface.
+func (c *Centroid) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(c, len(children), 1)
+	}
+	return NewCentroid(children[0]), nil
+}
+
+// GeometryCentroid returns the centroid of a point, linestring or polygon. Polygons are weighted by area, with the
+// interior rings cut out of the exterior ring, and linestrings are weighted by the length of their segments. Geometries
+// without area or length return the average of their vertices, using the exterior ring of a polygon.
+func GeometryCentroid(v interface{}) (sql.Point, bool) {
+	switch v := v.(type) {
+	case sql.Point:
+		return v, true
+	case sql.Linestring:
+		return linestringCentroid(v), true
+	case sql.Polygon:
+		return polygonCentroid(v), true
+	case sql.Geometry:
+		return GeometryCentroid(v.Inner)
+	default:
+		return sql.Point{}, false
+	}
+}
+
+// linestringCentroid returns the average of the segment midpoints of a linestring, weighted by segment length.
+func linestringCentroid(l sql.Linestring) sql.Point {
+	var x, y, length float64
+	for i := 1; i < len(l.Points); i++ {
+		a, b := l.Points[i-1], l.Points[i]
+		d := math.Hypot(b.X-a.X, b.Y-a.Y)
+		x += d * (a.X + b.X) / 2
+		y += d * (a.Y + b.Y) / 2
+		length += d
+	}
+
+	if length == 0 {
+		return vertexAverage(l.SRID, l.Points)
+	}
+	return sql.Point{SRID: l.SRID, X: x / length, Y: y / length}
+}
+
+// polygonCentroid returns the area weighted centroid of a polygon's rings. The first ring is the exterior, and the
+// area of the remaining rings is removed from it.
+func polygonCentroid(p sql.Polygon) sql.Point {
+	var x, y, area float64
+	for i, l := range p.Lines {
+		ringArea, ringX, ringY := ringCentroid(l.Points)
+		if i > 0 {
+			ringArea = -ringArea
+		}
+		x += ringArea * ringX
+		y += ringArea * ringY
+		area += ringArea
+	}
+
+	if area == 0 {
+		if len(p.Lines) == 0 {
+			return sql.Point{SRID: p.SRID}
+		}
+		return vertexAverage(p.SRID, p.Lines[0].Points)
+	}
+	return sql.Point{SRID: p.SRID, X: x / area, Y: y / area}
+}
+
+// ringCentroid returns the unsigned area and the centroid of a closed ring using the shoelace formula.
+func ringCentroid(points []sql.Point) (float64, float64, float64) {
+	var x, y, area float64
+	for i := 1; i < len(points); i++ {
+		a, b := points[i-1], points[i]
+		cross := a.X*b.Y - b.X*a.Y
+		x += (a.X + b.X) * cross
+		y += (a.Y + b.Y) * cross
+		area += cross
+	}
+
+	if area == 0 {
+		return 0, 0, 0
+	}
+	area /= 2
+	return math.Abs(area), x / (6 * area), y / (6 * area)
+}
+
+// vertexAverage returns the average of the distinct vertices of a geometry. The closing point of a ring is not counted
+// twice.
+func vertexAverage(srid uint32, points []sql.Point) sql.Point {
+	if len(points) > 1 && points[0].Equals(points[len(points)-1]) {
+		points = points[:len(points)-1]
+	}
+	if len(points) == 0 {
+		return sql.Point{SRID: srid}
+	}
+
+	var x, y float64
+	for _, p := range points {
+		x += p.X
+		y += p.Y
+	}
+	n := float64(len(points))
+	return sql.Point{SRID: srid, X: x / n, Y: y / n}
+}
+
+// Eval implements the sql.Expression interface.
+func (c *Centroid) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := c.Child.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return nil if geometry is nil
+	if val == nil {
+		return nil, nil
+	}
+
+	centroid, ok := GeometryCentroid(val)
+	if !ok {
+		return nil, sql.ErrInvalidGISData.New(c.FunctionName())
+	}
+	return centroid, nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestCentroid(t *testing.T) {
+	t.Run("unit square", func(t *testing.T) {
+		require := require.New(t)
+		square := sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}, {X: 0, Y: 0}}}}}
+		f := NewCentroid(expression.NewLiteral(square, sql.PolygonType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Point{X: 0.5, Y: 0.5}, v)
+	})
+
+	t.Run("l-shaped polygon", func(t *testing.T) {
+		require := require.New(t)
+		l := sql.Polygon{SRID: GeoSpatialSRID, Lines: []sql.Linestring{{Points: []sql.Point{
+			{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 2}, {X: 0, Y: 2}, {X: 0, Y: 0},
+		}}}}
+		f := NewCentroid(expression.NewLiteral(sql.Geometry{Inner: l}, sql.GeometryType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		p := v.(sql.Point)
+		require.Equal(uint32(GeoSpatialSRID), p.SRID)
+		require.InDelta(5.0/6.0, p.X, 1e-12)
+		require.InDelta(5.0/6.0, p.Y, 1e-12)
+	})
+
+	t.Run("polygon with hole", func(t *testing.T) {
+		require := require.New(t)
+		poly := sql.Polygon{Lines: []sql.Linestring{
+			{Points: []sql.Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 4}, {X: 0, Y: 0}}},
+			{Points: []sql.Point{{X: 2, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 2, Y: 4}, {X: 2, Y: 0}}},
+		}}
+		f := NewCentroid(expression.NewLiteral(poly, sql.PolygonType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Point{X: 1, Y: 2}, v)
+	})
+
+	t.Run("zero area polygon", func(t *testing.T) {
+		require := require.New(t)
+		poly := sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 2}, {X: 0, Y: 0}}}}}
+		f := NewCentroid(expression.NewLiteral(poly, sql.PolygonType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Point{X: 1, Y: 1}, v)
+	})
+
+	t.Run("linestring", func(t *testing.T) {
+		require := require.New(t)
+		line := sql.Linestring{Points: []sql.Point{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1}}}
+		f := NewCentroid(expression.NewLiteral(line, sql.LinestringType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Point{X: (2*1 + 1*2) / 3.0, Y: (1 * 0.5) / 3.0}, v)
+	})
+
+	t.Run("point", func(t *testing.T) {
+		require := require.New(t)
+		f := NewCentroid(expression.NewLiteral(sql.Point{X: 1, Y: 2}, sql.PointType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Point{X: 1, Y: 2}, v)
+	})
+
+	t.Run("null is null", func(t *testing.T) {
+		require := require.New(t)
+		f := NewCentroid(expression.NewLiteral(nil, sql.Null))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(nil, v)
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		require := require.New(t)
+		f := NewCentroid(expression.NewLiteral(123, sql.Int64))
+		_, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.Error(err)
+	})
+}
@@ -0,0 +1,486 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// Contains is a function that returns whether a geometry contains another geometry.
+type Contains struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*Contains)(nil)
+
+// NewContains creates a new ST_CONTAINS expression.
+func NewContains(g1, g2 sql.Expression) sql.Expression {
+	return &Contains{
+		expression.BinaryExpression{
+			Left:  g1,
+			Right: g2,
+		},
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (c *Contains) FunctionName() string {
+	return "st_contains"
+}
+
+// Description implements sql.FunctionExpression
+func (c *Contains) Description() string {
+	return "returns 1 or 0 to indicate whether g1 completely contains g2."
+}
+
+// Type implements the sql.Expression interface.
+func (c *Contains) Type() sql.Type {
+	return sql.Boolean
+}
+
+func (c *Contains) String() string {
+	return fmt.Sprintf("ST_CONTAINS(%s,%s)", c.Left, c.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (c *Contains) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(c, len(children), 2)
+	}
+	return NewContains(children[0], children[1]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (c *Contains) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return evalSpatialPredicate(ctx, row, c.FunctionName(), c.Left, c.Right, GeometryContains)
+}
+
+// Within is a function that returns whether a geometry is within another geometry.
+type Within struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*Within)(nil)
+
+// NewWithin creates a new ST_WITHIN expression.
+func NewWithin(g1, g2 sql.Expression) sql.Expression {
+	return &Within{
+		expression.BinaryExpression{
+			Left:  g1,
+			Right: g2,
+		},
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (w *Within) FunctionName() string {
+	return "st_within"
+}
+
+// Description implements sql.FunctionExpression
+func (w *Within) Description() string {
+	return "returns 1 or 0 to indicate whether g1 is spatially within g2."
+}
+
+// Type implements the sql.Expression interface.
+func (w *Within) Type() sql.Type {
+	return sql.Boolean
+}
+
+func (w *Within) String() string {
+	return fmt.Sprintf("ST_WITHIN(%s,%s)", w.Left, w.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (w *Within) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(w, len(children), 2)
+	}
+	return NewWithin(children[0], children[1]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (w *Within) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return evalSpatialPredicate(ctx, row, w.FunctionName(), w.Right, w.Left, GeometryContains)
+}
+
+// evalSpatialPredicate evaluates [predicate] on the geometries of [left] and [right], which must have the same SRID.
+func evalSpatialPredicate(ctx *sql.Context, row sql.Row, fnName string, left, right sql.Expression, predicate func(g1, g2 interface{}) bool) (interface{}, error) {
+	g1, g2, _, err := evalGeometryPair(ctx, row, fnName, left, right)
+	if err != nil || g1 == nil || g2 == nil {
+		return nil, err
+	}
+	return predicate(g1, g2), nil
+}
+
+// evalGeometryPair evaluates the geometries of [left] and [right], and returns them with their SRID, which must be the
+// same. The geometries are nil if either is null.
+func evalGeometryPair(ctx *sql.Context, row sql.Row, fnName string, left, right sql.Expression) (interface{}, interface{}, uint32, error) {
+	g1, err := left.Eval(ctx, row)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	g2, err := right.Eval(ctx, row)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	// Return null if either geometry is null
+	if g1 == nil || g2 == nil {
+		return nil, nil, 0, nil
+	}
+
+	srid1, err := geometrySRID(g1)
+	if err != nil {
+		return nil, nil, 0, sql.ErrInvalidGISData.New(fnName)
+	}
+	srid2, err := geometrySRID(g2)
+	if err != nil {
+		return nil, nil, 0, sql.ErrInvalidGISData.New(fnName)
+	}
+	if srid1 != srid2 {
+		return nil, nil, 0, sql.ErrDiffSRIDs.New(fnName, srid1, srid2)
+	}
+
+	return g1, g2, srid1, nil
+}
+
+// pointLocation is the location of a point relative to a geometry.
+type pointLocation int
+
+const (
+	locationExterior pointLocation = iota
+	locationBoundary
+	locationInterior
+)
+
+// geometryParts is a geometry flattened into its points, linestrings and polygons.
+type geometryParts struct {
+	points   []sql.Point
+	lines    [][]sql.Point
+	polygons []sql.Polygon
+}
+
+// flattenGeometry returns the points, linestrings and polygons of a geometry, including the members of multi geometries
+// and geometry collections.
+func flattenGeometry(v interface{}) geometryParts {
+	var parts geometryParts
+	var flatten func(v interface{})
+	flatten = func(v interface{}) {
+		switch v := v.(type) {
+		case sql.Point:
+			parts.points = append(parts.points, v)
+		case sql.Linestring:
+			if len(v.Points) > 0 {
+				parts.lines = append(parts.lines, v.Points)
+			}
+		case sql.Polygon:
+			if len(v.Lines) > 0 {
+				parts.polygons = append(parts.polygons, v)
+			}
+		case sql.MultiPoint:
+			parts.points = append(parts.points, v.Points...)
+		case sql.MultiLinestring:
+			for _, l := range v.Lines {
+				flatten(l)
+			}
+		case sql.MultiPolygon:
+			for _, p := range v.Polygons {
+				flatten(p)
+			}
+		case sql.GeometryCollection:
+			for _, g := range v.Geoms {
+				flatten(g)
+			}
+		case sql.Geometry:
+			flatten(v.Inner)
+		}
+	}
+	flatten(v)
+	return parts
+}
+
+// segments returns every segment of the linestrings and polygon rings of the geometry.
+func (g geometryParts) segments() [][2]sql.Point {
+	var res [][2]sql.Point
+	add := func(points []sql.Point) {
+		for i := 1; i < len(points); i++ {
+			res = append(res, [2]sql.Point{points[i-1], points[i]})
+		}
+	}
+	for _, l := range g.lines {
+		add(l)
+	}
+	for _, p := range g.polygons {
+		for _, r := range p.Lines {
+			add(r.Points)
+		}
+	}
+	return res
+}
+
+// vertices returns every point of the geometry.
+func (g geometryParts) vertices() []sql.Point {
+	res := append([]sql.Point(nil), g.points...)
+	for _, l := range g.lines {
+		res = append(res, l...)
+	}
+	for _, p := range g.polygons {
+		for _, r := range p.Lines {
+			res = append(res, r.Points...)
+		}
+	}
+	return res
+}
+
+// locate returns the location of a point relative to the geometry. A point in the interior of any member of the
+// geometry is in its interior, and otherwise a point on the boundary of any member is on its boundary.
+func (g geometryParts) locate(p sql.Point) pointLocation {
+	loc := locationExterior
+	update := func(l pointLocation) {
+		if l > loc {
+			loc = l
+		}
+	}
+	for _, q := range g.points {
+		if q.X == p.X && q.Y == p.Y {
+			return locationInterior
+		}
+	}
+	for _, l := range g.lines {
+		update(linestringLocation(l, p))
+	}
+	for _, poly := range g.polygons {
+		update(polygonLocation(poly, p))
+	}
+	return loc
+}
+
+// linestringLocation returns the location of a point relative to a linestring, whose boundary is its endpoints unless
+// it is closed.
+func linestringLocation(points []sql.Point, p sql.Point) pointLocation {
+	first, last := points[0], points[len(points)-1]
+	closed := first.X == last.X && first.Y == last.Y
+	if !closed && ((p.X == first.X && p.Y == first.Y) || (p.X == last.X && p.Y == last.Y)) {
+		return locationBoundary
+	}
+	if len(points) == 1 {
+		return locationExterior
+	}
+	for i := 1; i < len(points); i++ {
+		if pointOnSegment(p, points[i-1], points[i]) {
+			return locationInterior
+		}
+	}
+	return locationExterior
+}
+
+// polygonLocation returns the location of a point relative to a polygon, whose boundary is its rings.
+func polygonLocation(poly sql.Polygon, p sql.Point) pointLocation {
+	for _, r := range poly.Lines {
+		for i := 1; i < len(r.Points); i++ {
+			if pointOnSegment(p, r.Points[i-1], r.Points[i]) {
+				return locationBoundary
+			}
+		}
+	}
+	if !pointInRing(poly.Lines[0].Points, p) {
+		return locationExterior
+	}
+	for _, hole := range poly.Lines[1:] {
+		if pointInRing(hole.Points, p) {
+			return locationExterior
+		}
+	}
+	return locationInterior
+}
+
+// pointInRing returns whether a point that isn't on a ring is enclosed by it, by counting the edges of the ring
+// crossed by a ray cast from the point in the positive x direction.
+func pointInRing(ring []sql.Point, p sql.Point) bool {
+	inside := false
+	for i := 1; i < len(ring); i++ {
+		a, b := ring[i-1], ring[i]
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < a.X+(p.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// pointOnSegment returns whether [p] is on the segment from [a] to [b], including its endpoints.
+func pointOnSegment(p, a, b sql.Point) bool {
+	return pointOrientation(a, b, p) == 0 &&
+		p.X >= math.Min(a.X, b.X) && p.X <= math.Max(a.X, b.X) &&
+		p.Y >= math.Min(a.Y, b.Y) && p.Y <= math.Max(a.Y, b.Y)
+}
+
+// splitSegment returns the points dividing the segment from [a] to [b] at its intersections with [segments] and at the
+// [vertices] on it, from [a] to [b]. Every piece between consecutive points is either entirely on one of the segments
+// or crosses none of them.
+func splitSegment(a, b sql.Point, segments [][2]sql.Point, vertices []sql.Point) []sql.Point {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	length := dx*dx + dy*dy
+	if length == 0 {
+		return []sql.Point{a}
+	}
+	ts := []float64{0, 1}
+	project := func(p sql.Point) {
+		if pointOnSegment(p, a, b) {
+			ts = append(ts, ((p.X-a.X)*dx+(p.Y-a.Y)*dy)/length)
+		}
+	}
+	for _, v := range vertices {
+		project(v)
+	}
+	for _, s := range segments {
+		c, d := s[0], s[1]
+		denom := dx*(d.Y-c.Y) - dy*(d.X-c.X)
+		if denom == 0 {
+			// parallel segments only meet where the endpoints of one are on the other
+			project(c)
+			project(d)
+			continue
+		}
+		t := ((c.X-a.X)*(d.Y-c.Y) - (c.Y-a.Y)*(d.X-c.X)) / denom
+		u := ((c.X-a.X)*dy - (c.Y-a.Y)*dx) / denom
+		if t > 0 && t < 1 && u >= 0 && u <= 1 {
+			ts = append(ts, t)
+		}
+	}
+
+	sort.Float64s(ts)
+	res := make([]sql.Point, 0, len(ts))
+	for i, t := range ts {
+		if i > 0 && t == ts[i-1] {
+			continue
+		}
+		res = append(res, sql.Point{SRID: a.SRID, X: a.X + t*dx, Y: a.Y + t*dy})
+	}
+	return res
+}
+
+// GeometryContains returns whether no point of g2 lies in the exterior of g1, and at least one point of the interior
+// of g2 lies in the interior of g1. The linestrings and polygon rings of g2 are split where they cross the segments of
+// g1, so that the location of every piece is the location of its midpoint.
+func GeometryContains(g1, g2 interface{}) bool {
+	dim1, ok1 := geometryDimension(g1)
+	dim2, ok2 := geometryDimension(g2)
+	if !ok1 || !ok2 || dim1 < 0 || dim2 < 0 || dim2 > dim1 {
+		return false
+	}
+
+	container, contained := flattenGeometry(g1), flattenGeometry(g2)
+	segments, vertices := container.segments(), container.vertices()
+
+	interiorsIntersect := false
+	covers := func(p sql.Point) bool {
+		loc := container.locate(p)
+		if loc == locationInterior {
+			interiorsIntersect = true
+		}
+		return loc != locationExterior
+	}
+	coversPath := func(points []sql.Point) bool {
+		for i := 1; i < len(points); i++ {
+			pieces := splitSegment(points[i-1], points[i], segments, vertices)
+			for j, p := range pieces {
+				if !covers(p) {
+					return false
+				}
+				if j > 0 {
+					mid := sql.Point{X: (p.X + pieces[j-1].X) / 2, Y: (p.Y + pieces[j-1].Y) / 2}
+					if !covers(mid) {
+						return false
+					}
+				}
+			}
+		}
+		return true
+	}
+
+	for _, p := range contained.points {
+		if !covers(p) {
+			return false
+		}
+	}
+	for _, l := range contained.lines {
+		if len(l) == 1 && !covers(l[0]) {
+			return false
+		}
+		if !coversPath(l) {
+			return false
+		}
+	}
+
+	containedSegments, containedVertices := contained.segments(), contained.vertices()
+	for _, poly := range contained.polygons {
+		for _, r := range poly.Lines {
+			if !coversPath(r.Points) {
+				return false
+			}
+		}
+
+		// the exterior and the holes of g1 must not reach into the polygon
+		for _, s := range segments {
+			pieces := splitSegment(s[0], s[1], containedSegments, containedVertices)
+			for j, p := range pieces {
+				if polygonLocation(poly, p) == locationInterior {
+					return false
+				}
+				if j > 0 {
+					mid := sql.Point{X: (p.X + pieces[j-1].X) / 2, Y: (p.Y + pieces[j-1].Y) / 2}
+					if polygonLocation(poly, mid) == locationInterior {
+						return false
+					}
+				}
+			}
+		}
+
+		if p, ok := polygonInteriorPoint(poly); ok && !covers(p) {
+			return false
+		}
+	}
+
+	return interiorsIntersect
+}
+
+// polygonInteriorPoint returns a point in the interior of a polygon, found just beside the middle of one of the edges
+// of its exterior ring. Returns false if no such point is found, as with polygons without area.
+func polygonInteriorPoint(poly sql.Polygon) (sql.Point, bool) {
+	ring := poly.Lines[0].Points
+	for i := 1; i < len(ring); i++ {
+		a, b := ring[i-1], ring[i]
+		dx, dy := b.X-a.X, b.Y-a.Y
+		if dx == 0 && dy == 0 {
+			continue
+		}
+		mid := sql.Point{X: (a.X + b.X) / 2, Y: (a.Y + b.Y) / 2}
+		eps := 1e-7
+		for _, side := range []float64{1, -1} {
+			p := sql.Point{X: mid.X - side*dy*eps, Y: mid.Y + side*dx*eps}
+			if polygonLocation(poly, p) == locationInterior {
+				return p, true
+			}
+		}
+	}
+	return sql.Point{}, false
+}
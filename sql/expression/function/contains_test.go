@@ -0,0 +1,111 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestContains(t *testing.T) {
+	ring := func(points ...float64) sql.Linestring {
+		var l sql.Linestring
+		for i := 0; i < len(points); i += 2 {
+			l.Points = append(l.Points, sql.Point{X: points[i], Y: points[i+1]})
+		}
+		return l
+	}
+	square := sql.Polygon{Lines: []sql.Linestring{ring(0, 0, 4, 0, 4, 4, 0, 4, 0, 0)}}
+	withHole := sql.Polygon{Lines: []sql.Linestring{ring(0, 0, 4, 0, 4, 4, 0, 4, 0, 0), ring(1, 1, 3, 1, 3, 3, 1, 3, 1, 1)}}
+	// a square with a notch cut into its top edge
+	notched := sql.Polygon{Lines: []sql.Linestring{ring(0, 0, 4, 0, 4, 4, 3, 4, 2, 1, 1, 4, 0, 4, 0, 0)}}
+	line := ring(0, 0, 2, 2, 4, 0)
+
+	tests := []struct {
+		name     string
+		g1, g2   interface{}
+		expected interface{}
+	}{
+		{"point in polygon", square, sql.Point{X: 1, Y: 1}, true},
+		{"point outside polygon", square, sql.Point{X: 5, Y: 1}, false},
+		{"point on polygon boundary", square, sql.Point{X: 0, Y: 1}, false},
+		{"point in polygon hole", withHole, sql.Point{X: 2, Y: 2}, false},
+		{"point between polygon rings", withHole, sql.Point{X: 0.5, Y: 2}, true},
+		{"point in notch", notched, sql.Point{X: 2, Y: 3}, false},
+		{"point on linestring", line, sql.Point{X: 1, Y: 1}, true},
+		{"point at linestring endpoint", line, sql.Point{X: 0, Y: 0}, false},
+		{"point at linestring vertex", line, sql.Point{X: 2, Y: 2}, true},
+		{"equal points", sql.Point{X: 1, Y: 1}, sql.Point{X: 1, Y: 1}, true},
+		{"different points", sql.Point{X: 1, Y: 1}, sql.Point{X: 1, Y: 2}, false},
+		{"linestring in polygon", square, ring(1, 1, 3, 3), true},
+		{"linestring on polygon boundary", square, ring(0, 0, 4, 0), false},
+		{"linestring touching polygon boundary", square, ring(0, 0, 2, 2), true},
+		{"linestring crossing polygon", square, ring(1, 1, 5, 1), false},
+		{"linestring crossing notch", notched, ring(0.5, 3, 3.5, 3), false},
+		{"linestring below notch", notched, ring(0.5, 0.5, 3.5, 0.5), true},
+		{"linestring crossing hole", withHole, ring(0.5, 2, 3.5, 2), false},
+		{"part of linestring", line, ring(1, 1, 2, 2, 3, 1), true},
+		{"linestring leaving linestring", line, ring(1, 1, 2, 2, 3, 3), false},
+		{"polygon in polygon", square, sql.Polygon{Lines: []sql.Linestring{ring(1, 1, 2, 1, 2, 2, 1, 1)}}, true},
+		{"equal polygons", square, square, true},
+		{"polygon sharing an edge", square, sql.Polygon{Lines: []sql.Linestring{ring(0, 0, 4, 0, 2, 2, 0, 0)}}, true},
+		{"polygon overlapping polygon", square, sql.Polygon{Lines: []sql.Linestring{ring(2, 2, 6, 2, 6, 6, 2, 2)}}, false},
+		{"polygon around polygon", sql.Polygon{Lines: []sql.Linestring{ring(1, 1, 2, 1, 2, 2, 1, 1)}}, square, false},
+		{"polygon around hole", withHole, square, false},
+		{"polygon filling hole", withHole, sql.Polygon{Lines: []sql.Linestring{ring(1, 1, 3, 1, 3, 3, 1, 3, 1, 1)}}, false},
+		{"polygon with hole in polygon", square, withHole, true},
+		{"polygon covering notch", notched, sql.Polygon{Lines: []sql.Linestring{ring(0.5, 0.5, 3.5, 0.5, 3.5, 3.5, 0.5, 3.5, 0.5, 0.5)}}, false},
+		{"polygon in linestring", line, square, false},
+		{"multipoint in polygon", square, sql.MultiPoint{Points: []sql.Point{{X: 1, Y: 1}, {X: 0, Y: 0}}}, true},
+		{"multipoint partly outside polygon", square, sql.MultiPoint{Points: []sql.Point{{X: 1, Y: 1}, {X: 5, Y: 5}}}, false},
+		{"point in multipolygon", sql.MultiPolygon{Polygons: []sql.Polygon{square, {Lines: []sql.Linestring{ring(10, 10, 11, 10, 11, 11, 10, 10)}}}}, sql.Point{X: 10.8, Y: 10.5}, true},
+		{"geometry", sql.Geometry{Inner: square}, sql.Geometry{Inner: sql.Point{X: 1, Y: 1}}, true},
+		{"empty geometry collection", square, sql.GeometryCollection{}, false},
+		{"null", nil, sql.Point{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			f := NewContains(expression.NewLiteral(tt.g1, sql.GeometryType{}), expression.NewLiteral(tt.g2, sql.GeometryType{}))
+			v, err := f.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+
+			f = NewWithin(expression.NewLiteral(tt.g2, sql.GeometryType{}), expression.NewLiteral(tt.g1, sql.GeometryType{}))
+			v, err = f.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+
+	t.Run("different srids", func(t *testing.T) {
+		require := require.New(t)
+		f := NewContains(expression.NewLiteral(square, sql.PolygonType{}), expression.NewLiteral(sql.Point{SRID: GeoSpatialSRID, X: 1, Y: 1}, sql.PointType{}))
+		_, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.True(sql.ErrDiffSRIDs.Is(err))
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		require := require.New(t)
+		f := NewContains(expression.NewLiteral(123, sql.Int64), expression.NewLiteral(sql.Point{}, sql.PointType{}))
+		_, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.True(sql.ErrInvalidGISData.Is(err))
+	})
+}
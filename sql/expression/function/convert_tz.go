@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -106,12 +107,6 @@ func (c *ConvertTz) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	}
 
 	converted, success := convertTimeZone(datetime, fromStr, toStr)
-	if success {
-		return sql.Datetime.ConvertWithoutRangeCheck(converted)
-	}
-
-	// If we weren't successful converting by timezone try converting via offsets.
-	converted, success = convertOffsets(datetime, fromStr, toStr)
 	if !success {
 		return nil, nil
 	}
@@ -119,41 +114,61 @@ func (c *ConvertTz) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	return sql.Datetime.ConvertWithoutRangeCheck(converted)
 }
 
-// convertTimeZone returns the conversion of t from timezone fromLocation to toLocation.
-func convertTimeZone(datetime time.Time, fromLocation string, toLocation string) (time.Time, bool) {
-	fLoc, err := time.LoadLocation(fromLocation)
-	if err != nil {
+// convertTimeZone returns the wall clock time in the time zone toTz at the instant when the wall clock in the time zone
+// fromTz reads datetime. Time zones are either named locations or offsets like +01:00. The offset of a named location
+// is the one in effect at the converted instant, so conversions across daylight saving time boundaries are exact.
+func convertTimeZone(datetime time.Time, fromTz string, toTz string) (time.Time, bool) {
+	fLoc, ok := timeZoneLocation(fromTz)
+	if !ok {
 		return time.Time{}, false
 	}
 
-	tLoc, err := time.LoadLocation(toLocation)
-	if err != nil {
+	tLoc, ok := timeZoneLocation(toTz)
+	if !ok {
 		return time.Time{}, false
 	}
 
-	delta := getCopy(datetime, fLoc).Sub(getCopy(datetime, tLoc))
-
-	return datetime.Add(delta), true
+	t := time.Date(datetime.Year(), datetime.Month(), datetime.Day(), datetime.Hour(), datetime.Minute(), datetime.Second(), datetime.Nanosecond(), fLoc).In(tLoc)
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC), true
 }
 
-// getCopy recreates the time t in the wanted timezone.
-func getCopy(t time.Time, loc *time.Location) time.Time {
-	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc).UTC()
-}
+// timeZoneLocation returns the location of a MySQL offset (ex +01:00) or of a named time zone, and a boolean indicating
+// whether the time zone is valid.
+func timeZoneLocation(tz string) (*time.Location, bool) {
+	if offset, err := getDeltaAsDuration(tz); err == nil {
+		return time.FixedZone(tz, int(offset.Seconds())), true
+	}
 
-// convertOffsets returns the conversion of t to t + (endDuration - startDuration) and a boolean indicating success.
-func convertOffsets(t time.Time, startDuration string, endDuration string) (time.Time, bool) {
-	fromDuration, err := getDeltaAsDuration(startDuration)
+	// time.LoadLocation treats the empty string as UTC
+	if tz == "" {
+		return nil, false
+	}
+	loc, err := time.LoadLocation(tz)
 	if err != nil {
-		return time.Time{}, false
+		return nil, false
 	}
+	return loc, true
+}
 
-	toDuration, err := getDeltaAsDuration(endDuration)
+// sessionTimeZoneLocation returns the location of the time_zone system variable of the session, resolving SYSTEM to the
+// system_time_zone. It's UTC without a session or when the time zone isn't a valid location.
+func sessionTimeZoneLocation(ctx *sql.Context) (*time.Location, error) {
+	if ctx == nil || ctx.Session == nil {
+		return time.UTC, nil
+	}
+	val, err := ctx.Session.GetSessionVariable(ctx, "time_zone")
 	if err != nil {
-		return time.Time{}, false
+		return nil, err
 	}
-
-	return t.Add(toDuration - fromDuration), true
+	tz, _ := val.(string)
+	if strings.EqualFold(tz, "SYSTEM") {
+		_, val, _ = sql.SystemVariables.GetGlobal("system_time_zone")
+		tz, _ = val.(string)
+	}
+	if loc, ok := timeZoneLocation(tz); ok {
+		return loc, nil
+	}
+	return time.UTC, nil
 }
 
 // getDeltaAsDuration takes in a MySQL offset in the format (ex +01:00) and returns it as a time Duration.
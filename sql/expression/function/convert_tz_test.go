@@ -117,6 +117,62 @@ func TestConvertTz(t *testing.T) {
 			toTimeZone:     "+10:00",
 			expectedResult: time.Date(2010, 6, 3, 23, 12, 12, 0, time.UTC),
 		},
+		{
+			name:           "Before the start of daylight saving time",
+			datetime:       "2021-03-14 06:30:00",
+			fromTimeZone:   "UTC",
+			toTimeZone:     "America/New_York",
+			expectedResult: time.Date(2021, 3, 14, 1, 30, 0, 0, time.UTC),
+		},
+		{
+			name:           "After the start of daylight saving time",
+			datetime:       "2021-03-14 07:30:00",
+			fromTimeZone:   "UTC",
+			toTimeZone:     "America/New_York",
+			expectedResult: time.Date(2021, 3, 14, 3, 30, 0, 0, time.UTC),
+		},
+		{
+			name:           "Before the end of daylight saving time",
+			datetime:       "2021-11-07 05:30:00",
+			fromTimeZone:   "+00:00",
+			toTimeZone:     "America/New_York",
+			expectedResult: time.Date(2021, 11, 7, 1, 30, 0, 0, time.UTC),
+		},
+		{
+			name:           "After the end of daylight saving time",
+			datetime:       "2021-11-07 06:30:00",
+			fromTimeZone:   "+00:00",
+			toTimeZone:     "America/New_York",
+			expectedResult: time.Date(2021, 11, 7, 1, 30, 0, 0, time.UTC),
+		},
+		{
+			name:           "Named time zone to offset during daylight saving time",
+			datetime:       "2021-07-01 12:00:00",
+			fromTimeZone:   "America/New_York",
+			toTimeZone:     "+00:00",
+			expectedResult: time.Date(2021, 7, 1, 16, 0, 0, 0, time.UTC),
+		},
+		{
+			name:           "Named time zones across different daylight saving time rules",
+			datetime:       "2021-03-20 12:00:00",
+			fromTimeZone:   "America/New_York",
+			toTimeZone:     "Europe/London",
+			expectedResult: time.Date(2021, 3, 20, 16, 0, 0, 0, time.UTC),
+		},
+		{
+			name:           "Invalid named time zone returns nil",
+			datetime:       "2021-03-20 12:00:00",
+			fromTimeZone:   "America/Nowhere",
+			toTimeZone:     "+00:00",
+			expectedResult: nil,
+		},
+		{
+			name:           "Empty time zone returns nil",
+			datetime:       "2021-03-20 12:00:00",
+			fromTimeZone:   "+00:00",
+			toTimeZone:     "",
+			expectedResult: nil,
+		},
 		{
 			name:           "No symbol on toTimeZone errors",
 			datetime:       time.Date(2010, 6, 3, 12, 12, 12, 0, time.UTC),
@@ -16,6 +16,7 @@ package function
 
 import (
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -317,8 +318,9 @@ func NewDatetime(args ...sql.Expression) (sql.Expression, error) {
 	return &DatetimeConversion{args[0]}, nil
 }
 
-// UnixTimestamp converts the argument to the number of seconds since 1970-01-01 00:00:00 UTC.
-// With no argument, returns number of seconds since unix epoch for the current time.
+// UnixTimestamp converts the argument, a datetime in the session time zone, to the number of seconds since
+// 1970-01-01 00:00:00 UTC. Datetimes before the epoch return 0. With no argument, returns number of seconds since unix
+// epoch for the current time.
 type UnixTimestamp struct {
 	Date sql.Expression
 }
@@ -390,7 +392,18 @@ func (ut *UnixTimestamp) Eval(ctx *sql.Context, row sql.Row) (interface{}, error
 		return nil, err
 	}
 
-	return toUnixTimestamp(date.(time.Time))
+	// the datetime is a wall clock time in the session time zone
+	loc, err := sessionTimeZoneLocation(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t := date.(time.Time)
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+	if t.Unix() < 0 {
+		return float64(0), nil
+	}
+
+	return toUnixTimestamp(t)
 }
 
 func toUnixTimestamp(t time.Time) (interface{}, error) {
@@ -405,45 +418,116 @@ func (ut *UnixTimestamp) String() string {
 	}
 }
 
-// FromUnixtime converts the argument to a datetime.
+// maxUnixTimestamp is the largest Unix timestamp FROM_UNIXTIME converts, 3001-01-19 03:14:07.999999 UTC.
+const maxUnixTimestamp = 32536771199
+
+// FromUnixtime converts a Unix timestamp to a datetime in the session time zone. With a format argument, the datetime
+// is formatted like DATE_FORMAT does.
 type FromUnixtime struct {
-	*UnaryFunc
+	Timestamp sql.Expression
+	Format    sql.Expression
 }
 
 var _ sql.FunctionExpression = (*FromUnixtime)(nil)
 
-func NewFromUnixtime(arg sql.Expression) sql.Expression {
-	return &FromUnixtime{NewUnaryFunc(arg, "FROM_UNIXTIME", sql.Datetime)}
+func NewFromUnixtime(args ...sql.Expression) (sql.Expression, error) {
+	switch len(args) {
+	case 1:
+		return &FromUnixtime{Timestamp: args[0]}, nil
+	case 2:
+		return &FromUnixtime{Timestamp: args[0], Format: args[1]}, nil
+	default:
+		return nil, sql.ErrInvalidArgumentNumber.New("FROM_UNIXTIME", "1 or 2", len(args))
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (r *FromUnixtime) FunctionName() string {
+	return "from_unixtime"
 }
 
 // Description implements sql.FunctionExpression
 func (r *FromUnixtime) Description() string {
-	return "formats Unix timestamp as a date."
+	return "formats Unix timestamp as a date, optionally in the given DATE_FORMAT format."
+}
+
+func (r *FromUnixtime) Children() []sql.Expression {
+	if r.Format != nil {
+		return []sql.Expression{r.Timestamp, r.Format}
+	}
+	return []sql.Expression{r.Timestamp}
+}
+
+func (r *FromUnixtime) Resolved() bool {
+	return r.Timestamp.Resolved() && (r.Format == nil || r.Format.Resolved())
+}
+
+func (r *FromUnixtime) IsNullable() bool {
+	return true
+}
+
+func (r *FromUnixtime) Type() sql.Type {
+	if r.Format != nil {
+		return sql.LongText
+	}
+	return sql.Datetime
 }
 
+// Eval implements sql.Expression. Like MySQL, it returns NULL for a negative timestamp or one past maxUnixTimestamp.
 func (r *FromUnixtime) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
-	val, err := r.EvalChild(ctx, row)
+	val, err := r.Timestamp.Eval(ctx, row)
 	if err != nil {
 		return nil, err
 	}
-
 	if val == nil {
 		return nil, nil
 	}
 
-	n, err := sql.Int64.Convert(val)
+	f, err := sql.Float64.Convert(val)
+	if err != nil {
+		return nil, err
+	}
+	ts := f.(float64)
+	if ts < 0 || ts >= maxUnixTimestamp+1 {
+		return nil, nil
+	}
+
+	sec := math.Floor(ts)
+	usec := math.Round((ts - sec) * 1e6)
+	loc, err := sessionTimeZoneLocation(ctx)
 	if err != nil {
 		return nil, err
 	}
+	t := time.Unix(int64(sec), int64(usec)*int64(time.Microsecond)).In(loc)
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+
+	if r.Format == nil {
+		return t, nil
+	}
 
-	return time.Unix(n.(int64), 0), nil
+	format, err := r.Format.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	if format == nil {
+		return nil, nil
+	}
+	formatStr, ok := format.(string)
+	if !ok {
+		return nil, sql.ErrInvalidArgumentDetails.New("FROM_UNIXTIME", "format must be a string")
+	}
+	return formatDate(formatStr, t)
 }
 
 func (r *FromUnixtime) WithChildren(children ...sql.Expression) (sql.Expression, error) {
-	if len(children) != 1 {
-		return nil, sql.ErrInvalidChildrenNumber.New(r, len(children), 1)
+	return NewFromUnixtime(children...)
+}
+
+func (r *FromUnixtime) String() string {
+	if r.Format != nil {
+		return fmt.Sprintf("FROM_UNIXTIME(%s, %s)", r.Timestamp, r.Format)
 	}
-	return NewFromUnixtime(children[0]), nil
+	return fmt.Sprintf("FROM_UNIXTIME(%s)", r.Timestamp)
 }
 
 type CurrDate struct {
@@ -23,6 +23,7 @@ import (
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/parse/dateparse"
 )
 
 func panicIfErr(err error) {
@@ -36,20 +37,7 @@ func monthNum(t time.Time) string {
 }
 
 func dayWithSuffix(t time.Time) string {
-	suffix := "th"
-	day := int64(t.Day())
-	if day < 4 || day > 20 {
-		switch day % 10 {
-		case 1:
-			suffix = "st"
-		case 2:
-			suffix = "nd"
-		case 3:
-			suffix = "rd"
-		}
-	}
-
-	return strconv.FormatInt(day, 10) + suffix
+	return strconv.Itoa(t.Day()) + dateparse.DaySuffix(t.Day())
 }
 
 func dayOfMonth(t time.Time) string {
@@ -94,7 +82,40 @@ func twentyFourHourNoPadding(t time.Time) string {
 }
 
 func fullMonthName(t time.Time) string {
-	return t.Month().String()
+	return dateparse.MonthName(t.Month())
+}
+
+func monthAbbreviation(t time.Time) string {
+	return dateparse.MonthAbbreviation(t.Month())
+}
+
+func ampmStr(t time.Time) string {
+	_, ampm := twelveHour(t)
+	return ampm
+}
+
+func twentyFourHourPadded(t time.Time) string {
+	return fmt.Sprintf("%02d", t.Hour())
+}
+
+func dayOfMonthPadded(t time.Time) string {
+	return fmt.Sprintf("%02d", t.Day())
+}
+
+func monthNumPadded(t time.Time) string {
+	return fmt.Sprintf("%02d", int(t.Month()))
+}
+
+func dayOfYearPadded(t time.Time) string {
+	return fmt.Sprintf("%03d", dateparse.DayOfYear(t))
+}
+
+func weekdayNum(t time.Time) string {
+	return strconv.Itoa(dateparse.WeekdayNumber(t.Weekday()))
+}
+
+func yearFourDigit(t time.Time) string {
+	return fmt.Sprintf("%04d", t.Year())
 }
 
 func ampmClockStr(t time.Time) string {
@@ -116,37 +137,19 @@ func yearWeek(mode int32, t time.Time) (int32, int32) {
 }
 
 func weekMode0(t time.Time) string {
-	yr, wk := yearWeek(0, t)
-
-	if yr < int32(t.Year()) {
-		wk = 0
-	} else if yr > int32(t.Year()) {
-		wk = 53
-	}
-
-	return fmt.Sprintf("%02d", wk)
+	return fmt.Sprintf("%02d", weekOfYear(t, 0))
 }
 
 func weekMode1(t time.Time) string {
-	yr, wk := yearWeek(1, t)
-
-	if yr < int32(t.Year()) {
-		wk = 0
-	} else if yr > int32(t.Year()) {
-		wk = 53
-	}
-
-	return fmt.Sprintf("%02d", wk)
+	return fmt.Sprintf("%02d", weekOfYear(t, 1))
 }
 
 func weekMode2(t time.Time) string {
-	_, wk := yearWeek(2, t)
-	return fmt.Sprintf("%02d", wk)
+	return fmt.Sprintf("%02d", weekOfYear(t, 2))
 }
 
 func weekMode3(t time.Time) string {
-	_, wk := yearWeek(3, t)
-	return fmt.Sprintf("%02d", wk)
+	return fmt.Sprintf("%02d", weekOfYear(t, 3))
 }
 
 func yearMode0(t time.Time) string {
@@ -160,7 +163,11 @@ func yearMode1(t time.Time) string {
 }
 
 func dayName(t time.Time) string {
-	return t.Weekday().String()
+	return dateparse.WeekdayName(t.Weekday())
+}
+
+func dayAbbreviation(t time.Time) string {
+	return dateparse.WeekdayAbbreviation(t.Weekday())
 }
 
 func yearTwoDigit(t time.Time) string {
@@ -182,23 +189,23 @@ func (af AppendFuncWrapper) Append(bytes []byte, t time.Time) []byte {
 
 var mysqlDateFormatSpec = strftime.NewSpecificationSet()
 var dateFormatSpecifierToFunc = map[byte]func(time.Time) string{
-	'a': nil,
-	'b': nil,
+	'a': dayAbbreviation,
+	'b': monthAbbreviation,
 	'c': monthNum,
 	'D': dayWithSuffix,
-	'd': nil,
+	'd': dayOfMonthPadded,
 	'e': dayOfMonth,
 	'f': microsecondsStr,
-	'H': nil,
+	'H': twentyFourHourPadded,
 	'h': twelveHourPadded,
 	'I': twelveHourPadded,
 	'i': minutesStr,
-	'j': nil,
+	'j': dayOfYearPadded,
 	'k': twentyFourHourNoPadding,
 	'l': twelveHourNoPadding,
 	'M': fullMonthName,
-	'm': nil,
-	'p': nil,
+	'm': monthNumPadded,
+	'p': ampmStr,
 	'r': ampmClockStr,
 	'S': nil,
 	's': secondsStr,
@@ -208,10 +215,10 @@ var dateFormatSpecifierToFunc = map[byte]func(time.Time) string{
 	'V': weekMode2,
 	'v': weekMode3,
 	'W': dayName,
-	'w': nil,
+	'w': weekdayNum,
 	'X': yearMode0,
 	'x': yearMode1,
-	'Y': nil,
+	'Y': yearFourDigit,
 	'y': yearTwoDigit,
 }
 
@@ -64,6 +64,7 @@ func TestDateFormatting(t *testing.T) {
 		{"%v", "06", false},          // Week where Monday is the first day of the week (01 to 53). Used with %X
 		{"%X", "2020", false},        // Year for the week where Sunday is the first day of the week. Used with %V
 		{"%x", "2020", false},        // Year for the week where Monday is the first day of the week. Used with %V
+		{"%W, %M %d %Y %H:%i:%s (day %j) %p", "Monday, February 03 2020 04:05:06 (day 034) AM", false},
 	}
 
 	for _, test := range tests {
@@ -81,6 +82,26 @@ func TestDateFormatting(t *testing.T) {
 	}
 }
 
+func TestTwelveHourFormatting(t *testing.T) {
+	tests := []struct {
+		dt       time.Time
+		expected string
+	}{
+		{time.Date(2021, 11, 29, 16, 7, 8, 0, time.UTC), "04:07 PM"},
+		{time.Date(2021, 11, 29, 0, 30, 0, 0, time.UTC), "12:30 AM"},
+		{time.Date(2021, 11, 29, 12, 0, 0, 0, time.UTC), "12:00 PM"},
+		{time.Date(2021, 11, 29, 9, 59, 0, 0, time.UTC), "09:59 AM"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.dt.String(), func(t *testing.T) {
+			result, err := formatDate("%h:%i %p", test.dt)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
 func TestUnsupportedSpecifiers(t *testing.T) {
 	testFunc := func(t *testing.T, b byte) {
 		if _, ok := dateFormatSpecifierToFunc[b]; !ok {
@@ -147,6 +147,20 @@ func TestUnixTimestamp(t *testing.T) {
 	result, err = ut.Eval(ctx, nil)
 	require.NoError(err)
 	require.Equal(expected, result)
+
+	ut, err = NewUnixTimestamp(expression.NewLiteral("1969-12-31 23:59:59", sql.LongText))
+	require.NoError(err)
+	result, err = ut.Eval(ctx, nil)
+	require.NoError(err)
+	require.Equal(float64(0), result)
+
+	// the datetime is in the session time zone
+	require.NoError(ctx.SetSessionVariable(ctx, "time_zone", "-06:00"))
+	ut, err = NewUnixTimestamp(expression.NewLiteral("2015-11-13 10:08:01", sql.LongText))
+	require.NoError(err)
+	result, err = ut.Eval(ctx, nil)
+	require.NoError(err)
+	require.Equal(float64(1447430881), result)
 }
 
 func TestFromUnixtime(t *testing.T) {
@@ -157,4 +171,103 @@ func TestFromUnixtime(t *testing.T) {
 
 	_, err = NewUnixTimestamp(expression.NewLiteral(1447430881, sql.Int64))
 	require.NoError(err)
+
+	_, err = NewFromUnixtime()
+	require.Error(err)
+
+	_, err = NewFromUnixtime(expression.NewLiteral(0, sql.Int64), expression.NewLiteral("%Y", sql.LongText), expression.NewLiteral("%Y", sql.LongText))
+	require.Error(err)
+
+	testCases := []struct {
+		name     string
+		args     []sql.Expression
+		timeZone string
+		expected interface{}
+	}{
+		{
+			name:     "epoch",
+			args:     []sql.Expression{expression.NewLiteral(int64(0), sql.Int64)},
+			expected: time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "timestamp",
+			args:     []sql.Expression{expression.NewLiteral(int64(1447430881), sql.Int64)},
+			expected: time.Date(2015, 11, 13, 16, 8, 1, 0, time.UTC),
+		},
+		{
+			name:     "fractional timestamp",
+			args:     []sql.Expression{expression.NewLiteral(1447430881.25, sql.Float64)},
+			expected: time.Date(2015, 11, 13, 16, 8, 1, 250000000, time.UTC),
+		},
+		{
+			name:     "session time zone",
+			args:     []sql.Expression{expression.NewLiteral(int64(1447430881), sql.Int64)},
+			timeZone: "-06:00",
+			expected: time.Date(2015, 11, 13, 10, 8, 1, 0, time.UTC),
+		},
+		{
+			name:     "formatted",
+			args:     []sql.Expression{expression.NewLiteral(int64(1447430881), sql.Int64), expression.NewLiteral("%Y %D %M %h:%i:%s %x", sql.LongText)},
+			timeZone: "-06:00",
+			expected: "2015 13th November 10:08:01 2015",
+		},
+		{
+			name:     "negative timestamp",
+			args:     []sql.Expression{expression.NewLiteral(int64(-1), sql.Int64)},
+			expected: nil,
+		},
+		{
+			name:     "timestamp past the maximum",
+			args:     []sql.Expression{expression.NewLiteral(int64(32536771200), sql.Int64)},
+			expected: nil,
+		},
+		{
+			name:     "null timestamp",
+			args:     []sql.Expression{expression.NewLiteral(nil, sql.Null)},
+			expected: nil,
+		},
+		{
+			name:     "null format",
+			args:     []sql.Expression{expression.NewLiteral(int64(0), sql.Int64), expression.NewLiteral(nil, sql.Null)},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range testCases {
+		ctx := sql.NewEmptyContext()
+		if tt.timeZone != "" {
+			require.NoError(ctx.SetSessionVariable(ctx, "time_zone", tt.timeZone))
+		}
+		f, err := NewFromUnixtime(tt.args...)
+		require.NoError(err)
+		result, err := f.Eval(ctx, nil)
+		require.NoError(err, tt.name)
+		require.Equal(tt.expected, result, tt.name)
+	}
+}
+
+func TestFromUnixtimeOfCurrentUnixTimestamp(t *testing.T) {
+	require := require.New(t)
+
+	date := time.Date(2021, time.March, 14, 1, 59, 26, 0, time.UTC)
+	var ctx *sql.Context
+	err := sql.RunWithNowFunc(func() time.Time { return date }, func() error {
+		ctx = sql.NewEmptyContext()
+		return nil
+	})
+	require.NoError(err)
+	require.NoError(ctx.SetSessionVariable(ctx, "time_zone", "+05:30"))
+
+	ut, err := NewUnixTimestamp()
+	require.NoError(err)
+	f, err := NewFromUnixtime(ut, expression.NewLiteral("%Y-%m-%d %H:%i:%s", sql.LongText))
+	require.NoError(err)
+
+	result, err := ut.Eval(ctx, nil)
+	require.NoError(err)
+	require.Equal(float64(date.Unix()), result)
+
+	result, err = f.Eval(ctx, nil)
+	require.NoError(err)
+	require.Equal("2021-03-14 07:29:26", result)
 }
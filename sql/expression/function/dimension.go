@@ -21,14 +21,14 @@ import (
 	"github.com/dolthub/go-mysql-server/sql/expression"
 )
 
-// Dimension is a function that converts a spatial type into WKT format (alias for AsText)
+// Dimension is a function that returns the topological dimension of a geometry
 type Dimension struct {
 	expression.UnaryExpression
 }
 
 var _ sql.FunctionExpression = (*Dimension)(nil)
 
-// NewDimension creates a new point expression.
+// NewDimension creates a new ST_DIMENSION expression.
 func NewDimension(e sql.Expression) sql.Expression {
 	return &Dimension{expression.UnaryExpression{Child: e}}
 }
@@ -50,7 +50,7 @@ func (p *Dimension) IsNullable() bool {
 
 // Type implements the sql.Expression interface.
 func (p *Dimension) Type() sql.Type {
-	return p.Child.Type()
+	return sql.Int32
 }
 
 func (p *Dimension) String() string {
@@ -78,26 +78,60 @@ func (p *Dimension) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, nil
 	}
 
-	// Expect one of the geometry types
-	switch val.(type) {
+	dim, ok := geometryDimension(val)
+	if !ok {
+		return nil, sql.ErrInvalidGISData.New("ST_DIMENSION")
+	}
+	return dim, nil
+}
+
+// geometryDimension returns the topological dimension of a geometry: 0 for points, 1 for linestrings and 2 for
+// polygons, along with their multi geometries. A geometry collection has the largest dimension of its members, and
+// empty geometries have dimension -1. Returns false if the value is not a geometry.
+func geometryDimension(v interface{}) (int, bool) {
+	switch v := v.(type) {
 	case sql.Point:
-		return 0, nil
+		return 0, true
 	case sql.Linestring:
-		return 1, nil
+		if len(v.Points) == 0 {
+			return -1, true
+		}
+		return 1, true
 	case sql.Polygon:
-		return 2, nil
-	case sql.Geometry:
-		switch val.(sql.Geometry).Inner.(type) {
-		case sql.Point:
-			return 0, nil
-		case sql.Linestring:
-			return 1, nil
-		case sql.Polygon:
-			return 2, nil
-		default:
-			return nil, sql.ErrInvalidGISData.New("ST_DIMENSION")
+		if len(v.Lines) == 0 {
+			return -1, true
 		}
+		return 2, true
+	case sql.MultiPoint:
+		if len(v.Points) == 0 {
+			return -1, true
+		}
+		return 0, true
+	case sql.MultiLinestring:
+		if len(v.Lines) == 0 {
+			return -1, true
+		}
+		return 1, true
+	case sql.MultiPolygon:
+		if len(v.Polygons) == 0 {
+			return -1, true
+		}
+		return 2, true
+	case sql.GeometryCollection:
+		dim := -1
+		for _, g := range v.Geoms {
+			d, ok := geometryDimension(g)
+			if !ok {
+				return 0, false
+			}
+			if d > dim {
+				dim = d
+			}
+		}
+		return dim, true
+	case sql.Geometry:
+		return geometryDimension(v.Inner)
 	default:
-		return nil, sql.ErrInvalidGISData.New("ST_DIMENSION")
+		return 0, false
 	}
 }
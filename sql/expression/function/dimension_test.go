@@ -86,4 +86,56 @@ func TestDimension(t *testing.T) {
 		require.NoError(err)
 		require.Equal(nil, v)
 	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		require := require.New(t)
+		f := NewDimension(expression.NewLiteral("POINT(1 2)", sql.LongText))
+		_, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.True(sql.ErrInvalidGISData.Is(err))
+	})
+
+	t.Run("multi geometries", func(t *testing.T) {
+		line := sql.Linestring{Points: []sql.Point{{}, {X: 1}}}
+		poly := sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{}, {X: 1}, {X: 1, Y: 1}, {}}}}}
+		testCases := []struct {
+			geom     interface{}
+			typ      sql.Type
+			expected interface{}
+		}{
+			{sql.MultiPoint{Points: []sql.Point{{}, {X: 1}}}, sql.MultiPointType{}, 0},
+			{sql.MultiLinestring{Lines: []sql.Linestring{line}}, sql.MultiLinestringType{}, 1},
+			{sql.MultiPolygon{Polygons: []sql.Polygon{poly}}, sql.MultiPolygonType{}, 2},
+			{sql.GeometryCollection{Geoms: []interface{}{sql.Point{}, line}}, sql.GeometryCollectionType{}, 1},
+			{sql.GeometryCollection{Geoms: []interface{}{sql.MultiPoint{Points: []sql.Point{{}}}, poly}}, sql.GeometryCollectionType{}, 2},
+		}
+		for _, tt := range testCases {
+			require := require.New(t)
+			f := NewDimension(expression.NewLiteral(tt.geom, tt.typ))
+			v, err := f.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		}
+	})
+
+	t.Run("empty geometries are dimension -1", func(t *testing.T) {
+		testCases := []struct {
+			geom interface{}
+			typ  sql.Type
+		}{
+			{sql.Linestring{}, sql.LinestringType{}},
+			{sql.Polygon{}, sql.PolygonType{}},
+			{sql.MultiPoint{}, sql.MultiPointType{}},
+			{sql.MultiLinestring{}, sql.MultiLinestringType{}},
+			{sql.MultiPolygon{}, sql.MultiPolygonType{}},
+			{sql.GeometryCollection{}, sql.GeometryCollectionType{}},
+			{sql.Geometry{Inner: sql.GeometryCollection{}}, sql.GeometryType{}},
+		}
+		for _, tt := range testCases {
+			require := require.New(t)
+			f := NewDimension(expression.NewLiteral(tt.geom, tt.typ))
+			v, err := f.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(err)
+			require.Equal(-1, v)
+		}
+	})
 }
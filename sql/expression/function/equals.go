@@ -0,0 +1,188 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// STEquals is a function that returns whether two geometries are spatially equal.
+type STEquals struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*STEquals)(nil)
+
+// NewSTEquals creates a new ST_EQUALS expression.
+func NewSTEquals(g1, g2 sql.Expression) sql.Expression {
+	return &STEquals{
+		expression.BinaryExpression{
+			Left:  g1,
+			Right: g2,
+		},
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *STEquals) FunctionName() string {
+	return "st_equals"
+}
+
+// Description implements sql.FunctionExpression
+func (s *STEquals) Description() string {
+	return "returns 1 or 0 to indicate whether g1 is spatially equal to g2."
+}
+
+// Type implements the sql.Expression interface.
+func (s *STEquals) Type() sql.Type {
+	return sql.Boolean
+}
+
+func (s *STEquals) String() string {
+	return fmt.Sprintf("ST_EQUALS(%s,%s)", s.Left, s.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (s *STEquals) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 2)
+	}
+	return NewSTEquals(children[0], children[1]), nil
+}
+
+// GeometriesEqual returns whether two geometries describe the same points of space. Linestrings are equal to their
+// reverse, polygon rings are equal when they trace the same boundary from any vertex and in either direction, and the
+// interior rings of polygons may be in any order.
+func GeometriesEqual(g1, g2 interface{}) bool {
+	if g, ok := g1.(sql.Geometry); ok {
+		g1 = g.Inner
+	}
+	if g, ok := g2.(sql.Geometry); ok {
+		g2 = g.Inner
+	}
+
+	switch g1 := g1.(type) {
+	case sql.Point:
+		g2, ok := g2.(sql.Point)
+		return ok && g1.Equals(g2)
+	case sql.Linestring:
+		g2, ok := g2.(sql.Linestring)
+		return ok && g1.SRID == g2.SRID && linestringsEqual(g1.Points, g2.Points)
+	case sql.Polygon:
+		g2, ok := g2.(sql.Polygon)
+		return ok && g1.SRID == g2.SRID && polygonsEqual(g1, g2)
+	default:
+		return false
+	}
+}
+
+// linestringsEqual returns whether two linestrings have the same points in the same or in reverse order.
+func linestringsEqual(l1, l2 []sql.Point) bool {
+	if len(l1) != len(l2) {
+		return false
+	}
+
+	forward, backward := true, true
+	for i := range l1 {
+		forward = forward && l1[i].Equals(l2[i])
+		backward = backward && l1[i].Equals(l2[len(l2)-1-i])
+	}
+	return forward || backward
+}
+
+// ringsEqual returns whether two closed rings trace the same boundary, starting from any vertex and in either
+// direction.
+func ringsEqual(r1, r2 []sql.Point) bool {
+	if len(r1) != len(r2) {
+		return false
+	}
+	if len(r1) < 2 {
+		return linestringsEqual(r1, r2)
+	}
+
+	// The last point of a ring repeats the first, so only the first n points are distinct vertices
+	n := len(r1) - 1
+	for offset := 0; offset < n; offset++ {
+		forward, backward := true, true
+		for i := 0; i < n && (forward || backward); i++ {
+			forward = forward && r1[i].Equals(r2[(offset+i)%n])
+			backward = backward && r1[i].Equals(r2[((offset-i)%n+n)%n])
+		}
+		if forward || backward {
+			return true
+		}
+	}
+	return false
+}
+
+// polygonsEqual returns whether two polygons have equal exterior rings and equal interior rings in any order.
+func polygonsEqual(p1, p2 sql.Polygon) bool {
+	if len(p1.Lines) != len(p2.Lines) {
+		return false
+	}
+	if len(p1.Lines) == 0 {
+		return true
+	}
+	if !ringsEqual(p1.Lines[0].Points, p2.Lines[0].Points) {
+		return false
+	}
+
+	matched := make([]bool, len(p2.Lines))
+	for _, r1 := range p1.Lines[1:] {
+		found := false
+		for j, r2 := range p2.Lines[1:] {
+			if !matched[j+1] && ringsEqual(r1.Points, r2.Points) {
+				matched[j+1] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Eval implements the sql.Expression interface.
+func (s *STEquals) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	g1, err := s.Left.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	g2, err := s.Right.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return null if either geometry is null
+	if g1 == nil || g2 == nil {
+		return nil, nil
+	}
+
+	for _, g := range []interface{}{g1, g2} {
+		switch g.(type) {
+		case sql.Point, sql.Linestring, sql.Polygon, sql.Geometry:
+		default:
+			return nil, sql.ErrInvalidGISData.New(s.FunctionName())
+		}
+	}
+
+	return GeometriesEqual(g1, g2), nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestSTEquals(t *testing.T) {
+	square := sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 4}, {X: 0, Y: 0}}}}}
+	hole1 := sql.Linestring{Points: []sql.Point{{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 2}, {X: 1, Y: 1}}}
+	hole2 := sql.Linestring{Points: []sql.Point{{X: 3, Y: 3}, {X: 3.5, Y: 3}, {X: 3.5, Y: 3.5}, {X: 3, Y: 3}}}
+	line := sql.Linestring{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 0}}}
+
+	tests := []struct {
+		name     string
+		g1, g2   interface{}
+		expected interface{}
+	}{
+		{
+			name:     "identical points",
+			g1:       sql.Point{X: 1, Y: 2},
+			g2:       sql.Point{X: 1, Y: 2},
+			expected: true,
+		},
+		{
+			name:     "different points",
+			g1:       sql.Point{X: 1, Y: 2},
+			g2:       sql.Point{X: 2, Y: 1},
+			expected: false,
+		},
+		{
+			name:     "identical linestrings",
+			g1:       line,
+			g2:       sql.Geometry{Inner: line},
+			expected: true,
+		},
+		{
+			name:     "reversed linestring",
+			g1:       line,
+			g2:       sql.Linestring{Points: []sql.Point{{X: 2, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 0}}},
+			expected: true,
+		},
+		{
+			name:     "reordered linestring",
+			g1:       line,
+			g2:       sql.Linestring{Points: []sql.Point{{X: 1, Y: 1}, {X: 0, Y: 0}, {X: 2, Y: 0}}},
+			expected: false,
+		},
+		{
+			name:     "identical polygons",
+			g1:       square,
+			g2:       square,
+			expected: true,
+		},
+		{
+			name:     "ring starting at a different vertex",
+			g1:       square,
+			g2:       sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{X: 4, Y: 4}, {X: 0, Y: 4}, {X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}}}}},
+			expected: true,
+		},
+		{
+			name:     "ring traced in the opposite direction",
+			g1:       square,
+			g2:       sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{X: 4, Y: 0}, {X: 0, Y: 0}, {X: 0, Y: 4}, {X: 4, Y: 4}, {X: 4, Y: 0}}}}},
+			expected: true,
+		},
+		{
+			name:     "ring tracing a different boundary",
+			g1:       square,
+			g2:       sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{X: 0, Y: 0}, {X: 4, Y: 4}, {X: 4, Y: 0}, {X: 0, Y: 4}, {X: 0, Y: 0}}}}},
+			expected: false,
+		},
+		{
+			name:     "reordered interior rings",
+			g1:       sql.Polygon{Lines: []sql.Linestring{square.Lines[0], hole1, hole2}},
+			g2:       sql.Polygon{Lines: []sql.Linestring{square.Lines[0], hole2, hole1}},
+			expected: true,
+		},
+		{
+			name:     "different interior rings",
+			g1:       sql.Polygon{Lines: []sql.Linestring{square.Lines[0], hole1, hole1}},
+			g2:       sql.Polygon{Lines: []sql.Linestring{square.Lines[0], hole1, hole2}},
+			expected: false,
+		},
+		{
+			name:     "different types",
+			g1:       sql.Point{X: 0, Y: 0},
+			g2:       sql.Linestring{Points: []sql.Point{{X: 0, Y: 0}}},
+			expected: false,
+		},
+		{
+			name:     "different srids",
+			g1:       sql.Point{X: 1, Y: 2},
+			g2:       sql.Point{SRID: GeoSpatialSRID, X: 1, Y: 2},
+			expected: false,
+		},
+		{
+			name:     "null",
+			g1:       nil,
+			g2:       sql.Point{X: 1, Y: 2},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			f := NewSTEquals(expression.NewLiteral(tt.g1, sql.GeometryType{}), expression.NewLiteral(tt.g2, sql.GeometryType{}))
+			v, err := f.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+
+	t.Run("wrong type", func(t *testing.T) {
+		require := require.New(t)
+		f := NewSTEquals(expression.NewLiteral(123, sql.Int64), expression.NewLiteral(sql.Point{}, sql.PointType{}))
+		_, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.Error(err)
+	})
+}
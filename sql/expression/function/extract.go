@@ -0,0 +1,228 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// ErrInvalidExtractUnit is returned when EXTRACT is given a unit it doesn't know.
+var ErrInvalidExtractUnit = errors.NewKind("invalid unit for EXTRACT: %s")
+
+// extractUnits returns, for every unit of EXTRACT that reads the date, the part of a datetime it extracts. A composite
+// unit, like YEAR_MONTH, concatenates the digits of its parts, so EXTRACT(YEAR_MONTH FROM '2021-03-01') is 202103.
+var extractUnits = map[string]func(ctx *sql.Context, t time.Time) (int64, error){
+	"YEAR":    func(_ *sql.Context, t time.Time) (int64, error) { return int64(t.Year()), nil },
+	"QUARTER": func(_ *sql.Context, t time.Time) (int64, error) { return int64(t.Month()+2) / 3, nil },
+	"MONTH":   func(_ *sql.Context, t time.Time) (int64, error) { return int64(t.Month()), nil },
+	"DAY":     func(_ *sql.Context, t time.Time) (int64, error) { return int64(t.Day()), nil },
+	"WEEK": func(ctx *sql.Context, t time.Time) (int64, error) {
+		mode, err := defaultWeekMode(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return int64(weekOfYear(t, mode)), nil
+	},
+	"YEAR_MONTH": func(_ *sql.Context, t time.Time) (int64, error) {
+		return int64(t.Year())*100 + int64(t.Month()), nil
+	},
+	"DAY_HOUR": func(_ *sql.Context, t time.Time) (int64, error) {
+		return int64(t.Day())*100 + int64(t.Hour()), nil
+	},
+	"DAY_MINUTE": func(_ *sql.Context, t time.Time) (int64, error) {
+		return int64(t.Day())*10000 + hourMinute(datetimeClock(t)), nil
+	},
+	"DAY_SECOND": func(_ *sql.Context, t time.Time) (int64, error) {
+		return int64(t.Day())*1000000 + hourSecond(datetimeClock(t)), nil
+	},
+	"DAY_MICROSECOND": func(_ *sql.Context, t time.Time) (int64, error) {
+		c := datetimeClock(t)
+		return (int64(t.Day())*1000000+hourSecond(c))*1000000 + c.microseconds, nil
+	},
+}
+
+// extractTimeUnits returns, for every unit of EXTRACT that only reads the time of day, the part of a clock it extracts.
+// These units read TIME values too, so EXTRACT(HOUR FROM '100:10:10') is 100.
+var extractTimeUnits = map[string]func(c clock) int64{
+	"HOUR":        func(c clock) int64 { return c.hours },
+	"MINUTE":      func(c clock) int64 { return c.minutes },
+	"SECOND":      func(c clock) int64 { return c.seconds },
+	"MICROSECOND": func(c clock) int64 { return c.microseconds },
+	"HOUR_MINUTE": hourMinute,
+	"HOUR_SECOND": hourSecond,
+	"HOUR_MICROSECOND": func(c clock) int64 {
+		return hourSecond(c)*1000000 + c.microseconds
+	},
+	"MINUTE_SECOND": func(c clock) int64 {
+		return c.minutes*100 + c.seconds
+	},
+	"MINUTE_MICROSECOND": func(c clock) int64 {
+		return (c.minutes*100+c.seconds)*1000000 + c.microseconds
+	},
+	"SECOND_MICROSECOND": func(c clock) int64 {
+		return c.seconds*1000000 + c.microseconds
+	},
+}
+
+// clock is the time of day of a datetime, or the magnitude of a TIME value, whose hours may be more than a day.
+type clock struct {
+	hours, minutes, seconds, microseconds int64
+}
+
+func datetimeClock(t time.Time) clock {
+	return clock{
+		hours:        int64(t.Hour()),
+		minutes:      int64(t.Minute()),
+		seconds:      int64(t.Second()),
+		microseconds: int64(t.Nanosecond()) / int64(time.Microsecond),
+	}
+}
+
+func durationClock(d time.Duration) clock {
+	if d < 0 {
+		d = -d
+	}
+	return clock{
+		hours:        int64(d / time.Hour),
+		minutes:      int64(d/time.Minute) % 60,
+		seconds:      int64(d/time.Second) % 60,
+		microseconds: int64(d/time.Microsecond) % 1000000,
+	}
+}
+
+func hourMinute(c clock) int64 {
+	return c.hours*100 + c.minutes
+}
+
+func hourSecond(c clock) int64 {
+	return hourMinute(c)*100 + c.seconds
+}
+
+// Extract is a function that returns a part of a datetime, given by a unit like YEAR or the composite HOUR_MINUTE. It is
+// only built by the parser from the EXTRACT(unit FROM expr) syntax, with the unit as a string literal.
+type Extract struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*Extract)(nil)
+
+// NewExtract creates a new Extract expression of the unit and the datetime given.
+func NewExtract(unit, date sql.Expression) sql.Expression {
+	return &Extract{expression.BinaryExpression{Left: unit, Right: date}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (e *Extract) FunctionName() string {
+	return "extract"
+}
+
+// Description implements sql.FunctionExpression
+func (e *Extract) Description() string {
+	return "returns the part of the date given by the unit."
+}
+
+func (e *Extract) String() string {
+	unit := e.Left.String()
+	if l, ok := e.Left.(*expression.Literal); ok {
+		if s, ok := l.Value().(string); ok {
+			unit = strings.ToUpper(s)
+		}
+	}
+	return fmt.Sprintf("EXTRACT(%s FROM %s)", unit, e.Right)
+}
+
+// Type implements the Expression interface.
+func (e *Extract) Type() sql.Type { return sql.Int64 }
+
+// Eval implements the Expression interface.
+func (e *Extract) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	unit, err := e.Left.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	if unit == nil {
+		return nil, nil
+	}
+	unitStr, ok := unit.(string)
+	if !ok {
+		return nil, ErrInvalidExtractUnit.New(unit)
+	}
+	unitStr = strings.ToUpper(unitStr)
+	if part, ok := extractTimeUnits[unitStr]; ok {
+		return e.evalTime(ctx, row, part)
+	}
+	part, ok := extractUnits[unitStr]
+	if !ok {
+		return nil, ErrInvalidExtractUnit.New(unitStr)
+	}
+
+	date, err := getDate(ctx, expression.UnaryExpression{Child: e.Right}, row)
+	if err != nil {
+		return nil, err
+	}
+	if date == nil {
+		return nil, nil
+	}
+
+	return part(ctx, date.(time.Time))
+}
+
+// evalTime returns the part of the time of day of the datetime, or of the TIME value, given by a unit of
+// extractTimeUnits. TIME values and strings that aren't datetimes are read as TIME, so that their hours may be negative
+// or more than a day. The part of a negative TIME value is negative.
+func (e *Extract) evalTime(ctx *sql.Context, row sql.Row, part func(c clock) int64) (interface{}, error) {
+	val, err := e.Right.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	if val == nil {
+		return nil, nil
+	}
+
+	readAsTime := e.Right.Type() == sql.Time
+	if _, ok := val.(string); ok && !readAsTime {
+		_, err = sql.Datetime.Convert(val)
+		readAsTime = err != nil
+	}
+	if readAsTime {
+		if d, err := sql.Time.ConvertToTimeDuration(val); err == nil {
+			if d < 0 {
+				return -part(durationClock(d)), nil
+			}
+			return part(durationClock(d)), nil
+		}
+	}
+
+	date, err := sql.Datetime.ConvertWithoutRangeCheck(val)
+	if err != nil {
+		date = sql.Datetime.Zero().(time.Time)
+	}
+	return part(datetimeClock(date)), nil
+}
+
+// WithChildren implements the Expression interface.
+func (e *Extract) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(e, len(children), 2)
+	}
+	return NewExtract(children[0], children[1]), nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestExtract(t *testing.T) {
+	datetime := time.Date(2021, time.March, 1, 13, 7, 9, 123456000, time.UTC)
+	testCases := []struct {
+		unit     interface{}
+		date     interface{}
+		expected interface{}
+		err      bool
+	}{
+		{"YEAR", datetime, int64(2021), false},
+		{"QUARTER", datetime, int64(1), false},
+		{"MONTH", datetime, int64(3), false},
+		{"WEEK", datetime, int64(9), false},
+		{"DAY", datetime, int64(1), false},
+		{"HOUR", datetime, int64(13), false},
+		{"MINUTE", datetime, int64(7), false},
+		{"SECOND", datetime, int64(9), false},
+		{"MICROSECOND", datetime, int64(123456), false},
+		{"YEAR_MONTH", datetime, int64(202103), false},
+		{"year_month", "2021-03-01", int64(202103), false},
+		{"DAY_HOUR", datetime, int64(113), false},
+		{"DAY_MINUTE", datetime, int64(11307), false},
+		{"DAY_SECOND", datetime, int64(1130709), false},
+		{"DAY_MICROSECOND", datetime, int64(1130709123456), false},
+		{"HOUR_MINUTE", datetime, int64(1307), false},
+		{"HOUR_SECOND", datetime, int64(130709), false},
+		{"HOUR_MICROSECOND", datetime, int64(130709123456), false},
+		{"MINUTE_SECOND", datetime, int64(709), false},
+		{"MINUTE_MICROSECOND", datetime, int64(709123456), false},
+		{"SECOND_MICROSECOND", datetime, int64(9123456), false},
+		{"QUARTER", "2021-12-31", int64(4), false},
+		{"DAY", nil, nil, false},
+		{nil, datetime, nil, false},
+		{"DECADE", datetime, nil, true},
+		{int64(1), datetime, nil, true},
+	}
+
+	for _, tt := range testCases {
+		f := NewExtract(
+			expression.NewLiteral(tt.unit, sql.LongText),
+			expression.NewLiteral(tt.date, sql.Datetime),
+		)
+		t.Run(f.String(), func(t *testing.T) {
+			val, err := f.Eval(sql.NewEmptyContext(), nil)
+			if tt.err {
+				require.Error(t, err)
+				require.True(t, ErrInvalidExtractUnit.Is(err))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, val)
+		})
+	}
+}
+
+func TestExtractTime(t *testing.T) {
+	testCases := []struct {
+		unit     string
+		time     interface{}
+		typ      sql.Type
+		expected interface{}
+	}{
+		{"HOUR", "10:10:10", sql.LongText, int64(10)},
+		{"HOUR", "100:10:10", sql.LongText, int64(100)},
+		{"MINUTE", "100:10:10", sql.LongText, int64(10)},
+		{"HOUR_MINUTE", "100:10:10", sql.LongText, int64(10010)},
+		{"HOUR_SECOND", "-10:20:30", sql.LongText, int64(-102030)},
+		{"SECOND_MICROSECOND", "10:10:10.5", sql.LongText, int64(10500000)},
+		{"HOUR", "2021-03-01 13:07:09", sql.LongText, int64(13)},
+		{"HOUR", "838:59:59", sql.Time, int64(838)},
+		{"MICROSECOND", "-00:00:01.25", sql.Time, int64(-250000)},
+		{"MINUTE_MICROSECOND", "25:01:02.000003", sql.Time, int64(102000003)},
+		{"HOUR", nil, sql.Time, nil},
+	}
+
+	for _, tt := range testCases {
+		f := NewExtract(
+			expression.NewLiteral(tt.unit, sql.LongText),
+			expression.NewLiteral(tt.time, tt.typ),
+		)
+		t.Run(f.String(), func(t *testing.T) {
+			val, err := f.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, val)
+		})
+	}
+}
@@ -0,0 +1,301 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	errors "gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// geoHashAlphabet is the base 32 alphabet of geohashes, which leaves out the letters a, i, l and o.
+const geoHashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// maxGeoHashLength is the longest geohash that can be encoded, like in MySQL.
+const maxGeoHashLength = 100
+
+var ErrInvalidGeoHash = errors.NewKind("invalid geohash value '%s' in function %s")
+
+// GeoHash is a function that returns the geohash of a geographic point.
+type GeoHash struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*GeoHash)(nil)
+
+// NewGeoHash creates a new ST_GEOHASH expression.
+func NewGeoHash(point, maxLength sql.Expression) sql.Expression {
+	return &GeoHash{
+		expression.BinaryExpression{
+			Left:  point,
+			Right: maxLength,
+		},
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (g *GeoHash) FunctionName() string {
+	return "st_geohash"
+}
+
+// Description implements sql.FunctionExpression
+func (g *GeoHash) Description() string {
+	return "returns the geohash of the given geographic point, with at most the given number of characters."
+}
+
+// Type implements the sql.Expression interface.
+func (g *GeoHash) Type() sql.Type {
+	return sql.LongText
+}
+
+func (g *GeoHash) String() string {
+	return fmt.Sprintf("ST_GEOHASH(%s,%s)", g.Left, g.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (g *GeoHash) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(g, len(children), 2)
+	}
+	return NewGeoHash(children[0], children[1]), nil
+}
+
+// EncodeGeoHash returns the geohash of the longitude and latitude given with [length] characters. Each character holds
+// five bits, which alternately halve the longitude and the latitude ranges, starting with the longitude.
+func EncodeGeoHash(longitude, latitude float64, length int) string {
+	minLon, maxLon := -180.0, 180.0
+	minLat, maxLat := -90.0, 90.0
+
+	var sb strings.Builder
+	even := true
+	for i := 0; i < length; i++ {
+		idx := 0
+		for bit := 0; bit < 5; bit++ {
+			idx <<= 1
+			if even {
+				mid := (minLon + maxLon) / 2
+				if longitude >= mid {
+					idx |= 1
+					minLon = mid
+				} else {
+					maxLon = mid
+				}
+			} else {
+				mid := (minLat + maxLat) / 2
+				if latitude >= mid {
+					idx |= 1
+					minLat = mid
+				} else {
+					maxLat = mid
+				}
+			}
+			even = !even
+		}
+		sb.WriteByte(geoHashAlphabet[idx])
+	}
+	return sb.String()
+}
+
+// DecodeGeoHash returns the longitude and latitude ranges of the cell of the geohash given. Geohashes are case
+// insensitive. It returns false if the geohash is empty or has a character outside of the geohash alphabet.
+func DecodeGeoHash(hash string) (minLon, maxLon, minLat, maxLat float64, ok bool) {
+	minLon, maxLon = -180.0, 180.0
+	minLat, maxLat = -90.0, 90.0
+	if len(hash) == 0 {
+		return 0, 0, 0, 0, false
+	}
+
+	even := true
+	for _, c := range strings.ToLower(hash) {
+		idx := strings.IndexRune(geoHashAlphabet, c)
+		if idx < 0 {
+			return 0, 0, 0, 0, false
+		}
+		for bit := 4; bit >= 0; bit-- {
+			set := idx&(1<<bit) != 0
+			if even {
+				mid := (minLon + maxLon) / 2
+				if set {
+					minLon = mid
+				} else {
+					maxLon = mid
+				}
+			} else {
+				mid := (minLat + maxLat) / 2
+				if set {
+					minLat = mid
+				} else {
+					maxLat = mid
+				}
+			}
+			even = !even
+		}
+	}
+	return minLon, maxLon, minLat, maxLat, true
+}
+
+// Eval implements the sql.Expression interface.
+func (g *GeoHash) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	p, err := g.Left.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return null if geometry is null
+	if p == nil {
+		return nil, nil
+	}
+
+	// Points stored in geometry columns are wrapped
+	if geom, ok := p.(sql.Geometry); ok {
+		p = geom.Inner
+	}
+
+	// Check that it is a point
+	_p, ok := p.(sql.Point)
+	if !ok {
+		return nil, ErrInvalidType.New(g.FunctionName())
+	}
+
+	if _p.SRID != GeoSpatialSRID {
+		return nil, ErrNonGeographic.New(g.FunctionName(), _p.SRID)
+	}
+	if _p.X < -180.0 || _p.X > 180.0 {
+		return nil, ErrLongitudeOutOfRange.New(_p.X, g.FunctionName())
+	}
+	if _p.Y < -90.0 || _p.Y > 90.0 {
+		return nil, ErrLatitudeOutOfRange.New(_p.Y, g.FunctionName())
+	}
+
+	maxLength, err := g.Right.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return null if max length is null
+	if maxLength == nil {
+		return nil, nil
+	}
+
+	maxLength, err = sql.Int64.Convert(maxLength)
+	if err != nil {
+		return nil, err
+	}
+
+	length := maxLength.(int64)
+	if length <= 0 || length > maxGeoHashLength {
+		return nil, sql.ErrInvalidArgumentDetails.New(g.FunctionName(), fmt.Sprintf("max geohash length %d", length))
+	}
+
+	return EncodeGeoHash(_p.X, _p.Y, int(length)), nil
+}
+
+// PointFromGeoHash is a function that returns the point at the center of the cell of a geohash.
+type PointFromGeoHash struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*PointFromGeoHash)(nil)
+
+// NewPointFromGeoHash creates a new ST_POINTFROMGEOHASH expression.
+func NewPointFromGeoHash(hash, srid sql.Expression) sql.Expression {
+	return &PointFromGeoHash{
+		expression.BinaryExpression{
+			Left:  hash,
+			Right: srid,
+		},
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (p *PointFromGeoHash) FunctionName() string {
+	return "st_pointfromgeohash"
+}
+
+// Description implements sql.FunctionExpression
+func (p *PointFromGeoHash) Description() string {
+	return "returns a point with the given SRID at the center of the cell of the given geohash."
+}
+
+// Type implements the sql.Expression interface.
+func (p *PointFromGeoHash) Type() sql.Type {
+	return sql.PointType{}
+}
+
+func (p *PointFromGeoHash) String() string {
+	return fmt.Sprintf("ST_POINTFROMGEOHASH(%s,%s)", p.Left, p.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (p *PointFromGeoHash) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(p, len(children), 2)
+	}
+	return NewPointFromGeoHash(children[0], children[1]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (p *PointFromGeoHash) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	hash, err := p.Left.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return null if geohash is null
+	if hash == nil {
+		return nil, nil
+	}
+
+	hash, err = sql.LongText.Convert(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	srid, err := p.Right.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return null if srid is null
+	if srid == nil {
+		return nil, nil
+	}
+
+	srid, err = sql.Int64.Convert(srid)
+	if err != nil {
+		return nil, err
+	}
+
+	if srid.(int64) < 0 || srid.(int64) > math.MaxUint32 {
+		return nil, sql.ErrInvalidArgumentDetails.New(p.FunctionName(), "SRID must be a non-negative 32-bit integer")
+	}
+
+	_srid := uint32(srid.(int64))
+	if _srid != CartesianSRID && _srid != GeoSpatialSRID {
+		return nil, ErrInvalidSRID.New(_srid)
+	}
+
+	minLon, maxLon, minLat, maxLat, ok := DecodeGeoHash(hash.(string))
+	if !ok {
+		return nil, ErrInvalidGeoHash.New(hash, p.FunctionName())
+	}
+
+	return sql.Point{SRID: _srid, X: (minLon + maxLon) / 2, Y: (minLat + maxLat) / 2}, nil
+}
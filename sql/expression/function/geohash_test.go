@@ -0,0 +1,159 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestGeoHash(t *testing.T) {
+	geoHash := func(p interface{}, maxLength interface{}) (interface{}, error) {
+		f := NewGeoHash(expression.NewLiteral(p, sql.PointType{}), expression.NewLiteral(maxLength, sql.Int64))
+		return f.Eval(sql.NewEmptyContext(), nil)
+	}
+
+	t.Run("known geohashes", func(t *testing.T) {
+		require := require.New(t)
+		v, err := geoHash(sql.Point{SRID: GeoSpatialSRID, X: -5.6, Y: 42.6}, 5)
+		require.NoError(err)
+		require.Equal("ezs42", v)
+
+		v, err = geoHash(sql.Point{SRID: GeoSpatialSRID, X: 180, Y: 0}, 10)
+		require.NoError(err)
+		require.Equal("xbpbpbpbpb", v)
+
+		v, err = geoHash(sql.Point{SRID: GeoSpatialSRID, X: -180, Y: -90}, 15)
+		require.NoError(err)
+		require.Equal("000000000000000", v)
+	})
+
+	t.Run("geometry wrapped point", func(t *testing.T) {
+		require := require.New(t)
+		v, err := geoHash(sql.Geometry{Inner: sql.Point{SRID: GeoSpatialSRID, X: -5.6, Y: 42.6}}, 5)
+		require.NoError(err)
+		require.Equal("ezs42", v)
+	})
+
+	t.Run("cartesian point", func(t *testing.T) {
+		_, err := geoHash(sql.Point{X: 1, Y: 2}, 5)
+		require.True(t, ErrNonGeographic.Is(err))
+	})
+
+	t.Run("longitude out of range", func(t *testing.T) {
+		_, err := geoHash(sql.Point{SRID: GeoSpatialSRID, X: 181, Y: 2}, 5)
+		require.True(t, ErrLongitudeOutOfRange.Is(err))
+	})
+
+	t.Run("latitude out of range", func(t *testing.T) {
+		_, err := geoHash(sql.Point{SRID: GeoSpatialSRID, X: 1, Y: -91}, 5)
+		require.True(t, ErrLatitudeOutOfRange.Is(err))
+	})
+
+	t.Run("invalid max length", func(t *testing.T) {
+		_, err := geoHash(sql.Point{SRID: GeoSpatialSRID, X: 1, Y: 2}, 0)
+		require.True(t, sql.ErrInvalidArgumentDetails.Is(err))
+		_, err = geoHash(sql.Point{SRID: GeoSpatialSRID, X: 1, Y: 2}, 101)
+		require.True(t, sql.ErrInvalidArgumentDetails.Is(err))
+	})
+
+	t.Run("null arguments", func(t *testing.T) {
+		require := require.New(t)
+		v, err := geoHash(nil, 5)
+		require.NoError(err)
+		require.Nil(v)
+		v, err = geoHash(sql.Point{SRID: GeoSpatialSRID, X: 1, Y: 2}, nil)
+		require.NoError(err)
+		require.Nil(v)
+	})
+}
+
+func TestPointFromGeoHash(t *testing.T) {
+	pointFromGeoHash := func(hash interface{}, srid interface{}) (interface{}, error) {
+		f := NewPointFromGeoHash(expression.NewLiteral(hash, sql.LongText), expression.NewLiteral(srid, sql.Int64))
+		return f.Eval(sql.NewEmptyContext(), nil)
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		points := []sql.Point{
+			{SRID: GeoSpatialSRID, X: -5.6, Y: 42.6},
+			{SRID: GeoSpatialSRID, X: 139.6917, Y: 35.6895},
+			{SRID: GeoSpatialSRID, X: -122.4194, Y: 37.7749},
+			{SRID: GeoSpatialSRID, X: 180, Y: 90},
+			{SRID: GeoSpatialSRID, X: -180, Y: -90},
+		}
+		for _, p := range points {
+			for _, length := range []int{1, 3, 6, 10, 20} {
+				require := require.New(t)
+				hash := EncodeGeoHash(p.X, p.Y, length)
+				require.Len(hash, length)
+
+				v, err := pointFromGeoHash(hash, GeoSpatialSRID)
+				require.NoError(err)
+				decoded := v.(sql.Point)
+				require.Equal(uint32(GeoSpatialSRID), decoded.SRID)
+
+				minLon, maxLon, minLat, maxLat, ok := DecodeGeoHash(hash)
+				require.True(ok)
+				require.True(minLon <= p.X && p.X <= maxLon, "point %v is outside of cell of %s", p, hash)
+				require.True(minLat <= p.Y && p.Y <= maxLat, "point %v is outside of cell of %s", p, hash)
+				require.True(minLon <= decoded.X && decoded.X <= maxLon, "decoded %v is outside of cell of %s", decoded, hash)
+				require.True(minLat <= decoded.Y && decoded.Y <= maxLat, "decoded %v is outside of cell of %s", decoded, hash)
+			}
+		}
+	})
+
+	t.Run("cell center", func(t *testing.T) {
+		require := require.New(t)
+		v, err := pointFromGeoHash("s", CartesianSRID)
+		require.NoError(err)
+		require.Equal(sql.Point{X: 22.5, Y: 22.5}, v)
+	})
+
+	t.Run("upper case", func(t *testing.T) {
+		require := require.New(t)
+		v, err := pointFromGeoHash("EZS42", GeoSpatialSRID)
+		require.NoError(err)
+		expected, err := pointFromGeoHash("ezs42", GeoSpatialSRID)
+		require.NoError(err)
+		require.Equal(expected, v)
+	})
+
+	t.Run("invalid geohash", func(t *testing.T) {
+		_, err := pointFromGeoHash("ezs4a", GeoSpatialSRID)
+		require.True(t, ErrInvalidGeoHash.Is(err))
+		_, err = pointFromGeoHash("", GeoSpatialSRID)
+		require.True(t, ErrInvalidGeoHash.Is(err))
+	})
+
+	t.Run("invalid srid", func(t *testing.T) {
+		_, err := pointFromGeoHash("ezs42", 1234)
+		require.True(t, ErrInvalidSRID.Is(err))
+	})
+
+	t.Run("null arguments", func(t *testing.T) {
+		require := require.New(t)
+		v, err := pointFromGeoHash(nil, GeoSpatialSRID)
+		require.NoError(err)
+		require.Nil(v)
+		v, err = pointFromGeoHash("ezs42", nil)
+		require.NoError(err)
+		require.Nil(v)
+	})
+}
@@ -181,7 +181,7 @@ func TestGeomFromGeoJSON(t *testing.T) {
 
 		v, err := f.Eval(sql.NewEmptyContext(), nil)
 		require.NoError(err)
-		require.Equal(sql.Linestring{SRID: 4326, Points: []sql.Point{{4326, 2, 1}, {4326, 4, 3}}}, v)
+		require.Equal(sql.Linestring{SRID: 4326, Points: []sql.Point{{SRID: 4326, X: 2, Y: 1}, {SRID: 4326, X: 4, Y: 3}}}, v)
 	})
 	t.Run("convert polygon to geojson", func(t *testing.T) {
 		require := require.New(t)
@@ -190,7 +190,7 @@ func TestGeomFromGeoJSON(t *testing.T) {
 
 		v, err := f.Eval(sql.NewEmptyContext(), nil)
 		require.NoError(err)
-		require.Equal(sql.Polygon{SRID: 4326, Lines: []sql.Linestring{{4326, []sql.Point{{4326, 0, 0}, {4326, 1, 1}, {4326, 1, 0}, {4326, 0, 0}}}}}, v)
+		require.Equal(sql.Polygon{SRID: 4326, Lines: []sql.Linestring{{4326, []sql.Point{{SRID: 4326, X: 0, Y: 0}, {SRID: 4326, X: 1, Y: 1}, {SRID: 4326, X: 1, Y: 0}, {SRID: 4326, X: 0, Y: 0}}}}}, v)
 	})
 	t.Run("reject dimensions greater than 2 with flag 1", func(t *testing.T) {
 		require := require.New(t)
@@ -212,7 +212,7 @@ func TestGeomFromGeoJSON(t *testing.T) {
 		require.NoError(err)
 
 		v, err := f.Eval(sql.NewEmptyContext(), nil)
-		require.Equal(sql.Polygon{SRID: 4326, Lines: []sql.Linestring{{4326, []sql.Point{{4326, 0, 0}, {4326, 1, 1}, {4326, 1, 0}, {4326, 0, 0}}}}}, v)
+		require.Equal(sql.Polygon{SRID: 4326, Lines: []sql.Linestring{{4326, []sql.Point{{SRID: 4326, X: 0, Y: 0}, {SRID: 4326, X: 1, Y: 1}, {SRID: 4326, X: 1, Y: 0}, {SRID: 4326, X: 0, Y: 0}}}}}, v)
 	})
 	t.Run("srid 0 swaps x and y", func(t *testing.T) {
 		require := require.New(t)
@@ -224,7 +224,7 @@ func TestGeomFromGeoJSON(t *testing.T) {
 		require.NoError(err)
 
 		v, err := f.Eval(sql.NewEmptyContext(), nil)
-		require.Equal(sql.Point{0, 1, 2}, v)
+		require.Equal(sql.Point{SRID: 0, X: 1, Y: 2}, v)
 	})
 	t.Run("srid 0 swaps x and y", func(t *testing.T) {
 		require := require.New(t)
@@ -236,7 +236,7 @@ func TestGeomFromGeoJSON(t *testing.T) {
 		require.NoError(err)
 
 		v, err := f.Eval(sql.NewEmptyContext(), nil)
-		require.Equal(sql.Linestring{SRID: 0, Points: []sql.Point{{0, 1, 2}, {0, 3, 4}}}, v)
+		require.Equal(sql.Linestring{SRID: 0, Points: []sql.Point{{SRID: 0, X: 1, Y: 2}, {SRID: 0, X: 3, Y: 4}}}, v)
 	})
 	t.Run("srid 0 swaps x and y", func(t *testing.T) {
 		require := require.New(t)
@@ -248,6 +248,6 @@ func TestGeomFromGeoJSON(t *testing.T) {
 		require.NoError(err)
 
 		v, err := f.Eval(sql.NewEmptyContext(), nil)
-		require.Equal(sql.Polygon{SRID: 0, Lines: []sql.Linestring{{0, []sql.Point{{0, 0, 0}, {0, 1, 1}, {0, 0, 1}, {0, 0, 0}}}}}, v)
+		require.Equal(sql.Polygon{SRID: 0, Lines: []sql.Linestring{{0, []sql.Point{{SRID: 0, X: 0, Y: 0}, {SRID: 0, X: 1, Y: 1}, {SRID: 0, X: 0, Y: 1}, {SRID: 0, X: 0, Y: 0}}}}}, v)
 	})
 }
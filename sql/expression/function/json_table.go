@@ -0,0 +1,503 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// ErrInvalidJSONTableColumns is returned when the column definitions of a JSON_TABLE can't be parsed
+var ErrInvalidJSONTableColumns = errors.NewKind("invalid JSON_TABLE column definition '%s': %s")
+
+// ErrJSONTableConstantArgument is returned when the path or columns of a JSON_TABLE are not string literals
+var ErrJSONTableConstantArgument = errors.NewKind("the %s of JSON_TABLE must be a string literal")
+
+// ErrJSONTableMissingValue is returned for a column of a JSON_TABLE with ERROR ON EMPTY whose path matches nothing
+var ErrJSONTableMissingValue = errors.NewKind("missing value for JSON_TABLE column '%s'")
+
+// ErrJSONTableMultipleValues is returned for a column of a JSON_TABLE with ERROR ON ERROR whose path matches more than
+// one value
+var ErrJSONTableMultipleValues = errors.NewKind("more than one value for JSON_TABLE column '%s'")
+
+// JSON_TABLE(expr, path COLUMNS (column_list)) [AS] alias
+//
+// JSONTable extracts data from a JSON document and returns it as a relational table having the specified columns. Every
+// value matched by path becomes a row. The parser gives the text inside the COLUMNS clause as a third argument, e.g.
+// `id FOR ORDINALITY, name VARCHAR(20) PATH '$.name'`. Columns are either FOR ORDINALITY columns, which number the rows
+// starting from 1, or typed columns extracting the value at their path relative to the row. A typed column is NULL
+// when its path matches nothing or its value can't be converted to its type, unless its ON EMPTY or ON ERROR clause
+// says otherwise. NESTED PATH columns aren't supported, and expr can't refer to the columns of other tables in the FROM
+// clause.
+//
+// https://dev.mysql.com/doc/refman/8.0/en/json-table-functions.html#function_json-table
+type JSONTable struct {
+	db      sql.Database
+	args    []sql.Expression
+	path    jsonPath
+	columns []jsonTableColumn
+}
+
+// jsonTableColumn is a column of a JSON_TABLE.
+type jsonTableColumn struct {
+	name       string
+	typ        sql.Type
+	path       jsonPath
+	ordinality bool
+	onEmpty    jsonTableResponse
+	onError    jsonTableResponse
+}
+
+// jsonTableResponse is what a column of a JSON_TABLE returns when its path matches nothing, or when its value can't be
+// returned: either an error, or a default value, which is NULL unless given.
+type jsonTableResponse struct {
+	err   bool
+	value interface{}
+}
+
+var _ sql.TableFunction = (*JSONTable)(nil)
+
+// NewInstance implements the sql.TableFunction interface
+func (j *JSONTable) NewInstance(ctx *sql.Context, db sql.Database, args []sql.Expression) (sql.Node, error) {
+	if len(args) != 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New(j.FunctionName(), 3, len(args))
+	}
+
+	pathArg, err := constantStringArgument(ctx, args[1], "path")
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := parseJSONPath(pathArg)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := constantStringArgument(ctx, args[2], "columns")
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := parseJSONTableColumns(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONTable{
+		db:      db,
+		args:    args,
+		path:    path,
+		columns: columns,
+	}, nil
+}
+
+// constantStringArgument returns the value of an argument of JSON_TABLE that must be a string literal.
+func constantStringArgument(ctx *sql.Context, e sql.Expression, name string) (string, error) {
+	lit, ok := e.(*expression.Literal)
+	if !ok {
+		return "", ErrJSONTableConstantArgument.New(name)
+	}
+
+	v, err := lit.Eval(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		return "", ErrJSONTableConstantArgument.New(name)
+	}
+
+	return s, nil
+}
+
+// parseJSONTableColumns parses the column definitions of a JSON_TABLE, which are separated by commas. Column names must
+// be unique, ignoring case.
+func parseJSONTableColumns(spec string) ([]jsonTableColumn, error) {
+	var columns []jsonTableColumn
+	for _, def := range splitOutsideQuotes(spec, ',') {
+		def = strings.TrimSpace(def)
+		fields := strings.Fields(def)
+		if len(fields) < 2 {
+			return nil, ErrInvalidJSONTableColumns.New(def, "expected a column name and type")
+		}
+
+		name := strings.Trim(fields[0], "`")
+		for _, col := range columns {
+			if strings.EqualFold(col.name, name) {
+				return nil, sql.ErrDuplicateColumnName.New(name)
+			}
+		}
+
+		rest := strings.TrimSpace(def[len(fields[0]):])
+		if len(fields) == 3 && strings.EqualFold(fields[1], "for") && strings.EqualFold(fields[2], "ordinality") {
+			columns = append(columns, jsonTableColumn{name: name, typ: sql.Uint32, ordinality: true})
+			continue
+		}
+
+		pathIdx := indexOutsideQuotes(strings.ToLower(rest), " path ")
+		if pathIdx < 0 {
+			return nil, ErrInvalidJSONTableColumns.New(def, "expected FOR ORDINALITY or a PATH")
+		}
+
+		typ, err := parseJSONTableColumnType(strings.TrimSpace(rest[:pathIdx]))
+		if err != nil {
+			return nil, ErrInvalidJSONTableColumns.New(def, err.Error())
+		}
+
+		col, err := parseJSONTablePathColumn(name, typ, rest[pathIdx+len(" path "):])
+		if err != nil {
+			return nil, ErrInvalidJSONTableColumns.New(def, err.Error())
+		}
+		columns = append(columns, col)
+	}
+
+	return columns, nil
+}
+
+// parseJSONTablePathColumn parses the part of the definition of a typed column of a JSON_TABLE after the PATH keyword:
+// the quoted path, followed by the optional ON EMPTY and ON ERROR clauses.
+func parseJSONTablePathColumn(name string, typ sql.Type, def string) (jsonTableColumn, error) {
+	col := jsonTableColumn{name: name, typ: typ}
+	tokenizer := sqlparser.NewStringTokenizer(def)
+	next := func() (int, string) {
+		typ, val := tokenizer.Scan()
+		return typ, string(val)
+	}
+
+	tok, path := next()
+	if tok != sqlparser.STRING {
+		return col, fmt.Errorf("the PATH must be a quoted string")
+	}
+	var err error
+	if col.path, err = parseJSONPath(path); err != nil {
+		return col, err
+	}
+
+	var seenEmpty, seenError bool
+	for tok, val := next(); tok != 0; tok, val = next() {
+		var response jsonTableResponse
+		switch {
+		case tok == sqlparser.NULL:
+		case strings.EqualFold(val, "error"):
+			response.err = true
+		case tok == sqlparser.DEFAULT:
+			if tok, val = next(); tok != sqlparser.STRING {
+				return col, fmt.Errorf("expected a quoted string after DEFAULT")
+			}
+			if response.value, err = jsonTableDefault(typ, val); err != nil {
+				return col, err
+			}
+		default:
+			return col, fmt.Errorf("unexpected %s", val)
+		}
+
+		if tok, _ = next(); tok != sqlparser.ON {
+			return col, fmt.Errorf("expected ON EMPTY or ON ERROR")
+		}
+		switch _, val = next(); {
+		case strings.EqualFold(val, "empty") && !seenEmpty && !seenError:
+			col.onEmpty, seenEmpty = response, true
+		case strings.EqualFold(val, "error") && !seenError:
+			col.onError, seenError = response, true
+		default:
+			return col, fmt.Errorf("expected ON EMPTY or ON ERROR")
+		}
+	}
+
+	return col, nil
+}
+
+// jsonTableDefault returns the value of a DEFAULT clause of a column of a JSON_TABLE, which is a JSON text, or a
+// string if it isn't valid JSON, converted to the type of the column.
+func jsonTableDefault(typ sql.Type, def string) (interface{}, error) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(def), &v); err != nil {
+		v = def
+	}
+	return jsonTableColumnValue(typ, v)
+}
+
+// parseJSONTableColumnType parses a column type such as INT or VARCHAR(20).
+func parseJSONTableColumnType(typ string) (sql.Type, error) {
+	stmt, err := sqlparser.Parse(fmt.Sprintf("CREATE TABLE t (c %s)", typ))
+	if err != nil {
+		return nil, err
+	}
+
+	ddl, ok := stmt.(*sqlparser.DDL)
+	if !ok || ddl.TableSpec == nil || len(ddl.TableSpec.Columns) != 1 {
+		return nil, fmt.Errorf("unknown type %s", typ)
+	}
+
+	return sql.ColumnTypeToType(&ddl.TableSpec.Columns[0].Type)
+}
+
+// splitOutsideQuotes splits s on every sep that is neither quoted nor inside parentheses.
+func splitOutsideQuotes(s string, sep byte) []string {
+	var parts []string
+	var quote byte
+	var depth, start int
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == sep && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, s[start:])
+}
+
+// indexOutsideQuotes returns the index of the first occurrence of substr in s that is not quoted, or -1.
+func indexOutsideQuotes(s, substr string) int {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case strings.HasPrefix(s[i:], substr):
+			return i
+		}
+	}
+
+	return -1
+}
+
+// FunctionName implements the sql.TableFunction interface
+func (j *JSONTable) FunctionName() string {
+	return "json_table"
+}
+
+// Description returns a description of the function
+func (j *JSONTable) Description() string {
+	return "returns data from a JSON expression as a relational table."
+}
+
+// Database implements the sql.Databaser interface
+func (j *JSONTable) Database() sql.Database {
+	return j.db
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (j *JSONTable) WithDatabase(db sql.Database) (sql.Node, error) {
+	nj := *j
+	nj.db = db
+	return &nj, nil
+}
+
+// Expressions implements the sql.Expressioner interface
+func (j *JSONTable) Expressions() []sql.Expression {
+	return j.args
+}
+
+// WithExpressions implements the sql.Expressioner interface
+func (j *JSONTable) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	if len(exprs) != len(j.args) {
+		return nil, sql.ErrInvalidExpressionNumber.New(j, len(exprs), len(j.args))
+	}
+
+	nj := *j
+	nj.args = exprs
+	return &nj, nil
+}
+
+// Resolved implements the sql.Resolvable interface
+func (j *JSONTable) Resolved() bool {
+	for _, arg := range j.args {
+		if !arg.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// String implements the fmt.Stringer interface
+func (j *JSONTable) String() string {
+	args := make([]string, len(j.args))
+	for i, arg := range j.args {
+		args[i] = arg.String()
+	}
+	return fmt.Sprintf("JSON_TABLE(%s)", strings.Join(args, ", "))
+}
+
+// Schema implements the sql.Node interface
+func (j *JSONTable) Schema() sql.Schema {
+	schema := make(sql.Schema, len(j.columns))
+	for i, col := range j.columns {
+		schema[i] = &sql.Column{
+			Name:     col.name,
+			Type:     col.typ,
+			Nullable: !col.ordinality,
+		}
+	}
+	return schema
+}
+
+// Children implements the sql.Node interface
+func (j *JSONTable) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface
+func (j *JSONTable) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), 0)
+	}
+	return j, nil
+}
+
+// CheckPrivileges implements the sql.Node interface
+func (j *JSONTable) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	return true
+}
+
+// RowIter implements the sql.Node interface
+func (j *JSONTable) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	js, err := j.args[0].Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if js == nil {
+		return sql.RowsToRowIter(), nil
+	}
+
+	js, err = sql.JSON.Convert(js)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := js.(sql.JSONValue).Unmarshall(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every value matched by the path is a row
+	values := j.path.find(doc.Val)
+	return &jsonTableRowIter{jt: j, values: values}, nil
+}
+
+type jsonTableRowIter struct {
+	jt     *JSONTable
+	values []interface{}
+	pos    int
+}
+
+var _ sql.RowIter = (*jsonTableRowIter)(nil)
+
+// Next implements the sql.RowIter interface
+func (i *jsonTableRowIter) Next(ctx *sql.Context) (sql.Row, error) {
+	if i.pos >= len(i.values) {
+		return nil, io.EOF
+	}
+
+	value := i.values[i.pos]
+	i.pos++
+
+	row := make(sql.Row, len(i.jt.columns))
+	for c, col := range i.jt.columns {
+		if col.ordinality {
+			row[c] = uint32(i.pos)
+			continue
+		}
+
+		v, err := col.value(value)
+		if err != nil {
+			return nil, err
+		}
+		row[c] = v
+	}
+
+	return row, nil
+}
+
+// value returns the value of the column for the row value given. When the path of the column matches nothing, or
+// matches a value that can't be converted to the type of the column, the column's ON EMPTY or ON ERROR clause decides
+// the result.
+func (c jsonTableColumn) value(row interface{}) (interface{}, error) {
+	matched := c.path.find(row)
+	switch len(matched) {
+	case 0:
+		if c.onEmpty.err {
+			return nil, ErrJSONTableMissingValue.New(c.name)
+		}
+		return c.onEmpty.value, nil
+	case 1:
+		v, err := jsonTableColumnValue(c.typ, matched[0])
+		if err != nil {
+			if c.onError.err {
+				return nil, err
+			}
+			return c.onError.value, nil
+		}
+		return v, nil
+	default:
+		if c.onError.err {
+			return nil, ErrJSONTableMultipleValues.New(c.name)
+		}
+		return c.onError.value, nil
+	}
+}
+
+// jsonTableColumnValue converts a JSON value to the type of its column. Objects and arrays are converted from their
+// JSON text.
+func jsonTableColumnValue(typ sql.Type, v interface{}) (interface{}, error) {
+	switch v.(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}, []interface{}:
+		if sql.IsJSON(typ) {
+			return sql.JSONDocument{Val: v}, nil
+		}
+
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		return typ.Convert(string(b))
+	default:
+		return typ.Convert(v)
+	}
+}
+
+// Close implements the sql.RowIter interface
+func (i *jsonTableRowIter) Close(*sql.Context) error {
+	return nil
+}
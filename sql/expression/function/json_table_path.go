@@ -0,0 +1,220 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// ErrInvalidJSONTablePath is returned when a path of a JSON_TABLE can't be parsed
+var ErrInvalidJSONTablePath = errors.NewKind("invalid JSON path '%s': %s")
+
+// jsonPathStep is a step of a JSON path, which selects some of the members of an object or elements of an array.
+type jsonPathStep struct {
+	// key is the member selected by a member step
+	key string
+	// index is the element selected by an array step, counting from the end if fromEnd is set
+	index   int
+	fromEnd bool
+	// array is set for array steps, and unset for member steps
+	array bool
+	// wildcard is set for steps that select every member or element
+	wildcard bool
+	// recursive is set for the ** step, which selects a value and all the values nested in it
+	recursive bool
+}
+
+// jsonPath is a parsed JSON path, such as $.a[*]."b c".
+type jsonPath []jsonPathStep
+
+// parseJSONPath parses a JSON path with the syntax of MySQL. Ranges of array elements aren't supported.
+func parseJSONPath(path string) (jsonPath, error) {
+	p := strings.TrimSpace(path)
+	if !strings.HasPrefix(p, "$") {
+		return nil, ErrInvalidJSONTablePath.New(path, "a path must start with $")
+	}
+	p = p[1:]
+
+	var steps jsonPath
+	for p = strings.TrimLeft(p, " "); p != ""; p = strings.TrimLeft(p, " ") {
+		var step jsonPathStep
+		switch {
+		case strings.HasPrefix(p, "**"):
+			step.recursive = true
+			p = p[2:]
+		case p[0] == '.':
+			p = strings.TrimLeft(p[1:], " ")
+			switch {
+			case strings.HasPrefix(p, "*"):
+				step.wildcard = true
+				p = p[1:]
+			case strings.HasPrefix(p, `"`):
+				end := closingQuote(p)
+				if end < 0 {
+					return nil, ErrInvalidJSONTablePath.New(path, "unterminated quoted member name")
+				}
+				if err := json.Unmarshal([]byte(p[:end+1]), &step.key); err != nil {
+					return nil, ErrInvalidJSONTablePath.New(path, err.Error())
+				}
+				p = p[end+1:]
+			default:
+				end := strings.IndexFunc(p, func(r rune) bool {
+					return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '$'
+				})
+				if end < 0 {
+					end = len(p)
+				}
+				if end == 0 {
+					return nil, ErrInvalidJSONTablePath.New(path, "expected a member name")
+				}
+				step.key, p = p[:end], p[end:]
+			}
+		case p[0] == '[':
+			end := strings.IndexByte(p, ']')
+			if end < 0 {
+				return nil, ErrInvalidJSONTablePath.New(path, "unterminated array index")
+			}
+			step.array = true
+			index := strings.TrimSpace(p[1:end])
+			p = p[end+1:]
+			if index == "*" {
+				step.wildcard = true
+				break
+			}
+			if strings.HasPrefix(index, "last") {
+				step.fromEnd = true
+				index = strings.TrimSpace(strings.TrimPrefix(index, "last"))
+				if index == "" {
+					break
+				}
+				if index[0] != '-' {
+					return nil, ErrInvalidJSONTablePath.New(path, "invalid array index")
+				}
+				index = strings.TrimSpace(index[1:])
+			}
+			i, err := strconv.Atoi(index)
+			if err != nil || i < 0 {
+				return nil, ErrInvalidJSONTablePath.New(path, "invalid array index")
+			}
+			step.index = i
+		default:
+			return nil, ErrInvalidJSONTablePath.New(path, "unexpected "+strconv.Quote(p[:1]))
+		}
+		steps = append(steps, step)
+	}
+
+	if len(steps) > 0 && steps[len(steps)-1].recursive {
+		return nil, ErrInvalidJSONTablePath.New(path, "a path can't end with **")
+	}
+	return steps, nil
+}
+
+// closingQuote returns the index of the double quote that ends the quoted string s starts with, or -1.
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// find returns the values in the JSON value given that the path matches, in document order. Object members are in the
+// order of MySQL's normalized JSON objects, sorted by the length of their name and then by name.
+func (p jsonPath) find(v interface{}) []interface{} {
+	values := []interface{}{v}
+	for _, step := range p {
+		var next []interface{}
+		for _, v := range values {
+			next = step.find(next, v)
+		}
+		values = next
+	}
+	return values
+}
+
+// find appends the values selected by the step from the JSON value given to matched.
+func (s jsonPathStep) find(matched []interface{}, v interface{}) []interface{} {
+	switch {
+	case s.recursive:
+		matched = append(matched, v)
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for _, k := range sortedJSONKeys(v) {
+				matched = s.find(matched, v[k])
+			}
+		case []interface{}:
+			for _, e := range v {
+				matched = s.find(matched, e)
+			}
+		}
+	case s.array:
+		arr, ok := v.([]interface{})
+		if !ok {
+			// A value that isn't an array is treated as an array holding just that value
+			arr = []interface{}{v}
+		}
+		if s.wildcard {
+			if ok {
+				matched = append(matched, arr...)
+			}
+			break
+		}
+		i := s.index
+		if s.fromEnd {
+			i = len(arr) - 1 - i
+		}
+		if i >= 0 && i < len(arr) {
+			matched = append(matched, arr[i])
+		}
+	default:
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			break
+		}
+		if s.wildcard {
+			for _, k := range sortedJSONKeys(obj) {
+				matched = append(matched, obj[k])
+			}
+		} else if member, ok := obj[s.key]; ok {
+			matched = append(matched, member)
+		}
+	}
+	return matched
+}
+
+// sortedJSONKeys returns the member names of a JSON object, sorted by length and then by name.
+func sortedJSONKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) < len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
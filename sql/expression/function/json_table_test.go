@@ -0,0 +1,183 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestJSONTable(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	doc := `[{"id": 1, "name": "one", "tags": ["a"]}, {"id": "2", "name": "two, too"}]`
+	node, err := (&JSONTable{}).NewInstance(ctx, nil, []sql.Expression{
+		expression.NewLiteral(doc, sql.LongText),
+		expression.NewLiteral("$[*]", sql.LongText),
+		expression.NewLiteral("rn FOR ORDINALITY, id INT PATH '$.id', name VARCHAR(20) PATH \"$.name\", "+
+			"tags TEXT PATH '$.tags', price DECIMAL(10, 2) PATH '$.price'", sql.LongText),
+	})
+	require.NoError(err)
+
+	schema := node.Schema()
+	require.Len(schema, 5)
+	require.Equal([]string{"rn", "id", "name", "tags", "price"}, []string{
+		schema[0].Name, schema[1].Name, schema[2].Name, schema[3].Name, schema[4].Name,
+	})
+	require.Equal(sql.Uint32, schema[0].Type)
+	require.Equal(sql.Int32, schema[1].Type)
+
+	iter, err := node.RowIter(ctx, nil)
+	require.NoError(err)
+	rows, err := sql.RowIterToRows(ctx, nil, iter)
+	require.NoError(err)
+	require.Equal([]sql.Row{
+		{uint32(1), int32(1), "one", `["a"]`, nil},
+		{uint32(2), int32(2), "two, too", nil, nil},
+	}, rows)
+
+	invalid := []string{
+		"id INT",
+		"id",
+		"id FOR",
+		"id NOTATYPE PATH '$.id'",
+		"id INT PATH $.id",
+	}
+	for _, columns := range invalid {
+		_, err = (&JSONTable{}).NewInstance(ctx, nil, []sql.Expression{
+			expression.NewLiteral(doc, sql.LongText),
+			expression.NewLiteral("$[*]", sql.LongText),
+			expression.NewLiteral(columns, sql.LongText),
+		})
+		require.True(ErrInvalidJSONTableColumns.Is(err), "unexpected error %v for %s", err, columns)
+	}
+}
+
+func TestJSONTablePaths(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	rows := func(t *testing.T, doc, path, columns string) ([]sql.Row, error) {
+		node, err := (&JSONTable{}).NewInstance(ctx, nil, []sql.Expression{
+			expression.NewLiteral(doc, sql.LongText),
+			expression.NewLiteral(path, sql.LongText),
+			expression.NewLiteral(columns, sql.LongText),
+		})
+		if err != nil {
+			return nil, err
+		}
+		iter, err := node.RowIter(ctx, nil)
+		require.NoError(t, err)
+		return sql.RowIterToRows(ctx, nil, iter)
+	}
+
+	testCases := []struct {
+		name     string
+		doc      string
+		path     string
+		columns  string
+		expected []sql.Row
+	}{
+		{
+			name:     "quoted member name isn't a wildcard",
+			doc:      `{"a*": [1, 2], "ab": 3}`,
+			path:     `$."a*"`,
+			columns:  `v JSON PATH '$'`,
+			expected: []sql.Row{{sql.MustJSON(`[1, 2]`)}},
+		},
+		{
+			name:     "nested array wildcards",
+			doc:      `[[1, 2], [3]]`,
+			path:     `$[*][*]`,
+			columns:  `v INT PATH '$'`,
+			expected: []sql.Row{{int32(1)}, {int32(2)}, {int32(3)}},
+		},
+		{
+			name:     "member wildcard",
+			doc:      `{"bb": {"x": 2}, "a": {"x": 1}}`,
+			path:     `$.*`,
+			columns:  `x INT PATH '$.x'`,
+			expected: []sql.Row{{int32(1)}, {int32(2)}},
+		},
+		{
+			name:     "recursive wildcard",
+			doc:      `{"a": {"x": 1, "b": [{"x": 2}]}}`,
+			path:     `$**.x`,
+			columns:  `x INT PATH '$'`,
+			expected: []sql.Row{{int32(1)}, {int32(2)}},
+		},
+		{
+			name:     "last array element",
+			doc:      `[1, 2, 3]`,
+			path:     `$[last - 1]`,
+			columns:  `v INT PATH '$'`,
+			expected: []sql.Row{{int32(2)}},
+		},
+		{
+			name:     "errors are null by default",
+			doc:      `[{"a": "x"}, {"a": [1, 2]}, {"a": 3}]`,
+			path:     `$[*]`,
+			columns:  `a INT PATH '$.a', b INT PATH '$.a[*]'`,
+			expected: []sql.Row{{nil, nil}, {nil, nil}, {int32(3), nil}},
+		},
+		{
+			name:     "default on empty and on error",
+			doc:      `[{"a": "x"}, {}]`,
+			path:     `$[*]`,
+			columns:  `a INT PATH '$.a' DEFAULT '1' ON EMPTY DEFAULT '2' ON ERROR, b VARCHAR(10) PATH '$.b' DEFAULT '"none"' ON EMPTY`,
+			expected: []sql.Row{{int32(2), "none"}, {int32(1), "none"}},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := rows(t, tt.doc, tt.path, tt.columns)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+
+	t.Run("error on error", func(t *testing.T) {
+		_, err := rows(t, `[{"a": [1, 2]}]`, `$[*]`, `a INT PATH '$.a[*]' ERROR ON ERROR`)
+		require.True(t, ErrJSONTableMultipleValues.Is(err), "unexpected error %v", err)
+	})
+
+	t.Run("error on empty", func(t *testing.T) {
+		_, err := rows(t, `[{}]`, `$[*]`, `a INT PATH '$.a' ERROR ON EMPTY`)
+		require.True(t, ErrJSONTableMissingValue.Is(err), "unexpected error %v", err)
+	})
+
+	t.Run("duplicate column names", func(t *testing.T) {
+		_, err := rows(t, `[]`, `$[*]`, `a INT PATH '$.a', A INT PATH '$.b'`)
+		require.True(t, sql.ErrDuplicateColumnName.Is(err), "unexpected error %v", err)
+	})
+
+	for _, path := range []string{`a`, `$.`, `$[1`, `$[x]`, `$."a`, `$**`} {
+		t.Run("invalid path "+path, func(t *testing.T) {
+			_, err := rows(t, `[]`, path, `a INT PATH '$'`)
+			require.True(t, ErrInvalidJSONTablePath.Is(err), "unexpected error %v", err)
+		})
+	}
+
+	for _, columns := range []string{`a INT PATH '$' ON EMPTY`, `a INT PATH '$' NULL ON ERROR NULL ON EMPTY`, `a INT PATH '$' DEFAULT ON ERROR`} {
+		t.Run("invalid clauses "+columns, func(t *testing.T) {
+			_, err := rows(t, `[]`, `$`, columns)
+			require.True(t, ErrInvalidJSONTableColumns.Is(err), "unexpected error %v", err)
+		})
+	}
+}
@@ -704,42 +704,6 @@ func (j JSONValid) IsUnsupported() bool {
 	return true
 }
 
-//////////////////////////
-// JSON table functions //
-//////////////////////////
-
-// JSON_TABLE(expr, path COLUMNS (column_list) [AS] alias)
-//
-// JSONTable Extracts data from a JSON document and returns it as a relational table having the specified columns.
-// TODO(andy): this doc was heavily truncated
-//
-// https://dev.mysql.com/doc/refman/8.0/en/json-table-functions.html#function_json-table
-type JSONTable struct {
-	sql.Expression
-}
-
-var _ sql.FunctionExpression = JSONTable{}
-
-// NewJSONTable creates a new JSONTable function.
-func NewJSONTable(args ...sql.Expression) (sql.Expression, error) {
-	return nil, ErrUnsupportedJSONFunction.New(JSONTable{}.FunctionName())
-}
-
-// FunctionName implements sql.FunctionExpression
-func (j JSONTable) FunctionName() string {
-	return "json_table"
-}
-
-// Description implements sql.FunctionExpression
-func (j JSONTable) Description() string {
-	return "returns data from a JSON expression as a relational table."
-}
-
-// IsUnsupported implements sql.UnsupportedFunctionStub
-func (j JSONTable) IsUnsupported() bool {
-	return true
-}
-
 ///////////////////////////////
 // JSON validation functions //
 ///////////////////////////////
@@ -23,14 +23,14 @@ import (
 	"github.com/dolthub/go-mysql-server/sql"
 )
 
-// Linestring is a function that returns a point type containing values Y and Y.
+// Linestring is a function that returns a linestring through its point arguments.
 type Linestring struct {
 	expression.NaryExpression
 }
 
 var _ sql.FunctionExpression = (*Linestring)(nil)
 
-// NewLinestring creates a new point expression.
+// NewLinestring creates a new linestring expression.
 func NewLinestring(args ...sql.Expression) (sql.Expression, error) {
 	if len(args) < 2 {
 		return nil, sql.ErrInvalidArgumentNumber.New("Linestring", "2 or more", len(args))
@@ -80,6 +80,8 @@ func (l *Linestring) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		}
 		// Must be of type point, throw error otherwise
 		switch v := val.(type) {
+		case nil:
+			return nil, nil
 		case sql.Point:
 			points[i] = v
 		case sql.Linestring, sql.Polygon: // TODO: eventually add all spatial types
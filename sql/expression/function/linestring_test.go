@@ -36,6 +36,42 @@ func TestLinestring(t *testing.T) {
 		require.NoError(err)
 		require.Equal(sql.Linestring{Points: []sql.Point{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 5, Y: 6}}}, v)
 	})
+
+	t.Run("create triangle linestring from point expressions", func(t *testing.T) {
+		require := require.New(t)
+		point := func(x, y int) sql.Expression {
+			return NewPoint(expression.NewLiteral(x, sql.Int64), expression.NewLiteral(y, sql.Int64))
+		}
+		f, err := NewLinestring(point(0, 0), point(4, 0), point(0, 3), point(0, 0))
+		require.NoError(err)
+
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Linestring{Points: []sql.Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 0, Y: 3}, {X: 0, Y: 0}}}, v)
+	})
+
+	t.Run("create linestring with null point", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewLinestring(expression.NewLiteral(sql.Point{X: 1, Y: 2}, sql.PointType{}),
+			expression.NewLiteral(nil, sql.Null),
+		)
+		require.NoError(err)
+
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Nil(v)
+	})
+
+	t.Run("create invalid linestring with non-point", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewLinestring(expression.NewLiteral(sql.Point{X: 1, Y: 2}, sql.PointType{}),
+			expression.NewLiteral(1, sql.Int64),
+		)
+		require.NoError(err)
+
+		_, err = f.Eval(sql.NewEmptyContext(), nil)
+		require.Error(err)
+	})
 }
 
 func TestNewLinestring(t *testing.T) {
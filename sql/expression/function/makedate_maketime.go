@@ -0,0 +1,219 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/parse/dateparse"
+)
+
+// MakeDate is a function that returns a date from a year and a day of that year.
+type MakeDate struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*MakeDate)(nil)
+
+// NewMakeDate creates a new MAKEDATE expression.
+func NewMakeDate(year, dayOfYear sql.Expression) sql.Expression {
+	return &MakeDate{
+		expression.BinaryExpression{
+			Left:  year,
+			Right: dayOfYear,
+		},
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (m *MakeDate) FunctionName() string {
+	return "makedate"
+}
+
+// Description implements sql.FunctionExpression
+func (m *MakeDate) Description() string {
+	return "returns a date, given year and day-of-year values."
+}
+
+// Type implements the sql.Expression interface.
+func (m *MakeDate) Type() sql.Type {
+	return sql.Date
+}
+
+// IsNullable implements the sql.Expression interface.
+func (m *MakeDate) IsNullable() bool {
+	return true
+}
+
+func (m *MakeDate) String() string {
+	return fmt.Sprintf("MAKEDATE(%s, %s)", m.Left, m.Right)
+}
+
+// WithChildren implements the sql.Expression interface.
+func (m *MakeDate) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(m, len(children), 2)
+	}
+	return NewMakeDate(children[0], children[1]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (m *MakeDate) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	year, err := evalInt64(ctx, row, m.Left)
+	if err != nil || year == nil {
+		return nil, err
+	}
+	dayOfYear, err := evalInt64(ctx, row, m.Right)
+	if err != nil || dayOfYear == nil {
+		return nil, err
+	}
+
+	y, day := *year, *dayOfYear
+	if y < 0 || day <= 0 {
+		return nil, nil
+	}
+	// two digit years are in 1970 - 2069, like in dates
+	if y < 70 {
+		y += 2000
+	} else if y < 100 {
+		y += 1900
+	}
+	// skip the years out of range before adding the days, which could overflow otherwise
+	if y > 9999 || day > 366*10000 {
+		return nil, nil
+	}
+
+	t := dateparse.DateFromDayOfYear(int(y), int(day), time.UTC)
+	if t.Year() > 9999 {
+		return nil, nil
+	}
+	return sql.Date.Convert(t)
+}
+
+// MakeTime is a function that returns a time from hour, minute and second values.
+type MakeTime struct {
+	hour, minute, second sql.Expression
+}
+
+var _ sql.FunctionExpression = (*MakeTime)(nil)
+
+// NewMakeTime creates a new MAKETIME expression.
+func NewMakeTime(hour, minute, second sql.Expression) sql.Expression {
+	return &MakeTime{
+		hour:   hour,
+		minute: minute,
+		second: second,
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (m *MakeTime) FunctionName() string {
+	return "maketime"
+}
+
+// Description implements sql.FunctionExpression
+func (m *MakeTime) Description() string {
+	return "returns a time value calculated from the hour, minute, and second arguments."
+}
+
+// Resolved implements the sql.Expression interface.
+func (m *MakeTime) Resolved() bool {
+	return m.hour.Resolved() && m.minute.Resolved() && m.second.Resolved()
+}
+
+func (m *MakeTime) String() string {
+	return fmt.Sprintf("MAKETIME(%s, %s, %s)", m.hour, m.minute, m.second)
+}
+
+// Type implements the sql.Expression interface.
+func (m *MakeTime) Type() sql.Type {
+	return sql.Time
+}
+
+// IsNullable implements the sql.Expression interface.
+func (m *MakeTime) IsNullable() bool {
+	return true
+}
+
+// Children implements the sql.Expression interface.
+func (m *MakeTime) Children() []sql.Expression {
+	return []sql.Expression{m.hour, m.minute, m.second}
+}
+
+// WithChildren implements the sql.Expression interface.
+func (m *MakeTime) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 3 {
+		return nil, sql.ErrInvalidChildrenNumber.New(m, len(children), 3)
+	}
+	return NewMakeTime(children[0], children[1], children[2]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (m *MakeTime) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	hour, err := evalInt64(ctx, row, m.hour)
+	if err != nil || hour == nil {
+		return nil, err
+	}
+	minute, err := evalInt64(ctx, row, m.minute)
+	if err != nil || minute == nil {
+		return nil, err
+	}
+	val, err := m.second.Eval(ctx, row)
+	if err != nil || val == nil {
+		return nil, err
+	}
+	second, err := sql.Float64.Convert(val)
+	if err != nil {
+		return nil, err
+	}
+
+	s := second.(float64)
+	if *minute < 0 || *minute > 59 || s < 0 || s >= 60 {
+		return nil, nil
+	}
+
+	// the time is clamped to the range of the TIME type, which is under 1000 hours, so larger hours are all the same
+	h := *hour
+	if h > 1000 {
+		h = 1000
+	} else if h < -1000 {
+		h = -1000
+	}
+	d := time.Duration(*minute)*time.Minute + time.Duration(math.Round(s*1e6))*time.Microsecond
+	if h < 0 {
+		d = time.Duration(h)*time.Hour - d
+	} else {
+		d = time.Duration(h)*time.Hour + d
+	}
+	return sql.Time.Convert(d)
+}
+
+// evalInt64 evaluates an expression and converts the result to an int64, returning nil for NULL.
+func evalInt64(ctx *sql.Context, row sql.Row, e sql.Expression) (*int64, error) {
+	val, err := e.Eval(ctx, row)
+	if err != nil || val == nil {
+		return nil, err
+	}
+	i, err := sql.Int64.Convert(val)
+	if err != nil {
+		return nil, err
+	}
+	res := i.(int64)
+	return &res, nil
+}
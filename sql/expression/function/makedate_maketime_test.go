@@ -0,0 +1,100 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestMakeDate(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	testCases := []struct {
+		name      string
+		year      interface{}
+		dayOfYear interface{}
+		expected  interface{}
+	}{
+		{"first day", 2021, 1, date(2021, time.January, 1)},
+		{"after february", 2021, 60, date(2021, time.March, 1)},
+		{"after february in leap year", 2020, 60, date(2020, time.February, 29)},
+		{"last day", 2021, 365, date(2021, time.December, 31)},
+		{"past the end of the year", 2021, 366, date(2022, time.January, 1)},
+		{"two digit year", 21, 32, date(2021, time.February, 1)},
+		{"two digit year in last century", 99, 32, date(1999, time.February, 1)},
+		{"string arguments", "2021", "60", date(2021, time.March, 1)},
+		{"zero day", 2021, 0, nil},
+		{"negative day", 2021, -1, nil},
+		{"negative year", -1, 1, nil},
+		{"year out of range", 10000, 1, nil},
+		{"day out of range", 9999, 366, nil},
+		{"null year", nil, 1, nil},
+		{"null day", 2021, nil, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			f := NewMakeDate(expression.NewLiteral(tt.year, sql.Int64), expression.NewLiteral(tt.dayOfYear, sql.Int64))
+			res, err := f.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(err)
+			require.Equal(tt.expected, res)
+		})
+	}
+}
+
+func TestMakeTime(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		hour, minute, second interface{}
+		expected             interface{}
+	}{
+		{"time", 12, 30, 45, "12:30:45"},
+		{"midnight", 0, 0, 0, "00:00:00"},
+		{"fractional seconds", 12, 30, 45.5, "12:30:45.500000"},
+		{"more than a day", 100, 0, 0, "100:00:00"},
+		{"negative", -12, 30, 45, "-12:30:45"},
+		{"clamped", 900, 0, 0, "838:59:59"},
+		{"clamped negative", -900, 0, 0, "-838:59:59"},
+		{"string arguments", "12", "30", "45", "12:30:45"},
+		{"minute out of range", 12, 60, 0, nil},
+		{"negative minute", 12, -1, 0, nil},
+		{"second out of range", 12, 0, 60, nil},
+		{"null hour", nil, 0, 0, nil},
+		{"null minute", 12, nil, 0, nil},
+		{"null second", 12, 0, nil, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			f := NewMakeTime(
+				expression.NewLiteral(tt.hour, sql.Int64),
+				expression.NewLiteral(tt.minute, sql.Int64),
+				expression.NewLiteral(tt.second, sql.Float64),
+			)
+			res, err := f.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(err)
+			require.Equal(tt.expected, res)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// ForcePolygonOrientation is a function that returns a geometry with the rings of its polygons reversed as needed, so
+// that exterior rings are oriented one way and interior rings the other way.
+type ForcePolygonOrientation struct {
+	expression.UnaryExpression
+	clockwise bool
+}
+
+var _ sql.FunctionExpression = (*ForcePolygonOrientation)(nil)
+
+// NewForcePolygonCW creates a new ST_FORCEPOLYGONCW expression, with clockwise exterior rings and counter-clockwise
+// interior rings.
+func NewForcePolygonCW(e sql.Expression) sql.Expression {
+	return &ForcePolygonOrientation{expression.UnaryExpression{Child: e}, true}
+}
+
+// NewForcePolygonCCW creates a new ST_FORCEPOLYGONCCW expression, with counter-clockwise exterior rings and clockwise
+// interior rings.
+func NewForcePolygonCCW(e sql.Expression) sql.Expression {
+	return &ForcePolygonOrientation{expression.UnaryExpression{Child: e}, false}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (f *ForcePolygonOrientation) FunctionName() string {
+	if f.clockwise {
+		return "st_forcepolygoncw"
+	}
+	return "st_forcepolygonccw"
+}
+
+// Description implements sql.FunctionExpression
+func (f *ForcePolygonOrientation) Description() string {
+	if f.clockwise {
+		return "returns the geometry with clockwise exterior rings and counter-clockwise interior rings."
+	}
+	return "returns the geometry with counter-clockwise exterior rings and clockwise interior rings."
+}
+
+// IsNullable implements the sql.Expression interface.
+func (f *ForcePolygonOrientation) IsNullable() bool {
+	return f.Child.IsNullable()
+}
+
+// Type implements the sql.Expression interface.
+func (f *ForcePolygonOrientation) Type() sql.Type {
+	return f.Child.Type()
+}
+
+func (f *ForcePolygonOrientation) String() string {
+	if f.clockwise {
+		return fmt.Sprintf("ST_FORCEPOLYGONCW(%s)", f.Child.String())
+	}
+	return fmt.Sprintf("ST_FORCEPOLYGONCCW(%s)", f.Child.String())
+}
+
+// WithChildren implements the Expression interface.
+func (f *ForcePolygonOrientation) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(f, len(children), 1)
+	}
+	return &ForcePolygonOrientation{expression.UnaryExpression{Child: children[0]}, f.clockwise}, nil
+}
+
+// OrientPolygons returns a copy of the geometry with the rings of every polygon oriented so that the exterior
+// ring is clockwise and the interior rings are counter-clockwise if [clockwise] is true, or the other way around
+// otherwise. Rings that already have the right orientation are kept as they are, and geometries without polygons are
+// returned unchanged.
+func OrientPolygons(v interface{}, clockwise bool) interface{} {
+	switch v := v.(type) {
+	case sql.Polygon:
+		lines := make([]sql.Linestring, len(v.Lines))
+		for i, l := range v.Lines {
+			// interior rings have the opposite orientation of the exterior ring
+			ringClockwise := clockwise == (i == 0)
+			if area := ringSignedArea(l.Points); (ringClockwise && area > 0) || (!ringClockwise && area < 0) {
+				lines[i] = sql.Linestring{SRID: l.SRID, Points: reversePoints(l.Points)}
+			} else {
+				lines[i] = sql.Linestring{SRID: l.SRID, Points: append([]sql.Point(nil), l.Points...)}
+			}
+		}
+		return sql.Polygon{SRID: v.SRID, Lines: lines}
+	case sql.MultiPolygon:
+		polys := make([]sql.Polygon, len(v.Polygons))
+		for i, p := range v.Polygons {
+			polys[i] = OrientPolygons(p, clockwise).(sql.Polygon)
+		}
+		return sql.MultiPolygon{SRID: v.SRID, Polygons: polys}
+	case sql.GeometryCollection:
+		geoms := make([]interface{}, len(v.Geoms))
+		for i, g := range v.Geoms {
+			geoms[i] = OrientPolygons(g, clockwise)
+		}
+		return sql.GeometryCollection{SRID: v.SRID, Geoms: geoms}
+	case sql.Geometry:
+		return sql.Geometry{Inner: OrientPolygons(v.Inner, clockwise)}
+	default:
+		return v
+	}
+}
+
+// ringSignedArea returns the area of a closed ring using the shoelace formula. The area is positive when the ring is
+// counter-clockwise, and negative when it is clockwise.
+func ringSignedArea(points []sql.Point) float64 {
+	var area float64
+	for i := 1; i < len(points); i++ {
+		area += points[i-1].X*points[i].Y - points[i].X*points[i-1].Y
+	}
+	return area / 2
+}
+
+// reversePoints returns a copy of [points] in reverse order
+func reversePoints(points []sql.Point) []sql.Point {
+	res := make([]sql.Point, len(points))
+	for i, p := range points {
+		res[len(points)-1-i] = p
+	}
+	return res
+}
+
+// Eval implements the sql.Expression interface.
+func (f *ForcePolygonOrientation) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := f.Child.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return nil if geometry is nil
+	if val == nil {
+		return nil, nil
+	}
+
+	switch val.(type) {
+	case sql.Point, sql.Linestring, sql.Polygon, sql.MultiPoint, sql.MultiLinestring, sql.MultiPolygon, sql.GeometryCollection, sql.Geometry:
+		return OrientPolygons(val, f.clockwise), nil
+	default:
+		return nil, sql.ErrInvalidGISData.New(f.FunctionName())
+	}
+}
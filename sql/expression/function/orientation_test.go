@@ -0,0 +1,108 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestForcePolygonOrientation(t *testing.T) {
+	ccwSquare := sql.Linestring{Points: []sql.Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 4}, {X: 0, Y: 0}}}
+	cwSquare := sql.Linestring{Points: []sql.Point{{X: 0, Y: 0}, {X: 0, Y: 4}, {X: 4, Y: 4}, {X: 4, Y: 0}, {X: 0, Y: 0}}}
+	ccwHole := sql.Linestring{Points: []sql.Point{{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 2}, {X: 1, Y: 1}}}
+	cwHole := sql.Linestring{Points: []sql.Point{{X: 1, Y: 1}, {X: 2, Y: 2}, {X: 2, Y: 1}, {X: 1, Y: 1}}}
+
+	t.Run("force clockwise flips counter-clockwise rings", func(t *testing.T) {
+		require := require.New(t)
+		f := NewForcePolygonCW(expression.NewLiteral(sql.Polygon{Lines: []sql.Linestring{ccwSquare, cwHole}}, sql.PolygonType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Polygon{Lines: []sql.Linestring{cwSquare, ccwHole}}, v)
+	})
+
+	t.Run("force clockwise keeps clockwise rings", func(t *testing.T) {
+		require := require.New(t)
+		poly := sql.Polygon{Lines: []sql.Linestring{cwSquare, ccwHole}}
+		f := NewForcePolygonCW(expression.NewLiteral(poly, sql.PolygonType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(poly, v)
+	})
+
+	t.Run("force counter-clockwise flips clockwise rings", func(t *testing.T) {
+		require := require.New(t)
+		f := NewForcePolygonCCW(expression.NewLiteral(sql.Polygon{Lines: []sql.Linestring{cwSquare, ccwHole}}, sql.PolygonType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Polygon{Lines: []sql.Linestring{ccwSquare, cwHole}}, v)
+	})
+
+	t.Run("force counter-clockwise keeps counter-clockwise rings", func(t *testing.T) {
+		require := require.New(t)
+		poly := sql.Polygon{Lines: []sql.Linestring{ccwSquare, cwHole}}
+		f := NewForcePolygonCCW(expression.NewLiteral(poly, sql.PolygonType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(poly, v)
+	})
+
+	t.Run("multipolygon in geometry", func(t *testing.T) {
+		require := require.New(t)
+		mp := sql.MultiPolygon{Polygons: []sql.Polygon{{Lines: []sql.Linestring{ccwSquare}}, {Lines: []sql.Linestring{cwSquare}}}}
+		f := NewForcePolygonCW(expression.NewLiteral(sql.Geometry{Inner: mp}, sql.GeometryType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Geometry{Inner: sql.MultiPolygon{Polygons: []sql.Polygon{{Lines: []sql.Linestring{cwSquare}}, {Lines: []sql.Linestring{cwSquare}}}}}, v)
+	})
+
+	t.Run("other geometries are unchanged", func(t *testing.T) {
+		require := require.New(t)
+		f := NewForcePolygonCW(expression.NewLiteral(ccwSquare, sql.LinestringType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(ccwSquare, v)
+	})
+
+	t.Run("null is null", func(t *testing.T) {
+		require := require.New(t)
+		f := NewForcePolygonCCW(expression.NewLiteral(nil, sql.Null))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Nil(v)
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		require := require.New(t)
+		f := NewForcePolygonCCW(expression.NewLiteral(123, sql.Int64))
+		_, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.True(sql.ErrInvalidGISData.Is(err))
+	})
+
+	t.Run("with children keeps orientation", func(t *testing.T) {
+		require := require.New(t)
+		f := NewForcePolygonCW(expression.NewLiteral(nil, sql.Null))
+		f, err := f.WithChildren(expression.NewLiteral(sql.Polygon{Lines: []sql.Linestring{ccwSquare}}, sql.PolygonType{}))
+		require.NoError(err)
+		require.Equal("st_forcepolygoncw", f.(sql.FunctionExpression).FunctionName())
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Polygon{Lines: []sql.Linestring{cwSquare}}, v)
+	})
+}
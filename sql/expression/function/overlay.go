@@ -0,0 +1,622 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// Union is a function that returns the point set union of two geometries.
+type Union struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*Union)(nil)
+
+// NewUnion creates a new ST_UNION expression.
+func NewUnion(g1, g2 sql.Expression) sql.Expression {
+	return &Union{
+		expression.BinaryExpression{
+			Left:  g1,
+			Right: g2,
+		},
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (u *Union) FunctionName() string {
+	return "st_union"
+}
+
+// Description implements sql.FunctionExpression
+func (u *Union) Description() string {
+	return "returns a geometry that is the point set union of g1 and g2."
+}
+
+// Type implements the sql.Expression interface.
+func (u *Union) Type() sql.Type {
+	return sql.GeometryType{}
+}
+
+func (u *Union) String() string {
+	return fmt.Sprintf("ST_UNION(%s,%s)", u.Left, u.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (u *Union) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(u, len(children), 2)
+	}
+	return NewUnion(children[0], children[1]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (u *Union) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return evalSpatialOperation(ctx, row, u.FunctionName(), u.Left, u.Right, GeometryUnion)
+}
+
+// Intersection is a function that returns the point set intersection of two geometries.
+type Intersection struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*Intersection)(nil)
+
+// NewIntersection creates a new ST_INTERSECTION expression.
+func NewIntersection(g1, g2 sql.Expression) sql.Expression {
+	return &Intersection{
+		expression.BinaryExpression{
+			Left:  g1,
+			Right: g2,
+		},
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (i *Intersection) FunctionName() string {
+	return "st_intersection"
+}
+
+// Description implements sql.FunctionExpression
+func (i *Intersection) Description() string {
+	return "returns a geometry that is the point set intersection of g1 and g2."
+}
+
+// Type implements the sql.Expression interface.
+func (i *Intersection) Type() sql.Type {
+	return sql.GeometryType{}
+}
+
+func (i *Intersection) String() string {
+	return fmt.Sprintf("ST_INTERSECTION(%s,%s)", i.Left, i.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (i *Intersection) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(i, len(children), 2)
+	}
+	return NewIntersection(children[0], children[1]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (i *Intersection) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return evalSpatialOperation(ctx, row, i.FunctionName(), i.Left, i.Right, GeometryIntersection)
+}
+
+// Difference is a function that returns the point set difference of two geometries.
+type Difference struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*Difference)(nil)
+
+// NewDifference creates a new ST_DIFFERENCE expression.
+func NewDifference(g1, g2 sql.Expression) sql.Expression {
+	return &Difference{
+		expression.BinaryExpression{
+			Left:  g1,
+			Right: g2,
+		},
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (d *Difference) FunctionName() string {
+	return "st_difference"
+}
+
+// Description implements sql.FunctionExpression
+func (d *Difference) Description() string {
+	return "returns a geometry that is the point set difference of g1 and g2."
+}
+
+// Type implements the sql.Expression interface.
+func (d *Difference) Type() sql.Type {
+	return sql.GeometryType{}
+}
+
+func (d *Difference) String() string {
+	return fmt.Sprintf("ST_DIFFERENCE(%s,%s)", d.Left, d.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (d *Difference) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(d, len(children), 2)
+	}
+	return NewDifference(children[0], children[1]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (d *Difference) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return evalSpatialOperation(ctx, row, d.FunctionName(), d.Left, d.Right, GeometryDifference)
+}
+
+// evalSpatialOperation evaluates [operation] on the geometries of [left] and [right], which must have the same SRID.
+// The result has that SRID too.
+func evalSpatialOperation(ctx *sql.Context, row sql.Row, fnName string, left, right sql.Expression, operation func(g1, g2 interface{}) interface{}) (interface{}, error) {
+	g1, g2, srid, err := evalGeometryPair(ctx, row, fnName, left, right)
+	if err != nil || g1 == nil || g2 == nil {
+		return nil, err
+	}
+	return GeometryWithSRID(operation(g1, g2), srid)
+}
+
+// overlayOp is a set operation on the points of two geometries.
+type overlayOp int
+
+const (
+	overlayUnion overlayOp = iota
+	overlayIntersection
+	overlayDifference
+)
+
+// GeometryUnion returns the points that are in either geometry. Overlapping polygons are merged, and linestrings and
+// points are only kept where they aren't already covered by a geometry of higher dimension.
+func GeometryUnion(g1, g2 interface{}) interface{} {
+	return overlayGeometries(g1, g2, overlayUnion)
+}
+
+// GeometryIntersection returns the points that are in both geometries. Polygons sharing only an edge or a corner
+// intersect in a linestring or a point.
+func GeometryIntersection(g1, g2 interface{}) interface{} {
+	return overlayGeometries(g1, g2, overlayIntersection)
+}
+
+// GeometryDifference returns the points of g1 that aren't in g2. Only the parts of g2 with a dimension at least that of
+// a part of g1 remove anything from it, so a point can't be subtracted from a linestring.
+func GeometryDifference(g1, g2 interface{}) interface{} {
+	return overlayGeometries(g1, g2, overlayDifference)
+}
+
+// overlayGeometries returns the result of an operation on the point sets of two geometries. The polygons are combined
+// by clipping their rings against each other, then the pieces of linestrings and the points that belong in the result
+// are added where no polygon or linestring of the result covers them already.
+//
+// The result is a point, linestring or polygon, a multi geometry if there are several of one kind, or a geometry
+// collection if there are several kinds. It's an empty geometry collection if there are none.
+func overlayGeometries(g1, g2 interface{}, op overlayOp) interface{} {
+	a, b := flattenGeometry(g1), flattenGeometry(g2)
+	nodes := newOverlayNodes(a, b)
+
+	res := geometryParts{polygons: overlayPolygons(a.polygons, b.polygons, op, nodes)}
+
+	// addLines adds the pieces of the linestrings of a geometry that are in the result, merged back into linestrings
+	addLines := func(src [][]sql.Point, other geometryParts, keep func(mid sql.Point) bool) {
+		segments, vertices := other.segments(), other.vertices()
+		for _, l := range src {
+			for _, merged := range clipLinestring(l, segments, vertices, nodes, func(p, q sql.Point) bool {
+				mid := sql.Point{X: (p.X + q.X) / 2, Y: (p.Y + q.Y) / 2}
+				return keep(mid) && res.locate(mid) == locationExterior
+			}) {
+				res.lines = append(res.lines, merged)
+			}
+		}
+	}
+	// addPoints adds the points that are kept and not yet covered by the result
+	addPoints := func(src []sql.Point, keep func(p sql.Point) bool) {
+		for _, p := range src {
+			if keep(p) && res.locate(p) == locationExterior {
+				res.points = append(res.points, p)
+			}
+		}
+	}
+	in := func(g geometryParts) func(p sql.Point) bool {
+		return func(p sql.Point) bool { return g.locate(p) != locationExterior }
+	}
+	outside := func(g geometryParts) func(p sql.Point) bool {
+		return func(p sql.Point) bool { return g.locate(p) == locationExterior }
+	}
+
+	switch op {
+	case overlayUnion:
+		all := func(sql.Point) bool { return true }
+		addLines(a.lines, b, all)
+		addLines(b.lines, a, all)
+		addPoints(a.points, all)
+		addPoints(b.points, all)
+	case overlayIntersection:
+		addLines(a.lines, b, in(b))
+		addLines(b.lines, a, in(a))
+		// rings touching the other geometry along an edge, with the interiors on either side
+		addLines(polygonRings(a.polygons), b, in(b))
+		addPoints(a.points, in(b))
+		addPoints(b.points, in(a))
+		// points where the linestrings and rings of the geometries cross or touch
+		addPoints(crossingPoints(a, b, nodes), in(b))
+	case overlayDifference:
+		// only linestrings and polygons take anything away from a linestring
+		var lineCutter geometryParts
+		lineCutter.lines, lineCutter.polygons = b.lines, b.polygons
+		addLines(a.lines, lineCutter, outside(lineCutter))
+		addPoints(a.points, outside(b))
+	}
+
+	return overlayResult(res.points, res.lines, res.polygons)
+}
+
+// overlayResult returns the geometry of the points, linestrings and polygons of the result of an overlay.
+func overlayResult(points []sql.Point, lines [][]sql.Point, polygons []sql.Polygon) interface{} {
+	var geoms []interface{}
+	for _, p := range polygons {
+		geoms = append(geoms, p)
+	}
+	for _, l := range lines {
+		geoms = append(geoms, sql.Linestring{Points: l})
+	}
+	for _, p := range points {
+		geoms = append(geoms, sql.Point{X: p.X, Y: p.Y})
+	}
+
+	switch {
+	case len(geoms) == 0:
+		return sql.GeometryCollection{}
+	case len(geoms) == 1:
+		return geoms[0]
+	case len(geoms) == len(polygons):
+		return sql.MultiPolygon{Polygons: polygons}
+	case len(geoms) == len(lines):
+		res := sql.MultiLinestring{}
+		for _, l := range lines {
+			res.Lines = append(res.Lines, sql.Linestring{Points: l})
+		}
+		return res
+	case len(geoms) == len(points):
+		res := sql.MultiPoint{}
+		for _, p := range points {
+			res.Points = append(res.Points, sql.Point{X: p.X, Y: p.Y})
+		}
+		return res
+	default:
+		return sql.GeometryCollection{Geoms: geoms}
+	}
+}
+
+// overlayNodes are the points where the edges of two geometries are split. The pieces of both geometries are snapped
+// to the same nodes, so that they meet exactly where their edges intersect, although the intersection is computed
+// separately for each.
+type overlayNodes struct {
+	points    []sql.Point
+	tolerance float64
+}
+
+// newOverlayNodes returns the nodes of two geometries, starting with their vertices. The tolerance for snapping
+// points to the nodes is relative to the magnitude of the coordinates.
+func newOverlayNodes(a, b geometryParts) *overlayNodes {
+	n := &overlayNodes{}
+	scale := 1.0
+	for _, g := range []geometryParts{a, b} {
+		for _, v := range g.vertices() {
+			scale = math.Max(scale, math.Max(math.Abs(v.X), math.Abs(v.Y)))
+			n.snap(v)
+		}
+	}
+	n.tolerance = scale * 1e-9
+	return n
+}
+
+// snap returns the node at the point, adding it if there's none.
+func (n *overlayNodes) snap(p sql.Point) sql.Point {
+	for _, q := range n.points {
+		if math.Abs(p.X-q.X) <= n.tolerance && math.Abs(p.Y-q.Y) <= n.tolerance {
+			return q
+		}
+	}
+	p = sql.Point{X: p.X, Y: p.Y}
+	n.points = append(n.points, p)
+	return p
+}
+
+// split returns the pieces of the segment from [a] to [b] split by [segments] and [vertices], snapped to the nodes.
+// Pieces collapsing to a single node are dropped.
+func (n *overlayNodes) split(a, b sql.Point, segments [][2]sql.Point, vertices []sql.Point) [][2]sql.Point {
+	points := splitSegment(a, b, segments, vertices)
+	var res [][2]sql.Point
+	for i := 1; i < len(points); i++ {
+		p, q := n.snap(points[i-1]), n.snap(points[i])
+		if p.X != q.X || p.Y != q.Y {
+			res = append(res, [2]sql.Point{p, q})
+		}
+	}
+	return res
+}
+
+// clipLinestring splits a linestring by [segments] and [vertices], and returns the runs of consecutive pieces that are
+// kept. The points splitting a segment of the linestring in the middle of a run are removed again.
+func clipLinestring(l []sql.Point, segments [][2]sql.Point, vertices []sql.Point, nodes *overlayNodes, keep func(p, q sql.Point) bool) [][]sql.Point {
+	var res [][]sql.Point
+	var run []sql.Point
+	flush := func() {
+		if len(run) > 1 {
+			res = append(res, run)
+		}
+		run = nil
+	}
+	for i := 1; i < len(l); i++ {
+		pieces := nodes.split(l[i-1], l[i], segments, vertices)
+		for j, piece := range pieces {
+			if !keep(piece[0], piece[1]) {
+				flush()
+				continue
+			}
+			if len(run) == 0 {
+				run = append(run, piece[0])
+			} else if j > 0 {
+				// the last point of the run is inside the segment, so it's not a vertex of the linestring
+				run = run[:len(run)-1]
+			}
+			run = append(run, piece[1])
+		}
+	}
+	flush()
+	return res
+}
+
+// polygonRings returns the rings of polygons as paths.
+func polygonRings(polygons []sql.Polygon) [][]sql.Point {
+	var res [][]sql.Point
+	for _, p := range polygons {
+		for _, r := range p.Lines {
+			res = append(res, r.Points)
+		}
+	}
+	return res
+}
+
+// crossingPoints returns the ends of the pieces of the linestrings and rings of [a] split by those of [b], which
+// include every point where they cross or touch.
+func crossingPoints(a, b geometryParts, nodes *overlayNodes) []sql.Point {
+	segments, vertices := b.segments(), b.vertices()
+	var res []sql.Point
+	for _, s := range a.segments() {
+		for _, piece := range nodes.split(s[0], s[1], segments, vertices) {
+			res = append(res, piece[0], piece[1])
+		}
+	}
+	return res
+}
+
+// overlayEdge is a directed piece of a polygon ring, with the interior of the polygon on its left.
+type overlayEdge struct {
+	from, to sql.Point
+}
+
+// overlayEdges are the pieces of the rings of one set of polygons split by the rings of another, classified by their
+// location relative to the other polygons. Pieces on the boundary of the other polygons are either in the [same]
+// direction as it, with both interiors on the same side, or in the [opposite] direction.
+type overlayEdges struct {
+	inside, outside, same, opposite []overlayEdge
+}
+
+// overlayPolygons returns the polygons resulting from an operation on two sets of polygons, using the method of
+// Greiner and Hormann: the rings of each set are split where they cross the other, the pieces that bound the result
+// are selected by their location relative to the other set, and the selected pieces are linked into new rings.
+func overlayPolygons(a, b []sql.Polygon, op overlayOp, nodes *overlayNodes) []sql.Polygon {
+	edgesA := classifyRingEdges(a, b, nodes)
+	edgesB := classifyRingEdges(b, a, nodes)
+
+	var edges []overlayEdge
+	switch op {
+	case overlayUnion:
+		edges = append(edges, edgesA.outside...)
+		edges = append(edges, edgesB.outside...)
+		edges = append(edges, edgesA.same...)
+	case overlayIntersection:
+		edges = append(edges, edgesA.inside...)
+		edges = append(edges, edgesB.inside...)
+		edges = append(edges, edgesA.same...)
+	case overlayDifference:
+		edges = append(edges, edgesA.outside...)
+		for _, e := range edgesB.inside {
+			edges = append(edges, overlayEdge{from: e.to, to: e.from})
+		}
+		edges = append(edges, edgesA.opposite...)
+	}
+
+	return assemblePolygons(linkRings(edges))
+}
+
+// classifyRingEdges splits the rings of [polygons] by the rings of [other], orients them so that the interior is on
+// the left, and classifies the pieces by the location of their midpoints relative to [other].
+func classifyRingEdges(polygons, other []sql.Polygon, nodes *overlayNodes) overlayEdges {
+	var res overlayEdges
+	otherParts := geometryParts{polygons: other}
+	segments, vertices := otherParts.segments(), otherParts.vertices()
+	for _, poly := range polygons {
+		for i, r := range poly.Lines {
+			ring := r.Points
+			// exterior rings run counterclockwise and holes clockwise, which puts the interior on the left
+			if (ringSignedArea(ring) < 0) == (i == 0) {
+				ring = reversePoints(ring)
+			}
+			for j := 1; j < len(ring); j++ {
+				for _, piece := range nodes.split(ring[j-1], ring[j], segments, vertices) {
+					e := overlayEdge{from: piece[0], to: piece[1]}
+					mid := sql.Point{X: (e.from.X + e.to.X) / 2, Y: (e.from.Y + e.to.Y) / 2}
+					switch otherParts.locate(mid) {
+					case locationInterior:
+						res.inside = append(res.inside, e)
+					case locationExterior:
+						res.outside = append(res.outside, e)
+					default:
+						dx, dy := e.to.X-e.from.X, e.to.Y-e.from.Y
+						eps := 1e-7
+						left := sql.Point{X: mid.X - dy*eps, Y: mid.Y + dx*eps}
+						if otherParts.locate(left) == locationInterior {
+							res.same = append(res.same, e)
+						} else {
+							res.opposite = append(res.opposite, e)
+						}
+					}
+				}
+			}
+		}
+	}
+	return res
+}
+
+// linkRings links directed edges into closed rings. Where several edges leave a node, the ring takes the sharpest turn
+// to the left, so that rings touching at a node are kept apart.
+func linkRings(edges []overlayEdge) [][]sql.Point {
+	type node struct{ x, y float64 }
+	outgoing := make(map[node][]int)
+	for i, e := range edges {
+		n := node{e.from.X, e.from.Y}
+		outgoing[n] = append(outgoing[n], i)
+	}
+
+	used := make([]bool, len(edges))
+	var rings [][]sql.Point
+	for i := range edges {
+		if used[i] {
+			continue
+		}
+		used[i] = true
+		start := edges[i].from
+		ring := []sql.Point{edges[i].from, edges[i].to}
+		cur := edges[i]
+		for cur.to.X != start.X || cur.to.Y != start.Y {
+			back := math.Atan2(cur.from.Y-cur.to.Y, cur.from.X-cur.to.X)
+			next, best := -1, math.Inf(1)
+			for _, j := range outgoing[node{cur.to.X, cur.to.Y}] {
+				if used[j] {
+					continue
+				}
+				// the clockwise angle from the edge back to the outgoing edge, which is smallest for the leftmost turn
+				angle := math.Mod(back-math.Atan2(edges[j].to.Y-edges[j].from.Y, edges[j].to.X-edges[j].from.X)+4*math.Pi, 2*math.Pi)
+				if angle == 0 {
+					angle = 2 * math.Pi
+				}
+				if angle < best {
+					next, best = j, angle
+				}
+			}
+			if next < 0 {
+				// the edges don't close, which only happens for invalid input
+				ring = nil
+				break
+			}
+			used[next] = true
+			cur = edges[next]
+			ring = append(ring, cur.to)
+		}
+		if ring != nil {
+			rings = append(rings, ring)
+		}
+	}
+	return rings
+}
+
+// assemblePolygons returns the polygons of closed rings. Counterclockwise rings are exteriors, and clockwise rings are
+// holes of the smallest exterior around them. Vertices in the middle of straight edges are removed, and rings without
+// area are dropped.
+func assemblePolygons(rings [][]sql.Point) []sql.Polygon {
+	var shells, holes [][]sql.Point
+	for _, r := range rings {
+		r = removeCollinearPoints(r)
+		if len(r) < 4 {
+			continue
+		}
+		switch area := ringSignedArea(r); {
+		case area > 0:
+			shells = append(shells, normalizeRing(r))
+		case area < 0:
+			holes = append(holes, normalizeRing(r))
+		}
+	}
+
+	sort.Slice(shells, func(i, j int) bool {
+		return lessPoint(shells[i][0], shells[j][0])
+	})
+	polygons := make([]sql.Polygon, len(shells))
+	for i, s := range shells {
+		polygons[i].Lines = []sql.Linestring{{Points: s}}
+	}
+	for _, h := range holes {
+		p := sql.Point{X: (h[0].X + h[1].X) / 2, Y: (h[0].Y + h[1].Y) / 2}
+		shell, smallest := -1, math.Inf(1)
+		for i, s := range shells {
+			if area := ringSignedArea(s); area < smallest && pointInRing(s, p) {
+				shell, smallest = i, area
+			}
+		}
+		if shell >= 0 {
+			polygons[shell].Lines = append(polygons[shell].Lines, sql.Linestring{Points: h})
+		}
+	}
+	return polygons
+}
+
+// removeCollinearPoints returns a closed ring without the vertices in the middle of straight edges.
+func removeCollinearPoints(ring []sql.Point) []sql.Point {
+	points := append([]sql.Point(nil), ring[:len(ring)-1]...)
+	for removed := true; removed && len(points) >= 3; {
+		removed = false
+		for i := 0; i < len(points) && len(points) >= 3; i++ {
+			a, b, c := points[(i+len(points)-1)%len(points)], points[i], points[(i+1)%len(points)]
+			cross := (b.X-a.X)*(c.Y-b.Y) - (b.Y-a.Y)*(c.X-b.X)
+			if math.Abs(cross) <= 1e-12*math.Hypot(b.X-a.X, b.Y-a.Y)*math.Hypot(c.X-b.X, c.Y-b.Y) {
+				points = append(points[:i], points[i+1:]...)
+				removed = true
+				i--
+			}
+		}
+	}
+	return append(points, points[0])
+}
+
+// normalizeRing rotates a closed ring to start at its lowest vertex, the leftmost of those with the least y.
+func normalizeRing(ring []sql.Point) []sql.Point {
+	points := ring[:len(ring)-1]
+	first := 0
+	for i, p := range points {
+		if p.Y < points[first].Y || p.Y == points[first].Y && p.X < points[first].X {
+			first = i
+		}
+	}
+	res := append(append([]sql.Point(nil), points[first:]...), points[:first]...)
+	return append(res, res[0])
+}
+
+// lessPoint orders points by their y, and then their x coordinate.
+func lessPoint(p, q sql.Point) bool {
+	return p.Y < q.Y || p.Y == q.Y && p.X < q.X
+}
@@ -0,0 +1,182 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestOverlay(t *testing.T) {
+	ring := func(points ...float64) sql.Linestring {
+		var l sql.Linestring
+		for i := 0; i < len(points); i += 2 {
+			l.Points = append(l.Points, sql.Point{X: points[i], Y: points[i+1]})
+		}
+		return l
+	}
+	square := func(x1, y1, x2, y2 float64) sql.Polygon {
+		return sql.Polygon{Lines: []sql.Linestring{ring(x1, y1, x2, y1, x2, y2, x1, y2, x1, y1)}}
+	}
+	// polygonArea returns the area of a polygon or multipolygon, less the area of its holes
+	var polygonArea func(g interface{}) float64
+	polygonArea = func(g interface{}) float64 {
+		switch g := g.(type) {
+		case sql.Polygon:
+			area := ringArea(g.Lines[0].Points)
+			for _, h := range g.Lines[1:] {
+				area -= ringArea(h.Points)
+			}
+			return area
+		case sql.MultiPolygon:
+			var area float64
+			for _, p := range g.Polygons {
+				area += polygonArea(p)
+			}
+			return area
+		}
+		t.Fatalf("not a polygon: %#v", g)
+		return 0
+	}
+	empty := sql.GeometryCollection{Geoms: []interface{}{}}
+	eval := func(t *testing.T, newOperation func(g1, g2 sql.Expression) sql.Expression, g1, g2 interface{}) interface{} {
+		f := newOperation(expression.NewLiteral(g1, sql.GeometryType{}), expression.NewLiteral(g2, sql.GeometryType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(t, err)
+		return v
+	}
+
+	t.Run("overlapping squares", func(t *testing.T) {
+		require := require.New(t)
+		a, b := square(0, 0, 4, 4), square(2, 2, 6, 6)
+
+		res := eval(t, NewIntersection, a, b)
+		require.Equal(square(2, 2, 4, 4), res)
+		require.Equal(4.0, polygonArea(res))
+
+		res = eval(t, NewUnion, a, b)
+		require.IsType(sql.Polygon{}, res)
+		require.Len(res.(sql.Polygon).Lines, 1)
+		require.Len(res.(sql.Polygon).Lines[0].Points, 9)
+		require.Equal(28.0, polygonArea(res))
+
+		res = eval(t, NewDifference, a, b)
+		require.IsType(sql.Polygon{}, res)
+		require.Len(res.(sql.Polygon).Lines[0].Points, 7)
+		require.Equal(12.0, polygonArea(res))
+	})
+
+	t.Run("diamond over square", func(t *testing.T) {
+		require := require.New(t)
+		a := square(0, 0, 4, 4)
+		b := sql.Polygon{Lines: []sql.Linestring{ring(5, 0, 8, 3, 5, 6, 2, 3, 5, 0)}}
+		require.InDelta(3.5, polygonArea(eval(t, NewIntersection, a, b)), 1e-9)
+		require.InDelta(30.5, polygonArea(eval(t, NewUnion, a, b)), 1e-9)
+		require.InDelta(12.5, polygonArea(eval(t, NewDifference, a, b)), 1e-9)
+		require.InDelta(14.5, polygonArea(eval(t, NewDifference, b, a)), 1e-9)
+	})
+
+	t.Run("clockwise rings", func(t *testing.T) {
+		require := require.New(t)
+		a := sql.Polygon{Lines: []sql.Linestring{ring(0, 0, 0, 4, 4, 4, 4, 0, 0, 0)}}
+		require.Equal(square(2, 2, 4, 4), eval(t, NewIntersection, a, square(2, 2, 6, 6)))
+	})
+
+	t.Run("polygon inside polygon", func(t *testing.T) {
+		require := require.New(t)
+		outer, inner := square(0, 0, 6, 6), square(2, 2, 4, 4)
+		require.Equal(inner, eval(t, NewIntersection, outer, inner))
+		require.Equal(outer, eval(t, NewUnion, outer, inner))
+
+		res := eval(t, NewDifference, outer, inner)
+		require.Equal(sql.Polygon{Lines: []sql.Linestring{
+			ring(0, 0, 6, 0, 6, 6, 0, 6, 0, 0),
+			ring(2, 2, 2, 4, 4, 4, 4, 2, 2, 2),
+		}}, res)
+		require.Equal(32.0, polygonArea(res))
+
+		require.Equal(empty, eval(t, NewDifference, inner, outer))
+	})
+
+	t.Run("disjoint polygons", func(t *testing.T) {
+		require := require.New(t)
+		a, b := square(0, 0, 2, 2), square(4, 4, 6, 6)
+		require.Equal(sql.MultiPolygon{Polygons: []sql.Polygon{a, b}}, eval(t, NewUnion, a, b))
+		require.Equal(empty, eval(t, NewIntersection, a, b))
+		require.Equal(a, eval(t, NewDifference, a, b))
+	})
+
+	t.Run("polygons sharing an edge", func(t *testing.T) {
+		require := require.New(t)
+		a, b := square(0, 0, 2, 2), square(2, 0, 4, 2)
+		require.Equal(square(0, 0, 4, 2), eval(t, NewUnion, a, b))
+		require.Equal(ring(2, 0, 2, 2), eval(t, NewIntersection, a, b))
+		require.Equal(a, eval(t, NewDifference, a, b))
+	})
+
+	t.Run("polygons sharing a corner", func(t *testing.T) {
+		require := require.New(t)
+		a, b := square(0, 0, 2, 2), square(2, 2, 4, 4)
+		require.Equal(sql.MultiPolygon{Polygons: []sql.Polygon{a, b}}, eval(t, NewUnion, a, b))
+		require.Equal(sql.Point{X: 2, Y: 2}, eval(t, NewIntersection, a, b))
+	})
+
+	t.Run("linestrings", func(t *testing.T) {
+		require := require.New(t)
+		l := ring(0, 0, 4, 0)
+		require.Equal(sql.Point{X: 2, Y: 0}, eval(t, NewIntersection, l, ring(2, -2, 2, 2)))
+		require.Equal(ring(2, 0, 4, 0), eval(t, NewIntersection, l, ring(2, 0, 6, 0)))
+		require.Equal(ring(0, 0, 2, 0), eval(t, NewDifference, l, ring(2, 0, 6, 0)))
+		require.Equal(ring(1, 0, 3, 0), eval(t, NewIntersection, ring(-1, 0, 5, 0), square(1, -1, 3, 1)))
+		require.Equal(sql.MultiLinestring{Lines: []sql.Linestring{ring(-1, 0, 1, 0), ring(3, 0, 5, 0)}}, eval(t, NewDifference, ring(-1, 0, 5, 0), square(1, -1, 3, 1)))
+		require.Equal(sql.GeometryCollection{Geoms: []interface{}{square(1, -1, 3, 1), ring(-1, 0, 1, 0), ring(3, 0, 5, 0)}}, eval(t, NewUnion, ring(-1, 0, 5, 0), square(1, -1, 3, 1)))
+	})
+
+	t.Run("points", func(t *testing.T) {
+		require := require.New(t)
+		p, q := sql.Point{X: 1, Y: 1}, sql.Point{X: 5, Y: 5}
+		require.Equal(p, eval(t, NewIntersection, p, square(0, 0, 2, 2)))
+		require.Equal(empty, eval(t, NewIntersection, q, square(0, 0, 2, 2)))
+		require.Equal(sql.MultiPoint{Points: []sql.Point{p, q}}, eval(t, NewUnion, p, q))
+		require.Equal(square(0, 0, 2, 2), eval(t, NewUnion, p, square(0, 0, 2, 2)))
+		require.Equal(q, eval(t, NewDifference, sql.MultiPoint{Points: []sql.Point{p, q}}, square(0, 0, 2, 2)))
+		require.Equal(ring(0, 0, 4, 0), eval(t, NewDifference, ring(0, 0, 4, 0), sql.Point{X: 2, Y: 0}))
+	})
+
+	t.Run("null", func(t *testing.T) {
+		require := require.New(t)
+		require.Nil(eval(t, NewUnion, nil, square(0, 0, 2, 2)))
+		require.Nil(eval(t, NewIntersection, square(0, 0, 2, 2), nil))
+		require.Nil(eval(t, NewDifference, nil, nil))
+	})
+
+	t.Run("srid", func(t *testing.T) {
+		require := require.New(t)
+		a, b := square(0, 0, 4, 4), square(2, 2, 6, 6)
+		a.SRID, b.SRID = GeoSpatialSRID, GeoSpatialSRID
+		res := eval(t, NewIntersection, a, b)
+		srid, err := geometrySRID(res)
+		require.NoError(err)
+		require.Equal(uint32(GeoSpatialSRID), srid)
+
+		f := NewUnion(expression.NewLiteral(a, sql.PolygonType{}), expression.NewLiteral(square(2, 2, 6, 6), sql.PolygonType{}))
+		_, err = f.Eval(sql.NewEmptyContext(), nil)
+		require.True(sql.ErrDiffSRIDs.Is(err))
+	})
+}
@@ -0,0 +1,178 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// PeriodAdd is a function that adds a number of months to a period in the format YYMM or YYYYMM.
+type PeriodAdd struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*PeriodAdd)(nil)
+
+// NewPeriodAdd creates a new PERIOD_ADD expression.
+func NewPeriodAdd(period, months sql.Expression) sql.Expression {
+	return &PeriodAdd{
+		expression.BinaryExpression{
+			Left:  period,
+			Right: months,
+		},
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (p *PeriodAdd) FunctionName() string {
+	return "period_add"
+}
+
+// Description implements sql.FunctionExpression
+func (p *PeriodAdd) Description() string {
+	return "adds a number of months to a period in the format YYMM or YYYYMM, and returns a period in the format YYYYMM."
+}
+
+// Type implements the sql.Expression interface.
+func (p *PeriodAdd) Type() sql.Type {
+	return sql.Int64
+}
+
+func (p *PeriodAdd) String() string {
+	return fmt.Sprintf("PERIOD_ADD(%s, %s)", p.Left, p.Right)
+}
+
+// WithChildren implements the sql.Expression interface.
+func (p *PeriodAdd) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(p, len(children), 2)
+	}
+	return NewPeriodAdd(children[0], children[1]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (p *PeriodAdd) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	period, err := evalInt64(ctx, row, p.Left)
+	if err != nil || period == nil {
+		return nil, err
+	}
+	months, err := evalInt64(ctx, row, p.Right)
+	if err != nil || months == nil {
+		return nil, err
+	}
+
+	// like in MySQL, the empty period stays empty
+	if *period == 0 {
+		return int64(0), nil
+	}
+	m, ok := periodToMonths(*period)
+	if !ok || m+*months < 0 {
+		return nil, sql.ErrInvalidArgument.New(p.FunctionName())
+	}
+	return monthsToPeriod(m + *months), nil
+}
+
+// PeriodDiff is a function that returns the number of months between two periods in the format YYMM or YYYYMM.
+type PeriodDiff struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*PeriodDiff)(nil)
+
+// NewPeriodDiff creates a new PERIOD_DIFF expression.
+func NewPeriodDiff(period1, period2 sql.Expression) sql.Expression {
+	return &PeriodDiff{
+		expression.BinaryExpression{
+			Left:  period1,
+			Right: period2,
+		},
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (p *PeriodDiff) FunctionName() string {
+	return "period_diff"
+}
+
+// Description implements sql.FunctionExpression
+func (p *PeriodDiff) Description() string {
+	return "returns the number of months between periods in the format YYMM or YYYYMM."
+}
+
+// Type implements the sql.Expression interface.
+func (p *PeriodDiff) Type() sql.Type {
+	return sql.Int64
+}
+
+func (p *PeriodDiff) String() string {
+	return fmt.Sprintf("PERIOD_DIFF(%s, %s)", p.Left, p.Right)
+}
+
+// WithChildren implements the sql.Expression interface.
+func (p *PeriodDiff) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(p, len(children), 2)
+	}
+	return NewPeriodDiff(children[0], children[1]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (p *PeriodDiff) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	period1, err := evalInt64(ctx, row, p.Left)
+	if err != nil || period1 == nil {
+		return nil, err
+	}
+	period2, err := evalInt64(ctx, row, p.Right)
+	if err != nil || period2 == nil {
+		return nil, err
+	}
+
+	m1, ok := periodToMonths(*period1)
+	if !ok {
+		return nil, sql.ErrInvalidArgument.New(p.FunctionName())
+	}
+	m2, ok := periodToMonths(*period2)
+	if !ok {
+		return nil, sql.ErrInvalidArgument.New(p.FunctionName())
+	}
+	return m1 - m2, nil
+}
+
+// periodToMonths returns the number of months since the start of year 0 of a period in the format YYMM or YYYYMM.
+// Two digit years are in 1970 - 2069, and the empty period 0 is 0 months. It returns false for negative periods and
+// periods whose month isn't between 1 and 12.
+func periodToMonths(period int64) (int64, bool) {
+	if period == 0 {
+		return 0, true
+	}
+	year, month := period/100, period%100
+	if period < 0 || month < 1 || month > 12 {
+		return 0, false
+	}
+	if year < 70 {
+		year += 2000
+	} else if year < 100 {
+		year += 1900
+	}
+	return year*12 + month - 1, true
+}
+
+// monthsToPeriod returns the period in the format YYYYMM of a number of months since the start of year 0.
+func monthsToPeriod(months int64) int64 {
+	return months/12*100 + months%12 + 1
+}
@@ -0,0 +1,95 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestPeriodAdd(t *testing.T) {
+	testCases := []struct {
+		name     string
+		period   interface{}
+		months   interface{}
+		expected interface{}
+		err      bool
+	}{
+		{"add months", 200801, 2, int64(200803), false},
+		{"year rollover", 200812, 2, int64(200902), false},
+		{"several years", 200812, 25, int64(201101), false},
+		{"subtract months", 200801, -1, int64(200712), false},
+		{"no months", 200805, 0, int64(200805), false},
+		{"two digit year", 801, 2, int64(200803), false},
+		{"two digit year in last century", 9912, 1, int64(200001), false},
+		{"string period", "200801", 2, int64(200803), false},
+		{"empty period", 0, 2, int64(0), false},
+		{"month zero", 200800, 1, nil, true},
+		{"month out of range", 200813, 1, nil, true},
+		{"negative period", -200801, 1, nil, true},
+		{"null period", nil, 2, nil, false},
+		{"null months", 200801, nil, nil, false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			f := NewPeriodAdd(expression.NewLiteral(tt.period, sql.Int64), expression.NewLiteral(tt.months, sql.Int64))
+			res, err := f.Eval(sql.NewEmptyContext(), nil)
+			if tt.err {
+				require.True(sql.ErrInvalidArgument.Is(err))
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.expected, res)
+		})
+	}
+}
+
+func TestPeriodDiff(t *testing.T) {
+	testCases := []struct {
+		name     string
+		period1  interface{}
+		period2  interface{}
+		expected interface{}
+		err      bool
+	}{
+		{"same year", 200803, 200801, int64(2), false},
+		{"negative", 200801, 200803, int64(-2), false},
+		{"across years", 200902, 200812, int64(2), false},
+		{"two digit years", 802, 200712, int64(2), false},
+		{"equal", 200801, 200801, int64(0), false},
+		{"month out of range", 200813, 200801, nil, true},
+		{"null period", nil, 200801, nil, false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			f := NewPeriodDiff(expression.NewLiteral(tt.period1, sql.Int64), expression.NewLiteral(tt.period2, sql.Int64))
+			res, err := f.Eval(sql.NewEmptyContext(), nil)
+			if tt.err {
+				require.True(sql.ErrInvalidArgument.Is(err))
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.expected, res)
+		})
+	}
+}
@@ -18,19 +18,24 @@ import (
 	"fmt"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
 )
 
-// Point is a function that returns a point type containing values Y and Y.
+// Point is a function that returns a point type containing values X and Y.
 type Point struct {
-	X sql.Expression
-	Y sql.Expression
+	expression.BinaryExpression
 }
 
 var _ sql.FunctionExpression = (*Point)(nil)
 
 // NewPoint creates a new point expression.
 func NewPoint(e1, e2 sql.Expression) sql.Expression {
-	return &Point{e1, e2}
+	return &Point{
+		expression.BinaryExpression{
+			Left:  e1,
+			Right: e2,
+		},
+	}
 }
 
 // FunctionName implements sql.FunctionExpression
@@ -43,28 +48,13 @@ func (p *Point) Description() string {
 	return "returns a new point."
 }
 
-// Children implements the sql.Expression interface.
-func (p *Point) Children() []sql.Expression {
-	return []sql.Expression{p.X, p.Y}
-}
-
-// Resolved implements the sql.Expression interface.
-func (p *Point) Resolved() bool {
-	return p.X.Resolved() && p.Y.Resolved()
-}
-
-// IsNullable implements the sql.Expression interface.
-func (p *Point) IsNullable() bool {
-	return p.X.IsNullable() || p.Y.IsNullable()
-}
-
 // Type implements the sql.Expression interface.
 func (p *Point) Type() sql.Type {
 	return sql.PointType{}
 }
 
 func (p *Point) String() string {
-	return fmt.Sprintf("POINT(%f, %f)", p.X, p.Y)
+	return fmt.Sprintf("POINT(%s,%s)", p.Left, p.Right)
 }
 
 // WithChildren implements the Expression interface.
@@ -78,7 +68,7 @@ func (p *Point) WithChildren(children ...sql.Expression) (sql.Expression, error)
 // Eval implements the sql.Expression interface.
 func (p *Point) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	// Evaluate X
-	x, err := p.X.Eval(ctx, row)
+	x, err := p.Left.Eval(ctx, row)
 	if err != nil {
 		return nil, err
 	}
@@ -93,7 +83,7 @@ func (p *Point) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	}
 
 	// Evaluate Y
-	y, err := p.Y.Eval(ctx, row)
+	y, err := p.Right.Eval(ctx, row)
 	if err != nil {
 		return nil, err
 	}
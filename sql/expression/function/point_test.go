@@ -78,4 +78,36 @@ func TestPoint(t *testing.T) {
 		require.NoError(err)
 		require.Equal(nil, v)
 	})
+
+	t.Run("create point with non-numeric x", func(t *testing.T) {
+		require := require.New(t)
+		f := NewPoint(expression.NewLiteral("abc", sql.Text),
+			expression.NewLiteral(2, sql.Int32),
+		)
+
+		_, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.Error(err)
+	})
+
+	t.Run("create point with non-numeric y", func(t *testing.T) {
+		require := require.New(t)
+		f := NewPoint(expression.NewLiteral(1, sql.Int32),
+			expression.NewLiteral(sql.Point{X: 1, Y: 2}, sql.PointType{}),
+		)
+
+		_, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.Error(err)
+	})
+
+	t.Run("point as wkt", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewAsWKT(NewPoint(expression.NewLiteral(1, sql.Int64),
+			expression.NewLiteral(-2.5, sql.Float64),
+		))
+		require.NoError(err)
+
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal("POINT(1 -2.5)", v)
+	})
 }
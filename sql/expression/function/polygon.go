@@ -25,7 +25,7 @@ import (
 	"github.com/dolthub/go-mysql-server/sql"
 )
 
-// Polygon is a function that returns a polygon type containing values Y and Y.
+// Polygon is a function that returns a polygon with its linestring arguments as rings.
 type Polygon struct {
 	expression.NaryExpression
 }
@@ -89,6 +89,13 @@ func onSegment(a, b, c sql.Point) bool {
 
 // TODO: https://www.geeksforgeeks.org/check-if-two-given-line-segments-intersect/
 func lineSegmentsIntersect(a, b, c, d sql.Point) bool {
+	// segments whose bounding boxes are apart can't intersect, even when rounding makes nearly collinear points look
+	// like they are on different sides
+	if math.Max(a.X, b.X) < math.Min(c.X, d.X) || math.Max(c.X, d.X) < math.Min(a.X, b.X) ||
+		math.Max(a.Y, b.Y) < math.Min(c.Y, d.Y) || math.Max(c.Y, d.Y) < math.Min(a.Y, b.Y) {
+		return false
+	}
+
 	abc := pointOrientation(a, b, c)
 	abd := pointOrientation(a, b, d)
 	cda := pointOrientation(c, d, a)
@@ -117,18 +124,12 @@ func lineSegmentsIntersect(a, b, c, d sql.Point) bool {
 	return false
 }
 
-// TODO: should go in line?
 func isLinearRing(line sql.Linestring) bool {
-	// Get number of points
-	numPoints := len(line.Points)
-	// Check length of Linestring (must be 0 or 4+) points
-	if numPoints != 0 && numPoints < 4 {
-		return false
-	}
-	// Check if it is closed (first and last point are the same)
-	if line.Points[0] != line.Points[numPoints-1] {
+	// Check length and that it is closed
+	if !line.IsLinearRing() {
 		return false
 	}
+	numPoints := len(line.Points)
 	return true // TODO: MySQL appears to not check this, and there are issues so return true for now
 	// TODO: how to deal with same point?
 	// TODO: easy, but slow O(n^2) solution; apparently O(nlogn) exists
@@ -157,6 +158,8 @@ func (p *Polygon) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		}
 		// Must be of type linestring, throw error otherwise
 		switch v := val.(type) {
+		case nil:
+			return nil, nil
 		case sql.Linestring:
 			// Check that line is a linear ring
 			if isLinearRing(v) {
@@ -46,6 +46,49 @@ func TestPolygon(t *testing.T) {
 		require.Equal(sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 1, Y: 0}, {X: 0, Y: 0}}}, {Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 0}}}, {Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}, {X: 0, Y: 0}}}}}, v)
 	})
 
+	t.Run("create square polygon from point expressions", func(t *testing.T) {
+		require := require.New(t)
+		point := func(x, y int) sql.Expression {
+			return NewPoint(expression.NewLiteral(x, sql.Int64), expression.NewLiteral(y, sql.Int64))
+		}
+		ring, err := NewLinestring(point(0, 0), point(0, 2), point(2, 2), point(2, 0), point(0, 0))
+		require.NoError(err)
+		f, err := NewPolygon(ring)
+		require.NoError(err)
+
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 2, Y: 2}, {X: 2, Y: 0}, {X: 0, Y: 0}}}}}, v)
+	})
+
+	t.Run("create polygon with null linestring", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewPolygon(expression.NewLiteral(nil, sql.Null))
+		require.NoError(err)
+
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Nil(v)
+	})
+
+	t.Run("create invalid using empty linestring", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewPolygon(expression.NewLiteral(sql.Linestring{}, sql.LinestringType{}))
+		require.NoError(err)
+
+		_, err = f.Eval(sql.NewEmptyContext(), nil)
+		require.Error(err)
+	})
+
+	t.Run("create invalid using unclosed linestring", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewPolygon(expression.NewLiteral(sql.Linestring{Points: []sql.Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 2, Y: 2}, {X: 2, Y: 0}}}, sql.LinestringType{}))
+		require.NoError(err)
+
+		_, err = f.Eval(sql.NewEmptyContext(), nil)
+		require.Error(err)
+	})
+
 	t.Run("create invalid using invalid linestring", func(t *testing.T) {
 		require := require.New(t)
 		f, err := NewPolygon(expression.NewLiteral(sql.Linestring{Points: []sql.Point{{X: 0, Y: 0}}}, sql.LinestringType{}))
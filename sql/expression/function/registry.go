@@ -39,15 +39,15 @@ var BuiltIns = []sql.Function{
 	sql.Function1{Name: "atan", Fn: NewAtan},
 	sql.Function1{Name: "avg", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewAvg(e) }},
 	sql.Function1{Name: "bin", Fn: NewBin},
-	sql.FunctionN{Name: "bin_to_uuid", Fn: NewBinToUUID},
+	sql.FunctionN{Name: "bin_to_uuid", Fn: NewBinToUUID, MinArgs: 1, MaxArgs: 2},
 	sql.Function1{Name: "bit_length", Fn: NewBitlength},
 	sql.Function1{Name: "ceil", Fn: NewCeil},
 	sql.Function1{Name: "ceiling", Fn: NewCeil},
 	sql.Function1{Name: "char_length", Fn: NewCharLength},
 	sql.Function1{Name: "character_length", Fn: NewCharLength},
-	sql.FunctionN{Name: "coalesce", Fn: NewCoalesce},
-	sql.FunctionN{Name: "concat", Fn: NewConcat},
-	sql.FunctionN{Name: "concat_ws", Fn: NewConcatWithSeparator},
+	sql.FunctionN{Name: "coalesce", Fn: NewCoalesce, MinArgs: 1},
+	sql.FunctionN{Name: "concat", Fn: NewConcat, MinArgs: 1},
+	sql.FunctionN{Name: "concat_ws", Fn: NewConcatWithSeparator, MinArgs: 1},
 	sql.NewFunction0("connection_id", NewConnectionID),
 	sql.Function1{Name: "cos", Fn: NewCos},
 	sql.Function1{Name: "cot", Fn: NewCot},
@@ -57,16 +57,16 @@ var BuiltIns = []sql.Function{
 	sql.NewFunction0("curdate", NewCurrDate),
 	sql.NewFunction0("current_date", NewCurrentDate),
 	sql.NewFunction0("current_time", NewCurrentTime),
-	sql.FunctionN{Name: "current_timestamp", Fn: NewCurrTimestamp},
+	sql.FunctionN{Name: "current_timestamp", Fn: NewCurrTimestamp, MaxArgs: 1},
 	sql.NewFunction0("current_user", NewCurrentUser),
 	sql.NewFunction0("curtime", NewCurrTime),
 	sql.Function0{Name: "database", Fn: NewDatabase},
 	sql.Function1{Name: "date", Fn: NewDate},
-	sql.FunctionN{Name: "datetime", Fn: NewDatetime},
+	sql.FunctionN{Name: "datetime", Fn: NewDatetime, MinArgs: 1, MaxArgs: 1},
 	sql.Function2{Name: "datediff", Fn: NewDateDiff},
-	sql.FunctionN{Name: "date_add", Fn: NewDateAdd},
+	sql.FunctionN{Name: "date_add", Fn: NewDateAdd, MinArgs: 2, MaxArgs: 2},
 	sql.Function2{Name: "date_format", Fn: NewDateFormat},
-	sql.FunctionN{Name: "date_sub", Fn: NewDateSub},
+	sql.FunctionN{Name: "date_sub", Fn: NewDateSub, MinArgs: 2, MaxArgs: 2},
 	sql.Function1{Name: "day", Fn: NewDay},
 	sql.Function1{Name: "dayname", Fn: NewDayName},
 	sql.Function1{Name: "dayofmonth", Fn: NewDay},
@@ -77,10 +77,10 @@ var BuiltIns = []sql.Function{
 	sql.Function1{Name: "first", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewFirst(e) }},
 	sql.Function1{Name: "floor", Fn: NewFloor},
 	sql.Function0{Name: "found_rows", Fn: NewFoundRows},
-	sql.FunctionN{Name: "format", Fn: NewFormat},
+	sql.FunctionN{Name: "format", Fn: NewFormat, MinArgs: 2, MaxArgs: 3},
 	sql.Function1{Name: "from_base64", Fn: NewFromBase64},
-	sql.Function1{Name: "from_unixtime", Fn: NewFromUnixtime},
-	sql.FunctionN{Name: "greatest", Fn: NewGreatest},
+	sql.FunctionN{Name: "from_unixtime", Fn: NewFromUnixtime, MinArgs: 1, MaxArgs: 2},
+	sql.FunctionN{Name: "greatest", Fn: NewGreatest, MinArgs: 1},
 	sql.Function0{Name: "group_concat", Fn: aggregation.NewEmptyGroupConcat},
 	sql.Function1{Name: "hex", Fn: NewHex},
 	sql.Function1{Name: "hour", Fn: NewHour},
@@ -103,15 +103,15 @@ var BuiltIns = []sql.Function{
 	sql.Function2{Name: "json_objectagg", Fn: aggregation.NewJSONObjectAgg},
 	sql.FunctionN{Name: "json_array_append", Fn: NewJSONArrayAppend},
 	sql.FunctionN{Name: "json_array_insert", Fn: NewJSONArrayInsert},
-	sql.FunctionN{Name: "json_contains", Fn: NewJSONContains},
+	sql.FunctionN{Name: "json_contains", Fn: NewJSONContains, MinArgs: 2, MaxArgs: 3},
 	sql.FunctionN{Name: "json_contains_path", Fn: NewJSONContainsPath},
 	sql.FunctionN{Name: "json_depth", Fn: NewJSONDepth},
-	sql.FunctionN{Name: "json_extract", Fn: NewJSONExtract},
+	sql.FunctionN{Name: "json_extract", Fn: NewJSONExtract, MinArgs: 2},
 	sql.FunctionN{Name: "json_insert", Fn: NewJSONInsert},
 	sql.FunctionN{Name: "json_keys", Fn: NewJSONKeys},
 	sql.FunctionN{Name: "json_length", Fn: NewJSONLength},
 	sql.FunctionN{Name: "json_merge_patch", Fn: NewJSONMergePatch},
-	sql.FunctionN{Name: "json_merge_preserve", Fn: NewJSONMergePreserve},
+	sql.FunctionN{Name: "json_merge_preserve", Fn: NewJSONMergePreserve, MinArgs: 2},
 	sql.FunctionN{Name: "json_object", Fn: NewJSONObject},
 	sql.FunctionN{Name: "json_overlaps", Fn: NewJSONOverlaps},
 	sql.FunctionN{Name: "json_pretty", Fn: NewJSONPretty},
@@ -124,58 +124,65 @@ var BuiltIns = []sql.Function{
 	sql.FunctionN{Name: "json_set", Fn: NewJSONSet},
 	sql.FunctionN{Name: "json_storage_free", Fn: NewJSONStorageFree},
 	sql.FunctionN{Name: "json_storage_size", Fn: NewJSONStorageSize},
-	sql.FunctionN{Name: "json_table", Fn: NewJSONTable},
 	sql.FunctionN{Name: "json_type", Fn: NewJSONType},
 	sql.Function1{Name: "json_unquote", Fn: NewJSONUnquote},
 	sql.FunctionN{Name: "json_valid", Fn: NewJSONValid},
 	sql.FunctionN{Name: "json_value", Fn: NewJSONValue},
-	sql.FunctionN{Name: "lag", Fn: func(e ...sql.Expression) (sql.Expression, error) { return window.NewLag(e...) }},
+	sql.FunctionN{Name: "lag", Fn: func(e ...sql.Expression) (sql.Expression, error) { return window.NewLag(e...) }, MinArgs: 1, MaxArgs: 3},
 	sql.Function1{Name: "last", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewLast(e) }},
+	sql.Function1{Name: "last_day", Fn: NewLastDay},
 	sql.Function0{Name: "last_insert_id", Fn: NewLastInsertId},
 	sql.Function1{Name: "lcase", Fn: NewLower},
-	sql.FunctionN{Name: "least", Fn: NewLeast},
+	sql.FunctionN{Name: "least", Fn: NewLeast, MinArgs: 1},
 	sql.Function2{Name: "left", Fn: NewLeft},
 	sql.Function1{Name: "length", Fn: NewLength},
-	sql.FunctionN{Name: "linestring", Fn: NewLinestring},
+	sql.FunctionN{Name: "linestring", Fn: NewLinestring, MinArgs: 2},
 	sql.Function1{Name: "ln", Fn: NewLogBaseFunc(float64(math.E))},
 	sql.Function1{Name: "load_file", Fn: NewLoadFile},
-	sql.FunctionN{Name: "locate", Fn: NewLocate},
-	sql.FunctionN{Name: "log", Fn: NewLog},
+	sql.FunctionN{Name: "locate", Fn: NewLocate, MinArgs: 2, MaxArgs: 3},
+	sql.FunctionN{Name: "log", Fn: NewLog, MinArgs: 1, MaxArgs: 2},
 	sql.Function1{Name: "log10", Fn: NewLogBaseFunc(float64(10))},
 	sql.Function1{Name: "log2", Fn: NewLogBaseFunc(float64(2))},
 	sql.Function1{Name: "lower", Fn: NewLower},
-	sql.FunctionN{Name: "lpad", Fn: NewLeftPad},
+	sql.FunctionN{Name: "lpad", Fn: NewLeftPad, MinArgs: 3, MaxArgs: 3},
 	sql.Function1{Name: "ltrim", Fn: NewLeftTrim},
+	sql.Function2{Name: "makedate", Fn: NewMakeDate},
+	sql.Function3{Name: "maketime", Fn: NewMakeTime},
 	sql.Function1{Name: "max", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewMax(e) }},
 	sql.Function1{Name: "md5", Fn: NewMD5},
 	sql.Function1{Name: "microsecond", Fn: NewMicrosecond},
-	sql.FunctionN{Name: "mid", Fn: NewSubstring},
+	sql.FunctionN{Name: "mid", Fn: NewSubstring, MinArgs: 2, MaxArgs: 3},
 	sql.Function1{Name: "min", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewMin(e) }},
 	sql.Function1{Name: "minute", Fn: NewMinute},
 	sql.Function1{Name: "month", Fn: NewMonth},
 	sql.Function1{Name: "monthname", Fn: NewMonthName},
-	sql.FunctionN{Name: "now", Fn: NewNow},
+	sql.FunctionN{Name: "now", Fn: NewNow, MaxArgs: 1},
 	sql.Function2{Name: "nullif", Fn: NewNullIf},
+	sql.Function2{Name: "period_add", Fn: NewPeriodAdd},
+	sql.Function2{Name: "period_diff", Fn: NewPeriodDiff},
 	sql.Function2{Name: "point", Fn: NewPoint},
-	sql.FunctionN{Name: "polygon", Fn: NewPolygon},
+	sql.FunctionN{Name: "polygon", Fn: NewPolygon, MinArgs: 1},
 	sql.Function2{Name: "pow", Fn: NewPower},
 	sql.Function2{Name: "power", Fn: NewPower},
 	sql.Function1{Name: "radians", Fn: NewRadians},
-	sql.FunctionN{Name: "rand", Fn: NewRand},
-	sql.FunctionN{Name: "regexp_like", Fn: NewRegexpLike},
-	sql.FunctionN{Name: "regexp_replace", Fn: NewRegexpReplace},
+	sql.FunctionN{Name: "rand", Fn: NewRand, MaxArgs: 1},
+	sql.FunctionN{Name: "regexp_like", Fn: NewRegexpLike, MinArgs: 2, MaxArgs: 3},
+	sql.FunctionN{Name: "regexp_replace", Fn: NewRegexpReplace, MinArgs: 3, MaxArgs: 6},
 	sql.Function2{Name: "repeat", Fn: NewRepeat},
 	sql.Function3{Name: "replace", Fn: NewReplace},
 	sql.Function1{Name: "reverse", Fn: NewReverse},
 	sql.Function2{Name: "right", Fn: NewRight},
-	sql.FunctionN{Name: "round", Fn: NewRound},
+	sql.FunctionN{Name: "round", Fn: NewRound, MinArgs: 1, MaxArgs: 2},
 	sql.Function0{Name: "row_count", Fn: NewRowCount},
 	sql.Function0{Name: "row_number", Fn: window.NewRowNumber},
 	sql.Function0{Name: "percent_rank", Fn: window.NewPercentRank},
+	sql.Function0{Name: "rank", Fn: window.NewRank},
+	sql.Function0{Name: "dense_rank", Fn: window.NewDenseRank},
 	sql.Function1{Name: "first_value", Fn: window.NewFirstValue},
-	sql.FunctionN{Name: "rpad", Fn: NewRightPad},
+	sql.FunctionN{Name: "rpad", Fn: NewRightPad, MinArgs: 3, MaxArgs: 3},
 	sql.Function1{Name: "rtrim", Fn: NewRightTrim},
 	sql.Function0{Name: "schema", Fn: NewDatabase},
+	sql.Function1{Name: "sec_to_time", Fn: NewSecToTime},
 	sql.Function1{Name: "second", Fn: NewSecond},
 	sql.Function1{Name: "sha", Fn: NewSHA1},
 	sql.Function1{Name: "sha1", Fn: NewSHA1},
@@ -186,53 +193,91 @@ var BuiltIns = []sql.Function{
 	sql.Function1{Name: "soundex", Fn: NewSoundex},
 	sql.Function2{Name: "split", Fn: NewSplit},
 	sql.Function1{Name: "sqrt", Fn: NewSqrt},
-	sql.FunctionN{Name: "str_to_date", Fn: NewStrToDate},
+	sql.FunctionN{Name: "str_to_date", Fn: NewStrToDate, MinArgs: 2, MaxArgs: 2},
+	sql.Function1{Name: "st_area", Fn: NewArea},
 	sql.Function1{Name: "st_asbinary", Fn: NewAsWKB},
-	sql.FunctionN{Name: "st_asgeojson", Fn: NewAsGeoJSON},
+	sql.FunctionN{Name: "st_asgeojson", Fn: NewAsGeoJSON, MinArgs: 1, MaxArgs: 3},
 	sql.Function1{Name: "st_aswkb", Fn: NewAsWKB},
-	sql.Function1{Name: "st_aswkt", Fn: NewAsWKT},
-	sql.Function1{Name: "st_astext", Fn: NewAsWKT},
+	sql.FunctionN{Name: "st_aswkt", Fn: NewAsWKT, MinArgs: 1, MaxArgs: 3},
+	sql.FunctionN{Name: "st_astext", Fn: NewAsWKT, MinArgs: 1, MaxArgs: 3},
+	sql.Function1{Name: "st_boundary", Fn: NewBoundary},
+	sql.FunctionN{Name: "st_buffer", Fn: NewBuffer, MinArgs: 2, MaxArgs: 3},
+	sql.Function1{Name: "st_centroid", Fn: NewCentroid},
+	sql.Function1{Name: "st_collect", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewCollect(e) }},
+	sql.Function2{Name: "st_contains", Fn: NewContains},
+	sql.Function2{Name: "st_crosses", Fn: NewCrosses},
+	sql.Function2{Name: "st_difference", Fn: NewDifference},
 	sql.Function1{Name: "st_dimension", Fn: NewDimension},
-	sql.FunctionN{Name: "st_geomfromgeojson", Fn: NewGeomFromGeoJSON},
-	sql.FunctionN{Name: "st_geomfromtext", Fn: NewGeomFromWKT},
-	sql.FunctionN{Name: "st_geomfromwkb", Fn: NewGeomFromWKB},
-	sql.FunctionN{Name: "st_longitude", Fn: NewLongitude},
-	sql.FunctionN{Name: "st_linefromwkb", Fn: NewLineFromWKB},
-	sql.FunctionN{Name: "st_pointfromwkb", Fn: NewPointFromWKB},
-	sql.FunctionN{Name: "st_polyfromwkb", Fn: NewPolyFromWKB},
-	sql.FunctionN{Name: "st_geomfromwkt", Fn: NewGeomFromWKT},
-	sql.FunctionN{Name: "st_linefromwkt", Fn: NewLineFromWKT},
-	sql.FunctionN{Name: "st_pointfromwkt", Fn: NewPointFromWKT},
-	sql.FunctionN{Name: "st_polyfromwkt", Fn: NewPolyFromWKT},
-	sql.FunctionN{Name: "st_srid", Fn: NewSRID},
+	sql.Function2{Name: "st_disjoint", Fn: NewDisjoint},
+	sql.Function2{Name: "st_equals", Fn: NewSTEquals},
+	sql.Function1{Name: "st_flipcoordinates", Fn: NewSwapXY},
+	sql.Function1{Name: "st_forcepolygonccw", Fn: NewForcePolygonCCW},
+	sql.Function1{Name: "st_forcepolygoncw", Fn: NewForcePolygonCW},
+	sql.FunctionN{Name: "st_geomcollfromtext", Fn: NewGeomCollFromWKT, MinArgs: 1, MaxArgs: 3},
+	sql.FunctionN{Name: "st_geomcollfromwkt", Fn: NewGeomCollFromWKT, MinArgs: 1, MaxArgs: 3},
+	sql.FunctionN{Name: "st_geometrycollectionfromtext", Fn: NewGeomCollFromWKT, MinArgs: 1, MaxArgs: 3},
+	sql.FunctionN{Name: "st_geometrycollectionfromwkt", Fn: NewGeomCollFromWKT, MinArgs: 1, MaxArgs: 3},
+	sql.Function2{Name: "st_geohash", Fn: NewGeoHash},
+	sql.FunctionN{Name: "st_geomfromgeojson", Fn: NewGeomFromGeoJSON, MinArgs: 1, MaxArgs: 3},
+	sql.FunctionN{Name: "st_geomfromtext", Fn: NewGeomFromWKT, MinArgs: 1, MaxArgs: 3},
+	sql.FunctionN{Name: "st_geomfromwkb", Fn: NewGeomFromWKB, MinArgs: 1, MaxArgs: 3},
+	sql.Function2{Name: "st_intersection", Fn: NewIntersection},
+	sql.Function1{Name: "st_issimple", Fn: NewIsSimple},
+	sql.Function1{Name: "st_isvalid", Fn: NewIsValid},
+	sql.FunctionN{Name: "st_latitude", Fn: NewLatitude, MinArgs: 1, MaxArgs: 2},
+	sql.Function1{Name: "st_length", Fn: NewSTLength},
+	sql.FunctionN{Name: "st_longitude", Fn: NewLongitude, MinArgs: 1, MaxArgs: 2},
+	sql.FunctionN{Name: "st_linefromwkb", Fn: NewLineFromWKB, MinArgs: 1, MaxArgs: 3},
+	sql.Function2{Name: "st_makepoint", Fn: NewPoint},
+	sql.Function2{Name: "st_pointfromgeohash", Fn: NewPointFromGeoHash},
+	sql.FunctionN{Name: "st_pointfromwkb", Fn: NewPointFromWKB, MinArgs: 1, MaxArgs: 3},
+	sql.Function2{Name: "st_overlaps", Fn: NewOverlaps},
+	sql.FunctionN{Name: "st_polyfromwkb", Fn: NewPolyFromWKB, MinArgs: 1, MaxArgs: 3},
+	sql.FunctionN{Name: "st_geomfromwkt", Fn: NewGeomFromWKT, MinArgs: 1, MaxArgs: 3},
+	sql.FunctionN{Name: "st_linefromwkt", Fn: NewLineFromWKT, MinArgs: 1, MaxArgs: 3},
+	sql.FunctionN{Name: "st_pointfromwkt", Fn: NewPointFromWKT, MinArgs: 1, MaxArgs: 3},
+	sql.FunctionN{Name: "st_polyfromwkt", Fn: NewPolyFromWKT, MinArgs: 1, MaxArgs: 3},
+	sql.Function1{Name: "st_reverse", Fn: NewSTReverse},
+	sql.Function2{Name: "st_simplify", Fn: NewSimplify},
+	sql.FunctionN{Name: "st_srid", Fn: NewSRID, MinArgs: 1, MaxArgs: 2},
 	sql.Function1{Name: "st_swapxy", Fn: NewSwapXY},
-	sql.FunctionN{Name: "st_x", Fn: NewSTX},
-	sql.FunctionN{Name: "st_y", Fn: NewSTY},
-	sql.FunctionN{Name: "substr", Fn: NewSubstring},
-	sql.FunctionN{Name: "substring", Fn: NewSubstring},
+	sql.Function2{Name: "st_touches", Fn: NewTouches},
+	sql.Function2{Name: "st_transform", Fn: NewTransform},
+	sql.Function2{Name: "st_union", Fn: NewUnion},
+	sql.Function2{Name: "st_within", Fn: NewWithin},
+	sql.FunctionN{Name: "st_x", Fn: NewSTX, MinArgs: 1, MaxArgs: 2},
+	sql.FunctionN{Name: "st_y", Fn: NewSTY, MinArgs: 1, MaxArgs: 2},
+	sql.FunctionN{Name: "substr", Fn: NewSubstring, MinArgs: 2, MaxArgs: 3},
+	sql.FunctionN{Name: "substring", Fn: NewSubstring, MinArgs: 2, MaxArgs: 3},
 	sql.Function3{Name: "substring_index", Fn: NewSubstringIndex},
 	sql.Function1{Name: "sum", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewSum(e) }},
 	sql.Function1{Name: "tan", Fn: NewTan},
 	sql.Function2{Name: "time_format", Fn: NewTimeFormat},
 	sql.Function1{Name: "time_to_sec", Fn: NewTimeToSec},
 	sql.Function2{Name: "timediff", Fn: NewTimeDiff},
-	sql.FunctionN{Name: "timestamp", Fn: NewTimestamp},
+	sql.FunctionN{Name: "timestamp", Fn: NewTimestamp, MinArgs: 1, MaxArgs: 1},
+	sql.Function3{Name: "timestampadd", Fn: NewTimestampAdd},
 	sql.Function3{Name: "timestampdiff", Fn: NewTimestampDiff},
 	sql.Function1{Name: "to_base64", Fn: NewToBase64},
 	sql.Function1{Name: "ucase", Fn: NewUpper},
 	sql.Function1{Name: "unhex", Fn: NewUnhex},
-	sql.FunctionN{Name: "unix_timestamp", Fn: NewUnixTimestamp},
+	sql.FunctionN{Name: "unix_timestamp", Fn: NewUnixTimestamp, MaxArgs: 1},
 	sql.Function1{Name: "upper", Fn: NewUpper},
 	sql.NewFunction0("user", NewUser),
-	sql.FunctionN{Name: "utc_timestamp", Fn: NewUTCTimestamp},
+	sql.FunctionN{Name: "utc_timestamp", Fn: NewUTCTimestamp, MaxArgs: 1},
 	sql.Function0{Name: "uuid", Fn: NewUUIDFunc},
-	sql.FunctionN{Name: "uuid_to_bin", Fn: NewUUIDToBin},
-	sql.FunctionN{Name: "week", Fn: NewWeek},
+	sql.FunctionN{Name: "uuid_to_bin", Fn: NewUUIDToBin, MinArgs: 1, MaxArgs: 2},
+	sql.FunctionN{Name: "week", Fn: NewWeek, MinArgs: 1, MaxArgs: 2},
 	sql.Function1{Name: "values", Fn: NewValues},
 	sql.Function1{Name: "weekday", Fn: NewWeekday},
 	sql.Function1{Name: "weekofyear", Fn: NewWeekOfYear},
 	sql.Function1{Name: "year", Fn: NewYear},
-	sql.FunctionN{Name: "yearweek", Fn: NewYearWeek},
+	sql.FunctionN{Name: "yearweek", Fn: NewYearWeek, MinArgs: 1, MaxArgs: 2},
+}
+
+// BuiltInTableFunctions is the set of built-in table functions any integrator can use
+var BuiltInTableFunctions = []sql.TableFunction{
+	&JSONTable{},
 }
 
 func GetLockingFuncs(ls *sql.LockSubsystem) []sql.Function {
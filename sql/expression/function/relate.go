@@ -0,0 +1,344 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// Overlaps is a function that returns whether two geometries overlap.
+type Overlaps struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*Overlaps)(nil)
+
+// NewOverlaps creates a new ST_OVERLAPS expression.
+func NewOverlaps(g1, g2 sql.Expression) sql.Expression {
+	return &Overlaps{
+		expression.BinaryExpression{
+			Left:  g1,
+			Right: g2,
+		},
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (o *Overlaps) FunctionName() string {
+	return "st_overlaps"
+}
+
+// Description implements sql.FunctionExpression
+func (o *Overlaps) Description() string {
+	return "returns 1 or 0 to indicate whether g1 spatially overlaps g2."
+}
+
+// Type implements the sql.Expression interface.
+func (o *Overlaps) Type() sql.Type {
+	return sql.Boolean
+}
+
+func (o *Overlaps) String() string {
+	return fmt.Sprintf("ST_OVERLAPS(%s,%s)", o.Left, o.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (o *Overlaps) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(o, len(children), 2)
+	}
+	return NewOverlaps(children[0], children[1]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (o *Overlaps) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return evalSpatialPredicate(ctx, row, o.FunctionName(), o.Left, o.Right, GeometriesOverlap)
+}
+
+// Touches is a function that returns whether two geometries touch.
+type Touches struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*Touches)(nil)
+
+// NewTouches creates a new ST_TOUCHES expression.
+func NewTouches(g1, g2 sql.Expression) sql.Expression {
+	return &Touches{
+		expression.BinaryExpression{
+			Left:  g1,
+			Right: g2,
+		},
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (t *Touches) FunctionName() string {
+	return "st_touches"
+}
+
+// Description implements sql.FunctionExpression
+func (t *Touches) Description() string {
+	return "returns 1 or 0 to indicate whether g1 spatially touches g2."
+}
+
+// Type implements the sql.Expression interface.
+func (t *Touches) Type() sql.Type {
+	return sql.Boolean
+}
+
+func (t *Touches) String() string {
+	return fmt.Sprintf("ST_TOUCHES(%s,%s)", t.Left, t.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (t *Touches) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(t, len(children), 2)
+	}
+	return NewTouches(children[0], children[1]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (t *Touches) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return evalSpatialPredicate(ctx, row, t.FunctionName(), t.Left, t.Right, GeometriesTouch)
+}
+
+// Crosses is a function that returns whether a geometry crosses another geometry.
+type Crosses struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*Crosses)(nil)
+
+// NewCrosses creates a new ST_CROSSES expression.
+func NewCrosses(g1, g2 sql.Expression) sql.Expression {
+	return &Crosses{
+		expression.BinaryExpression{
+			Left:  g1,
+			Right: g2,
+		},
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (c *Crosses) FunctionName() string {
+	return "st_crosses"
+}
+
+// Description implements sql.FunctionExpression
+func (c *Crosses) Description() string {
+	return "returns 1 or 0 to indicate whether g1 spatially crosses g2."
+}
+
+// Type implements the sql.Expression interface.
+func (c *Crosses) Type() sql.Type {
+	return sql.Boolean
+}
+
+func (c *Crosses) String() string {
+	return fmt.Sprintf("ST_CROSSES(%s,%s)", c.Left, c.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (c *Crosses) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(c, len(children), 2)
+	}
+	return NewCrosses(children[0], children[1]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (c *Crosses) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return evalSpatialPredicate(ctx, row, c.FunctionName(), c.Left, c.Right, GeometryCrosses)
+}
+
+// Disjoint is a function that returns whether two geometries are disjoint.
+type Disjoint struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*Disjoint)(nil)
+
+// NewDisjoint creates a new ST_DISJOINT expression.
+func NewDisjoint(g1, g2 sql.Expression) sql.Expression {
+	return &Disjoint{
+		expression.BinaryExpression{
+			Left:  g1,
+			Right: g2,
+		},
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (d *Disjoint) FunctionName() string {
+	return "st_disjoint"
+}
+
+// Description implements sql.FunctionExpression
+func (d *Disjoint) Description() string {
+	return "returns 1 or 0 to indicate whether g1 is spatially disjoint from g2."
+}
+
+// Type implements the sql.Expression interface.
+func (d *Disjoint) Type() sql.Type {
+	return sql.Boolean
+}
+
+func (d *Disjoint) String() string {
+	return fmt.Sprintf("ST_DISJOINT(%s,%s)", d.Left, d.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (d *Disjoint) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(d, len(children), 2)
+	}
+	return NewDisjoint(children[0], children[1]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (d *Disjoint) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return evalSpatialPredicate(ctx, row, d.FunctionName(), d.Left, d.Right, GeometriesDisjoint)
+}
+
+// intersectionMatrix is a DE-9IM matrix of two geometries. The entry for a location in the first geometry and a
+// location in the second geometry is the dimension of the intersection of those locations, or -1 if they don't
+// intersect.
+type intersectionMatrix [3][3]int
+
+// relateGeometries returns the intersection matrix of two geometries. It's computed by locating sample points in both
+// geometries: their vertices, the ends and middles of the pieces their segments are split into by the other geometry,
+// and points just beside the pieces of polygon rings. The dimension of each sample is that of the part of the geometries
+// it stands for.
+func relateGeometries(g1, g2 interface{}) intersectionMatrix {
+	var m intersectionMatrix
+	for i := range m {
+		for j := range m[i] {
+			m[i][j] = -1
+		}
+	}
+
+	parts1, parts2 := flattenGeometry(g1), flattenGeometry(g2)
+	sample := func(p sql.Point, dim int) {
+		l1, l2 := parts1.locate(p), parts2.locate(p)
+		if dim > m[l1][l2] {
+			m[l1][l2] = dim
+		}
+	}
+
+	sampleParts := func(parts, other geometryParts) {
+		segments, vertices := other.segments(), other.vertices()
+		samplePath := func(points []sql.Point, area bool) {
+			for i := 1; i < len(points); i++ {
+				pieces := splitSegment(points[i-1], points[i], segments, vertices)
+				for j, p := range pieces {
+					sample(p, 0)
+					if j == 0 {
+						continue
+					}
+					a := pieces[j-1]
+					mid := sql.Point{X: (a.X + p.X) / 2, Y: (a.Y + p.Y) / 2}
+					sample(mid, 1)
+					if area {
+						dx, dy := p.X-a.X, p.Y-a.Y
+						eps := 1e-7
+						sample(sql.Point{X: mid.X - dy*eps, Y: mid.Y + dx*eps}, 2)
+						sample(sql.Point{X: mid.X + dy*eps, Y: mid.Y - dx*eps}, 2)
+					}
+				}
+			}
+		}
+
+		for _, p := range parts.points {
+			sample(p, 0)
+		}
+		for _, l := range parts.lines {
+			sample(l[0], 0)
+			samplePath(l, false)
+		}
+		for _, poly := range parts.polygons {
+			for _, r := range poly.Lines {
+				samplePath(r.Points, true)
+			}
+		}
+	}
+	sampleParts(parts1, parts2)
+	sampleParts(parts2, parts1)
+
+	return m
+}
+
+// intersects returns whether the locations of the first and second geometries of the matrix intersect.
+func (m intersectionMatrix) intersects(l1, l2 pointLocation) bool {
+	return m[l1][l2] >= 0
+}
+
+// GeometriesDisjoint returns whether two geometries have no point in common.
+func GeometriesDisjoint(g1, g2 interface{}) bool {
+	m := relateGeometries(g1, g2)
+	for _, l1 := range []pointLocation{locationInterior, locationBoundary} {
+		for _, l2 := range []pointLocation{locationInterior, locationBoundary} {
+			if m.intersects(l1, l2) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// GeometriesTouch returns whether two geometries have a point in common, but their interiors don't intersect.
+func GeometriesTouch(g1, g2 interface{}) bool {
+	m := relateGeometries(g1, g2)
+	return !m.intersects(locationInterior, locationInterior) &&
+		(m.intersects(locationInterior, locationBoundary) ||
+			m.intersects(locationBoundary, locationInterior) ||
+			m.intersects(locationBoundary, locationBoundary))
+}
+
+// GeometryCrosses returns whether the interiors of two geometries intersect in a geometry of lower dimension than the
+// greater of theirs, and the interior of g1 isn't entirely in g2. As in MySQL, it's false if g1 is a polygon or g2 is a
+// point.
+func GeometryCrosses(g1, g2 interface{}) bool {
+	dim1, ok1 := geometryDimension(g1)
+	dim2, ok2 := geometryDimension(g2)
+	if !ok1 || !ok2 || dim1 < 0 || dim1 == 2 || dim2 <= 0 {
+		return false
+	}
+
+	m := relateGeometries(g1, g2)
+	if dim1 == dim2 {
+		return m[locationInterior][locationInterior] == 0
+	}
+	return m.intersects(locationInterior, locationInterior) && m.intersects(locationInterior, locationExterior)
+}
+
+// GeometriesOverlap returns whether two geometries of the same dimension have interiors that intersect in a geometry of
+// that dimension, and each has a point that isn't in the other.
+func GeometriesOverlap(g1, g2 interface{}) bool {
+	dim1, ok1 := geometryDimension(g1)
+	dim2, ok2 := geometryDimension(g2)
+	if !ok1 || !ok2 || dim1 < 0 || dim1 != dim2 {
+		return false
+	}
+
+	m := relateGeometries(g1, g2)
+	return m[locationInterior][locationInterior] == dim1 &&
+		m.intersects(locationInterior, locationExterior) &&
+		m.intersects(locationExterior, locationInterior)
+}
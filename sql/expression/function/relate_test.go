@@ -0,0 +1,95 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestSpatialRelations(t *testing.T) {
+	ring := func(points ...float64) sql.Linestring {
+		var l sql.Linestring
+		for i := 0; i < len(points); i += 2 {
+			l.Points = append(l.Points, sql.Point{X: points[i], Y: points[i+1]})
+		}
+		return l
+	}
+	square := sql.Polygon{Lines: []sql.Linestring{ring(0, 0, 4, 0, 4, 4, 0, 4, 0, 0)}}
+	// shares the edge x = 4 with square
+	edgeNeighbor := sql.Polygon{Lines: []sql.Linestring{ring(4, 0, 8, 0, 8, 4, 4, 4, 4, 0)}}
+	// shares only the corner (4, 4) with square
+	cornerNeighbor := sql.Polygon{Lines: []sql.Linestring{ring(4, 4, 8, 4, 8, 8, 4, 8, 4, 4)}}
+	overlapping := sql.Polygon{Lines: []sql.Linestring{ring(2, 2, 6, 2, 6, 6, 2, 6, 2, 2)}}
+	inside := sql.Polygon{Lines: []sql.Linestring{ring(1, 1, 3, 1, 3, 3, 1, 3, 1, 1)}}
+	far := sql.Polygon{Lines: []sql.Linestring{ring(10, 10, 12, 10, 12, 12, 10, 12, 10, 10)}}
+
+	type relations struct {
+		overlaps, touches, crosses, disjoint interface{}
+	}
+	tests := []struct {
+		name     string
+		g1, g2   interface{}
+		expected relations
+	}{
+		{"polygons touching at an edge", square, edgeNeighbor, relations{false, true, false, false}},
+		{"polygons touching at a corner", square, cornerNeighbor, relations{false, true, false, false}},
+		{"overlapping polygons", square, overlapping, relations{true, false, false, false}},
+		{"disjoint polygons", square, far, relations{false, false, false, true}},
+		{"polygon inside polygon", square, inside, relations{false, false, false, false}},
+		{"equal polygons", square, square, relations{false, false, false, false}},
+		{"crossing linestrings", ring(0, 0, 2, 2), ring(0, 2, 2, 0), relations{false, false, true, false}},
+		{"overlapping linestrings", ring(0, 0, 2, 0), ring(1, 0, 3, 0), relations{true, false, false, false}},
+		{"linestrings touching at an endpoint", ring(0, 0, 2, 0), ring(2, 0, 2, 2), relations{false, true, false, false}},
+		{"linestring crossing polygon", ring(2, 2, 6, 2), square, relations{false, false, true, false}},
+		{"linestring inside polygon", ring(1, 1, 3, 3), square, relations{false, false, false, false}},
+		{"linestring along polygon edge", ring(0, 0, 4, 0), square, relations{false, true, false, false}},
+		{"polygon crossed by linestring", square, ring(2, 2, 6, 2), relations{false, false, false, false}},
+		{"point on polygon boundary", sql.Point{X: 0, Y: 2}, square, relations{false, true, false, false}},
+		{"point in polygon", sql.Point{X: 2, Y: 2}, square, relations{false, false, false, false}},
+		{"point outside polygon", sql.Point{X: 5, Y: 5}, square, relations{false, false, false, true}},
+		{"multipoint partly in polygon", sql.MultiPoint{Points: []sql.Point{{X: 2, Y: 2}, {X: 5, Y: 5}}}, square, relations{false, false, true, false}},
+		{"overlapping multipoints", sql.MultiPoint{Points: []sql.Point{{X: 1, Y: 1}, {X: 2, Y: 2}}}, sql.MultiPoint{Points: []sql.Point{{X: 2, Y: 2}, {X: 3, Y: 3}}}, relations{true, false, false, false}},
+		{"empty geometry collection", square, sql.GeometryCollection{}, relations{false, false, false, true}},
+		{"null", nil, square, relations{nil, nil, nil, nil}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			eval := func(newRelation func(g1, g2 sql.Expression) sql.Expression) interface{} {
+				f := newRelation(expression.NewLiteral(tt.g1, sql.GeometryType{}), expression.NewLiteral(tt.g2, sql.GeometryType{}))
+				v, err := f.Eval(sql.NewEmptyContext(), nil)
+				require.NoError(err)
+				return v
+			}
+			require.Equal(tt.expected.overlaps, eval(NewOverlaps), "overlaps")
+			require.Equal(tt.expected.touches, eval(NewTouches), "touches")
+			require.Equal(tt.expected.crosses, eval(NewCrosses), "crosses")
+			require.Equal(tt.expected.disjoint, eval(NewDisjoint), "disjoint")
+		})
+	}
+
+	t.Run("different srids", func(t *testing.T) {
+		require := require.New(t)
+		f := NewDisjoint(expression.NewLiteral(square, sql.PolygonType{}), expression.NewLiteral(sql.Point{SRID: GeoSpatialSRID, X: 1, Y: 1}, sql.PointType{}))
+		_, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.True(sql.ErrDiffSRIDs.Is(err))
+	})
+}
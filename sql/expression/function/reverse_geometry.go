@@ -0,0 +1,127 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// STReverse is a function that returns a geometry with the order of its points reversed.
+type STReverse struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*STReverse)(nil)
+
+// NewSTReverse creates a new ST_REVERSE expression.
+func NewSTReverse(e sql.Expression) sql.Expression {
+	return &STReverse{expression.UnaryExpression{Child: e}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (r *STReverse) FunctionName() string {
+	return "st_reverse"
+}
+
+// Description implements sql.FunctionExpression
+func (r *STReverse) Description() string {
+	return "returns the geometry with the order of its points reversed."
+}
+
+// IsNullable implements the sql.Expression interface.
+func (r *STReverse) IsNullable() bool {
+	return r.Child.IsNullable()
+}
+
+// Type implements the sql.Expression interface.
+func (r *STReverse) Type() sql.Type {
+	return r.Child.Type()
+}
+
+func (r *STReverse) String() string {
+	return fmt.Sprintf("ST_REVERSE(%s)", r.Child.String())
+}
+
+// WithChildren implements the Expression interface.
+func (r *STReverse) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(r, len(children), 1)
+	}
+	return NewSTReverse(children[0]), nil
+}
+
+// ReverseGeometry returns a copy of the geometry with the points of every linestring and polygon ring in reverse
+// order. Points are returned unchanged, and the order of the members of multi-geometries is kept.
+func ReverseGeometry(v interface{}) interface{} {
+	switch v := v.(type) {
+	case sql.Point:
+		return v
+	case sql.Linestring:
+		return sql.Linestring{SRID: v.SRID, Points: reversePoints(v.Points)}
+	case sql.Polygon:
+		lines := make([]sql.Linestring, len(v.Lines))
+		for i, l := range v.Lines {
+			lines[i] = ReverseGeometry(l).(sql.Linestring)
+		}
+		return sql.Polygon{SRID: v.SRID, Lines: lines}
+	case sql.MultiPoint:
+		return sql.MultiPoint{SRID: v.SRID, Points: append([]sql.Point(nil), v.Points...)}
+	case sql.MultiLinestring:
+		lines := make([]sql.Linestring, len(v.Lines))
+		for i, l := range v.Lines {
+			lines[i] = ReverseGeometry(l).(sql.Linestring)
+		}
+		return sql.MultiLinestring{SRID: v.SRID, Lines: lines}
+	case sql.MultiPolygon:
+		polys := make([]sql.Polygon, len(v.Polygons))
+		for i, p := range v.Polygons {
+			polys[i] = ReverseGeometry(p).(sql.Polygon)
+		}
+		return sql.MultiPolygon{SRID: v.SRID, Polygons: polys}
+	case sql.GeometryCollection:
+		geoms := make([]interface{}, len(v.Geoms))
+		for i, g := range v.Geoms {
+			geoms[i] = ReverseGeometry(g)
+		}
+		return sql.GeometryCollection{SRID: v.SRID, Geoms: geoms}
+	case sql.Geometry:
+		return sql.Geometry{Inner: ReverseGeometry(v.Inner)}
+	default:
+		return nil
+	}
+}
+
+// Eval implements the sql.Expression interface.
+func (r *STReverse) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := r.Child.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return nil if geometry is nil
+	if val == nil {
+		return nil, nil
+	}
+
+	switch val.(type) {
+	case sql.Point, sql.Linestring, sql.Polygon, sql.MultiPoint, sql.MultiLinestring, sql.MultiPolygon, sql.GeometryCollection, sql.Geometry:
+		return ReverseGeometry(val), nil
+	default:
+		return nil, sql.ErrInvalidGISData.New(r.FunctionName())
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestSTReverse(t *testing.T) {
+	t.Run("point is unchanged", func(t *testing.T) {
+		require := require.New(t)
+		f := NewSTReverse(expression.NewLiteral(sql.Point{X: 1, Y: 2}, sql.PointType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Point{X: 1, Y: 2}, v)
+	})
+
+	t.Run("linestring start and end points swap", func(t *testing.T) {
+		require := require.New(t)
+		line := sql.Linestring{Points: []sql.Point{{X: 0, Y: 1}, {X: 2, Y: 3}, {X: 4, Y: 5}}}
+		f := NewSTReverse(expression.NewLiteral(line, sql.LinestringType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Linestring{Points: []sql.Point{{X: 4, Y: 5}, {X: 2, Y: 3}, {X: 0, Y: 1}}}, v)
+
+		reversed := v.(sql.Linestring)
+		require.Equal(line.Points[0], reversed.Points[len(reversed.Points)-1])
+		require.Equal(line.Points[len(line.Points)-1], reversed.Points[0])
+	})
+
+	t.Run("polygon rings are each reversed", func(t *testing.T) {
+		require := require.New(t)
+		poly := sql.Polygon{Lines: []sql.Linestring{
+			{Points: []sql.Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 0}}},
+			{Points: []sql.Point{{X: 1, Y: 1}, {X: 2, Y: 2}, {X: 3, Y: 1}, {X: 1, Y: 1}}},
+		}}
+		f := NewSTReverse(expression.NewLiteral(poly, sql.PolygonType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Polygon{Lines: []sql.Linestring{
+			{Points: []sql.Point{{X: 0, Y: 0}, {X: 4, Y: 4}, {X: 4, Y: 0}, {X: 0, Y: 0}}},
+			{Points: []sql.Point{{X: 1, Y: 1}, {X: 3, Y: 1}, {X: 2, Y: 2}, {X: 1, Y: 1}}},
+		}}, v)
+	})
+
+	t.Run("reversing twice is the original", func(t *testing.T) {
+		require := require.New(t)
+		geoms := []interface{}{
+			sql.Linestring{Points: []sql.Point{{X: 0, Y: 1}, {X: 2, Y: 3}, {X: 4, Y: 5}}},
+			sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}, {X: 0, Y: 0}}}}},
+			sql.MultiLinestring{Lines: []sql.Linestring{
+				{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 1}}},
+				{Points: []sql.Point{{X: 2, Y: 2}, {X: 3, Y: 3}, {X: 4, Y: 2}}},
+			}},
+			sql.Geometry{Inner: sql.Linestring{Points: []sql.Point{{X: 0, Y: 1}, {X: 2, Y: 3}}}},
+		}
+		for _, g := range geoms {
+			f := NewSTReverse(NewSTReverse(expression.NewLiteral(g, sql.GeometryType{})))
+			v, err := f.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(err)
+			require.Equal(g, v)
+		}
+	})
+
+	t.Run("geometry linestring", func(t *testing.T) {
+		require := require.New(t)
+		f := NewSTReverse(expression.NewLiteral(sql.Geometry{Inner: sql.Linestring{Points: []sql.Point{{X: 0, Y: 1}, {X: 2, Y: 3}}}}, sql.GeometryType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Geometry{Inner: sql.Linestring{Points: []sql.Point{{X: 2, Y: 3}, {X: 0, Y: 1}}}}, v)
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		require := require.New(t)
+		f := NewSTReverse(expression.NewLiteral(123, sql.Int64))
+		_, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.Error(err)
+	})
+
+	t.Run("null is null", func(t *testing.T) {
+		require := require.New(t)
+		f := NewSTReverse(expression.NewLiteral(nil, sql.Null))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(nil, v)
+	})
+}
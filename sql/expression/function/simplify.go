@@ -0,0 +1,170 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// Simplify is a function that reduces the number of points of a geometry with the Ramer-Douglas-Peucker algorithm.
+type Simplify struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*Simplify)(nil)
+
+// NewSimplify creates a new ST_SIMPLIFY expression.
+func NewSimplify(g, tolerance sql.Expression) sql.Expression {
+	return &Simplify{
+		expression.BinaryExpression{
+			Left:  g,
+			Right: tolerance,
+		},
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *Simplify) FunctionName() string {
+	return "st_simplify"
+}
+
+// Description implements sql.FunctionExpression
+func (s *Simplify) Description() string {
+	return "returns the geometry simplified with the Douglas-Peucker algorithm using the given tolerance."
+}
+
+// Type implements the sql.Expression interface.
+func (s *Simplify) Type() sql.Type {
+	return s.Left.Type()
+}
+
+func (s *Simplify) String() string {
+	return fmt.Sprintf("ST_SIMPLIFY(%s,%s)", s.Left, s.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (s *Simplify) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 2)
+	}
+	return NewSimplify(children[0], children[1]), nil
+}
+
+// SimplifyGeometry returns a copy of the geometry with the points of every linestring and polygon ring reduced by the
+// Ramer-Douglas-Peucker algorithm. Endpoints are always kept, so rings stay closed. Rings that would collapse to fewer
+// than four points are kept unchanged.
+func SimplifyGeometry(v interface{}, tolerance float64) interface{} {
+	switch v := v.(type) {
+	case sql.Point:
+		return v
+	case sql.Linestring:
+		return sql.Linestring{SRID: v.SRID, Points: simplifyPoints(v.Points, tolerance)}
+	case sql.Polygon:
+		lines := make([]sql.Linestring, len(v.Lines))
+		for i, l := range v.Lines {
+			points := simplifyPoints(l.Points, tolerance)
+			if len(points) < 4 {
+				points = append([]sql.Point(nil), l.Points...)
+			}
+			lines[i] = sql.Linestring{SRID: l.SRID, Points: points}
+		}
+		return sql.Polygon{SRID: v.SRID, Lines: lines}
+	case sql.Geometry:
+		return sql.Geometry{Inner: SimplifyGeometry(v.Inner, tolerance)}
+	default:
+		return nil
+	}
+}
+
+// simplifyPoints returns the points kept by the Ramer-Douglas-Peucker algorithm: the endpoints, and recursively the
+// point farthest from the segment between them when it is farther than [tolerance].
+func simplifyPoints(points []sql.Point, tolerance float64) []sql.Point {
+	if len(points) < 3 {
+		return append([]sql.Point(nil), points...)
+	}
+
+	first, last := points[0], points[len(points)-1]
+	var maxDist float64
+	maxIdx := 0
+	for i := 1; i < len(points)-1; i++ {
+		if d := segmentDistance(points[i], first, last); d > maxDist {
+			maxDist, maxIdx = d, i
+		}
+	}
+
+	if maxDist <= tolerance {
+		return []sql.Point{first, last}
+	}
+
+	left := simplifyPoints(points[:maxIdx+1], tolerance)
+	right := simplifyPoints(points[maxIdx:], tolerance)
+	return append(left[:len(left)-1], right...)
+}
+
+// segmentDistance returns the distance from the point [p] to the segment between [a] and [b].
+func segmentDistance(p, a, b sql.Point) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	if dx == 0 && dy == 0 {
+		return math.Hypot(p.X-a.X, p.Y-a.Y)
+	}
+
+	t := ((p.X-a.X)*dx + (p.Y-a.Y)*dy) / (dx*dx + dy*dy)
+	t = math.Max(0, math.Min(1, t))
+	return math.Hypot(p.X-(a.X+t*dx), p.Y-(a.Y+t*dy))
+}
+
+// Eval implements the sql.Expression interface.
+func (s *Simplify) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	g, err := s.Left.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return null if geometry is null
+	if g == nil {
+		return nil, nil
+	}
+
+	tolerance, err := s.Right.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return null if tolerance is null
+	if tolerance == nil {
+		return nil, nil
+	}
+
+	tolerance, err = sql.Float64.Convert(tolerance)
+	if err != nil {
+		return nil, err
+	}
+
+	// Negative tolerances are not valid
+	if tolerance.(float64) < 0 {
+		return nil, nil
+	}
+
+	switch g.(type) {
+	case sql.Point, sql.Linestring, sql.Polygon, sql.Geometry:
+		return SimplifyGeometry(g, tolerance.(float64)), nil
+	default:
+		return nil, sql.ErrInvalidGISData.New(s.FunctionName())
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestSimplify(t *testing.T) {
+	zigzag := sql.Linestring{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 0}, {X: 3, Y: 1}, {X: 4, Y: 0}}}
+
+	t.Run("nearly straight line simplifies to endpoints", func(t *testing.T) {
+		require := require.New(t)
+		line := sql.Linestring{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 0.01}, {X: 2, Y: 0}}}
+		f := NewSimplify(expression.NewLiteral(line, sql.LinestringType{}), expression.NewLiteral(0.1, sql.Float64))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Linestring{Points: []sql.Point{{X: 0, Y: 0}, {X: 2, Y: 0}}}, v)
+	})
+
+	t.Run("zigzag is preserved at small tolerance", func(t *testing.T) {
+		require := require.New(t)
+		f := NewSimplify(expression.NewLiteral(zigzag, sql.LinestringType{}), expression.NewLiteral(0.5, sql.Float64))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(zigzag, v)
+	})
+
+	t.Run("zigzag flattens at large tolerance", func(t *testing.T) {
+		require := require.New(t)
+		f := NewSimplify(expression.NewLiteral(sql.Geometry{Inner: zigzag}, sql.GeometryType{}), expression.NewLiteral(2, sql.Int32))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Geometry{Inner: sql.Linestring{Points: []sql.Point{{X: 0, Y: 0}, {X: 4, Y: 0}}}}, v)
+	})
+
+	t.Run("polygon ring stays closed", func(t *testing.T) {
+		require := require.New(t)
+		poly := sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 4, Y: 0.01}, {X: 4, Y: 4}, {X: 0, Y: 4}, {X: 0, Y: 0}}}}}
+		f := NewSimplify(expression.NewLiteral(poly, sql.PolygonType{}), expression.NewLiteral(0.1, sql.Float64))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{X: 0, Y: 0}, {X: 4, Y: 0.01}, {X: 4, Y: 4}, {X: 0, Y: 4}, {X: 0, Y: 0}}}}}, v)
+	})
+
+	t.Run("collapsing polygon ring is unchanged", func(t *testing.T) {
+		require := require.New(t)
+		poly := sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 0}}}}}
+		f := NewSimplify(expression.NewLiteral(poly, sql.PolygonType{}), expression.NewLiteral(10, sql.Int32))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(poly, v)
+	})
+
+	t.Run("point is unchanged", func(t *testing.T) {
+		require := require.New(t)
+		f := NewSimplify(expression.NewLiteral(sql.Point{X: 1, Y: 2}, sql.PointType{}), expression.NewLiteral(1, sql.Int32))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Point{X: 1, Y: 2}, v)
+	})
+
+	t.Run("negative tolerance is null", func(t *testing.T) {
+		require := require.New(t)
+		f := NewSimplify(expression.NewLiteral(zigzag, sql.LinestringType{}), expression.NewLiteral(-1, sql.Int32))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(nil, v)
+	})
+
+	t.Run("null is null", func(t *testing.T) {
+		require := require.New(t)
+		f := NewSimplify(expression.NewLiteral(zigzag, sql.LinestringType{}), expression.NewLiteral(nil, sql.Null))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(nil, v)
+
+		f = NewSimplify(expression.NewLiteral(nil, sql.Null), expression.NewLiteral(1, sql.Int32))
+		v, err = f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(nil, v)
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		require := require.New(t)
+		f := NewSimplify(expression.NewLiteral(123, sql.Int64), expression.NewLiteral(1, sql.Int32))
+		_, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.Error(err)
+	})
+}
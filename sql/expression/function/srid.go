@@ -16,6 +16,7 @@ package function
 
 import (
 	"fmt"
+	"math"
 	"strings"
 
 	"gopkg.in/src-d/go-errors.v1"
@@ -59,7 +60,7 @@ func (s *SRID) Description() string {
 // Type implements the sql.Expression interface.
 func (s *SRID) Type() sql.Type {
 	if len(s.ChildExpressions) == 1 {
-		return sql.Int32
+		return sql.Uint32
 	} else {
 		return s.ChildExpressions[0].Type()
 	}
@@ -80,7 +81,7 @@ func (s *SRID) WithChildren(children ...sql.Expression) (sql.Expression, error)
 
 // PointWithSRID creates a deep copy of point object with given SRID
 func PointWithSRID(p sql.Point, srid uint32) sql.Point {
-	return sql.Point{SRID: srid, X: p.X, Y: p.Y}
+	return sql.Point{SRID: srid, X: p.X, Y: p.Y, Z: p.Z, M: p.M}
 }
 
 // LineWithSRID creates a deep copy of linestring object with given SRID
@@ -101,6 +102,54 @@ func PolyWithSRID(p sql.Polygon, srid uint32) sql.Polygon {
 	return sql.Polygon{SRID: srid, Lines: lines}
 }
 
+// GeometryWithSRID creates a deep copy of any geometry object with given SRID, including the members of multi
+// geometries and geometry collections. The coordinates are not transformed.
+func GeometryWithSRID(g interface{}, srid uint32) (interface{}, error) {
+	switch g := g.(type) {
+	case sql.Point:
+		return PointWithSRID(g, srid), nil
+	case sql.Linestring:
+		return LineWithSRID(g, srid), nil
+	case sql.Polygon:
+		return PolyWithSRID(g, srid), nil
+	case sql.MultiPoint:
+		points := make([]sql.Point, len(g.Points))
+		for i, p := range g.Points {
+			points[i] = PointWithSRID(p, srid)
+		}
+		return sql.MultiPoint{SRID: srid, Points: points}, nil
+	case sql.MultiLinestring:
+		lines := make([]sql.Linestring, len(g.Lines))
+		for i, l := range g.Lines {
+			lines[i] = LineWithSRID(l, srid)
+		}
+		return sql.MultiLinestring{SRID: srid, Lines: lines}, nil
+	case sql.MultiPolygon:
+		polys := make([]sql.Polygon, len(g.Polygons))
+		for i, p := range g.Polygons {
+			polys[i] = PolyWithSRID(p, srid)
+		}
+		return sql.MultiPolygon{SRID: srid, Polygons: polys}, nil
+	case sql.GeometryCollection:
+		geoms := make([]interface{}, len(g.Geoms))
+		for i, inner := range g.Geoms {
+			var err error
+			if geoms[i], err = GeometryWithSRID(inner, srid); err != nil {
+				return nil, err
+			}
+		}
+		return sql.GeometryCollection{SRID: srid, Geoms: geoms}, nil
+	case sql.Geometry:
+		inner, err := GeometryWithSRID(g.Inner, srid)
+		if err != nil {
+			return nil, err
+		}
+		return sql.Geometry{Inner: inner}, nil
+	default:
+		return nil, sql.ErrIllegalGISValue.New(g)
+	}
+}
+
 // Eval implements the sql.Expression interface.
 func (s *SRID) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	// Evaluate geometry type
@@ -116,28 +165,7 @@ func (s *SRID) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 
 	// If just one argument, return SRID
 	if len(s.ChildExpressions) == 1 {
-		// Check that it is a geometry type
-		switch g := g.(type) {
-		case sql.Point:
-			return g.SRID, nil
-		case sql.Linestring:
-			return g.SRID, nil
-		case sql.Polygon:
-			return g.SRID, nil
-		case sql.Geometry:
-			switch inner := g.Inner.(type) {
-			case sql.Point:
-				return inner.SRID, nil
-			case sql.Linestring:
-				return inner.SRID, nil
-			case sql.Polygon:
-				return inner.SRID, nil
-			default:
-				return nil, sql.ErrIllegalGISValue.New(g)
-			}
-		default:
-			return nil, sql.ErrIllegalGISValue.New(g)
-		}
+		return geometrySRID(g)
 	}
 
 	// Evaluate second argument
@@ -151,14 +179,19 @@ func (s *SRID) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, nil
 	}
 
-	// Convert to int32
-	srid, err = sql.Uint32.Convert(srid)
+	// Convert to int64, so that negative values can be told apart from other conversion errors
+	srid, err = sql.Int64.Convert(srid)
 	if err != nil {
 		return nil, err
 	}
 
+	// Must be a non-negative integer that fits in an uint32
+	if srid.(int64) < 0 || srid.(int64) > math.MaxUint32 {
+		return nil, sql.ErrInvalidArgumentDetails.New(s.FunctionName(), "SRID must be a non-negative 32-bit integer")
+	}
+
 	// Type assertion
-	_srid := srid.(uint32)
+	_srid := uint32(srid.(int64))
 
 	// Must be either 0 or 4326
 	if _srid != CartesianSRID && _srid != GeoSpatialSRID {
@@ -166,25 +199,5 @@ func (s *SRID) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	}
 
 	// Create new geometry object with matching SRID
-	switch g := g.(type) {
-	case sql.Point:
-		return PointWithSRID(g, _srid), nil
-	case sql.Linestring:
-		return LineWithSRID(g, _srid), nil
-	case sql.Polygon:
-		return PolyWithSRID(g, _srid), nil
-	case sql.Geometry:
-		switch inner := g.Inner.(type) {
-		case sql.Point:
-			return sql.Geometry{Inner: PointWithSRID(inner, _srid)}, nil
-		case sql.Linestring:
-			return sql.Geometry{Inner: LineWithSRID(inner, _srid)}, nil
-		case sql.Polygon:
-			return sql.Geometry{Inner: PolyWithSRID(inner, _srid)}, nil
-		default:
-			return nil, sql.ErrIllegalGISValue.New(g)
-		}
-	default:
-		return nil, sql.ErrIllegalGISValue.New(g)
-	}
+	return GeometryWithSRID(g, _srid)
 }
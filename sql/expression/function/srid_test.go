@@ -182,4 +182,60 @@ func TestSRID(t *testing.T) {
 		require.NoError(err)
 		require.Equal(sql.Geometry{Inner: sql.Polygon{SRID: 4326, Lines: []sql.Linestring{{SRID: 4326, Points: []sql.Point{{SRID: 4326, X: 0, Y: 0}, {SRID: 4326, X: 0, Y: 1}, {SRID: 4326, X: 1, Y: 1}, {SRID: 4326, X: 0, Y: 0}}}}}}, v)
 	})
+
+	t.Run("select srid returns an unsigned integer", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewSRID(expression.NewLiteral(sql.Point{X: 1, Y: 2}, sql.PointType{}))
+		require.NoError(err)
+		require.Equal(sql.Uint32, f.Type())
+	})
+
+	t.Run("select srid of multipoint", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewSRID(expression.NewLiteral(sql.MultiPoint{SRID: 4326, Points: []sql.Point{{SRID: 4326, X: 1, Y: 2}}}, sql.MultiPointType{}))
+		require.NoError(err)
+
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(uint32(4326), v)
+	})
+
+	t.Run("change srid of geometry collection to 4326", func(t *testing.T) {
+		require := require.New(t)
+		gc := sql.GeometryCollection{Geoms: []interface{}{
+			sql.Point{X: 1, Y: 2},
+			sql.MultiPoint{Points: []sql.Point{{X: 3, Y: 4}}},
+		}}
+		f, err := NewSRID(expression.NewLiteral(gc, sql.GeometryCollectionType{}),
+			expression.NewLiteral(4326, sql.Int32))
+		require.NoError(err)
+
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.GeometryCollection{SRID: 4326, Geoms: []interface{}{
+			sql.Point{SRID: 4326, X: 1, Y: 2},
+			sql.MultiPoint{SRID: 4326, Points: []sql.Point{{SRID: 4326, X: 3, Y: 4}}},
+		}}, v)
+	})
+
+	t.Run("change SRID to negative value", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewSRID(expression.NewLiteral(sql.Point{X: 1, Y: 2}, sql.PointType{}),
+			expression.NewLiteral(-1, sql.Int32))
+		require.NoError(err)
+
+		_, err = f.Eval(sql.NewEmptyContext(), nil)
+		require.True(sql.ErrInvalidArgumentDetails.Is(err))
+	})
+
+	t.Run("change SRID to null", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewSRID(expression.NewLiteral(sql.Point{X: 1, Y: 2}, sql.PointType{}),
+			expression.NewLiteral(nil, sql.Null))
+		require.NoError(err)
+
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Nil(v)
+	})
 }
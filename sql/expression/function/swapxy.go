@@ -54,7 +54,7 @@ func (s *SwapXY) Type() sql.Type {
 }
 
 func (s *SwapXY) String() string {
-	return fmt.Sprintf("ST_DIMENSION(%s)", s.Child.String())
+	return fmt.Sprintf("ST_SWAPXY(%s)", s.Child.String())
 }
 
 // WithChildren implements the Expression interface.
@@ -69,7 +69,7 @@ func (s *SwapXY) WithChildren(children ...sql.Expression) (sql.Expression, error
 func SwapGeometryXY(v interface{}) interface{} {
 	switch v := v.(type) {
 	case sql.Point:
-		return sql.Point{SRID: v.SRID, X: v.Y, Y: v.X}
+		return sql.Point{SRID: v.SRID, X: v.Y, Y: v.X, Z: v.Z, M: v.M}
 	case sql.Linestring:
 		points := make([]sql.Point, len(v.Points))
 		for i, p := range v.Points {
@@ -82,6 +82,30 @@ func SwapGeometryXY(v interface{}) interface{} {
 			lines[i] = SwapGeometryXY(l).(sql.Linestring)
 		}
 		return sql.Polygon{SRID: v.SRID, Lines: lines}
+	case sql.MultiPoint:
+		points := make([]sql.Point, len(v.Points))
+		for i, p := range v.Points {
+			points[i] = SwapGeometryXY(p).(sql.Point)
+		}
+		return sql.MultiPoint{SRID: v.SRID, Points: points}
+	case sql.MultiLinestring:
+		lines := make([]sql.Linestring, len(v.Lines))
+		for i, l := range v.Lines {
+			lines[i] = SwapGeometryXY(l).(sql.Linestring)
+		}
+		return sql.MultiLinestring{SRID: v.SRID, Lines: lines}
+	case sql.MultiPolygon:
+		polys := make([]sql.Polygon, len(v.Polygons))
+		for i, p := range v.Polygons {
+			polys[i] = SwapGeometryXY(p).(sql.Polygon)
+		}
+		return sql.MultiPolygon{SRID: v.SRID, Polygons: polys}
+	case sql.GeometryCollection:
+		geoms := make([]interface{}, len(v.Geoms))
+		for i, g := range v.Geoms {
+			geoms[i] = SwapGeometryXY(g)
+		}
+		return sql.GeometryCollection{SRID: v.SRID, Geoms: geoms}
 	case sql.Geometry:
 		return sql.Geometry{Inner: SwapGeometryXY(v.Inner)}
 	default:
@@ -104,9 +128,9 @@ func (s *SwapXY) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 
 	// Expect one of the geometry types
 	switch val.(type) {
-	case sql.Point, sql.Linestring, sql.Polygon, sql.Geometry:
+	case sql.Point, sql.Linestring, sql.Polygon, sql.MultiPoint, sql.MultiLinestring, sql.MultiPolygon, sql.GeometryCollection, sql.Geometry:
 		return SwapGeometryXY(val), nil
 	default:
-		return nil, sql.ErrInvalidGISData.New("ST_DIMENSION")
+		return nil, sql.ErrInvalidGISData.New(s.FunctionName())
 	}
 }
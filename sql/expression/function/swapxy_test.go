@@ -86,4 +86,21 @@ func TestSwapXY(t *testing.T) {
 		require.NoError(err)
 		require.Equal(sql.Geometry{Inner: sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 1, Y: 0}, {X: 0, Y: 0}}}}}}, v)
 	})
+
+	t.Run("multipoint swap", func(t *testing.T) {
+		require := require.New(t)
+		f := NewSwapXY(expression.NewLiteral(sql.MultiPoint{Points: []sql.Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}, sql.MultiPointType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.MultiPoint{Points: []sql.Point{{X: 2, Y: 1}, {X: 4, Y: 3}}}, v)
+	})
+
+	t.Run("geometry collection swap", func(t *testing.T) {
+		require := require.New(t)
+		gc := sql.GeometryCollection{Geoms: []interface{}{sql.Point{X: 1, Y: 2}, sql.Linestring{Points: []sql.Point{{X: 0, Y: 1}, {X: 2, Y: 3}}}}}
+		f := NewSwapXY(expression.NewLiteral(gc, sql.GeometryCollectionType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.GeometryCollection{Geoms: []interface{}{sql.Point{X: 2, Y: 1}, sql.Linestring{Points: []sql.Point{{X: 1, Y: 0}, {X: 3, Y: 2}}}}}, v)
+	})
 }
@@ -16,6 +16,7 @@ package function
 
 import (
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -23,6 +24,7 @@ import (
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/parse/dateparse"
 )
 
 // ErrInvalidArgumentType is thrown when a function receives invalid argument types
@@ -432,6 +434,62 @@ func (d *DayOfYear) WithChildren(children ...sql.Expression) (sql.Expression, er
 	return NewDayOfYear(children[0]), nil
 }
 
+// LastDay is a function that returns the date of the last day of the month of a date.
+type LastDay struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*LastDay)(nil)
+
+// NewLastDay creates a new LastDay UDF.
+func NewLastDay(date sql.Expression) sql.Expression {
+	return &LastDay{expression.UnaryExpression{Child: date}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (d *LastDay) FunctionName() string {
+	return "last_day"
+}
+
+// Description implements sql.FunctionExpression
+func (d *LastDay) Description() string {
+	return "returns the last day of the month of the given date."
+}
+
+func (d *LastDay) String() string { return fmt.Sprintf("LAST_DAY(%s)", d.Child) }
+
+// Type implements the Expression interface.
+func (d *LastDay) Type() sql.Type { return sql.Date }
+
+// IsNullable implements the Expression interface.
+func (d *LastDay) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (d *LastDay) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := d.Child.Eval(ctx, row)
+	if err != nil || val == nil {
+		return nil, err
+	}
+
+	// invalid dates have no last day
+	date, err := sql.Datetime.Convert(val)
+	if err != nil {
+		return nil, nil
+	}
+	t := date.(time.Time)
+
+	// day 0 of the next month is the last day of this month
+	return sql.Date.Convert(time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC))
+}
+
+// WithChildren implements the Expression interface.
+func (d *LastDay) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(d, len(children), 1)
+	}
+	return NewLastDay(children[0]), nil
+}
+
 func datePartFunc(fn func(time.Time) int) func(interface{}) interface{} {
 	return func(v interface{}) interface{} {
 		if v == nil {
@@ -459,9 +517,10 @@ func NewYearWeek(args ...sql.Expression) (sql.Expression, error) {
 	}
 
 	yw := &YearWeek{date: args[0]}
-	if len(args) > 1 && args[1].Resolved() && sql.IsInteger(args[1].Type()) {
+	if len(args) > 1 {
 		yw.mode = args[1]
 	} else {
+		// unlike WEEK, YEARWEEK ignores default_week_format
 		yw.mode = expression.NewLiteral(0, sql.Int64)
 	}
 
@@ -478,7 +537,7 @@ func (d *YearWeek) Description() string {
 	return "returns year and week for a date. The year in the result may be different from the year in the date argument for the first and the last week of the year."
 }
 
-func (d *YearWeek) String() string { return fmt.Sprintf("YEARWEEK(%s, %d)", d.date, d.mode) }
+func (d *YearWeek) String() string { return fmt.Sprintf("YEARWEEK(%s, %s)", d.date, d.mode) }
 
 // Type implements the Expression interface.
 func (d *YearWeek) Type() sql.Type { return sql.Int32 }
@@ -489,31 +548,15 @@ func (d *YearWeek) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	yyyy, ok := year(date).(int32)
-	if !ok {
-		return nil, sql.ErrInvalidArgumentDetails.New("YEARWEEK", "invalid year")
-	}
-	mm, ok := month(date).(int32)
-	if !ok {
-		return nil, sql.ErrInvalidArgumentDetails.New("YEARWEEK", "invalid month")
-	}
-	dd, ok := day(date).(int32)
-	if !ok {
-		return nil, sql.ErrInvalidArgumentDetails.New("YEARWEEK", "invalid day")
+	yyyy, mm, dd, err := weekDateParts("YEARWEEK", date)
+	if err != nil {
+		return nil, err
 	}
 
-	mode := int64(0)
-	val, err := d.mode.Eval(ctx, row)
+	mode, err := evalWeekMode(ctx, d.mode, row)
 	if err != nil {
 		return nil, err
 	}
-	if val != nil {
-		if i64, err := sql.Int64.Convert(val); err == nil {
-			if mode, ok = i64.(int64); ok {
-				mode %= 8 // mode in [0, 7]
-			}
-		}
-	}
 	yyyy, week := calcWeek(yyyy, mm, dd, weekMode(mode)|weekBehaviourYear)
 
 	return (yyyy * 100) + week, nil
@@ -537,9 +580,9 @@ func (*YearWeek) WithChildren(children ...sql.Expression) (sql.Expression, error
 	return NewYearWeek(children...)
 }
 
-// Week is a function that returns year and week for a date.
-// The year in the result may be different from the year in the date argument for the first and the last week of the year.
-// Details: https://dev.mysql.com/doc/refman/5.5/en/date-and-time-functions.html#function_yearweek
+// Week is a function that returns the week number for a date.
+// Without a mode argument, the mode is taken from the default_week_format system variable.
+// Details: https://dev.mysql.com/doc/refman/8.0/en/date-and-time-functions.html#function_week
 type Week struct {
 	date sql.Expression
 	mode sql.Expression
@@ -550,14 +593,12 @@ var _ sql.FunctionExpression = (*Week)(nil)
 // NewWeek creates a new Week UDF
 func NewWeek(args ...sql.Expression) (sql.Expression, error) {
 	if len(args) == 0 {
-		return nil, sql.ErrInvalidArgumentNumber.New("YEARWEEK", "1 or more", 0)
+		return nil, sql.ErrInvalidArgumentNumber.New("WEEK", "1 or more", 0)
 	}
 
 	w := &Week{date: args[0]}
-	if len(args) > 1 && args[1].Resolved() && sql.IsInteger(args[1].Type()) {
+	if len(args) > 1 {
 		w.mode = args[1]
-	} else {
-		w.mode = expression.NewLiteral(0, sql.Int64)
 	}
 
 	return w, nil
@@ -573,7 +614,12 @@ func (d *Week) Description() string {
 	return "returns the week number."
 }
 
-func (d *Week) String() string { return fmt.Sprintf("WEEK(%s, %d)", d.date, d.mode) }
+func (d *Week) String() string {
+	if d.mode == nil {
+		return fmt.Sprintf("WEEK(%s)", d.date)
+	}
+	return fmt.Sprintf("WEEK(%s, %s)", d.date, d.mode)
+}
 
 // Type implements the Expression interface.
 func (d *Week) Type() sql.Type { return sql.Int32 }
@@ -584,51 +630,37 @@ func (d *Week) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-
-	yyyy, ok := year(date).(int32)
-	if !ok {
-		return nil, sql.ErrInvalidArgumentDetails.New("WEEK", "invalid year")
-	}
-	mm, ok := month(date).(int32)
-	if !ok {
-		return nil, sql.ErrInvalidArgumentDetails.New("WEEK", "invalid month")
-	}
-	dd, ok := day(date).(int32)
-	if !ok {
-		return nil, sql.ErrInvalidArgumentDetails.New("WEEK", "invalid day")
-	}
-
-	mode := int64(0)
-	val, err := d.mode.Eval(ctx, row)
+	yyyy, mm, dd, err := weekDateParts("WEEK", date)
 	if err != nil {
 		return nil, err
 	}
-	if val != nil {
-		if i64, err := sql.Int64.Convert(val); err == nil {
-			if mode, ok = i64.(int64); ok {
-				mode %= 8 // mode in [0, 7]
-			}
-		}
-	}
 
-	yearForWeek, week := calcWeek(yyyy, mm, dd, weekMode(mode)|weekBehaviourYear)
-
-	if yearForWeek < yyyy {
-		week = 0
-	} else if yearForWeek > yyyy {
-		week = 53
+	var mode int64
+	if d.mode != nil {
+		mode, err = evalWeekMode(ctx, d.mode, row)
+	} else {
+		mode, err = defaultWeekMode(ctx)
+	}
+	if err != nil {
+		return nil, err
 	}
 
+	_, week := calcWeek(yyyy, mm, dd, weekMode(mode))
 	return week, nil
 }
 
 // Resolved implements the Expression interface.
 func (d *Week) Resolved() bool {
-	return d.date.Resolved() && d.mode.Resolved()
+	return d.date.Resolved() && (d.mode == nil || d.mode.Resolved())
 }
 
 // Children implements the Expression interface.
-func (d *Week) Children() []sql.Expression { return []sql.Expression{d.date, d.mode} }
+func (d *Week) Children() []sql.Expression {
+	if d.mode == nil {
+		return []sql.Expression{d.date}
+	}
+	return []sql.Expression{d.date, d.mode}
+}
 
 // IsNullable implements the Expression interface.
 func (d *Week) IsNullable() bool {
@@ -640,6 +672,62 @@ func (*Week) WithChildren(children ...sql.Expression) (sql.Expression, error) {
 	return NewWeek(children...)
 }
 
+// weekDateParts returns the year, month and day of a date evaluated for the function [name].
+func weekDateParts(name string, date interface{}) (int32, int32, int32, error) {
+	yyyy, ok := year(date).(int32)
+	if !ok {
+		return 0, 0, 0, sql.ErrInvalidArgumentDetails.New(name, "invalid year")
+	}
+	mm, ok := month(date).(int32)
+	if !ok {
+		return 0, 0, 0, sql.ErrInvalidArgumentDetails.New(name, "invalid month")
+	}
+	dd, ok := day(date).(int32)
+	if !ok {
+		return 0, 0, 0, sql.ErrInvalidArgumentDetails.New(name, "invalid day")
+	}
+	return yyyy, mm, dd, nil
+}
+
+// evalWeekMode evaluates the mode argument of WEEK and YEARWEEK. Modes are taken modulo 8, and values that are null or
+// can't be converted to an integer are treated as mode 0.
+func evalWeekMode(ctx *sql.Context, mode sql.Expression, row sql.Row) (int64, error) {
+	val, err := mode.Eval(ctx, row)
+	if err != nil {
+		return 0, err
+	}
+	if val == nil {
+		return 0, nil
+	}
+	i64, err := sql.Int64.Convert(val)
+	if err != nil {
+		return 0, nil
+	}
+	return i64.(int64) & 7, nil
+}
+
+// defaultWeekMode returns the value of the default_week_format system variable, or mode 0 without a session.
+func defaultWeekMode(ctx *sql.Context) (int64, error) {
+	if ctx == nil || ctx.Session == nil {
+		return 0, nil
+	}
+	val, err := ctx.Session.GetSessionVariable(ctx, "default_week_format")
+	if err != nil {
+		return 0, err
+	}
+	i64, err := sql.Int64.Convert(val)
+	if err != nil {
+		return 0, err
+	}
+	return i64.(int64) & 7, nil
+}
+
+// weekOfYear returns the week number of [t] as computed by WEEK with the given mode.
+func weekOfYear(t time.Time, mode int64) int32 {
+	_, week := calcWeek(int32(t.Year()), int32(t.Month()), int32(t.Day()), weekMode(mode))
+	return week
+}
+
 // Following solution of YearWeek was taken from tidb: https://github.com/pingcap/tidb/blob/master/types/mytime.go
 type weekBehaviour int64
 
@@ -748,8 +836,8 @@ var (
 	hour      = datePartFunc((time.Time).Hour)
 	minute    = datePartFunc((time.Time).Minute)
 	second    = datePartFunc((time.Time).Second)
-	dayOfWeek = datePartFunc(func(t time.Time) int { return int(t.Weekday()) + 1 })
-	dayOfYear = datePartFunc((time.Time).YearDay)
+	dayOfWeek = datePartFunc(func(t time.Time) int { return dateparse.WeekdayNumber(t.Weekday()) + 1 })
+	dayOfYear = datePartFunc(dateparse.DayOfYear)
 )
 
 // Now is a function that returns the current time.
@@ -1159,7 +1247,7 @@ type TimeToSec struct {
 var _ sql.FunctionExpression = (*TimeToSec)(nil)
 
 func NewTimeToSec(arg sql.Expression) sql.Expression {
-	return &TimeToSec{NewUnaryDatetimeFunc(arg, "TIME_TO_SEC", sql.Uint64)}
+	return &TimeToSec{NewUnaryDatetimeFunc(arg, "TIME_TO_SEC", sql.Int64)}
 }
 
 // Description implements sql.FunctionExpression
@@ -1168,13 +1256,22 @@ func (m *TimeToSec) Description() string {
 }
 
 func (m *TimeToSec) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
-	val, err := m.EvalChild(ctx, row)
+	val, err := m.Child.Eval(ctx, row)
+	if err != nil || val == nil {
+		return nil, err
+	}
+
+	// times may be negative or longer than a day, and datetimes only count their time of day
+	if d, err := sql.Time.ConvertToTimeDuration(val); err == nil {
+		return int64(d / time.Second), nil
+	}
+	val, err = sql.Datetime.Convert(val)
 	if err != nil {
 		return nil, err
 	}
 
 	t := val.(time.Time)
-	return uint64(t.Hour()*3600 + t.Minute()*60 + t.Second()), nil
+	return int64(t.Hour()*3600 + t.Minute()*60 + t.Second()), nil
 }
 
 func (m *TimeToSec) WithChildren(children ...sql.Expression) (sql.Expression, error) {
@@ -1184,6 +1281,63 @@ func (m *TimeToSec) WithChildren(children ...sql.Expression) (sql.Expression, er
 	return NewTimeToSec(children[0]), nil
 }
 
+// SecToTime implements the sec_to_time function
+type SecToTime struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*SecToTime)(nil)
+
+// maxTimeSeconds is the number of seconds of the largest TIME value, 838:59:59.
+const maxTimeSeconds = 838*3600 + 59*60 + 59
+
+func NewSecToTime(arg sql.Expression) sql.Expression {
+	return &SecToTime{expression.UnaryExpression{Child: arg}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *SecToTime) FunctionName() string {
+	return "sec_to_time"
+}
+
+// Description implements sql.FunctionExpression
+func (s *SecToTime) Description() string {
+	return "converts seconds to 'hh:mm:ss' format."
+}
+
+func (s *SecToTime) String() string { return fmt.Sprintf("SEC_TO_TIME(%s)", s.Child) }
+
+// Type implements the Expression interface.
+func (s *SecToTime) Type() sql.Type { return sql.Time }
+
+func (s *SecToTime) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := s.Child.Eval(ctx, row)
+	if err != nil || val == nil {
+		return nil, err
+	}
+
+	val, err = sql.Float64.Convert(val)
+	if err != nil {
+		return nil, err
+	}
+
+	// times out of the range of TIME are clamped to the nearest limit
+	seconds := val.(float64)
+	if seconds > maxTimeSeconds || seconds < -maxTimeSeconds {
+		ctx.Warn(1292, "Truncated incorrect time value: '%v'", seconds)
+		seconds = math.Max(-maxTimeSeconds, math.Min(maxTimeSeconds, seconds))
+	}
+
+	return sql.Time.Convert(time.Duration(math.Round(seconds*1e6)) * time.Microsecond)
+}
+
+func (s *SecToTime) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 1)
+	}
+	return NewSecToTime(children[0]), nil
+}
+
 // WeekOfYear implements the weekofyear function
 type WeekOfYear struct {
 	*UnaryDatetimeFunc
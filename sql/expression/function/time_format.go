@@ -31,6 +31,8 @@ var timeFormatSpecifierToFunc = map[byte]func(time.Time) string{
 	'h': twelveHourPadded,
 	'I': twelveHourPadded,
 	'i': minutesStr,
+	'k': twentyFourHourNoPadding,
+	'l': twelveHourNoPadding,
 	'p': nil,
 	'r': ampmClockStr,
 	'S': nil,
@@ -61,10 +63,32 @@ func init() {
 	}
 }
 
-func formatTime(format string, t time.Time) (string, error) {
+// hasDateSpecifier returns whether a format has any of the DATE_FORMAT specifiers for the parts of a date, like %Y or
+// %d. MySQL's TIME_FORMAT returns NULL for these formats.
+func hasDateSpecifier(format string) bool {
+	for i := 0; i < len(format)-1; i++ {
+		if format[i] != '%' {
+			continue
+		}
+		i++
+		_, isDate := dateFormatSpecifierToFunc[format[i]]
+		_, isTime := timeFormatSpecifierToFunc[format[i]]
+		if isDate && !isTime {
+			return true
+		}
+	}
+	return false
+}
+
+// formatTime formats the time of day of [t], and returns nil if the format has a specifier for a part of a date.
+func formatTime(format string, t time.Time) (interface{}, error) {
+	if hasDateSpecifier(format) {
+		return nil, nil
+	}
+
 	formatter, err := strftime.New(format, strftime.WithSpecificationSet(mysqlTimeFormatSpec))
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	return formatter.FormatString(t), nil
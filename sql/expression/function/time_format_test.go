@@ -28,7 +28,7 @@ func TestTimeFormatting(t *testing.T) {
 	dt := time.Date(2020, 2, 3, 4, 5, 6, 7000, time.UTC)
 	tests := []struct {
 		formatStr string
-		expected  string
+		expected  interface{}
 		expectErr bool
 	}{
 		{"%f", "000007", false},               // Microseconds (000000 to 999999)
@@ -37,12 +37,16 @@ func TestTimeFormatting(t *testing.T) {
 		{"%h", "04", false},                   // Hour (00 to 12)
 		{"%I", "04", false},                   // Hour (00 to 12)
 		{"%i", "05", false},                   // Minutes (00 to 59)
+		{"%k", "4", false},                    // Hour (0 to 23)
+		{"%l", "4", false},                    // Hour (1 to 12)
 		{"%p", "AM", false},                   // AM or PM
 		{"%r", "04:05:06 AM", false},          // Time in 12 hour AM or PM format (hh:mm:ss AM/PM)
 		{"%S", "06", false},                   // Seconds (00 to 59)
 		{"%s", "06", false},                   // Seconds (00 to 59)
 		{"%T", "04:05:06", false},             // Time in 24 hour format (hh:mm:ss)
-		{"%U", "U", false},                    // Assert that unsupported (date) verbs are ignored
+		{"%U", nil, false},                    // Assert that date verbs return NULL
+		{"%H %Y", nil, false},                 // Assert that date verbs return NULL
+		{"%%Y %H", "%Y 04", false},            // Assert that escaped date verbs are allowed
 		{"%z", "z", false},                    // Assert that unsupported (unknown) verbs are ignored
 	}
 
@@ -71,6 +75,16 @@ func TestTimeFormatEval(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "04-05-06|000007", res)
 
+	timeFormat = NewTimeFormat(expression.NewLiteral("13:14:15.123", sql.Time), expression.NewLiteral("%H:%i:%s.%f", sql.Text))
+	res, err = timeFormat.Eval(nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "13:14:15.123000", res)
+
+	timeFormat = NewTimeFormat(timeLit, expression.NewLiteral("%d %H", sql.Text))
+	res, err = timeFormat.Eval(nil, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
 	timeFormat = NewTimeFormat(timeLit, nil)
 	res, err = timeFormat.Eval(nil, nil)
 	assert.NoError(t, err)
@@ -16,6 +16,7 @@ package function
 
 import (
 	"fmt"
+	"strconv"
 	"testing"
 	"time"
 
@@ -24,6 +25,7 @@ import (
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/parse/dateparse"
 )
 
 const (
@@ -301,6 +303,165 @@ func TestTime_DayOfYear(t *testing.T) {
 	}
 }
 
+func TestTime_LastDay(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	f := NewLastDay(expression.NewGetField(0, sql.LongText, "foo", false))
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"null date", sql.NewRow(nil), nil},
+		{"invalid date", sql.NewRow("not a date"), nil},
+		{"date as string", sql.NewRow(stringDate), date(2007, time.January, 31)},
+		{"datetime as string", sql.NewRow("2021-04-05 10:11:12"), date(2021, time.April, 30)},
+		{"february", sql.NewRow("2021-02-10"), date(2021, time.February, 28)},
+		{"february in leap year", sql.NewRow("2020-02-10"), date(2020, time.February, 29)},
+		{"february in century", sql.NewRow("1900-02-10"), date(1900, time.February, 28)},
+		{"december", sql.NewRow(date(2021, time.December, 1)), date(2021, time.December, 31)},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			val, err := f.Eval(ctx, tt.row)
+			require.NoError(err)
+			require.Equal(tt.expected, val)
+		})
+	}
+}
+
+func TestTime_SecToTime(t *testing.T) {
+	f := NewSecToTime(expression.NewGetField(0, sql.LongText, "foo", true))
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+		warning  bool
+	}{
+		{"null", sql.NewRow(nil), nil, false},
+		{"zero", sql.NewRow(0), "00:00:00", false},
+		{"seconds", sql.NewRow(2378), "00:39:38", false},
+		{"more than a day", sql.NewRow(90061), "25:01:01", false},
+		{"negative", sql.NewRow(-3661), "-01:01:01", false},
+		{"fraction", sql.NewRow(1.5), "00:00:01.500000", false},
+		{"string", sql.NewRow("3600"), "01:00:00", false},
+		{"maximum", sql.NewRow(3020399), "838:59:59", false},
+		{"clamped", sql.NewRow(3020400), "838:59:59", true},
+		{"clamped negative", sql.NewRow(-5000000), "-838:59:59", true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := sql.NewEmptyContext()
+			val, err := f.Eval(ctx, tt.row)
+			require.NoError(err)
+			require.Equal(tt.expected, val)
+			if tt.warning {
+				require.Len(ctx.Warnings(), 1)
+				require.Equal(1292, ctx.Warnings()[0].Code)
+			} else {
+				require.Empty(ctx.Warnings())
+			}
+		})
+	}
+}
+
+func TestTime_TimeToSec(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	f := NewTimeToSec(expression.NewGetField(0, sql.LongText, "foo", true))
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"null", sql.NewRow(nil), nil},
+		{"time", sql.NewRow("00:39:38"), int64(2378)},
+		{"more than a day", sql.NewRow("25:01:01"), int64(90061)},
+		{"negative", sql.NewRow("-01:01:01"), int64(-3661)},
+		{"fraction", sql.NewRow("00:00:01.5"), int64(1)},
+		{"maximum", sql.NewRow("838:59:59"), int64(3020399)},
+		{"datetime", sql.NewRow("2021-03-04 01:02:03"), int64(3723)},
+		{"datetime as time", sql.NewRow(time.Date(2021, 3, 4, 1, 2, 3, 0, time.UTC)), int64(3723)},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			val, err := f.Eval(ctx, tt.row)
+			require.NoError(err)
+			require.Equal(tt.expected, val)
+		})
+	}
+
+	// converting back gives the same time
+	for _, s := range []string{"00:00:00", "25:01:01", "-01:01:01", "838:59:59"} {
+		secs, err := f.Eval(ctx, sql.NewRow(s))
+		require.NoError(t, err)
+		back, err := NewSecToTime(expression.NewLiteral(secs, sql.Int64)).Eval(ctx, nil)
+		require.NoError(t, err)
+		require.Equal(t, s, back)
+	}
+}
+
+// TestTime_DateParseConsistency checks that DAYOFYEAR, DAYOFWEEK and DATE_FORMAT agree with the day of year and
+// weekday conventions of the dateparse package for every day of leap and non-leap years.
+func TestTime_DateParseConsistency(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	field := expression.NewGetField(0, sql.LongText, "foo", false)
+	dayOfYear, dayOfWeek, lastDay := NewDayOfYear(field), NewDayOfWeek(field), NewLastDay(field)
+
+	for _, year := range []int{1900, 2000, 2020, 2021} {
+		t.Run(strconv.Itoa(year), func(t *testing.T) {
+			require := require.New(t)
+			days := 365
+			if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+				days = 366
+			}
+
+			for day := 1; day <= days; day++ {
+				// parsing the day of the year and computing DAYOFYEAR must agree
+				parsed, err := dateparse.ParseDateWithFormat(fmt.Sprintf("%d %03d", year, day), "%Y %j")
+				require.NoError(err)
+				require.Equal(year, parsed.Year())
+				doy, err := dayOfYear.Eval(ctx, sql.NewRow(parsed))
+				require.NoError(err)
+				require.Equal(int32(day), doy)
+
+				// formatting the day of the year and weekday must agree with DAYOFYEAR and DAYOFWEEK
+				formatted, err := formatDate("%j %w %W", parsed)
+				require.NoError(err)
+				dow, err := dayOfWeek.Eval(ctx, sql.NewRow(parsed))
+				require.NoError(err)
+				require.Equal(fmt.Sprintf("%03d %d %s", day, dow.(int32)-1, parsed.Weekday()), formatted)
+
+				// and so must parsing the formatted weekday along with the date
+				_, err = dateparse.ParseDateWithFormat(fmt.Sprintf("%d %03d %s", year, day, formatted[4:]), "%Y %j %w %W")
+				require.NoError(err)
+
+				// the last day of the month is the day before the first day of the next month
+				last, err := lastDay.Eval(ctx, sql.NewRow(parsed))
+				require.NoError(err)
+				next := last.(time.Time).AddDate(0, 0, 1)
+				require.Equal(1, next.Day())
+				require.Equal(parsed.Month()%12+1, next.Month())
+			}
+
+			// day of year past the end of the year continues into the next year
+			parsed, err := dateparse.ParseDateWithFormat(fmt.Sprintf("%d %03d", year, days+1), "%Y %j")
+			require.NoError(err)
+			require.Equal(time.Date(year+1, time.January, 1, 0, 0, 0, 0, time.Local), parsed)
+		})
+	}
+}
+
 func TestYearWeek(t *testing.T) {
 	ctx := sql.NewEmptyContext()
 	f, err := NewYearWeek(expression.NewGetField(0, sql.LongText, "foo", false))
@@ -331,6 +492,79 @@ func TestYearWeek(t *testing.T) {
 	}
 }
 
+func TestWeekModes(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	// 2021-01-01 is a Friday, so its week has fewer than four days in 2021 and doesn't contain the first Sunday or
+	// Monday of the year.
+	date := expression.NewLiteral("2021-01-01", sql.LongText)
+
+	testCases := []struct {
+		mode     int64
+		week     int32
+		yearWeek int32
+	}{
+		{0, 0, 202052},
+		{1, 0, 202053},
+		{2, 52, 202052},
+		{3, 53, 202053},
+		{4, 0, 202053},
+		{5, 0, 202052},
+		{6, 53, 202053},
+		{7, 52, 202052},
+	}
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("mode %d", tt.mode), func(t *testing.T) {
+			require := require.New(t)
+			mode := expression.NewLiteral(tt.mode, sql.Int64)
+
+			w, err := NewWeek(date, mode)
+			require.NoError(err)
+			val, err := w.Eval(ctx, nil)
+			require.NoError(err)
+			require.Equal(tt.week, val)
+
+			yw, err := NewYearWeek(date, mode)
+			require.NoError(err)
+			val, err = yw.Eval(ctx, nil)
+			require.NoError(err)
+			require.Equal(tt.yearWeek, val)
+
+			// modes wrap around after 7
+			w, err = NewWeek(date, expression.NewLiteral(tt.mode+8, sql.Int64))
+			require.NoError(err)
+			val, err = w.Eval(ctx, nil)
+			require.NoError(err)
+			require.Equal(tt.week, val)
+		})
+	}
+}
+
+func TestWeekDefaultMode(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+	date := expression.NewLiteral("2021-01-01", sql.LongText)
+
+	w, err := NewWeek(date)
+	require.NoError(err)
+	require.Equal(`WEEK("2021-01-01")`, w.String())
+	val, err := w.Eval(ctx, nil)
+	require.NoError(err)
+	require.Equal(int32(0), val)
+
+	require.NoError(ctx.SetSessionVariable(ctx, "default_week_format", int64(3)))
+	val, err = w.Eval(ctx, nil)
+	require.NoError(err)
+	require.Equal(int32(53), val)
+
+	// YEARWEEK is not affected by default_week_format
+	yw, err := NewYearWeek(date)
+	require.NoError(err)
+	val, err = yw.Eval(ctx, nil)
+	require.NoError(err)
+	require.Equal(int32(202052), val)
+}
+
 func TestCalcDaynr(t *testing.T) {
 	require.EqualValues(t, calcDaynr(0, 0, 0), 0)
 	require.EqualValues(t, calcDaynr(9999, 12, 31), 3652424)
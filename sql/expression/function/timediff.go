@@ -295,81 +295,178 @@ func (t *TimestampDiff) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 		return nil, nil
 	}
 
-	unit = strings.TrimPrefix(strings.ToLower(unit.(string)), "sql_tsi_")
+	u, err := timestampUnit(unit)
+	if err != nil {
+		return nil, err
+	}
 
 	date1 := expr1.(time.Time)
 	date2 := expr2.(time.Time)
 
-	diff := date2.Sub(date1)
-
-	var res int64
-	switch unit {
-	case "microsecond":
-		res = diff.Microseconds()
-	case "second":
-		res = int64(diff.Seconds())
-	case "minute":
-		res = int64(diff.Minutes())
-	case "hour":
-		res = int64(diff.Hours())
-	case "day":
-		res = int64(diff.Hours() / 24)
-	case "week":
-		res = int64(diff.Hours() / (24 * 7))
-	case "month":
-		res = int64(diff.Hours() / (24 * 30))
-		if res > 0 {
-			if date2.Day()-date1.Day() < 0 {
-				res -= 1
-			} else if date2.Hour()-date1.Hour() < 0 {
-				res -= 1
-			} else if date2.Minute()-date1.Minute() < 0 {
-				res -= 1
-			} else if date2.Second()-date1.Second() < 0 {
-				res -= 1
-			}
-		}
-	case "quarter":
-		monthRes := int64(diff.Hours() / (24 * 30))
-		if monthRes > 0 {
-			if date2.Day()-date1.Day() < 0 {
-				monthRes -= 1
-			} else if date2.Hour()-date1.Hour() < 0 {
-				monthRes -= 1
-			} else if date2.Minute()-date1.Minute() < 0 {
-				monthRes -= 1
-			} else if date2.Second()-date1.Second() < 0 {
-				monthRes -= 1
-			}
-		}
-		res = monthRes / 3
-	case "year":
-		yearRes := int64(diff.Hours() / (24 * 365))
-		if yearRes > 0 {
-			monthRes := int64(diff.Hours() / (24 * 30))
-			if monthRes > 0 {
-				if date2.Day()-date1.Day() < 0 {
-					monthRes -= 1
-				} else if date2.Hour()-date1.Hour() < 0 {
-					monthRes -= 1
-				} else if date2.Minute()-date1.Minute() < 0 {
-					monthRes -= 1
-				} else if date2.Second()-date1.Second() < 0 {
-					monthRes -= 1
-				}
-			}
-			res = monthRes / 12
-		} else {
-			res = yearRes
-		}
+	switch u {
+	case "MONTH":
+		return monthsBetween(date1, date2), nil
+	case "QUARTER":
+		return monthsBetween(date1, date2) / 3, nil
+	case "YEAR":
+		return monthsBetween(date1, date2) / 12, nil
+	}
 
-	default:
-		return nil, errors.NewKind("invalid interval unit: %s").New(unit)
+	// A time.Duration only spans about 292 years, so the difference is taken in microseconds, which span all the
+	// datetimes MySQL supports. Go's division truncates toward zero, like MySQL does with partial units.
+	micros := (date2.Unix()-date1.Unix())*1000000 + int64(date2.Nanosecond()/1000-date1.Nanosecond()/1000)
+	return micros / timestampUnitMicroseconds[u], nil
+}
+
+// timestampUnitMicroseconds is the number of microseconds in each unit of TIMESTAMPDIFF whose length is fixed.
+var timestampUnitMicroseconds = map[string]int64{
+	"MICROSECOND": 1,
+	"SECOND":      int64(time.Second / time.Microsecond),
+	"MINUTE":      int64(time.Minute / time.Microsecond),
+	"HOUR":        int64(time.Hour / time.Microsecond),
+	"DAY":         int64(24 * time.Hour / time.Microsecond),
+	"WEEK":        int64(7 * 24 * time.Hour / time.Microsecond),
+}
+
+// monthsBetween returns the number of whole months from date1 to date2, negative if date2 is before date1. A month
+// is only whole once the day and time of date1 are reached again, so there are no months between Jan 31 and Feb 28.
+func monthsBetween(date1, date2 time.Time) int64 {
+	sign := int64(1)
+	if date2.Before(date1) {
+		date1, date2 = date2, date1
+		sign = -1
+	}
+
+	months := int64(date2.Year()-date1.Year())*12 + int64(date2.Month()-date1.Month())
+	if sinceMonthStart(date2) < sinceMonthStart(date1) {
+		months--
 	}
+	return months * sign
+}
+
+// sinceMonthStart returns the time elapsed since the start of the month of the date given.
+func sinceMonthStart(t time.Time) time.Duration {
+	return time.Duration(t.Day()-1)*24*time.Hour + time.Duration(t.Hour())*time.Hour +
+		time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+}
 
-	return res, nil
+var errInvalidTimestampUnit = errors.NewKind("invalid interval unit: %s")
+
+// timestampUnit returns the unit of TIMESTAMPADD or TIMESTAMPDIFF in upper case, without the SQL_TSI_ prefix it
+// may have.
+func timestampUnit(unit interface{}) (string, error) {
+	unitStr, ok := unit.(string)
+	if !ok {
+		return "", errInvalidTimestampUnit.New(unit)
+	}
+
+	u := strings.TrimPrefix(strings.ToUpper(unitStr), "SQL_TSI_")
+	switch u {
+	case "MICROSECOND", "SECOND", "MINUTE", "HOUR", "DAY", "WEEK", "MONTH", "QUARTER", "YEAR":
+		return u, nil
+	default:
+		return "", errInvalidTimestampUnit.New(unitStr)
+	}
 }
 
 func (t *TimestampDiff) String() string {
 	return fmt.Sprintf("TIMESTAMPDIFF(%s, %s, %s)", t.unit, t.expr1, t.expr2)
 }
+
+// TimestampAdd returns the datetime plus a count of the unit specified.
+type TimestampAdd struct {
+	unit  sql.Expression
+	count sql.Expression
+	date  sql.Expression
+}
+
+var _ sql.FunctionExpression = (*TimestampAdd)(nil)
+
+// NewTimestampAdd creates a new TIMESTAMPADD() function.
+func NewTimestampAdd(u, count, date sql.Expression) sql.Expression {
+	return &TimestampAdd{u, count, date}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (t *TimestampAdd) FunctionName() string {
+	return "timestampadd"
+}
+
+// Description implements sql.FunctionExpression
+func (t *TimestampAdd) Description() string {
+	return "adds a count of the units specified to the date."
+}
+
+// Children implements the sql.Expression interface.
+func (t *TimestampAdd) Children() []sql.Expression {
+	return []sql.Expression{t.unit, t.count, t.date}
+}
+
+// Resolved implements the sql.Expression interface.
+func (t *TimestampAdd) Resolved() bool {
+	return t.unit.Resolved() && t.count.Resolved() && t.date.Resolved()
+}
+
+// IsNullable implements the sql.Expression interface.
+func (t *TimestampAdd) IsNullable() bool {
+	return true
+}
+
+// Type implements the sql.Expression interface.
+func (t *TimestampAdd) Type() sql.Type { return sql.Datetime }
+
+// WithChildren implements the Expression interface.
+func (t *TimestampAdd) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 3 {
+		return nil, sql.ErrInvalidChildrenNumber.New(t, len(children), 3)
+	}
+	return NewTimestampAdd(children[0], children[1], children[2]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (t *TimestampAdd) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	unit, err := t.unit.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	if unit == nil {
+		return nil, nil
+	}
+
+	u, err := timestampUnit(unit)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := t.count.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	if count == nil {
+		return nil, nil
+	}
+
+	date, err := t.date.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	if date == nil {
+		return nil, nil
+	}
+
+	date, err = sql.Datetime.Convert(date)
+	if err != nil {
+		return nil, err
+	}
+
+	delta, err := expression.NewTimeDelta(count, u)
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.ValidateTime(delta.Add(date.(time.Time))), nil
+}
+
+func (t *TimestampAdd) String() string {
+	return fmt.Sprintf("TIMESTAMPADD(%s, %s, %s)", t.unit, t.count, t.date)
+}
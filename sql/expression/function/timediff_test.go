@@ -220,6 +220,18 @@ func TestTimestampDiff(t *testing.T) {
 		{"year", sql.Text, sql.Text, sql.Text, sql.NewRow("YEAR", "2016-09-04 00:00:01", "2021-09-04 00:00:00"), int64(4), false},
 		{"year - ", sql.Text, sql.Text, sql.Text, sql.NewRow("YEAR", "2016-09-04 01:00:01", "2021-09-04 02:00:02"), int64(5), false},
 		{"year - negative", sql.Text, sql.Text, sql.Text, sql.NewRow("SQL_TSI_YEAR", "2016-09-05 00:00:00", "2006-09-04 23:59:59"), int64(-10), false},
+		{"month - end of january to end of february", sql.Text, sql.Text, sql.Text, sql.NewRow("MONTH", "2021-01-31", "2021-02-28"), int64(0), false},
+		{"month - end of january to first of march", sql.Text, sql.Text, sql.Text, sql.NewRow("MONTH", "2021-01-31", "2021-03-01"), int64(1), false},
+		{"month - end of february to end of march", sql.Text, sql.Text, sql.Text, sql.NewRow("MONTH", "2021-02-28", "2021-03-31"), int64(1), false},
+		{"month - end of february to end of january", sql.Text, sql.Text, sql.Text, sql.NewRow("MONTH", "2021-02-28", "2021-01-31"), int64(0), false},
+		{"month - microsecond less than a month", sql.Text, sql.Text, sql.Text, sql.NewRow("MONTH", "2021-01-15 12:00:00.000001", "2021-02-15 12:00:00"), int64(0), false},
+		{"month - over years", sql.Text, sql.Text, sql.Text, sql.NewRow("MONTH", "2019-11-30", "2021-02-28"), int64(14), false},
+		{"year - leap day", sql.Text, sql.Text, sql.Text, sql.NewRow("YEAR", "2020-02-29", "2021-02-28"), int64(0), false},
+		{"day - across a leap day", sql.Text, sql.Text, sql.Text, sql.NewRow("DAY", "2020-02-28", "2020-03-01"), int64(2), false},
+		{"day - second less than a day, negative", sql.Text, sql.Text, sql.Text, sql.NewRow("DAY", "2021-01-02 00:00:00", "2021-01-01 00:00:01"), int64(0), false},
+		{"second - truncated toward zero", sql.Text, sql.Text, sql.Text, sql.NewRow("SECOND", "2021-01-01 00:00:01.9", "2021-01-01 00:00:00"), int64(-1), false},
+		{"second - across a year", sql.Text, sql.Text, sql.Text, sql.NewRow("SECOND", "2020-12-31 23:59:59", "2021-01-01 00:00:00"), int64(1), false},
+		{"second - over centuries", sql.Text, sql.Text, sql.Text, sql.NewRow("SECOND", "1000-01-01 00:00:00", "9999-12-31 23:59:59"), int64(284012524799), false},
 	}
 
 	for _, tt := range testCases {
@@ -241,3 +253,49 @@ func TestTimestampDiff(t *testing.T) {
 		})
 	}
 }
+
+func TestTimestampAdd(t *testing.T) {
+	testCases := []struct {
+		name     string
+		unit     interface{}
+		count    interface{}
+		date     interface{}
+		expected interface{}
+		err      bool
+	}{
+		{"invalid unit", "MILLISECOND", int64(1), "2021-01-31", nil, true},
+		{"compound unit", "DAY_HOUR", "1 2", "2021-01-31", nil, true},
+		{"microsecond", "MICROSECOND", int64(1), "2021-01-31 23:59:59.999999", time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC), false},
+		{"second", "SECOND", int64(-1), "2021-01-01", time.Date(2020, time.December, 31, 23, 59, 59, 0, time.UTC), false},
+		{"minute", "SQL_TSI_MINUTE", int64(90), "2021-01-01", time.Date(2021, time.January, 1, 1, 30, 0, 0, time.UTC), false},
+		{"hour", "hour", int64(25), "2021-01-01", time.Date(2021, time.January, 2, 1, 0, 0, 0, time.UTC), false},
+		{"day", "DAY", int64(1), "2020-02-28", time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC), false},
+		{"week", "WEEK", int64(-1), "2021-01-01", time.Date(2020, time.December, 25, 0, 0, 0, 0, time.UTC), false},
+		{"month - end of month", "MONTH", int64(1), "2021-01-31", time.Date(2021, time.February, 28, 0, 0, 0, 0, time.UTC), false},
+		{"month - negative", "MONTH", int64(-1), "2021-03-31 12:00:00", time.Date(2021, time.February, 28, 12, 0, 0, 0, time.UTC), false},
+		{"quarter", "QUARTER", int64(1), "2021-11-30", time.Date(2022, time.February, 28, 0, 0, 0, 0, time.UTC), false},
+		{"year - leap day", "YEAR", int64(1), "2020-02-29", time.Date(2021, time.February, 28, 0, 0, 0, 0, time.UTC), false},
+		{"null count", "DAY", nil, "2021-01-01", nil, false},
+		{"null date", "DAY", int64(1), nil, nil, false},
+	}
+
+	for _, tt := range testCases {
+		f := NewTimestampAdd(
+			expression.NewLiteral(tt.unit, sql.LongText),
+			expression.NewLiteral(tt.count, sql.Int64),
+			expression.NewLiteral(tt.date, sql.LongText),
+		)
+
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			result, err := f.Eval(sql.NewEmptyContext(), nil)
+			if tt.err {
+				require.Error(err)
+			} else {
+				require.NoError(err)
+				require.Equal(tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// WebMercatorSRID is the SRID of the WGS 84 / Pseudo-Mercator projection used by web maps
+const WebMercatorSRID = 3857
+
+// webMercatorRadius is the radius of the sphere used by the Web Mercator projection, in meters
+const webMercatorRadius = 6378137.0
+
+var ErrUnsupportedTransform = errors.NewKind("transformation from SRID %d to SRID %d is not supported")
+
+// SRIDTransform converts a coordinate pair from one spatial reference system to another.
+type SRIDTransform func(x, y float64) (float64, float64, error)
+
+type sridPair struct {
+	from, to uint32
+}
+
+var (
+	sridTransformsMu sync.RWMutex
+	sridTransforms   = map[sridPair]SRIDTransform{
+		{GeoSpatialSRID, WebMercatorSRID}: wgs84ToWebMercator,
+		{WebMercatorSRID, GeoSpatialSRID}: webMercatorToWGS84,
+	}
+)
+
+// RegisterSRIDTransform registers the transform used by ST_TRANSFORM to convert geometries from the spatial reference
+// system [from] to [to], replacing any transform registered for the same pair. Integrators can use this to plug in
+// projection libraries for the reference systems they need.
+func RegisterSRIDTransform(from, to uint32, transform SRIDTransform) {
+	sridTransformsMu.Lock()
+	defer sridTransformsMu.Unlock()
+	sridTransforms[sridPair{from: from, to: to}] = transform
+}
+
+// LookupSRIDTransform returns the transform from the spatial reference system [from] to [to]. Transforming to the
+// same spatial reference system is always the identity.
+func LookupSRIDTransform(from, to uint32) (SRIDTransform, bool) {
+	if from == to {
+		return identityTransform, true
+	}
+	sridTransformsMu.RLock()
+	defer sridTransformsMu.RUnlock()
+	transform, ok := sridTransforms[sridPair{from: from, to: to}]
+	return transform, ok
+}
+
+func identityTransform(x, y float64) (float64, float64, error) {
+	return x, y, nil
+}
+
+// wgs84ToWebMercator projects a longitude and latitude in degrees to Web Mercator meters.
+func wgs84ToWebMercator(lon, lat float64) (float64, float64, error) {
+	if lat <= -90.0 || lat >= 90.0 {
+		return 0, 0, ErrLatitudeOutOfRange.New(lat, "st_transform")
+	}
+	x := webMercatorRadius * lon * math.Pi / 180
+	y := webMercatorRadius * math.Log(math.Tan(math.Pi/4+lat*math.Pi/360))
+	return x, y, nil
+}
+
+// webMercatorToWGS84 converts Web Mercator meters to a longitude and latitude in degrees.
+func webMercatorToWGS84(x, y float64) (float64, float64, error) {
+	lon := x / webMercatorRadius * 180 / math.Pi
+	lat := (2*math.Atan(math.Exp(y/webMercatorRadius)) - math.Pi/2) * 180 / math.Pi
+	return lon, lat, nil
+}
+
+// Transform is a function that converts a geometry to another spatial reference system.
+type Transform struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*Transform)(nil)
+
+// NewTransform creates a new ST_TRANSFORM expression.
+func NewTransform(g, srid sql.Expression) sql.Expression {
+	return &Transform{
+		expression.BinaryExpression{
+			Left:  g,
+			Right: srid,
+		},
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (t *Transform) FunctionName() string {
+	return "st_transform"
+}
+
+// Description implements sql.FunctionExpression
+func (t *Transform) Description() string {
+	return "returns the geometry converted to the spatial reference system of the given SRID."
+}
+
+// Type implements the sql.Expression interface.
+func (t *Transform) Type() sql.Type {
+	return t.Left.Type()
+}
+
+func (t *Transform) String() string {
+	return fmt.Sprintf("ST_TRANSFORM(%s,%s)", t.Left, t.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (t *Transform) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(t, len(children), 2)
+	}
+	return NewTransform(children[0], children[1]), nil
+}
+
+// TransformGeometry returns a deep copy of the geometry with every point converted by [transform] and the given SRID
+func TransformGeometry(v interface{}, srid uint32, transform SRIDTransform) (interface{}, error) {
+	switch v := v.(type) {
+	case sql.Point:
+		x, y, err := transform(v.X, v.Y)
+		if err != nil {
+			return nil, err
+		}
+		return sql.Point{SRID: srid, X: x, Y: y, Z: v.Z, M: v.M}, nil
+	case sql.Linestring:
+		points := make([]sql.Point, len(v.Points))
+		for i, p := range v.Points {
+			tp, err := TransformGeometry(p, srid, transform)
+			if err != nil {
+				return nil, err
+			}
+			points[i] = tp.(sql.Point)
+		}
+		return sql.Linestring{SRID: srid, Points: points}, nil
+	case sql.Polygon:
+		lines := make([]sql.Linestring, len(v.Lines))
+		for i, l := range v.Lines {
+			tl, err := TransformGeometry(l, srid, transform)
+			if err != nil {
+				return nil, err
+			}
+			lines[i] = tl.(sql.Linestring)
+		}
+		return sql.Polygon{SRID: srid, Lines: lines}, nil
+	case sql.MultiPoint:
+		points := make([]sql.Point, len(v.Points))
+		for i, p := range v.Points {
+			tp, err := TransformGeometry(p, srid, transform)
+			if err != nil {
+				return nil, err
+			}
+			points[i] = tp.(sql.Point)
+		}
+		return sql.MultiPoint{SRID: srid, Points: points}, nil
+	case sql.MultiLinestring:
+		lines := make([]sql.Linestring, len(v.Lines))
+		for i, l := range v.Lines {
+			tl, err := TransformGeometry(l, srid, transform)
+			if err != nil {
+				return nil, err
+			}
+			lines[i] = tl.(sql.Linestring)
+		}
+		return sql.MultiLinestring{SRID: srid, Lines: lines}, nil
+	case sql.MultiPolygon:
+		polys := make([]sql.Polygon, len(v.Polygons))
+		for i, p := range v.Polygons {
+			tp, err := TransformGeometry(p, srid, transform)
+			if err != nil {
+				return nil, err
+			}
+			polys[i] = tp.(sql.Polygon)
+		}
+		return sql.MultiPolygon{SRID: srid, Polygons: polys}, nil
+	case sql.GeometryCollection:
+		geoms := make([]interface{}, len(v.Geoms))
+		for i, g := range v.Geoms {
+			tg, err := TransformGeometry(g, srid, transform)
+			if err != nil {
+				return nil, err
+			}
+			geoms[i] = tg
+		}
+		return sql.GeometryCollection{SRID: srid, Geoms: geoms}, nil
+	case sql.Geometry:
+		inner, err := TransformGeometry(v.Inner, srid, transform)
+		if err != nil {
+			return nil, err
+		}
+		return sql.Geometry{Inner: inner}, nil
+	default:
+		return nil, sql.ErrIllegalGISValue.New(v)
+	}
+}
+
+// geometrySRID returns the SRID of a geometry value.
+func geometrySRID(v interface{}) (uint32, error) {
+	switch v := v.(type) {
+	case sql.Point:
+		return v.SRID, nil
+	case sql.Linestring:
+		return v.SRID, nil
+	case sql.Polygon:
+		return v.SRID, nil
+	case sql.MultiPoint:
+		return v.SRID, nil
+	case sql.MultiLinestring:
+		return v.SRID, nil
+	case sql.MultiPolygon:
+		return v.SRID, nil
+	case sql.GeometryCollection:
+		return v.SRID, nil
+	case sql.Geometry:
+		return geometrySRID(v.Inner)
+	default:
+		return 0, sql.ErrIllegalGISValue.New(v)
+	}
+}
+
+// Eval implements the sql.Expression interface.
+func (t *Transform) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	g, err := t.Left.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return null if geometry is null
+	if g == nil {
+		return nil, nil
+	}
+
+	srid, err := t.Right.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return null if the target SRID is null
+	if srid == nil {
+		return nil, nil
+	}
+
+	srid, err = sql.Uint32.Convert(srid)
+	if err != nil {
+		return nil, err
+	}
+	to := srid.(uint32)
+
+	from, err := geometrySRID(g)
+	if err != nil {
+		return nil, err
+	}
+
+	transform, ok := LookupSRIDTransform(from, to)
+	if !ok {
+		return nil, ErrUnsupportedTransform.New(from, to)
+	}
+
+	return TransformGeometry(g, to, transform)
+}
@@ -0,0 +1,113 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestTransform(t *testing.T) {
+	t.Run("wgs84 point to web mercator", func(t *testing.T) {
+		require := require.New(t)
+		london := sql.Point{SRID: GeoSpatialSRID, X: -0.1278, Y: 51.5074}
+		f := NewTransform(expression.NewLiteral(london, sql.PointType{}), expression.NewLiteral(WebMercatorSRID, sql.Int32))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		p := v.(sql.Point)
+		require.Equal(uint32(WebMercatorSRID), p.SRID)
+		require.InDelta(-14226.6309, p.X, 1e-3)
+		require.InDelta(6711542.4756, p.Y, 1e-3)
+
+		f = NewTransform(expression.NewLiteral(p, sql.PointType{}), expression.NewLiteral(GeoSpatialSRID, sql.Int32))
+		v, err = f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		p = v.(sql.Point)
+		require.Equal(uint32(GeoSpatialSRID), p.SRID)
+		require.InDelta(london.X, p.X, 1e-9)
+		require.InDelta(london.Y, p.Y, 1e-9)
+	})
+
+	t.Run("wgs84 linestring to web mercator", func(t *testing.T) {
+		require := require.New(t)
+		line := sql.Linestring{SRID: GeoSpatialSRID, Points: []sql.Point{{SRID: GeoSpatialSRID, X: 0, Y: 0}, {SRID: GeoSpatialSRID, X: 180, Y: 0}}}
+		f := NewTransform(expression.NewLiteral(sql.Geometry{Inner: line}, sql.GeometryType{}), expression.NewLiteral(WebMercatorSRID, sql.Int32))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		l := v.(sql.Geometry).Inner.(sql.Linestring)
+		require.Equal(uint32(WebMercatorSRID), l.SRID)
+		require.Equal(sql.Point{SRID: WebMercatorSRID}, l.Points[0])
+		require.InDelta(20037508.3428, l.Points[1].X, 1e-3)
+		require.InDelta(0, l.Points[1].Y, 1e-9)
+	})
+
+	t.Run("identity", func(t *testing.T) {
+		require := require.New(t)
+		poly := sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}, {X: 0, Y: 0}}}}}
+		f := NewTransform(expression.NewLiteral(poly, sql.PolygonType{}), expression.NewLiteral(0, sql.Int32))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(poly, v)
+	})
+
+	t.Run("registered transform", func(t *testing.T) {
+		require := require.New(t)
+		RegisterSRIDTransform(0, 1234, func(x, y float64) (float64, float64, error) {
+			return x * 2, y * 2, nil
+		})
+		f := NewTransform(expression.NewLiteral(sql.Point{X: 1, Y: 2}, sql.PointType{}), expression.NewLiteral(1234, sql.Int32))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Point{SRID: 1234, X: 2, Y: 4}, v)
+	})
+
+	t.Run("unregistered srid pair", func(t *testing.T) {
+		require := require.New(t)
+		f := NewTransform(expression.NewLiteral(sql.Point{X: 1, Y: 2}, sql.PointType{}), expression.NewLiteral(WebMercatorSRID, sql.Int32))
+		_, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.True(ErrUnsupportedTransform.Is(err))
+	})
+
+	t.Run("latitude out of range", func(t *testing.T) {
+		require := require.New(t)
+		f := NewTransform(expression.NewLiteral(sql.Point{SRID: GeoSpatialSRID, X: 0, Y: 90}, sql.PointType{}), expression.NewLiteral(WebMercatorSRID, sql.Int32))
+		_, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.True(ErrLatitudeOutOfRange.Is(err))
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		require := require.New(t)
+		f := NewTransform(expression.NewLiteral(123, sql.Int64), expression.NewLiteral(0, sql.Int32))
+		_, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.Error(err)
+	})
+
+	t.Run("null is null", func(t *testing.T) {
+		require := require.New(t)
+		f := NewTransform(expression.NewLiteral(nil, sql.Null), expression.NewLiteral(0, sql.Int32))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(nil, v)
+
+		f = NewTransform(expression.NewLiteral(sql.Point{X: 1, Y: 2}, sql.PointType{}), expression.NewLiteral(nil, sql.Null))
+		v, err = f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(nil, v)
+	})
+}
@@ -0,0 +1,233 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// IsValid is a function that returns whether a geometry is geometrically valid.
+type IsValid struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*IsValid)(nil)
+
+// NewIsValid creates a new ST_ISVALID expression.
+func NewIsValid(e sql.Expression) sql.Expression {
+	return &IsValid{expression.UnaryExpression{Child: e}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (v *IsValid) FunctionName() string {
+	return "st_isvalid"
+}
+
+// Description implements sql.FunctionExpression
+func (v *IsValid) Description() string {
+	return "returns 1 if the argument is geometrically valid, 0 if it is not."
+}
+
+// Type implements the sql.Expression interface.
+func (v *IsValid) Type() sql.Type {
+	return sql.Boolean
+}
+
+func (v *IsValid) String() string {
+	return fmt.Sprintf("ST_ISVALID(%s)", v.Child.String())
+}
+
+// WithChildren implements the Expression interface.
+func (v *IsValid) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(v, len(children), 1)
+	}
+	return NewIsValid(children[0]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (v *IsValid) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := v.Child.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return nil if geometry is nil
+	if val == nil {
+		return nil, nil
+	}
+
+	switch val.(type) {
+	case sql.Point, sql.Linestring, sql.Polygon, sql.Geometry:
+		return isValidGeometry(val), nil
+	default:
+		return nil, sql.ErrInvalidGISData.New(v.FunctionName())
+	}
+}
+
+// IsSimple is a function that returns whether a geometry has no anomalous points, such as self intersections.
+type IsSimple struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*IsSimple)(nil)
+
+// NewIsSimple creates a new ST_ISSIMPLE expression.
+func NewIsSimple(e sql.Expression) sql.Expression {
+	return &IsSimple{expression.UnaryExpression{Child: e}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *IsSimple) FunctionName() string {
+	return "st_issimple"
+}
+
+// Description implements sql.FunctionExpression
+func (s *IsSimple) Description() string {
+	return "returns 1 if the argument is geometrically simple, 0 if it is not."
+}
+
+// Type implements the sql.Expression interface.
+func (s *IsSimple) Type() sql.Type {
+	return sql.Boolean
+}
+
+func (s *IsSimple) String() string {
+	return fmt.Sprintf("ST_ISSIMPLE(%s)", s.Child.String())
+}
+
+// WithChildren implements the Expression interface.
+func (s *IsSimple) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 1)
+	}
+	return NewIsSimple(children[0]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (s *IsSimple) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := s.Child.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return nil if geometry is nil
+	if val == nil {
+		return nil, nil
+	}
+
+	switch val.(type) {
+	case sql.Point, sql.Linestring, sql.Polygon, sql.Geometry:
+		return isSimpleGeometry(val), nil
+	default:
+		return nil, sql.ErrInvalidGISData.New(s.FunctionName())
+	}
+}
+
+// isValidGeometry returns whether a geometry is valid: points have finite coordinates, linestrings have at least two
+// distinct points, and polygons have at least one ring, each of them closed, with at least four points and without
+// self intersections.
+func isValidGeometry(v interface{}) bool {
+	switch v := v.(type) {
+	case sql.Point:
+		return !math.IsNaN(v.X) && !math.IsInf(v.X, 0) && !math.IsNaN(v.Y) && !math.IsInf(v.Y, 0)
+	case sql.Linestring:
+		distinct := false
+		for _, p := range v.Points {
+			if !isValidGeometry(p) {
+				return false
+			}
+			if !p.Equals(v.Points[0]) {
+				distinct = true
+			}
+		}
+		return distinct
+	case sql.Polygon:
+		if len(v.Lines) == 0 {
+			return false
+		}
+		for _, l := range v.Lines {
+			if len(l.Points) < 4 || !isLinearRing(l) || !isSimpleLinestring(l.Points) {
+				return false
+			}
+		}
+		return true
+	case sql.Geometry:
+		return isValidGeometry(v.Inner)
+	default:
+		return false
+	}
+}
+
+// isSimpleGeometry returns whether a geometry is simple. Points are always simple, and linestrings and polygon rings
+// are simple when they don't intersect themselves.
+func isSimpleGeometry(v interface{}) bool {
+	switch v := v.(type) {
+	case sql.Point:
+		return true
+	case sql.Linestring:
+		return isSimpleLinestring(v.Points)
+	case sql.Polygon:
+		for _, l := range v.Lines {
+			if !isSimpleLinestring(l.Points) {
+				return false
+			}
+		}
+		return true
+	case sql.Geometry:
+		return isSimpleGeometry(v.Inner)
+	default:
+		return false
+	}
+}
+
+// isSimpleLinestring returns whether a linestring doesn't pass through the same point twice. The endpoints of a
+// closed linestring are the only points allowed to touch.
+func isSimpleLinestring(points []sql.Point) bool {
+	n := len(points) - 1
+	closed := n > 1 && points[0].Equals(points[n])
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			a, b, c, d := points[i], points[i+1], points[j], points[j+1]
+			switch {
+			case j == i+1:
+				// consecutive segments share b and c
+				if segmentsOverlap(b, a, d) {
+					return false
+				}
+			case closed && i == 0 && j == n-1:
+				// the first and last segments of a closed linestring share a and d
+				if segmentsOverlap(a, b, c) {
+					return false
+				}
+			case a.Equals(c) || a.Equals(d) || b.Equals(c) || b.Equals(d) || lineSegmentsIntersect(a, b, c, d):
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// segmentsOverlap returns whether the segments from [shared] to [p] and from [shared] to [q] are collinear and point
+// in the same direction, overlapping beyond their shared endpoint.
+func segmentsOverlap(shared, p, q sql.Point) bool {
+	px, py := p.X-shared.X, p.Y-shared.Y
+	qx, qy := q.X-shared.X, q.Y-shared.Y
+	return px*qy-py*qx == 0 && px*qx+py*qy > 0
+}
@@ -0,0 +1,78 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestIsValidAndIsSimple(t *testing.T) {
+	square := sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}, {X: 0, Y: 0}}}}}
+	unclosed := sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}}}}}
+	triangle := sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 0}}}}}
+	bowtieRing := sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: 0}}}}}
+	bowtie := sql.Linestring{Points: []sql.Point{{X: 0, Y: 0}, {X: 2, Y: 2}, {X: 2, Y: 0}, {X: 0, Y: 2}}}
+	zigzag := sql.Linestring{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 0}, {X: 3, Y: 1}}}
+	backtrack := sql.Linestring{Points: []sql.Point{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 1, Y: 0}}}
+	touching := sql.Linestring{Points: []sql.Point{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 2}, {X: 1, Y: 2}, {X: 1, Y: 0}}}
+
+	tests := []struct {
+		name   string
+		g      interface{}
+		valid  interface{}
+		simple interface{}
+	}{
+		{name: "point", g: sql.Point{X: 1, Y: 2}, valid: true, simple: true},
+		{name: "valid square", g: square, valid: true, simple: true},
+		{name: "valid square geometry", g: sql.Geometry{Inner: square}, valid: true, simple: true},
+		{name: "unclosed ring", g: unclosed, valid: false, simple: true},
+		{name: "ring with too few points", g: triangle, valid: false, simple: false},
+		{name: "self intersecting ring", g: bowtieRing, valid: false, simple: false},
+		{name: "bowtie linestring", g: bowtie, valid: true, simple: false},
+		{name: "zigzag linestring", g: zigzag, valid: true, simple: true},
+		{name: "backtracking linestring", g: backtrack, valid: true, simple: false},
+		{name: "linestring touching itself", g: touching, valid: true, simple: false},
+		{name: "degenerate linestring", g: sql.Linestring{Points: []sql.Point{{X: 1, Y: 1}, {X: 1, Y: 1}}}, valid: false, simple: true},
+		{name: "linestring with an invalid point after distinct points", g: sql.Linestring{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: math.Inf(1), Y: 2}}}, valid: false, simple: true},
+		{name: "null", g: nil, valid: nil, simple: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			v, err := NewIsValid(expression.NewLiteral(tt.g, sql.GeometryType{})).Eval(sql.NewEmptyContext(), nil)
+			require.NoError(err)
+			require.Equal(tt.valid, v)
+
+			v, err = NewIsSimple(expression.NewLiteral(tt.g, sql.GeometryType{})).Eval(sql.NewEmptyContext(), nil)
+			require.NoError(err)
+			require.Equal(tt.simple, v)
+		})
+	}
+
+	t.Run("wrong type", func(t *testing.T) {
+		require := require.New(t)
+		_, err := NewIsValid(expression.NewLiteral(123, sql.Int64)).Eval(sql.NewEmptyContext(), nil)
+		require.Error(err)
+		_, err = NewIsSimple(expression.NewLiteral(123, sql.Int64)).Eval(sql.NewEmptyContext(), nil)
+		require.Error(err)
+	})
+}
@@ -17,7 +17,6 @@ package function
 import (
 	"encoding/binary"
 	"fmt"
-	"math"
 	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -53,7 +52,7 @@ func (a *AsWKB) IsNullable() bool {
 
 // Type implements the sql.Expression interface.
 func (a *AsWKB) Type() sql.Type {
-	return a.Child.Type()
+	return sql.LongBlob
 }
 
 func (a *AsWKB) String() string {
@@ -68,63 +67,6 @@ func (a *AsWKB) WithChildren(children ...sql.Expression) (sql.Expression, error)
 	return NewAsWKB(children[0]), nil
 }
 
-// serializePoint fills in buf with the values from point
-func serializePoint(p sql.Point, buf []byte) {
-	// Assumes buf is correct size
-	binary.LittleEndian.PutUint64(buf[0:8], math.Float64bits(p.X))
-	binary.LittleEndian.PutUint64(buf[8:16], math.Float64bits(p.Y))
-}
-
-// PointToBytes converts a sql.Point to a byte array
-func PointToBytes(p sql.Point) []byte {
-	// Initialize point buffer
-	buf := make([]byte, 16)
-	serializePoint(p, buf)
-	return buf
-}
-
-// serializeLine fills in buf with values from linestring
-func serializeLine(l sql.Linestring, buf []byte) {
-	// Write number of points
-	binary.LittleEndian.PutUint32(buf[0:4], uint32(len(l.Points)))
-	// Append each point
-	for i, p := range l.Points {
-		start, stop := 4+16*i, 4+16*(i+1)
-		serializePoint(p, buf[start:stop])
-	}
-}
-
-// LineToBytes converts a sql.Linestring to a byte array
-func LineToBytes(l sql.Linestring) []byte {
-	// Initialize line buffer
-	buf := make([]byte, 4+16*len(l.Points))
-	serializeLine(l, buf)
-	return buf
-}
-
-func serializePoly(p sql.Polygon, buf []byte) {
-	// Write number of lines
-	binary.LittleEndian.PutUint32(buf[0:4], uint32(len(p.Lines)))
-	// Append each line
-	start, stop := 0, 4
-	for _, l := range p.Lines {
-		start, stop = stop, stop+4+16*len(l.Points)
-		serializeLine(l, buf[start:stop])
-	}
-}
-
-// PolyToBytes converts a sql.Polygon to a byte array
-func PolyToBytes(p sql.Polygon) []byte {
-	// Initialize polygon buffer
-	size := 0
-	for _, l := range p.Lines {
-		size += 4 + 16*len(l.Points)
-	}
-	buf := make([]byte, 4+size)
-	serializePoly(p, buf)
-	return buf
-}
-
 // Eval implements the sql.Expression interface.
 func (a *AsWKB) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	// Evaluate child
@@ -150,28 +92,28 @@ func (a *AsWKB) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		case sql.Point:
 			// Mark as point type
 			binary.LittleEndian.PutUint32(buf[1:5], 1)
-			data = PointToBytes(inner)
+			data = sql.PointToBytes(inner)
 		case sql.Linestring:
 			// Mark as linestring type
 			binary.LittleEndian.PutUint32(buf[1:5], 2)
-			data = LineToBytes(inner)
+			data = sql.LineToBytes(inner)
 		case sql.Polygon:
 			// Mark as Polygon type
 			binary.LittleEndian.PutUint32(buf[1:5], 3)
-			data = PolyToBytes(inner)
+			data = sql.PolyToBytes(inner)
 		}
 	case sql.Point:
 		// Mark as point type
 		binary.LittleEndian.PutUint32(buf[1:5], 1)
-		data = PointToBytes(v)
+		data = sql.PointToBytes(v)
 	case sql.Linestring:
 		// Mark as linestring type
 		binary.LittleEndian.PutUint32(buf[1:5], 2)
-		data = LineToBytes(v)
+		data = sql.LineToBytes(v)
 	case sql.Polygon:
 		// Mark as Polygon type
 		binary.LittleEndian.PutUint32(buf[1:5], 3)
-		data = PolyToBytes(v)
+		data = sql.PolyToBytes(v)
 	default:
 		return nil, sql.ErrInvalidGISData.New("ST_AsWKB")
 	}
@@ -182,17 +124,6 @@ func (a *AsWKB) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	return buf, nil
 }
 
-// Header contains endianness (1 byte) and geometry type (4 bytes)
-const WKBHeaderLength = 5
-
-// Type IDs
-const (
-	WKBUnknown = iota
-	WKBPointID
-	WKBLineID
-	WKBPolyID
-)
-
 // GeomFromWKB is a function that returns a geometry type from a WKB byte array
 type GeomFromWKB struct {
 	expression.NaryExpression
@@ -236,125 +167,6 @@ func (g *GeomFromWKB) WithChildren(children ...sql.Expression) (sql.Expression,
 	return NewGeomFromWKB(children...)
 }
 
-// ParseWKBHeader parses the header portion of a byte array in WKB format to extract endianness and type
-func ParseWKBHeader(buf []byte) (bool, uint32, error) {
-	// Header length
-	if len(buf) < WKBHeaderLength {
-		return false, 0, sql.ErrInvalidGISData.New("ST_GeomFromWKB3")
-	}
-
-	// Get Endianness
-	isBig := buf[0] == 0
-
-	// Get Geometry Type
-	var geomType uint32
-	if isBig {
-		geomType = binary.BigEndian.Uint32(buf[1:5])
-	} else {
-		geomType = binary.LittleEndian.Uint32(buf[1:5])
-	}
-
-	return isBig, geomType, nil
-}
-
-// WKBToPoint parses the data portion of a byte array in WKB format to a point object
-func WKBToPoint(buf []byte, isBig bool, srid uint32, order bool) (sql.Point, error) {
-	// Must be 16 bytes (2 floats)
-	if len(buf) != 16 {
-		return sql.Point{}, sql.ErrInvalidGISData.New("ST_PointFromWKB1")
-	}
-
-	// Read floats x and y
-	var x, y float64
-	if isBig {
-		x = math.Float64frombits(binary.BigEndian.Uint64(buf[:8]))
-		y = math.Float64frombits(binary.BigEndian.Uint64(buf[8:]))
-	} else {
-		x = math.Float64frombits(binary.LittleEndian.Uint64(buf[:8]))
-		y = math.Float64frombits(binary.LittleEndian.Uint64(buf[8:]))
-	}
-
-	// Determine if bool needs to be flipped
-	if order {
-		x, y = y, x
-	}
-
-	return sql.Point{SRID: srid, X: x, Y: y}, nil
-}
-
-// WKBToLine parses the data portion of a byte array in WKB format to a point object
-func WKBToLine(buf []byte, isBig bool, srid uint32, order bool) (sql.Linestring, error) {
-	// Must be at least 4 bytes (length of linestring)
-	if len(buf) < 4 {
-		return sql.Linestring{}, sql.ErrInvalidGISData.New("ST_LineFromWKB")
-	}
-
-	// Read length of line string
-	var numPoints uint32
-	if isBig {
-		numPoints = binary.BigEndian.Uint32(buf[:4])
-	} else {
-		numPoints = binary.LittleEndian.Uint32(buf[:4])
-	}
-
-	// Extract line data
-	lineData := buf[4:]
-
-	// Check length
-	if uint32(len(lineData)) < 16*numPoints {
-		return sql.Linestring{}, sql.ErrInvalidGISData.New("ST_LineFromWKB")
-	}
-
-	// Parse points
-	points := make([]sql.Point, numPoints)
-	for i := uint32(0); i < numPoints; i++ {
-		if point, err := WKBToPoint(lineData[16*i:16*(i+1)], isBig, srid, order); err == nil {
-			points[i] = point
-		} else {
-			return sql.Linestring{}, sql.ErrInvalidGISData.New("ST_LineFromWKB")
-		}
-	}
-
-	return sql.Linestring{SRID: srid, Points: points}, nil
-}
-
-// WKBToPoly parses the data portion of a byte array in WKB format to a point object
-func WKBToPoly(buf []byte, isBig bool, srid uint32, order bool) (sql.Polygon, error) {
-	// Must be at least 4 bytes (length of polygon)
-	if len(buf) < 4 {
-		return sql.Polygon{}, sql.ErrInvalidGISData.New("ST_PolyFromWKB1")
-	}
-
-	// Get number of lines in polygon
-	var numLines uint32
-	if isBig {
-		numLines = binary.BigEndian.Uint32(buf[:4])
-	} else {
-		numLines = binary.LittleEndian.Uint32(buf[:4])
-	}
-
-	// Extract poly data
-	polyData := buf[4:]
-
-	// Parse lines
-	s := 0
-	lines := make([]sql.Linestring, numLines)
-	for i := uint32(0); i < numLines; i++ {
-		if line, err := WKBToLine(polyData[s:], isBig, srid, order); err == nil {
-			if isLinearRing(line) {
-				lines[i] = line
-				s += 4 + 16*len(line.Points) // shift parsing location over
-			} else {
-				return sql.Polygon{}, sql.ErrInvalidGISData.New("ST_PolyFromWKB2")
-			}
-		} else {
-			return sql.Polygon{}, sql.ErrInvalidGISData.New("ST_PolyFromWKB3")
-		}
-	}
-
-	return sql.Polygon{SRID: srid, Lines: lines}, nil
-}
-
 // ParseAxisOrder takes in a key, value string and determines the order of the xy coords
 func ParseAxisOrder(s string) (bool, error) {
 	// TODO: need to deal with whitespace, lowercase, and json-like parsing
@@ -389,7 +201,7 @@ func (g *GeomFromWKB) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	}
 
 	// Parse Header
-	isBig, geomType, err := ParseWKBHeader(v)
+	isBig, geomType, err := sql.ParseWKBHeader(v)
 	if err != nil {
 		return nil, err
 	}
@@ -436,12 +248,12 @@ func (g *GeomFromWKB) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 
 	// Parse accordingly
 	switch geomType {
-	case WKBPointID:
-		return WKBToPoint(v[WKBHeaderLength:], isBig, srid, order)
-	case WKBLineID:
-		return WKBToLine(v[WKBHeaderLength:], isBig, srid, order)
-	case WKBPolyID:
-		return WKBToPoly(v[WKBHeaderLength:], isBig, srid, order)
+	case sql.WKBPointID:
+		return sql.WKBToPoint(v[sql.WKBHeaderLength:], isBig, srid, order)
+	case sql.WKBLineID:
+		return sql.WKBToLine(v[sql.WKBHeaderLength:], isBig, srid, order)
+	case sql.WKBPolyID:
+		return sql.WKBToPoly(v[sql.WKBHeaderLength:], isBig, srid, order)
 	default:
 		return nil, sql.ErrInvalidGISData.New("ST_GeomFromWKB")
 	}
@@ -509,13 +321,13 @@ func (p *PointFromWKB) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 	}
 
 	// Parse Header
-	isBig, geomType, err := ParseWKBHeader(v)
+	isBig, geomType, err := sql.ParseWKBHeader(v)
 	if err != nil {
 		return nil, sql.ErrInvalidGISData.New("ST_PointFromWKB")
 	}
 
 	// Not a point, throw error
-	if geomType != WKBPointID {
+	if geomType != sql.WKBPointID {
 		return nil, sql.ErrInvalidGISData.New("ST_PointFromWKB")
 	}
 
@@ -559,7 +371,7 @@ func (p *PointFromWKB) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 	}
 
 	// Read data
-	return WKBToPoint(v[WKBHeaderLength:], isBig, srid, order)
+	return sql.WKBToPoint(v[sql.WKBHeaderLength:], isBig, srid, order)
 }
 
 // LineFromWKB is a function that returns a linestring type from a WKB byte array
@@ -624,13 +436,13 @@ func (l *LineFromWKB) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	}
 
 	// Parse Header
-	isBig, geomType, err := ParseWKBHeader(v)
+	isBig, geomType, err := sql.ParseWKBHeader(v)
 	if err != nil {
 		return nil, sql.ErrInvalidGISData.New("ST_LineFromWKB")
 	}
 
 	// Not a line, throw error
-	if geomType != WKBLineID {
+	if geomType != sql.WKBLineID {
 		return nil, sql.ErrInvalidGISData.New("ST_LineFromWKB")
 	}
 
@@ -674,7 +486,7 @@ func (l *LineFromWKB) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	}
 
 	// Read data
-	return WKBToLine(v[WKBHeaderLength:], isBig, srid, order)
+	return sql.WKBToLine(v[sql.WKBHeaderLength:], isBig, srid, order)
 }
 
 // PolyFromWKB is a function that returns a polygon type from a WKB byte array
@@ -739,13 +551,13 @@ func (p *PolyFromWKB) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	}
 
 	// Parse Header
-	isBig, geomType, err := ParseWKBHeader(v)
+	isBig, geomType, err := sql.ParseWKBHeader(v)
 	if err != nil {
 		return nil, sql.ErrInvalidGISData.New("ST_PolyFromWKB")
 	}
 
 	// Not a polygon, throw error
-	if geomType != WKBPolyID {
+	if geomType != sql.WKBPolyID {
 		return nil, sql.ErrInvalidGISData.New("ST_PolyFromWKB")
 	}
 
@@ -789,5 +601,5 @@ func (p *PolyFromWKB) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	}
 
 	// Read data
-	return WKBToPoly(v[WKBHeaderLength:], isBig, srid, order)
+	return sql.WKBToPoly(v[sql.WKBHeaderLength:], isBig, srid, order)
 }
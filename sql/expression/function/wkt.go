@@ -16,6 +16,7 @@ package function
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 
@@ -25,53 +26,91 @@ import (
 
 // AsWKT is a function that converts a spatial type into WKT format (alias for AsText)
 type AsWKT struct {
-	expression.UnaryExpression
+	expression.NaryExpression
 }
 
 var _ sql.FunctionExpression = (*AsWKT)(nil)
 
-// NewAsWKT creates a new point expression.
-func NewAsWKT(e sql.Expression) sql.Expression {
-	return &AsWKT{expression.UnaryExpression{Child: e}}
+// NewAsWKT creates a new AsWKT expression. The optional arguments are an axis-order option and the maximum number of
+// decimal digits of the coordinates.
+func NewAsWKT(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 1 || len(args) > 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New("ST_ASWKT", "1, 2, or 3", len(args))
+	}
+	return &AsWKT{expression.NaryExpression{ChildExpressions: args}}, nil
 }
 
 // FunctionName implements sql.FunctionExpression
 func (p *AsWKT) FunctionName() string {
-	return "st_aswkb"
+	return "st_aswkt"
 }
 
 // Description implements sql.FunctionExpression
 func (p *AsWKT) Description() string {
-	return "returns binary representation of given spatial type."
-}
-
-// IsNullable implements the sql.Expression interface.
-func (p *AsWKT) IsNullable() bool {
-	return p.Child.IsNullable()
+	return "returns the WKT representation of given spatial type."
 }
 
 // Type implements the sql.Expression interface.
 func (p *AsWKT) Type() sql.Type {
-	return p.Child.Type()
+	return sql.LongText
 }
 
 func (p *AsWKT) String() string {
-	return fmt.Sprintf("ST_ASWKT(%s)", p.Child.String())
+	var args = make([]string, len(p.ChildExpressions))
+	for i, arg := range p.ChildExpressions {
+		args[i] = arg.String()
+	}
+	return fmt.Sprintf("ST_ASWKT(%s)", strings.Join(args, ","))
 }
 
 // WithChildren implements the Expression interface.
 func (p *AsWKT) WithChildren(children ...sql.Expression) (sql.Expression, error) {
-	if len(children) != 1 {
-		return nil, sql.ErrInvalidChildrenNumber.New(p, len(children), 1)
-	}
-	return NewAsWKT(children[0]), nil
+	return NewAsWKT(children...)
 }
 
 // PointToWKT converts a sql.Point to a string
 func PointToWKT(p sql.Point) string {
 	x := strconv.FormatFloat(p.X, 'g', -1, 64)
 	y := strconv.FormatFloat(p.Y, 'g', -1, 64)
-	return fmt.Sprintf("%s %s", x, y)
+	wkt := fmt.Sprintf("%s %s", x, y)
+	if p.Z != nil {
+		wkt += " " + strconv.FormatFloat(*p.Z, 'g', -1, 64)
+	}
+	if p.M != nil {
+		wkt += " " + strconv.FormatFloat(*p.M, 'g', -1, 64)
+	}
+	return wkt
+}
+
+// firstPoint returns the first point of a geometry, which determines the dimension written in its WKT header.
+func firstPoint(v interface{}) (sql.Point, bool) {
+	switch v := v.(type) {
+	case sql.Geometry:
+		return firstPoint(v.Inner)
+	case sql.Point:
+		return v, true
+	case sql.Linestring:
+		if len(v.Points) > 0 {
+			return v.Points[0], true
+		}
+	case sql.Polygon:
+		if len(v.Lines) > 0 {
+			return firstPoint(v.Lines[0])
+		}
+	case sql.MultiPoint:
+		if len(v.Points) > 0 {
+			return v.Points[0], true
+		}
+	case sql.MultiLinestring:
+		if len(v.Lines) > 0 {
+			return firstPoint(v.Lines[0])
+		}
+	case sql.MultiPolygon:
+		if len(v.Polygons) > 0 {
+			return firstPoint(v.Polygons[0])
+		}
+	}
+	return sql.Point{}, false
 }
 
 // LineToWKT converts a sql.Linestring to a string
@@ -92,37 +131,42 @@ func PolygonToWKT(p sql.Polygon) string {
 	return strings.Join(lines, ",")
 }
 
-// Eval implements the sql.Expression interface.
-func (p *AsWKT) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
-	// Evaluate child
-	val, err := p.Child.Eval(ctx, row)
-	if err != nil {
-		return nil, err
+// MultiPointToWKT converts a sql.MultiPoint to a string
+func MultiPointToWKT(m sql.MultiPoint) string {
+	points := make([]string, len(m.Points))
+	for i, p := range m.Points {
+		points[i] = "(" + PointToWKT(p) + ")"
+	}
+	return strings.Join(points, ",")
+}
+
+// MultiLineToWKT converts a sql.MultiLinestring to a string
+func MultiLineToWKT(m sql.MultiLinestring) string {
+	lines := make([]string, len(m.Lines))
+	for i, l := range m.Lines {
+		lines[i] = "(" + LineToWKT(l) + ")"
 	}
+	return strings.Join(lines, ",")
+}
 
-	if val == nil {
-		return nil, nil
+// MultiPolygonToWKT converts a sql.MultiPolygon to a string
+func MultiPolygonToWKT(m sql.MultiPolygon) string {
+	polys := make([]string, len(m.Polygons))
+	for i, p := range m.Polygons {
+		polys[i] = "(" + PolygonToWKT(p) + ")"
 	}
+	return strings.Join(polys, ",")
+}
 
+// GeometryToWKT converts any geometry to a string, including its type, such that geometry collections can be written
+// out with each of their members
+func GeometryToWKT(v interface{}) (string, error) {
 	var geomType string
 	var data string
 	// Expect one of the geometry types
-	switch v := val.(type) {
+	switch v := v.(type) {
 	case sql.Geometry:
-		switch inner := v.Inner.(type) {
-		case sql.Point:
-			// Mark as point type
-			geomType = "POINT"
-			data = PointToWKT(inner)
-		case sql.Linestring:
-			// Mark as linestring type
-			geomType = "LINESTRING"
-			data = LineToWKT(inner)
-		case sql.Polygon:
-			// Mark as Polygon type
-			geomType = "POLYGON"
-			data = PolygonToWKT(inner)
-		}
+		return GeometryToWKT(v.Inner)
 	case sql.Point:
 		// Mark as point type
 		geomType = "POINT"
@@ -135,13 +179,118 @@ func (p *AsWKT) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		// Mark as Polygon type
 		geomType = "POLYGON"
 		data = PolygonToWKT(v)
+	case sql.MultiPoint:
+		geomType = "MULTIPOINT"
+		data = MultiPointToWKT(v)
+	case sql.MultiLinestring:
+		geomType = "MULTILINESTRING"
+		data = MultiLineToWKT(v)
+	case sql.MultiPolygon:
+		geomType = "MULTIPOLYGON"
+		data = MultiPolygonToWKT(v)
+	case sql.GeometryCollection:
+		geomType = "GEOMETRYCOLLECTION"
+		geoms := make([]string, len(v.Geoms))
+		for i, g := range v.Geoms {
+			wkt, err := GeometryToWKT(g)
+			if err != nil {
+				return "", err
+			}
+			geoms[i] = wkt
+		}
+		data = strings.Join(geoms, ",")
 	default:
-		return nil, sql.ErrInvalidGISData.New("ST_AsWKT")
+		return "", sql.ErrInvalidGISData.New("ST_AsWKT")
+	}
+
+	// Geometries with Z or M ordinates name them after the type, like "POINT Z (1 2 3)"
+	if p, ok := firstPoint(v); ok {
+		if dim := sql.WKTDimension(p); dim != "" {
+			return fmt.Sprintf("%s %s (%s)", geomType, strings.ToUpper(dim), data), nil
+		}
 	}
 
 	return fmt.Sprintf("%s(%s)", geomType, data), nil
 }
 
+// Eval implements the sql.Expression interface.
+func (p *AsWKT) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	// Evaluate child
+	val, err := p.ChildExpressions[0].Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if val == nil {
+		return nil, nil
+	}
+
+	srid, err := geometrySRID(val)
+	if err != nil {
+		return nil, sql.ErrInvalidGISData.New("ST_AsWKT")
+	}
+
+	// Determine xy order, which only applies to geographic spatial reference systems
+	if len(p.ChildExpressions) >= 2 {
+		o, err := p.ChildExpressions[1].Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if o == nil {
+			return nil, nil
+		}
+		s, ok := o.(string)
+		if !ok {
+			return nil, sql.ErrInvalidArgument.New(p.FunctionName())
+		}
+		order, err := ParseAxisOrder(s)
+		if err != nil {
+			return nil, sql.ErrInvalidArgument.New(p.FunctionName())
+		}
+		if order && srid == GeoSpatialSRID {
+			val = SwapGeometryXY(val)
+		}
+	}
+
+	// Round the coordinates to the maximum number of decimal digits
+	if len(p.ChildExpressions) == 3 {
+		d, err := p.ChildExpressions[2].Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if d == nil {
+			return nil, nil
+		}
+		d, err = sql.Int64.Convert(d)
+		if err != nil {
+			return nil, err
+		}
+		digits := d.(int64)
+		if digits < 0 {
+			return nil, sql.ErrInvalidArgumentDetails.New(p.FunctionName(), "the maximum number of decimal digits must not be negative")
+		}
+		pow := math.Pow10(int(digits))
+		val, err = TransformGeometry(val, srid, func(x, y float64) (float64, float64, error) {
+			return math.Round(x*pow) / pow, math.Round(y*pow) / pow, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	wkt, err := GeometryToWKT(val)
+	if err != nil {
+		return nil, err
+	}
+	return wkt, nil
+}
+
+// ParseWKT parses a WKT string of any supported geometry type, and returns the concrete geometry with the cartesian
+// SRID and the default axis order. The parser is in the sql package, where the spatial types use it to convert strings.
+func ParseWKT(s string) (interface{}, error) {
+	return sql.ParseWKT(s)
+}
+
 // GeomFromText is a function that returns a point type from a WKT string
 type GeomFromText struct {
 	expression.NaryExpression
@@ -185,161 +334,6 @@ func (g *GeomFromText) WithChildren(children ...sql.Expression) (sql.Expression,
 	return NewGeomFromWKT(children...)
 }
 
-// ParseWKTHeader should extract the type from the geometry string
-func ParseWKTHeader(s string) (string, string, error) {
-	// Read until first open parenthesis
-	end := strings.Index(s, "(")
-
-	// Bad if no parenthesis found
-	if end == -1 {
-		return "", "", sql.ErrInvalidGISData.New("ST_GeomFromText")
-	}
-
-	// Get Geometry Type
-	geomType := s[:end]
-	geomType = strings.TrimSpace(geomType)
-	geomType = strings.ToLower(geomType)
-
-	// Get data
-	data := s[end:]
-	data = strings.TrimSpace(data)
-
-	// Check that data is surrounded by parentheses
-	if data[0] != '(' || data[len(data)-1] != ')' {
-		return "", "", sql.ErrInvalidGISData.New("ST_GeomFromText")
-	}
-	// Remove parentheses, and trim
-	data = data[1 : len(data)-1]
-	data = strings.TrimSpace(data)
-
-	return geomType, data, nil
-}
-
-// WKTToPoint expects a string like this "1.2 3.4"
-func WKTToPoint(s string, srid uint32, order bool) (sql.Point, error) {
-	// Empty string is wrong
-	if len(s) == 0 {
-		return sql.Point{}, sql.ErrInvalidGISData.New("ST_PointFromText")
-	}
-
-	// Get everything between spaces
-	args := strings.Fields(s)
-
-	// Check length
-	if len(args) != 2 {
-		return sql.Point{}, sql.ErrInvalidGISData.New("ST_PointFromText")
-	}
-
-	// Parse x
-	x, err := strconv.ParseFloat(args[0], 64)
-	if err != nil {
-		return sql.Point{}, sql.ErrInvalidGISData.New("ST_PointFromText")
-	}
-
-	// Parse y
-	y, err := strconv.ParseFloat(args[1], 64)
-	if err != nil {
-		return sql.Point{}, sql.ErrInvalidGISData.New("ST_PointFromText")
-	}
-
-	// See if we need to swap x and y
-	if order {
-		x, y = y, x
-	}
-
-	// Create point object
-	return sql.Point{SRID: srid, X: x, Y: y}, nil
-}
-
-// WKTToLine expects a string like "1.2 3.4, 5.6 7.8, ..."
-func WKTToLine(s string, srid uint32, order bool) (sql.Linestring, error) {
-	// Empty string is wrong
-	if len(s) == 0 {
-		return sql.Linestring{}, sql.ErrInvalidGISData.New("ST_LineFromText")
-	}
-
-	// Separate by comma
-	pointStrs := strings.Split(s, ",")
-
-	// Parse each point string
-	var points = make([]sql.Point, len(pointStrs))
-	for i, ps := range pointStrs {
-		// Remove leading and trailing whitespace
-		ps = strings.TrimSpace(ps)
-
-		// Parse point
-		if p, err := WKTToPoint(ps, srid, order); err == nil {
-			points[i] = p
-		} else {
-			return sql.Linestring{}, sql.ErrInvalidGISData.New("ST_LineFromText")
-		}
-	}
-
-	// Create Linestring object
-	return sql.Linestring{SRID: srid, Points: points}, nil
-}
-
-// WKTToPoly Expects a string like "(1 2, 3 4), (5 6, 7 8), ..."
-func WKTToPoly(s string, srid uint32, order bool) (sql.Polygon, error) {
-	var lines []sql.Linestring
-	for {
-		// Look for closing parentheses
-		end := strings.Index(s, ")")
-		if end == -1 {
-			return sql.Polygon{}, sql.ErrInvalidGISData.New("ST_PolyFromText")
-		}
-
-		// Extract linestring string; does not include ")"
-		lineStr := s[:end]
-
-		// Must start with open parenthesis
-		if len(lineStr) == 0 || lineStr[0] != '(' {
-			return sql.Polygon{}, sql.ErrInvalidGISData.New("ST_PolyFromText")
-		}
-
-		// Remove leading "("
-		lineStr = lineStr[1:]
-
-		// Remove leading and trailing whitespace
-		lineStr = strings.TrimSpace(lineStr)
-
-		// Parse line
-		if line, err := WKTToLine(lineStr, srid, order); err == nil {
-			// Check if line is linearring
-			if isLinearRing(line) {
-				lines = append(lines, line)
-			} else {
-				return sql.Polygon{}, sql.ErrInvalidGISData.New("ST_PolyFromText")
-			}
-		} else {
-			return sql.Polygon{}, sql.ErrInvalidGISData.New("ST_PolyFromText")
-		}
-
-		// Prepare next string
-		s = s[end+1:]
-		s = strings.TrimSpace(s)
-
-		// Reached end
-		if len(s) == 0 {
-			break
-		}
-
-		// Linestrings must be comma-separated
-		if s[0] != ',' {
-			return sql.Polygon{}, sql.ErrInvalidGISData.New("ST_PolyFromText")
-		}
-
-		// Drop leading comma
-		s = s[1:]
-
-		// Trim leading spaces
-		s = strings.TrimSpace(s)
-	}
-
-	// Create Polygon object
-	return sql.Polygon{SRID: srid, Lines: lines}, nil
-}
-
 // Eval implements the sql.Expression interface.
 func (g *GeomFromText) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	// Evaluate child
@@ -359,7 +353,7 @@ func (g *GeomFromText) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 	}
 
 	// Determine type, and get data
-	geomType, data, err := ParseWKTHeader(s)
+	geomType, dim, data, err := sql.ParseWKTHeader(s)
 	if err != nil {
 		return nil, err
 	}
@@ -403,17 +397,7 @@ func (g *GeomFromText) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 	}
 
 	// Parse accordingly
-	// TODO: define consts instead of string comparison?
-	switch geomType {
-	case "point":
-		return WKTToPoint(data, srid, order)
-	case "linestring":
-		return WKTToLine(data, srid, order)
-	case "polygon":
-		return WKTToPoly(data, srid, order)
-	default:
-		return nil, sql.ErrInvalidGISData.New("ST_GeomFromText")
-	}
+	return sql.WKTToGeometry(geomType, dim, data, srid, order, "ST_GeomFromText")
 }
 
 // PointFromWKT is a function that returns a point type from a WKT string
@@ -478,14 +462,14 @@ func (p *PointFromWKT) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 	}
 
 	// Parse Header
-	geomType, data, err := ParseWKTHeader(s)
+	geomType, dim, data, err := sql.ParseWKTHeader(s)
 	if err != nil {
 		return nil, sql.ErrInvalidGISData.New("ST_PointFromText")
 	}
 
 	// Not a point, throw error
-	if geomType == "point" {
-		return nil, sql.ErrInvalidGISData.New("ST_PointFromText")
+	if geomType != "point" {
+		return nil, sql.ErrUnexpectedGeometryType.New(geomType, "ST_PointFromText")
 	}
 
 	// Determine SRID
@@ -526,7 +510,7 @@ func (p *PointFromWKT) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 		}
 	}
 
-	return WKTToPoint(data, srid, order)
+	return sql.WKTToPoint(data, srid, order, dim, "ST_PointFromText")
 }
 
 // LineFromWKT is a function that returns a point type from a WKT string
@@ -591,14 +575,14 @@ func (l *LineFromWKT) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	}
 
 	// Parse Header
-	geomType, data, err := ParseWKTHeader(s)
+	geomType, dim, data, err := sql.ParseWKTHeader(s)
 	if err != nil {
 		return nil, sql.ErrInvalidGISData.New("ST_LineFromText")
 	}
 
 	// Not a line, throw error
 	if geomType != "linestring" {
-		return nil, sql.ErrInvalidGISData.New("ST_LineFromText")
+		return nil, sql.ErrUnexpectedGeometryType.New(geomType, "ST_LineFromText")
 	}
 
 	// Evaluate second argument
@@ -639,7 +623,7 @@ func (l *LineFromWKT) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		}
 	}
 
-	return WKTToLine(data, srid, order)
+	return sql.WKTToLine(data, srid, order, dim, "ST_LineFromText")
 }
 
 // PolyFromWKT is a function that returns a polygon type from a WKT string
@@ -700,18 +684,18 @@ func (p *PolyFromWKT) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	// Expect a string, throw error otherwise
 	s, ok := val.(string)
 	if !ok {
-		return nil, sql.ErrInvalidGISData.New("ST_PolyFromWKT")
+		return nil, sql.ErrInvalidGISData.New("ST_PolyFromText")
 	}
 
 	// Parse Header
-	geomType, data, err := ParseWKTHeader(s)
+	geomType, dim, data, err := sql.ParseWKTHeader(s)
 	if err != nil {
-		return nil, sql.ErrInvalidGISData.New("ST_PolyFromWKT")
+		return nil, sql.ErrInvalidGISData.New("ST_PolyFromText")
 	}
 
 	// Not a polygon, throw error
 	if geomType != "polygon" {
-		return nil, sql.ErrInvalidGISData.New("ST_PolyFromText")
+		return nil, sql.ErrUnexpectedGeometryType.New(geomType, "ST_PolyFromText")
 	}
 
 	// Determine SRID
@@ -752,5 +736,118 @@ func (p *PolyFromWKT) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		}
 	}
 
-	return WKTToPoly(data, srid, order)
+	return sql.WKTToPoly(data, srid, order, dim, "ST_PolyFromText")
+}
+
+// GeomCollFromWKT is a function that returns a geometry collection type from a WKT string
+type GeomCollFromWKT struct {
+	expression.NaryExpression
+}
+
+var _ sql.FunctionExpression = (*GeomCollFromWKT)(nil)
+
+// NewGeomCollFromWKT creates a new geometry collection expression.
+func NewGeomCollFromWKT(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 1 || len(args) > 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New("ST_GEOMCOLLFROMWKT", "1, 2, or 3", len(args))
+	}
+	return &GeomCollFromWKT{expression.NaryExpression{ChildExpressions: args}}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (g *GeomCollFromWKT) FunctionName() string {
+	return "st_geomcollfromwkt"
+}
+
+// Description implements sql.FunctionExpression
+func (g *GeomCollFromWKT) Description() string {
+	return "returns a new geometry collection from a WKT string."
+}
+
+// Type implements the sql.Expression interface.
+func (g *GeomCollFromWKT) Type() sql.Type {
+	return sql.GeometryCollectionType{}
+}
+
+func (g *GeomCollFromWKT) String() string {
+	var args = make([]string, len(g.ChildExpressions))
+	for i, arg := range g.ChildExpressions {
+		args[i] = arg.String()
+	}
+	return fmt.Sprintf("ST_GEOMCOLLFROMWKT(%s)", strings.Join(args, ","))
+}
+
+// WithChildren implements the Expression interface.
+func (g *GeomCollFromWKT) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewGeomCollFromWKT(children...)
+}
+
+// Eval implements the sql.Expression interface.
+func (g *GeomCollFromWKT) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	// Evaluate child
+	val, err := g.ChildExpressions[0].Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if val == nil {
+		return nil, nil
+	}
+
+	// Expect a string, throw error otherwise
+	s, ok := val.(string)
+	if !ok {
+		return nil, sql.ErrInvalidGISData.New("ST_GeomCollFromText")
+	}
+
+	// Parse Header
+	geomType, _, data, err := sql.ParseWKTHeader(s)
+	if err != nil {
+		return nil, sql.ErrInvalidGISData.New("ST_GeomCollFromText")
+	}
+
+	// Not a geometry collection, throw error
+	if geomType != "geometrycollection" {
+		return nil, sql.ErrInvalidGISData.New("ST_GeomCollFromText")
+	}
+
+	// Determine SRID
+	srid := uint32(0)
+	if len(g.ChildExpressions) >= 2 {
+		s, err := g.ChildExpressions[1].Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if s == nil {
+			return nil, nil
+		}
+		s, err = sql.Uint32.Convert(s)
+		if err != nil {
+			return nil, err
+		}
+		srid = s.(uint32)
+	}
+
+	// Must be valid SRID
+	if srid != 0 && srid != 4230 {
+		return nil, ErrInvalidSRID.New(srid)
+	}
+
+	// Determine xy order
+	order := false
+	if len(g.ChildExpressions) == 3 {
+		o, err := g.ChildExpressions[2].Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if o == nil {
+			return nil, nil
+		}
+		order, err = ParseAxisOrder(o.(string))
+		if err != nil {
+			return nil, sql.ErrInvalidArgument.New(g.FunctionName())
+		}
+	}
+
+	return sql.WKTToGeomColl(data, srid, order, "ST_GeomCollFromText")
 }
@@ -15,9 +15,11 @@
 package function
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
@@ -26,7 +28,8 @@ import (
 func TestAsWKT(t *testing.T) {
 	t.Run("convert point", func(t *testing.T) {
 		require := require.New(t)
-		f := NewAsWKT(expression.NewLiteral(sql.Point{X: 1, Y: 2}, sql.PointType{}))
+		f, err := NewAsWKT(expression.NewLiteral(sql.Point{X: 1, Y: 2}, sql.PointType{}))
+		require.NoError(err)
 		v, err := f.Eval(sql.NewEmptyContext(), nil)
 		require.NoError(err)
 		require.Equal("POINT(1 2)", v)
@@ -34,7 +37,8 @@ func TestAsWKT(t *testing.T) {
 
 	t.Run("convert point with negative floats", func(t *testing.T) {
 		require := require.New(t)
-		f := NewAsWKT(expression.NewLiteral(sql.Point{X: -123.45, Y: 678.9}, sql.PointType{}))
+		f, err := NewAsWKT(expression.NewLiteral(sql.Point{X: -123.45, Y: 678.9}, sql.PointType{}))
+		require.NoError(err)
 		v, err := f.Eval(sql.NewEmptyContext(), nil)
 		require.NoError(err)
 		require.Equal("POINT(-123.45 678.9)", v)
@@ -42,7 +46,8 @@ func TestAsWKT(t *testing.T) {
 
 	t.Run("convert linestring", func(t *testing.T) {
 		require := require.New(t)
-		f := NewAsWKT(expression.NewLiteral(sql.Linestring{Points: []sql.Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}, sql.LinestringType{}))
+		f, err := NewAsWKT(expression.NewLiteral(sql.Linestring{Points: []sql.Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}, sql.LinestringType{}))
+		require.NoError(err)
 		v, err := f.Eval(sql.NewEmptyContext(), nil)
 		require.NoError(err)
 		require.Equal("LINESTRING(1 2,3 4)", v)
@@ -50,28 +55,251 @@ func TestAsWKT(t *testing.T) {
 
 	t.Run("convert polygon", func(t *testing.T) {
 		require := require.New(t)
-		f := NewAsWKT(expression.NewLiteral(sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 1, Y: 0}, {X: 0, Y: 0}}}}}, sql.PolygonType{}))
+		f, err := NewAsWKT(expression.NewLiteral(sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 1, Y: 0}, {X: 0, Y: 0}}}}}, sql.PolygonType{}))
+		require.NoError(err)
 		v, err := f.Eval(sql.NewEmptyContext(), nil)
 		require.NoError(err)
 		require.Equal("POLYGON((0 0,1 1,1 0,0 0))", v)
 	})
 
+	t.Run("convert multipoint", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewAsWKT(expression.NewLiteral(sql.MultiPoint{Points: []sql.Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}, sql.MultiPointType{}))
+		require.NoError(err)
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal("MULTIPOINT((1 2),(3 4))", v)
+	})
+
+	t.Run("convert multilinestring", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewAsWKT(expression.NewLiteral(sql.MultiLinestring{Lines: []sql.Linestring{{Points: []sql.Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}, {Points: []sql.Point{{X: 5, Y: 6}, {X: 7, Y: 8}}}}}, sql.MultiLinestringType{}))
+		require.NoError(err)
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal("MULTILINESTRING((1 2,3 4),(5 6,7 8))", v)
+	})
+
+	t.Run("convert multipolygon", func(t *testing.T) {
+		require := require.New(t)
+		poly := sql.Polygon{Lines: []sql.Linestring{{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 1, Y: 0}, {X: 0, Y: 0}}}}}
+		f, err := NewAsWKT(expression.NewLiteral(sql.MultiPolygon{Polygons: []sql.Polygon{poly, poly}}, sql.MultiPolygonType{}))
+		require.NoError(err)
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal("MULTIPOLYGON(((0 0,1 1,1 0,0 0)),((0 0,1 1,1 0,0 0)))", v)
+	})
+
+	t.Run("convert geometry collection", func(t *testing.T) {
+		require := require.New(t)
+		gc := sql.GeometryCollection{Geoms: []interface{}{
+			sql.Point{X: 1, Y: 2},
+			sql.Linestring{Points: []sql.Point{{X: 3, Y: 4}, {X: 5, Y: 6}}},
+			sql.GeometryCollection{Geoms: []interface{}{sql.MultiPoint{Points: []sql.Point{{X: 7, Y: 8}}}}},
+		}}
+		f, err := NewAsWKT(expression.NewLiteral(sql.Geometry{Inner: gc}, sql.GeometryType{}))
+		require.NoError(err)
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal("GEOMETRYCOLLECTION(POINT(1 2),LINESTRING(3 4,5 6),GEOMETRYCOLLECTION(MULTIPOINT((7 8))))", v)
+	})
+
 	t.Run("convert null", func(t *testing.T) {
 		require := require.New(t)
-		f := NewAsWKT(expression.NewLiteral(nil, sql.Null))
+		f, err := NewAsWKT(expression.NewLiteral(nil, sql.Null))
+		require.NoError(err)
 		v, err := f.Eval(sql.NewEmptyContext(), nil)
 		require.NoError(err)
 		require.Equal(nil, v)
 	})
 
+	t.Run("convert with max decimal digits", func(t *testing.T) {
+		require := require.New(t)
+		line := sql.Linestring{Points: []sql.Point{{X: 1.23456, Y: -2.98765}, {X: 3, Y: 4.5}}}
+		tests := []struct {
+			digits   int
+			expected string
+		}{
+			{0, "LINESTRING(1 -3,3 5)"},
+			{2, "LINESTRING(1.23 -2.99,3 4.5)"},
+			{10, "LINESTRING(1.23456 -2.98765,3 4.5)"},
+		}
+		for _, tt := range tests {
+			f, err := NewAsWKT(
+				expression.NewLiteral(line, sql.LinestringType{}),
+				expression.NewLiteral("axis-order=srid-defined", sql.LongText),
+				expression.NewLiteral(tt.digits, sql.Int32),
+			)
+			require.NoError(err)
+			v, err := f.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		}
+
+		gc := sql.GeometryCollection{Geoms: []interface{}{sql.Point{X: 0.05, Y: 1.04}, sql.MultiPoint{Points: []sql.Point{{X: 2.96, Y: 3}}}}}
+		f, err := NewAsWKT(
+			expression.NewLiteral(gc, sql.GeometryCollectionType{}),
+			expression.NewLiteral("axis-order=srid-defined", sql.LongText),
+			expression.NewLiteral(1, sql.Int32),
+		)
+		require.NoError(err)
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal("GEOMETRYCOLLECTION(POINT(0.1 1),MULTIPOINT((3 3)))", v)
+	})
+
+	t.Run("convert with axis order", func(t *testing.T) {
+		require := require.New(t)
+		geographic := sql.Linestring{SRID: GeoSpatialSRID, Points: []sql.Point{{SRID: GeoSpatialSRID, X: 1, Y: 2}, {SRID: GeoSpatialSRID, X: 3, Y: 4}}}
+		cartesian := sql.Linestring{Points: []sql.Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}
+		tests := []struct {
+			geom     sql.Linestring
+			order    string
+			expected string
+		}{
+			{geographic, "axis-order=lat-long", "LINESTRING(1 2,3 4)"},
+			{geographic, "axis-order=srid-defined", "LINESTRING(1 2,3 4)"},
+			{geographic, "axis-order=long-lat", "LINESTRING(2 1,4 3)"},
+			{cartesian, "axis-order=long-lat", "LINESTRING(1 2,3 4)"},
+		}
+		for _, tt := range tests {
+			f, err := NewAsWKT(expression.NewLiteral(tt.geom, sql.LinestringType{}), expression.NewLiteral(tt.order, sql.LongText))
+			require.NoError(err)
+			v, err := f.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(err)
+			require.Equal(tt.expected, v, tt.order)
+		}
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		require := require.New(t)
+		p := expression.NewLiteral(sql.Point{X: 1, Y: 2}, sql.PointType{})
+
+		f, err := NewAsWKT(p, expression.NewLiteral("axis-order=up-down", sql.LongText))
+		require.NoError(err)
+		_, err = f.Eval(sql.NewEmptyContext(), nil)
+		require.True(sql.ErrInvalidArgument.Is(err))
+
+		f, err = NewAsWKT(p, expression.NewLiteral("axis-order=lat-long", sql.LongText), expression.NewLiteral(-1, sql.Int32))
+		require.NoError(err)
+		_, err = f.Eval(sql.NewEmptyContext(), nil)
+		require.True(sql.ErrInvalidArgumentDetails.Is(err))
+
+		f, err = NewAsWKT(p, expression.NewLiteral(nil, sql.Null))
+		require.NoError(err)
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Nil(v)
+
+		_, err = NewAsWKT(p, p, p, p)
+		require.True(sql.ErrInvalidArgumentNumber.Is(err))
+	})
+
 	t.Run("wrong type", func(t *testing.T) {
 		require := require.New(t)
-		f := NewAsWKT(expression.NewLiteral("notageometry", sql.Blob))
-		_, err := f.Eval(sql.NewEmptyContext(), nil)
+		f, err := NewAsWKT(expression.NewLiteral("notageometry", sql.Blob))
+		require.NoError(err)
+		_, err = f.Eval(sql.NewEmptyContext(), nil)
 		require.Error(err)
 	})
 }
 
+func TestPointFromText(t *testing.T) {
+	tests := []struct {
+		wkt      string
+		expected interface{}
+		err      *errors.Kind
+	}{
+		{wkt: "POINT(1 2)", expected: sql.Point{X: 1, Y: 2}},
+		{wkt: "point ( -1.5   2e3 )", expected: sql.Point{X: -1.5, Y: 2000}},
+		{wkt: "POINT(1 2 3)", expected: sql.Point{X: 1, Y: 2, Z: ordinate(3)}},
+		{wkt: "POINT(1 2 3 4)", expected: sql.Point{X: 1, Y: 2, Z: ordinate(3), M: ordinate(4)}},
+		{wkt: "POINT Z (1 2 3)", expected: sql.Point{X: 1, Y: 2, Z: ordinate(3)}},
+		{wkt: "POINT M (1 2 3)", expected: sql.Point{X: 1, Y: 2, M: ordinate(3)}},
+		{wkt: "POINT ZM (1 2 3 4)", expected: sql.Point{X: 1, Y: 2, Z: ordinate(3), M: ordinate(4)}},
+		{wkt: "POINTZ(1 2 3)", expected: sql.Point{X: 1, Y: 2, Z: ordinate(3)}},
+		{wkt: "POINTZM(1 2 3 4)", expected: sql.Point{X: 1, Y: 2, Z: ordinate(3), M: ordinate(4)}},
+		{wkt: "POINT Z (1 2)", err: sql.ErrInvalidGISData},
+		{wkt: "POINT M (1 2 3 4)", err: sql.ErrInvalidGISData},
+		{wkt: "POINT ZM (1 2 3)", err: sql.ErrInvalidGISData},
+		{wkt: "POINT()", err: sql.ErrInvalidGISData},
+		{wkt: "POINT(1)", err: sql.ErrInvalidGISData},
+		{wkt: "POINT(1 2 3 4 5)", err: sql.ErrInvalidGISData},
+		{wkt: "POINT(1 a 3)", err: sql.ErrInvalidGISData},
+		{wkt: "POINT(1, 2)", err: sql.ErrInvalidGISData},
+		{wkt: "POINT Q (1 2)", err: sql.ErrInvalidGISData},
+		{wkt: "POINT(1 2) 3", err: sql.ErrInvalidGISData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wkt, func(t *testing.T) {
+			require := require.New(t)
+			f, err := NewPointFromWKT(expression.NewLiteral(tt.wkt, sql.Blob))
+			require.NoError(err)
+
+			v, err := f.Eval(sql.NewEmptyContext(), nil)
+			if tt.err != nil {
+				require.True(tt.err.Is(err), "%v", err)
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+
+	t.Run("lines and polygons with z coordinates", func(t *testing.T) {
+		require := require.New(t)
+		for _, wkt := range []string{"LINESTRING(1 2 3, 4 5 6)", "LINESTRING Z (1 2 3, 4 5 6)"} {
+			f, err := NewGeomFromWKT(expression.NewLiteral(wkt, sql.Blob))
+			require.NoError(err)
+			v, err := f.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(err)
+			require.Equal(sql.Linestring{Points: []sql.Point{{X: 1, Y: 2, Z: ordinate(3)}, {X: 4, Y: 5, Z: ordinate(6)}}}, v, wkt)
+		}
+
+		f, err := NewGeomFromWKT(expression.NewLiteral("POLYGON Z ((0 0 0, 1 1 0, 1 0 0, 0 0 0))", sql.Blob))
+		require.NoError(err)
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(ordinate(0), v.(sql.Polygon).Lines[0].Points[1].Z)
+
+		for _, wkt := range []string{"LINESTRING(1 2 3, 4 5)", "LINESTRING Z (1 2, 4 5)", "POLYGON((0 0 0, 1 1 0, 1 0 0, 0 0 0), (0 0, 1 1, 1 0, 0 0))"} {
+			f, err := NewGeomFromWKT(expression.NewLiteral(wkt, sql.Blob))
+			require.NoError(err)
+			_, err = f.Eval(sql.NewEmptyContext(), nil)
+			require.True(sql.ErrInvalidGISData.Is(err), wkt)
+		}
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		require := require.New(t)
+		for _, wkt := range []string{
+			"POINT Z (1 2 3)",
+			"POINT M (1 2 3)",
+			"POINT ZM (1 2 3 4)",
+			"LINESTRING Z (1 2 3,4 5 6)",
+			"POLYGON Z ((0 0 1,1 1 1,1 0 1,0 0 1))",
+			"POINT(1 2)",
+		} {
+			f, err := NewGeomFromWKT(expression.NewLiteral(wkt, sql.Blob))
+			require.NoError(err)
+			v, err := f.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(err)
+
+			f, err = NewAsWKT(expression.NewLiteral(v, sql.GeometryType{}))
+			require.NoError(err)
+			v, err = f.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(err)
+			require.Equal(wkt, v)
+		}
+	})
+}
+
+// ordinate returns a pointer to [f], for the Z and M ordinates of points.
+func ordinate(f float64) *float64 {
+	return &f
+}
+
 func TestGeomFromText(t *testing.T) {
 	t.Run("create valid point with well formatted string", func(t *testing.T) {
 		require := require.New(t)
@@ -243,6 +471,41 @@ func TestGeomFromText(t *testing.T) {
 		require.Error(err)
 	})
 
+	t.Run("create polygon with generous whitespace", func(t *testing.T) {
+		require := require.New(t)
+		square := sql.Linestring{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 0}}}
+		for _, wkt := range []string{
+			"POLYGON ( ( 0 0 , 1 0 , 1 1 , 0 0 ) )",
+			"POLYGON(\t(0 0,1 0,1 1,0 0)\n)",
+			"  polygon((0 0,1 0,1 1,0 0) ,  ( 0 0,1 0 , 1 1,0 0 ) )  ",
+		} {
+			f, err := NewGeomFromWKT(expression.NewLiteral(wkt, sql.Blob))
+			require.NoError(err)
+
+			v, err := f.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(err, wkt)
+			require.Equal(square, v.(sql.Polygon).Lines[0], wkt)
+		}
+	})
+
+	t.Run("create polygon with empty ring", func(t *testing.T) {
+		require := require.New(t)
+		for _, wkt := range []string{
+			"POLYGON(())",
+			"POLYGON(( ))",
+			"POLYGON((0 0,1 0,1 1,0 0),())",
+			"POLYGON((0 0,1 0,1 1,0 0)())",
+			"POLYGON((0 0,1 0,1 1,0 0),)",
+			"POLYGON(((0 0,1 0,1 1,0 0)))",
+		} {
+			f, err := NewGeomFromWKT(expression.NewLiteral(wkt, sql.Blob))
+			require.NoError(err)
+
+			_, err = f.Eval(sql.NewEmptyContext(), nil)
+			require.True(sql.ErrInvalidGISData.Is(err), wkt)
+		}
+	})
+
 	t.Run("create polygon with bad string", func(t *testing.T) {
 		require := require.New(t)
 		f, err := NewGeomFromWKT(expression.NewLiteral("badlinestring(1 2)", sql.Blob))
@@ -321,3 +584,176 @@ func TestGeomFromText(t *testing.T) {
 		require.Equal(sql.Polygon{SRID: 4230, Lines: []sql.Linestring{{SRID: 4230, Points: []sql.Point{{SRID: 4230, X: 0, Y: 0}, {SRID: 4230, X: 1, Y: 0}, {SRID: 4230, X: 0, Y: 1}, {SRID: 4230, X: 0, Y: 0}}}}}, v)
 	})
 }
+
+func TestGeomFromTextMulti(t *testing.T) {
+	tests := []struct {
+		wkt      string
+		expected interface{}
+	}{
+		{"MULTIPOINT((0 0),(1 1))", sql.MultiPoint{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 1}}}},
+		{"MULTILINESTRING((0 0,1 1),(2 2,3 3))", sql.MultiLinestring{Lines: []sql.Linestring{
+			{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 1}}},
+			{Points: []sql.Point{{X: 2, Y: 2}, {X: 3, Y: 3}}},
+		}}},
+		{"MULTIPOLYGON(((0 0,1 0,1 1,0 0)))", sql.MultiPolygon{Polygons: []sql.Polygon{
+			{Lines: []sql.Linestring{{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 0}}}}},
+		}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wkt, func(t *testing.T) {
+			require := require.New(t)
+			f, err := NewGeomFromWKT(expression.NewLiteral(tt.wkt, sql.Blob))
+			require.NoError(err)
+
+			v, err := f.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+
+			v, err = ParseWKT(tt.wkt)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+
+			wkt, err := GeometryToWKT(v)
+			require.NoError(err)
+			require.Equal(strings.ReplaceAll(tt.wkt, " ", ""), strings.ReplaceAll(wkt, " ", ""))
+		})
+	}
+}
+
+func TestWKTErrorFunctionName(t *testing.T) {
+	tests := []struct {
+		name        string
+		constructor func(args ...sql.Expression) (sql.Expression, error)
+		wkt         string
+		fnName      string
+	}{
+		{"unclosed ring from geomfromtext", NewGeomFromWKT, "POLYGON((0 0,1 0,1 1,0 1))", "ST_GeomFromText"},
+		{"unclosed ring from polyfromtext", NewPolyFromWKT, "POLYGON((0 0,1 0,1 1,0 1))", "ST_PolyFromText"},
+		{"bad point from geomfromtext", NewGeomFromWKT, "POINT(1 a)", "ST_GeomFromText"},
+		{"bad point from pointfromtext", NewPointFromWKT, "POINT(1 a)", "ST_PointFromText"},
+		{"bad point in linestring from geomfromtext", NewGeomFromWKT, "LINESTRING(1 2,3)", "ST_GeomFromText"},
+		{"bad point in linestring from linefromtext", NewLineFromWKT, "LINESTRING(1 2,3)", "ST_LineFromText"},
+		{"unclosed ring in collection from geomfromtext", NewGeomFromWKT, "GEOMETRYCOLLECTION(POLYGON((0 0,1 0,1 1,0 1)))", "ST_GeomFromText"},
+		{"unclosed ring in collection from geomcollfromtext", NewGeomCollFromWKT, "GEOMETRYCOLLECTION(POLYGON((0 0,1 0,1 1,0 1)))", "ST_GeomCollFromText"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			f, err := tt.constructor(expression.NewLiteral(tt.wkt, sql.Blob))
+			require.NoError(err)
+
+			_, err = f.Eval(sql.NewEmptyContext(), nil)
+			require.True(sql.ErrInvalidGISData.Is(err))
+			require.Equal(sql.ErrInvalidGISData.New(tt.fnName).Error(), err.Error())
+		})
+	}
+}
+
+func TestFromTextErrorKinds(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(args ...sql.Expression) (sql.Expression, error)
+		wkt  string
+		err  *errors.Kind
+	}{
+		{"point from polygon", NewPointFromWKT, "POLYGON((0 0,1 1,1 0,0 0))", sql.ErrUnexpectedGeometryType},
+		{"point from linestring", NewPointFromWKT, "LINESTRING(1 2,3 4)", sql.ErrUnexpectedGeometryType},
+		{"point syntax error", NewPointFromWKT, "POINT(1 2", sql.ErrInvalidGISData},
+		{"point bad coordinates", NewPointFromWKT, "POINT(1)", sql.ErrInvalidGISData},
+		{"line from point", NewLineFromWKT, "POINT(1 2)", sql.ErrUnexpectedGeometryType},
+		{"line syntax error", NewLineFromWKT, "LINESTRING 1 2,3 4", sql.ErrInvalidGISData},
+		{"line bad coordinates", NewLineFromWKT, "LINESTRING(1 2,a b)", sql.ErrInvalidGISData},
+		{"polygon from geometry collection", NewPolyFromWKT, "GEOMETRYCOLLECTION(POINT(1 2))", sql.ErrUnexpectedGeometryType},
+		{"polygon syntax error", NewPolyFromWKT, "POLYGON((0 0,1 1,1 0,0 0)) x", sql.ErrInvalidGISData},
+		{"polygon bad ring", NewPolyFromWKT, "POLYGON((0 0,1 1,1 0))", sql.ErrInvalidGISData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := tt.fn(expression.NewLiteral(tt.wkt, sql.Blob))
+			require.NoError(t, err)
+			_, err = f.Eval(sql.NewEmptyContext(), nil)
+			require.Error(t, err)
+			require.True(t, tt.err.Is(err), "unexpected error %v", err)
+		})
+	}
+}
+
+func TestGeomCollFromText(t *testing.T) {
+	t.Run("create valid geometry collection", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewGeomCollFromWKT(expression.NewLiteral("GEOMETRYCOLLECTION(POINT(1 2), LINESTRING(3 4,5 6))", sql.Blob))
+		require.NoError(err)
+
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.GeometryCollection{Geoms: []interface{}{
+			sql.Point{X: 1, Y: 2},
+			sql.Linestring{Points: []sql.Point{{X: 3, Y: 4}, {X: 5, Y: 6}}},
+		}}, v)
+	})
+
+	t.Run("create valid nested geometry collection with srid", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewGeomCollFromWKT(expression.NewLiteral("GEOMETRYCOLLECTION(GEOMETRYCOLLECTION(POINT(1 2), GEOMETRYCOLLECTION()), POLYGON((0 0, 0 1, 1 0, 0 0)))", sql.Blob),
+			expression.NewLiteral(4230, sql.Uint32))
+		require.NoError(err)
+
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.GeometryCollection{SRID: 4230, Geoms: []interface{}{
+			sql.GeometryCollection{SRID: 4230, Geoms: []interface{}{
+				sql.Point{SRID: 4230, X: 1, Y: 2},
+				sql.GeometryCollection{SRID: 4230},
+			}},
+			sql.Polygon{SRID: 4230, Lines: []sql.Linestring{{SRID: 4230, Points: []sql.Point{{SRID: 4230, X: 0, Y: 0}, {SRID: 4230, X: 0, Y: 1}, {SRID: 4230, X: 1, Y: 0}, {SRID: 4230, X: 0, Y: 0}}}}},
+		}}, v)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		require := require.New(t)
+		for _, wkt := range []string{
+			"GEOMETRYCOLLECTION(POINT(1 2),LINESTRING(3 4,5 6))",
+			"GEOMETRYCOLLECTION(GEOMETRYCOLLECTION(POINT(1 2)),GEOMETRYCOLLECTION())",
+			"GEOMETRYCOLLECTION()",
+		} {
+			f, err := NewGeomFromWKT(expression.NewLiteral(wkt, sql.Blob))
+			require.NoError(err)
+			v, err := f.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(err)
+
+			f, err = NewAsWKT(expression.NewLiteral(v, sql.GeometryType{}))
+			require.NoError(err)
+			v, err = f.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(err)
+			require.Equal(wkt, v)
+		}
+	})
+
+	t.Run("invalid geometry collections", func(t *testing.T) {
+		for _, wkt := range []string{
+			"POINT(1 2)",
+			"GEOMETRYCOLLECTION(POINT(1 2),)",
+			"GEOMETRYCOLLECTION(1 2)",
+			"GEOMETRYCOLLECTION(POINT(1 2) LINESTRING(3 4,5 6))",
+			"GEOMETRYCOLLECTION(GEOMETRYCOLLECTION(POINT(1)))",
+		} {
+			f, err := NewGeomCollFromWKT(expression.NewLiteral(wkt, sql.Blob))
+			require.NoError(t, err)
+			_, err = f.Eval(sql.NewEmptyContext(), nil)
+			require.True(t, sql.ErrInvalidGISData.Is(err), wkt)
+		}
+	})
+
+	t.Run("null string returns null", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewGeomCollFromWKT(expression.NewLiteral(nil, sql.Null))
+		require.NoError(err)
+
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(nil, v)
+	})
+}
@@ -115,7 +115,7 @@ func (s *STX) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	}
 
 	// Create point with new X and old Y
-	return sql.Point{SRID: _p.SRID, X: _x.(float64), Y: _p.Y}, nil
+	return sql.Point{SRID: _p.SRID, X: _x.(float64), Y: _p.Y, Z: _p.Z, M: _p.M}, nil
 }
 
 // STY is a function that returns the y value from a given point.
@@ -207,10 +207,10 @@ func (s *STY) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	}
 
 	// Create point with old X and new Ys
-	return sql.Point{SRID: _p.SRID, X: _p.X, Y: _y.(float64)}, nil
+	return sql.Point{SRID: _p.SRID, X: _p.X, Y: _y.(float64), Z: _p.Z, M: _p.M}, nil
 }
 
-// Longitude is a function that returns the x value from a given point.
+// Longitude is a function that returns the longitude, which is the x value, of a given geographic point.
 type Longitude struct {
 	expression.NaryExpression
 }
@@ -274,6 +274,11 @@ func (l *Longitude) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, nil
 	}
 
+	// Points stored in geometry columns are wrapped
+	if g, ok := p.(sql.Geometry); ok {
+		p = g.Inner
+	}
+
 	// Check that it is a point
 	_p, ok := p.(sql.Point)
 	if !ok {
@@ -288,6 +293,9 @@ func (l *Longitude) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 
 	// If just one argument, return X
 	if len(l.ChildExpressions) == 1 {
+		if _p.X < -180.0 || _p.X > 180.0 {
+			return nil, ErrLongitudeOutOfRange.New(_p.X, l.FunctionName())
+		}
 		return _p.X, nil
 	}
 
@@ -315,10 +323,10 @@ func (l *Longitude) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	}
 
 	// Create point with new X and old Y
-	return sql.Point{SRID: _p.SRID, X: _x, Y: _p.Y}, nil
+	return sql.Point{SRID: _p.SRID, X: _x, Y: _p.Y, Z: _p.Z, M: _p.M}, nil
 }
 
-// Latitude is a function that returns the x value from a given point.
+// Latitude is a function that returns the latitude, which is the y value, of a given geographic point.
 type Latitude struct {
 	expression.NaryExpression
 }
@@ -378,6 +386,11 @@ func (l *Latitude) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, nil
 	}
 
+	// Points stored in geometry columns are wrapped
+	if g, ok := p.(sql.Geometry); ok {
+		p = g.Inner
+	}
+
 	// Check that it is a point
 	_p, ok := p.(sql.Point)
 	if !ok {
@@ -392,6 +405,9 @@ func (l *Latitude) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 
 	// If just one argument, return Y
 	if len(l.ChildExpressions) == 1 {
+		if _p.Y < -90.0 || _p.Y > 90.0 {
+			return nil, ErrLatitudeOutOfRange.New(_p.Y, l.FunctionName())
+		}
 		return _p.Y, nil
 	}
 
@@ -415,9 +431,9 @@ func (l *Latitude) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	// Check that value is within latitude range [-90, 90]
 	_y := y.(float64)
 	if _y < -90.0 || _y > 90.0 {
-		return nil, ErrLongitudeOutOfRange.New(_y, l.FunctionName())
+		return nil, ErrLatitudeOutOfRange.New(_y, l.FunctionName())
 	}
 
 	// Create point with old X and new Y
-	return sql.Point{SRID: _p.SRID, X: _p.X, Y: _y}, nil
+	return sql.Point{SRID: _p.SRID, X: _p.X, Y: _y, Z: _p.Z, M: _p.M}, nil
 }
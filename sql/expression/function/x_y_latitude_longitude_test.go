@@ -308,7 +308,35 @@ func TestLatitude(t *testing.T) {
 		require.NoError(err)
 
 		_, err = f.Eval(sql.NewEmptyContext(), nil)
-		require.Error(err)
+		require.True(ErrLatitudeOutOfRange.Is(err))
+	})
+
+	t.Run("select out of range latitude", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewLatitude(expression.NewLiteral(sql.Point{SRID: 4326, X: 0, Y: 91}, sql.PointType{}))
+		require.NoError(err)
+
+		_, err = f.Eval(sql.NewEmptyContext(), nil)
+		require.True(ErrLatitudeOutOfRange.Is(err))
+	})
+
+	t.Run("select latitude of geometry with inner point", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewLatitude(expression.NewLiteral(sql.Geometry{Inner: sql.Point{SRID: 4326, X: -0.1276, Y: 51.5072}}, sql.GeometryType{}))
+		require.NoError(err)
+
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(51.5072, v)
+	})
+
+	t.Run("cartesian point is not geographic", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewLatitude(expression.NewLiteral(sql.Point{X: 1, Y: 2}, sql.PointType{}))
+		require.NoError(err)
+
+		_, err = f.Eval(sql.NewEmptyContext(), nil)
+		require.True(ErrNonGeographic.Is(err))
 	})
 
 	t.Run("non-point provided", func(t *testing.T) {
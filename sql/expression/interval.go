@@ -16,7 +16,7 @@ package expression
 
 import (
 	"fmt"
-	"regexp"
+	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -66,68 +66,36 @@ func (i *Interval) EvalDelta(ctx *sql.Context, row sql.Row) (*TimeDelta, error)
 		return nil, nil
 	}
 
+	return NewTimeDelta(val, i.Unit)
+}
+
+// NewTimeDelta returns the TimeDelta of an interval of the value and unit given, like 2 DAY or '1 2' DAY_HOUR. The
+// unit must be in upper case.
+func NewTimeDelta(val interface{}, unit string) (*TimeDelta, error) {
+	var err error
 	var td TimeDelta
 
-	if r, ok := unitTextFormats[i.Unit]; ok {
+	if fields, ok := compoundUnitFields[unit]; ok {
 		val, err = sql.LongText.Convert(val)
 		if err != nil {
 			return nil, err
 		}
 
 		text := val.(string)
-		if !r.MatchString(text) {
-			return nil, errInvalidIntervalFormat.New(i.Unit, text)
+		f := fields(&td)
+		if !parseCompoundInterval(text, f, f[len(f)-1] == &td.Microseconds) {
+			return nil, errInvalidIntervalFormat.New(unit, text)
 		}
-
-		parts := textFormatParts(text, r)
-
-		switch i.Unit {
-		case "DAY_HOUR":
-			td.Days = parts[0]
-			td.Hours = parts[1]
-		case "DAY_MICROSECOND":
-			td.Days = parts[0]
-			td.Hours = parts[1]
-			td.Minutes = parts[2]
-			td.Seconds = parts[3]
-			td.Microseconds = parts[4]
-		case "DAY_MINUTE":
-			td.Days = parts[0]
-			td.Hours = parts[1]
-			td.Minutes = parts[2]
-		case "DAY_SECOND":
-			td.Days = parts[0]
-			td.Hours = parts[1]
-			td.Minutes = parts[2]
-			td.Seconds = parts[3]
-		case "HOUR_MICROSECOND":
-			td.Hours = parts[0]
-			td.Minutes = parts[1]
-			td.Seconds = parts[2]
-			td.Microseconds = parts[3]
-		case "HOUR_SECOND":
-			td.Hours = parts[0]
-			td.Minutes = parts[1]
-			td.Seconds = parts[2]
-		case "HOUR_MINUTE":
-			td.Hours = parts[0]
-			td.Minutes = parts[1]
-		case "MINUTE_MICROSECOND":
-			td.Minutes = parts[0]
-			td.Seconds = parts[1]
-			td.Microseconds = parts[2]
-		case "MINUTE_SECOND":
-			td.Minutes = parts[0]
-			td.Seconds = parts[1]
-		case "SECOND_MICROSECOND":
-			td.Seconds = parts[0]
-			td.Microseconds = parts[1]
-		case "YEAR_MONTH":
-			td.Years = parts[0]
-			td.Months = parts[1]
-		default:
-			return nil, errInvalidIntervalUnit.New(i.Unit)
+	} else if unit == "SECOND" {
+		// A number of seconds may have a fraction, which is kept as microseconds.
+		val, err = sql.Float64.Convert(val)
+		if err != nil {
+			return nil, err
 		}
+
+		secs := val.(float64)
+		td.Seconds = int64(secs)
+		td.Microseconds = int64(math.Round((secs - float64(td.Seconds)) * 1e6))
 	} else {
 		val, err = sql.Int64.Convert(val)
 		if err != nil {
@@ -136,15 +104,13 @@ func (i *Interval) EvalDelta(ctx *sql.Context, row sql.Row) (*TimeDelta, error)
 
 		num := val.(int64)
 
-		switch i.Unit {
+		switch unit {
 		case "DAY":
 			td.Days = num
 		case "HOUR":
 			td.Hours = num
 		case "MINUTE":
 			td.Minutes = num
-		case "SECOND":
-			td.Seconds = num
 		case "MICROSECOND":
 			td.Microseconds = num
 		case "QUARTER":
@@ -156,7 +122,7 @@ func (i *Interval) EvalDelta(ctx *sql.Context, row sql.Row) (*TimeDelta, error)
 		case "YEAR":
 			td.Years = num
 		default:
-			return nil, errInvalidIntervalUnit.New(i.Unit)
+			return nil, errInvalidIntervalUnit.New(unit)
 		}
 	}
 
@@ -175,31 +141,85 @@ func (i *Interval) String() string {
 	return fmt.Sprintf("INTERVAL %s %s", i.Child, i.Unit)
 }
 
-var unitTextFormats = map[string]*regexp.Regexp{
-	"DAY_HOUR":           regexp.MustCompile(`^(\d+)\s+(\d+)$`),
-	"DAY_MICROSECOND":    regexp.MustCompile(`^(\d+)\s+(\d+):(\d+):(\d+).(\d+)$`),
-	"DAY_MINUTE":         regexp.MustCompile(`^(\d+)\s+(\d+):(\d+)$`),
-	"DAY_SECOND":         regexp.MustCompile(`^(\d+)\s+(\d+):(\d+):(\d+)$`),
-	"HOUR_MICROSECOND":   regexp.MustCompile(`^(\d+):(\d+):(\d+).(\d+)$`),
-	"HOUR_SECOND":        regexp.MustCompile(`^(\d+):(\d+):(\d+)$`),
-	"HOUR_MINUTE":        regexp.MustCompile(`^(\d+):(\d+)$`),
-	"MINUTE_MICROSECOND": regexp.MustCompile(`^(\d+):(\d+).(\d+)$`),
-	"MINUTE_SECOND":      regexp.MustCompile(`^(\d+):(\d+)$`),
-	"SECOND_MICROSECOND": regexp.MustCompile(`^(\d+).(\d+)$`),
-	"YEAR_MONTH":         regexp.MustCompile(`^(\d+)-(\d+)$`),
+// compoundUnitFields returns, for every compound unit, the fields of a TimeDelta its value sets, from the most to the
+// least significant.
+var compoundUnitFields = map[string]func(td *TimeDelta) []*int64{
+	"DAY_HOUR": func(td *TimeDelta) []*int64 {
+		return []*int64{&td.Days, &td.Hours}
+	},
+	"DAY_MICROSECOND": func(td *TimeDelta) []*int64 {
+		return []*int64{&td.Days, &td.Hours, &td.Minutes, &td.Seconds, &td.Microseconds}
+	},
+	"DAY_MINUTE": func(td *TimeDelta) []*int64 {
+		return []*int64{&td.Days, &td.Hours, &td.Minutes}
+	},
+	"DAY_SECOND": func(td *TimeDelta) []*int64 {
+		return []*int64{&td.Days, &td.Hours, &td.Minutes, &td.Seconds}
+	},
+	"HOUR_MICROSECOND": func(td *TimeDelta) []*int64 {
+		return []*int64{&td.Hours, &td.Minutes, &td.Seconds, &td.Microseconds}
+	},
+	"HOUR_SECOND": func(td *TimeDelta) []*int64 {
+		return []*int64{&td.Hours, &td.Minutes, &td.Seconds}
+	},
+	"HOUR_MINUTE": func(td *TimeDelta) []*int64 {
+		return []*int64{&td.Hours, &td.Minutes}
+	},
+	"MINUTE_MICROSECOND": func(td *TimeDelta) []*int64 {
+		return []*int64{&td.Minutes, &td.Seconds, &td.Microseconds}
+	},
+	"MINUTE_SECOND": func(td *TimeDelta) []*int64 {
+		return []*int64{&td.Minutes, &td.Seconds}
+	},
+	"SECOND_MICROSECOND": func(td *TimeDelta) []*int64 {
+		return []*int64{&td.Seconds, &td.Microseconds}
+	},
+	"YEAR_MONTH": func(td *TimeDelta) []*int64 {
+		return []*int64{&td.Years, &td.Months}
+	},
 }
 
-func textFormatParts(text string, r *regexp.Regexp) []int64 {
-	parts := r.FindStringSubmatch(text)
-	var result []int64
-	for _, p := range parts[1:] {
-		// It is safe to igore the error here, because at this point we know
-		// the string matches the regexp, and that means it can't be an
-		// invalid number.
-		n, _ := strconv.ParseInt(p, 10, 64)
-		result = append(result, n)
+// parseCompoundInterval parses the value of a compound interval, like '1 30' for DAY_HOUR, into the fields given.
+// Like MySQL, any run of non-digits separates two parts, a leading '-' negates the whole interval, and a value with
+// fewer parts than fields sets the least significant ones, so '1:10' is 1 minute and 10 seconds as a DAY_SECOND. When
+// the last field holds microseconds, its part is read as a fraction of a second, so '1.5' is 1 second and 500000
+// microseconds as a SECOND_MICROSECOND. It returns false if the text isn't a valid value for the fields.
+func parseCompoundInterval(text string, fields []*int64, fractional bool) bool {
+	text = strings.TrimSpace(text)
+	sign := int64(1)
+	if strings.HasPrefix(text, "-") {
+		sign = -1
+		text = text[1:]
+	}
+
+	parts := strings.FieldsFunc(text, func(r rune) bool { return r < '0' || r > '9' })
+	if len(parts) == 0 || len(parts) > len(fields) {
+		return false
+	}
+
+	if fractional {
+		// Six digits make a whole number of microseconds, so shorter parts are padded and longer ones truncated.
+		last := parts[len(parts)-1]
+		if len(last) < 6 {
+			last += strings.Repeat("0", 6-len(last))
+		}
+		parts[len(parts)-1] = last[:6]
+	}
+
+	values := make([]int64, len(parts))
+	for i, p := range parts {
+		n, err := strconv.ParseInt(p, 10, 64)
+		if err != nil {
+			return false
+		}
+		values[i] = n
+	}
+
+	offset := len(fields) - len(values)
+	for i, v := range values {
+		*fields[offset+i] = v * sign
 	}
-	return result
+	return true
 }
 
 // TimeDelta is the difference between a time and another time.
@@ -242,24 +262,18 @@ func (td TimeDelta) apply(t time.Time, sign int64) time.Time {
 	}
 
 	if td.Months != 0 {
-		m := mo + td.Months*sign
-		if m < 1 {
-			mo = 12 + (m % 12)
-			y += m/12 - 1
-		} else if m > 12 {
-			mo = m % 12
-			y += m / 12
-		} else {
-			mo = m
-		}
-
-		// Due to the operations done before, month may be zero, which means it's
-		// december.
-		if mo == 0 {
-			mo = 12
+		// Months are counted from zero here, so that whole years carry over in both directions.
+		m := mo - 1 + td.Months*sign
+		y += m / 12
+		m %= 12
+		if m < 0 {
+			m += 12
+			y--
 		}
+		mo = m + 1
 	}
 
+	// The day is clamped to the last one of the month, so that Jan 31 plus one month is the last day of February.
 	if days := daysInMonth(time.Month(mo), int(y)); days < d {
 		d = days
 	}
@@ -267,7 +281,7 @@ func (td TimeDelta) apply(t time.Time, sign int64) time.Time {
 	date := time.Date(int(y), time.Month(mo), d, h, min, s, ns, t.Location())
 
 	if td.Days != 0 {
-		date = date.Add(time.Duration(td.Days) * day * time.Duration(sign))
+		date = date.AddDate(0, 0, int(td.Days*sign))
 	}
 
 	if td.Hours != 0 {
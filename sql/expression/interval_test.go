@@ -53,7 +53,37 @@ func TestTimeDelta(t *testing.T) {
 			"plus overflowing until december",
 			TimeDelta{Months: 22},
 			leapYear,
-			date(2006, time.December, 29, 0, 0, 0, 0),
+			date(2005, time.December, 29, 0, 0, 0, 0),
+		},
+		{
+			"plus whole years of months",
+			TimeDelta{Months: 24},
+			leapYear,
+			date(2006, time.February, 28, 0, 0, 0, 0),
+		},
+		{
+			"plus months until the end of a shorter month",
+			TimeDelta{Months: 1},
+			date(2003, time.January, 31, 0, 0, 0, 0),
+			date(2003, time.February, 28, 0, 0, 0, 0),
+		},
+		{
+			"plus months until the end of february of a leap year",
+			TimeDelta{Months: 1},
+			date(2004, time.January, 31, 0, 0, 0, 0),
+			date(2004, time.February, 29, 0, 0, 0, 0),
+		},
+		{
+			"minus months until the end of a shorter month",
+			TimeDelta{Months: -1},
+			date(2004, time.May, 31, 0, 0, 0, 0),
+			date(2004, time.April, 30, 0, 0, 0, 0),
+		},
+		{
+			"plus a quarter from the end of a month",
+			TimeDelta{Months: 3},
+			date(2004, time.November, 30, 0, 0, 0, 0),
+			date(2005, time.February, 28, 0, 0, 0, 0),
 		},
 		{
 			"minus overflowing months",
@@ -226,7 +256,7 @@ func TestIntervalEvalDelta(t *testing.T) {
 			NewLiteral("2 3:04:05.06", sql.LongText),
 			"DAY_MICROSECOND",
 			nil,
-			TimeDelta{Days: 2, Hours: 3, Minutes: 4, Seconds: 5, Microseconds: 6},
+			TimeDelta{Days: 2, Hours: 3, Minutes: 4, Seconds: 5, Microseconds: 60000},
 		},
 		{
 			NewLiteral("2 3:04:05", sql.LongText),
@@ -244,7 +274,7 @@ func TestIntervalEvalDelta(t *testing.T) {
 			NewLiteral("3:04:05.06", sql.LongText),
 			"HOUR_MICROSECOND",
 			nil,
-			TimeDelta{Hours: 3, Minutes: 4, Seconds: 5, Microseconds: 6},
+			TimeDelta{Hours: 3, Minutes: 4, Seconds: 5, Microseconds: 60000},
 		},
 		{
 			NewLiteral("3:04:05", sql.LongText),
@@ -262,7 +292,7 @@ func TestIntervalEvalDelta(t *testing.T) {
 			NewLiteral("04:05.06", sql.LongText),
 			"MINUTE_MICROSECOND",
 			nil,
-			TimeDelta{Minutes: 4, Seconds: 5, Microseconds: 6},
+			TimeDelta{Minutes: 4, Seconds: 5, Microseconds: 60000},
 		},
 		{
 			NewLiteral("04:05", sql.LongText),
@@ -274,8 +304,50 @@ func TestIntervalEvalDelta(t *testing.T) {
 			NewLiteral("04.05", sql.LongText),
 			"SECOND_MICROSECOND",
 			nil,
+			TimeDelta{Seconds: 4, Microseconds: 50000},
+		},
+		{
+			NewLiteral("4.000005", sql.LongText),
+			"SECOND_MICROSECOND",
+			nil,
 			TimeDelta{Seconds: 4, Microseconds: 5},
 		},
+		{
+			NewLiteral("4.1234567", sql.LongText),
+			"SECOND_MICROSECOND",
+			nil,
+			TimeDelta{Seconds: 4, Microseconds: 123456},
+		},
+		{
+			NewLiteral("1 30", sql.LongText),
+			"DAY_HOUR",
+			nil,
+			TimeDelta{Days: 1, Hours: 30},
+		},
+		{
+			NewLiteral("-1 2", sql.LongText),
+			"DAY_HOUR",
+			nil,
+			TimeDelta{Days: -1, Hours: -2},
+		},
+		{
+			NewLiteral("1:10", sql.LongText),
+			"DAY_SECOND",
+			nil,
+			TimeDelta{Minutes: 1, Seconds: 10},
+		},
+		{
+			NewLiteral("2.5", sql.LongText),
+			"DAY_MICROSECOND",
+			nil,
+			TimeDelta{Seconds: 2, Microseconds: 500000},
+		},
+		{
+			NewLiteral(1.25, sql.Float64),
+			"SECOND",
+			nil,
+			TimeDelta{Seconds: 1, Microseconds: 250000},
+		},
 		{
 			NewLiteral("1-5", sql.LongText),
 			"YEAR_MONTH",
@@ -295,6 +367,26 @@ func TestIntervalEvalDelta(t *testing.T) {
 	}
 }
 
+func TestIntervalEvalDeltaInvalidFormat(t *testing.T) {
+	testCases := []struct {
+		text string
+		unit string
+	}{
+		{"", "DAY_HOUR"},
+		{"1 2 3", "DAY_HOUR"},
+		{"1-2-3", "YEAR_MONTH"},
+	}
+
+	for _, tt := range testCases {
+		interval := NewInterval(NewLiteral(tt.text, sql.LongText), tt.unit)
+		t.Run(interval.String(), func(t *testing.T) {
+			_, err := interval.EvalDelta(sql.NewEmptyContext(), nil)
+			require.Error(t, err)
+			require.True(t, errInvalidIntervalFormat.Is(err))
+		})
+	}
+}
+
 func date(year int, month time.Month, day, hour, min, sec, micro int) time.Time {
 	return time.Date(year, month, day, hour, min, sec, micro*int(time.Microsecond), time.Local)
 }
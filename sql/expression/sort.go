@@ -26,6 +26,8 @@ type Sorter struct {
 	Rows       []sql.Row
 	LastError  error
 	Ctx        *sql.Context
+	// Collated sorts strings with the collation of their type instead of byte by byte
+	Collated bool
 }
 
 func (s *Sorter) Len() int {
@@ -69,7 +71,12 @@ func (s *Sorter) Less(i, j int) bool {
 			return sf.NullOrdering != sql.NullsFirst
 		}
 
-		cmp, err := typ.Compare(av, bv)
+		var cmp int
+		if s.Collated {
+			cmp, err = sql.CollatedCompare(typ, av, bv)
+		} else {
+			cmp, err = typ.Compare(av, bv)
+		}
 		if err != nil {
 			s.LastError = err
 			return false
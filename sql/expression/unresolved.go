@@ -110,7 +110,10 @@ var _ sql.TableFunction = (*UnresolvedTableFunction)(nil)
 type UnresolvedTableFunction struct {
 	name      string
 	Arguments []sql.Expression
-	database  sql.Database
+	// ArgumentNames holds the name of each argument given by name, or an
+	// empty string for arguments given by position.
+	ArgumentNames []string
+	database      sql.Database
 }
 
 // NewUnresolvedTableFunction creates a new UnresolvedTableFunction node for a sql plan.
@@ -121,6 +124,26 @@ func NewUnresolvedTableFunction(name string, arguments []sql.Expression) *Unreso
 	}
 }
 
+// NewUnresolvedTableFunctionWithNames creates a new UnresolvedTableFunction node whose arguments may be given by
+// name. The names slice is parallel to the arguments, with an empty string for positional arguments.
+func NewUnresolvedTableFunctionWithNames(name string, arguments []sql.Expression, names []string) *UnresolvedTableFunction {
+	return &UnresolvedTableFunction{
+		name:          name,
+		Arguments:     arguments,
+		ArgumentNames: names,
+	}
+}
+
+// HasNamedArguments returns whether any of the arguments of this table function were given by name.
+func (utf UnresolvedTableFunction) HasNamedArguments() bool {
+	for _, name := range utf.ArgumentNames {
+		if name != "" {
+			return true
+		}
+	}
+	return false
+}
+
 // NewInstance implements the TableFunction interface
 func (utf UnresolvedTableFunction) NewInstance(_ *sql.Context, _ sql.Database, _ []sql.Expression) (sql.Node, error) {
 	return nil, ErrUnresolvedTableFunction.New()
@@ -197,6 +220,9 @@ func (utf UnresolvedTableFunction) String() string {
 	var exprs = make([]string, len(utf.Arguments))
 	for i, e := range utf.Arguments {
 		exprs[i] = e.String()
+		if i < len(utf.ArgumentNames) && utf.ArgumentNames[i] != "" {
+			exprs[i] = fmt.Sprintf("%s => %s", utf.ArgumentNames[i], exprs[i])
+		}
 	}
 
 	return fmt.Sprintf("%s(%s)", utf.name, strings.Join(exprs, ", "))
@@ -213,6 +239,9 @@ type UnresolvedFunction struct {
 	IsAggregate bool
 	// Window is the window for this function, if present
 	Window *sql.WindowDefinition
+	// Distinct is whether the arguments of an aggregate function were
+	// qualified with DISTINCT.
+	Distinct bool
 	// Children of the expression.
 	Arguments []sql.Expression
 }
@@ -244,6 +273,13 @@ func (uf *UnresolvedFunction) WithWindow(def *sql.WindowDefinition) *UnresolvedF
 	return &nf
 }
 
+// WithDistinct returns a copy of this function with the DISTINCT qualifier set.
+func (uf *UnresolvedFunction) WithDistinct(distinct bool) *UnresolvedFunction {
+	nf := *uf
+	nf.Distinct = distinct
+	return &nf
+}
+
 // Resolved implements the Expression interface.
 func (*UnresolvedFunction) Resolved() bool {
 	return false
@@ -273,7 +309,12 @@ func (uf *UnresolvedFunction) String() string {
 		over = fmt.Sprintf(" %s", uf.Window)
 	}
 
-	return fmt.Sprintf("%s(%s)%s", uf.name, strings.Join(exprs, ", "), over)
+	distinct := ""
+	if uf.Distinct {
+		distinct = "DISTINCT "
+	}
+
+	return fmt.Sprintf("%s(%s%s)%s", uf.name, distinct, strings.Join(exprs, ", "), over)
 }
 
 func (uf *UnresolvedFunction) DebugString() string {
@@ -287,7 +328,12 @@ func (uf *UnresolvedFunction) DebugString() string {
 		over = fmt.Sprintf(" %s", sql.DebugString(uf.Window))
 	}
 
-	return fmt.Sprintf("(unresolved)%s(%s)%s", uf.name, strings.Join(exprs, ", "), over)
+	distinct := ""
+	if uf.Distinct {
+		distinct = "DISTINCT "
+	}
+
+	return fmt.Sprintf("(unresolved)%s(%s%s)%s", uf.name, distinct, strings.Join(exprs, ", "), over)
 }
 
 // Eval implements the Expression interface.
@@ -306,5 +352,5 @@ func (uf *UnresolvedFunction) WithChildren(children ...sql.Expression) (sql.Expr
 		return nil, err
 	}
 
-	return NewUnresolvedFunction(uf.name, uf.IsAggregate, window, children[:len(uf.Arguments)]...), nil
+	return NewUnresolvedFunction(uf.name, uf.IsAggregate, window, children[:len(uf.Arguments)]...).WithDistinct(uf.Distinct), nil
 }
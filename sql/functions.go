@@ -14,12 +14,17 @@
 
 package sql
 
+import "fmt"
+
 // Function is a function defined by the user that can be applied in a SQL query.
 type Function interface {
 	// NewInstance returns a new instance of the function to evaluate against rows
 	NewInstance([]Expression) (Expression, error)
 	// FunctionName returns the name of this function
 	FunctionName() string
+	// Arity returns the minimum and maximum number of arguments this function
+	// accepts. A maximum of -1 means there is no upper bound.
+	Arity() (min, max int)
 	// isFunction is a private method to restrict implementations of Function
 	isFunction()
 }
@@ -75,12 +80,15 @@ type (
 		Name string
 		Fn   CreateFunc7Args
 	}
-	// FunctionN is a function with variable number of arguments. This function
-	// is expected to return ErrInvalidArgumentNumber if the arity does not
-	// match, since the check has to be done in the implementation.
+	// FunctionN is a function with variable number of arguments. MinArgs and
+	// MaxArgs declare the accepted arity, with a MaxArgs of zero meaning there
+	// is no upper bound. Functions that need finer checks than a range are
+	// expected to return ErrInvalidArgumentNumber from the implementation.
 	FunctionN struct {
-		Name string
-		Fn   CreateFuncNArgs
+		Name    string
+		Fn      CreateFuncNArgs
+		MinArgs int
+		MaxArgs int
 	}
 )
 
@@ -179,6 +187,21 @@ func (fn Function6) FunctionName() string { return fn.Name }
 func (fn Function7) FunctionName() string { return fn.Name }
 func (fn FunctionN) FunctionName() string { return fn.Name }
 
+func (Function0) Arity() (int, int) { return 0, 0 }
+func (Function1) Arity() (int, int) { return 1, 1 }
+func (Function2) Arity() (int, int) { return 2, 2 }
+func (Function3) Arity() (int, int) { return 3, 3 }
+func (Function4) Arity() (int, int) { return 4, 4 }
+func (Function5) Arity() (int, int) { return 5, 5 }
+func (Function6) Arity() (int, int) { return 6, 6 }
+func (Function7) Arity() (int, int) { return 7, 7 }
+func (fn FunctionN) Arity() (int, int) {
+	if fn.MaxArgs == 0 {
+		return fn.MinArgs, -1
+	}
+	return fn.MinArgs, fn.MaxArgs
+}
+
 func (Function0) isFunction() {}
 func (Function1) isFunction() {}
 func (Function2) isFunction() {}
@@ -188,3 +211,26 @@ func (Function5) isFunction() {}
 func (Function6) isFunction() {}
 func (Function7) isFunction() {}
 func (FunctionN) isFunction() {}
+
+// ValidateArity returns ErrInvalidArgumentNumber if the number of arguments
+// given is outside of the arity declared by the function.
+func ValidateArity(fn Function, numArgs int) error {
+	min, max := fn.Arity()
+	if numArgs >= min && (max < 0 || numArgs <= max) {
+		return nil
+	}
+
+	var expected string
+	switch {
+	case min == max:
+		expected = fmt.Sprint(min)
+	case max < 0:
+		expected = fmt.Sprintf("%d or more", min)
+	case max == min+1:
+		expected = fmt.Sprintf("%d or %d", min, max)
+	default:
+		expected = fmt.Sprintf("%d to %d", min, max)
+	}
+
+	return ErrInvalidArgumentNumber.New(fn.FunctionName(), expected, numArgs)
+}
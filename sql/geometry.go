@@ -23,11 +23,11 @@ import (
 // Represents the Geometry type.
 // https://dev.mysql.com/doc/refman/8.0/en/gis-class-geometry.html
 type Geometry struct {
-	Inner interface{} // Will be Point, Linestring, or Polygon
+	Inner interface{} // Will be Point, Linestring, Polygon, MultiPoint, MultiLinestring, MultiPolygon, or GeometryCollection
 }
 
 type GeometryType struct {
-	InnerType Type // Will be PointType, LinestringType, or PolygonType
+	InnerType Type // Will be PointType, LinestringType, PolygonType, or one of the multi geometry types
 }
 
 var _ Type = GeometryType{}
@@ -54,6 +54,14 @@ func (t GeometryType) Compare(a any, b any) (int, error) {
 		return LinestringType{}.Compare(this, b)
 	case Polygon:
 		return PolygonType{}.Compare(this, b)
+	case MultiPoint:
+		return MultiPointType{}.Compare(this, b)
+	case MultiLinestring:
+		return MultiLinestringType{}.Compare(this, b)
+	case MultiPolygon:
+		return MultiPolygonType{}.Compare(this, b)
+	case GeometryCollection:
+		return GeometryCollectionType{}.Compare(this, b)
 	case Geometry:
 		return t.Compare(this.Inner, b)
 	default:
@@ -71,13 +79,35 @@ func (t GeometryType) Convert(v interface{}) (interface{}, error) {
 		return Geometry{Inner: this}, nil
 	case Polygon:
 		return Geometry{Inner: this}, nil
+	case MultiPoint, MultiLinestring, MultiPolygon, GeometryCollection:
+		return Geometry{Inner: this}, nil
 	case Geometry:
 		return this, nil
+	case string, []byte:
+		g, err := parseSpatialValue(this)
+		if err != nil {
+			return nil, err
+		}
+		return t.Convert(g)
 	default:
 		return nil, ErrNotPoint.New(v) // TODO: change to be geometry error
 	}
 }
 
+// parseSpatialValue parses a string as WKT and a byte array as MySQL's internal geometry format, an SRID followed by
+// WKB, which is how values of other types are converted to the spatial types, and returns the geometry they describe.
+// Other values are returned unchanged.
+func parseSpatialValue(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case string:
+		return ParseWKT(v)
+	case []byte:
+		return DeserializeGeometry(v)
+	default:
+		return v, nil
+	}
+}
+
 // Promote implements the Type interface.
 func (t GeometryType) Promote() Type {
 	return t
@@ -94,7 +124,11 @@ func (t GeometryType) SQL(dest []byte, v interface{}) (sqltypes.Value, error) {
 		return sqltypes.Value{}, nil
 	}
 
-	val := appendAndSlice(dest, []byte(pv.(string)))
+	buf, err := SerializeGeometry(pv)
+	if err != nil {
+		return sqltypes.Value{}, err
+	}
+	val := appendAndSlice(dest, buf)
 
 	return sqltypes.MakeTrusted(sqltypes.Geometry, val), nil
 }
@@ -0,0 +1,140 @@
+// Copyright 2020-2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/proto/query"
+)
+
+// Represents the GeometryCollection type.
+// https://dev.mysql.com/doc/refman/8.0/en/gis-class-geometrycollection.html
+type GeometryCollection struct {
+	SRID  uint32
+	Geoms []interface{} // Any of Point, Linestring, Polygon, MultiPoint, MultiLinestring, MultiPolygon or GeometryCollection
+}
+
+type GeometryCollectionType struct{}
+
+var _ Type = GeometryCollectionType{}
+
+var ErrNotGeometryCollection = errors.NewKind("value of type %T is not a geometry collection")
+
+// Compare implements Type interface.
+func (t GeometryCollectionType) Compare(a interface{}, b interface{}) (int, error) {
+	// Compare nulls
+	if hasNulls, res := compareNulls(a, b); hasNulls {
+		return res, nil
+	}
+
+	// Expect to receive a GeometryCollection, throw error otherwise
+	_a, ok := a.(GeometryCollection)
+	if !ok {
+		return 0, ErrNotGeometryCollection.New(a)
+	}
+	_b, ok := b.(GeometryCollection)
+	if !ok {
+		return 0, ErrNotGeometryCollection.New(b)
+	}
+
+	// Get shorter length
+	var n int
+	lenA := len(_a.Geoms)
+	lenB := len(_b.Geoms)
+	if lenA < lenB {
+		n = lenA
+	} else {
+		n = lenB
+	}
+
+	// Compare each geometry until there's a difference
+	for i := 0; i < n; i++ {
+		diff, err := GeometryType{}.Compare(_a.Geoms[i], _b.Geoms[i])
+		if err != nil {
+			return 0, err
+		}
+		if diff != 0 {
+			return diff, nil
+		}
+	}
+
+	// Determine based off length
+	if lenA > lenB {
+		return 1, nil
+	}
+	if lenA < lenB {
+		return -1, nil
+	}
+
+	// GeometryCollections must be the same
+	return 0, nil
+}
+
+// Convert implements Type interface.
+func (t GeometryCollectionType) Convert(v interface{}) (interface{}, error) {
+	// Must be a GeometryCollection, fail otherwise
+	if v, ok := v.(GeometryCollection); ok {
+		return v, nil
+	}
+
+	return nil, ErrNotGeometryCollection.New(v)
+}
+
+// Promote implements the Type interface.
+func (t GeometryCollectionType) Promote() Type {
+	return t
+}
+
+// SQL implements Type interface.
+func (t GeometryCollectionType) SQL(dest []byte, v interface{}) (sqltypes.Value, error) {
+	if v == nil {
+		return sqltypes.NULL, nil
+	}
+
+	mv, err := t.Convert(v)
+	if err != nil {
+		return sqltypes.Value{}, nil
+	}
+
+	var b []byte
+	switch mv := mv.(type) {
+	case string:
+		b = []byte(mv)
+	case []byte:
+		b = mv
+	default:
+		return sqltypes.Value{}, ErrNotGeometryCollection.New(v)
+	}
+	val := appendAndSlice(dest, b)
+
+	return sqltypes.MakeTrusted(sqltypes.Geometry, val), nil
+}
+
+// String implements Type interface.
+func (t GeometryCollectionType) String() string {
+	return "GEOMETRYCOLLECTION"
+}
+
+// Type implements Type interface.
+func (t GeometryCollectionType) Type() query.Type {
+	return sqltypes.Geometry
+}
+
+// Zero implements Type interface.
+func (t GeometryCollectionType) Zero() interface{} {
+	return GeometryCollection{}
+}
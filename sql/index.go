@@ -58,6 +58,18 @@ type FilteredIndex interface {
 	HandledFilters(filters []Expression) (handled []Expression)
 }
 
+// SpatialIndex is an index over a single geometry column, which finds the rows whose geometries may intersect an area
+// rather than the rows whose values fall within ranges. Implementations usually build an RTree over the envelopes of
+// the indexed geometries. Spatial indexes are only used to find the candidate rows for spatial predicates, so they never
+// handle filters, and NewLookup is expected to return nil.
+type SpatialIndex interface {
+	Index
+	// NewSpatialLookup returns a new SpatialIndexLookup for the rows whose geometries have envelopes intersecting the
+	// envelope given. A lookup may return rows that do not intersect, as the predicate is always applied to the
+	// returned rows. If an integrator is unable to process the envelope, then a nil may be returned.
+	NewSpatialLookup(ctx *Context, envelope Envelope) (SpatialIndexLookup, error)
+}
+
 // IndexLookup is the implementation-specific definition of an index lookup. The IndexLookup must contain all necessary
 // information to retrieve exactly the rows in the table as specified by the ranges given to their parent index.
 // Implementors are responsible for all semantics of correctly returning rows that match an index lookup.
@@ -69,6 +81,14 @@ type IndexLookup interface {
 	Ranges() RangeCollection
 }
 
+// SpatialIndexLookup is an IndexLookup created by a SpatialIndex. It has no ranges, and is instead defined by the
+// envelope given to the index.
+type SpatialIndexLookup interface {
+	IndexLookup
+	// Envelope returns the Envelope that created this SpatialIndexLookup.
+	Envelope() Envelope
+}
+
 // ColumnExpressionType returns a column expression along with its Type.
 type ColumnExpressionType struct {
 	Expression string
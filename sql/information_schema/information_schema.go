@@ -1142,6 +1142,10 @@ func triggersRowIter(ctx *Context, c Catalog) (RowIter, error) {
 					return nil, ErrTriggerCreateStatementInvalid.New(trigger.CreateStatement)
 				}
 				triggerPlan.CreatedAt = trigger.CreatedAt // Keep stored created time
+				if trigger.Definer != "" {
+					triggerPlan.Definer = trigger.Definer // Keep stored definer
+				}
+				triggerPlan.SqlMode = trigger.SqlMode // Keep stored sql_mode
 				triggerPlans = append(triggerPlans, triggerPlan)
 			}
 
@@ -1179,6 +1183,7 @@ func triggersRowIter(ctx *Context, c Catalog) (RowIter, error) {
 				for order, triggerPlan := range planGroup {
 					triggerEvent := strings.ToUpper(triggerPlan.TriggerEvent)
 					triggerTime := strings.ToUpper(triggerPlan.TriggerTime)
+					triggerForEach := strings.ToUpper(triggerPlan.ForEach)
 					tableName := triggerPlan.Table.(*plan.UnresolvedTable).Name()
 					characterSetClient, err := ctx.GetSessionVariable(ctx, "character_set_client")
 					if err != nil {
@@ -1203,15 +1208,15 @@ func triggersRowIter(ctx *Context, c Catalog) (RowIter, error) {
 						int64(order + 1),        // action_order
 						nil,                     // action_condition
 						triggerPlan.BodyString,  // action_statement
-						"ROW",                   // action_orientation
+						triggerForEach,          // action_orientation
 						triggerTime,             // action_timing
 						nil,                     // action_reference_old_table
 						nil,                     // action_reference_new_table
 						"OLD",                   // action_reference_old_row
 						"NEW",                   // action_reference_new_row
 						triggerPlan.CreatedAt,   // created
-						"",                      // sql_mode
-						"",                      // definer
+						triggerPlan.SqlMode,     // sql_mode
+						triggerPlan.Definer,     // definer
 						characterSetClient,      // character_set_client
 						collationConnection,     // collation_connection
 						collationServer,         // database_collation
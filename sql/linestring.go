@@ -28,6 +28,16 @@ type Linestring struct {
 	Points []Point
 }
 
+// IsLinearRing returns whether the linestring is closed and has at least four points, as the rings of a polygon must.
+func (l Linestring) IsLinearRing() bool {
+	// Check length of Linestring (must be 4+ points)
+	if len(l.Points) < 4 {
+		return false
+	}
+	// Check if it is closed (first and last point are the same)
+	return l.Points[0].Equals(l.Points[len(l.Points)-1])
+}
+
 type LinestringType struct{}
 
 var _ Type = LinestringType{}
@@ -86,6 +96,12 @@ func (t LinestringType) Compare(a interface{}, b interface{}) (int, error) {
 
 // Convert implements Type interface.
 func (t LinestringType) Convert(v interface{}) (interface{}, error) {
+	// Parse strings and byte arrays
+	v, err := parseSpatialValue(v)
+	if err != nil {
+		return nil, err
+	}
+
 	// Must be a Linestring, fail otherwise
 	if v, ok := v.(Linestring); ok {
 		return v, nil
@@ -110,7 +126,11 @@ func (t LinestringType) SQL(dest []byte, v interface{}) (sqltypes.Value, error)
 		return sqltypes.Value{}, nil
 	}
 
-	val := appendAndSlice(dest, []byte(pv.(string)))
+	buf, err := SerializeGeometry(pv)
+	if err != nil {
+		return sqltypes.Value{}, err
+	}
+	val := appendAndSlice(dest, buf)
 
 	return sqltypes.MakeTrusted(sqltypes.Geometry, val), nil
 }
@@ -34,7 +34,7 @@ func ParseDateWithFormat(date, format string) (time.Time, error) {
 		target = takeAllSpaces(target)
 		rest, err := parser(&result, target)
 		if err != nil {
-			return time.Time{}, err
+			return time.Time{}, withOffset(err, len(date)-len(target))
 		}
 		target = rest
 	}
@@ -105,6 +105,20 @@ func wrapSpecifierParser(p parser, specifier byte) parser {
 	}
 }
 
+// withOffset records the position in the date string at which a
+// parse error occurred.
+func withOffset(err error, offset int) error {
+	switch e := err.(type) {
+	case ParseSpecifierErr:
+		e.Offset = offset
+		return e
+	case ParseLiteralErr:
+		e.Offset = offset
+		return e
+	}
+	return err
+}
+
 // datetime defines the fields parsed by format specifiers.
 // Some combinations of values are invalid and cannot be mapped
 // unambiguously to time.Time.
@@ -134,31 +148,39 @@ type datetime struct {
 
 // ParseSpecifierErr defines a error when attempting to parse
 // the date string input according to a specified format directive.
+//
+// Offset is the position in the date string of the first of the
+// remaining Tokens.
 type ParseSpecifierErr struct {
 	Specifier byte
 	Tokens    string
+	Offset    int
 	err       error
 }
 
 func (p ParseSpecifierErr) Unwrap() error { return p.err }
 
 func (p ParseSpecifierErr) Error() string {
-	return fmt.Sprintf("specifier %%%c failed to parse \"%s\": %s", p.Specifier, p.Tokens, p.err.Error())
+	return fmt.Sprintf("specifier %%%c failed to parse \"%s\" at offset %d: %s", p.Specifier, p.Tokens, p.Offset, p.err.Error())
 }
 
 // ParseLiteralErr defines a error when attempting to parse
 // the date string input according to a literal character specified
 // in the format string.
+//
+// Offset is the position in the date string of the first of the
+// remaining Tokens.
 type ParseLiteralErr struct {
 	Literal byte
 	Tokens  string
+	Offset  int
 	err     error
 }
 
 func (p ParseLiteralErr) Unwrap() error { return p.err }
 
 func (p ParseLiteralErr) Error() string {
-	return fmt.Sprintf("literal %c not matched in \"%s\": %s", p.Literal, p.Tokens, p.err.Error())
+	return fmt.Sprintf("literal %c not matched in \"%s\" at offset %d: %s", p.Literal, p.Tokens, p.Offset, p.err.Error())
 }
 
 // formatSpecifiers defines the formatting directives for parsing and formatting dates.
@@ -211,8 +233,10 @@ var formatSpecifiers = map[byte]parser{
 	'u': nil,
 	'V': nil,
 	'v': nil,
-	'W': nil,
-	'w': nil,
+	// %W	Weekday name (Sunday..Saturday)
+	'W': parseWeekdayName,
+	// %w	Day of the week (0=Sunday..6=Saturday)
+	'w': parseWeekdayNumeric,
 	'X': nil,
 	'x': nil,
 	// %Y	Year, numeric, four digits
@@ -224,70 +248,141 @@ var formatSpecifiers = map[byte]parser{
 
 func boolPtr(a bool) *bool { return &a }
 
-// Convert a week abbreviation to a defined weekday.
+// monthNames are the English month names used by %M and %b, indexed by time.Month.
+var monthNames = [...]string{
+	time.January:   "January",
+	time.February:  "February",
+	time.March:     "March",
+	time.April:     "April",
+	time.May:       "May",
+	time.June:      "June",
+	time.July:      "July",
+	time.August:    "August",
+	time.September: "September",
+	time.October:   "October",
+	time.November:  "November",
+	time.December:  "December",
+}
+
+// weekdayNames are the English weekday names used by %W and %a, indexed by time.Weekday.
+var weekdayNames = [...]string{
+	time.Sunday:    "Sunday",
+	time.Monday:    "Monday",
+	time.Tuesday:   "Tuesday",
+	time.Wednesday: "Wednesday",
+	time.Thursday:  "Thursday",
+	time.Friday:    "Friday",
+	time.Saturday:  "Saturday",
+}
+
+// MonthName returns the full name of the month, as formatted by %M.
+func MonthName(m time.Month) string {
+	if m < time.January || m > time.December {
+		return ""
+	}
+	return monthNames[m]
+}
+
+// MonthAbbreviation returns the three letter abbreviation of the month, as formatted by %b.
+func MonthAbbreviation(m time.Month) string {
+	name := MonthName(m)
+	if name == "" {
+		return ""
+	}
+	return name[:3]
+}
+
+// WeekdayName returns the full name of the weekday, as formatted by %W.
+func WeekdayName(d time.Weekday) string {
+	if d < time.Sunday || d > time.Saturday {
+		return ""
+	}
+	return weekdayNames[d]
+}
+
+// WeekdayAbbreviation returns the three letter abbreviation of the weekday, as formatted by %a.
+func WeekdayAbbreviation(d time.Weekday) string {
+	name := WeekdayName(d)
+	if name == "" {
+		return ""
+	}
+	return name[:3]
+}
+
+// WeekdayNumber returns the number of the weekday, as parsed and formatted by %w, from 0 for Sunday to 6 for Saturday.
+func WeekdayNumber(d time.Weekday) int {
+	return int(d)
+}
+
+// DaySuffix returns the English ordinal suffix of the day of the month, as parsed and formatted by %D: "st", "nd", "rd"
+// or "th".
+func DaySuffix(day int) string {
+	if day < 4 || day > 20 {
+		switch day % 10 {
+		case 1:
+			return "st"
+		case 2:
+			return "nd"
+		case 3:
+			return "rd"
+		}
+	}
+	return "th"
+}
+
+// DayOfYear returns the day of the year of the date, as parsed and formatted by %j, from 1 for January 1st. It's the
+// inverse of DateFromDayOfYear.
+func DayOfYear(t time.Time) int {
+	return t.YearDay()
+}
+
+// Convert a week abbreviation to a defined weekday. The abbreviation is matched regardless of case.
 func weekdayAbbrev(abbrev string) (time.Weekday, bool) {
-	switch abbrev {
-	case "sun":
-		return time.Sunday, true
-	case "mon":
-		return time.Monday, true
-	case "tue":
-		return time.Tuesday, true
-	case "wed":
-		return time.Wednesday, true
-	case "thu":
-		return time.Thursday, true
-	case "fri":
-		return time.Friday, true
-	case "sat":
-		return time.Saturday, true
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(abbrev, WeekdayAbbreviation(d)) {
+			return d, true
+		}
 	}
 	return 0, false
 }
 
-// Convert a month abbreviation to a defined month.
+// Convert a month abbreviation to a defined month. The abbreviation is matched regardless of case.
 func monthAbbrev(abbrev string) (time.Month, bool) {
-	switch abbrev {
-	case "jan":
-		return time.January, true
-	case "feb":
-		return time.February, true
-	case "mar":
-		return time.March, true
-	case "apr":
-		return time.April, true
-	case "may":
-		return time.May, true
-	case "jun":
-		return time.June, true
-	case "jul":
-		return time.July, true
-	case "aug":
-		return time.August, true
-	case "sep":
-		return time.September, true
-	case "oct":
-		return time.October, true
-	case "nov":
-		return time.November, true
-	case "dec":
-		return time.December, true
+	for m := time.January; m <= time.December; m++ {
+		if strings.EqualFold(abbrev, MonthAbbreviation(m)) {
+			return m, true
+		}
 	}
 	return 0, false
 }
 
+// weekdayName converts the full name of a weekday at the start of [name] to a weekday, and returns the length of the
+// name. The name is matched regardless of case.
+func weekdayName(name string) (weekday time.Weekday, charCount int, ok bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if hasPrefixFold(name, WeekdayName(d)) {
+			return d, len(WeekdayName(d)), true
+		}
+	}
+	return 0, 0, false
+}
+
 // TODO: allow this to match partial months
 // janu should match janurary
 func monthName(name string) (month time.Month, charCount int, ok bool) {
-	for i := 1; i < 13; i++ {
-		m := time.Month(i)
-		if strings.HasPrefix(name, strings.ToLower(m.String())) {
-			return m, len(m.String()), true
+	for m := time.January; m <= time.December; m++ {
+		if hasPrefixFold(name, MonthName(m)) {
+			return m, len(MonthName(m)), true
 		}
 	}
 	return 0, 0, false
 }
 
+// hasPrefixFold is strings.HasPrefix, ignoring the case of the ASCII names it's used for.
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
 // MySQL specification, valid format specifiers.
 // Specifier	Description
 // %a			Abbreviated weekday name (Sun..Sat)
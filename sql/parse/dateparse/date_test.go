@@ -2,6 +2,7 @@ package dateparse
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -20,6 +21,8 @@ func TestParseDate(t *testing.T) {
 		{"simple", "Jan 3, 2000", "%b %e, %Y", "2000-01-03 00:00:00 -0600 CST"},
 		{"simple_with_spaces", "Nov  03 ,   2000", "%b %e, %Y", "2000-11-03 00:00:00 -0600 CST"},
 		{"simple_with_spaces_2", "Dec  15 ,   2000", "%b %e, %Y", "2000-12-15 00:00:00 -0600 CST"},
+		{"upper_case_month_abbreviation", "JAN 3, 2000", "%b %e, %Y", "2000-01-03 00:00:00 -0600 CST"},
+		{"lower_case_month_abbreviation", "jan 3, 2000", "%b %e, %Y", "2000-01-03 00:00:00 -0600 CST"},
 		{"reverse", "2023/Feb/ 1", "%Y/%b/%e", "2023-02-01 00:00:00 -0600 CST"},
 		{"reverse_with_spaces", " 2023 /Apr/ 01  ", "%Y/%b/%e", "2023-04-01 00:00:00 -0500 CDT"},
 		{"weekday", "Thu, Aug 5, 2021", "%a, %b %e, %Y", "2021-08-05 00:00:00 -0500 CDT"},
@@ -29,6 +32,8 @@ func TestParseDate(t *testing.T) {
 		{"weekday", "Mon, Aug 9, 2021", "%a, %b %e, %Y", "2021-08-09 00:00:00 -0500 CDT"},
 		{"weekday", "Tue, Aug 10, 2021", "%a, %b %e, %Y", "2021-08-10 00:00:00 -0500 CDT"},
 		{"weekday", "Wed, Aug 11, 2021", "%a, %b %e, %Y", "2021-08-11 00:00:00 -0500 CDT"},
+		{"weekday_name", "Thursday, Aug 5, 2021", "%W, %b %e, %Y", "2021-08-05 00:00:00 -0500 CDT"},
+		{"weekday_number", "0, Aug 8, 2021", "%w, %b %e, %Y", "2021-08-08 00:00:00 -0500 CDT"},
 
 		{"time_only", "22:23:00", "%H:%i:%s", "0001-01-01 22:23:00 +0000 UTC"},
 		{"with_time", "Sep 3, 22:23:00 2000", "%b %e, %H:%i:%s %Y", "2000-09-03 22:23:00 -0500 CDT"},
@@ -48,15 +53,25 @@ func TestParseDate(t *testing.T) {
 		{"two_digit_date_2000", "september: 3, 70", "%M: %e, %y", "1970-09-03 00:00:00 -0500 CDT"},
 		{"two_digit_date_1900", "may: 3, 69", "%M: %e, %y", "2069-05-03 00:00:00 -0500 CDT"},
 
-		{"microseconds", "01/02/99 314", "%m/%e/%y %f", "1999-01-02 00:00:00.000314 -0600 CST"},
+		{"microseconds", "01/02/99 314", "%m/%e/%y %f", "1999-01-02 00:00:00.314 -0600 CST"},
+		{"microseconds_six_digits", "01/02/99 000314", "%m/%e/%y %f", "1999-01-02 00:00:00.000314 -0600 CST"},
+		{"microseconds_at_most_six_digits", "01/02/99 0500009", "%m/%e/%y %f9", "1999-01-02 00:00:00.05 -0600 CST"},
 		{"hour_number", "01/02/99 5:14", "%m/%e/%y %h:%i", "1999-01-02 05:14:00 -0600 CST"},
 		{"hour_number_2", "01/02/99 5:14", "%m/%e/%y %I:%i", "1999-01-02 05:14:00 -0600 CST"},
 
 		{"timestamp", "01/02/99 05:14:12 PM", "%m/%e/%y %r", "1999-01-02 17:14:12 -0600 CST"},
+		{"timestamp_pm", "01/02/99 07:05:09 PM", "%m/%e/%y %r", "1999-01-02 19:05:09 -0600 CST"},
+		{"timestamp_noon", "01/02/99 12:00:01 PM", "%m/%e/%y %r", "1999-01-02 12:00:01 -0600 CST"},
+		{"timestamp_midnight", "01/02/99 12:00:01 AM", "%m/%e/%y %r", "1999-01-02 00:00:01 -0600 CST"},
+		{"timestamp_single_digits", "01/02/99 1:2:3 AM", "%m/%e/%y %r", "1999-01-02 01:02:03 -0600 CST"},
+		{"timestamp_24_hour", "01/02/99 23:01:02", "%m/%e/%y %T", "1999-01-02 23:01:02 -0600 CST"},
 		{"date_with_seconds", "01/02/99 57", "%m/%e/%y %S", "1999-01-02 00:00:57 -0600 CST"},
 
 		{"date_by_year_offset", "100 20", "%j %y", "2020-04-09 00:00:00 -0500 CDT"},
 		{"date_by_year_offset_singledigit_year", "100 5", "%j %y", "2005-04-10 00:00:00 -0500 CDT"},
+		{"date_by_4_digit_year_without_separator", "2021100", "%Y%j", "2021-04-10 00:00:00 -0500 CDT"},
+		{"date_by_year_offset_leap_year", "2020 366", "%Y %j", "2020-12-31 00:00:00 -0600 CST"},
+		{"date_by_year_offset_past_end_of_year", "2021 366", "%Y %j", "2022-01-01 00:00:00 -0600 CST"},
 	}
 
 	for _, tt := range tests {
@@ -90,8 +105,12 @@ func TestConversionFailure(t *testing.T) {
 		{"day_of_month_and_day_of_year", "Jan 3, 100 2000", "%b %e, %j %y", "day is ambiguous"},
 		{"specifier_end_of_line", "Jan 3", "%b %e %", `"%" found at end of format string`},
 		{"unknown_format_specifier", "Jan 3", "%b %e %L", `unknown format specifier "L"`},
-		{"invalid_number_hour", "0021:12:14", "%T", `specifier %T failed to parse "0021:12:14": expected literal ":", got "2"`},
-		{"invalid_number_hour_2", "0012:12:14", "%r", `specifier %r failed to parse "0012:12:14": expected literal ":", got "1"`},
+		{"timestamp_without_am_pm", "07:05:09", "%r", `specifier %r failed to parse "07:05:09" at offset 0: expected > 2 chars, found 0`},
+		{"timestamp_seconds_overflow", "23:01:61", "%T", `specifier %T failed to parse "23:01:61" at offset 0: second 61 out of range`},
+		{"offset_after_date", "Jan 3, 2000 25:00:00", "%b %e, %Y %T", `specifier %T failed to parse "25:00:00" at offset 12: hour 25 out of range`},
+		{"offset_of_literal", "2000-01/03", "%Y-%m-%d", `literal - not matched in "/03" at offset 7: expected literal "-", got "/"`},
+		{"invalid_number_hour", "0021:12:14", "%T", `specifier %T failed to parse "0021:12:14" at offset 0: expected literal ":", got "2"`},
+		{"invalid_number_hour_2", "0012:12:14", "%r", `specifier %r failed to parse "0012:12:14" at offset 0: expected literal ":", got "1"`},
 	}
 
 	for _, tt := range tests {
@@ -111,16 +130,25 @@ func TestParseErr(t *testing.T) {
 		expectedError interface{}
 	}{
 		{"simple", "a", "b", ParseLiteralErr{
-			Literal: 'b', Tokens: "a", err: fmt.Errorf(`expected literal "b", got "a"`)},
+			Literal: 'b', Tokens: "a", Offset: 0, err: fmt.Errorf(`expected literal "b", got "a"`)},
 		},
 		{"bad_numeral", "abc", "%e", ParseSpecifierErr{
 			Specifier: 'e', Tokens: "abc", err: fmt.Errorf("strconv.ParseUint: parsing \"\": invalid syntax")},
 		},
 		{"bad_month", "1 Jen, 2000", "%e %b, %Y", ParseSpecifierErr{
-			Specifier: 'b', Tokens: "jen, 2000", err: fmt.Errorf(`invalid month abbreviation "jen"`)},
+			Specifier: 'b', Tokens: "jen, 2000", Offset: 2, err: fmt.Errorf(`invalid month abbreviation "jen"`)},
+		},
+		{"bad_day_after_spaces", "  Jan   x, 2000", "%b %e, %Y", ParseSpecifierErr{
+			Specifier: 'e', Tokens: "x, 2000", Offset: 6, err: fmt.Errorf("strconv.ParseUint: parsing \"\": invalid syntax")},
 		},
 		{"bad_weekday", "Ten 1 Jan, 2000", "%a %e %b, %Y", ParseSpecifierErr{
-			Specifier: 'a', Tokens: "ten 1 jan, 2000", err: fmt.Errorf(`invalid week abbreviation "ten"`)},
+			Specifier: 'a', Tokens: "ten 1 jan, 2000", Offset: 0, err: fmt.Errorf(`invalid week abbreviation "ten"`)},
+		},
+		{"bad_weekday_number", "7 1 Jan, 2000", "%w %e %b, %Y", ParseSpecifierErr{
+			Specifier: 'w', Tokens: "7 1 jan, 2000", Offset: 0, err: fmt.Errorf("expected a weekday from 0 to 6, got 7")},
+		},
+		{"bad_weekday_name", "Someday 1 Jan, 2000", "%W %e %b, %Y", ParseSpecifierErr{
+			Specifier: 'W', Tokens: "someday 1 jan, 2000", Offset: 0, err: fmt.Errorf(`unknown weekday name, got "someday 1 jan, 2000"`)},
 		},
 	}
 
@@ -133,3 +161,23 @@ func TestParseErr(t *testing.T) {
 		})
 	}
 }
+
+func TestNames(t *testing.T) {
+	require.Equal(t, "January", MonthName(time.January))
+	require.Equal(t, "Sep", MonthAbbreviation(time.September))
+	require.Equal(t, "", MonthName(time.Month(13)))
+	require.Equal(t, "Saturday", WeekdayName(time.Saturday))
+	require.Equal(t, "Thu", WeekdayAbbreviation(time.Thursday))
+	require.Equal(t, "", WeekdayAbbreviation(time.Weekday(7)))
+
+	for m := time.January; m <= time.December; m++ {
+		parsed, ok := monthAbbrev(strings.ToLower(MonthAbbreviation(m)))
+		require.True(t, ok)
+		require.Equal(t, m, parsed)
+	}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		parsed, ok := weekdayAbbrev(strings.ToLower(WeekdayAbbreviation(d)))
+		require.True(t, ok)
+		require.Equal(t, d, parsed)
+	}
+}
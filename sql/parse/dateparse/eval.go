@@ -37,8 +37,12 @@ func evaluate(dt datetime) (time.Time, error) {
 
 	var hour, minute, second, miliseconds, microseconds, nanoseconds int
 	if dt.hours != nil {
-		if *dt.hours < 13 && dt.am != nil && !*dt.am {
-			*dt.hours += 12
+		if *dt.hours < 13 && dt.am != nil {
+			// 12 AM is midnight and 12 PM is noon
+			*dt.hours %= 12
+			if !*dt.am {
+				*dt.hours += 12
+			}
 		}
 		hour = int(*dt.hours)
 	}
@@ -68,8 +72,9 @@ func evaluate(dt datetime) (time.Time, error) {
 		year = int(*dt.year)
 	}
 	if dt.dayOfYear != nil {
-		// offset from Jan 1st by the specified number of days
-		dayOffsetted := time.Date(year, time.January, 0, 0, 0, 0, 0, time.Local).AddDate(0, 0, int(*dt.dayOfYear))
+		// days past the end of the year are in the next year
+		dayOffsetted := DateFromDayOfYear(year, int(*dt.dayOfYear), time.Local)
+		year = dayOffsetted.Year()
 		month = dayOffsetted.Month()
 		day = dayOffsetted.Day()
 	} else if dt.day != nil {
@@ -87,3 +92,10 @@ func evaluate(dt datetime) (time.Time, error) {
 
 	return time.Date(year, month, day, hour, minute, second, int(nanosecondDuration), time.Local), nil
 }
+
+// DateFromDayOfYear returns midnight of the given day of a year in [loc], where day 1 is January 1st. Days past the
+// end of the year continue into the following years.
+func DateFromDayOfYear(year, dayOfYear int, loc *time.Location) time.Time {
+	// offset from Jan 1st by the specified number of days
+	return time.Date(year, time.January, 0, 0, 0, 0, 0, loc).AddDate(0, 0, dayOfYear)
+}
@@ -2,6 +2,7 @@ package dateparse
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 )
 
@@ -62,6 +63,29 @@ func parseWeedayAbbreviation(result *datetime, chars string) (rest string, _ err
 	return trimPrefix(3, chars), nil
 }
 
+func parseWeekdayName(result *datetime, chars string) (rest string, _ error) {
+	weekday, charCount, ok := weekdayName(chars)
+	if !ok {
+		return "", fmt.Errorf("unknown weekday name, got \"%s\"", chars)
+	}
+	result.weekday = &weekday
+	return trimPrefix(charCount, chars), nil
+}
+
+func parseWeekdayNumeric(result *datetime, chars string) (rest string, _ error) {
+	num, rest, err := takeNumberAtMostNChars(1, chars)
+	if err != nil {
+		return "", err
+	}
+	if num > 6 {
+		return "", fmt.Errorf("expected a weekday from 0 to 6, got %d", num)
+	}
+	// %w numbers the weekdays like time.Weekday, from 0 for Sunday
+	weekday := time.Weekday(num)
+	result.weekday = &weekday
+	return rest, nil
+}
+
 func parseMonthAbbreviation(result *datetime, chars string) (rest string, _ error) {
 	if len(chars) < 3 {
 		return "", fmt.Errorf("expected at least 3 chars, got %d", len(chars))
@@ -93,12 +117,19 @@ func parseDayOfMonthNumeric(result *datetime, chars string) (rest string, _ erro
 	return rest, nil
 }
 
+// parseMicrosecondsNumeric parses up to six digits of a fraction of a second, which are left-aligned like the digits
+// after a decimal point, so "5" is 500000 microseconds.
 func parseMicrosecondsNumeric(result *datetime, chars string) (rest string, _ error) {
-	num, rest, err := takeNumber(chars)
+	numChars, rest := takeAtMost(6, chars, isNumeral)
+	num, err := strconv.ParseUint(numChars, 10, 32)
 	if err != nil {
 		return "", err
 	}
-	result.microseconds = &num
+	for i := len(numChars); i < 6; i++ {
+		num *= 10
+	}
+	micros := uint(num)
+	result.microseconds = &micros
 	return rest, nil
 }
 
@@ -159,6 +190,9 @@ func parse12HourTimestamp(result *datetime, chars string) (rest string, _ error)
 	if err != nil {
 		return "", err
 	}
+	if err = validateTimeComponents(hour, min, sec, 12); err != nil {
+		return "", err
+	}
 	rest = takeAllSpaces(rest)
 	rest, err = parseAmPm(result, rest)
 	if err != nil {
@@ -200,12 +234,30 @@ func parse24HourTimestamp(result *datetime, chars string) (rest string, _ error)
 	if err != nil {
 		return "", err
 	}
+	if err = validateTimeComponents(hour, minute, seconds, 23); err != nil {
+		return "", err
+	}
 	result.hours = &hour
 	result.minutes = &minute
 	result.seconds = &seconds
 	return rest, err
 }
 
+// validateTimeComponents returns an error if the hour of a timestamp is greater than [maxHour], or if its minutes or
+// seconds are greater than 59.
+func validateTimeComponents(hour, minute, second, maxHour uint) error {
+	if hour > maxHour {
+		return fmt.Errorf("hour %d out of range", hour)
+	}
+	if minute > 59 {
+		return fmt.Errorf("minute %d out of range", minute)
+	}
+	if second > 59 {
+		return fmt.Errorf("second %d out of range", second)
+	}
+	return nil
+}
+
 func parseYear2DigitNumeric(result *datetime, chars string) (rest string, _ error) {
 	year, rest, err := takeNumberAtMostNChars(2, chars)
 	if err != nil {
@@ -224,7 +276,7 @@ func parseYear4DigitNumeric(result *datetime, chars string) (rest string, _ erro
 	if len(chars) < 4 {
 		return "", fmt.Errorf("expected at least 4 chars, got %d", len(chars))
 	}
-	year, rest, err := takeNumber(chars)
+	year, rest, err := takeNumberAtMostNChars(4, chars)
 	if err != nil {
 		return "", err
 	}
@@ -237,8 +289,13 @@ func parseDayNumericWithEnglishSuffix(result *datetime, chars string) (rest stri
 	if err != nil {
 		return "", err
 	}
+	// The suffix must be the one of the day, like "1st" or "11th"
+	suffix := DaySuffix(int(num))
+	if !hasPrefixFold(rest, suffix) {
+		return "", fmt.Errorf("expected day suffix %q after %d, got %q", suffix, num, rest)
+	}
 	result.day = &num
-	return trimPrefix(2, rest), nil
+	return trimPrefix(len(suffix), rest), nil
 }
 
 func parseDayOfYearNumeric(result *datetime, chars string) (rest string, _ error) {
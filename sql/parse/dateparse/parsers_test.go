@@ -2,6 +2,7 @@ package dateparse
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -17,6 +18,37 @@ func TestParsers(t *testing.T) {
 		{"24_timestamp", "13:12:15", parse24HourTimestamp, "",
 			datetime{hours: uintPtr(13), minutes: uintPtr(12), seconds: uintPtr(15)},
 		},
+		{"24_timestamp_single_digits", "1:2:3", parse24HourTimestamp, "",
+			datetime{hours: uintPtr(1), minutes: uintPtr(2), seconds: uintPtr(3)},
+		},
+		{"24_timestamp_late", "23:01:02", parse24HourTimestamp, "",
+			datetime{hours: uintPtr(23), minutes: uintPtr(1), seconds: uintPtr(2)},
+		},
+		{"12_timestamp", "07:05:09 pm", parse12HourTimestamp, "",
+			datetime{hours: uintPtr(7), minutes: uintPtr(5), seconds: uintPtr(9), am: boolPtr(false)},
+		},
+		{"12_timestamp_single_digits", "1:2:3 am", parse12HourTimestamp, "",
+			datetime{hours: uintPtr(1), minutes: uintPtr(2), seconds: uintPtr(3), am: boolPtr(true)},
+		},
+		{"2_digit_year", "21", parseYear2DigitNumeric, "", datetime{year: uintPtr(2021)}},
+		{"2_digit_year_stops_after_2_digits", "2021", parseYear2DigitNumeric, "21", datetime{year: uintPtr(2020)}},
+		{"2_digit_year_single_digit", "5/", parseYear2DigitNumeric, "/", datetime{year: uintPtr(2005)}},
+		{"4_digit_year", "2021", parseYear4DigitNumeric, "", datetime{year: uintPtr(2021)}},
+		{"4_digit_year_leading_zeros", "0099", parseYear4DigitNumeric, "", datetime{year: uintPtr(99)}},
+		{"4_digit_year_stops_after_4_digits", "20210315", parseYear4DigitNumeric, "0315", datetime{year: uintPtr(2021)}},
+		{"4_digit_year_stops_at_non_digit", "2021-03", parseYear4DigitNumeric, "-03", datetime{year: uintPtr(2021)}},
+		{"month_abbreviation_upper_case", "JAN 3", parseMonthAbbreviation, " 3", datetime{month: monthPtr(time.January)}},
+		{"month_abbreviation_lower_case", "jan 3", parseMonthAbbreviation, " 3", datetime{month: monthPtr(time.January)}},
+		{"month_abbreviation_title_case", "Jan 3", parseMonthAbbreviation, " 3", datetime{month: monthPtr(time.January)}},
+		{"month_name_upper_case", "JANUARY 3", parseMonthName, " 3", datetime{month: monthPtr(time.January)}},
+		{"month_name_mixed_case", "jAnUaRy", parseMonthName, "", datetime{month: monthPtr(time.January)}},
+		{"weekday_abbreviation_upper_case", "THU,", parseWeedayAbbreviation, ",", datetime{weekday: weekdayPtr(time.Thursday)}},
+		{"day_with_suffix_1st", "1st", parseDayNumericWithEnglishSuffix, "", datetime{day: uintPtr(1)}},
+		{"day_with_suffix_2nd", "2nd", parseDayNumericWithEnglishSuffix, "", datetime{day: uintPtr(2)}},
+		{"day_with_suffix_3rd", "3rd", parseDayNumericWithEnglishSuffix, "", datetime{day: uintPtr(3)}},
+		{"day_with_suffix_11th", "11th", parseDayNumericWithEnglishSuffix, "", datetime{day: uintPtr(11)}},
+		{"day_with_suffix_21st", "21st, 2000", parseDayNumericWithEnglishSuffix, ", 2000", datetime{day: uintPtr(21)}},
+		{"weekday_name_title_case", "Thursday,", parseWeekdayName, ",", datetime{weekday: weekdayPtr(time.Thursday)}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -37,6 +69,17 @@ func TestParserErr(t *testing.T) {
 		expectedErr string
 	}{
 		{"24_timestamp", "13:12", parse24HourTimestamp, `expected literal ":", found empty string`},
+		{"24_timestamp_hour_overflow", "24:00:00", parse24HourTimestamp, "hour 24 out of range"},
+		{"24_timestamp_minute_overflow", "23:60:00", parse24HourTimestamp, "minute 60 out of range"},
+		{"24_timestamp_second_overflow", "23:01:60", parse24HourTimestamp, "second 60 out of range"},
+		{"12_timestamp_no_am_pm", "07:05:09", parse12HourTimestamp, "expected > 2 chars, found 0"},
+		{"12_timestamp_bad_am_pm", "07:05:09 xm", parse12HourTimestamp, `expected AM or PM, got "xm"`},
+		{"12_timestamp_hour_overflow", "13:05:09 pm", parse12HourTimestamp, "hour 13 out of range"},
+		{"12_timestamp_second_overflow", "07:05:75 pm", parse12HourTimestamp, "second 75 out of range"},
+		{"day_with_wrong_suffix", "1th", parseDayNumericWithEnglishSuffix, `expected day suffix "st" after 1, got "th"`},
+		{"day_with_wrong_suffix_teen", "12nd", parseDayNumericWithEnglishSuffix, `expected day suffix "th" after 12, got "nd"`},
+		{"day_without_suffix", "3", parseDayNumericWithEnglishSuffix, `expected day suffix "rd" after 3, got ""`},
+		{"4_digit_year_too_short", "202", parseYear4DigitNumeric, "expected at least 4 chars, got 3"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -49,3 +92,7 @@ func TestParserErr(t *testing.T) {
 }
 
 func uintPtr(u uint) *uint { return &u }
+
+func monthPtr(m time.Month) *time.Month { return &m }
+
+func weekdayPtr(d time.Weekday) *time.Weekday { return &d }
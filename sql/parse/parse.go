@@ -15,9 +15,11 @@
 package parse
 
 import (
+	"context"
 	"encoding/hex"
 	goerrors "errors"
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
 	"time"
@@ -113,12 +115,20 @@ func parse(ctx *sql.Context, query string, multi bool) (sql.Node, string, string
 	var remainder string
 
 	parsed = s
+	withLateral, lateral := rewriteLateralDerivedTables(rewriteCreateTrigger(s))
+	withUnits, extractCalls := rewriteExtractUnits(withLateral)
+	withArrows, calls := replaceNamedArgumentArrows(withUnits)
+	withColumns, jsonTables := rewriteJSONTableColumns(withArrows)
+	rewritten, windows := rewriteGroupConcatWindows(withColumns)
 	if !multi {
-		stmt, err = sqlparser.Parse(s)
+		stmt, err = sqlparser.Parse(rewritten)
 	} else {
 		var ri int
-		stmt, ri, err = sqlparser.ParseOne(s)
+		stmt, ri, err = sqlparser.ParseOne(rewritten)
 		if ri != 0 && ri < len(s) {
+			lateral = derivedTablesBefore(lateral, ri)
+			jsonTables = jsonTableCallsBefore(jsonTables, ri)
+			windows = groupConcatWindowsBefore(windows, ri)
 			parsed = s[:ri]
 			parsed = strings.TrimSpace(parsed)
 			if strings.HasSuffix(parsed, ";") {
@@ -136,7 +146,23 @@ func parse(ctx *sql.Context, query string, multi bool) (sql.Node, string, string
 		return nil, parsed, remainder, sql.ErrSyntaxError.New(err.Error())
 	}
 
-	node, err := convert(ctx, stmt, s)
+	syntax := &rewrittenSyntax{}
+	if syntax.namedArguments, err = findNamedArguments(stmt, calls); err != nil {
+		return nil, parsed, remainder, err
+	}
+	syntax.extractCalls = findExtractCalls(stmt, extractCalls)
+	if syntax.lateral, err = findLateralDerivedTables(stmt, lateral); err != nil {
+		return nil, parsed, remainder, err
+	}
+	if syntax.jsonTables, err = findJSONTableCalls(stmt, jsonTables); err != nil {
+		return nil, parsed, remainder, err
+	}
+	if syntax.groupConcatWindows, err = findGroupConcatWindows(stmt, windows); err != nil {
+		return nil, parsed, remainder, err
+	}
+	restoreInputExpressions(stmt, s, rewritten)
+
+	node, err := convert(withRewrittenSyntax(ctx, syntax), stmt, s)
 
 	return node, parsed, remainder, err
 }
@@ -226,6 +252,8 @@ func convert(ctx *sql.Context, stmt sqlparser.Statement, query string) (sql.Node
 		return convertKill(ctx, n)
 	case *sqlparser.Signal:
 		return convertSignal(ctx, n)
+	case *sqlparser.Resignal:
+		return convertResignal(ctx, n)
 	case *sqlparser.LockTables:
 		return convertLockTables(ctx, n)
 	case *sqlparser.UnlockTables:
@@ -1002,20 +1030,194 @@ func convertCreateTrigger(ctx *sql.Context, query string, c *sqlparser.DDL) (sql
 		return nil, err
 	}
 
+	// triggers run under the sql_mode of the session that created them, not the one that fires them
+	sqlMode, err := getSqlMode(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clauses, _ := scanCreateTrigger(query)
+
 	return plan.NewCreateTrigger(
 		sql.UnresolvedDatabase(c.TriggerSpec.TrigName.Qualifier.String()),
 		c.TriggerSpec.TrigName.Name.String(),
 		c.TriggerSpec.Time,
 		c.TriggerSpec.Event,
+		clauses.forEach,
 		triggerOrder,
 		tableNameToUnresolvedTable(c.Table),
 		body,
 		query,
 		bodyStr,
 		ctx.QueryTime(),
+		getCurrentUserForDefiner(ctx, clauses.definerUser, clauses.definerHost),
+		sqlMode,
+		clauses.ifNotExistsPos != nil,
 	), nil
 }
 
+// getSqlMode returns the sql_mode of the current session, with its modes in the order of the sql_mode set, since the
+// default value of the session variable isn't.
+func getSqlMode(ctx *sql.Context) (string, error) {
+	sqlMode, err := ctx.GetSessionVariable(ctx, "sql_mode")
+	if err != nil {
+		return "", err
+	}
+	sysVar, _, ok := sql.SystemVariables.GetGlobal("sql_mode")
+	if !ok {
+		return "", sql.ErrUnknownSystemVariable.New("sql_mode")
+	}
+	sqlMode, err = sysVar.Type.Convert(sqlMode)
+	if err != nil {
+		return "", err
+	}
+	return sqlMode.(string), nil
+}
+
+// createTriggerClauses are the clauses of a CREATE TRIGGER statement that the SQL parser accepts but doesn't return, or
+// doesn't accept at all. Positions are start and end offsets in the statement, or nil if it doesn't have the clause.
+type createTriggerClauses struct {
+	definerUser    string
+	definerHost    string
+	definerPos     []int
+	ifNotExistsPos []int
+	forEach        string
+	forEachPos     []int
+}
+
+// scanCreateTrigger returns the clauses of the CREATE TRIGGER statement given, or false if the statement isn't a CREATE
+// TRIGGER statement. The statement is read with the SQL tokenizer, so that keywords in comments, strings and quoted
+// identifiers aren't mistaken for clauses. The FOR EACH keyword is "row" if the statement doesn't have one. The definer
+// user is empty for CURRENT_USER, and its host is % if the definer doesn't have one, so that a quoted `bob@localhost` is
+// the user bob@localhost at any host, as in MySQL. The position of the definer is only set when it isn't a single
+// identifier.
+func scanCreateTrigger(query string) (createTriggerClauses, bool) {
+	clauses := createTriggerClauses{forEach: string(plan.RowTrigger)}
+	tokenizer := sqlparser.NewStringTokenizer(query)
+	// next returns the next token that isn't a comment, with the position of its unquoted text, or nil if the token is
+	// quoted.
+	next := func() (int, string, []int) {
+		for {
+			typ, val := tokenizer.Scan()
+			if typ == sqlparser.COMMENT {
+				continue
+			}
+			end := tokenizer.Position - 1
+			start := end - len(val)
+			if start < 0 || !strings.EqualFold(query[start:end], string(val)) {
+				return typ, string(val), nil
+			}
+			return typ, string(val), []int{start, end}
+		}
+	}
+
+	if typ, _, _ := next(); typ != sqlparser.CREATE {
+		return clauses, false
+	}
+	typ, val, pos := next()
+	if typ == sqlparser.DEFINER {
+		if typ, _, _ = next(); typ != '=' {
+			return clauses, false
+		}
+		start := tokenizer.Position - 1
+		typ, val, _ = next()
+		userTyp, user := typ, val
+		end := tokenizer.Position - 1
+		typ, val, pos = next()
+		switch {
+		case userTyp == sqlparser.CURRENT_USER:
+			if typ == '(' {
+				if typ, _, _ = next(); typ != ')' {
+					return clauses, false
+				}
+				end = tokenizer.Position - 1
+				typ, val, pos = next()
+			}
+			clauses.definerPos = []int{start, end}
+		case typ == '@':
+			_, host, _ := next()
+			clauses.definerUser, clauses.definerHost = user, host
+			clauses.definerPos = []int{start, tokenizer.Position - 1}
+			typ, val, pos = next()
+		default:
+			clauses.definerUser, clauses.definerHost = user, "%"
+			if userTyp != sqlparser.ID {
+				clauses.definerPos = []int{start, end}
+			}
+		}
+	}
+	if typ != sqlparser.TRIGGER {
+		return clauses, false
+	}
+
+	typ, val, pos = next()
+	if typ == sqlparser.IF && pos != nil {
+		start := pos[0]
+		if typ, _, _ = next(); typ != sqlparser.NOT {
+			return clauses, true
+		}
+		if typ, _, pos = next(); typ != sqlparser.EXISTS || pos == nil {
+			return clauses, true
+		}
+		clauses.ifNotExistsPos = []int{start, pos[1]}
+		typ, val, pos = next()
+	}
+
+	for typ != 0 && typ != ';' {
+		if typ != sqlparser.FOR {
+			typ, val, pos = next()
+			continue
+		}
+		if typ, val, pos = next(); typ != sqlparser.EACH {
+			continue
+		}
+		_, val, pos = next()
+		clauses.forEach = strings.ToLower(val)
+		clauses.forEachPos = pos
+		break
+	}
+	return clauses, true
+}
+
+// rewriteCreateTrigger rewrites the clauses of a CREATE TRIGGER statement that the SQL parser doesn't know about: a
+// definer that isn't a single identifier is replaced with one, the IF NOT EXISTS clause is blanked out, and FOR EACH
+// STATEMENT becomes FOR EACH ROW. The clauses are read back from the
+// original statement by convertCreateTrigger. The rewritten query has the same length as the original, so positions
+// in it still match the original text.
+func rewriteCreateTrigger(query string) string {
+	clauses, ok := scanCreateTrigger(query)
+	if !ok {
+		return query
+	}
+	if pos := clauses.definerPos; pos != nil {
+		query = query[:pos[0]] + fmt.Sprintf("%-*s", pos[1]-pos[0], "x") + query[pos[1]:]
+	}
+	if pos := clauses.ifNotExistsPos; pos != nil {
+		query = query[:pos[0]] + strings.Repeat(" ", pos[1]-pos[0]) + query[pos[1]:]
+	}
+	if pos := clauses.forEachPos; pos != nil && clauses.forEach == string(plan.StatementTrigger) {
+		query = query[:pos[0]] + fmt.Sprintf("%-*s", pos[1]-pos[0], plan.RowTrigger) + query[pos[1]:]
+	}
+	return query
+}
+
+// getCurrentUserForDefiner returns the definer user and host given in user@host form, or the user of the current
+// session if the definer user is empty.
+func getCurrentUserForDefiner(ctx *sql.Context, user, host string) string {
+	if user != "" {
+		return fmt.Sprintf("%s@%s", user, host)
+	}
+	client := ctx.Session.Client()
+	if client.User == "" {
+		return ""
+	}
+	host = client.Address
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return fmt.Sprintf("%s@%s", client.User, host)
+}
+
 func convertCreateProcedure(ctx *sql.Context, query string, c *sqlparser.DDL) (sql.Node, error) {
 	var params []plan.ProcedureParam
 	for _, param := range c.ProcedureSpec.Params {
@@ -1134,10 +1336,53 @@ func convertDeclareCondition(ctx *sql.Context, d *sqlparser.Declare) (sql.Node,
 }
 
 func convertSignal(ctx *sql.Context, s *sqlparser.Signal) (sql.Node, error) {
+	signalInfo, err := convertSignalInfo(s.Info)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.ConditionName != "" {
+		return plan.NewSignalName(strings.ToLower(s.ConditionName), signalInfo), nil
+	} else {
+		if err := validateSignalSqlState(s.SqlStateValue); err != nil {
+			return nil, err
+		}
+		return plan.NewSignal(s.SqlStateValue, signalInfo), nil
+	}
+}
+
+func convertResignal(ctx *sql.Context, r *sqlparser.Resignal) (sql.Node, error) {
+	signalInfo, err := convertSignalInfo(r.Info)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unlike SIGNAL, RESIGNAL may keep the SQLSTATE of the condition it passes on
+	if r.SqlStateValue != "" {
+		if err := validateSignalSqlState(r.SqlStateValue); err != nil {
+			return nil, err
+		}
+	}
+	return plan.NewResignal(r.SqlStateValue, strings.ToLower(r.ConditionName), signalInfo), nil
+}
+
+// validateSignalSqlState returns an error if the SQLSTATE given can't be signaled.
+func validateSignalSqlState(sqlstate string) error {
+	if len(sqlstate) != 5 {
+		return fmt.Errorf("SQLSTATE VALUE must be a string with length 5 consisting of only integers")
+	}
+	if sqlstate[0:2] == "00" {
+		return fmt.Errorf("invalid SQLSTATE VALUE: '%s'", sqlstate)
+	}
+	return nil
+}
+
+// convertSignalInfo converts the condition information items of a SIGNAL or RESIGNAL statement.
+func convertSignalInfo(infos []sqlparser.SignalInfo) (map[plan.SignalConditionItemName]plan.SignalInfo, error) {
 	// https://dev.mysql.com/doc/refman/8.0/en/signal.html#signal-condition-information-items
 	var err error
 	signalInfo := make(map[plan.SignalConditionItemName]plan.SignalInfo)
-	for _, info := range s.Info {
+	for _, info := range infos {
 		si := plan.SignalInfo{}
 		si.ConditionItemName, err = convertSignalConditionItemName(info.ConditionItemName)
 		if err != nil {
@@ -1169,18 +1414,7 @@ func convertSignal(ctx *sql.Context, s *sqlparser.Signal) (sql.Node, error) {
 		}
 		signalInfo[si.ConditionItemName] = si
 	}
-
-	if s.ConditionName != "" {
-		return plan.NewSignalName(strings.ToLower(s.ConditionName), signalInfo), nil
-	} else {
-		if len(s.SqlStateValue) != 5 {
-			return nil, fmt.Errorf("SQLSTATE VALUE must be a string with length 5 consisting of only integers")
-		}
-		if s.SqlStateValue[0:2] == "00" {
-			return nil, fmt.Errorf("invalid SQLSTATE VALUE: '%s'", s.SqlStateValue)
-		}
-		return plan.NewSignal(s.SqlStateValue, signalInfo), nil
-	}
+	return signalInfo, nil
 }
 
 func convertLockTables(ctx *sql.Context, s *sqlparser.LockTables) (sql.Node, error) {
@@ -2442,6 +2676,10 @@ func tableExprToTable(
 				sq = sq.WithColumns(columns)
 			}
 
+			if rewrittenSyntaxFromContext(ctx).lateral[t] {
+				sq = sq.WithLateral(true)
+			}
+
 			return sq, nil
 		case *sqlparser.ValuesStatement:
 			if t.As.IsEmpty() {
@@ -2466,12 +2704,10 @@ func tableExprToTable(
 		}
 
 	case *sqlparser.TableFuncExpr:
-		exprs, err := selectExprsToExpressions(ctx, t.Exprs)
-		if err != nil {
-			return nil, err
+		if strings.EqualFold(t.Name, "json_table") {
+			return jsonTableToUnresolvedTableFunction(ctx, t)
 		}
-
-		return expression.NewUnresolvedTableFunction(t.Name, exprs), nil
+		return tableFuncExprToUnresolvedTableFunction(ctx, t)
 
 	case *sqlparser.JoinTableExpr:
 		// TODO: add support for using, once we have proper table
@@ -2661,7 +2897,7 @@ func isAggregateExpr(e sql.Expression) bool {
 		switch e := e.(type) {
 		case *expression.UnresolvedFunction:
 			isAgg = isAgg || e.IsAggregate
-		case *aggregation.CountDistinct, *aggregation.GroupConcat:
+		case *aggregation.GroupConcat:
 			isAgg = true
 		}
 
@@ -2737,8 +2973,14 @@ func selectToSelectionNode(
 func isWindowExpr(e sql.Expression) bool {
 	isWindow := false
 	sql.Inspect(e, func(e sql.Expression) bool {
-		if uf, ok := e.(*expression.UnresolvedFunction); ok {
-			if uf.Window != nil {
+		switch e := e.(type) {
+		case *expression.UnresolvedFunction:
+			if e.Window != nil {
+				isWindow = true
+				return false
+			}
+		case *aggregation.GroupConcat:
+			if e.Window() != nil {
 				isWindow = true
 				return false
 			}
@@ -2749,6 +2991,840 @@ func isWindowExpr(e sql.Expression) bool {
 	return isWindow
 }
 
+// tableFuncExprToUnresolvedTableFunction converts the arguments of a table function call, where arguments given with
+// the `name => value` syntax (see findNamedArguments) give the argument called name.
+func tableFuncExprToUnresolvedTableFunction(ctx *sql.Context, t *sqlparser.TableFuncExpr) (sql.Node, error) {
+	names := rewrittenSyntaxFromContext(ctx).namedArguments[t]
+	exprs := make([]sql.Expression, len(t.Exprs))
+	for i, se := range t.Exprs {
+		if names != nil && names[i] != "" {
+			// The SQL parser returns the argument as a `name = value` comparison
+			expr, err := ExprToExpression(ctx, se.(*sqlparser.AliasedExpr).Expr.(*sqlparser.ComparisonExpr).Right)
+			if err != nil {
+				return nil, err
+			}
+			exprs[i] = expr
+			continue
+		}
+
+		expr, err := selectExprToExpression(ctx, se)
+		if err != nil {
+			return nil, err
+		}
+		exprs[i] = expr
+	}
+
+	if names == nil {
+		return expression.NewUnresolvedTableFunction(t.Name, exprs), nil
+	}
+	return expression.NewUnresolvedTableFunctionWithNames(t.Name, exprs, names), nil
+}
+
+// namedArgumentsCall is a table function call with arguments given by name. Ordinal is the position of the call among
+// the calls to table functions with the same name in the query, and Named has whether each argument is given by name.
+type namedArgumentsCall struct {
+	Name    string
+	Ordinal int
+	Named   []bool
+}
+
+// replaceNamedArgumentArrows rewrites the `name => value` syntax for the named arguments of table functions, which the
+// SQL parser doesn't know about, to `name = value`, and returns the calls it rewrote so that findNamedArguments
+// can tell these arguments apart from comparisons. The query is read with the SQL tokenizer, and only arrows that
+// start an argument of a table function in a FROM clause are rewritten, so that the arrow is still a syntax error
+// anywhere else. The rewritten query has the same length as the original, so positions in it still match the
+// original text.
+func replaceNamedArgumentArrows(query string) (string, []namedArgumentsCall) {
+	if !strings.Contains(query, "=>") {
+		return query, nil
+	}
+
+	// parens is a level of parentheses. For the arguments of a table function, call is the call and args the number of
+	// tokens in its current argument so far.
+	type parens struct {
+		inFrom bool
+		call   *namedArgumentsCall
+		args   int
+	}
+
+	b := []byte(query)
+	stack := []*parens{{}}
+	ordinals := make(map[string]int)
+	var calls []namedArgumentsCall
+	var prev, prev2 int
+	var prevVal string
+	var prevPos int
+	tokenizer := sqlparser.NewStringTokenizer(query)
+	for {
+		typ, val := tokenizer.Scan()
+		if typ == 0 || typ == sqlparser.LEX_ERROR {
+			break
+		}
+		if typ == sqlparser.COMMENT {
+			continue
+		}
+		// The tokenizer has read one character past single character tokens
+		pos := tokenizer.Position - 2
+
+		top := stack[len(stack)-1]
+		top.args++
+		switch typ {
+		case sqlparser.FROM:
+			top.inFrom = true
+		case sqlparser.WHERE, sqlparser.GROUP, sqlparser.HAVING, sqlparser.ORDER, sqlparser.LIMIT, sqlparser.ON,
+			sqlparser.USING, sqlparser.UNION, sqlparser.WINDOW, sqlparser.INTO:
+			top.inFrom = false
+		case '(':
+			p := &parens{}
+			if prev == sqlparser.ID && (prev2 == sqlparser.FROM || prev2 == sqlparser.JOIN ||
+				prev2 == sqlparser.STRAIGHT_JOIN || (prev2 == ',' && top.inFrom)) {
+				name := strings.ToLower(prevVal)
+				p.call = &namedArgumentsCall{Name: name, Ordinal: ordinals[name], Named: []bool{false}}
+				ordinals[name]++
+			}
+			stack = append(stack, p)
+		case ')':
+			if len(stack) > 1 {
+				if call := top.call; call != nil {
+					for _, named := range call.Named {
+						if named {
+							calls = append(calls, *call)
+							break
+						}
+					}
+				}
+				stack = stack[:len(stack)-1]
+			}
+		case ',':
+			if top.call != nil {
+				top.call.Named = append(top.call.Named, false)
+				top.args = 0
+			}
+		case '>':
+			// The arrow is read as separate = and > tokens, and the name may be a keyword
+			isName := prev2 == sqlparser.ID || sqlparser.KeywordString(prev2) != ""
+			if top.call != nil && top.args == 3 && prev == '=' && isName && prevPos == pos-1 &&
+				pos < len(b) && b[pos] == '>' && b[pos-1] == '=' {
+				b[pos] = ' '
+				top.call.Named[len(top.call.Named)-1] = true
+			}
+		}
+
+		prev, prev2 = typ, prev
+		prevVal, prevPos = string(val), pos
+	}
+
+	return string(b), calls
+}
+
+// findNamedArguments returns the names of the arguments of the table function calls given that were rewritten by
+// replaceNamedArgumentArrows as `name = value`, keyed by the calls in the statement. The calls in the statement are
+// matched with the calls given in the order they appear in the query, which is the order they are walked in. Since the
+// arrow became an equals sign, a value with operators that bind less tightly, like `start => x > 1`, isn't parsed as a
+// `name = value` comparison, and is a syntax error unless it is parenthesized.
+func findNamedArguments(stmt sqlparser.Statement, calls []namedArgumentsCall) (map[*sqlparser.TableFuncExpr][]string, error) {
+	if len(calls) == 0 {
+		return nil, nil
+	}
+
+	named := make(map[string]map[int][]bool)
+	for _, call := range calls {
+		if named[call.Name] == nil {
+			named[call.Name] = make(map[int][]bool)
+		}
+		named[call.Name][call.Ordinal] = call.Named
+	}
+
+	found := make(map[*sqlparser.TableFuncExpr][]string)
+	ordinals := make(map[string]int)
+	err := walkStatement(func(node sqlparser.SQLNode) (bool, error) {
+		t, ok := node.(*sqlparser.TableFuncExpr)
+		if !ok {
+			return true, nil
+		}
+		name := strings.ToLower(t.Name)
+		isNamed := named[name][ordinals[name]]
+		ordinals[name]++
+		if isNamed == nil {
+			return true, nil
+		}
+
+		names, err := namedArguments(t, isNamed)
+		if err != nil {
+			return false, err
+		}
+		found[t] = names
+		return true, nil
+	}, stmt)
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// namedArguments returns the names of the arguments of the table function call given that |named| says are given by
+// name, which the SQL parser returns as `name = value` comparisons, with an empty name for the other arguments.
+func namedArguments(t *sqlparser.TableFuncExpr, named []bool) ([]string, error) {
+	if len(named) != len(t.Exprs) {
+		return nil, sql.ErrSyntaxError.New(fmt.Sprintf("invalid named arguments of %s", t.Name))
+	}
+
+	names := make([]string, len(t.Exprs))
+	for i, se := range t.Exprs {
+		if !named[i] {
+			continue
+		}
+		if ae, ok := se.(*sqlparser.AliasedExpr); ok && ae.As.IsEmpty() {
+			cmp, ok := ae.Expr.(*sqlparser.ComparisonExpr)
+			if ok && cmp.Operator == sqlparser.EqualStr {
+				if col, ok := cmp.Left.(*sqlparser.ColName); ok && col.Qualifier.IsEmpty() {
+					names[i] = col.Name.String()
+					continue
+				}
+			}
+		}
+		return nil, sql.ErrSyntaxError.New(fmt.Sprintf("the value of a named argument of %s must be parenthesized "+
+			"when it has comparison or logical operators", t.Name))
+	}
+	return names, nil
+}
+
+// jsonTableCall is a call to JSON_TABLE found by rewriteJSONTableColumns, with the offset of its opening parenthesis,
+// the text inside its COLUMNS clause and its alias. Columns is empty if the call has no COLUMNS clause.
+type jsonTableCall struct {
+	pos     int
+	columns string
+	alias   string
+}
+
+// jsonTableCallsBefore returns the calls to JSON_TABLE given that start before the offset given.
+func jsonTableCallsBefore(calls []jsonTableCall, end int) []jsonTableCall {
+	for i, c := range calls {
+		if c.pos >= end {
+			return calls[:i]
+		}
+	}
+	return calls
+}
+
+// rewriteJSONTableColumns removes the syntax of JSON_TABLE that the SQL parser doesn't know about, the COLUMNS clause
+// after its path and the alias after the call, and returns the calls to JSON_TABLE in the query in the order they
+// appear, with the text that was removed, for findJSONTableCalls. A call is a table function call that starts a table
+// reference, after FROM, a join or a comma of a FROM clause. The rewritten query has the same length as the original.
+func rewriteJSONTableColumns(query string) (string, []jsonTableCall) {
+	if !strings.Contains(strings.ToLower(query), "json_table") {
+		return query, nil
+	}
+
+	// parens is a level of parentheses. For the arguments of a call to JSON_TABLE, call is the call, and for its
+	// COLUMNS clause, columnsStart is the offset of the COLUMNS keyword and columnsEnd the offset after the opening
+	// parenthesis.
+	type parens struct {
+		inFrom                   bool
+		call                     *jsonTableCall
+		columnsStart, columnsEnd int
+	}
+
+	b := []byte(query)
+	stack := []*parens{{}}
+	var calls []jsonTableCall
+	var prev, prev2 int
+	var prevVal string
+	var prevStart int
+	// aliasStart is the offset after the closing parenthesis of the last call, while its alias may follow
+	aliasStart := -1
+	for tokenizer := sqlparser.NewStringTokenizer(query); ; {
+		typ, val := tokenizer.Scan()
+		if typ == 0 || typ == sqlparser.LEX_ERROR {
+			break
+		}
+		if typ == sqlparser.COMMENT {
+			continue
+		}
+		// The tokenizer has read one character past the token
+		end := tokenizer.Position - 1
+		start := end - 1
+		if len(val) > 0 {
+			start = end - len(val)
+		}
+
+		if aliasStart >= 0 {
+			switch {
+			case typ == sqlparser.AS && prev == ')':
+			case typ == sqlparser.ID:
+				calls[len(calls)-1].alias = string(val)
+				copy(b[aliasStart:end], strings.Repeat(" ", end-aliasStart))
+				aliasStart = -1
+			default:
+				aliasStart = -1
+			}
+		}
+
+		top := stack[len(stack)-1]
+		switch typ {
+		case sqlparser.FROM:
+			top.inFrom = true
+		case sqlparser.SELECT, sqlparser.WHERE, sqlparser.GROUP, sqlparser.HAVING, sqlparser.WINDOW, sqlparser.ORDER,
+			sqlparser.LIMIT, sqlparser.UNION, sqlparser.ON, sqlparser.USING, sqlparser.SET:
+			top.inFrom = false
+		case '(':
+			p := &parens{columnsStart: -1}
+			if top.call != nil && prev == sqlparser.COLUMNS && top.call.columns == "" {
+				p.columnsStart, p.columnsEnd = prevStart, end
+			} else if prev == sqlparser.ID && strings.EqualFold(prevVal, "json_table") && (prev2 == sqlparser.FROM ||
+				prev2 == sqlparser.JOIN || prev2 == sqlparser.STRAIGHT_JOIN || (prev2 == ',' && top.inFrom)) {
+				p.call = &jsonTableCall{pos: start}
+			}
+			stack = append(stack, p)
+		case ')':
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+				if top.columnsStart >= 0 {
+					stack[len(stack)-1].call.columns = strings.TrimSpace(query[top.columnsEnd:start])
+					copy(b[top.columnsStart:end], strings.Repeat(" ", end-top.columnsStart))
+				}
+				if top.call != nil {
+					calls = append(calls, *top.call)
+					aliasStart = end
+				}
+			}
+		}
+
+		prev, prev2 = typ, prev
+		prevVal, prevStart = string(val), start
+	}
+
+	return string(b), calls
+}
+
+// findJSONTableCalls returns the COLUMNS clauses and aliases of the calls to JSON_TABLE in the statement given, which
+// were removed by rewriteJSONTableColumns. The calls in the statement are matched with the calls given in the order
+// they appear in the query, which is the order they are walked in. Returns an error when the calls don't match.
+func findJSONTableCalls(stmt sqlparser.Statement, calls []jsonTableCall) (map[*sqlparser.TableFuncExpr]jsonTableCall, error) {
+	if len(calls) == 0 {
+		return nil, nil
+	}
+
+	found := make(map[*sqlparser.TableFuncExpr]jsonTableCall)
+	i := 0
+	_ = walkStatement(func(node sqlparser.SQLNode) (bool, error) {
+		if t, ok := node.(*sqlparser.TableFuncExpr); ok && strings.EqualFold(t.Name, "json_table") {
+			if i < len(calls) {
+				found[t] = calls[i]
+			}
+			i++
+		}
+		return true, nil
+	}, stmt)
+
+	if i != len(calls) {
+		return nil, sql.ErrUnsupportedSyntax.New("JSON_TABLE")
+	}
+	return found, nil
+}
+
+// jsonTableToUnresolvedTableFunction converts a call to JSON_TABLE, whose COLUMNS clause and alias were recorded by
+// findJSONTableCalls. The text inside the COLUMNS clause is given to the table function as its last argument.
+func jsonTableToUnresolvedTableFunction(ctx *sql.Context, t *sqlparser.TableFuncExpr) (sql.Node, error) {
+	call, ok := rewrittenSyntaxFromContext(ctx).jsonTables[t]
+	if !ok || call.columns == "" {
+		return nil, sql.ErrSyntaxError.New(fmt.Sprintf("%s requires a COLUMNS clause", t.Name))
+	}
+
+	node, err := tableFuncExprToUnresolvedTableFunction(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+
+	tf := node.(*expression.UnresolvedTableFunction)
+	tf.Arguments = append(tf.Arguments, expression.NewLiteral(call.columns, sql.LongText))
+	if tf.ArgumentNames != nil {
+		tf.ArgumentNames = append(tf.ArgumentNames, "")
+	}
+
+	// The alias names the rows of the table function like a derived table
+	if call.alias != "" {
+		return plan.NewSubqueryAlias(call.alias, tf.String(), tf), nil
+	}
+	return tf, nil
+}
+
+// groupConcatWindow is a call to GROUP_CONCAT found by rewriteGroupConcatWindows, with the offset of its opening
+// parenthesis and the text of its OVER clause. Over is empty if the call has no OVER clause.
+type groupConcatWindow struct {
+	pos  int
+	over string
+}
+
+// groupConcatWindowsBefore returns the calls to GROUP_CONCAT given that start before the offset given.
+func groupConcatWindowsBefore(calls []groupConcatWindow, end int) []groupConcatWindow {
+	for i, c := range calls {
+		if c.pos >= end {
+			return calls[:i]
+		}
+	}
+	return calls
+}
+
+// rewriteGroupConcatWindows removes the OVER clauses after calls to GROUP_CONCAT, which the SQL parser doesn't know
+// about, and returns the calls to GROUP_CONCAT in the query in the order they appear, with the text of the clauses
+// that were removed, for findGroupConcatWindows. An OVER clause is either a window specification in parentheses or
+// the name of a window. The rewritten query has the same length as the original.
+func rewriteGroupConcatWindows(query string) (string, []groupConcatWindow) {
+	if !strings.Contains(strings.ToLower(query), "over") {
+		return query, nil
+	}
+
+	// parens is a level of parentheses. Call is the index of the call to GROUP_CONCAT whose arguments they hold, and
+	// window the index of the call whose window specification they hold, starting at the offset windowStart.
+	type parens struct {
+		call, window, windowStart int
+	}
+
+	b := []byte(query)
+	stack := []parens{{call: -1, window: -1}}
+	var calls []groupConcatWindow
+	found := false
+	var prev int
+	// closedCall is the index of the call whose closing parenthesis was the last token, and overCall the index of the
+	// call whose OVER keyword was the last token, which starts at overStart
+	closedCall, overCall, overStart := -1, -1, 0
+	for tokenizer := sqlparser.NewStringTokenizer(query); ; {
+		typ, val := tokenizer.Scan()
+		if typ == 0 || typ == sqlparser.LEX_ERROR {
+			break
+		}
+		if typ == sqlparser.COMMENT {
+			continue
+		}
+		// The tokenizer has read one character past the token
+		end := tokenizer.Position - 1
+		start := end - 1
+		if len(val) > 0 {
+			start = end - len(val)
+		}
+
+		afterCall, afterOver := closedCall, overCall
+		closedCall, overCall = -1, -1
+		switch typ {
+		case sqlparser.OVER:
+			if afterCall >= 0 {
+				overCall, overStart = afterCall, start
+			}
+		case sqlparser.ID:
+			if afterOver >= 0 {
+				calls[afterOver].over = query[overStart:end]
+				copy(b[overStart:end], strings.Repeat(" ", end-overStart))
+				found = true
+			}
+		case '(':
+			p := parens{call: -1, window: -1}
+			if prev == sqlparser.GROUP_CONCAT {
+				calls = append(calls, groupConcatWindow{pos: start})
+				p.call = len(calls) - 1
+			} else if afterOver >= 0 {
+				p.window, p.windowStart = afterOver, overStart
+			}
+			stack = append(stack, p)
+		case ')':
+			if len(stack) > 1 {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				if top.call >= 0 {
+					closedCall = top.call
+				}
+				if top.window >= 0 {
+					calls[top.window].over = query[top.windowStart:end]
+					copy(b[top.windowStart:end], strings.Repeat(" ", end-top.windowStart))
+					found = true
+				}
+			}
+		}
+		prev = typ
+	}
+
+	if !found {
+		return query, nil
+	}
+	return string(b), calls
+}
+
+// findGroupConcatWindows returns the windows of the calls to GROUP_CONCAT in the statement given whose OVER clauses were
+// removed by rewriteGroupConcatWindows. The calls in the statement are matched with the calls given in the order they
+// appear in the query, which is the order they are walked in. Returns an error when the calls don't match, or an OVER
+// clause isn't valid.
+func findGroupConcatWindows(stmt sqlparser.Statement, calls []groupConcatWindow) (map[*sqlparser.GroupConcatExpr]*sqlparser.Over, error) {
+	if len(calls) == 0 {
+		return nil, nil
+	}
+
+	found := make(map[*sqlparser.GroupConcatExpr]*sqlparser.Over)
+	i := 0
+	err := walkStatement(func(node sqlparser.SQLNode) (bool, error) {
+		gc, ok := node.(*sqlparser.GroupConcatExpr)
+		if !ok {
+			return true, nil
+		}
+		if i < len(calls) && calls[i].over != "" {
+			over, err := parseOver(calls[i].over)
+			if err != nil {
+				return false, err
+			}
+			found[gc] = over
+		}
+		i++
+		return true, nil
+	}, stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	if i != len(calls) {
+		return nil, sql.ErrUnsupportedSyntax.New("GROUP_CONCAT with OVER")
+	}
+	return found, nil
+}
+
+// parseOver parses the OVER clause given, by parsing it after a window function.
+func parseOver(over string) (*sqlparser.Over, error) {
+	stmt, err := sqlparser.Parse("select row_number() " + over)
+	if err != nil {
+		return nil, sql.ErrSyntaxError.New(err.Error())
+	}
+	return stmt.(*sqlparser.Select).SelectExprs[0].(*sqlparser.AliasedExpr).Expr.(*sqlparser.FuncExpr).Over, nil
+}
+
+// rewriteExtractUnits rewrites the `EXTRACT(unit FROM expr)` syntax, which the SQL parser doesn't know about, to
+// `EXTRACT('unit', expr)`, and returns whether each call to EXTRACT in the query was rewritten, in the order they
+// appear, so that findExtractCalls can tell the rewritten calls apart from calls that were written with a string
+// argument. The rewritten query has the same length as the original, so positions in it still match the original
+// text.
+func rewriteExtractUnits(query string) (string, []bool) {
+	if !strings.Contains(strings.ToLower(query), "extract") {
+		return query, nil
+	}
+
+	// token is a token of the query, with its start and end offsets in it if its text isn't quoted
+	type token struct {
+		typ        int
+		val        string
+		start, end int
+		unquoted   bool
+	}
+
+	b := []byte(query)
+	var calls []bool
+	var last [4]token
+	tokenizer := sqlparser.NewStringTokenizer(query)
+	for {
+		typ, val := tokenizer.Scan()
+		if typ == 0 || typ == sqlparser.LEX_ERROR {
+			break
+		}
+		if typ == sqlparser.COMMENT {
+			continue
+		}
+		t := token{typ: typ, val: string(val)}
+		if len(val) == 0 {
+			// The tokenizer has read one character past single character tokens
+			t.start = tokenizer.Position - 2
+			t.end = t.start + 1
+		} else {
+			t.end = tokenizer.Position - 1
+			t.start = t.end - len(val)
+			t.unquoted = t.start >= 0 && strings.EqualFold(query[t.start:t.end], t.val)
+		}
+		copy(last[:], last[1:])
+		last[3] = t
+
+		call, open, unit := last[0], last[1], last[2]
+		switch {
+		case t.typ == '(' && unit.typ == sqlparser.ID && strings.EqualFold(unit.val, "extract") && open.typ != '.':
+			calls = append(calls, false)
+		case t.typ == sqlparser.FROM && open.typ == '(' && call.typ == sqlparser.ID &&
+			strings.EqualFold(call.val, "extract") && unit.unquoted && isExtractUnitWord(unit.val):
+			copy(b[unit.start:t.end], fmt.Sprintf("%-*s", t.end-unit.start, "'"+unit.val+"',"))
+			calls[len(calls)-1] = true
+		}
+	}
+
+	return string(b), calls
+}
+
+// isExtractUnitWord returns whether the text given could be an unquoted unit of EXTRACT. Most units are scanned as
+// keywords that the SQL parser doesn't use, rather than as identifiers.
+func isExtractUnitWord(s string) bool {
+	for _, c := range s {
+		if !(c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// findExtractCalls returns the calls to EXTRACT in the statement given that were rewritten by rewriteExtractUnits. The
+// calls in the statement are matched with the calls given in the order they appear in the query, which is the order
+// they are walked in.
+func findExtractCalls(stmt sqlparser.Statement, calls []bool) map[*sqlparser.FuncExpr]bool {
+	if len(calls) == 0 {
+		return nil
+	}
+
+	found := make(map[*sqlparser.FuncExpr]bool)
+	i := 0
+	_ = walkStatement(func(node sqlparser.SQLNode) (bool, error) {
+		if f, ok := node.(*sqlparser.FuncExpr); ok && f.Qualifier.IsEmpty() && f.Name.Lowered() == "extract" {
+			if i < len(calls) && calls[i] {
+				found[f] = true
+			}
+			i++
+		}
+		return true, nil
+	}, stmt)
+	return found
+}
+
+// rewrittenSyntax is the syntax of a statement that the SQL parser doesn't know about, which was rewritten before the
+// statement was parsed. It is recorded for the nodes of the parsed statement it belongs to, which are converted with the
+// context returned by withRewrittenSyntax.
+type rewrittenSyntax struct {
+	// extractCalls are the calls to EXTRACT that were written as EXTRACT(unit FROM expr)
+	extractCalls map[*sqlparser.FuncExpr]bool
+	// lateral are the derived tables that were written with the LATERAL keyword
+	lateral map[*sqlparser.AliasedTableExpr]bool
+	// namedArguments are the names of the arguments of the table function calls that have arguments written as
+	// `name => value`, with an empty name for positional arguments
+	namedArguments map[*sqlparser.TableFuncExpr][]string
+	// jsonTables are the COLUMNS clauses and aliases of the calls to JSON_TABLE
+	jsonTables map[*sqlparser.TableFuncExpr]jsonTableCall
+	// groupConcatWindows are the windows of the calls to GROUP_CONCAT that were written with an OVER clause
+	groupConcatWindows map[*sqlparser.GroupConcatExpr]*sqlparser.Over
+}
+
+type rewrittenSyntaxKey struct{}
+
+// withRewrittenSyntax returns a context holding the rewritten syntax given, for the conversion of its statement.
+func withRewrittenSyntax(ctx *sql.Context, syntax *rewrittenSyntax) *sql.Context {
+	return ctx.WithContext(context.WithValue(ctx.Context, rewrittenSyntaxKey{}, syntax))
+}
+
+// rewrittenSyntaxFromContext returns the rewritten syntax of the statement converted with the context given, which is
+// empty if nothing was rewritten.
+func rewrittenSyntaxFromContext(ctx *sql.Context) *rewrittenSyntax {
+	if syntax, ok := ctx.Value(rewrittenSyntaxKey{}).(*rewrittenSyntax); ok {
+		return syntax
+	}
+	return &rewrittenSyntax{}
+}
+
+// walkStatement walks the nodes of the statement given like sqlparser.Walk, in the order they appear in the query, but
+// also walks the nodes that sqlparser.Walk skips: the common table expressions of a select, before the rest of it, the
+// statements of explain and DDL statements, and the arguments of table functions.
+func walkStatement(visit sqlparser.Visit, stmt sqlparser.SQLNode) error {
+	var walk sqlparser.Visit
+	walk = func(node sqlparser.SQLNode) (bool, error) {
+		if kontinue, err := visit(node); err != nil || !kontinue {
+			return false, err
+		}
+
+		switch n := node.(type) {
+		case *sqlparser.Select:
+			if n.With == nil {
+				break
+			}
+			for _, cte := range n.With.Ctes {
+				if cte, ok := cte.(*sqlparser.CommonTableExpr); ok {
+					if sq, ok := cte.Expr.(*sqlparser.Subquery); ok {
+						if err := sqlparser.Walk(walk, sq.Select); err != nil {
+							return false, err
+						}
+					}
+				}
+			}
+		case *sqlparser.Explain:
+			return false, sqlparser.Walk(walk, n.Statement)
+		case *sqlparser.DDL:
+			// The statements in a DDL statement aren't part of its subtree
+			var nodes []sqlparser.SQLNode
+			if n.ViewExpr != nil {
+				nodes = append(nodes, n.ViewExpr)
+			}
+			if n.TriggerSpec != nil {
+				nodes = append(nodes, n.TriggerSpec.Body)
+			}
+			if n.ProcedureSpec != nil {
+				nodes = append(nodes, n.ProcedureSpec.Body)
+			}
+			if n.OptSelect != nil {
+				nodes = append(nodes, n.OptSelect.Select)
+			}
+			return false, sqlparser.Walk(walk, nodes...)
+		case *sqlparser.TableFuncExpr:
+			return false, sqlparser.Walk(walk, n.Exprs)
+		}
+		return true, nil
+	}
+	return sqlparser.Walk(walk, stmt)
+}
+
+// restoreInputExpressions takes the text of the select expressions of the statement given that were changed by the
+// rewrites in parse back from the original query, since it names their columns. This includes text that was removed
+// right after an expression, like the OVER clause of GROUP_CONCAT. The rewritten query has the same length as the
+// original.
+func restoreInputExpressions(stmt sqlparser.Statement, query, rewritten string) {
+	if query == rewritten {
+		return
+	}
+
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		ae, ok := node.(*sqlparser.AliasedExpr)
+		if !ok || ae.InputExpression == "" || ae.StartParsePos >= ae.EndParsePos || ae.EndParsePos > len(query) {
+			return true, nil
+		}
+		end := ae.EndParsePos
+		for i := end; i < len(rewritten) && rewritten[i] == ' '; i++ {
+			if query[i] != ' ' {
+				end = i + 1
+			}
+		}
+		if original := query[ae.StartParsePos:end]; original != rewritten[ae.StartParsePos:end] {
+			ae.InputExpression = strings.TrimLeft(original, " \n\t")
+		}
+		return true, nil
+	}, stmt)
+}
+
+// derivedTable is a derived table found by rewriteLateralDerivedTables, with the offset of its opening parenthesis.
+type derivedTable struct {
+	pos     int
+	lateral bool
+}
+
+// derivedTablesBefore returns the derived tables given that start before the offset given.
+func derivedTablesBefore(tables []derivedTable, end int) []derivedTable {
+	for i, t := range tables {
+		if t.pos >= end {
+			return tables[:i]
+		}
+	}
+	return tables
+}
+
+// rewriteLateralDerivedTables removes the LATERAL keyword, which the SQL parser doesn't know about, from the derived
+// tables of the query, and returns the derived tables in the query in the order they appear, for
+// findLateralDerivedTables. A derived table is a parenthesized subquery that starts a table reference, after FROM, a
+// join or a comma of a FROM clause. The rewritten query has the same length as the original.
+func rewriteLateralDerivedTables(query string) (string, []derivedTable) {
+	if !strings.Contains(strings.ToLower(query), "lateral") {
+		return query, nil
+	}
+
+	b := []byte(query)
+	var derived []derivedTable
+	found := false
+	// inFrom is whether each level of parentheses is in a FROM clause, where commas separate table references
+	inFrom := []bool{false}
+	prev := 0
+	lateralStart := -1
+	afterLateral, pending, pendingLateral := false, false, false
+	pendingPos := 0
+	tokenizer := sqlparser.NewStringTokenizer(query)
+	for {
+		typ, val := tokenizer.Scan()
+		if typ == 0 || typ == sqlparser.LEX_ERROR {
+			break
+		}
+		if typ == sqlparser.COMMENT {
+			continue
+		}
+
+		if pending && (typ == sqlparser.SELECT || typ == sqlparser.WITH || typ == '(') {
+			derived = append(derived, derivedTable{pos: pendingPos, lateral: pendingLateral})
+			found = found || pendingLateral
+		}
+		pending = false
+
+		atTable := prev == sqlparser.FROM || prev == sqlparser.JOIN || prev == sqlparser.STRAIGHT_JOIN ||
+			prev == ',' && inFrom[len(inFrom)-1]
+		switch typ {
+		case sqlparser.FROM:
+			inFrom[len(inFrom)-1] = true
+		case sqlparser.SELECT, sqlparser.WHERE, sqlparser.GROUP, sqlparser.HAVING, sqlparser.WINDOW, sqlparser.ORDER,
+			sqlparser.LIMIT, sqlparser.UNION, sqlparser.SET:
+			inFrom[len(inFrom)-1] = false
+		case '(':
+			pending, pendingLateral, pendingPos = atTable || afterLateral, afterLateral, tokenizer.Position-2
+			if afterLateral {
+				copy(b[lateralStart:], "       ")
+			}
+			inFrom = append(inFrom, false)
+		case ')':
+			if len(inFrom) > 1 {
+				inFrom = inFrom[:len(inFrom)-1]
+			}
+		}
+
+		afterLateral = false
+		if atTable && typ == sqlparser.ID && strings.EqualFold(string(val), "lateral") {
+			end := tokenizer.Position - 1
+			if start := end - len(val); start >= 0 && strings.EqualFold(query[start:end], "lateral") {
+				afterLateral, lateralStart = true, start
+			}
+		}
+		prev = typ
+	}
+
+	if !found {
+		return query, nil
+	}
+	return string(b), derived
+}
+
+// findLateralDerivedTables returns the derived tables of the statement given that rewriteLateralDerivedTables found to
+// be lateral. The derived tables in the statement are matched with the ones given in the order they appear in the
+// query, which is the order they are walked in. Returns an error when the derived tables don't match.
+func findLateralDerivedTables(stmt sqlparser.Statement, derived []derivedTable) (map[*sqlparser.AliasedTableExpr]bool, error) {
+	if len(derived) == 0 {
+		return nil, nil
+	}
+
+	lateral := make(map[*sqlparser.AliasedTableExpr]bool)
+	i := 0
+	_ = walkStatement(func(node sqlparser.SQLNode) (bool, error) {
+		if n, ok := node.(*sqlparser.AliasedTableExpr); ok {
+			if _, ok := n.Expr.(*sqlparser.Subquery); ok {
+				if i < len(derived) && derived[i].lateral {
+					lateral[n] = true
+				}
+				i++
+			}
+		}
+		return true, nil
+	}, stmt)
+
+	if i != len(derived) {
+		return nil, sql.ErrUnsupportedSyntax.New("LATERAL")
+	}
+	return lateral, nil
+}
+
+// extractToExpression converts a call to EXTRACT that was rewritten by rewriteExtractUnits. A call that wasn't rewritten
+// wasn't written as EXTRACT(unit FROM expr), the only syntax of EXTRACT.
+func extractToExpression(ctx *sql.Context, f *sqlparser.FuncExpr) (sql.Expression, error) {
+	if rewrittenSyntaxFromContext(ctx).extractCalls[f] && len(f.Exprs) == 2 {
+		if unit, ok := f.Exprs[0].(*sqlparser.AliasedExpr); ok {
+			if val, ok := unit.Expr.(*sqlparser.SQLVal); ok && val.Type == sqlparser.StrVal {
+				exprs, err := selectExprsToExpressions(ctx, f.Exprs[1:])
+				if err != nil {
+					return nil, err
+				}
+				return function.NewExtract(expression.NewLiteral(string(val.Val), sql.LongText), exprs[0]), nil
+			}
+		}
+	}
+	return nil, sql.ErrSyntaxError.New("EXTRACT must be called as EXTRACT(unit FROM expr)")
+}
+
 func selectExprsToExpressions(ctx *sql.Context, se sqlparser.SelectExprs) ([]sql.Expression, error) {
 	var exprs []sql.Expression
 	for _, e := range se {
@@ -2884,35 +3960,28 @@ func ExprToExpression(ctx *sql.Context, e sqlparser.Expr) (sql.Expression, error
 		}
 		return expression.NewUnresolvedColumn(v.Name.String()), nil
 	case *sqlparser.FuncExpr:
+		if v.Qualifier.IsEmpty() && v.Name.Lowered() == "extract" {
+			return extractToExpression(ctx, v)
+		}
+
 		exprs, err := selectExprsToExpressions(ctx, v.Exprs)
 		if err != nil {
 			return nil, err
 		}
 
-		// NOTE: The count distinct expressions work differently due to the * syntax. eg. COUNT(*)
-		if v.Distinct && v.Name.Lowered() == "count" {
-			if len(exprs) != 1 {
-				return nil, sql.ErrUnsupportedSyntax.New("more than one expression in COUNT")
-			}
-
-			return aggregation.NewCountDistinct(exprs[0]), nil
-		}
-
 		// NOTE: Not all aggregate functions support DISTINCT. Fortunately, the vitess parser will throw
-		// errors for when DISTINCT is used on aggregate functions that don't support DISTINCT.
-		if v.Distinct {
-			if len(exprs) != 1 {
-				return nil, sql.ErrUnsupportedSyntax.New("more than one expression with distinct")
-			}
-
-			exprs[0] = expression.NewDistinctExpression(exprs[0])
+		// errors for when DISTINCT is used on aggregate functions that don't support DISTINCT. The
+		// deduplication itself is applied to the argument when the function is resolved.
+		if v.Distinct && len(exprs) != 1 {
+			return nil, sql.ErrUnsupportedSyntax.New("more than one expression with distinct")
 		}
+
 		over, err := windowDefToWindow(ctx, (*sqlparser.WindowDef)(v.Over))
 		if err != nil {
 			return nil, err
 		}
 		return expression.NewUnresolvedFunction(v.Name.Lowered(),
-			isAggregateFunc(v), over, exprs...), nil
+			isAggregateFunc(v), over, exprs...).WithDistinct(v.Distinct), nil
 	case *sqlparser.GroupConcatExpr:
 		exprs, err := selectExprsToExpressions(ctx, v.Exprs)
 		if err != nil {
@@ -2936,7 +4005,19 @@ func ExprToExpression(ctx *sql.Context, e sqlparser.Expr) (sql.Expression, error
 		}
 		groupConcatMaxLen := gcml.(uint64)
 
-		return aggregation.NewGroupConcat(v.Distinct, sortFields, separatorS, exprs, int(groupConcatMaxLen))
+		gc, err := aggregation.NewGroupConcat(v.Distinct, sortFields, separatorS, exprs, int(groupConcatMaxLen))
+		if err != nil {
+			return nil, err
+		}
+
+		if over := rewrittenSyntaxFromContext(ctx).groupConcatWindows[v]; over != nil {
+			window, err := windowDefToWindow(ctx, (*sqlparser.WindowDef)(over))
+			if err != nil {
+				return nil, err
+			}
+			return gc.WithWindow(window)
+		}
+		return gc, nil
 	case *sqlparser.ParenExpr:
 		return ExprToExpression(ctx, v.Expr)
 	case *sqlparser.AndExpr:
@@ -3023,8 +4104,15 @@ func ExprToExpression(ctx *sql.Context, e sqlparser.Expr) (sql.Expression, error
 	case *sqlparser.IntervalExpr:
 		return intervalExprToExpression(ctx, v)
 	case *sqlparser.CollateExpr:
-		// TODO: handle collation
-		return ExprToExpression(ctx, v.Expr)
+		expr, err := ExprToExpression(ctx, v.Expr)
+		if err != nil {
+			return nil, err
+		}
+		collation, ok := sql.Collations[strings.ToLower(v.Charset)]
+		if !ok {
+			return nil, sql.ErrCollationNotSupported.New(v.Charset)
+		}
+		return expression.NewCollate(expr, collation), nil
 	case *sqlparser.ValuesFuncExpr:
 		col, err := ExprToExpression(ctx, v.Name)
 		if err != nil {
@@ -3048,12 +4136,18 @@ func ExprToExpression(ctx *sql.Context, e sqlparser.Expr) (sql.Expression, error
 
 		unit = expression.NewLiteral(v.Unit, sql.LongText)
 		expr1, err = ExprToExpression(ctx, v.Expr1)
+		if err != nil {
+			return nil, err
+		}
 		expr2, err = ExprToExpression(ctx, v.Expr2)
+		if err != nil {
+			return nil, err
+		}
 
 		if v.Name == "timestampdiff" {
-			return function.NewTimestampDiff(unit, expr1, expr2), err
+			return function.NewTimestampDiff(unit, expr1, expr2), nil
 		} else if v.Name == "timestampadd" {
-			return nil, fmt.Errorf("TIMESTAMPADD() not supported")
+			return function.NewTimestampAdd(unit, expr1, expr2), nil
 		}
 		return nil, nil
 	}
@@ -3088,8 +4182,8 @@ func windowDefToWindow(ctx *sql.Context, def *sqlparser.WindowDef) (*sql.WindowD
 func isAggregateFunc(v *sqlparser.FuncExpr) bool {
 	switch v.Name.Lowered() {
 	case "first", "last", "count", "sum", "avg", "max", "min",
-		"count_distinct", "json_arrayagg",
-		"row_number", "percent_rank", "lag", "first_value":
+		"count_distinct", "json_arrayagg", "st_collect",
+		"row_number", "percent_rank", "rank", "dense_rank", "lag", "first_value":
 		return true
 	}
 
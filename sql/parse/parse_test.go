@@ -30,7 +30,7 @@ import (
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
-	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
 	"github.com/dolthub/go-mysql-server/sql/plan"
 )
 
@@ -1773,6 +1773,43 @@ CREATE TABLE t2
 		},
 		plan.NewUnresolvedTable("foo", ""),
 	),
+	`SELECT * FROM foo(1, stop => 'a=>b', step => 2)`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewStar(),
+		},
+		expression.NewUnresolvedTableFunctionWithNames("foo", []sql.Expression{
+			expression.NewLiteral(int8(1), sql.Int8),
+			expression.NewLiteral("a=>b", sql.LongText),
+			expression.NewLiteral(int8(2), sql.Int8),
+		}, []string{"", "stop", "step"}),
+	),
+	`SELECT * FROM bar, foo(x = 2 /* don't */, stop => 1) JOIN foo(x = 3) ON true`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewStar(),
+		},
+		plan.NewCrossJoin(
+			plan.NewUnresolvedTable("bar", ""),
+			plan.NewInnerJoin(
+				expression.NewUnresolvedTableFunctionWithNames("foo", []sql.Expression{
+					expression.NewEquals(expression.NewUnresolvedColumn("x"), expression.NewLiteral(int8(2), sql.Int8)),
+					expression.NewLiteral(int8(1), sql.Int8),
+				}, []string{"", "stop"}),
+				expression.NewUnresolvedTableFunction("foo", []sql.Expression{
+					expression.NewEquals(expression.NewUnresolvedColumn("x"), expression.NewLiteral(int8(3), sql.Int8)),
+				}),
+				expression.NewLiteral(true, sql.Boolean),
+			),
+		),
+	),
+	`SELECT * FROM foo(start => (x > 1), stop => 1 + 2)`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewStar(),
+		},
+		expression.NewUnresolvedTableFunctionWithNames("foo", []sql.Expression{
+			expression.NewGreaterThan(expression.NewUnresolvedColumn("x"), expression.NewLiteral(int8(1), sql.Int8)),
+			expression.NewArithmetic(expression.NewLiteral(int8(1), sql.Int8), expression.NewLiteral(int8(2), sql.Int8), "+"),
+		}, []string{"start", "stop"}),
+	),
 	`SELECT foo, bar FROM foo LIMIT 2 OFFSET 5;`: plan.NewLimit(expression.NewLiteral(int8(2), sql.Int8),
 		plan.NewOffset(expression.NewLiteral(int8(5), sql.Int8), plan.NewProject(
 			[]sql.Expression{
@@ -1994,6 +2031,43 @@ CREATE TABLE t2
 		},
 		plan.NewUnresolvedTable("foo", ""),
 	),
+	`SELECT EXTRACT(YEAR_MONTH FROM a), extract(day from '2021-03-01') FROM foo`: plan.NewProject(
+		[]sql.Expression{
+			function.NewExtract(expression.NewLiteral("YEAR_MONTH", sql.LongText), expression.NewUnresolvedColumn("a")),
+			expression.NewAlias("extract(day from '2021-03-01')",
+				function.NewExtract(expression.NewLiteral("day", sql.LongText), expression.NewLiteral("2021-03-01", sql.LongText)),
+			),
+		},
+		plan.NewUnresolvedTable("foo", ""),
+	),
+	`SELECT EXTRACT(DAY FROM unit) AS unit FROM foo`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewAlias("unit",
+				function.NewExtract(expression.NewLiteral("DAY", sql.LongText), expression.NewUnresolvedColumn("unit")),
+			),
+		},
+		plan.NewUnresolvedTable("foo", ""),
+	),
+	`SELECT a.x FROM a JOIN LATERAL (SELECT b.z FROM b WHERE b.x = a.x) t`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewUnresolvedQualifiedColumn("a", "x"),
+		},
+		plan.NewCrossJoin(
+			plan.NewUnresolvedTable("a", ""),
+			plan.NewSubqueryAlias("t", "select b.z from b where b.x = a.x",
+				plan.NewProject(
+					[]sql.Expression{expression.NewUnresolvedQualifiedColumn("b", "z")},
+					plan.NewFilter(
+						expression.NewEquals(
+							expression.NewUnresolvedQualifiedColumn("b", "x"),
+							expression.NewUnresolvedQualifiedColumn("a", "x"),
+						),
+						plan.NewUnresolvedTable("b", ""),
+					),
+				),
+			).WithLateral(true),
+		),
+	),
 	`SELECT 2 = 2 FROM foo`: plan.NewProject(
 		[]sql.Expression{
 			expression.NewAlias("2 = 2",
@@ -2821,7 +2895,7 @@ CREATE TABLE t2
 	`SELECT COUNT(DISTINCT i) FROM foo`: plan.NewGroupBy(
 		[]sql.Expression{
 			expression.NewAlias("COUNT(DISTINCT i)",
-				aggregation.NewCountDistinct(expression.NewUnresolvedColumn("i"))),
+				expression.NewUnresolvedFunction("count", true, nil, expression.NewUnresolvedColumn("i")).WithDistinct(true)),
 		},
 		[]sql.Expression{},
 		plan.NewUnresolvedTable("foo", ""),
@@ -2829,7 +2903,7 @@ CREATE TABLE t2
 	`SELECT AVG(DISTINCT a) FROM foo`: plan.NewGroupBy(
 		[]sql.Expression{
 			expression.NewAlias("AVG(DISTINCT a)",
-				expression.NewUnresolvedFunction("avg", true, nil, expression.NewDistinctExpression(expression.NewUnresolvedColumn("a")))),
+				expression.NewUnresolvedFunction("avg", true, nil, expression.NewUnresolvedColumn("a")).WithDistinct(true)),
 		},
 		[]sql.Expression{},
 		plan.NewUnresolvedTable("foo", ""),
@@ -2838,9 +2912,8 @@ CREATE TABLE t2
 		[]sql.Expression{
 			expression.NewAlias("SUM(DISTINCT a*b)",
 				expression.NewUnresolvedFunction("sum", true, nil,
-					expression.NewDistinctExpression(
-						expression.NewMult(expression.NewUnresolvedColumn("a"),
-							expression.NewUnresolvedColumn("b")))))},
+					expression.NewMult(expression.NewUnresolvedColumn("a"),
+						expression.NewUnresolvedColumn("b"))).WithDistinct(true))},
 		[]sql.Expression{},
 		plan.NewUnresolvedTable("foo", ""),
 	),
@@ -2848,9 +2921,8 @@ CREATE TABLE t2
 		[]sql.Expression{
 			expression.NewAlias("AVG(DISTINCT a / b)",
 				expression.NewUnresolvedFunction("avg", true, nil,
-					expression.NewDistinctExpression(
-						expression.NewDiv(expression.NewUnresolvedColumn("a"),
-							expression.NewUnresolvedColumn("b")))))},
+					expression.NewDiv(expression.NewUnresolvedColumn("a"),
+						expression.NewUnresolvedColumn("b"))).WithDistinct(true))},
 		[]sql.Expression{},
 		plan.NewUnresolvedTable("foo", ""),
 	),
@@ -2858,9 +2930,8 @@ CREATE TABLE t2
 		[]sql.Expression{
 			expression.NewAlias("SUM(DISTINCT POWER(a, 2))",
 				expression.NewUnresolvedFunction("sum", true, nil,
-					expression.NewDistinctExpression(
-						expression.NewUnresolvedFunction("power", false, nil,
-							expression.NewUnresolvedColumn("a"), expression.NewLiteral(int8(2), sql.Int8)))))},
+					expression.NewUnresolvedFunction("power", false, nil,
+						expression.NewUnresolvedColumn("a"), expression.NewLiteral(int8(2), sql.Int8))).WithDistinct(true))},
 		[]sql.Expression{},
 		plan.NewUnresolvedTable("foo", ""),
 	),
@@ -3496,7 +3567,7 @@ CREATE TABLE t2
 			"myview", "SELECT AVG(DISTINCT foo) FROM b",
 			plan.NewGroupBy(
 				[]sql.Expression{
-					expression.NewUnresolvedFunction("avg", true, nil, expression.NewDistinctExpression(expression.NewUnresolvedColumn("foo"))),
+					expression.NewUnresolvedFunction("avg", true, nil, expression.NewUnresolvedColumn("foo")).WithDistinct(true),
 				},
 				[]sql.Expression{},
 				plan.NewUnresolvedTable("b", ""),
@@ -3645,6 +3716,14 @@ CREATE TABLE t2
 	`DROP DATABASE IF EXISTS test`:       plan.NewDropDatabase("test", true),
 	`KILL QUERY 1`:                       plan.NewKill(plan.KillType_Query, 1),
 	`KILL CONNECTION 1`:                  plan.NewKill(plan.KillType_Connection, 1),
+	`RESIGNAL`:                           plan.NewResignal("", "", map[plan.SignalConditionItemName]plan.SignalInfo{}),
+	`RESIGNAL cond_name`:                 plan.NewResignal("", "cond_name", map[plan.SignalConditionItemName]plan.SignalInfo{}),
+	`RESIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'err'`: plan.NewResignal("45000", "", map[plan.SignalConditionItemName]plan.SignalInfo{
+		plan.SignalConditionItemName_MessageText: {ConditionItemName: plan.SignalConditionItemName_MessageText, StrValue: "err"},
+	}),
+	`RESIGNAL SET MYSQL_ERRNO = 1000`: plan.NewResignal("", "", map[plan.SignalConditionItemName]plan.SignalInfo{
+		plan.SignalConditionItemName_MysqlErrno: {ConditionItemName: plan.SignalConditionItemName_MysqlErrno, IntValue: 1000},
+	}),
 }
 
 var triggerFixtures = map[string]sql.Node{
@@ -3653,7 +3732,7 @@ var triggerFixtures = map[string]sql.Node{
      UPDATE bar SET x = old.y WHERE z = new.y;
 		 DELETE FROM baz WHERE a = old.b;
 		 INSERT INTO zzz (a,b) VALUES (old.a, old.b);
-   END`: plan.NewCreateTrigger(sql.UnresolvedDatabase(""), "myTrigger", "before", "update", nil,
+   END`: plan.NewCreateTrigger(sql.UnresolvedDatabase(""), "myTrigger", "before", "update", "row", nil,
 		plan.NewUnresolvedTable("foo", ""),
 		plan.NewBeginEndBlock(
 			plan.NewBlock([]sql.Node{
@@ -3691,9 +3770,12 @@ var triggerFixtures = map[string]sql.Node{
 		 INSERT INTO zzz (a,b) VALUES (old.a, old.b);
    END`,
 		time.Unix(0, 0),
+		"",
+		"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES",
+		false,
 	),
 	`CREATE TRIGGER myTrigger BEFORE UPDATE ON foo FOR EACH ROW INSERT INTO zzz (a,b) VALUES (old.a, old.b)`: plan.NewCreateTrigger(sql.UnresolvedDatabase(""),
-		"myTrigger", "before", "update", nil,
+		"myTrigger", "before", "update", "row", nil,
 		plan.NewUnresolvedTable("foo", ""),
 		plan.NewInsertInto(sql.UnresolvedDatabase(""), plan.NewUnresolvedTable("zzz", ""), plan.NewValues([][]sql.Expression{{
 			expression.NewUnresolvedQualifiedColumn("old", "a"),
@@ -3703,9 +3785,12 @@ var triggerFixtures = map[string]sql.Node{
 		`CREATE TRIGGER myTrigger BEFORE UPDATE ON foo FOR EACH ROW INSERT INTO zzz (a,b) VALUES (old.a, old.b)`,
 		`INSERT INTO zzz (a,b) VALUES (old.a, old.b)`,
 		time.Unix(0, 0),
+		"",
+		"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES",
+		false,
 	),
 	`CREATE TRIGGER myTrigger BEFORE UPDATE ON foo FOR EACH ROW FOLLOWS yourTrigger INSERT INTO zzz (a,b) VALUES (old.a, old.b)`: plan.NewCreateTrigger(sql.UnresolvedDatabase(""),
-		"myTrigger", "before", "update",
+		"myTrigger", "before", "update", "row",
 		&plan.TriggerOrder{PrecedesOrFollows: sqlparser.FollowsStr, OtherTriggerName: "yourTrigger"},
 		plan.NewUnresolvedTable("foo", ""),
 		plan.NewInsertInto(sql.UnresolvedDatabase(""), plan.NewUnresolvedTable("zzz", ""), plan.NewValues([][]sql.Expression{{
@@ -3716,6 +3801,84 @@ var triggerFixtures = map[string]sql.Node{
 		`CREATE TRIGGER myTrigger BEFORE UPDATE ON foo FOR EACH ROW FOLLOWS yourTrigger INSERT INTO zzz (a,b) VALUES (old.a, old.b)`,
 		`INSERT INTO zzz (a,b) VALUES (old.a, old.b)`,
 		time.Unix(0, 0),
+		"",
+		"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES",
+		false,
+	),
+	`CREATE TRIGGER myTrigger AFTER INSERT ON foo FOR EACH STATEMENT INSERT INTO zzz (a,b) VALUES (1, 2)`: plan.NewCreateTrigger(sql.UnresolvedDatabase(""),
+		"myTrigger", "after", "insert", "statement", nil,
+		plan.NewUnresolvedTable("foo", ""),
+		plan.NewInsertInto(sql.UnresolvedDatabase(""), plan.NewUnresolvedTable("zzz", ""), plan.NewValues([][]sql.Expression{{
+			expression.NewLiteral(int8(1), sql.Int8),
+			expression.NewLiteral(int8(2), sql.Int8),
+		}},
+		), false, []string{"a", "b"}, []sql.Expression{}, false),
+		`CREATE TRIGGER myTrigger AFTER INSERT ON foo FOR EACH STATEMENT INSERT INTO zzz (a,b) VALUES (1, 2)`,
+		`INSERT INTO zzz (a,b) VALUES (1, 2)`,
+		time.Unix(0, 0),
+		"",
+		"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES",
+		false,
+	),
+	`CREATE TRIGGER IF NOT EXISTS myTrigger BEFORE UPDATE ON foo FOR EACH ROW INSERT INTO zzz (a,b) VALUES (old.a, old.b)`: plan.NewCreateTrigger(sql.UnresolvedDatabase(""),
+		"myTrigger", "before", "update", "row", nil,
+		plan.NewUnresolvedTable("foo", ""),
+		plan.NewInsertInto(sql.UnresolvedDatabase(""), plan.NewUnresolvedTable("zzz", ""), plan.NewValues([][]sql.Expression{{
+			expression.NewUnresolvedQualifiedColumn("old", "a"),
+			expression.NewUnresolvedQualifiedColumn("old", "b"),
+		}},
+		), false, []string{"a", "b"}, []sql.Expression{}, false),
+		`CREATE TRIGGER IF NOT EXISTS myTrigger BEFORE UPDATE ON foo FOR EACH ROW INSERT INTO zzz (a,b) VALUES (old.a, old.b)`,
+		`INSERT INTO zzz (a,b) VALUES (old.a, old.b)`,
+		time.Unix(0, 0),
+		"",
+		"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES",
+		true,
+	),
+	"CREATE DEFINER = `bob@localhost` TRIGGER myTrigger BEFORE UPDATE ON foo FOR EACH ROW INSERT INTO zzz (a,b) VALUES (old.a, old.b)": plan.NewCreateTrigger(sql.UnresolvedDatabase(""),
+		"myTrigger", "before", "update", "row", nil,
+		plan.NewUnresolvedTable("foo", ""),
+		plan.NewInsertInto(sql.UnresolvedDatabase(""), plan.NewUnresolvedTable("zzz", ""), plan.NewValues([][]sql.Expression{{
+			expression.NewUnresolvedQualifiedColumn("old", "a"),
+			expression.NewUnresolvedQualifiedColumn("old", "b"),
+		}},
+		), false, []string{"a", "b"}, []sql.Expression{}, false),
+		"CREATE DEFINER = `bob@localhost` TRIGGER myTrigger BEFORE UPDATE ON foo FOR EACH ROW INSERT INTO zzz (a,b) VALUES (old.a, old.b)",
+		`INSERT INTO zzz (a,b) VALUES (old.a, old.b)`,
+		time.Unix(0, 0),
+		"bob@localhost@%",
+		"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES",
+		false,
+	),
+	"CREATE DEFINER = 'bob'@'localhost' TRIGGER myTrigger BEFORE UPDATE ON foo FOR EACH ROW INSERT INTO zzz (a,b) VALUES (old.a, old.b)": plan.NewCreateTrigger(sql.UnresolvedDatabase(""),
+		"myTrigger", "before", "update", "row", nil,
+		plan.NewUnresolvedTable("foo", ""),
+		plan.NewInsertInto(sql.UnresolvedDatabase(""), plan.NewUnresolvedTable("zzz", ""), plan.NewValues([][]sql.Expression{{
+			expression.NewUnresolvedQualifiedColumn("old", "a"),
+			expression.NewUnresolvedQualifiedColumn("old", "b"),
+		}},
+		), false, []string{"a", "b"}, []sql.Expression{}, false),
+		"CREATE DEFINER = 'bob'@'localhost' TRIGGER myTrigger BEFORE UPDATE ON foo FOR EACH ROW INSERT INTO zzz (a,b) VALUES (old.a, old.b)",
+		`INSERT INTO zzz (a,b) VALUES (old.a, old.b)`,
+		time.Unix(0, 0),
+		"bob@localhost",
+		"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES",
+		false,
+	),
+	"CREATE DEFINER = CURRENT_USER() TRIGGER myTrigger BEFORE UPDATE ON foo FOR EACH ROW INSERT INTO zzz (a,b) VALUES (old.a, old.b)": plan.NewCreateTrigger(sql.UnresolvedDatabase(""),
+		"myTrigger", "before", "update", "row", nil,
+		plan.NewUnresolvedTable("foo", ""),
+		plan.NewInsertInto(sql.UnresolvedDatabase(""), plan.NewUnresolvedTable("zzz", ""), plan.NewValues([][]sql.Expression{{
+			expression.NewUnresolvedQualifiedColumn("old", "a"),
+			expression.NewUnresolvedQualifiedColumn("old", "b"),
+		}},
+		), false, []string{"a", "b"}, []sql.Expression{}, false),
+		"CREATE DEFINER = CURRENT_USER() TRIGGER myTrigger BEFORE UPDATE ON foo FOR EACH ROW INSERT INTO zzz (a,b) VALUES (old.a, old.b)",
+		`INSERT INTO zzz (a,b) VALUES (old.a, old.b)`,
+		time.Unix(0, 0),
+		"",
+		"NO_ENGINE_SUBSTITUTION,STRICT_TRANS_TABLES",
+		false,
 	),
 }
 
@@ -3765,6 +3928,292 @@ func TestParseCreateTrigger(t *testing.T) {
 	}
 }
 
+func TestScanCreateTrigger(t *testing.T) {
+	testCases := []struct {
+		query       string
+		definerUser string
+		definerHost string
+		ifNotExists bool
+		forEach     string
+		rewritten   string
+	}{
+		{
+			query:     "CREATE TRIGGER t BEFORE INSERT ON a FOR EACH ROW SET new.x = 1",
+			forEach:   "row",
+			rewritten: "CREATE TRIGGER t BEFORE INSERT ON a FOR EACH ROW SET new.x = 1",
+		},
+		{
+			query:       "create definer = `bob@localhost` trigger if not exists t after delete on a for each statement delete from b",
+			definerUser: "bob@localhost",
+			definerHost: "%",
+			ifNotExists: true,
+			forEach:     "statement",
+			rewritten:   "create definer = `bob@localhost` trigger               t after delete on a for each row       delete from b",
+		},
+		{
+			query:     "CREATE /* if not exists, don't */ TRIGGER t BEFORE INSERT ON a -- for each statement\nFOR EACH ROW SET new.x = 1",
+			forEach:   "row",
+			rewritten: "CREATE /* if not exists, don't */ TRIGGER t BEFORE INSERT ON a -- for each statement\nFOR EACH ROW SET new.x = 1",
+		},
+		{
+			query:     "CREATE TRIGGER `if` BEFORE INSERT ON `for` FOR EACH ROW SET new.x = 'for each statement'",
+			forEach:   "row",
+			rewritten: "CREATE TRIGGER `if` BEFORE INSERT ON `for` FOR EACH ROW SET new.x = 'for each statement'",
+		},
+		{
+			query:       "CREATE DEFINER=`trigger` TRIGGER IF /* really */ NOT EXISTS `statement` BEFORE UPDATE ON `each` FOR EACH STATEMENT SET @x = 1",
+			definerUser: "trigger",
+			definerHost: "%",
+			ifNotExists: true,
+			forEach:     "statement",
+			rewritten:   "CREATE DEFINER=`trigger` TRIGGER                            `statement` BEFORE UPDATE ON `each` FOR EACH row       SET @x = 1",
+		},
+		{
+			query:       "create definer = 'bob'@'localhost' trigger t before insert on a for each row set new.x = 1",
+			definerUser: "bob",
+			definerHost: "localhost",
+			forEach:     "row",
+			rewritten:   "create definer =x                  trigger t before insert on a for each row set new.x = 1",
+		},
+		{
+			query:       "create definer=`bob@localhost` trigger t before insert on a for each row set new.x = 1",
+			definerUser: "bob@localhost",
+			definerHost: "%",
+			forEach:     "row",
+			rewritten:   "create definer=`bob@localhost` trigger t before insert on a for each row set new.x = 1",
+		},
+		{
+			query:       "create definer=`u`@`h` trigger t before insert on a for each row set new.x = 1",
+			definerUser: "u",
+			definerHost: "h",
+			forEach:     "row",
+			rewritten:   "create definer=x       trigger t before insert on a for each row set new.x = 1",
+		},
+		{
+			query:       "create definer = u@h trigger t before insert on a for each row set new.x = 1",
+			definerUser: "u",
+			definerHost: "h",
+			forEach:     "row",
+			rewritten:   "create definer =x    trigger t before insert on a for each row set new.x = 1",
+		},
+		{
+			query:       "create definer = bob@'%' trigger t before insert on a for each row set new.x = 1",
+			definerUser: "bob",
+			definerHost: "%",
+			forEach:     "row",
+			rewritten:   "create definer =x        trigger t before insert on a for each row set new.x = 1",
+		},
+		{
+			query:     "create definer = current_user trigger t before insert on a for each row set new.x = 1",
+			forEach:   "row",
+			rewritten: "create definer =x             trigger t before insert on a for each row set new.x = 1",
+		},
+		{
+			query:     "create definer = CURRENT_USER() trigger t before insert on a for each row set new.x = 1",
+			forEach:   "row",
+			rewritten: "create definer =x               trigger t before insert on a for each row set new.x = 1",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.query, func(t *testing.T) {
+			require := require.New(t)
+			clauses, ok := scanCreateTrigger(tt.query)
+			require.True(ok)
+			require.Equal(tt.definerUser, clauses.definerUser)
+			require.Equal(tt.definerHost, clauses.definerHost)
+			require.Equal(tt.ifNotExists, clauses.ifNotExistsPos != nil)
+			require.Equal(tt.forEach, clauses.forEach)
+			require.Equal(tt.rewritten, rewriteCreateTrigger(tt.query))
+			_, err := sqlparser.Parse(tt.rewritten)
+			require.NoError(err)
+		})
+	}
+
+	for _, query := range []string{
+		"SELECT 'CREATE TRIGGER IF NOT EXISTS t BEFORE INSERT ON a FOR EACH STATEMENT'",
+		"/* CREATE TRIGGER IF NOT EXISTS */ CREATE TABLE `trigger` (`for each statement` int)",
+	} {
+		_, ok := scanCreateTrigger(query)
+		require.False(t, ok)
+		require.Equal(t, query, rewriteCreateTrigger(query))
+	}
+}
+
+func TestRewriteLateralDerivedTables(t *testing.T) {
+	testCases := []struct {
+		query     string
+		lateral   []bool
+		rewritten string
+	}{
+		{
+			query:     "select * from a join lateral (select a.x) t",
+			lateral:   []bool{true},
+			rewritten: "select * from a join         (select a.x) t",
+		},
+		{
+			query:     "SELECT * FROM (SELECT 1) s, LATERAL (SELECT s.x) t LEFT JOIN LATERAL (SELECT t.x) u ON true",
+			lateral:   []bool{false, true, true},
+			rewritten: "SELECT * FROM (SELECT 1) s,         (SELECT s.x) t LEFT JOIN         (SELECT t.x) u ON true",
+		},
+		{
+			query:     "select (select 1 from b), x in (select y from c) from a, lateral (select a.x from (select 1) s) t",
+			lateral:   []bool{true, false},
+			rewritten: "select (select 1 from b), x in (select y from c) from a,         (select a.x from (select 1) s) t",
+		},
+		{
+			query:     "select * from a /* lateral */ join lateral /* lateral */ (select 1) t where x in (select 1 from (select 2) u)",
+			lateral:   []bool{true, false},
+			rewritten: "select * from a /* lateral */ join         /* lateral */ (select 1) t where x in (select 1 from (select 2) u)",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.query, func(t *testing.T) {
+			require := require.New(t)
+			rewritten, derived := rewriteLateralDerivedTables(tt.query)
+			require.Equal(tt.rewritten, rewritten)
+			var lateral []bool
+			for _, d := range derived {
+				lateral = append(lateral, d.lateral)
+			}
+			require.Equal(tt.lateral, lateral)
+			stmt, err := sqlparser.Parse(rewritten)
+			require.NoError(err)
+			found, err := findLateralDerivedTables(stmt, derived)
+			require.NoError(err)
+			lateralCount := 0
+			for _, l := range tt.lateral {
+				if l {
+					lateralCount++
+				}
+			}
+			require.Len(found, lateralCount)
+			for table := range found {
+				require.Nil(table.Hints)
+			}
+		})
+	}
+
+	for _, query := range []string{
+		"select * from (select 1 as lateral) lateral",
+		"select lateral from lateral join (select 1) t",
+		"select 'join lateral (select 1)' from a",
+		"select * from a join (select 1) lateral",
+	} {
+		rewritten, derived := rewriteLateralDerivedTables(query)
+		require.Equal(t, query, rewritten)
+		require.Nil(t, derived)
+	}
+}
+
+func TestRewriteJSONTableColumns(t *testing.T) {
+	testCases := []struct {
+		query     string
+		calls     []jsonTableCall
+		rewritten string
+	}{
+		{
+			query:     "select * from json_table('[1]', '$[*]' columns (x int path '$')) as jt",
+			calls:     []jsonTableCall{{pos: 24, columns: "x int path '$'", alias: "jt"}},
+			rewritten: "select * from json_table('[1]', '$[*]'                         )      ",
+		},
+		{
+			query: "SELECT * FROM a, JSON_TABLE(a.j, '$' COLUMNS (n FOR ORDINALITY, v VARCHAR(10) PATH '$.v')) `t` " +
+				"JOIN JSON_TABLE('{}', '$' COLUMNS(x JSON PATH '$')) u ON true",
+			calls: []jsonTableCall{
+				{pos: 27, columns: "n FOR ORDINALITY, v VARCHAR(10) PATH '$.v'", alias: "t"},
+				{pos: 110, columns: "x JSON PATH '$'", alias: "u"},
+			},
+			rewritten: "SELECT * FROM a, JSON_TABLE(a.j, '$'                                                     )     " +
+				"JOIN JSON_TABLE('{}', '$'                         )   ON true",
+		},
+		{
+			query:     "select * from json_table('[1]', '$') where x = 1",
+			calls:     []jsonTableCall{{pos: 24}},
+			rewritten: "select * from json_table('[1]', '$') where x = 1",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.query, func(t *testing.T) {
+			require := require.New(t)
+			rewritten, calls := rewriteJSONTableColumns(tt.query)
+			require.Equal(tt.rewritten, rewritten)
+			require.Equal(tt.calls, calls)
+			stmt, err := sqlparser.Parse(rewritten)
+			require.NoError(err)
+			found, err := findJSONTableCalls(stmt, calls)
+			require.NoError(err)
+			require.Len(found, len(tt.calls))
+		})
+	}
+
+	for _, query := range []string{
+		"select json_table from json_table",
+		"select 'from json_table(1 columns (x int))' from a",
+		"select json_table('a' columns (x int)) from a",
+	} {
+		rewritten, calls := rewriteJSONTableColumns(query)
+		require.Equal(t, query, rewritten)
+		require.Nil(t, calls)
+	}
+}
+
+func TestRewriteGroupConcatWindows(t *testing.T) {
+	testCases := []struct {
+		query     string
+		calls     []groupConcatWindow
+		rewritten string
+	}{
+		{
+			query:     "select group_concat(x separator '-') over (partition by y order by z) from t",
+			calls:     []groupConcatWindow{{pos: 19, over: "over (partition by y order by z)"}},
+			rewritten: "select group_concat(x separator '-')                                  from t",
+		},
+		{
+			query:     "SELECT GROUP_CONCAT(a), GROUP_CONCAT(b ORDER BY b) OVER w FROM t WINDOW w AS (ORDER BY a)",
+			calls:     []groupConcatWindow{{pos: 19}, {pos: 36, over: "OVER w"}},
+			rewritten: "SELECT GROUP_CONCAT(a), GROUP_CONCAT(b ORDER BY b)        FROM t WINDOW w AS (ORDER BY a)",
+		},
+		{
+			query:     "select group_concat(x) over (order by (y)) as g, sum(x) over () from t",
+			calls:     []groupConcatWindow{{pos: 19, over: "over (order by (y))"}},
+			rewritten: "select group_concat(x)                     as g, sum(x) over () from t",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.query, func(t *testing.T) {
+			require := require.New(t)
+			rewritten, calls := rewriteGroupConcatWindows(tt.query)
+			require.Equal(tt.rewritten, rewritten)
+			require.Equal(tt.calls, calls)
+			stmt, err := sqlparser.Parse(rewritten)
+			require.NoError(err)
+			found, err := findGroupConcatWindows(stmt, calls)
+			require.NoError(err)
+			windows := 0
+			for _, c := range tt.calls {
+				if c.over != "" {
+					windows++
+				}
+			}
+			require.Len(found, windows)
+		})
+	}
+
+	for _, query := range []string{
+		"select group_concat(x) from t",
+		"select sum(x) over (), 'group_concat(x) over ()' from t",
+		"select group_concat(x), over from t",
+	} {
+		rewritten, calls := rewriteGroupConcatWindows(query)
+		require.Equal(t, query, rewritten)
+		require.Nil(t, calls)
+	}
+}
+
 // assertNodesEqualWithDiff asserts the two nodes given to be equal and prints any diff according to their DebugString
 // methods.
 func assertNodesEqualWithDiff(t *testing.T, expected, actual sql.Node) bool {
@@ -3830,6 +4279,12 @@ var fixturesErrors = map[string]*errors.Kind{
 	`CREATE TABLE test (i int unique)`:                          sql.ErrUnsupportedFeature,
 	`CREATE TABLE test (i int, j int unique)`:                   sql.ErrUnsupportedFeature,
 	`CREATE TABLE test (i int, unique(i))`:                      sql.ErrUnsupportedFeature,
+	`SELECT foo(start => 1)`:                                    sql.ErrSyntaxError,
+	`SELECT * FROM foo(start => x > 1)`:                         sql.ErrSyntaxError,
+	`SELECT * FROM foo(start => x AND y)`:                       sql.ErrSyntaxError,
+	`SELECT * FROM foo(start => x = 1)`:                         sql.ErrSyntaxError,
+	`SELECT * FROM foo(start => x IS NULL)`:                     sql.ErrSyntaxError,
+	`SELECT EXTRACT('YEAR', '2021-03-01')`:                      sql.ErrSyntaxError,
 }
 
 func TestParseOne(t *testing.T) {
@@ -33,6 +33,12 @@ var (
 	ErrCreateIndexNonExistentColumn = errors.NewKind("column `%v` does not exist in the table")
 	// ErrCreateIndexDuplicateColumn is returned when a CREATE INDEX statement has the same column multiple times
 	ErrCreateIndexDuplicateColumn = errors.NewKind("cannot have duplicates of columns in an index: `%v`")
+	// ErrSpatialIndexColumns is returned when a SPATIAL index is given more than one column
+	ErrSpatialIndexColumns = errors.NewKind("a SPATIAL index may only contain a single column")
+	// ErrSpatialIndexNotGeometry is returned when a SPATIAL index is given a column that does not hold geometries
+	ErrSpatialIndexNotGeometry = errors.NewKind("a SPATIAL index may only contain a geometrical type column: `%v`")
+	// ErrSpatialIndexNullable is returned when a SPATIAL index is given a nullable column
+	ErrSpatialIndexNullable = errors.NewKind("all parts of a SPATIAL index must be NOT NULL: `%v`")
 )
 
 type IndexAction byte
@@ -153,6 +159,12 @@ func (p *AlterIndex) Execute(ctx *sql.Context) error {
 			}
 		}
 
+		if p.Constraint == sql.IndexConstraint_Spatial {
+			if err := validateSpatialIndex(indexable.Schema(), p.Columns); err != nil {
+				return err
+			}
+		}
+
 		return indexable.CreateIndex(ctx, p.IndexName, p.Using, p.Constraint, p.Columns, p.Comment)
 	case IndexAction_Drop:
 		return indexable.DropIndex(ctx, p.IndexName)
@@ -170,6 +182,30 @@ func (p *AlterIndex) Execute(ctx *sql.Context) error {
 	}
 }
 
+// validateSpatialIndex returns an error if the columns can't be indexed by a SPATIAL index, which indexes a single
+// geometry column that is NOT NULL.
+func validateSpatialIndex(sch sql.Schema, columns []sql.IndexColumn) error {
+	if len(columns) != 1 {
+		return ErrSpatialIndexColumns.New()
+	}
+	var col *sql.Column
+	for _, c := range sch {
+		if strings.EqualFold(c.Name, columns[0].Name) {
+			col = c
+		}
+	}
+	if col == nil {
+		return ErrCreateIndexNonExistentColumn.New(columns[0].Name)
+	}
+	if !sql.IsSpatial(col.Type) {
+		return ErrSpatialIndexNotGeometry.New(col.Name)
+	}
+	if col.Nullable {
+		return ErrSpatialIndexNullable.New(col.Name)
+	}
+	return nil
+}
+
 // RowIter implements the Node interface.
 func (p *AlterIndex) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
 	err := p.Execute(ctx)
@@ -25,7 +25,9 @@ import (
 // RowCache to cache results generated by Child.RowIter() and return those
 // results for future calls to RowIter. This node is only safe to use if the
 // Child is determinstic and is not dependent on the |row| parameter in the
-// call to RowIter.
+// call to RowIter. Once the cached rows take more memory than the
+// tmp_table_size session variable allows, they're spilled to a temporary
+// file instead, and read back from it.
 func NewCachedResults(n sql.Node) *CachedResults {
 	return &CachedResults{UnaryNode: UnaryNode{n}}
 }
@@ -34,6 +36,7 @@ type CachedResults struct {
 	UnaryNode
 	cache   sql.RowsCache
 	dispose sql.DisposeFunc
+	spilled *spilledRows
 	mutex   sync.Mutex
 	noCache bool
 }
@@ -43,6 +46,8 @@ func (n *CachedResults) RowIter(ctx *sql.Context, r sql.Row) (sql.RowIter, error
 	defer n.mutex.Unlock()
 	if n.cache != nil {
 		return sql.RowsToRowIter(n.cache.Get()...), nil
+	} else if n.spilled != nil {
+		return n.spilled.RowIter(), nil
 	} else if n.noCache {
 		return n.UnaryNode.Child.RowIter(ctx, r)
 	}
@@ -51,13 +56,17 @@ func (n *CachedResults) RowIter(ctx *sql.Context, r sql.Row) (sql.RowIter, error
 		return nil, err
 	}
 	cache, dispose := ctx.Memory.NewRowsCache()
-	return &cachedResultsIter{n, ci, cache, dispose}, nil
+	return &cachedResultsIter{parent: n, iter: ci, cache: cache, dispose: dispose, spillAt: spillThreshold(ctx)}, nil
 }
 
 func (n *CachedResults) Dispose() {
 	if n.dispose != nil {
 		n.dispose()
 	}
+	if n.spilled != nil {
+		n.spilled.Dispose()
+		n.spilled = nil
+	}
 }
 
 func (n *CachedResults) String() string {
@@ -102,11 +111,17 @@ type cachedResultsIter struct {
 	iter    sql.RowIter
 	cache   sql.RowsCache
 	dispose sql.DisposeFunc
+	// spillAt is the estimated size of the rows cached in memory above which they're spilled
+	spillAt uint64
+	size    uint64
+	spilled *spilledRows
 }
 
 func (i *cachedResultsIter) Next(ctx *sql.Context) (sql.Row, error) {
 	r, err := i.iter.Next(ctx)
-	if i.cache != nil {
+	if i.spilled != nil {
+		i.nextSpilled(r, err)
+	} else if i.cache != nil {
 		if err != nil {
 			if err == io.EOF {
 				i.parent.mutex.Lock()
@@ -117,19 +132,70 @@ func (i *cachedResultsIter) Next(ctx *sql.Context) (sql.Row, error) {
 			}
 		} else {
 			aerr := i.cache.Add(r)
+			if aerr == nil {
+				i.size += estimatedRowSize(r)
+				if i.spillAt > 0 && i.size > i.spillAt {
+					aerr = i.spill(ctx)
+				}
+			}
 			if aerr != nil {
-				i.cleanUp()
-				i.parent.mutex.Lock()
-				defer i.parent.mutex.Unlock()
-				i.parent.noCache = true
+				i.stopCaching()
 			}
 		}
 	}
 	return r, err
 }
 
+// spill moves the rows cached in memory to a temporary file, where the rest of the rows are also written.
+func (i *cachedResultsIter) spill(ctx *sql.Context) error {
+	spilled, err := newSpilledRows(ctx)
+	if err != nil {
+		return err
+	}
+	for _, r := range i.cache.Get() {
+		if err := spilled.Add(r); err != nil {
+			spilled.Dispose()
+			return err
+		}
+	}
+	i.cleanUp()
+	i.spilled = spilled
+	return nil
+}
+
+// nextSpilled writes the result of the child iterator to the temporary file of spilled rows.
+func (i *cachedResultsIter) nextSpilled(r sql.Row, err error) {
+	if err == io.EOF {
+		if ferr := i.spilled.Finish(); ferr != nil {
+			i.stopCaching()
+			return
+		}
+		i.parent.mutex.Lock()
+		defer i.parent.mutex.Unlock()
+		if i.parent.cache == nil && i.parent.spilled == nil {
+			i.parent.spilled = i.spilled
+		} else {
+			i.spilled.Dispose()
+		}
+		i.spilled = nil
+	} else if err != nil {
+		i.cleanUp()
+	} else if aerr := i.spilled.Add(r); aerr != nil {
+		i.stopCaching()
+	}
+}
+
+// stopCaching discards the rows cached so far and makes the parent node stop caching the rows of its child, as when
+// they can't all be cached.
+func (i *cachedResultsIter) stopCaching() {
+	i.cleanUp()
+	i.parent.mutex.Lock()
+	defer i.parent.mutex.Unlock()
+	i.parent.noCache = true
+}
+
 func (i *cachedResultsIter) setCacheInParent() {
-	if i.parent.cache == nil {
+	if i.parent.cache == nil && i.parent.spilled == nil {
 		i.parent.cache = i.cache
 		i.parent.dispose = i.dispose
 		i.cache = nil
@@ -145,6 +211,10 @@ func (i *cachedResultsIter) cleanUp() {
 		i.cache = nil
 		i.dispose = nil
 	}
+	if i.spilled != nil {
+		i.spilled.Dispose()
+		i.spilled = nil
+	}
 }
 
 func (i *cachedResultsIter) Close(ctx *sql.Context) error {
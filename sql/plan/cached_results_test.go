@@ -0,0 +1,123 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
+)
+
+func TestCachedResultsSpill(t *testing.T) {
+	table := memory.NewTable("t", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "i", Type: sql.Int64, Source: "t", PrimaryKey: true},
+		{Name: "s", Type: sql.Text, Source: "t", Nullable: true},
+		{Name: "f", Type: sql.Float64, Source: "t"},
+		{Name: "ts", Type: sql.Datetime, Source: "t"},
+		{Name: "d", Type: sql.MustCreateDecimalType(10, 2), Source: "t"},
+		{Name: "j", Type: sql.JSON, Source: "t"},
+		{Name: "p", Type: sql.PointType{}, Source: "t"},
+	}))
+	for i := int64(0); i < 200; i++ {
+		var s interface{}
+		if i%7 != 0 {
+			s = fmt.Sprintf("row %d", i)
+		}
+		row := sql.NewRow(
+			i,
+			s,
+			float64(i)/4,
+			time.Date(2022, 1, 1, 0, 0, int(i), 0, time.UTC),
+			decimal.New(i, -2),
+			sql.MustJSON(fmt.Sprintf(`{"i": %d, "a": [1, "x", null]}`, i)),
+			sql.Point{X: float64(i), Y: 1},
+		)
+		require.NoError(t, table.Insert(sql.NewEmptyContext(), row))
+	}
+
+	// collect reads the rows of the node twice, caching them the first time and reading the cache the second time
+	collect := func(t *testing.T, ctx *sql.Context, n *CachedResults) []sql.Row {
+		rows, err := sql.NodeToRows(ctx, n)
+		require.NoError(t, err)
+		cached, err := sql.NodeToRows(ctx, n)
+		require.NoError(t, err)
+		require.Equal(t, rows, cached)
+		return rows
+	}
+
+	inMemory := NewCachedResults(NewResolvedTable(table, nil, nil))
+	expected := collect(t, sql.NewEmptyContext(), inMemory)
+	require.Len(t, expected, 200)
+	require.NotNil(t, inMemory.cache)
+	require.Nil(t, inMemory.spilled)
+	inMemory.Dispose()
+
+	ctx := sql.NewEmptyContext()
+	require.NoError(t, ctx.SetSessionVariable(ctx, spillThresholdSessionVar, uint64(1024)))
+	spilled := NewCachedResults(NewResolvedTable(table, nil, nil))
+	require.Equal(t, expected, collect(t, ctx, spilled))
+	require.Nil(t, spilled.cache)
+	require.NotNil(t, spilled.spilled)
+
+	file := spilled.spilled.file.Name()
+	_, err := os.Stat(file)
+	require.NoError(t, err)
+	spilled.Dispose()
+	_, err = os.Stat(file)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestCachedResultsNonDeterministic(t *testing.T) {
+	table := memory.NewTable("t", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "i", Type: sql.Int64, Source: "t", PrimaryKey: true},
+	}))
+	for i := int64(0); i < 3; i++ {
+		require.NoError(t, table.Insert(sql.NewEmptyContext(), sql.NewRow(i)))
+	}
+
+	// the child returns fresh random values every time it's iterated, as for a joined SELECT i, RAND() FROM t
+	rand, err := function.NewRand()
+	require.NoError(t, err)
+	n := NewCachedResults(NewProject(
+		[]sql.Expression{
+			expression.NewGetFieldWithTable(0, sql.Int64, "t", "i", false),
+			rand,
+		},
+		NewResolvedTable(table, nil, nil),
+	))
+	defer n.Dispose()
+
+	ctx := sql.NewEmptyContext()
+	first, err := sql.NodeToRows(ctx, n)
+	require.NoError(t, err)
+	require.Len(t, first, 3)
+	require.NotNil(t, n.cache)
+
+	// every later iteration, like the ones for each row of the other side of a join, reads the cached rows
+	for i := 0; i < 3; i++ {
+		rows, err := sql.NodeToRows(ctx, n)
+		require.NoError(t, err)
+		require.Equal(t, first, rows)
+	}
+}
@@ -300,6 +300,11 @@ func (c *CreateTable) createIndexes(ctx *sql.Context, tableNode sql.Table, idxes
 	}
 
 	for _, idxDef := range idxes {
+		if idxDef.Constraint == sql.IndexConstraint_Spatial {
+			if err := validateSpatialIndex(tableNode.Schema(), idxDef.Columns); err != nil {
+				return err
+			}
+		}
 		err := idxAlterable.CreateIndex(ctx, idxDef.IndexName, idxDef.Using, idxDef.Constraint, idxDef.Columns, idxDef.Comment)
 		if err != nil {
 			return err
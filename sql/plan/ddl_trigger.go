@@ -17,6 +17,7 @@ package plan
 import (
 	"fmt"
 	"io"
+	"strings"
 	"sync"
 	"time"
 
@@ -33,35 +34,47 @@ type CreateTrigger struct {
 	TriggerName         string
 	TriggerTime         string
 	TriggerEvent        string
+	ForEach             string
 	TriggerOrder        *TriggerOrder
 	Table               sql.Node
 	Body                sql.Node
 	CreateTriggerString string
 	BodyString          string
 	CreatedAt           time.Time
+	Definer             string
+	SqlMode             string
+	IfNotExists         bool
 }
 
 func NewCreateTrigger(triggerDb sql.Database,
 	triggerName,
 	triggerTime,
-	triggerEvent string,
+	triggerEvent,
+	forEach string,
 	triggerOrder *TriggerOrder,
 	table sql.Node,
 	body sql.Node,
 	createTriggerString,
 	bodyString string,
-	createdAt time.Time) *CreateTrigger {
+	createdAt time.Time,
+	definer,
+	sqlMode string,
+	ifNotExists bool) *CreateTrigger {
 	return &CreateTrigger{
 		ddlNode:             ddlNode{db: triggerDb},
 		TriggerName:         triggerName,
 		TriggerTime:         triggerTime,
 		TriggerEvent:        triggerEvent,
+		ForEach:             forEach,
 		TriggerOrder:        triggerOrder,
 		Table:               table,
 		Body:                body,
 		BodyString:          bodyString,
 		CreateTriggerString: createTriggerString,
 		CreatedAt:           createdAt,
+		Definer:             definer,
+		SqlMode:             sqlMode,
+		IfNotExists:         ifNotExists,
 	}
 }
 
@@ -105,26 +118,43 @@ func (c *CreateTrigger) CheckPrivileges(ctx *sql.Context, opChecker sql.Privileg
 }
 
 func (c *CreateTrigger) String() string {
+	definer := ""
+	if c.Definer != "" {
+		definer = fmt.Sprintf(" DEFINER = %s", c.Definer)
+	}
+	ifNotExists := ""
+	if c.IfNotExists {
+		ifNotExists = "IF NOT EXISTS "
+	}
 	order := ""
 	if c.TriggerOrder != nil {
 		order = fmt.Sprintf("%s %s ", c.TriggerOrder.PrecedesOrFollows, c.TriggerOrder.OtherTriggerName)
 	}
-	return fmt.Sprintf("CREATE TRIGGER %s %s %s ON %s FOR EACH ROW %s%s", c.TriggerName, c.TriggerTime, c.TriggerEvent, c.Table, order, c.Body)
+	return fmt.Sprintf("CREATE%s TRIGGER %s%s %s %s ON %s FOR EACH %s %s%s", definer, ifNotExists, c.TriggerName, c.TriggerTime, c.TriggerEvent, c.Table, strings.ToUpper(c.ForEach), order, c.Body)
 }
 
 func (c *CreateTrigger) DebugString() string {
+	definer := ""
+	if c.Definer != "" {
+		definer = fmt.Sprintf(" DEFINER = %s", c.Definer)
+	}
+	ifNotExists := ""
+	if c.IfNotExists {
+		ifNotExists = "IF NOT EXISTS "
+	}
 	order := ""
 	if c.TriggerOrder != nil {
 		order = fmt.Sprintf("%s %s ", c.TriggerOrder.PrecedesOrFollows, c.TriggerOrder.OtherTriggerName)
 	}
-	return fmt.Sprintf("CREATE TRIGGER %s %s %s ON %s FOR EACH ROW %s%s", c.TriggerName, c.TriggerTime, c.TriggerEvent, sql.DebugString(c.Table), order, sql.DebugString(c.Body))
+	return fmt.Sprintf("CREATE%s TRIGGER %s%s %s %s ON %s FOR EACH %s %s%s", definer, ifNotExists, c.TriggerName, c.TriggerTime, c.TriggerEvent, sql.DebugString(c.Table), strings.ToUpper(c.ForEach), order, sql.DebugString(c.Body))
 }
 
 type createTriggerIter struct {
-	once       sync.Once
-	definition sql.TriggerDefinition
-	db         sql.Database
-	ctx        *sql.Context
+	once        sync.Once
+	definition  sql.TriggerDefinition
+	db          sql.Database
+	ctx         *sql.Context
+	ifNotExists bool
 }
 
 func (c *createTriggerIter) Next(ctx *sql.Context) (sql.Row, error) {
@@ -142,7 +172,26 @@ func (c *createTriggerIter) Next(ctx *sql.Context) (sql.Row, error) {
 		return nil, sql.ErrTriggersNotSupported.New(c.db.Name())
 	}
 
-	err := tdb.CreateTrigger(ctx, c.definition)
+	triggers, err := tdb.GetTriggers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, trigger := range triggers {
+		if !strings.EqualFold(trigger.Name, c.definition.Name) {
+			continue
+		}
+		if !c.ifNotExists {
+			return nil, sql.ErrTriggerAlreadyExists.New(c.definition.Name)
+		}
+		ctx.Session.Warn(&sql.Warning{
+			Level:   "Note",
+			Code:    1359, // ER_TRG_ALREADY_EXISTS, which vitess doesn't define
+			Message: "Trigger already exists",
+		})
+		return sql.Row{sql.NewOkResult(0)}, nil
+	}
+
+	err = tdb.CreateTrigger(ctx, c.definition)
 	if err != nil {
 		return nil, err
 	}
@@ -160,7 +209,11 @@ func (c *CreateTrigger) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, err
 			Name:            c.TriggerName,
 			CreateStatement: c.CreateTriggerString,
 			CreatedAt:       c.CreatedAt,
+			Definer:         c.Definer,
+			ForEach:         c.ForEach,
+			SqlMode:         c.SqlMode,
 		},
-		db: c.db,
+		db:          c.db,
+		ifNotExists: c.IfNotExists,
 	}, nil
 }
@@ -140,11 +140,20 @@ func (i *IndexedTableAccess) getLookup(ctx *sql.Context, row sql.Row) (sql.Index
 func (i *IndexedTableAccess) String() string {
 	var filters string
 	if i.lookup != nil {
-		filters = fmt.Sprintf(" with ranges: %s", i.lookup.Ranges().DebugString())
+		filters = fmt.Sprintf(" with %s", lookupFilterString(i.lookup))
 	}
 	return fmt.Sprintf("IndexedTableAccess(%s on %s%s)", i.Name(), formatIndexDecoratorString(i.index), filters)
 }
 
+// lookupFilterString returns the description of the rows matched by a static lookup: the envelope searched by a spatial
+// lookup, and the ranges of any other lookup.
+func lookupFilterString(lookup sql.IndexLookup) string {
+	if spatial, ok := lookup.(sql.SpatialIndexLookup); ok {
+		return fmt.Sprintf("envelope: %s", spatial.Envelope())
+	}
+	return fmt.Sprintf("ranges: %s", lookup.Ranges().DebugString())
+}
+
 func formatIndexDecoratorString(idx sql.Index) string {
 	var expStrs []string
 	for _, e := range idx.Expressions() {
@@ -155,7 +164,7 @@ func formatIndexDecoratorString(idx sql.Index) string {
 
 func (i *IndexedTableAccess) DebugString() string {
 	if i.lookup != nil {
-		filters := fmt.Sprintf(" with ranges: %s,", i.lookup.Ranges().DebugString())
+		filters := fmt.Sprintf(" with %s,", lookupFilterString(i.lookup))
 		return fmt.Sprintf("IndexedTableAccess(%s on %s,%s using fields %s)", i.Name(), formatIndexDecoratorString(i.index), filters, "STATIC LOOKUP("+sql.DebugString(i.lookup)+")")
 	}
 	keyExprs := make([]string, len(i.keyExprs))
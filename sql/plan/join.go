@@ -420,6 +420,15 @@ func joinRowIter(ctx *sql.Context, typ JoinType, left, right sql.Node, cond sql.
 		}
 	}
 
+	// The rows of a lateral secondary depend on the primary row, so they can't be loaded into memory just once.
+	secondary := right
+	if typ == JoinTypeRight {
+		secondary = left
+	}
+	if isLateral(secondary) {
+		mode = multipassMode
+	}
+
 	cache, dispose := ctx.Memory.NewRowsCache()
 	if typ == JoinTypeRight {
 		r, err := right.RowIter(ctx, row)
@@ -461,6 +470,18 @@ func joinRowIter(ctx *sql.Context, typ JoinType, left, right sql.Node, cond sql.
 	}), nil
 }
 
+// isLateral returns whether the node given is a lateral subquery alias, possibly wrapped in a StripRowNode.
+func isLateral(n sql.Node) bool {
+	switch n := n.(type) {
+	case *SubqueryAlias:
+		return n.Lateral
+	case *StripRowNode:
+		return isLateral(n.Child)
+	default:
+		return false
+	}
+}
+
 // joinMode defines the mode in which a join will be performed.
 type joinMode byte
 
@@ -0,0 +1,151 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"io"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// Materialization holds the result set of a common table expression. Every MaterializedResults node built from a
+// reference to the same CTE shares one Materialization, so all of them return identical rows even when the CTE is
+// non-deterministic.
+type Materialization struct {
+	mutex   sync.Mutex
+	cache   sql.RowsCache
+	dispose sql.DisposeFunc
+}
+
+// NewMaterialization returns an empty Materialization.
+func NewMaterialization() *Materialization {
+	return &Materialization{}
+}
+
+// rows returns the materialized rows, computing them from |n| if this is the first request.
+func (m *Materialization) rows(ctx *sql.Context, n sql.Node) ([]sql.Row, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.cache != nil {
+		return m.cache.Get(), nil
+	}
+
+	iter, err := n.RowIter(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	cache, dispose := ctx.Memory.NewRowsCache()
+	for {
+		row, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err == nil {
+			err = cache.Add(row)
+		}
+		if err != nil {
+			dispose()
+			iter.Close(ctx)
+			return nil, err
+		}
+	}
+	if err := iter.Close(ctx); err != nil {
+		dispose()
+		return nil, err
+	}
+
+	m.cache, m.dispose = cache, dispose
+	return m.cache.Get(), nil
+}
+
+// Dispose implements sql.Disposable. It releases the rows so that the next execution of the query computes them anew.
+func (m *Materialization) Dispose() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.dispose != nil {
+		m.dispose()
+	}
+	m.cache = nil
+	m.dispose = nil
+}
+
+// MaterializedResults is a node that evaluates its child to completion the first time any node sharing its
+// Materialization is iterated, and returns the stored rows for that and every later iteration. Unlike CachedResults,
+// rows are never streamed from the child, so every reader sees the same result set.
+type MaterializedResults struct {
+	UnaryNode
+	Materialization *Materialization
+}
+
+var _ sql.Node = (*MaterializedResults)(nil)
+var _ sql.Disposable = (*MaterializedResults)(nil)
+var _ sql.OpaqueNode = (*MaterializedResults)(nil)
+
+// NewMaterializedResults returns a MaterializedResults node over |n| backed by |m|.
+func NewMaterializedResults(n sql.Node, m *Materialization) *MaterializedResults {
+	return &MaterializedResults{UnaryNode: UnaryNode{n}, Materialization: m}
+}
+
+// RowIter implements the sql.Node interface.
+func (n *MaterializedResults) RowIter(ctx *sql.Context, r sql.Row) (sql.RowIter, error) {
+	rows, err := n.Materialization.rows(ctx, n.Child)
+	if err != nil {
+		return nil, err
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// Opaque implements the sql.OpaqueNode interface. The child is iterated with no outer row, even in a subquery, so that
+// every reference stores and reads the same rows; the outer row of a subquery is prepended to the rows of this node
+// instead.
+func (n *MaterializedResults) Opaque() bool {
+	return true
+}
+
+// Dispose implements sql.Disposable.
+func (n *MaterializedResults) Dispose() {
+	n.Materialization.Dispose()
+}
+
+func (n *MaterializedResults) String() string {
+	pr := sql.NewTreePrinter()
+	_ = pr.WriteNode("MaterializedResults")
+	_ = pr.WriteChildren(n.Child.String())
+	return pr.String()
+}
+
+func (n *MaterializedResults) DebugString() string {
+	pr := sql.NewTreePrinter()
+	_ = pr.WriteNode("MaterializedResults")
+	_ = pr.WriteChildren(sql.DebugString(n.Child))
+	return pr.String()
+}
+
+// WithChildren implements the sql.Node interface.
+func (n *MaterializedResults) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(n, len(children), 1)
+	}
+	nn := *n
+	nn.Child = children[0]
+	return &nn, nil
+}
+
+// CheckPrivileges implements the interface sql.Node.
+func (n *MaterializedResults) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	return n.Child.CheckPrivileges(ctx, opChecker)
+}
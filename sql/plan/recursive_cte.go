@@ -22,7 +22,8 @@ import (
 	"github.com/dolthub/go-mysql-server/sql"
 )
 
-const cteRecursionLimit = 1000
+// cteRecursionLimitVariable is the session variable that limits the number of iterations of a recursive CTE.
+const cteRecursionLimitVariable = "cte_max_recursion_depth"
 
 // RecursiveCte is defined by two subqueries
 // connected with a union:
@@ -98,6 +99,15 @@ func (r *RecursiveCte) Schema() sql.Schema {
 
 // RowIter implements sql.Node
 func (r *RecursiveCte) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	limit, err := ctx.GetSessionVariable(ctx, cteRecursionLimitVariable)
+	if err != nil {
+		return nil, err
+	}
+	maxCycles, err := sql.Int64.Convert(limit)
+	if err != nil {
+		return nil, err
+	}
+
 	return &recursiveCteIter{
 		init:        r.Init,
 		rec:         r.Rec,
@@ -105,6 +115,7 @@ func (r *RecursiveCte) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, erro
 		working:     r.working,
 		temp:        make([]sql.Row, 0),
 		deduplicate: r.Deduplicate,
+		maxCycles:   int(maxCycles.(int64)),
 	}, nil
 }
 
@@ -182,6 +193,8 @@ type recursiveCteIter struct {
 	iter sql.RowIter
 	// number of recursive iterations finished
 	cycle int
+	// maximum number of recursive iterations, from cte_max_recursion_depth
+	maxCycles int
 	// buffer to collect intermediate results for next recursion
 	temp []sql.Row
 	// duplicate lookup if [deduplicated] set
@@ -252,7 +265,7 @@ func (r *recursiveCteIter) resetIter(ctx *sql.Context) error {
 		return io.EOF
 	}
 	r.cycle++
-	if r.cycle > cteRecursionLimit {
+	if r.cycle > r.maxCycles {
 		return sql.ErrCteRecursionLimitExceeded.New()
 	}
 
@@ -0,0 +1,140 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"bufio"
+	"encoding/gob"
+	"io"
+	"os"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+const (
+	// spillThresholdSessionVar is the session variable with the number of bytes of rows that are buffered in memory
+	// before they're spilled to disk
+	spillThresholdSessionVar = "tmp_table_size"
+	// spillDirSessionVar is the session variable with the directory that spilled rows are written to
+	spillDirSessionVar = "tmpdir"
+)
+
+func init() {
+	// The values of rows are encoded as interfaces, so every type besides the basic ones must be registered
+	gob.Register(time.Time{})
+	gob.Register(decimal.Decimal{})
+	gob.Register(sql.JSONDocument{})
+	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+	gob.Register(sql.Point{})
+	gob.Register(sql.Linestring{})
+	gob.Register(sql.Polygon{})
+	gob.Register(sql.MultiPoint{})
+	gob.Register(sql.MultiLinestring{})
+	gob.Register(sql.MultiPolygon{})
+	gob.Register(sql.GeometryCollection{})
+	gob.Register(sql.Geometry{})
+}
+
+// spillThreshold returns the number of bytes of rows that may be buffered in memory before they're spilled to disk.
+func spillThreshold(ctx *sql.Context) uint64 {
+	val, err := ctx.GetSessionVariable(ctx, spillThresholdSessionVar)
+	if err != nil {
+		return 0
+	}
+	if threshold, ok := val.(uint64); ok {
+		return threshold
+	}
+	return 0
+}
+
+// estimatedRowSize returns the approximate number of bytes of memory taken by a row, which is compared to the spill
+// threshold.
+func estimatedRowSize(row sql.Row) uint64 {
+	size := uint64(0)
+	for _, v := range row {
+		// every value takes an interface, plus the contents of variable length values
+		size += 16
+		switch v := v.(type) {
+		case string:
+			size += uint64(len(v))
+		case []byte:
+			size += uint64(len(v))
+		}
+	}
+	return size
+}
+
+// spilledRows is a temporary file of rows, which are written once and can then be read any number of times.
+type spilledRows struct {
+	file *os.File
+	buf  *bufio.Writer
+	enc  *gob.Encoder
+}
+
+// newSpilledRows creates a temporary file for rows in the directory of the tmpdir session variable.
+func newSpilledRows(ctx *sql.Context) (*spilledRows, error) {
+	dir := ""
+	if val, err := ctx.GetSessionVariable(ctx, spillDirSessionVar); err == nil {
+		dir, _ = val.(string)
+	}
+	file, err := os.CreateTemp(dir, "gms-spilled-rows-")
+	if err != nil {
+		return nil, err
+	}
+	buf := bufio.NewWriter(file)
+	return &spilledRows{file: file, buf: buf, enc: gob.NewEncoder(buf)}, nil
+}
+
+// Add writes a row to the file.
+func (s *spilledRows) Add(row sql.Row) error {
+	return s.enc.Encode(row)
+}
+
+// Finish flushes the rows written. No rows may be added afterwards.
+func (s *spilledRows) Finish() error {
+	return s.buf.Flush()
+}
+
+// RowIter returns an iterator of the rows written, which reads them from the file.
+func (s *spilledRows) RowIter() sql.RowIter {
+	r := io.NewSectionReader(s.file, 0, 1<<62)
+	return &spilledRowsIter{dec: gob.NewDecoder(bufio.NewReader(r))}
+}
+
+// Dispose removes the file.
+func (s *spilledRows) Dispose() {
+	s.file.Close()
+	os.Remove(s.file.Name())
+}
+
+type spilledRowsIter struct {
+	dec *gob.Decoder
+}
+
+func (i *spilledRowsIter) Next(*sql.Context) (sql.Row, error) {
+	var row sql.Row
+	if err := i.dec.Decode(&row); err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+func (i *spilledRowsIter) Close(*sql.Context) error {
+	return nil
+}
@@ -0,0 +1,443 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// SemiJoin returns the rows of its left child for which at least one row of its right child satisfies the join
+// condition. Each left row is returned at most once, and the schema is that of the left child. The right child is
+// evaluated once and its rows held in memory, so it must not depend on the row of the left child.
+type SemiJoin struct {
+	BinaryNode
+	Cond sql.Expression
+}
+
+var _ sql.Node = (*SemiJoin)(nil)
+var _ sql.Expressioner = (*SemiJoin)(nil)
+
+// NewSemiJoin returns a new SemiJoin node. The condition is evaluated against the concatenation of a left row and a
+// right row.
+func NewSemiJoin(left, right sql.Node, cond sql.Expression) *SemiJoin {
+	return &SemiJoin{
+		BinaryNode: BinaryNode{left, right},
+		Cond:       cond,
+	}
+}
+
+// Schema implements the sql.Node interface.
+func (j *SemiJoin) Schema() sql.Schema {
+	return j.left.Schema()
+}
+
+// Resolved implements the sql.Node interface.
+func (j *SemiJoin) Resolved() bool {
+	return j.BinaryNode.Resolved() && j.Cond.Resolved()
+}
+
+// Expressions implements the sql.Expressioner interface.
+func (j *SemiJoin) Expressions() []sql.Expression {
+	return []sql.Expression{j.Cond}
+}
+
+// WithExpressions implements the sql.Expressioner interface.
+func (j *SemiJoin) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	if len(exprs) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(exprs), 1)
+	}
+	return NewSemiJoin(j.left, j.right, exprs[0]), nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (j *SemiJoin) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), 2)
+	}
+	return NewSemiJoin(children[0], children[1], j.Cond), nil
+}
+
+// CheckPrivileges implements the interface sql.Node.
+func (j *SemiJoin) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	return j.left.CheckPrivileges(ctx, opChecker) && j.right.CheckPrivileges(ctx, opChecker)
+}
+
+// RowIter implements the sql.Node interface.
+func (j *SemiJoin) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	span, ctx := ctx.Span("plan.SemiJoin")
+
+	l, err := j.left.RowIter(ctx, row)
+	if err != nil {
+		span.Finish()
+		return nil, err
+	}
+
+	return sql.NewSpanIter(span, &semiJoinIter{
+		left:        l,
+		right:       j.right,
+		cond:        j.Cond,
+		keys:        hashJoinKeys(j.Cond, len(j.left.Schema())),
+		leftLen:     len(j.left.Schema()),
+		originalRow: row,
+	}), nil
+}
+
+func (j *SemiJoin) String() string {
+	pr := sql.NewTreePrinter()
+	_ = pr.WriteNode("SemiJoin%s", j.Cond)
+	_ = pr.WriteChildren(j.left.String(), j.right.String())
+	return pr.String()
+}
+
+func (j *SemiJoin) DebugString() string {
+	pr := sql.NewTreePrinter()
+	_ = pr.WriteNode("SemiJoin%s", sql.DebugString(j.Cond))
+	_ = pr.WriteChildren(sql.DebugString(j.left), sql.DebugString(j.right))
+	return pr.String()
+}
+
+// AntiJoin returns the rows of its left child for which no row of its right child satisfies the join condition. A
+// condition that evaluates to NULL for any right row also excludes the left row, which gives the semantics of NOT IN.
+// Like SemiJoin, the schema is that of the left child and the right child is evaluated only once.
+type AntiJoin struct {
+	BinaryNode
+	Cond sql.Expression
+}
+
+var _ sql.Node = (*AntiJoin)(nil)
+var _ sql.Expressioner = (*AntiJoin)(nil)
+
+// NewAntiJoin returns a new AntiJoin node. The condition is evaluated against the concatenation of a left row and a
+// right row.
+func NewAntiJoin(left, right sql.Node, cond sql.Expression) *AntiJoin {
+	return &AntiJoin{
+		BinaryNode: BinaryNode{left, right},
+		Cond:       cond,
+	}
+}
+
+// Schema implements the sql.Node interface.
+func (j *AntiJoin) Schema() sql.Schema {
+	return j.left.Schema()
+}
+
+// Resolved implements the sql.Node interface.
+func (j *AntiJoin) Resolved() bool {
+	return j.BinaryNode.Resolved() && j.Cond.Resolved()
+}
+
+// Expressions implements the sql.Expressioner interface.
+func (j *AntiJoin) Expressions() []sql.Expression {
+	return []sql.Expression{j.Cond}
+}
+
+// WithExpressions implements the sql.Expressioner interface.
+func (j *AntiJoin) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	if len(exprs) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(exprs), 1)
+	}
+	return NewAntiJoin(j.left, j.right, exprs[0]), nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (j *AntiJoin) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), 2)
+	}
+	return NewAntiJoin(children[0], children[1], j.Cond), nil
+}
+
+// CheckPrivileges implements the interface sql.Node.
+func (j *AntiJoin) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	return j.left.CheckPrivileges(ctx, opChecker) && j.right.CheckPrivileges(ctx, opChecker)
+}
+
+// RowIter implements the sql.Node interface.
+func (j *AntiJoin) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	span, ctx := ctx.Span("plan.AntiJoin")
+
+	l, err := j.left.RowIter(ctx, row)
+	if err != nil {
+		span.Finish()
+		return nil, err
+	}
+
+	return sql.NewSpanIter(span, &semiJoinIter{
+		anti:        true,
+		left:        l,
+		right:       j.right,
+		cond:        j.Cond,
+		keys:        hashJoinKeys(j.Cond, len(j.left.Schema())),
+		leftLen:     len(j.left.Schema()),
+		originalRow: row,
+	}), nil
+}
+
+func (j *AntiJoin) String() string {
+	pr := sql.NewTreePrinter()
+	_ = pr.WriteNode("AntiJoin%s", j.Cond)
+	_ = pr.WriteChildren(j.left.String(), j.right.String())
+	return pr.String()
+}
+
+func (j *AntiJoin) DebugString() string {
+	pr := sql.NewTreePrinter()
+	_ = pr.WriteNode("AntiJoin%s", sql.DebugString(j.Cond))
+	_ = pr.WriteChildren(sql.DebugString(j.left), sql.DebugString(j.right))
+	return pr.String()
+}
+
+// joinKey is a pair of expressions that the condition of a join requires to be equal, the first one evaluated on the
+// left row and the second one on the right row.
+type joinKey struct {
+	left, right sql.Expression
+}
+
+// hashJoinKeys returns the equalities of the join condition given that the right rows can be hashed on, given the
+// number of columns of the left side. Only integer comparisons are used, since values of other types can be equal
+// without having the same representation.
+func hashJoinKeys(cond sql.Expression, leftLen int) []joinKey {
+	var keys []joinKey
+	for _, e := range splitConjunction(cond) {
+		eq, ok := e.(*expression.Equals)
+		if !ok || !sql.IsInteger(eq.Left().Type()) || !sql.IsInteger(eq.Right().Type()) {
+			continue
+		}
+		switch {
+		case readsOnlyColumns(eq.Left(), 0, leftLen) && readsOnlyColumns(eq.Right(), leftLen, -1):
+			keys = append(keys, joinKey{eq.Left(), eq.Right()})
+		case readsOnlyColumns(eq.Right(), 0, leftLen) && readsOnlyColumns(eq.Left(), leftLen, -1):
+			keys = append(keys, joinKey{eq.Right(), eq.Left()})
+		}
+	}
+	return keys
+}
+
+// splitConjunction breaks AND expressions into their left and right parts, recursively.
+func splitConjunction(e sql.Expression) []sql.Expression {
+	and, ok := e.(*expression.And)
+	if !ok {
+		return []sql.Expression{e}
+	}
+	return append(splitConjunction(and.Left), splitConjunction(and.Right)...)
+}
+
+// readsOnlyColumns returns whether the expression given reads at least one column, and only columns with an index in
+// [start, end). An |end| of -1 means there is no upper bound.
+func readsOnlyColumns(e sql.Expression, start, end int) bool {
+	found := false
+	valid := true
+	sql.Inspect(e, func(e sql.Expression) bool {
+		switch e := e.(type) {
+		case *expression.GetField:
+			found = true
+			if e.Index() < start || (end >= 0 && e.Index() >= end) {
+				valid = false
+			}
+		case sql.NonDeterministicExpression:
+			valid = false
+		case *Subquery:
+			valid = false
+		}
+		return valid
+	})
+	return found && valid
+}
+
+// semiJoinIter is the iterator for both semi joins and anti joins.
+type semiJoinIter struct {
+	// anti is true for an anti join, which returns the left rows that a semi join would not.
+	anti        bool
+	left        sql.RowIter
+	right       rowIterProvider
+	cond        sql.Expression
+	keys        []joinKey
+	leftLen     int
+	originalRow sql.Row
+
+	rightRows sql.RowsCache
+	dispose   sql.DisposeFunc
+	// byKey holds the positions of the right rows for each hash of their join keys, when there are any. unkeyed holds
+	// the positions of the right rows that have a NULL key, which a condition can still evaluate to NULL for.
+	byKey   map[uint64][]int
+	unkeyed []int
+}
+
+// loadRight evaluates the right side of the join into memory.
+func (i *semiJoinIter) loadRight(ctx *sql.Context) error {
+	iter, err := i.right.RowIter(ctx, i.originalRow)
+	if err != nil {
+		return err
+	}
+
+	i.rightRows, i.dispose = ctx.Memory.NewRowsCache()
+	for {
+		row, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err == nil {
+			err = i.rightRows.Add(row)
+		}
+		if err != nil {
+			iter.Close(ctx)
+			return err
+		}
+	}
+
+	if len(i.keys) > 0 {
+		i.byKey = make(map[uint64][]int)
+		for pos, row := range i.rightRows.Get() {
+			hash, ok, err := i.hashKeys(ctx, row, false)
+			if err != nil {
+				iter.Close(ctx)
+				return err
+			}
+			if ok {
+				i.byKey[hash] = append(i.byKey[hash], pos)
+			} else {
+				i.unkeyed = append(i.unkeyed, pos)
+			}
+		}
+	}
+
+	return iter.Close(ctx)
+}
+
+// hashKeys returns the hash of the join keys of the row given, which is a left row if |left| is true and a right row
+// otherwise. Returns false when a key is NULL or can't be converted to a common type, in which case the row must be
+// compared with every row of the other side.
+func (i *semiJoinIter) hashKeys(ctx *sql.Context, row sql.Row, left bool) (uint64, bool, error) {
+	if !left {
+		// The expressions of the right keys index into the concatenation of a left row and a right row
+		row = append(make(sql.Row, i.leftLen), row...)
+	}
+
+	values := make(sql.Row, len(i.keys))
+	for k, key := range i.keys {
+		e := key.right
+		if left {
+			e = key.left
+		}
+		v, err := e.Eval(ctx, row)
+		if err != nil {
+			return 0, false, err
+		}
+		if v == nil {
+			return 0, false, nil
+		}
+		v, err = sql.Int64.Convert(v)
+		if err != nil {
+			return 0, false, nil
+		}
+		values[k] = v
+	}
+
+	hash, err := sql.HashOf(values)
+	if err != nil {
+		return 0, false, err
+	}
+	return hash, true, nil
+}
+
+func (i *semiJoinIter) Next(ctx *sql.Context) (sql.Row, error) {
+	if i.rightRows == nil {
+		if err := i.loadRight(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	for {
+		leftRow, err := i.left.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		matched, err := i.matches(ctx, leftRow)
+		if err != nil {
+			return nil, err
+		}
+		if matched != i.anti {
+			return leftRow, nil
+		}
+	}
+}
+
+// matches returns whether any row of the right side satisfies the join condition for the left row given. For an anti
+// join, a condition that evaluates to NULL also counts as satisfied. When the condition has join keys, only the right
+// rows with the same keys as the left row and the ones with NULL keys are compared, since the condition is false for
+// every other right row.
+func (i *semiJoinIter) matches(ctx *sql.Context, leftRow sql.Row) (bool, error) {
+	rightRows := i.rightRows.Get()
+	if i.byKey == nil {
+		return i.matchesAny(ctx, leftRow, rightRows, nil)
+	}
+
+	hash, ok, err := i.hashKeys(ctx, leftRow, true)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return i.matchesAny(ctx, leftRow, rightRows, nil)
+	}
+
+	for _, positions := range [][]int{i.byKey[hash], i.unkeyed} {
+		if len(positions) == 0 {
+			continue
+		}
+		matched, err := i.matchesAny(ctx, leftRow, rightRows, positions)
+		if err != nil || matched {
+			return matched, err
+		}
+	}
+	return false, nil
+}
+
+// matchesAny returns whether any of the right rows at the positions given satisfies the join condition for the left
+// row given. A nil list of positions means all of the right rows.
+func (i *semiJoinIter) matchesAny(ctx *sql.Context, leftRow sql.Row, rightRows []sql.Row, positions []int) (bool, error) {
+	n := len(rightRows)
+	if positions != nil {
+		n = len(positions)
+	}
+	for p := 0; p < n; p++ {
+		rightRow := rightRows[p]
+		if positions != nil {
+			rightRow = rightRows[positions[p]]
+		}
+		row := make(sql.Row, 0, len(leftRow)+len(rightRow))
+		row = append(row, leftRow...)
+		row = append(row, rightRow...)
+		v, err := i.cond.Eval(ctx, row)
+		if err != nil {
+			return false, err
+		}
+		if v == true || (i.anti && v == nil) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (i *semiJoinIter) Close(ctx *sql.Context) error {
+	if i.dispose != nil {
+		i.dispose()
+		i.dispose = nil
+	}
+	return i.left.Close(ctx)
+}
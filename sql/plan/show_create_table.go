@@ -264,6 +264,8 @@ func (i *showCreateTablesIter) produceCreateTableStatement(ctx *sql.Context, tab
 		unique := ""
 		if index.IsUnique() {
 			unique = "UNIQUE "
+		} else if _, ok := index.(sql.SpatialIndex); ok {
+			unique = "SPATIAL "
 		}
 
 		key := fmt.Sprintf("  %sKEY `%s` (%s)", unique, index.ID(), strings.Join(indexCols, ","))
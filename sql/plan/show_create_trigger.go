@@ -94,7 +94,7 @@ func (s *ShowCreateTrigger) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter,
 			}
 			return sql.RowsToRowIter(sql.Row{
 				trigger.Name,            // Trigger
-				"",                      // sql_mode
+				trigger.SqlMode,         // sql_mode
 				trigger.CreateStatement, // SQL Original Statement
 				characterSetClient,      // character_set_client
 				collationConnection,     // collation_connection
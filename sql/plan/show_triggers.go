@@ -96,8 +96,8 @@ func (s *ShowTriggers) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, erro
 			trigger.BodyString,  // Statement
 			triggerTime,         // Timing
 			trigger.CreatedAt,   // Created
-			"",                  // sql_mode
-			"",                  // Definer
+			trigger.SqlMode,     // sql_mode
+			trigger.Definer,     // Definer
 			characterSetClient,  // character_set_client
 			collationConnection, // collation_connection
 			collationServer,     // Database Collation
@@ -60,8 +60,17 @@ type SignalName struct {
 	Name   string
 }
 
+// Resignal represents the RESIGNAL statement, which passes on the condition of the handler being executed, optionally
+// with a different SQLSTATE or condition name and additional condition information.
+type Resignal struct {
+	SqlStateValue string // Empty when the SQLSTATE of the handled condition is kept
+	Name          string // Set instead of SqlStateValue when a condition name is given, until it's resolved
+	Info          map[SignalConditionItemName]SignalInfo
+}
+
 var _ sql.Node = (*Signal)(nil)
 var _ sql.Node = (*SignalName)(nil)
+var _ sql.Node = (*Resignal)(nil)
 
 // NewSignal returns a *Signal node.
 func NewSignal(sqlstate string, info map[SignalConditionItemName]SignalInfo) *Signal {
@@ -112,6 +121,15 @@ func NewSignalName(name string, info map[SignalConditionItemName]SignalInfo) *Si
 	}
 }
 
+// NewResignal returns a *Resignal node.
+func NewResignal(sqlstate string, name string, info map[SignalConditionItemName]SignalInfo) *Resignal {
+	return &Resignal{
+		SqlStateValue: sqlstate,
+		Name:          name,
+		Info:          info,
+	}
+}
+
 // Resolved implements the sql.Node interface.
 func (s *Signal) Resolved() bool {
 	return true
@@ -225,6 +243,61 @@ func (s *SignalName) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error)
 	return nil, fmt.Errorf("may not iterate over unresolved node *SignalName")
 }
 
+// Resolved implements the sql.Node interface.
+func (r *Resignal) Resolved() bool {
+	return r.Name == ""
+}
+
+// String implements the sql.Node interface.
+func (r *Resignal) String() string {
+	condition := ""
+	if r.Name != "" {
+		condition = " " + r.Name
+	} else if r.SqlStateValue != "" {
+		condition = fmt.Sprintf(" SQLSTATE '%s'", r.SqlStateValue)
+	}
+	infoStr := ""
+	if len(r.Info) > 0 {
+		infoStr = " SET"
+		i := 0
+		for _, info := range r.Info {
+			if i > 0 {
+				infoStr += ","
+			}
+			infoStr += " " + info.String()
+			i++
+		}
+	}
+	return fmt.Sprintf("RESIGNAL%s%s", condition, infoStr)
+}
+
+// Schema implements the sql.Node interface.
+func (r *Resignal) Schema() sql.Schema {
+	return nil
+}
+
+// Children implements the sql.Node interface.
+func (r *Resignal) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (r *Resignal) WithChildren(children ...sql.Node) (sql.Node, error) {
+	return NillaryWithChildren(r, children...)
+}
+
+// CheckPrivileges implements the interface sql.Node.
+func (r *Resignal) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	return true
+}
+
+// RowIter implements the sql.Node interface.
+func (r *Resignal) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	//TODO: pass on the handled condition once DECLARE ... HANDLER is implemented. Until then no handler can be active,
+	// which is an error in MySQL too.
+	return nil, mysql.NewSQLError(1645, "0K000", "RESIGNAL when handler not active")
+}
+
 func (s SignalInfo) String() string {
 	itemName := strings.ToUpper(string(s.ConditionItemName))
 	if s.ConditionItemName == SignalConditionItemName_MysqlErrno {
@@ -17,6 +17,7 @@ package plan
 import (
 	"fmt"
 	"io"
+	"reflect"
 	"sync"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -41,10 +42,29 @@ type Subquery struct {
 	// Dispose function for the cache, if any. This would appear to violate the rule that nodes must be comparable by
 	// reflect.DeepEquals, but it's safe in practice because the function is always nil until execution.
 	disposeFunc sql.DisposeFunc
+	// Sorted indexes of the outer scope columns referenced by the subquery. When set, scalar results are memoized per
+	// distinct tuple of these values.
+	correlationIdxs []int
+	// Memoized scalar results, keyed by the hash of the correlation values
+	correlatedCache map[uint64][]correlatedResult
+	// The number of results in correlatedCache
+	correlatedCacheLen int
 	// Mutex to guard the caches
 	cacheMu sync.Mutex
+	// The error that caused the analysis of the subquery to be deferred to a later pass, if any
+	deferredErr error
 }
 
+// correlatedResult is a memoized scalar subquery result, along with the correlation values it was computed for.
+type correlatedResult struct {
+	key sql.Row
+	val interface{}
+}
+
+// maxCorrelatedCacheEntries bounds the number of distinct correlation keys a subquery will memoize results for.
+// Once the limit is reached, results for new keys are computed but no longer stored.
+const maxCorrelatedCacheEntries = 1024
+
 // NewSubquery returns a new subquery expression.
 func NewSubquery(node sql.Node, queryString string) *Subquery {
 	return &Subquery{Query: node, QueryString: queryString}
@@ -186,6 +206,21 @@ func (s *Subquery) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return s.cache[0], nil
 	}
 
+	useCorrelatedCache := len(s.correlationIdxs) > 0 && s.correlationIdxs[len(s.correlationIdxs)-1] < len(row)
+	var key sql.Row
+	var hash uint64
+	if useCorrelatedCache {
+		var err error
+		key, hash, err = s.correlationKey(row)
+		if err != nil {
+			return nil, err
+		}
+
+		if val, ok := s.correlatedCacheGet(hash, key); ok {
+			return val, nil
+		}
+	}
+
 	rows, err := s.evalMultiple(ctx, row)
 	if err != nil {
 		return nil, err
@@ -195,6 +230,14 @@ func (s *Subquery) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, sql.ErrExpectedSingleRow.New()
 	}
 
+	if useCorrelatedCache {
+		var val interface{}
+		if len(rows) > 0 {
+			val = rows[0]
+		}
+		s.correlatedCachePut(hash, key, val)
+	}
+
 	if s.canCacheResults {
 		s.cacheMu.Lock()
 		if !s.resultsCached {
@@ -209,13 +252,54 @@ func (s *Subquery) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	return rows[0], nil
 }
 
+// correlationKey returns the correlation column values in the row given, and their hash.
+func (s *Subquery) correlationKey(row sql.Row) (sql.Row, uint64, error) {
+	key := make(sql.Row, len(s.correlationIdxs))
+	for i, idx := range s.correlationIdxs {
+		key[i] = row[idx]
+	}
+	hash, err := sql.HashOf(key)
+	if err != nil {
+		return nil, 0, err
+	}
+	return key, hash, nil
+}
+
+// correlatedCacheGet returns the memoized result for the correlation values given, which have the hash given. The
+// values are compared with those the result was computed for, so that colliding hashes don't share results.
+func (s *Subquery) correlatedCacheGet(hash uint64, key sql.Row) (interface{}, bool) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	for _, r := range s.correlatedCache[hash] {
+		if reflect.DeepEqual(r.key, key) {
+			return r.val, true
+		}
+	}
+	return nil, false
+}
+
+// correlatedCachePut memoizes the result given for the correlation values given, which have the hash given, unless
+// the cache is full.
+func (s *Subquery) correlatedCachePut(hash uint64, key sql.Row, val interface{}) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	if s.correlatedCache == nil {
+		s.correlatedCache = make(map[uint64][]correlatedResult)
+	}
+	if s.correlatedCacheLen < maxCorrelatedCacheEntries {
+		s.correlatedCache[hash] = append(s.correlatedCache[hash], correlatedResult{key: key, val: val})
+		s.correlatedCacheLen++
+	}
+}
+
 // prependRowInPlan returns a transformation function that prepends the row given to any row source in a query
 // plan. Any source of rows, as well as any node that alters the schema of its children, will be wrapped so that its
 // result rows are prepended with the row given.
 func prependRowInPlan(row sql.Row) func(n sql.Node) (sql.Node, error) {
 	return func(n sql.Node) (sql.Node, error) {
 		switch n := n.(type) {
-		case *Project, *GroupBy, *Having, *SubqueryAlias, *Window, sql.Table, *ValueDerivedTable, *Union:
+		case *Project, *GroupBy, *Having, *SubqueryAlias, *MaterializedResults, *Window, sql.Table, *ValueDerivedTable,
+			*Union:
 			return &prependNode{
 				UnaryNode: UnaryNode{Child: n},
 				row:       row,
@@ -258,6 +342,7 @@ func (s *Subquery) evalMultiple(ctx *sql.Context, row sql.Row) ([]interface{}, e
 	if err != nil {
 		return nil, err
 	}
+	defer disposeCopiedCachedResults(s.Query, q)
 
 	iter, err := q.RowIter(ctx, row)
 	if err != nil {
@@ -293,6 +378,39 @@ func (s *Subquery) evalMultiple(ctx *sql.Context, row sql.Row) ([]interface{}, e
 	return result, nil
 }
 
+// disposeCopiedCachedResults disposes the CachedResults nodes of the query [q] that aren't nodes of the [original]
+// query it was transformed from. Those are copies made for a single evaluation of a subquery, which aren't disposed
+// with the rest of the query plan.
+func disposeCopiedCachedResults(original, q sql.Node) {
+	originals := make(map[*CachedResults]struct{})
+	Inspect(original, func(n sql.Node) bool {
+		if cr, ok := n.(*CachedResults); ok {
+			originals[cr] = struct{}{}
+		}
+		return true
+	})
+	Inspect(q, func(n sql.Node) bool {
+		if cr, ok := n.(*CachedResults); ok {
+			if _, ok := originals[cr]; !ok {
+				cr.Dispose()
+			}
+		}
+		return true
+	})
+}
+
+// disposeCopiesIter disposes the copied CachedResults nodes of a query transformed for a single evaluation once its
+// iterator is closed.
+type disposeCopiesIter struct {
+	sql.RowIter
+	original, q sql.Node
+}
+
+func (i *disposeCopiesIter) Close(ctx *sql.Context) error {
+	defer disposeCopiedCachedResults(i.original, i.q)
+	return i.RowIter.Close(ctx)
+}
+
 // HashMultiple returns all rows returned by a subquery, backed by a sql.KeyValueCache. Keys are constructed using the
 // 64-bit hash of the values stored.
 func (s *Subquery) HashMultiple(ctx *sql.Context, row sql.Row) (sql.KeyValueCache, error) {
@@ -343,6 +461,7 @@ func (s *Subquery) HasResultRow(ctx *sql.Context, row sql.Row) (bool, error) {
 	if err != nil {
 		return false, err
 	}
+	defer disposeCopiedCachedResults(s.Query, q)
 
 	iter, err := q.RowIter(ctx, row)
 	if err != nil {
@@ -391,6 +510,9 @@ func (s *Subquery) String() string {
 }
 
 func (s *Subquery) DebugString() string {
+	if len(s.correlationIdxs) > 0 {
+		return fmt.Sprintf("(%s), cacheable = %t, correlation cache = %v", sql.DebugString(s.Query), s.canCacheResults, s.correlationIdxs)
+	}
 	return fmt.Sprintf("(%s), cacheable = %t", sql.DebugString(s.Query), s.canCacheResults)
 }
 
@@ -425,11 +547,36 @@ func (s *Subquery) Children() []sql.Expression {
 	return nil
 }
 
+// copy returns a copy of the subquery with everything but its mutex and its caches, which belong to the subquery they
+// were filled for.
+func (s *Subquery) copy() *Subquery {
+	return &Subquery{
+		Query:           s.Query,
+		QueryString:     s.QueryString,
+		canCacheResults: s.canCacheResults,
+		correlationIdxs: s.correlationIdxs,
+		deferredErr:     s.deferredErr,
+	}
+}
+
 // WithQuery returns the subquery with the query node changed.
 func (s *Subquery) WithQuery(node sql.Node) *Subquery {
-	ns := *s
+	ns := s.copy()
 	ns.Query = node
-	return &ns
+	return ns
+}
+
+// WithDeferredError returns the subquery with the error that caused its analysis to be deferred to a later pass.
+func (s *Subquery) WithDeferredError(err error) *Subquery {
+	ns := s.copy()
+	ns.deferredErr = err
+	return ns
+}
+
+// DeferredError returns the error that caused the analysis of the subquery to be deferred to a later pass, or nil if
+// its last analysis succeeded.
+func (s *Subquery) DeferredError() error {
+	return s.deferredErr
 }
 
 func (s *Subquery) IsNonDeterministic() bool {
@@ -438,13 +585,28 @@ func (s *Subquery) IsNonDeterministic() bool {
 
 // WithCachedResults returns the subquery with CanCacheResults set to true.
 func (s *Subquery) WithCachedResults() *Subquery {
-	ns := *s
+	ns := s.copy()
 	ns.canCacheResults = true
-	return &ns
+	return ns
+}
+
+// WithCorrelationCache returns the subquery with scalar results memoized per distinct tuple of the outer scope columns
+// given, which must be sorted. This is only safe when the subquery is deterministic and references no outer scope
+// columns other than these.
+func (s *Subquery) WithCorrelationCache(correlationIdxs []int) *Subquery {
+	ns := s.copy()
+	ns.correlationIdxs = correlationIdxs
+	// Results memoized for the previous correlation columns don't apply to the new ones, so the cache is reset here
+	// rather than relying on copy to leave it out.
+	ns.correlatedCache, ns.correlatedCacheLen = nil, 0
+	return ns
 }
 
 // Dispose implements sql.Disposable
 func (s *Subquery) Dispose() {
+	s.cacheMu.Lock()
+	s.correlatedCache, s.correlatedCacheLen = nil, 0
+	s.cacheMu.Unlock()
 	if s.disposeFunc != nil {
 		s.disposeFunc()
 		s.disposeFunc = nil
@@ -82,3 +82,101 @@ func TestSubqueryMultipleRows(t *testing.T) {
 	require.NoError(err)
 	require.Equal(values, []interface{}{"one", "two", "three"})
 }
+
+func TestSubqueryCorrelationCache(t *testing.T) {
+	require := require.New(t)
+
+	ctx := sql.NewEmptyContext()
+	table := memory.NewTable("foo", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "k", Source: "foo", Type: sql.Int64},
+		{Name: "v", Source: "foo", Type: sql.Text},
+	}))
+
+	require.NoError(table.Insert(ctx, sql.Row{int64(1), "one"}))
+	require.NoError(table.Insert(ctx, sql.Row{int64(2), "two"}))
+	require.NoError(table.Insert(ctx, sql.Row{int64(3), "three"}))
+
+	var executions int
+	counter := &rowIterCounter{UnaryNode: plan.UnaryNode{Child: plan.NewResolvedTable(table, nil, nil)}, count: &executions}
+	subquery := plan.NewSubquery(plan.NewProject(
+		[]sql.Expression{
+			expression.NewGetField(2, sql.Text, "v", false),
+		},
+		plan.NewFilter(
+			expression.NewEquals(
+				expression.NewGetField(0, sql.Int64, "k", false),
+				expression.NewGetField(1, sql.Int64, "k", false),
+			),
+			counter,
+		),
+	), "select v from foo where foo.k = outer.k").WithCorrelationCache([]int{0})
+
+	expected := map[int64]interface{}{1: "one", 2: "two", 3: "three", 4: nil}
+	for i := 0; i < 100; i++ {
+		k := int64(i%4 + 1)
+		value, err := subquery.Eval(ctx, sql.Row{k})
+		require.NoError(err)
+		require.Equal(expected[k], value)
+	}
+
+	require.Equal(4, executions)
+
+	// A subquery with new correlation columns doesn't reuse the results memoized for the old ones
+	recorrelated := subquery.WithCorrelationCache([]int{0})
+	value, err := recorrelated.Eval(ctx, sql.Row{int64(1)})
+	require.NoError(err)
+	require.Equal("one", value)
+	require.Equal(5, executions)
+
+	value, err = subquery.Eval(ctx, sql.Row{int64(1)})
+	require.NoError(err)
+	require.Equal("one", value)
+	require.Equal(5, executions)
+}
+
+func TestSubqueryWithMethodsKeepFields(t *testing.T) {
+	require := require.New(t)
+
+	deferredErr := sql.ErrTableNotFound.New("foo")
+	subquery := plan.NewSubquery(plan.NewUnresolvedTable("foo", ""), "select 1").
+		WithCachedResults().
+		WithDeferredError(deferredErr).
+		WithQuery(plan.NewUnresolvedTable("foo", ""))
+
+	require.Equal("select 1", subquery.QueryString)
+	require.False(subquery.IsNonDeterministic())
+	require.Equal(deferredErr, subquery.DeferredError())
+
+	subquery = subquery.WithDeferredError(nil).WithCachedResults()
+	require.Equal("select 1", subquery.QueryString)
+	require.False(subquery.IsNonDeterministic())
+	require.NoError(subquery.DeferredError())
+}
+
+// rowIterCounter counts the number of times its child is executed. The count is shared by all copies of the node.
+type rowIterCounter struct {
+	plan.UnaryNode
+	count *int
+}
+
+func (c *rowIterCounter) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	*c.count++
+	return c.Child.RowIter(ctx, row)
+}
+
+func (c *rowIterCounter) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(c, len(children), 1)
+	}
+	nc := *c
+	nc.Child = children[0]
+	return &nc, nil
+}
+
+func (c *rowIterCounter) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	return c.Child.CheckPrivileges(ctx, opChecker)
+}
+
+func (c *rowIterCounter) String() string {
+	return c.Child.String()
+}
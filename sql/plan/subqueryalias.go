@@ -15,6 +15,10 @@
 package plan
 
 import (
+	"fmt"
+
+	opentracing "github.com/opentracing/opentracing-go"
+
 	"github.com/dolthub/go-mysql-server/sql"
 )
 
@@ -24,6 +28,12 @@ type SubqueryAlias struct {
 	Columns        []string
 	name           string
 	TextDefinition string
+	// Materialization is shared by every reference to the same non-recursive common table expression referenced more
+	// than once, and is nil for other subquery aliases.
+	Materialization *Materialization
+	// Lateral is true for a LATERAL derived table, which may reference the columns of the tables preceding it in the
+	// FROM clause. Its child is evaluated once per row of the left side of its join.
+	Lateral bool
 }
 
 // NewSubqueryAlias creates a new SubqueryAlias node.
@@ -62,6 +72,10 @@ func (sq *SubqueryAlias) Schema() sql.Schema {
 func (sq *SubqueryAlias) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
 	span, ctx := ctx.Span("plan.SubqueryAlias")
 
+	if sq.Lateral {
+		return sq.lateralRowIter(ctx, span, row)
+	}
+
 	// subqueries do not have access to outer scope
 	iter, err := sq.Child.RowIter(ctx, nil)
 	if err != nil {
@@ -72,6 +86,26 @@ func (sq *SubqueryAlias) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, er
 	return sql.NewSpanIter(span, iter), nil
 }
 
+// lateralRowIter returns an iterator over the child of a lateral subquery alias. Like a subquery expression, the child
+// was analyzed with the left side of the join as its scope, so the row given is prepended to the rows of its sources
+// and stripped from its results.
+func (sq *SubqueryAlias) lateralRowIter(ctx *sql.Context, span opentracing.Span, row sql.Row) (sql.RowIter, error) {
+	child, err := TransformUp(sq.Child, prependRowInPlan(row))
+	if err != nil {
+		span.Finish()
+		return nil, err
+	}
+
+	iter, err := child.RowIter(ctx, row)
+	if err != nil {
+		span.Finish()
+		return nil, err
+	}
+
+	iter = &disposeCopiesIter{RowIter: iter, original: sq.Child, q: child}
+	return sql.NewSpanIter(span, &stripRowIter{iter, len(row)}), nil
+}
+
 // WithChildren implements the Node interface.
 func (sq *SubqueryAlias) WithChildren(children ...sql.Node) (sql.Node, error) {
 	if len(children) != 1 {
@@ -100,19 +134,37 @@ func (sq *SubqueryAlias) Opaque() bool {
 
 func (sq SubqueryAlias) String() string {
 	pr := sql.NewTreePrinter()
-	_ = pr.WriteNode("SubqueryAlias(%s)", sq.name)
+	_ = pr.WriteNode("%s", sq.nodeName())
 	_ = pr.WriteChildren(sq.Child.String())
 	return pr.String()
 }
 
 func (sq SubqueryAlias) DebugString() string {
 	pr := sql.NewTreePrinter()
-	_ = pr.WriteNode("SubqueryAlias(%s)", sq.name)
+	_ = pr.WriteNode("%s", sq.nodeName())
 	_ = pr.WriteChildren(sql.DebugString(sq.Child))
 	return pr.String()
 }
 
+// nodeName returns the name of this node in the String and DebugString output, which marks lateral subquery aliases.
+func (sq SubqueryAlias) nodeName() string {
+	if sq.Lateral {
+		return fmt.Sprintf("SubqueryAlias(%s, lateral)", sq.name)
+	}
+	return fmt.Sprintf("SubqueryAlias(%s)", sq.name)
+}
+
 func (sq SubqueryAlias) WithColumns(columns []string) *SubqueryAlias {
 	sq.Columns = columns
 	return &sq
 }
+
+func (sq SubqueryAlias) WithMaterialization(m *Materialization) *SubqueryAlias {
+	sq.Materialization = m
+	return &sq
+}
+
+func (sq SubqueryAlias) WithLateral(lateral bool) *SubqueryAlias {
+	sq.Lateral = lateral
+	return &sq
+}
@@ -52,3 +52,15 @@ func TestSubqueryAliasSchema(t *testing.T) {
 		NewSubqueryAlias("alias", "", subquery).Schema(),
 	)
 }
+
+func TestSubqueryAliasString(t *testing.T) {
+	require := require.New(t)
+
+	table := memory.NewTable("bar", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "foo", Type: sql.Text, Nullable: false, Source: "bar"},
+	}))
+	sq := NewSubqueryAlias("alias", "", NewResolvedTable(table, nil, nil))
+
+	require.Equal("SubqueryAlias(alias)\n └─ Table(bar)\n", sq.String())
+	require.Equal("SubqueryAlias(alias, lateral)\n └─ Table(bar)\n", sq.WithLateral(true).String())
+}
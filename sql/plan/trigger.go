@@ -37,22 +37,32 @@ const (
 	AfterTrigger              = "after"
 )
 
+type TriggerForEach string
+
+const (
+	RowTrigger       TriggerForEach = "row"
+	StatementTrigger                = "statement"
+)
+
 // TriggerExecutor is node that wraps, or is wrapped by, an INSERT, UPDATE, or DELETE node to execute defined trigger
 // logic either before or after that operation. When a table has multiple triggers defined, TriggerExecutor nodes can
-// wrap each other as well.
+// wrap each other as well. Row triggers execute their logic for each row of the wrapped node, while statement triggers
+// execute it once, before the first row or after the last one.
 type TriggerExecutor struct {
 	BinaryNode        // Left = wrapped node, Right = trigger execution logic
+	TriggerTable      string
 	TriggerEvent      TriggerEvent
 	TriggerTime       TriggerTime
 	TriggerDefinition sql.TriggerDefinition
 }
 
-func NewTriggerExecutor(child, triggerLogic sql.Node, triggerEvent TriggerEvent, triggerTime TriggerTime, triggerDefinition sql.TriggerDefinition) *TriggerExecutor {
+func NewTriggerExecutor(child, triggerLogic sql.Node, triggerTable string, triggerEvent TriggerEvent, triggerTime TriggerTime, triggerDefinition sql.TriggerDefinition) *TriggerExecutor {
 	return &TriggerExecutor{
 		BinaryNode: BinaryNode{
 			left:  child,
 			right: triggerLogic,
 		},
+		TriggerTable:      triggerTable,
 		TriggerEvent:      triggerEvent,
 		TriggerTime:       triggerTime,
 		TriggerDefinition: triggerDefinition,
@@ -82,7 +92,7 @@ func (t *TriggerExecutor) WithChildren(children ...sql.Node) (sql.Node, error) {
 		return nil, sql.ErrInvalidChildrenNumber.New(t, len(children), 2)
 	}
 
-	return NewTriggerExecutor(children[0], children[1], t.TriggerEvent, t.TriggerTime, t.TriggerDefinition), nil
+	return NewTriggerExecutor(children[0], children[1], t.TriggerTable, t.TriggerEvent, t.TriggerTime, t.TriggerDefinition), nil
 }
 
 // CheckPrivileges implements the interface sql.Node.
@@ -95,9 +105,24 @@ func (t *TriggerExecutor) CheckPrivileges(ctx *sql.Context, opChecker sql.Privil
 type triggerIter struct {
 	child          sql.RowIter
 	executionLogic sql.Node
+	triggerTable   string
+	triggerTime    TriggerTime
+	triggerEvent   TriggerEvent
+	sqlMode        string
+	ctx            *sql.Context
+}
+
+// statementTriggerIter executes the logic of a statement trigger once for all the rows of its child: a BEFORE trigger
+// before the child returns its first row, and an AFTER trigger after it returns its last one.
+type statementTriggerIter struct {
+	child          sql.RowIter
+	executionLogic sql.Node
+	triggerTable   string
 	triggerTime    TriggerTime
 	triggerEvent   TriggerEvent
+	sqlMode        string
 	ctx            *sql.Context
+	executed       bool
 }
 
 // prependRowInPlanForTriggerExecution returns a transformation function that prepends the row given to any row source in a query
@@ -128,7 +153,7 @@ func prependRowInPlanForTriggerExecution(row sql.Row) func(c TransformContext) (
 	}
 }
 
-func (t *triggerIter) Next(ctx *sql.Context) (row sql.Row, returnErr error) {
+func (t *triggerIter) Next(ctx *sql.Context) (sql.Row, error) {
 	childRow, err := t.child.Next(ctx)
 	if err != nil {
 		return nil, err
@@ -140,35 +165,11 @@ func (t *triggerIter) Next(ctx *sql.Context) (row sql.Row, returnErr error) {
 		return nil, err
 	}
 
-	// We don't do anything interesting with this subcontext yet, but it's a good idea to cancel it independently of the
-	// parent context if something goes wrong in trigger execution.
-	ctx, cancelFunc := t.ctx.NewSubContext()
-	defer cancelFunc()
-
-	logicIter, err := logic.RowIter(ctx, childRow)
+	logicRow, err := executeTriggerLogic(t.ctx, logic, childRow, t.triggerTable, t.triggerEvent, t.sqlMode)
 	if err != nil {
 		return nil, err
 	}
 
-	defer func() {
-		err := logicIter.Close(t.ctx)
-		if returnErr == nil {
-			returnErr = err
-		}
-	}()
-
-	var logicRow sql.Row
-	for {
-		row, err := logicIter.Next(ctx)
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return nil, err
-		}
-		logicRow = row
-	}
-
 	// For some logic statements, we want to return the result of the logic operation as our row, e.g. a Set that alters
 	// the fields of the new row
 	if ok, returnRow := shouldUseLogicResult(logic, logicRow); ok {
@@ -221,16 +222,105 @@ func (t *triggerIter) Close(ctx *sql.Context) error {
 	return t.child.Close(ctx)
 }
 
+func (t *statementTriggerIter) Next(ctx *sql.Context) (sql.Row, error) {
+	if t.triggerTime == BeforeTrigger && !t.executed {
+		t.executed = true
+		if _, err := executeTriggerLogic(t.ctx, t.executionLogic, nil, t.triggerTable, t.triggerEvent, t.sqlMode); err != nil {
+			return nil, err
+		}
+	}
+
+	row, err := t.child.Next(ctx)
+	if err == io.EOF && t.triggerTime == AfterTrigger && !t.executed {
+		t.executed = true
+		if _, err := executeTriggerLogic(t.ctx, t.executionLogic, nil, t.triggerTable, t.triggerEvent, t.sqlMode); err != nil {
+			return nil, err
+		}
+	}
+	return row, err
+}
+
+func (t *statementTriggerIter) Close(ctx *sql.Context) error {
+	return t.child.Close(ctx)
+}
+
+// executeTriggerLogic executes the trigger logic given on the row given, returning the last row it returns. The logic
+// runs under the sql_mode given, which is the one the trigger was created with, unless it's empty.
+func executeTriggerLogic(parent *sql.Context, logic sql.Node, row sql.Row, triggerTable string, triggerEvent TriggerEvent, sqlMode string) (logicRow sql.Row, returnErr error) {
+	// The subcontext records the table and event being executed, so that any trigger logic that would re-enter them
+	// errors instead of recursing. It's also a good idea to cancel it independently of the parent context if something
+	// goes wrong in trigger execution.
+	ctx, err := parent.WithTriggerFrame(triggerTable, string(triggerEvent))
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelFunc := ctx.NewSubContext()
+	defer cancelFunc()
+
+	if sqlMode != "" {
+		sessionSqlMode, err := ctx.GetSessionVariable(ctx, "sql_mode")
+		if err != nil {
+			return nil, err
+		}
+		if err := ctx.SetSessionVariable(ctx, "sql_mode", sqlMode); err != nil {
+			return nil, err
+		}
+		defer func() {
+			err := ctx.SetSessionVariable(ctx, "sql_mode", sessionSqlMode)
+			if returnErr == nil {
+				returnErr = err
+			}
+		}()
+	}
+
+	logicIter, err := logic.RowIter(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		err := logicIter.Close(parent)
+		if returnErr == nil {
+			returnErr = err
+		}
+	}()
+
+	for {
+		row, err := logicIter.Next(ctx)
+		if err == io.EOF {
+			return logicRow, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		logicRow = row
+	}
+}
+
 func (t *TriggerExecutor) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
 	childIter, err := t.left.RowIter(ctx, row)
 	if err != nil {
 		return nil, err
 	}
 
+	if TriggerForEach(t.TriggerDefinition.ForEach) == StatementTrigger {
+		return &statementTriggerIter{
+			child:          childIter,
+			triggerTable:   t.TriggerTable,
+			triggerTime:    t.TriggerTime,
+			triggerEvent:   t.TriggerEvent,
+			sqlMode:        t.TriggerDefinition.SqlMode,
+			executionLogic: t.right,
+			ctx:            ctx,
+		}, nil
+	}
+
 	return &triggerIter{
 		child:          childIter,
+		triggerTable:   t.TriggerTable,
 		triggerTime:    t.TriggerTime,
 		triggerEvent:   t.TriggerEvent,
+		sqlMode:        t.TriggerDefinition.SqlMode,
 		executionLogic: t.right,
 		ctx:            ctx,
 	}, nil
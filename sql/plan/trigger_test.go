@@ -0,0 +1,68 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestTriggerExecutorRecursion(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	a := memory.NewTable("a", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "x", Type: sql.Int64, Source: "a"},
+	}))
+	b := memory.NewTable("b", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "y", Type: sql.Int64, Source: "b"},
+	}))
+	require.NoError(a.Insert(ctx, sql.NewRow(int64(1))))
+	require.NoError(b.Insert(ctx, sql.NewRow(int64(2))))
+
+	definition := sql.TriggerDefinition{Name: "trig"}
+
+	// A trigger on a whose logic fires a trigger on b is fine
+	nested := NewTriggerExecutor(NewResolvedTable(b, nil, nil), NewResolvedTable(b, nil, nil), "b", InsertTrigger, BeforeTrigger, definition)
+	executor := NewTriggerExecutor(NewResolvedTable(a, nil, nil), nested, "a", InsertTrigger, BeforeTrigger, definition)
+	rows, err := sql.NodeToRows(ctx, executor)
+	require.NoError(err)
+	require.Equal([]sql.Row{{int64(1)}}, rows)
+
+	// A trigger on a whose logic re-enters the insert triggers on a is recursive
+	recursive := NewTriggerExecutor(NewResolvedTable(a, nil, nil), NewResolvedTable(a, nil, nil), "A", InsertTrigger, BeforeTrigger, definition)
+	executor = NewTriggerExecutor(NewResolvedTable(a, nil, nil), recursive, "a", InsertTrigger, BeforeTrigger, definition)
+	_, err = sql.NodeToRows(ctx, executor)
+	require.Error(err)
+	require.True(sql.ErrRecursiveTrigger.Is(err), "unexpected error %v", err)
+
+	// A pair of triggers on a and b whose logic fires each other's triggers is recursive
+	pair := NewTriggerExecutor(NewResolvedTable(a, nil, nil), NewResolvedTable(a, nil, nil), "a", InsertTrigger, BeforeTrigger, definition)
+	nested = NewTriggerExecutor(NewResolvedTable(b, nil, nil), pair, "b", InsertTrigger, BeforeTrigger, definition)
+	executor = NewTriggerExecutor(NewResolvedTable(a, nil, nil), nested, "a", InsertTrigger, BeforeTrigger, definition)
+	_, err = sql.NodeToRows(ctx, executor)
+	require.Error(err)
+	require.True(sql.ErrRecursiveTrigger.Is(err), "unexpected error %v", err)
+
+	// The same table with a different event is a different frame
+	nested = NewTriggerExecutor(NewResolvedTable(a, nil, nil), NewResolvedTable(a, nil, nil), "a", UpdateTrigger, BeforeTrigger, definition)
+	executor = NewTriggerExecutor(NewResolvedTable(a, nil, nil), nested, "a", InsertTrigger, BeforeTrigger, definition)
+	_, err = sql.NodeToRows(ctx, executor)
+	require.NoError(err)
+}
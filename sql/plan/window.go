@@ -16,6 +16,7 @@ package plan
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
@@ -28,6 +29,10 @@ var ErrAggregationMissingWindow = errors.New("aggregation missing window express
 
 type Window struct {
 	SelectExprs []sql.Expression
+	// PartitionLimit, when positive, keeps only the first PartitionLimit rows of each partition of the Window's one
+	// window function, which must be ROW_NUMBER(). The Window then returns only the rows a parent filter on
+	// ROW_NUMBER() <= PartitionLimit would keep, without sorting the others.
+	PartitionLimit int
 	UnaryNode
 }
 
@@ -41,6 +46,13 @@ func NewWindow(selectExprs []sql.Expression, node sql.Node) *Window {
 	}
 }
 
+// WithPartitionLimit returns a copy of this Window with the PartitionLimit given.
+func (w *Window) WithPartitionLimit(limit int) *Window {
+	nw := *w
+	nw.PartitionLimit = limit
+	return &nw
+}
+
 // Resolved implements sql.Node
 func (w *Window) Resolved() bool {
 	return w.UnaryNode.Child.Resolved() &&
@@ -51,9 +63,13 @@ func (w *Window) String() string {
 	pr := sql.NewTreePrinter()
 	var exprs = make([]string, len(w.SelectExprs))
 	for i, expr := range w.SelectExprs {
-		exprs[i] = expr.String()
+		exprs[i] = windowExprString(expr, expr.String())
+	}
+	if w.PartitionLimit > 0 {
+		_ = pr.WriteNode("Window(Limit per partition: [%d]; %s)", w.PartitionLimit, strings.Join(exprs, ", "))
+	} else {
+		_ = pr.WriteNode("Window(%s)", strings.Join(exprs, ", "))
 	}
-	_ = pr.WriteNode("Window(%s)", strings.Join(exprs, ", "))
 	_ = pr.WriteChildren(w.Child.String())
 	return pr.String()
 }
@@ -62,13 +78,40 @@ func (w *Window) DebugString() string {
 	pr := sql.NewTreePrinter()
 	var exprs = make([]string, len(w.SelectExprs))
 	for i, expr := range w.SelectExprs {
-		exprs[i] = sql.DebugString(expr)
+		exprs[i] = windowExprString(expr, sql.DebugString(expr))
+	}
+	if w.PartitionLimit > 0 {
+		_ = pr.WriteNode("Window(Limit per partition: [%d]; %s)", w.PartitionLimit, strings.Join(exprs, ", "))
+	} else {
+		_ = pr.WriteNode("Window(%s)", strings.Join(exprs, ", "))
 	}
-	_ = pr.WriteNode("Window(%s)", strings.Join(exprs, ", "))
 	_ = pr.WriteChildren(sql.DebugString(w.Child))
 	return pr.String()
 }
 
+// windowExprString returns [exprString], the string of a select expression of a Window, followed by the framer that
+// frames its window function, like
+// sum(x) over ( order by y ROWS BETWEEN 1 PRECEDING AND CURRENT ROW) [RowFramer(ROWS BETWEEN 1 PRECEDING AND CURRENT ROW)]
+func windowExprString(expr sql.Expression, exprString string) string {
+	var fn sql.WindowFunction
+	var err error
+	switch e := expr.(type) {
+	case sql.Aggregation:
+		fn, err = e.NewWindowFunction()
+	case sql.WindowAggregation:
+		fn, err = e.NewWindowFunction()
+	default:
+		return exprString
+	}
+	if err != nil {
+		return exprString
+	}
+	if framer, ok := fn.DefaultFramer().(fmt.Stringer); ok {
+		return fmt.Sprintf("%s [%s]", exprString, framer)
+	}
+	return exprString
+}
+
 // Schema implements sql.Node
 func (w *Window) Schema() sql.Schema {
 	var s = make(sql.Schema, len(w.SelectExprs))
@@ -84,7 +127,7 @@ func (w *Window) WithChildren(children ...sql.Node) (sql.Node, error) {
 		return nil, sql.ErrInvalidChildrenNumber.New(w, len(children), 1)
 	}
 
-	return NewWindow(w.SelectExprs, children[0]), nil
+	return NewWindow(w.SelectExprs, children[0]).WithPartitionLimit(w.PartitionLimit), nil
 }
 
 // CheckPrivileges implements the interface sql.Node.
@@ -103,7 +146,7 @@ func (w *Window) WithExpressions(e ...sql.Expression) (sql.Node, error) {
 		return nil, sql.ErrInvalidChildrenNumber.New(w, len(e), len(w.SelectExprs))
 	}
 
-	return NewWindow(e, w.Child), nil
+	return NewWindow(e, w.Child).WithPartitionLimit(w.PartitionLimit), nil
 }
 
 // RowIter implements sql.Node
@@ -117,7 +160,25 @@ func (w *Window) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
 	if err != nil {
 		return nil, err
 	}
-	return aggregation.NewWindowIter(blockIters, outputOrdinals, childIter), nil
+	iter := aggregation.NewWindowIter(blockIters, outputOrdinals, childIter)
+	if w.PartitionLimit > 0 {
+		iter = iter.WithPartitionLimit(w.partitionLimit())
+	}
+	return iter, nil
+}
+
+// partitionLimit returns the aggregation.PartitionLimit of the PartitionLimit of this Window, which partitions and
+// sorts rows like its one window function.
+func (w *Window) partitionLimit() *aggregation.PartitionLimit {
+	limit := &aggregation.PartitionLimit{Limit: w.PartitionLimit}
+	for _, expr := range w.SelectExprs {
+		if wa, ok := expr.(sql.WindowAggregation); ok && wa.Window() != nil {
+			limit.PartitionBy = wa.Window().PartitionBy
+			limit.SortBy = wa.Window().OrderBy
+			break
+		}
+	}
+	return limit
 }
 
 // windowToIter transforms a plan.Window into a series
@@ -101,3 +101,22 @@ func TestWindowPlanToIter(t *testing.T) {
 			expression.NewGetField(2, sql.Int64, "c", false),
 		}})
 }
+
+func TestWindowFrameString(t *testing.T) {
+	one := expression.NewLiteral(int8(1), sql.Int8)
+	require.Equal(t, "ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW", NewRowsUnboundedPrecedingToCurrentRowFrame().String())
+	require.Equal(t, "ROWS BETWEEN 1 PRECEDING AND CURRENT ROW", NewRowsNPrecedingToCurrentRowFrame(one).String())
+	require.Equal(t, "RANGE BETWEEN CURRENT ROW AND UNBOUNDED FOLLOWING", NewRangeCurrentRowToUnboundedFollowingFrame().String())
+}
+
+func TestWindowStringShowsFramers(t *testing.T) {
+	x := expression.NewGetField(0, sql.Int64, "x", true)
+	frame := NewRowsNPrecedingToCurrentRowFrame(expression.NewLiteral(int8(1), sql.Int8))
+	sum, err := aggregation.NewSum(x).WithWindow(sql.NewWindowDefinition(nil, sql.SortFields{{Column: x}}, frame, "", ""))
+	require.NoError(t, err)
+	w := NewWindow([]sql.Expression{x, sum}, NewUnresolvedTable("t", ""))
+
+	require.Contains(t, w.String(), "SUM(x) [RowFramer(ROWS BETWEEN 1 PRECEDING AND CURRENT ROW)]")
+	require.Contains(t, w.DebugString(), "[RowFramer(ROWS BETWEEN 1 PRECEDING AND CURRENT ROW)]")
+	require.NotContains(t, w.String(), "x [")
+}
@@ -15,6 +15,8 @@
 package sql
 
 import (
+	"fmt"
+
 	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/dolthub/vitess/go/vt/proto/query"
 	"gopkg.in/src-d/go-errors.v1"
@@ -26,6 +28,44 @@ type Point struct {
 	SRID uint32
 	X    float64
 	Y    float64
+	// Z and M are the optional elevation and measure ordinates, which are nil for 2D points
+	Z *float64
+	M *float64
+}
+
+// String implements fmt.Stringer. 2D points print like the default format of their fields, as {SRID X Y}.
+func (p Point) String() string {
+	s := fmt.Sprintf("{%v %v %v", p.SRID, p.X, p.Y)
+	if p.Z != nil {
+		s += fmt.Sprintf(" Z=%v", *p.Z)
+	}
+	if p.M != nil {
+		s += fmt.Sprintf(" M=%v", *p.M)
+	}
+	return s + "}"
+}
+
+// Equals returns whether the points have the same SRID and ordinates, including Z and M.
+func (p Point) Equals(o Point) bool {
+	return p.SRID == o.SRID && p.X == o.X && p.Y == o.Y && compareOrdinates(p.Z, o.Z) == 0 && compareOrdinates(p.M, o.M) == 0
+}
+
+// compareOrdinates compares two optional ordinates, where a missing ordinate sorts first.
+func compareOrdinates(a, b *float64) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	case *a < *b:
+		return -1
+	case *a > *b:
+		return 1
+	default:
+		return 0
+	}
 }
 
 type PointType struct{}
@@ -67,12 +107,26 @@ func (t PointType) Compare(a interface{}, b interface{}) (int, error) {
 		return -1, nil
 	}
 
+	// Compare Z and M values
+	if cmp := compareOrdinates(_a.Z, _b.Z); cmp != 0 {
+		return cmp, nil
+	}
+	if cmp := compareOrdinates(_a.M, _b.M); cmp != 0 {
+		return cmp, nil
+	}
+
 	// Points must be the same
 	return 0, nil
 }
 
 // Convert implements Type interface.
 func (t PointType) Convert(v interface{}) (interface{}, error) {
+	// Parse strings and byte arrays
+	v, err := parseSpatialValue(v)
+	if err != nil {
+		return nil, err
+	}
+
 	// Must be a Point, fail otherwise
 	if v, ok := v.(Point); ok {
 		return v, nil
@@ -97,7 +151,13 @@ func (t PointType) SQL(dest []byte, v interface{}) (sqltypes.Value, error) {
 		return sqltypes.Value{}, nil
 	}
 
-	return sqltypes.MakeTrusted(sqltypes.Geometry, []byte(pv.(string))), nil
+	buf, err := SerializeGeometry(pv)
+	if err != nil {
+		return sqltypes.Value{}, err
+	}
+	val := appendAndSlice(dest, buf)
+
+	return sqltypes.MakeTrusted(sqltypes.Geometry, val), nil
 }
 
 // String implements Type interface.
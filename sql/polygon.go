@@ -86,6 +86,12 @@ func (t PolygonType) Compare(a interface{}, b interface{}) (int, error) {
 
 // Convert implements Type interface.
 func (t PolygonType) Convert(v interface{}) (interface{}, error) {
+	// Parse strings and byte arrays
+	v, err := parseSpatialValue(v)
+	if err != nil {
+		return nil, err
+	}
+
 	// Must be a Polygon, fail otherwise
 	if v, ok := v.(Polygon); ok {
 		return v, nil
@@ -110,7 +116,13 @@ func (t PolygonType) SQL(dest []byte, v interface{}) (sqltypes.Value, error) {
 		return sqltypes.Value{}, nil
 	}
 
-	return sqltypes.MakeTrusted(sqltypes.Geometry, []byte(lv.(string))), nil
+	buf, err := SerializeGeometry(lv)
+	if err != nil {
+		return sqltypes.Value{}, err
+	}
+	val := appendAndSlice(dest, buf)
+
+	return sqltypes.MakeTrusted(sqltypes.Geometry, val), nil
 }
 
 // String implements Type interface.
@@ -0,0 +1,304 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// Referenced https://en.wikipedia.org/wiki/R-tree and Guttman's "R-Trees: A Dynamic Index Structure for Spatial
+// Searching" for the quadratic split.
+
+import (
+	"fmt"
+	"math"
+)
+
+// Envelope is the smallest rectangle with sides parallel to the axes that contains a geometry.
+type Envelope struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// Intersects returns whether the envelopes share at least one point.
+func (e Envelope) Intersects(o Envelope) bool {
+	return e.MinX <= o.MaxX && o.MinX <= e.MaxX && e.MinY <= o.MaxY && o.MinY <= e.MaxY
+}
+
+// Contains returns whether every point of the other envelope is in this envelope.
+func (e Envelope) Contains(o Envelope) bool {
+	return e.MinX <= o.MinX && o.MaxX <= e.MaxX && e.MinY <= o.MinY && o.MaxY <= e.MaxY
+}
+
+// Union returns the smallest envelope containing both envelopes.
+func (e Envelope) Union(o Envelope) Envelope {
+	return Envelope{
+		MinX: math.Min(e.MinX, o.MinX),
+		MinY: math.Min(e.MinY, o.MinY),
+		MaxX: math.Max(e.MaxX, o.MaxX),
+		MaxY: math.Max(e.MaxY, o.MaxY),
+	}
+}
+
+// cost returns the measure of an envelope minimized when building an RTree. The half perimeter is added to the area so
+// that envelopes of points and axis-aligned lines, which have no area, still grow as they cover more space.
+func (e Envelope) cost() float64 {
+	w, h := e.MaxX-e.MinX, e.MaxY-e.MinY
+	return w*h + w + h
+}
+
+func (e Envelope) String() string {
+	return fmt.Sprintf("[(%v, %v), (%v, %v)]", e.MinX, e.MinY, e.MaxX, e.MaxY)
+}
+
+// GeometryEnvelope returns the envelope of a geometry. Empty geometries and values that aren't geometries have no
+// envelope, and return false.
+func GeometryEnvelope(v interface{}) (Envelope, bool) {
+	switch v := v.(type) {
+	case Point:
+		return Envelope{MinX: v.X, MinY: v.Y, MaxX: v.X, MaxY: v.Y}, true
+	case Linestring:
+		return pointsEnvelope(v.Points)
+	case Polygon:
+		var geoms []interface{}
+		for _, l := range v.Lines {
+			geoms = append(geoms, l)
+		}
+		return geometriesEnvelope(geoms)
+	case MultiPoint:
+		return pointsEnvelope(v.Points)
+	case MultiLinestring:
+		var geoms []interface{}
+		for _, l := range v.Lines {
+			geoms = append(geoms, l)
+		}
+		return geometriesEnvelope(geoms)
+	case MultiPolygon:
+		var geoms []interface{}
+		for _, p := range v.Polygons {
+			geoms = append(geoms, p)
+		}
+		return geometriesEnvelope(geoms)
+	case GeometryCollection:
+		return geometriesEnvelope(v.Geoms)
+	case Geometry:
+		return GeometryEnvelope(v.Inner)
+	default:
+		return Envelope{}, false
+	}
+}
+
+// pointsEnvelope returns the envelope of the points, or false if there are none.
+func pointsEnvelope(points []Point) (Envelope, bool) {
+	if len(points) == 0 {
+		return Envelope{}, false
+	}
+	env, _ := GeometryEnvelope(points[0])
+	for _, p := range points[1:] {
+		pEnv, _ := GeometryEnvelope(p)
+		env = env.Union(pEnv)
+	}
+	return env, true
+}
+
+// geometriesEnvelope returns the union of the envelopes of the non-empty geometries, or false if there are none.
+func geometriesEnvelope(geoms []interface{}) (Envelope, bool) {
+	var env Envelope
+	found := false
+	for _, g := range geoms {
+		gEnv, ok := GeometryEnvelope(g)
+		if !ok {
+			continue
+		}
+		if found {
+			env = env.Union(gEnv)
+		} else {
+			env, found = gEnv, true
+		}
+	}
+	return env, found
+}
+
+const (
+	// rtreeMaxEntries is the number of entries a node holds before it is split.
+	rtreeMaxEntries = 8
+	// rtreeMinEntries is the smallest number of entries given to either node of a split.
+	rtreeMinEntries = 3
+)
+
+// RTree is a tree of envelopes, used to find the values whose envelopes intersect a search envelope without comparing
+// against every value. Every node covers the envelopes of its children, and nodes that overflow are split with the
+// quadratic split, which groups together the entries that would waste the most space when covered by the same node.
+type RTree struct {
+	root *rtreeNode
+	size int
+}
+
+// rtreeNode is a node of an RTree. The entries of a leaf hold values, and the entries of other nodes hold children.
+type rtreeNode struct {
+	leaf    bool
+	entries []rtreeEntry
+}
+
+// rtreeEntry is the envelope of either a value or a child node.
+type rtreeEntry struct {
+	envelope Envelope
+	child    *rtreeNode
+	value    interface{}
+}
+
+// NewRTree returns an empty RTree.
+func NewRTree() *RTree {
+	return &RTree{root: &rtreeNode{leaf: true}}
+}
+
+// Len returns the number of values in the tree.
+func (t *RTree) Len() int {
+	return t.size
+}
+
+// Insert adds a value with the given envelope to the tree.
+func (t *RTree) Insert(envelope Envelope, value interface{}) {
+	if sibling := t.root.insert(rtreeEntry{envelope: envelope, value: value}); sibling != nil {
+		t.root = &rtreeNode{
+			entries: []rtreeEntry{
+				{envelope: t.root.envelope(), child: t.root},
+				{envelope: sibling.envelope(), child: sibling},
+			},
+		}
+	}
+	t.size++
+}
+
+// Search returns the values whose envelopes intersect the given envelope, in no particular order.
+func (t *RTree) Search(envelope Envelope) []interface{} {
+	var res []interface{}
+	t.root.search(envelope, &res)
+	return res
+}
+
+func (n *rtreeNode) search(envelope Envelope, res *[]interface{}) {
+	for _, e := range n.entries {
+		if !e.envelope.Intersects(envelope) {
+			continue
+		}
+		if n.leaf {
+			*res = append(*res, e.value)
+		} else {
+			e.child.search(envelope, res)
+		}
+	}
+}
+
+// insert adds a leaf entry to the subtree of this node, and returns the new sibling of this node if it was split.
+func (n *rtreeNode) insert(entry rtreeEntry) *rtreeNode {
+	if n.leaf {
+		n.entries = append(n.entries, entry)
+	} else {
+		i := n.chooseSubtree(entry.envelope)
+		child := n.entries[i].child
+		sibling := child.insert(entry)
+		n.entries[i].envelope = child.envelope()
+		if sibling != nil {
+			n.entries = append(n.entries, rtreeEntry{envelope: sibling.envelope(), child: sibling})
+		}
+	}
+
+	if len(n.entries) > rtreeMaxEntries {
+		return n.split()
+	}
+	return nil
+}
+
+// chooseSubtree returns the index of the entry whose envelope grows the least to cover the given envelope, preferring
+// the smallest entry on ties.
+func (n *rtreeNode) chooseSubtree(envelope Envelope) int {
+	best := 0
+	bestGrowth, bestCost := math.Inf(1), math.Inf(1)
+	for i, e := range n.entries {
+		cost := e.envelope.cost()
+		growth := e.envelope.Union(envelope).cost() - cost
+		if growth < bestGrowth || (growth == bestGrowth && cost < bestCost) {
+			best, bestGrowth, bestCost = i, growth, cost
+		}
+	}
+	return best
+}
+
+// split keeps one group of the entries of this node and moves the other to a new sibling, which is returned. The two
+// entries that waste the most space when covered together seed the groups, and the remaining entries are assigned one at
+// a time, starting with the one that prefers one group the most, to the group that grows the least to cover it.
+func (n *rtreeNode) split() *rtreeNode {
+	seed1, seed2 := 0, 1
+	worst := math.Inf(-1)
+	for i := range n.entries {
+		for j := i + 1; j < len(n.entries); j++ {
+			a, b := n.entries[i].envelope, n.entries[j].envelope
+			if waste := a.Union(b).cost() - a.cost() - b.cost(); waste > worst {
+				seed1, seed2, worst = i, j, waste
+			}
+		}
+	}
+
+	group1 := []rtreeEntry{n.entries[seed1]}
+	group2 := []rtreeEntry{n.entries[seed2]}
+	env1, env2 := n.entries[seed1].envelope, n.entries[seed2].envelope
+	var remaining []rtreeEntry
+	for i, e := range n.entries {
+		if i != seed1 && i != seed2 {
+			remaining = append(remaining, e)
+		}
+	}
+
+	for len(remaining) > 0 {
+		// a group that needs every remaining entry to reach the minimum size takes them all
+		if len(group1)+len(remaining) == rtreeMinEntries {
+			group1 = append(group1, remaining...)
+			break
+		}
+		if len(group2)+len(remaining) == rtreeMinEntries {
+			group2 = append(group2, remaining...)
+			break
+		}
+
+		next := 0
+		var growth1, growth2 float64
+		maxPreference := math.Inf(-1)
+		for i, e := range remaining {
+			g1 := env1.Union(e.envelope).cost() - env1.cost()
+			g2 := env2.Union(e.envelope).cost() - env2.cost()
+			if preference := math.Abs(g1 - g2); preference > maxPreference {
+				next, growth1, growth2, maxPreference = i, g1, g2, preference
+			}
+		}
+
+		e := remaining[next]
+		remaining = append(remaining[:next], remaining[next+1:]...)
+		if growth1 < growth2 || (growth1 == growth2 && (env1.cost() < env2.cost() || (env1.cost() == env2.cost() && len(group1) <= len(group2)))) {
+			group1 = append(group1, e)
+			env1 = env1.Union(e.envelope)
+		} else {
+			group2 = append(group2, e)
+			env2 = env2.Union(e.envelope)
+		}
+	}
+
+	n.entries = group1
+	return &rtreeNode{leaf: n.leaf, entries: group2}
+}
+
+// envelope returns the envelope covering every entry of this node.
+func (n *rtreeNode) envelope() Envelope {
+	env := n.entries[0].envelope
+	for _, e := range n.entries[1:] {
+		env = env.Union(e.envelope)
+	}
+	return env
+}
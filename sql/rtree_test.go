@@ -0,0 +1,138 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeometryEnvelope(t *testing.T) {
+	tests := []struct {
+		name     string
+		geom     interface{}
+		expected Envelope
+		ok       bool
+	}{
+		{"point", Point{X: 1, Y: 2}, Envelope{1, 2, 1, 2}, true},
+		{"linestring", Linestring{Points: []Point{{X: 1, Y: 5}, {X: -2, Y: 3}}}, Envelope{-2, 3, 1, 5}, true},
+		{"polygon", Polygon{Lines: []Linestring{{Points: []Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 0, Y: 3}, {X: 0, Y: 0}}}}}, Envelope{0, 0, 4, 3}, true},
+		{"multipoint", MultiPoint{Points: []Point{{X: 1, Y: 1}, {X: 3, Y: -1}}}, Envelope{1, -1, 3, 1}, true},
+		{"geometry collection", GeometryCollection{Geoms: []interface{}{Point{X: 5, Y: 5}, MultiPoint{}, Linestring{Points: []Point{{X: 0, Y: 1}, {X: 1, Y: 0}}}}}, Envelope{0, 0, 5, 5}, true},
+		{"geometry", Geometry{Inner: Point{X: 1, Y: 2}}, Envelope{1, 2, 1, 2}, true},
+		{"empty linestring", Linestring{}, Envelope{}, false},
+		{"empty geometry collection", GeometryCollection{}, Envelope{}, false},
+		{"not a geometry", 1, Envelope{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, ok := GeometryEnvelope(tt.geom)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.expected, env)
+		})
+	}
+}
+
+func TestEnvelope(t *testing.T) {
+	a := Envelope{0, 0, 2, 2}
+	require.True(t, a.Intersects(Envelope{2, 2, 3, 3}))
+	require.True(t, a.Intersects(Envelope{-1, 1, 3, 1}))
+	require.False(t, a.Intersects(Envelope{2.5, 0, 3, 3}))
+	require.True(t, a.Contains(Envelope{1, 1, 2, 2}))
+	require.False(t, a.Contains(Envelope{1, 1, 3, 2}))
+	require.Equal(t, Envelope{-1, 0, 2, 5}, a.Union(Envelope{-1, 3, 0, 5}))
+}
+
+func TestRTree(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	tree := NewRTree()
+	var envelopes []Envelope
+	for i := 0; i < 1000; i++ {
+		x, y := r.Float64()*1000, r.Float64()*1000
+		// mix points with rectangles of different sizes
+		var w, h float64
+		if i%3 != 0 {
+			w, h = r.Float64()*20, r.Float64()*20
+		}
+		env := Envelope{x, y, x + w, y + h}
+		envelopes = append(envelopes, env)
+		tree.Insert(env, i)
+	}
+	require.Equal(t, 1000, tree.Len())
+
+	t.Run("structure", func(t *testing.T) {
+		var check func(n *rtreeNode, isRoot bool) int
+		check = func(n *rtreeNode, isRoot bool) int {
+			require.LessOrEqual(t, len(n.entries), rtreeMaxEntries)
+			if !isRoot {
+				require.GreaterOrEqual(t, len(n.entries), rtreeMinEntries)
+			}
+			if n.leaf {
+				return 1
+			}
+			depth := -1
+			for _, e := range n.entries {
+				require.Equal(t, e.child.envelope(), e.envelope)
+				childDepth := check(e.child, false)
+				if depth != -1 {
+					// every leaf is at the same depth
+					require.Equal(t, depth, childDepth)
+				}
+				depth = childDepth
+			}
+			return depth + 1
+		}
+		require.Greater(t, check(tree.root, true), 2)
+	})
+
+	queries := []Envelope{
+		{0, 0, 1000, 1000},
+		{100, 100, 200, 200},
+		{500, 500, 500, 500},
+		{-10, -10, -1, -1},
+		{990, 0, 1020, 1020},
+	}
+	for _, q := range queries {
+		t.Run(q.String(), func(t *testing.T) {
+			var expected []int
+			for i, env := range envelopes {
+				if env.Intersects(q) {
+					expected = append(expected, i)
+				}
+			}
+
+			var actual []int
+			for _, v := range tree.Search(q) {
+				actual = append(actual, v.(int))
+			}
+			sort.Ints(actual)
+			require.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestRTreeEmpty(t *testing.T) {
+	tree := NewRTree()
+	require.Equal(t, 0, tree.Len())
+	require.Empty(t, tree.Search(Envelope{0, 0, 1, 1}))
+
+	tree.Insert(Envelope{0, 0, 0, 0}, "a")
+	require.Equal(t, []interface{}{"a"}, tree.Search(Envelope{-1, -1, 1, 1}))
+	require.Empty(t, tree.Search(Envelope{1, 1, 2, 2}))
+}
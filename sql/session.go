@@ -540,6 +540,16 @@ type Context struct {
 	queryTime   time.Time
 	tracer      opentracing.Tracer
 	rootSpan    opentracing.Span
+	triggers    *triggerFrame
+	nesting     int
+}
+
+// triggerFrame records a table and event whose triggers are currently executing. Frames form an immutable linked list,
+// so that pushing a frame onto a sub-context never affects the parent context.
+type triggerFrame struct {
+	table  string
+	event  string
+	parent *triggerFrame
 }
 
 // ContextOption is a function to configure the context.
@@ -593,6 +603,14 @@ func WithProcessList(p ProcessList) ContextOption {
 	}
 }
 
+// WithQueryTime sets the time of the query of the context, which is otherwise the time the context is created. It lets
+// an embedder fix the time returned by functions like NOW() for a query.
+func WithQueryTime(t time.Time) ContextOption {
+	return func(ctx *Context) {
+		ctx.queryTime = t
+	}
+}
+
 // WithServices sets the services for the Context
 func WithServices(services Services) ContextOption {
 	return func(ctx *Context) {
@@ -603,6 +621,7 @@ func WithServices(services Services) ContextOption {
 var ctxNowFunc = time.Now
 var ctxNowFuncMutex = &sync.Mutex{}
 
+// RunWithNowFunc runs fn with nowFunc as the clock read for the query time of the contexts created by NewContext.
 func RunWithNowFunc(nowFunc func() time.Time, fn func() error) error {
 	ctxNowFuncMutex.Lock()
 	defer ctxNowFuncMutex.Unlock()
@@ -669,7 +688,9 @@ func (c Context) WithQuery(q string) *Context {
 	return &c
 }
 
-// QueryTime returns the time.Time when the context associated with this query was created
+// QueryTime returns the time.Time when the context associated with this query was created, unless set by
+// WithQueryTime. It's fixed for the query, so every function of the current time, like NOW(), CURDATE(), CURTIME() and
+// UNIX_TIMESTAMP(), returns the same time in all its evaluations in the query, like MySQL does for a statement.
 func (c *Context) QueryTime() time.Time {
 	return c.queryTime
 }
@@ -711,6 +732,42 @@ func (c *Context) WithContext(ctx context.Context) *Context {
 	return &nc
 }
 
+// WithTriggerFrame returns a new context recording that the triggers for the table and event given are executing.
+// Returns ErrRecursiveTrigger if triggers for the same table and event are already executing in this context.
+func (c *Context) WithTriggerFrame(table, event string) (*Context, error) {
+	table, event = strings.ToLower(table), strings.ToLower(event)
+	for f := c.triggers; f != nil; f = f.parent {
+		if f.table == table && f.event == event {
+			return nil, ErrRecursiveTrigger.New(strings.ToUpper(event), table)
+		}
+	}
+
+	nc := *c
+	nc.triggers = &triggerFrame{
+		table:  table,
+		event:  event,
+		parent: c.triggers,
+	}
+	return &nc, nil
+}
+
+// WithNestingDepth returns a new context for a subquery or common table expression nested one level deeper than the
+// query of this context. Returns ErrMaxNestingDepthExceeded if that level is deeper than the maximum given.
+func (c *Context) WithNestingDepth(max int) (*Context, error) {
+	if c.nesting >= max {
+		return nil, ErrMaxNestingDepthExceeded.New(max)
+	}
+
+	nc := *c
+	nc.nesting = c.nesting + 1
+	return &nc, nil
+}
+
+// NestingDepth returns the number of subqueries and common table expressions the query of this context is nested in.
+func (c *Context) NestingDepth() int {
+	return c.nesting
+}
+
 // RootSpan returns the root span, if any.
 func (c *Context) RootSpan() opentracing.Span {
 	return c.rootSpan
@@ -18,6 +18,7 @@ import (
 	"context"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -140,3 +141,21 @@ func TestSessionIterator(t *testing.T) {
 		counter++
 	}
 }
+
+func TestContextQueryTime(t *testing.T) {
+	queryTime := time.Date(2021, time.March, 14, 1, 59, 26, 535897932, time.UTC)
+	ctx := NewContext(context.Background(), WithQueryTime(queryTime))
+	require.Equal(t, queryTime, ctx.QueryTime())
+	require.Equal(t, queryTime, ctx.QueryTime())
+
+	subCtx, cancel := ctx.NewSubContext()
+	defer cancel()
+	require.Equal(t, queryTime, subCtx.QueryTime())
+	require.Equal(t, queryTime, ctx.WithCurrentDB("mydb").QueryTime())
+
+	err := RunWithNowFunc(func() time.Time { return queryTime }, func() error {
+		require.Equal(t, queryTime, NewEmptyContext().QueryTime())
+		return nil
+	})
+	require.NoError(t, err)
+}
@@ -221,6 +221,28 @@ func (t stringType) Compare(a interface{}, b interface{}) (int, error) {
 	return strings.Compare(as, bs), nil
 }
 
+// CollatedCompare compares two values of the type given like Type.Compare, except that the values of string types are
+// compared with the collation of the type rather than byte by byte.
+func CollatedCompare(t Type, a interface{}, b interface{}) (int, error) {
+	st, ok := t.(StringType)
+	if !ok {
+		return t.Compare(a, b)
+	}
+	if hasNulls, res := compareNulls(a, b); hasNulls {
+		return res, nil
+	}
+
+	as, err := st.Convert(a)
+	if err != nil {
+		return 0, err
+	}
+	bs, err := st.Convert(b)
+	if err != nil {
+		return 0, err
+	}
+	return st.Collation().Compare(as.(string), bs.(string)), nil
+}
+
 // Convert implements Type interface.
 func (t stringType) Convert(v interface{}) (interface{}, error) {
 	if v == nil {
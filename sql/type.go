@@ -458,19 +458,22 @@ func ColumnTypeToType(ct *sqlparser.ColumnType) (Type, error) {
 	case "geometry":
 		return GeometryType{}, nil
 	case "geometrycollection":
+		return GeometryCollectionType{}, nil
 	case "linestring":
 		return LinestringType{}, nil
 	case "multilinestring":
+		return MultiLinestringType{}, nil
 	case "point":
 		return PointType{}, nil
 	case "multipoint":
+		return MultiPointType{}, nil
 	case "polygon":
 		return PolygonType{}, nil
 	case "multipolygon":
+		return MultiPolygonType{}, nil
 	default:
 		return nil, fmt.Errorf("unknown type: %v", ct.Type)
 	}
-	return nil, fmt.Errorf("type not yet implemented: %v", ct.Type)
 }
 
 func ConvertToBool(v interface{}) (bool, error) {
@@ -593,6 +596,17 @@ func IsSigned(t Type) bool {
 	return t == Int8 || t == Int16 || t == Int32 || t == Int64
 }
 
+// IsSpatial checks if t is GEOMETRY or one of the types of geometries.
+func IsSpatial(t Type) bool {
+	switch t.(type) {
+	case GeometryType, PointType, LinestringType, PolygonType, MultiPointType, MultiLinestringType, MultiPolygonType,
+		GeometryCollectionType:
+		return true
+	default:
+		return false
+	}
+}
+
 // IsText checks if t is a text type.
 func IsText(t Type) bool {
 	_, ok := t.(stringType)
@@ -0,0 +1,266 @@
+// Copyright 2020-2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Header contains endianness (1 byte) and geometry type (4 bytes)
+const WKBHeaderLength = 5
+
+// Type IDs
+const (
+	WKBUnknown = iota
+	WKBPointID
+	WKBLineID
+	WKBPolyID
+)
+
+// serializePoint fills in buf with the values from point
+func serializePoint(p Point, buf []byte) {
+	// Assumes buf is correct size
+	binary.LittleEndian.PutUint64(buf[0:8], math.Float64bits(p.X))
+	binary.LittleEndian.PutUint64(buf[8:16], math.Float64bits(p.Y))
+}
+
+// PointToBytes converts a Point to a byte array
+func PointToBytes(p Point) []byte {
+	// Initialize point buffer
+	buf := make([]byte, 16)
+	serializePoint(p, buf)
+	return buf
+}
+
+// serializeLine fills in buf with values from linestring
+func serializeLine(l Linestring, buf []byte) {
+	// Write number of points
+	binary.LittleEndian.PutUint32(buf[0:4], uint32(len(l.Points)))
+	// Append each point
+	for i, p := range l.Points {
+		start, stop := 4+16*i, 4+16*(i+1)
+		serializePoint(p, buf[start:stop])
+	}
+}
+
+// LineToBytes converts a Linestring to a byte array
+func LineToBytes(l Linestring) []byte {
+	// Initialize line buffer
+	buf := make([]byte, 4+16*len(l.Points))
+	serializeLine(l, buf)
+	return buf
+}
+
+func serializePoly(p Polygon, buf []byte) {
+	// Write number of lines
+	binary.LittleEndian.PutUint32(buf[0:4], uint32(len(p.Lines)))
+	// Append each line
+	start, stop := 0, 4
+	for _, l := range p.Lines {
+		start, stop = stop, stop+4+16*len(l.Points)
+		serializeLine(l, buf[start:stop])
+	}
+}
+
+// PolyToBytes converts a Polygon to a byte array
+func PolyToBytes(p Polygon) []byte {
+	// Initialize polygon buffer
+	size := 0
+	for _, l := range p.Lines {
+		size += 4 + 16*len(l.Points)
+	}
+	buf := make([]byte, 4+size)
+	serializePoly(p, buf)
+	return buf
+}
+
+// SRIDLength is the length of the SRID that precedes the WKB of a geometry in MySQL's internal geometry format
+const SRIDLength = 4
+
+// SerializeGeometry converts a point, a linestring, or a polygon, which may be wrapped in a Geometry, to MySQL's
+// internal geometry format, which is how clients send and receive geometry values: its SRID as a little-endian 4-byte
+// integer, followed by its little-endian WKB.
+func SerializeGeometry(v interface{}) ([]byte, error) {
+	var srid, geomType uint32
+	var data []byte
+	switch v := v.(type) {
+	case Geometry:
+		return SerializeGeometry(v.Inner)
+	case Point:
+		srid, geomType, data = v.SRID, WKBPointID, PointToBytes(v)
+	case Linestring:
+		srid, geomType, data = v.SRID, WKBLineID, LineToBytes(v)
+	case Polygon:
+		srid, geomType, data = v.SRID, WKBPolyID, PolyToBytes(v)
+	default:
+		return nil, ErrNotGeometry.New(v)
+	}
+
+	buf := make([]byte, SRIDLength+WKBHeaderLength, SRIDLength+WKBHeaderLength+len(data))
+	binary.LittleEndian.PutUint32(buf[:SRIDLength], srid)
+	buf[SRIDLength] = 1
+	binary.LittleEndian.PutUint32(buf[SRIDLength+1:], geomType)
+	return append(buf, data...), nil
+}
+
+// DeserializeGeometry parses a value in MySQL's internal geometry format, an SRID followed by the WKB of a point, a
+// linestring, or a polygon, and returns the concrete geometry with that SRID.
+func DeserializeGeometry(buf []byte) (interface{}, error) {
+	if len(buf) < SRIDLength {
+		return nil, ErrInvalidGISData.New("ST_GeomFromWKB")
+	}
+	srid := binary.LittleEndian.Uint32(buf[:SRIDLength])
+	return parseWKB(buf[SRIDLength:], srid)
+}
+
+// parseWKB parses the WKB of a point, a linestring, or a polygon, and returns the concrete geometry with the SRID given
+// and the default axis order.
+func parseWKB(buf []byte, srid uint32) (interface{}, error) {
+	isBig, geomType, err := ParseWKBHeader(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	switch geomType {
+	case WKBPointID:
+		return WKBToPoint(buf[WKBHeaderLength:], isBig, srid, false)
+	case WKBLineID:
+		return WKBToLine(buf[WKBHeaderLength:], isBig, srid, false)
+	case WKBPolyID:
+		return WKBToPoly(buf[WKBHeaderLength:], isBig, srid, false)
+	default:
+		return nil, ErrInvalidGISData.New("ST_GeomFromWKB")
+	}
+}
+
+// ParseWKBHeader parses the header portion of a byte array in WKB format to extract endianness and type
+func ParseWKBHeader(buf []byte) (bool, uint32, error) {
+	// Header length
+	if len(buf) < WKBHeaderLength {
+		return false, 0, ErrInvalidGISData.New("ST_GeomFromWKB3")
+	}
+
+	// Get Endianness
+	isBig := buf[0] == 0
+
+	// Get Geometry Type
+	var geomType uint32
+	if isBig {
+		geomType = binary.BigEndian.Uint32(buf[1:5])
+	} else {
+		geomType = binary.LittleEndian.Uint32(buf[1:5])
+	}
+
+	return isBig, geomType, nil
+}
+
+// WKBToPoint parses the data portion of a byte array in WKB format to a point object
+func WKBToPoint(buf []byte, isBig bool, srid uint32, order bool) (Point, error) {
+	// Must be 16 bytes (2 floats)
+	if len(buf) != 16 {
+		return Point{}, ErrInvalidGISData.New("ST_PointFromWKB1")
+	}
+
+	// Read floats x and y
+	var x, y float64
+	if isBig {
+		x = math.Float64frombits(binary.BigEndian.Uint64(buf[:8]))
+		y = math.Float64frombits(binary.BigEndian.Uint64(buf[8:]))
+	} else {
+		x = math.Float64frombits(binary.LittleEndian.Uint64(buf[:8]))
+		y = math.Float64frombits(binary.LittleEndian.Uint64(buf[8:]))
+	}
+
+	// Determine if bool needs to be flipped
+	if order {
+		x, y = y, x
+	}
+
+	return Point{SRID: srid, X: x, Y: y}, nil
+}
+
+// WKBToLine parses the data portion of a byte array in WKB format to a point object
+func WKBToLine(buf []byte, isBig bool, srid uint32, order bool) (Linestring, error) {
+	// Must be at least 4 bytes (length of linestring)
+	if len(buf) < 4 {
+		return Linestring{}, ErrInvalidGISData.New("ST_LineFromWKB")
+	}
+
+	// Read length of line string
+	var numPoints uint32
+	if isBig {
+		numPoints = binary.BigEndian.Uint32(buf[:4])
+	} else {
+		numPoints = binary.LittleEndian.Uint32(buf[:4])
+	}
+
+	// Extract line data
+	lineData := buf[4:]
+
+	// Check length
+	if uint32(len(lineData)) < 16*numPoints {
+		return Linestring{}, ErrInvalidGISData.New("ST_LineFromWKB")
+	}
+
+	// Parse points
+	points := make([]Point, numPoints)
+	for i := uint32(0); i < numPoints; i++ {
+		if point, err := WKBToPoint(lineData[16*i:16*(i+1)], isBig, srid, order); err == nil {
+			points[i] = point
+		} else {
+			return Linestring{}, ErrInvalidGISData.New("ST_LineFromWKB")
+		}
+	}
+
+	return Linestring{SRID: srid, Points: points}, nil
+}
+
+// WKBToPoly parses the data portion of a byte array in WKB format to a point object
+func WKBToPoly(buf []byte, isBig bool, srid uint32, order bool) (Polygon, error) {
+	// Must be at least 4 bytes (length of polygon)
+	if len(buf) < 4 {
+		return Polygon{}, ErrInvalidGISData.New("ST_PolyFromWKB1")
+	}
+
+	// Get number of lines in polygon
+	var numLines uint32
+	if isBig {
+		numLines = binary.BigEndian.Uint32(buf[:4])
+	} else {
+		numLines = binary.LittleEndian.Uint32(buf[:4])
+	}
+
+	// Extract poly data
+	polyData := buf[4:]
+
+	// Parse lines
+	s := 0
+	lines := make([]Linestring, numLines)
+	for i := uint32(0); i < numLines; i++ {
+		if line, err := WKBToLine(polyData[s:], isBig, srid, order); err == nil {
+			if line.IsLinearRing() {
+				lines[i] = line
+				s += 4 + 16*len(line.Points) // shift parsing location over
+			} else {
+				return Polygon{}, ErrInvalidGISData.New("ST_PolyFromWKB2")
+			}
+		} else {
+			return Polygon{}, ErrInvalidGISData.New("ST_PolyFromWKB3")
+		}
+	}
+
+	return Polygon{SRID: srid, Lines: lines}, nil
+}
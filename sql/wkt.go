@@ -0,0 +1,448 @@
+// Copyright 2020-2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// WKTDimension returns the dimension of a point as written after a WKT geometry type, which is one of "", "z", "m",
+// or "zm".
+func WKTDimension(p Point) string {
+	var dim string
+	if p.Z != nil {
+		dim += "z"
+	}
+	if p.M != nil {
+		dim += "m"
+	}
+	return dim
+}
+
+// wktGeometryTypes are the geometry types that may be written with a dimension suffix and no space, like "POINTZ".
+var wktGeometryTypes = []string{"point", "linestring", "polygon", "multipoint", "multilinestring", "multipolygon", "geometrycollection"}
+
+// ParseWKTHeader extracts the type of the geometry string, the dimension declared after it, which is one of "", "z", "m",
+// or "zm", and the data between the parentheses that follow.
+func ParseWKTHeader(s string) (string, string, string, error) {
+	// Read until first open parenthesis
+	end := strings.Index(s, "(")
+
+	// Bad if no parenthesis found
+	if end == -1 {
+		return "", "", "", ErrInvalidGISData.New("ST_GeomFromText")
+	}
+
+	// Get Geometry Type
+	geomType := s[:end]
+	geomType = strings.TrimSpace(geomType)
+	geomType = strings.ToLower(geomType)
+
+	// Split off the dimension, written either as a separate word like "POINT Z" or as a suffix like "POINTZM"
+	var dim string
+	if fields := strings.Fields(geomType); len(fields) == 2 {
+		switch fields[1] {
+		case "z", "m", "zm":
+			geomType, dim = fields[0], fields[1]
+		default:
+			return "", "", "", ErrInvalidGISData.New("ST_GeomFromText")
+		}
+	} else {
+		for _, t := range wktGeometryTypes {
+			if suffix := strings.TrimPrefix(geomType, t); suffix == "z" || suffix == "m" || suffix == "zm" {
+				geomType, dim = t, suffix
+				break
+			}
+		}
+	}
+
+	// Find the parenthesis closing the first one
+	depth := 0
+	closing := -1
+	for i := end; i < len(s) && closing == -1; i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				closing = i
+			}
+		}
+	}
+
+	// Bad if the parentheses are unbalanced, or if anything other than whitespace follows them
+	if closing == -1 || strings.TrimSpace(s[closing+1:]) != "" {
+		return "", "", "", ErrInvalidGISData.New("ST_GeomFromText")
+	}
+
+	// Get data without the surrounding parentheses, and trim
+	data := s[end+1 : closing]
+	data = strings.TrimSpace(data)
+
+	return geomType, dim, data, nil
+}
+
+// WKTToPoint expects a string like this "1.2 3.4". A third number is the Z ordinate, and a fourth the M ordinate. The
+// point must have the dimension [dim] declared in the WKT header, and an empty dimension accepts two, three, or four
+// numbers. Errors name the function [fnName] that parses the string.
+func WKTToPoint(s string, srid uint32, order bool, dim, fnName string) (Point, error) {
+	// Empty string is wrong
+	if len(s) == 0 {
+		return Point{}, ErrInvalidGISData.New(fnName)
+	}
+
+	// Get everything between spaces
+	args := strings.Fields(s)
+
+	// Check length against the declared dimension, and infer the dimension if none was declared
+	switch dim {
+	case "":
+		switch len(args) {
+		case 2:
+		case 3:
+			dim = "z"
+		case 4:
+			dim = "zm"
+		default:
+			return Point{}, ErrInvalidGISData.New(fnName)
+		}
+	case "z", "m":
+		if len(args) != 3 {
+			return Point{}, ErrInvalidGISData.New(fnName)
+		}
+	case "zm":
+		if len(args) != 4 {
+			return Point{}, ErrInvalidGISData.New(fnName)
+		}
+	}
+
+	// Parse every ordinate
+	ords := make([]float64, len(args))
+	for i, arg := range args {
+		f, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return Point{}, ErrInvalidGISData.New(fnName)
+		}
+		ords[i] = f
+	}
+	x, y := ords[0], ords[1]
+
+	// See if we need to swap x and y
+	if order {
+		x, y = y, x
+	}
+
+	// Create point object
+	p := Point{SRID: srid, X: x, Y: y}
+	switch dim {
+	case "z":
+		p.Z = &ords[2]
+	case "m":
+		p.M = &ords[2]
+	case "zm":
+		p.Z, p.M = &ords[2], &ords[3]
+	}
+	return p, nil
+}
+
+// WKTToLine expects a string like "1.2 3.4, 5.6 7.8, ...", of points of the dimension [dim] declared in the WKT header.
+// Every point must have the same dimension. Errors name the function [fnName] that parses the string.
+func WKTToLine(s string, srid uint32, order bool, dim, fnName string) (Linestring, error) {
+	// Empty string is wrong
+	if len(s) == 0 {
+		return Linestring{}, ErrInvalidGISData.New(fnName)
+	}
+
+	// Separate by comma
+	pointStrs := strings.Split(s, ",")
+
+	// Parse each point string
+	var points = make([]Point, len(pointStrs))
+	for i, ps := range pointStrs {
+		// Remove leading and trailing whitespace
+		ps = strings.TrimSpace(ps)
+
+		// Parse point
+		p, err := WKTToPoint(ps, srid, order, dim, fnName)
+		if err != nil || i > 0 && WKTDimension(p) != WKTDimension(points[0]) {
+			return Linestring{}, ErrInvalidGISData.New(fnName)
+		}
+		points[i] = p
+	}
+
+	// Create Linestring object
+	return Linestring{SRID: srid, Points: points}, nil
+}
+
+// WKTToPoly Expects a string like "(1 2, 3 4), (5 6, 7 8), ...", of points of the dimension [dim] declared in the WKT
+// header. Every point must have the same dimension. Errors name the function [fnName] that parses the string.
+func WKTToPoly(s string, srid uint32, order bool, dim, fnName string) (Polygon, error) {
+	rings, ok := splitWKTRings(s)
+	if !ok {
+		return Polygon{}, ErrInvalidGISData.New(fnName)
+	}
+
+	lines := make([]Linestring, 0, len(rings))
+	for _, ring := range rings {
+		// Remove leading and trailing whitespace
+		lineStr := strings.TrimSpace(ring)
+
+		// Empty rings like "()" or "( )" are not allowed
+		if len(lineStr) == 0 {
+			return Polygon{}, ErrInvalidGISData.New(fnName)
+		}
+
+		// Parse line
+		line, err := WKTToLine(lineStr, srid, order, dim, fnName)
+		if err != nil {
+			return Polygon{}, ErrInvalidGISData.New(fnName)
+		}
+
+		// Check if line is linearring, with the same dimension as the other rings
+		if !line.IsLinearRing() || len(lines) > 0 && WKTDimension(line.Points[0]) != WKTDimension(lines[0].Points[0]) {
+			return Polygon{}, ErrInvalidGISData.New(fnName)
+		}
+		lines = append(lines, line)
+	}
+
+	// Create Polygon object
+	return Polygon{SRID: srid, Lines: lines}, nil
+}
+
+// splitWKTRings splits a string like "(1 2, 3 4, 1 2), (5 6, 7 8, 5 6)" into the contents of its rings in a single pass,
+// without the parentheses. Rings must be comma-separated and can't contain any other parentheses; only whitespace may
+// surround them. It returns false if the string isn't a list of at least one ring.
+func splitWKTRings(s string) ([]string, bool) {
+	var rings []string
+	// start is the index after the open parenthesis of the ring being read, or -1 outside of a ring
+	start := -1
+	// expectRing is whether the next token outside of a ring must be an open parenthesis, rather than a comma
+	expectRing := true
+	for i, c := range s {
+		switch {
+		case start >= 0:
+			switch c {
+			case '(':
+				return nil, false
+			case ')':
+				rings = append(rings, s[start:i])
+				start = -1
+				expectRing = false
+			}
+		case unicode.IsSpace(c):
+		case c == '(' && expectRing:
+			start = i + 1
+		case c == ',' && !expectRing:
+			expectRing = true
+		default:
+			return nil, false
+		}
+	}
+
+	// Bad if a ring isn't closed, or if the string is empty or ends with a comma
+	if start >= 0 || expectRing {
+		return nil, false
+	}
+	return rings, true
+}
+
+// WKTToMultiPoint expects a string like "1 2, 3 4, ..." or "(1 2), (3 4), ...", of points of the dimension [dim]
+// declared in the WKT header. Every point must have the same dimension. Errors name the function [fnName] that parses
+// the string.
+func WKTToMultiPoint(s string, srid uint32, order bool, dim, fnName string) (MultiPoint, error) {
+	// Points may each be in parentheses, which are otherwise written like the points of a linestring
+	if strings.ContainsRune(s, '(') {
+		pointStrs, ok := splitWKTRings(s)
+		if !ok {
+			return MultiPoint{}, ErrInvalidGISData.New(fnName)
+		}
+		s = strings.Join(pointStrs, ",")
+	}
+
+	line, err := WKTToLine(s, srid, order, dim, fnName)
+	if err != nil {
+		return MultiPoint{}, ErrInvalidGISData.New(fnName)
+	}
+
+	// Create MultiPoint object
+	return MultiPoint{SRID: srid, Points: line.Points}, nil
+}
+
+// WKTToMultiLine expects a string like "(1 2, 3 4), (5 6, 7 8), ...", of points of the dimension [dim] declared in the
+// WKT header. Every point must have the same dimension. Errors name the function [fnName] that parses the string.
+func WKTToMultiLine(s string, srid uint32, order bool, dim, fnName string) (MultiLinestring, error) {
+	lineStrs, ok := splitWKTRings(s)
+	if !ok {
+		return MultiLinestring{}, ErrInvalidGISData.New(fnName)
+	}
+
+	lines := make([]Linestring, len(lineStrs))
+	for i, ls := range lineStrs {
+		line, err := WKTToLine(strings.TrimSpace(ls), srid, order, dim, fnName)
+		if err != nil || i > 0 && WKTDimension(line.Points[0]) != WKTDimension(lines[0].Points[0]) {
+			return MultiLinestring{}, ErrInvalidGISData.New(fnName)
+		}
+		lines[i] = line
+	}
+
+	// Create MultiLinestring object
+	return MultiLinestring{SRID: srid, Lines: lines}, nil
+}
+
+// WKTToMultiPoly expects a string like "((1 2, 3 4, 5 6, 1 2)), ((7 8, ...), (...)), ...", of points of the dimension
+// [dim] declared in the WKT header. Every point must have the same dimension. Errors name the function [fnName] that
+// parses the string.
+func WKTToMultiPoly(s string, srid uint32, order bool, dim, fnName string) (MultiPolygon, error) {
+	polyStrs, ok := splitWKTPolygons(s)
+	if !ok {
+		return MultiPolygon{}, ErrInvalidGISData.New(fnName)
+	}
+
+	polys := make([]Polygon, len(polyStrs))
+	for i, ps := range polyStrs {
+		poly, err := WKTToPoly(ps, srid, order, dim, fnName)
+		if err != nil || i > 0 && WKTDimension(poly.Lines[0].Points[0]) != WKTDimension(polys[0].Lines[0].Points[0]) {
+			return MultiPolygon{}, ErrInvalidGISData.New(fnName)
+		}
+		polys[i] = poly
+	}
+
+	// Create MultiPolygon object
+	return MultiPolygon{SRID: srid, Polygons: polys}, nil
+}
+
+// splitWKTPolygons splits a string like "((0 0, 1 1, 1 0, 0 0)), ((2 2, 3 3, 3 2, 2 2))" into the contents of its
+// polygons, without their outer parentheses. Polygons must be comma-separated; only whitespace may surround them. It
+// returns false if the string isn't a list of at least one polygon.
+func splitWKTPolygons(s string) ([]string, bool) {
+	var polys []string
+	// depth is the number of open parentheses, and start the index after the one opening the polygon being read
+	depth, start := 0, 0
+	// expectPoly is whether the next token outside of a polygon must be an open parenthesis, rather than a comma
+	expectPoly := true
+	for i, c := range s {
+		switch {
+		case c == '(':
+			if depth == 0 {
+				if !expectPoly {
+					return nil, false
+				}
+				start = i + 1
+			}
+			depth++
+		case c == ')':
+			if depth == 0 {
+				return nil, false
+			}
+			depth--
+			if depth == 0 {
+				polys = append(polys, s[start:i])
+				expectPoly = false
+			}
+		case depth > 0 || unicode.IsSpace(c):
+		case c == ',' && !expectPoly:
+			expectPoly = true
+		default:
+			return nil, false
+		}
+	}
+
+	// Bad if a polygon isn't closed, or if the string is empty or ends with a comma
+	if depth > 0 || expectPoly {
+		return nil, false
+	}
+	return polys, true
+}
+
+// WKTToGeomColl expects a string like "POINT(1 2), LINESTRING(3 4, 5 6), ...", where each geometry has its own type.
+// Nested geometry collections are parsed recursively.
+// Errors name the function [fnName] that parses the string.
+func WKTToGeomColl(s string, srid uint32, order bool, fnName string) (GeometryCollection, error) {
+	// Empty string is an empty collection
+	if len(s) == 0 {
+		return GeometryCollection{SRID: srid}, nil
+	}
+
+	// Separate the geometries by the commas outside their parentheses
+	var geomStrs []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				geomStrs = append(geomStrs, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	geomStrs = append(geomStrs, s[start:])
+
+	// Parse each geometry string according to its header
+	var geoms = make([]interface{}, len(geomStrs))
+	for i, gs := range geomStrs {
+		geomType, dim, data, err := ParseWKTHeader(strings.TrimSpace(gs))
+		if err != nil {
+			return GeometryCollection{}, ErrInvalidGISData.New(fnName)
+		}
+		g, err := WKTToGeometry(geomType, dim, data, srid, order, fnName)
+		if err != nil {
+			return GeometryCollection{}, ErrInvalidGISData.New(fnName)
+		}
+		geoms[i] = g
+	}
+
+	// Create GeometryCollection object
+	return GeometryCollection{SRID: srid, Geoms: geoms}, nil
+}
+
+// WKTToGeometry parses the data of a WKT string with the type and dimension of its header.
+func WKTToGeometry(geomType, dim, data string, srid uint32, order bool, fnName string) (interface{}, error) {
+	// TODO: define consts instead of string comparison?
+	switch geomType {
+	case "point":
+		return WKTToPoint(data, srid, order, dim, fnName)
+	case "linestring":
+		return WKTToLine(data, srid, order, dim, fnName)
+	case "polygon":
+		return WKTToPoly(data, srid, order, dim, fnName)
+	case "multipoint":
+		return WKTToMultiPoint(data, srid, order, dim, fnName)
+	case "multilinestring":
+		return WKTToMultiLine(data, srid, order, dim, fnName)
+	case "multipolygon":
+		return WKTToMultiPoly(data, srid, order, dim, fnName)
+	case "geometrycollection":
+		return WKTToGeomColl(data, srid, order, fnName)
+	default:
+		return nil, ErrInvalidGISData.New(fnName)
+	}
+}
+
+// ParseWKT parses a WKT string of any supported geometry type, and returns the concrete geometry, such as a Point
+// or a Polygon, with the cartesian SRID and the default axis order.
+func ParseWKT(s string) (interface{}, error) {
+	geomType, dim, data, err := ParseWKTHeader(s)
+	if err != nil {
+		return nil, err
+	}
+	return WKTToGeometry(geomType, dim, data, 0, false, "ST_GeomFromText")
+}
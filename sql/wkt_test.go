@@ -0,0 +1,294 @@
+// Copyright 2020-2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWKTHeader(t *testing.T) {
+	t.Run("clean input", func(t *testing.T) {
+		require := require.New(t)
+		geomType, _, data, err := ParseWKTHeader("POLYGON((0 0,1 1,1 0,0 0))")
+		require.NoError(err)
+		require.Equal("polygon", geomType)
+		require.Equal("(0 0,1 1,1 0,0 0)", data)
+	})
+
+	t.Run("trailing whitespace is allowed", func(t *testing.T) {
+		require := require.New(t)
+		geomType, _, data, err := ParseWKTHeader("  point ( 1 2 )  \t\n")
+		require.NoError(err)
+		require.Equal("point", geomType)
+		require.Equal("1 2", data)
+	})
+
+	t.Run("trailing garbage is rejected", func(t *testing.T) {
+		for _, s := range []string{
+			"POINT(1 2) garbage",
+			"POINT(1 2)(3 4)",
+			"POINT(1 2))",
+			"POLYGON((0 0,1 1,1 0,0 0)) x",
+			"LINESTRING(1 2,3 4),",
+		} {
+			_, _, _, err := ParseWKTHeader(s)
+			require.True(t, ErrInvalidGISData.Is(err), s)
+		}
+	})
+
+	t.Run("unbalanced parentheses are rejected", func(t *testing.T) {
+		for _, s := range []string{
+			"POINT(1 2",
+			"POLYGON((0 0,1 1,1 0,0 0)",
+			"POINT",
+		} {
+			_, _, _, err := ParseWKTHeader(s)
+			require.True(t, ErrInvalidGISData.Is(err), s)
+		}
+	})
+}
+
+func TestParseWKT(t *testing.T) {
+	tests := []struct {
+		wkt      string
+		expected interface{}
+	}{
+		{"POINT(1 2)", Point{X: 1, Y: 2}},
+		{"LINESTRING(1 2,3 4)", Linestring{Points: []Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}},
+		{"POLYGON((0 0,1 1,1 0,0 0))", Polygon{Lines: []Linestring{{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 1, Y: 0}, {X: 0, Y: 0}}}}}},
+		{"GEOMETRYCOLLECTION(POINT(1 2),LINESTRING(1 2,3 4))", GeometryCollection{Geoms: []interface{}{
+			Point{X: 1, Y: 2},
+			Linestring{Points: []Point{{X: 1, Y: 2}, {X: 3, Y: 4}}},
+		}}},
+		{"GEOMETRYCOLLECTION()", GeometryCollection{}},
+		{"MULTIPOINT((0 0),(1 1))", MultiPoint{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}}}},
+		{"MULTIPOINT(0 0, 1 1)", MultiPoint{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}}}},
+		{"MULTILINESTRING((0 0,1 1),(2 2,3 3))", MultiLinestring{Lines: []Linestring{
+			{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}}},
+			{Points: []Point{{X: 2, Y: 2}, {X: 3, Y: 3}}},
+		}}},
+		{"MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((2 2,3 2,3 3,2 2),(2 2,2 3,3 3,2 2)))", MultiPolygon{Polygons: []Polygon{
+			{Lines: []Linestring{{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 0}}}}},
+			{Lines: []Linestring{
+				{Points: []Point{{X: 2, Y: 2}, {X: 3, Y: 2}, {X: 3, Y: 3}, {X: 2, Y: 2}}},
+				{Points: []Point{{X: 2, Y: 2}, {X: 2, Y: 3}, {X: 3, Y: 3}, {X: 2, Y: 2}}},
+			}},
+		}}},
+		{"GEOMETRYCOLLECTION(MULTIPOINT(1 2),POINT(3 4))", GeometryCollection{Geoms: []interface{}{
+			MultiPoint{Points: []Point{{X: 1, Y: 2}}},
+			Point{X: 3, Y: 4},
+		}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wkt, func(t *testing.T) {
+			require := require.New(t)
+			v, err := ParseWKT(tt.wkt)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+
+	t.Run("invalid wkt", func(t *testing.T) {
+		for _, s := range []string{
+			"",
+			"POINT(1 2",
+			"CIRCLE(1 2)",
+			"MULTIPOINT()",
+			"MULTIPOINT((0 0),1 1)",
+			"MULTIPOINT((0 0),(1 1 1))",
+			"MULTILINESTRING(0 0,1 1)",
+			"MULTILINESTRING((0 0,1 1),)",
+			"MULTIPOLYGON((0 0,1 0,1 1,0 0))",
+			"MULTIPOLYGON(((0 0,1 0,1 1,0 0))((2 2,3 2,3 3,2 2)))",
+			"MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((2 2,3 2,3 3)))",
+		} {
+			_, err := ParseWKT(s)
+			require.True(t, ErrInvalidGISData.Is(err), s)
+		}
+	})
+}
+
+// referenceWKTToPoly is the polygon parser that scanned for each ring separately, which WKTToPoly must agree with.
+func referenceWKTToPoly(s string, srid uint32, order bool, dim, fnName string) (Polygon, error) {
+	var lines []Linestring
+	s = strings.TrimSpace(s)
+	for {
+		if len(s) == 0 || s[0] != '(' {
+			return Polygon{}, ErrInvalidGISData.New(fnName)
+		}
+
+		end := strings.IndexAny(s[1:], "()")
+		if end == -1 || s[1+end] != ')' {
+			return Polygon{}, ErrInvalidGISData.New(fnName)
+		}
+
+		lineStr := strings.TrimSpace(s[1 : 1+end])
+		if len(lineStr) == 0 {
+			return Polygon{}, ErrInvalidGISData.New(fnName)
+		}
+
+		line, err := WKTToLine(lineStr, srid, order, dim, fnName)
+		if err != nil {
+			return Polygon{}, ErrInvalidGISData.New(fnName)
+		}
+
+		if !line.IsLinearRing() || len(lines) > 0 && WKTDimension(line.Points[0]) != WKTDimension(lines[0].Points[0]) {
+			return Polygon{}, ErrInvalidGISData.New(fnName)
+		}
+		lines = append(lines, line)
+
+		s = strings.TrimSpace(s[end+2:])
+		if len(s) == 0 {
+			break
+		}
+		if s[0] != ',' {
+			return Polygon{}, ErrInvalidGISData.New(fnName)
+		}
+		s = strings.TrimSpace(s[1:])
+	}
+
+	return Polygon{SRID: srid, Lines: lines}, nil
+}
+
+// manyRingsWKT returns the data of a polygon with an exterior ring and [holes] interior rings.
+func manyRingsWKT(holes int) string {
+	var sb strings.Builder
+	sb.WriteString("(0 0,0 1000,1000 1000,1000 0,0 0)")
+	for i := 0; i < holes; i++ {
+		x, y := 1+(i%100)*9, 1+(i/100)*9
+		fmt.Fprintf(&sb, ",(%d %d,%d %d,%d %d,%d %d)", x, y, x+1, y, x+1, y+1, x, y)
+	}
+	return sb.String()
+}
+
+func TestWKTToPolyMatchesReference(t *testing.T) {
+	inputs := []string{
+		"(0 0,1 1,1 0,0 0)",
+		"  ( 0 0 , 1 1 , 1 0 , 0 0 )  ",
+		"(0 0,1 1,1 0,0 0),(0 0,2 2,2 0,0 0)",
+		"(0 0,1 1,1 0,0 0) ,\t\n( 0 0,2 2,2 0,0 0 )",
+		"(0 0 1,1 1 1,1 0 1,0 0 1)",
+		"(0 0 1,1 1 1,1 0 1,0 0 1),(0 0,1 1,1 0,0 0)",
+		manyRingsWKT(300),
+		"",
+		" ",
+		"()",
+		"( )",
+		"(0 0,1 1,1 0,0 0),",
+		"(0 0,1 1,1 0,0 0),,(0 0,1 1,1 0,0 0)",
+		"(0 0,1 1,1 0,0 0)(0 0,1 1,1 0,0 0)",
+		"(0 0,1 1,1 0,0 0) x",
+		",(0 0,1 1,1 0,0 0)",
+		"(0 0,1 1,1 0,0 0",
+		"((0 0,1 1,1 0,0 0))",
+		"(0 0,(1 1),1 0,0 0)",
+		"0 0,1 1,1 0,0 0",
+		"(0 0,1 1,1 0,1 1)",
+		"(0 0,1 1,0 0)",
+	}
+
+	for _, dim := range []string{"", "z"} {
+		for _, s := range inputs {
+			expected, expectedErr := referenceWKTToPoly(s, 0, false, dim, "ST_PolyFromText")
+			actual, err := WKTToPoly(s, 0, false, dim, "ST_PolyFromText")
+			if expectedErr != nil {
+				require.Error(t, err, s)
+				require.Equal(t, expectedErr.Error(), err.Error(), s)
+			} else {
+				require.NoError(t, err, s)
+				require.Equal(t, expected, actual, s)
+			}
+		}
+	}
+}
+
+func BenchmarkWKTToPolyManyRings(b *testing.B) {
+	s := manyRingsWKT(500)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := WKTToPoly(s, 0, false, "", "ST_PolyFromText")
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestSpatialTypesConvertWKTAndWKB(t *testing.T) {
+	point := Point{X: 1, Y: 2}
+	line := Linestring{Points: []Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}
+	pointWKB := []byte{0, 0, 0, 0, 1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f, 0, 0, 0, 0, 0, 0, 0, 0x40}
+
+	tests := []struct {
+		typ      Type
+		val      interface{}
+		expected interface{}
+	}{
+		{PointType{}, "POINT(1 2)", point},
+		{PointType{}, pointWKB, point},
+		{LinestringType{}, "LINESTRING(1 2,3 4)", line},
+		{PolygonType{}, "POLYGON((0 0,1 1,1 0,0 0))", Polygon{Lines: []Linestring{{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 1, Y: 0}, {X: 0, Y: 0}}}}}},
+		{GeometryType{}, "LINESTRING(1 2,3 4)", Geometry{Inner: line}},
+		{GeometryType{}, pointWKB, Geometry{Inner: point}},
+		{PointType{}, append([]byte{0xe6, 0x10, 0, 0}, pointWKB[SRIDLength:]...), Point{SRID: 4326, X: 1, Y: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v %v", tt.typ, tt.val), func(t *testing.T) {
+			v, err := tt.typ.Convert(tt.val)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, v)
+		})
+	}
+
+	t.Run("invalid values", func(t *testing.T) {
+		_, err := PointType{}.Convert("POINT(1 2")
+		require.True(t, ErrInvalidGISData.Is(err))
+		_, err = PointType{}.Convert(pointWKB[:10])
+		require.True(t, ErrInvalidGISData.Is(err))
+		_, err = PointType{}.Convert(pointWKB[SRIDLength:])
+		require.Error(t, err)
+		_, err = PointType{}.Convert("LINESTRING(1 2,3 4)")
+		require.True(t, ErrNotPoint.Is(err))
+		_, err = LinestringType{}.Convert("POINT(1 2)")
+		require.True(t, ErrNotLinestring.Is(err))
+	})
+}
+
+func TestSpatialTypesSQLRoundTrip(t *testing.T) {
+	values := []struct {
+		typ Type
+		val interface{}
+	}{
+		{PointType{}, Point{SRID: 4326, X: 1, Y: 2}},
+		{LinestringType{}, Linestring{Points: []Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}},
+		{PolygonType{}, Polygon{Lines: []Linestring{{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 1, Y: 0}, {X: 0, Y: 0}}}}}},
+		{GeometryType{}, Geometry{Inner: Point{X: 1, Y: 2}}},
+	}
+
+	for _, tt := range values {
+		t.Run(fmt.Sprintf("%v %v", tt.typ, tt.val), func(t *testing.T) {
+			sqlVal, err := tt.typ.SQL(nil, tt.val)
+			require.NoError(t, err)
+			v, err := tt.typ.Convert(sqlVal.Raw())
+			require.NoError(t, err)
+			require.Equal(t, tt.val, v)
+		})
+	}
+}